@@ -111,8 +111,9 @@ func main() {
 							decoded := dec.FromChunk(&sources.Chunk{Data: chunk.Data})
 							if decoded != nil {
 								foundKeyword := false
-								for _, kw := range scanner.Keywords() {
-									if strings.Contains(strings.ToLower(string(decoded.Data)), strings.ToLower(kw)) {
+								dataFolded := detectors.FoldCase(string(decoded.Data))
+								for _, kw := range detectors.Keywords(scanner) {
+									if strings.Contains(dataFolded, kw) {
 										foundKeyword = true
 									}
 								}
@@ -162,7 +163,7 @@ func main() {
 				defer sem.Release(1)
 				defer wgChunkers.Done()
 				log.Infof("cloning %s", r)
-				path, repo, err := git.CloneRepoUsingUnauthenticated(r)
+				path, repo, err := git.CloneRepoUsingUnauthenticated(ctx, r)
 				if err != nil {
 					log.Fatal(err)
 				}
@@ -170,15 +171,19 @@ func main() {
 				log.Infof("cloned %s", r)
 
 				s := git.NewGit(sourcespb.SourceType_SOURCE_TYPE_GIT, 0, 0, "snifftest", false, runtime.NumCPU(),
-					func(file, email, commit, timestamp, repository string, line int64) *source_metadatapb.MetaData {
+					func(file, email, commit, timestamp, repository string, line int64, authorName, committerName, committerEmail, commitTimestamp string) *source_metadatapb.MetaData {
 						return &source_metadatapb.MetaData{
 							Data: &source_metadatapb.MetaData_Git{
 								Git: &source_metadatapb.Git{
-									Commit:     commit,
-									File:       file,
-									Email:      email,
-									Repository: repository,
-									Timestamp:  timestamp,
+									Commit:          commit,
+									File:            file,
+									Email:           email,
+									Repository:      repository,
+									Timestamp:       timestamp,
+									AuthorName:      authorName,
+									CommitterName:   committerName,
+									CommitterEmail:  committerEmail,
+									CommitTimestamp: commitTimestamp,
 								},
 							},
 						}
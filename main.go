@@ -2,47 +2,150 @@ package main
 
 import (
 	"context"
+	"crypto"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"io"
 	"net/http"
 	_ "net/http/pprof"
+	"net/url"
 	"os"
+	"os/signal"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
+	"text/tabwriter"
+	"text/template"
 	"time"
 
 	"github.com/felixge/fgprof"
 	"github.com/gorilla/mux"
 	"github.com/jpillora/overseer"
 	"github.com/sirupsen/logrus"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/telemetry"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/updater"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/version"
 	"gopkg.in/alecthomas/kingpin.v2"
+	"gopkg.in/yaml.v3"
 
+	"github.com/trufflesecurity/trufflehog/v3/pkg/allowlist"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/config"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/credentials"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/decoders"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/defectdojo"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/aws"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/azure"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/combolist"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/connectionstring"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/consul"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/custom"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/gcp"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/scaffold"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/vault"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/diff"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/health"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/heatmap"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/operator"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/output"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/policy"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/secretstore"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/server"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sourcecheck"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/awsaccount"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/azuresubscription"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/gcpproject"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/git"
+	githubsource "github.com/trufflesecurity/trufflehog/v3/pkg/sources/github"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/sshremote"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sprawl"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/store"
+	// Register the kafka, nats, eventbridge, pubsub, servicenow, securityhub, chronicle, and sentinel sinks.
+	_ "github.com/trufflesecurity/trufflehog/v3/pkg/chronicle"
+	_ "github.com/trufflesecurity/trufflehog/v3/pkg/securityhub"
+	_ "github.com/trufflesecurity/trufflehog/v3/pkg/sentinel"
+	_ "github.com/trufflesecurity/trufflehog/v3/pkg/servicenow"
+	_ "github.com/trufflesecurity/trufflehog/v3/pkg/stream"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/tui"
 )
 
 var (
-	cli            = kingpin.New("TruffleHog", "TruffleHog is a tool for finding credentials.")
+	cli            = kingpin.New("TruffleHog", "TruffleHog is a tool for finding credentials.").DefaultEnvars()
+	configFile     = cli.Flag("config", "Path to a YAML config file. Its values are used as flag defaults, which TRUFFLEHOG_* environment variables and flags override.").String()
 	cmd            string
 	debug          = cli.Flag("debug", "Run in debug mode.").Bool()
 	trace          = cli.Flag("trace", "Run in trace mode.").Bool()
+	logLevel       = cli.Flag("log-level", "Log level: trace, debug, info, warn, or error. Overrides --debug and --trace.").String()
+	logFormat      = cli.Flag("log-format", "Log format: text or json. Defaults to json when --json is set.").Enum("", "text", "json")
+	logModules     = cli.Flag("log-module", `Log level for a module and its submodules. You can repeat this flag. Example: "sources.github=debug"`).Strings()
 	jsonOut        = cli.Flag("json", "Output in JSON format.").Short('j').Bool()
+	jsonManifest   = cli.Flag("manifest", "With --json, write a scan manifest line before the results and a scan end line after them, recording the scan ID, version, options, and detectors.").Bool()
+	outputPath     = cli.Flag("output-file", "Write --json output to this file. It's written as <file>.partial and renamed once the scan completes, so a crashed scan never leaves a truncated file in its place.").String()
+	outputDir      = cli.Flag("output-dir", "Write --json or --protobuf results to a file per repository, bucket, or other unit in this directory, such as <dir>/github.com/org/repo.json, with an index of the files in <dir>/index.json. Files are renamed into place once the scan completes.").String()
+	signKey        = cli.Flag("sign-key", "Sign --json or --protobuf output with this unencrypted PEM ECDSA or RSA private key. Each output file gets a detached signature in <file>.sig, which cosign verify-blob and trufflehog verify-signature can check.").String()
+	signatureFile  = cli.Flag("signature-file", "With --sign-key and no --output-file, write the signature of the output on stdout to this file.").String()
+	outputMaxSize  = cli.Flag("output-file-max-size", "Split the output file into <file>.1, <file>.2, and so on, each renamed into place once it reaches this size. Example: 100MB").Bytes()
+	protobufOut    = cli.Flag("protobuf", "Output results as a stream of length-delimited protobuf messages, defined in proto/results.proto, for consumers that don't want to parse JSON.").Bool()
+	sarifOut       = cli.Flag("sarif", "Output results as a SARIF 2.1.0 log once the scan finishes, for GitHub code scanning and other SARIF consumers. Secrets are redacted.").Bool()
+	junitOut       = cli.Flag("junit", "Output results as a JUnit XML report once the scan finishes, for CI systems that show test failures, such as Jenkins, GitLab, and Azure DevOps. Each detector and file with secrets is a failed test case. Secrets are redacted.").Bool()
+	outputTmpl     = cli.Flag("output-template", "Output each result by rendering it through the Go text/template in this file, for tools that expect a format of their own. The template's dot is the result, and it can call json, raw, hash, file, and metadata. See the README for examples.").String()
+	htmlReport     = cli.Flag("html-report", "Also write the results to this file as a self-contained HTML report once the scan finishes, which can be filtered by detector, repository, and verification. Secrets are redacted. Add --context-lines to include the lines around each secret, with the secret masked.").String()
 	jsonLegacy     = cli.Flag("json-legacy", "Use the pre-v3.0 JSON format. Only works with git, gitlab, and github sources.").Bool()
 	concurrency    = cli.Flag("concurrency", "Number of concurrent workers.").Default(strconv.Itoa(runtime.NumCPU())).Int()
 	noVerification = cli.Flag("no-verification", "Don't verify the results.").Bool()
+	verifyConns    = cli.Flag("verify-connections", "Verify database and message broker connection strings by connecting to their hosts, which may be on internal networks.").Bool()
+	comboCount     = cli.Flag("combolist-count-only", "Report leaked email and password pairs, such as combolists, only as a count per chunk, so no password is output.").Bool()
+	comboThreshold = cli.Flag("combolist-bulk-threshold", "Report chunks with more than this many email and password pairs as one result that counts them.").Default(strconv.Itoa(combolist.DefaultBulkThreshold)).Int()
+	vaultAddr      = cli.Flag("vault-addr", "Address of the HashiCorp Vault server to verify Vault tokens against. Example: https://vault.internal:8200").String()
+	consulAddr     = cli.Flag("consul-addr", "Address of the HashiCorp Consul server to verify Consul tokens against. Example: https://consul.internal:8501").String()
+	awsVerifyRgn   = cli.Flag("aws-verify-region", "AWS region to verify AWS credentials in. Defaults to $AWS_REGION, then us-west-2.").String()
+	awsSTSEndpoint = cli.Flag("aws-sts-endpoint", "STS endpoint to verify AWS credentials with, such as an interface VPC endpoint. Defaults to $AWS_ENDPOINT_URL_STS, then the region's public endpoint.").String()
+	gcpTokenURL    = cli.Flag("gcp-token-url", "OAuth token endpoint to verify GCP service account keys with instead of the one in each key, such as a Private Service Connect endpoint. Example: https://oauth2-internal.p.googleapis.com/token").String()
+	azureAuthority = cli.Flag("azure-authority-host", "Entra ID authority host to verify Azure client secrets with. Defaults to $AZURE_AUTHORITY_HOST, then https://login.microsoftonline.com/.").String()
+	azureARM       = cli.Flag("azure-resource-manager", "Azure Resource Manager endpoint to list the subscriptions of verified Azure client secrets with. Defaults to https://management.azure.com/.").String()
+	secretStores   = cli.Flag("secret-store", "Tag results whose secret is held in this secret manager as managed secrets, or that are assigned to a variable named after one, and make them critical. Secrets are compared by hash. You can repeat this flag.").Enums("vault", "aws-secrets-manager")
+	vaultMounts    = cli.Flag("secret-store-vault-mount", "Path of a Vault KV secrets engine to inventory with --secret-store=vault. You can repeat this flag.").Default("secret").Strings()
+	awsRegions     = cli.Flag("secret-store-aws-region", "AWS region to inventory with --secret-store=aws-secrets-manager. You can repeat this flag. Defaults to the SDK's region.").Strings()
 	onlyVerified   = cli.Flag("only-verified", "Only output verified results.").Bool()
-	// rules = cli.Flag("rules", "Path to file with custom rules.").String()
+	minSeverity    = cli.Flag("min-severity", "Only output results of at least this severity: low, medium, high, or critical.").Enum("low", "medium", "high", "critical")
+	allowlistFile  = cli.Flag("allowlist", "Path to a file of SHA-256 hashes of accepted secrets, one per line, to suppress. Generate one with: printf %s \"$SECRET\" | sha256sum").String()
+	allowlistTag   = cli.Flag("allowlist-tag", "Output allowlisted results tagged as allowlisted instead of suppressing them.").Bool()
+	allowPatterns  = cli.Flag("allowlist-pattern", `Regular expression matching whole unverified secrets to suppress, such as placeholders. You can repeat this flag. Example: "EXAMPLE.*"`).Strings()
+	detectorFilter = cli.Flag("detector", `Only output results from this detector type. You can repeat this flag. Example: "aws"`).Strings()
+	rulesFiles     = cli.Flag("rules", "Path to a gitleaks .toml configuration or semgrep .yaml rules file whose regex rules to run as custom detectors. You can repeat this flag.").Strings()
+	noFPFilter     = cli.Flag("no-fp-filter", "Don't filter out likely false positives, such as example keys and dictionary words. Useful for forensic scans.").Bool()
+	sinkSpecs      = cli.Flag("sink", `Also send results to a registered sink, given as name:key=value,key=value. You can repeat this flag. Add actions=notify,ticket to only send results a policy rule tagged with those actions. Example: "json:file=results.json"`).Strings()
+	suppressedPath = cli.Flag("suppressed-output", "Write the results suppressed by allowlists, false positive filters, and triage in the results database to this file as JSON lines, with the reason each was suppressed, so suppressions can be audited.").String()
+
+	contextLines         = cli.Flag("context-lines", "Include this many lines before and after each secret in its result.").Int()
+	extractStrings       = cli.Flag("extract-strings", "In source code files, such as .go and .py files, suppress unverified secrets found outside string literals and comments, such as in identifiers and hashes.").Bool()
 	printAvgDetectorTime = cli.Flag("print-avg-detector-time", "Print the average time spent on each detector.").Bool()
+	deterministic        = cli.Flag("deterministic", "Output results sorted by source, file, commit, and detector once the scan finishes, instead of as they're found, so repeated scans can be diffed.").Bool()
+	groupResults         = cli.Flag("group", "Group all occurrences of the same secret into one result with a list of locations. Results are output when the scan finishes.").Bool()
+	printSummary         = cli.Flag("summary", "Print a summary of the scan with per-detector statistics and skipped files.").Bool()
 	noUpdate             = cli.Flag("no-update", "Don't check for updates.").Bool()
 	fail                 = cli.Flag("fail", "Exit with code 183 if results are found.").Bool()
+	scanTimeout          = cli.Flag("scan-timeout", "Stop scanning after this duration, outputting the results found so far and exiting with code 1. Example: 30m").Duration()
+	telemetryEndpoint    = cli.Flag("telemetry-endpoint", "Opt in to sending anonymized statistics of each scan, detector hit counts and performance only, to this URL as JSON. Secrets, paths, and repository names are never sent.").String()
+	telemetryToken       = cli.Flag("telemetry-token", "Bearer token for --telemetry-endpoint.").String()
+	egressAuditLog       = cli.Flag("egress-audit-log", "Append a JSON line to this file for every verification request or connection, recording the detector, host, status, and latency. Secrets are never logged.").String()
+	resultsDB            = cli.Flag("results-db", "Record scans and their findings in this database: the path of a SQLite file, or a postgres:// URL. Secrets are stored as fingerprints, never in the clear.").String()
+	purgeResolvedAfter   = cli.Flag("purge-resolved-after", "With --results-db, delete findings resolved more than this many days ago, with the record of where they were found, at the end of each scan.").Int()
+	purgeExport          = cli.Flag("purge-export", "Append findings to this file as JSON lines before --purge-resolved-after deletes them. Findings that can't be written aren't deleted.").String()
+	apiCacheDir          = cli.Flag("api-cache-dir", "Cache source API responses, such as GitHub and GitLab repository listings, in this directory. Later scans make conditional requests, and unchanged responses are reused without counting against rate limits.").String()
+	maxRepoSize          = cli.Flag("max-repo-size", "Skip enumerated repositories larger than this, as reported by the GitHub API before cloning, and list them in the summary. Example: 10GB").Bytes()
+	repoTimeout          = cli.Flag("repo-timeout", "Stop cloning and scanning an enumerated repository after this long, and list it in the summary. Example: 30m").Duration()
+	maxRepoObjects       = cli.Flag("max-repo-objects", "Skip cloned repositories with more git objects than this, and list them in the summary.").Int64()
+	credentialHelper     = cli.Flag("credential-helper", `Command to get source credentials from when no --token is given. It's run with "get" appended and speaks git's credential helper protocol. Example: "git credential-osxkeychain"`).String()
 
 	gitScan             = cli.Command("git", "Find credentials in git repositories.")
 	gitScanURI          = gitScan.Arg("uri", "Git repository URL. https:// or file:// schema expected.").Required().String()
@@ -51,6 +154,7 @@ var (
 	gitScanSinceCommit  = gitScan.Flag("since-commit", "Commit to start scan from.").String()
 	gitScanBranch       = gitScan.Flag("branch", "Branch to scan.").String()
 	gitScanMaxDepth     = gitScan.Flag("max-depth", "Maximum depth of commits to scan.").Int()
+	gitScanSecretAge    = gitScan.Flag("secret-age", "Estimate how long each secret has been in history, from when it was committed to when it was removed, or to now. Slower for repositories with long histories.").Bool()
 	_                   = gitScan.Flag("allow", "No-op flag for backwards compat.").Bool()
 	_                   = gitScan.Flag("entropy", "No-op flag for backwards compat.").Bool()
 	_                   = gitScan.Flag("regex", "No-op flag for backwards compat.").Bool()
@@ -59,15 +163,34 @@ var (
 	githubScanEndpoint   = githubScan.Flag("endpoint", "GitHub endpoint.").Default("https://api.github.com").String()
 	githubScanRepos      = githubScan.Flag("repo", `GitHub repository to scan. You can repeat this flag. Example: "https://github.com/dustin-decker/secretsandstuff"`).Strings()
 	githubScanOrgs       = githubScan.Flag("org", `GitHub organization to scan. You can repeat this flag. Example: "trufflesecurity"`).Strings()
-	githubScanToken      = githubScan.Flag("token", "GitHub token.").String()
+	githubScanToken      = githubScan.Flag("token", "GitHub token. Defaults to $GITHUB_TOKEN, $GH_TOKEN, the credential helper, ~/.netrc, or the OS keychain.").String()
 	githubIncludeForks   = githubScan.Flag("include-forks", "Include forks in scan.").Bool()
 	githubIncludeMembers = githubScan.Flag("include-members", "Include organization member repositories in scan.").Bool()
+	githubIncludeRepos   = githubScan.Flag("include-repos", `Only scan enumerated repositories matching this name or glob. You can repeat this flag, or give "@file" to read them from a file. Example: "trufflesecurity/*"`).Strings()
+	githubExcludeRepos   = githubScan.Flag("exclude-repos", `Skip enumerated repositories matching this name or glob. You can repeat this flag, or give "@file" to read them from a file. Example: "*-archive"`).Strings()
+	githubSecretNames    = githubScan.Flag("correlate-secret-names", "List the names of the organizations' Actions and Dependabot secrets, and make results critical when they're assigned to a variable of the same name. Secret values are never read.").Bool()
+	githubSnapshot       = githubScan.Flag("snapshot", "Save the repositories listed from organizations to this file, and reuse them in later scans. Interrupted listings resume where they stopped.").String()
+	githubSnapshotMaxAge = githubScan.Flag("snapshot-max-age", "How old an organization's repositories in the snapshot can be before they're refreshed with the repositories updated since.").Default("24h").Duration()
+	githubReenumerate    = githubScan.Flag("snapshot-rebuild", "List the organizations' repositories from scratch, replacing them in the snapshot and dropping deleted repositories.").Bool()
+	githubEnumerateOnly  = githubScan.Flag("enumerate-only", "Update the snapshot without scanning the repositories. Requires --snapshot.").Bool()
+
+	githubAuditScan        = cli.Command("github-audit-log", "Tail the audit logs of GitHub organizations and enterprises, scanning events such as webhook configuration changes and secret scanning alerts as they're recorded.")
+	githubAuditEndpoint    = githubAuditScan.Flag("endpoint", "GitHub endpoint.").Default("https://api.github.com").String()
+	githubAuditOrgs        = githubAuditScan.Flag("org", `Organization whose audit log to follow. You can repeat this flag. Example: "trufflesecurity"`).Strings()
+	githubAuditEnterprises = githubAuditScan.Flag("enterprise", "Enterprise whose audit log to follow. You can repeat this flag.").Strings()
+	githubAuditToken       = githubAuditScan.Flag("token", "GitHub token with the read:audit_log scope. Defaults to $GITHUB_TOKEN, $GH_TOKEN, the credential helper, ~/.netrc, or the OS keychain.").String()
+	githubAuditPhrase      = githubAuditScan.Flag("phrase", `Audit log search phrase limiting the events scanned. Example: "action:hook"`).String()
+	githubAuditSince       = githubAuditScan.Flag("since", "How far back to start scanning events.").Default("1h").Duration()
+	githubAuditInterval    = githubAuditScan.Flag("interval", "Time between polls of the audit logs.").Default("1m").Duration()
+	githubAuditHealth      = githubAuditScan.Flag("health-address", "Address to serve /healthz and /readyz on. Example: :8080").String()
 
 	gitlabScan = cli.Command("gitlab", "Find credentials in GitLab repositories.")
 	// TODO: Add more GitLab options
 	gitlabScanEndpoint = gitlabScan.Flag("endpoint", "GitLab endpoint.").Default("https://gitlab.com").String()
 	gitlabScanRepos    = gitlabScan.Flag("repo", "GitLab repo url. You can repeat this flag. Leave empty to scan all repos accessible with provided credential. Example: https://gitlab.com/org/repo.git").Strings()
-	gitlabScanToken    = gitlabScan.Flag("token", "GitLab token.").Required().String()
+	gitlabScanToken    = gitlabScan.Flag("token", "GitLab token. Defaults to $GITLAB_TOKEN, the credential helper, ~/.netrc, or the OS keychain.").String()
+	gitlabIncludeRepos = gitlabScan.Flag("include-repos", `Only scan enumerated projects matching this path or glob. You can repeat this flag, or give "@file" to read them from a file. Example: "group/*"`).Strings()
+	gitlabExcludeRepos = gitlabScan.Flag("exclude-repos", `Skip enumerated projects matching this path or glob. You can repeat this flag, or give "@file" to read them from a file.`).Strings()
 
 	filesystemScan        = cli.Command("filesystem", "Find credentials in a filesystem.")
 	filesystemDirectories = filesystemScan.Flag("directory", "Path to directory to scan. You can repeat this flag.").Required().Strings()
@@ -77,17 +200,162 @@ var (
 	// filesystemScanExcludePaths = filesystemScan.Flag("exclude-paths", "Path to file with newline separated regexes for files to exclude in scan.").Short('x').String()
 
 	s3Scan         = cli.Command("s3", "Find credentials in S3 buckets.")
-	s3ScanKey      = s3Scan.Flag("key", "S3 key used to authenticate.").String()
-	s3ScanSecret   = s3Scan.Flag("secret", "S3 secret used to authenticate.").String()
+	s3ScanKey      = s3Scan.Flag("key", "S3 key used to authenticate. Defaults to $AWS_ACCESS_KEY_ID, the credential helper, ~/.netrc, or the OS keychain.").String()
+	s3ScanSecret   = s3Scan.Flag("secret", "S3 secret used to authenticate. Defaults to $AWS_SECRET_ACCESS_KEY, the credential helper, ~/.netrc, or the OS keychain.").String()
 	s3ScanCloudEnv = s3Scan.Flag("cloud-environment", "Use IAM credentials in cloud environment.").Bool()
 	s3ScanBuckets  = s3Scan.Flag("bucket", "Name of S3 bucket to scan. You can repeat this flag.").Strings()
 
+	awsScan        = cli.Command("aws", "Sweep an AWS account for credentials in Lambda environment variables, EC2 user data, ECS task definitions, CloudFormation templates, and SSM documents.")
+	awsScanKey     = awsScan.Flag("key", "AWS access key ID used to authenticate. Defaults to $AWS_ACCESS_KEY_ID, the credential helper, ~/.netrc, the OS keychain, or the default AWS credential chain.").String()
+	awsScanSecret  = awsScan.Flag("secret", "AWS secret access key used to authenticate. Defaults to $AWS_SECRET_ACCESS_KEY, the credential helper, ~/.netrc, the OS keychain, or the default AWS credential chain.").String()
+	awsScanRegions = awsScan.Flag("region", "Region to sweep. You can repeat this flag. Defaults to all the regions enabled for the account.").Strings()
+
+	gcpScan             = cli.Command("gcp", "Sweep GCP projects for credentials in Cloud Functions and Cloud Run environment variables, Compute Engine metadata and startup scripts, and Deployment Manager configurations.")
+	gcpScanProjects     = gcpScan.Flag("project", "ID of a project to sweep. You can repeat this flag.").Strings()
+	gcpScanOrganization = gcpScan.Flag("organization", "Numeric ID of an organization to sweep every active project of, including those in folders.").String()
+	gcpScanCredentials  = gcpScan.Flag("credentials", "Path to a service account key. Defaults to the application default credentials.").ExistingFile()
+	gcpScanServices     = gcpScan.Flag("service", "Service to sweep: functions, run, compute, or deploymentmanager. You can repeat this flag. Defaults to all of them.").Enums(gcpproject.Services...)
+
+	azureScan              = cli.Command("azure", "Sweep Azure subscriptions for credentials in App Service and Function app settings, VM custom script extensions, and ARM deployments. Authenticates with $AZURE_TENANT_ID, $AZURE_CLIENT_ID, and $AZURE_CLIENT_SECRET, or a managed identity.")
+	azureScanSubscriptions = azureScan.Flag("subscription", "ID of a subscription to sweep. You can repeat this flag. Defaults to every enabled subscription the credentials can read.").Strings()
+	azureScanServices      = azureScan.Flag("service", "Service to sweep: appservice, functions, vm, or deployments. You can repeat this flag. Defaults to all of them.").Enums(azuresubscription.Services...)
+
+	sshScan           = cli.Command("ssh", "Scan files on hosts over SSH, for auditing VMs and jump hosts for credentials on disk. Files are streamed with tar over the connection, so nothing is installed on the hosts.")
+	sshScanHosts      = sshScan.Flag("host", "Host to scan, as host, user@host, or user@host:port. You can repeat this flag.").Required().Strings()
+	sshScanPaths      = sshScan.Flag("path", "File or directory to scan on each host. Relative paths are relative to the home directory. You can repeat this flag. Example: /etc").Required().Strings()
+	sshScanUser       = sshScan.Flag("user", "User to log in as on hosts that don't name one. Defaults to the local user.").String()
+	sshScanIdentities = sshScan.Flag("identity-file", "Unencrypted private key to authenticate with, in addition to the keys of ssh-agent. You can repeat this flag. Defaults to the keys in ~/.ssh.").ExistingFiles()
+	sshScanKnownHosts = sshScan.Flag("known-hosts", "File of known host keys to check hosts against. You can repeat this flag. Defaults to ~/.ssh/known_hosts.").ExistingFiles()
+	sshScanInsecure   = sshScan.Flag("insecure-ignore-host-key", "Connect to hosts without checking their keys.").Bool()
+	sshScanJump       = sshScan.Flag("jump", "Host to connect to the hosts through, like ssh -J, as host, user@host, or user@host:port.").String()
+	sshScanSudo       = sshScan.Flag("sudo", "Read the paths as root with sudo -n, which needs passwordless sudo on the hosts.").Bool()
+
 	syslogScan     = cli.Command("syslog", "Scan syslog")
 	syslogAddress  = syslogScan.Flag("address", "Address and port to listen on for syslog. Example: 127.0.0.1:514").String()
 	syslogProtocol = syslogScan.Flag("protocol", "Protocol to listen on. udp or tcp").String()
 	syslogTLSCert  = syslogScan.Flag("cert", "Path to TLS cert.").String()
 	syslogTLSKey   = syslogScan.Flag("key", "Path to TLS key.").String()
 	syslogFormat   = syslogScan.Flag("format", "Log format. Can be rfc3164 or rfc5424").String()
+	syslogHealth   = syslogScan.Flag("health-address", "Address to serve /healthz and /readyz on. Example: :8080").String()
+
+	multiScan = cli.Command("scan", "Scan all the sources in the config file's sources list in one run. Results are attributed to the source's name.")
+
+	testSourceCmd   = cli.Command("test-source", "Check that the sources in the config file can be scanned: that their credentials work and have the needed scopes, and that their organizations, repositories, buckets, and directories exist and can be read.")
+	testSourceNames = testSourceCmd.Arg("name", "Only test the sources with these names.").Strings()
+
+	tuiCmd      = cli.Command("tui", "Interactively browse findings from a previous scan run with --json.")
+	tuiResults  = tuiCmd.Arg("results", "Path to a file of results written with --json.").Required().ExistingFile()
+	tuiBaseline = tuiCmd.Flag("baseline", "File that findings marked as false positives are written to. Findings already in it are hidden. It can be used with --allowlist.").Default(".trufflehog-baseline").String()
+	tuiRepo     = tuiCmd.Flag("repo", "Path to a local clone of the scanned repository, used to show diffs.").ExistingDir()
+
+	operatorCmd                = cli.Command("operator", "Run as a Kubernetes operator that schedules scans from SecretScan resources, running each one in a Job.")
+	operatorNamespace          = operatorCmd.Flag("namespace", "Namespace to watch for SecretScan resources. Watches all namespaces if empty.").String()
+	operatorResyncInterval     = operatorCmd.Flag("resync-interval", "How often to check SecretScan resources for scans that are due, and scan Jobs that finished.").Default("1m").Duration()
+	operatorMaxConcurrentScans = operatorCmd.Flag("max-concurrent-scans", "Maximum number of scan Jobs to run at the same time.").Default("1").Int()
+	operatorJobImage           = operatorCmd.Flag("job-image", "Image of the scan Jobs.").Default("trufflesecurity/trufflehog:latest").String()
+	operatorJobServiceAccount  = operatorCmd.Flag("job-service-account", "Service account of the scan Jobs, which must exist in the namespace of each SecretScan and be allowed to write ConfigMaps.").Default("trufflehog-scanner").String()
+	operatorHealth             = operatorCmd.Flag("health-address", "Address to serve /healthz and /readyz on.").Default(":8080").String()
+
+	operatorScanCmd = cli.Command("operator-scan", "Run the scan of a Job started by the operator.").Hidden()
+
+	serverCmd                = cli.Command("server", "Serve an HTTP API for teams to register sources, start scans, and read results. Each API token acts for a tenant, which only sees its own sources and scans, and has roles that allow submitting scans, reading results, or both.")
+	serverAddress            = serverCmd.Flag("address", "Address to serve the API, /healthz, and /readyz on.").Default(":8080").String()
+	serverTokens             = serverCmd.Flag("tokens", "Path to a YAML file of the API tokens' SHA-256 hashes, tenants, and roles.").Required().String()
+	serverMaxConcurrentScans = serverCmd.Flag("max-concurrent-scans", "Maximum number of scans to run at the same time, across tenants. Scans started beyond it are rejected with 429.").Default("2").Int()
+	serverRetention          = serverCmd.Flag("retention", "Purge finished scans and their results after this long. By default, the 100 most recent scans of each tenant are kept. Example: 720h").Duration()
+	serverRedactRaw          = serverCmd.Flag("redact-raw", "Deprecated: raw secrets are always replaced by their fingerprints once their scan is done.").Hidden().Bool()
+	serverAllowedHosts       = serverCmd.Flag("allowed-hosts", `Host that sources can be scanned from. You can repeat this flag. "*.example.com" allows every subdomain of example.com.`).Default(server.DefaultAllowedHosts...).Strings()
+	serverAllowPrivate       = serverCmd.Flag("allow-private-networks", "Allow scanning sources on hosts that resolve to private addresses, such as a self-hosted GitHub Enterprise or GitLab. Loopback and link-local addresses are always rejected.").Bool()
+	serverRetentionExport    = serverCmd.Flag("retention-export", "Append scans and their results to this file as JSON lines before they're purged. Scans that can't be written are kept until they can be.").String()
+
+	verifySignatureCmd  = cli.Command("verify-signature", "Check that results written with --sign-key haven't changed since they were signed. Exits with code 1 if they have.")
+	verifySignatureKey  = verifySignatureCmd.Flag("key", "PEM public key or certificate of the signing key.").Required().ExistingFile()
+	verifySignatureSig  = verifySignatureCmd.Flag("signature", "Signature file. Defaults to <file>.sig.").String()
+	verifySignatureFile = verifySignatureCmd.Arg("file", "Results file to check.").Required().ExistingFile()
+
+	diffCmd = cli.Command("diff", "Compare two scans run with --json and report new, resolved, and persisting findings. With --fail, exits with code 183 if there are new findings.")
+	diffOld = diffCmd.Arg("old", "Results of the earlier scan.").Required().ExistingFile()
+	diffNew = diffCmd.Arg("new", "Results of the later scan.").Required().ExistingFile()
+
+	sprawlCmd     = cli.Command("sprawl", "Report how many of the secrets found by a scan run with --json are held in a secret manager, assigned to a variable named after one, or not managed at all. Results of scans run without --secret-store are looked up in the --secret-store secret managers.")
+	sprawlResults = sprawlCmd.Arg("results", "Path to a file of results written with --json.").Required().ExistingFile()
+
+	defectdojoCmd         = cli.Command("defectdojo", "Push findings from a scan run with --json to DefectDojo as CWE-798 findings, creating the product and engagement if they don't exist.")
+	defectdojoResults     = defectdojoCmd.Arg("results", "Path to a file of results written with --json.").Required().ExistingFile()
+	defectdojoURL         = defectdojoCmd.Flag("url", "Address of the DefectDojo instance. Example: https://defectdojo.example.com").String()
+	defectdojoToken       = defectdojoCmd.Flag("token", "DefectDojo API v2 key. Defaults to $DEFECTDOJO_API_KEY, the credential helper, ~/.netrc, or the OS keychain.").String()
+	defectdojoProduct     = defectdojoCmd.Flag("product", "Product to import into.").Required().String()
+	defectdojoProductType = defectdojoCmd.Flag("product-type", "Product type to create the product in, if it doesn't exist.").String()
+	defectdojoEngagement  = defectdojoCmd.Flag("engagement", "Engagement to import into.").Default("TruffleHog").String()
+	defectdojoCloseOld    = defectdojoCmd.Flag("close-old", "Close findings from earlier imports into the engagement that aren't in this one.").Bool()
+	defectdojoTags        = defectdojoCmd.Flag("tag", "Tag to add to the imported findings. You can repeat this flag.").Strings()
+	defectdojoDryRun      = defectdojoCmd.Flag("dry-run", "Print the findings in the Generic Findings Import format instead of uploading them. Other tools, such as ThreadFix, can import the format too.").Bool()
+
+	scansCmd       = cli.Command("scans", "Query the scans recorded with --results-db.")
+	scansList      = scansCmd.Command("list", "List recent scans.")
+	scansListLimit = scansList.Flag("limit", "Number of scans to list.").Default("20").Int()
+	scansShow      = scansCmd.Command("show", "List the findings of a scan.")
+	scansShowID    = scansShow.Arg("id", "ID of the scan.").Required().String()
+
+	reportCmd           = cli.Command("report", "Report on the findings recorded with --results-db.")
+	reportHeatmap       = reportCmd.Command("heatmap", "Aggregate findings into a heatmap of the findings of each repository, team, and detector over time, with the file types that leak the most secrets and the trend of the share of findings that's verified. Teams are the code owners of the files secrets were found in. Writes JSON to stdout unless --json-file or --html-file is set.")
+	reportHeatmapPeriod = reportHeatmap.Flag("period", "Length of the heatmap's periods.").Default("week").Enum("day", "week", "month")
+	reportHeatmapDays   = reportHeatmap.Flag("days", "Report the findings seen in this many days up to now.").Default("90").Int()
+	reportHeatmapTop    = reportHeatmap.Flag("top", "Number of repositories, teams, detectors, and file types to report, with the most findings. 0 reports all of them.").Default("20").Int()
+	reportHeatmapJSON   = reportHeatmap.Flag("json-file", "Write the report as JSON to this file.").String()
+	reportHeatmapHTML   = reportHeatmap.Flag("html-file", "Write the report as a self-contained HTML page to this file.").String()
+
+	findingsCmd           = cli.Command("findings", "Triage the findings recorded with --results-db. Later scans don't report findings marked as false positives or resolved.")
+	findingsList          = findingsCmd.Command("list", "List findings, most recently seen first.")
+	findingsListState     = findingsList.Flag("state", "Only list findings in this state.").Enum("open", "false-positive", "resolved")
+	findingsListLimit     = findingsList.Flag("limit", "Number of findings to list.").Default("100").Int()
+	findingsShow          = findingsCmd.Command("show", "Show a finding and where it was found.")
+	findingsShowID        = findingsShow.Arg("fingerprint", "Fingerprint of the finding, or a unique prefix of it.").Required().String()
+	findingsResolve       = findingsCmd.Command("resolve", "Mark a finding as resolved, for example once the secret has been rotated.")
+	findingsResolveID     = findingsResolve.Arg("fingerprint", "Fingerprint of the finding, or a unique prefix of it.").Required().String()
+	findingsResolveReason = findingsResolve.Flag("reason", "Note recorded with the change.").String()
+	findingsFP            = findingsCmd.Command("fp", "Mark a finding as a false positive.")
+	findingsFPID          = findingsFP.Arg("fingerprint", "Fingerprint of the finding, or a unique prefix of it.").Required().String()
+	findingsFPReason      = findingsFP.Flag("reason", "Note recorded with the change.").String()
+	findingsReopen        = findingsCmd.Command("reopen", "Mark a finding as open again, so that scans report it.")
+	findingsReopenID      = findingsReopen.Arg("fingerprint", "Fingerprint of the finding, or a unique prefix of it.").Required().String()
+	findingsReopenReason  = findingsReopen.Flag("reason", "Note recorded with the change.").String()
+	findingsPurge         = findingsCmd.Command("purge", "Delete the findings resolved more than --purge-resolved-after days ago, exporting them to --purge-export first if it's set.")
+
+	detectorsCmd          = cli.Command("detectors", "Work with detectors.")
+	detectorsTest         = detectorsCmd.Command("test", "Run detectors against true and false positive fixtures. Verification requests are answered by mocks, so no live credentials are needed.")
+	detectorsTestFixtures = detectorsTest.Flag("fixtures", "Directory of fixture files to run in addition to the shipped fixtures.").ExistingDir()
+	detectorsTestNames    = detectorsTest.Arg("detector", "Only test these detectors.").Strings()
+
+	generateCmd         = cli.Command("generate", "Generate code for contributions.")
+	generateDetector    = generateCmd.Command("detector", "Scaffold a detector package with keywords, a pattern, a verification stub, and tests.")
+	generateName        = generateDetector.Flag("name", "DetectorType enum name of the detector, such as AcmeCloud. The package is named after it in lower case.").Required().String()
+	generateKeyRegex    = generateDetector.Flag("key-regex", "Regular expression matching the secret. Its only capture group, if it has one, is the secret.").Required().String()
+	generateKeywords    = generateDetector.Flag("keyword", "Keyword to pre-filter chunks with. You can repeat this flag. Defaults to the package name.").Strings()
+	generateVerifyURL   = generateDetector.Flag("verify-url", "URL to request with the secret as a bearer token to verify it. Without it, the verification request is a stub to fill in.").String()
+	generateExample     = generateDetector.Flag("example", "Example secret matching --key-regex, to generate tests with mocked verification that need no live credentials.").String()
+	generateDetectorDir = generateDetector.Flag("dir", "Directory of the detector packages.").Default("pkg/detectors").String()
+
+	configCmd          = cli.Command("config", "Validate and show configuration.")
+	configValidate     = configCmd.Command("validate", "Check a config file for errors.")
+	configValidatePath = configValidate.Arg("path", "Config file to check. Defaults to --config.").String()
+	configShow         = configCmd.Command("show", "Print the effective configuration after applying the config file, environment variables, and flags.")
+
+	// jsonWriter writes --json output, to stdout or --output-file.
+	jsonWriter = output.NewLineWriter(os.Stdout)
+	// protoWriter writes --protobuf output, to stdout or --output-file.
+	protoWriter = output.NewProtoWriter(os.Stdout)
+	// sarifWriter writes --sarif output, to stdout or --output-file.
+	sarifWriter io.Writer = os.Stdout
+	// junitWriter writes --junit output, to stdout or --output-file.
+	junitWriter io.Writer = os.Stdout
+	// templateWriter writes --output-template output, to stdout or --output-file.
+	templateWriter io.Writer = os.Stdout
+
+	// loadedConfig is the file loaded from --config, if any, for settings that have no flag.
+	loadedConfig *config.Config
+	// configErr is an error loading --config, reported after parsing so that `config validate` can print it.
+	configErr error
 )
 
 func init() {
@@ -99,22 +367,126 @@ func init() {
 		}
 	}
 
+	// The config file provides flag defaults, so it has to be loaded before the flags are parsed.
+	if path := configPath(os.Args[1:]); path != "" {
+		configErr = applyConfig(path)
+	}
+
 	cli.Version("trufflehog " + version.BuildVersion)
 	cmd = kingpin.MustParse(cli.Parse(os.Args[1:]))
+	if configErr != nil && cmd != configValidate.FullCommand() {
+		fmt.Fprintln(os.Stderr, configErr)
+		os.Exit(1)
+	}
 
-	if *jsonOut {
-		logrus.SetFormatter(&logrus.JSONFormatter{})
+	if err := configureLogging(); err != nil {
+		logrus.WithError(err).Fatal("invalid logging configuration")
+	}
+	logrus.Debugf("running version %s", version.BuildVersion)
+}
+
+// resultFilterOptions returns the engine options for the result filtering flags.
+func resultFilterOptions() ([]engine.EngineOption, error) {
+	opts := []engine.EngineOption{engine.WithOnlyVerified(*onlyVerified)}
+	if *minSeverity != "" {
+		severity, err := detectors.ParseSeverity(*minSeverity)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, engine.WithMinSeverity(severity))
+	}
+	var types []detectorspb.DetectorType
+	for _, name := range *detectorFilter {
+		detectorType, err := detectors.ParseDetectorType(name)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, detectorType)
+	}
+	opts = append(opts, engine.WithDetectorTypes(types...))
+	if *allowlistFile != "" {
+		accepted, err := allowlist.Load(*allowlistFile)
+		if err != nil {
+			return nil, err
+		}
+		logrus.Debugf("loaded %d allowlisted secret hashes", accepted.Len())
+		opts = append(opts, engine.WithAllowlist(accepted, *allowlistTag))
+	}
+	if len(*allowPatterns) > 0 {
+		patterns, err := allowlist.NewPatterns(*allowPatterns)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, engine.WithAllowlistPatterns(patterns, *allowlistTag))
+	}
+	if !*noFPFilter && loadedConfig != nil {
+		fpFilter, err := loadedConfig.FalsePositiveFilter()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, engine.WithFalsePositiveFilter(fpFilter))
+	}
+	if loadedConfig != nil {
+		pathRules, err := loadedConfig.PathRuleSet()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, engine.WithPathRules(pathRules))
+		resultPolicy, err := loadedConfig.PolicySet()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, engine.WithPolicy(resultPolicy))
+	}
+	if *extractStrings {
+		opts = append(opts, engine.WithStringExtraction(true))
+	}
+	return opts, nil
+}
+
+// configureDetectors applies the flags that configure detectors, which are set for the whole process rather than per
+// engine. It runs before any command that scans, including the server's scans.
+func configureDetectors() {
+	if *verifyConns {
+		connectionstring.EnableVerification()
+	}
+	combolist.SetCountOnly(*comboCount)
+	combolist.SetBulkThreshold(*comboThreshold)
+	vault.SetAddress(*vaultAddr)
+	consul.SetAddress(*consulAddr)
+	// Cloud credentials are verified with only the credentials found, but at the endpoints the SDKs are configured with.
+	aws.SetEndpoint(firstNonEmpty(*awsVerifyRgn, os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION")),
+		firstNonEmpty(*awsSTSEndpoint, os.Getenv("AWS_ENDPOINT_URL_STS")))
+	gcp.SetTokenURL(*gcpTokenURL)
+	azure.SetEndpoints(firstNonEmpty(*azureAuthority, os.Getenv("AZURE_AUTHORITY_HOST")), *azureARM)
+	if *noFPFilter {
+		detectors.DisableFalsePositiveFilter()
+	}
+}
+
+func configureLogging() error {
+	logConfig := logging.Config{Format: *logFormat, Level: logrus.InfoLevel}
+	if logConfig.Format == "" && *jsonOut {
+		logConfig.Format = "json"
 	}
 	switch {
+	case *logLevel != "":
+		level, err := logging.ParseLevel(*logLevel)
+		if err != nil {
+			return err
+		}
+		logConfig.Level = level
 	case *trace:
-		logrus.SetLevel(logrus.TraceLevel)
-		logrus.Debugf("running version %s", version.BuildVersion)
+		logConfig.Level = logrus.TraceLevel
 	case *debug:
-		logrus.SetLevel(logrus.DebugLevel)
-		logrus.Debugf("running version %s", version.BuildVersion)
-	default:
-		logrus.SetLevel(logrus.InfoLevel)
+		logConfig.Level = logrus.DebugLevel
+	}
+	modules, err := logging.ParseModuleLevels(*logModules)
+	if err != nil {
+		return err
 	}
+	logConfig.ModuleLevels = modules
+	return logging.Configure(logConfig)
 }
 
 func main() {
@@ -161,20 +533,372 @@ func run(state overseer.State) {
 		}()
 	}
 
-	ctx := context.TODO()
-	e := engine.Start(ctx,
+	// Sources, detectors and sinks stop when ctx is cancelled, by a signal or --scan-timeout. The results database is
+	// updated with storeCtx, which isn't cancelled, so the results found before then are still recorded.
+	storeCtx := context.TODO()
+	ctx := storeCtx
+
+	// Daemon modes run until asked to stop, so they shut down gracefully and report their health.
+	checker := &health.Checker{}
+	switch cmd {
+	case operatorCmd.FullCommand():
+		ctx = daemonContext(ctx, state, checker, *operatorHealth)
+	case syslogScan.FullCommand():
+		ctx = daemonContext(ctx, state, checker, *syslogHealth)
+	case githubAuditScan.FullCommand():
+		ctx = daemonContext(ctx, state, checker, *githubAuditHealth)
+	case serverCmd.FullCommand():
+		// The server serves the health endpoints itself, alongside the API.
+		ctx = daemonContext(ctx, state, checker, "")
+	default:
+		ctx = interruptContext(ctx)
+	}
+
+	if *scanTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *scanTimeout)
+		defer cancel()
+	}
+
+	if *egressAuditLog != "" {
+		auditFile, err := os.OpenFile(*egressAuditLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			logrus.WithError(err).Fatal("could not open egress audit log")
+		}
+		defer auditFile.Close()
+		common.SetEgressAuditLog(auditFile)
+	}
+
+	if *apiCacheDir != "" {
+		if err := common.SetAPICacheDir(*apiCacheDir); err != nil {
+			logrus.WithError(err).Fatal("could not create API cache directory")
+		}
+	}
+	configureDetectors()
+
+	switch cmd {
+	case configValidate.FullCommand():
+		runConfigValidate()
+		return
+	case configShow.FullCommand():
+		if err := runConfigShow(); err != nil {
+			logrus.WithError(err).Fatal("could not show configuration")
+		}
+		return
+	case testSourceCmd.FullCommand():
+		failed, err := runTestSource(ctx)
+		if err != nil {
+			logrus.WithError(err).Fatal("could not test sources")
+		}
+		if failed {
+			os.Exit(1)
+		}
+		return
+	case detectorsTest.FullCommand():
+		failed, err := runDetectorsTest(ctx)
+		if err != nil {
+			logrus.WithError(err).Fatal("could not test detectors")
+		}
+		if failed {
+			os.Exit(1)
+		}
+		return
+	case generateDetector.FullCommand():
+		if err := runGenerateDetector(); err != nil {
+			logrus.WithError(err).Fatal("could not generate detector")
+		}
+		return
+	case defectdojoCmd.FullCommand():
+		if err := runDefectDojo(ctx); err != nil {
+			logrus.WithError(err).Fatal("could not push findings to DefectDojo")
+		}
+		return
+	case scansList.FullCommand(), scansShow.FullCommand():
+		if err := runScans(ctx); err != nil {
+			logrus.WithError(err).Fatal("could not query results database")
+		}
+		return
+	case findingsList.FullCommand(), findingsShow.FullCommand(), findingsResolve.FullCommand(), findingsFP.FullCommand(), findingsReopen.FullCommand(), findingsPurge.FullCommand():
+		if err := runFindings(ctx); err != nil {
+			logrus.WithError(err).Fatal("could not triage findings")
+		}
+		return
+	case verifySignatureCmd.FullCommand():
+		if err := runVerifySignature(); err != nil {
+			logrus.WithError(err).Fatal("signature verification failed")
+		}
+		return
+	case diffCmd.FullCommand():
+		report, err := diff.CompareFiles(*diffOld, *diffNew)
+		if err != nil {
+			logrus.WithError(err).Fatal("could not compare results")
+		}
+		if *jsonOut {
+			err = json.NewEncoder(os.Stdout).Encode(report)
+		} else {
+			err = report.WriteText(os.Stdout)
+		}
+		if err != nil {
+			logrus.WithError(err).Fatal("could not write comparison")
+		}
+		if len(report.New) > 0 && *fail {
+			logrus.Debug("exiting with code 183 because new results were found")
+			os.Exit(183)
+		}
+		return
+	case sprawlCmd.FullCommand():
+		if err := runSprawl(ctx); err != nil {
+			logrus.WithError(err).Fatal("could not report secrets sprawl")
+		}
+		return
+	case reportHeatmap.FullCommand():
+		if err := runHeatmap(ctx); err != nil {
+			logrus.WithError(err).Fatal("could not report heatmap")
+		}
+		return
+	}
+
+	if cmd == tuiCmd.FullCommand() {
+		if err := runTUI(); err != nil {
+			logrus.WithError(err).Fatal("could not browse results")
+		}
+		return
+	}
+
+	if cmd == operatorCmd.FullCommand() {
+		client, err := operator.InClusterClient()
+		if err != nil {
+			logrus.WithError(err).Fatal("could not create kubernetes client")
+		}
+		controller := operator.NewController(client, *operatorNamespace, *operatorResyncInterval, *operatorMaxConcurrentScans, *operatorJobImage, *operatorJobServiceAccount)
+		checker.SetReady(true)
+		if err := controller.Run(ctx); err != nil {
+			logrus.WithError(err).Fatal("operator stopped")
+		}
+		logrus.Info("operator stopped")
+		return
+	}
+
+	if cmd == operatorScanCmd.FullCommand() {
+		client, err := operator.InClusterClient()
+		if err != nil {
+			logrus.WithError(err).Fatal("could not create kubernetes client")
+		}
+		if err := operator.RunScan(ctx, client); err != nil {
+			logrus.WithError(err).Fatal("scan failed")
+		}
+		return
+	}
+
+	if cmd == serverCmd.FullCommand() {
+		if err := runServer(ctx, checker); err != nil {
+			logrus.WithError(err).Fatal("server stopped")
+		}
+		logrus.Info("server stopped")
+		return
+	}
+
+	if *jsonManifest && (!*jsonOut || *jsonLegacy) {
+		logrus.Fatal("--manifest requires --json")
+	}
+	if *protobufOut && (*jsonOut || *jsonLegacy || *groupResults) {
+		logrus.Fatal("--protobuf can't be used with --json, --json-legacy, or --group")
+	}
+	if *sarifOut && (*jsonOut || *jsonLegacy || *protobufOut || *groupResults || *outputDir != "") {
+		logrus.Fatal("--sarif can't be used with --json, --json-legacy, --protobuf, --group, or --output-dir")
+	}
+	if *junitOut && (*jsonOut || *jsonLegacy || *protobufOut || *sarifOut || *groupResults || *outputDir != "") {
+		logrus.Fatal("--junit can't be used with --json, --json-legacy, --protobuf, --sarif, --group, or --output-dir")
+	}
+	var outputTemplate *template.Template
+	if *outputTmpl != "" {
+		if *jsonOut || *jsonLegacy || *protobufOut || *sarifOut || *junitOut || *groupResults || *outputDir != "" {
+			logrus.Fatal("--output-template can't be used with --json, --json-legacy, --protobuf, --sarif, --junit, --group, or --output-dir")
+		}
+		tmpl, err := output.LoadOutputTemplate(*outputTmpl)
+		if err != nil {
+			logrus.WithError(err).Fatal("could not load output template")
+		}
+		outputTemplate = tmpl
+	}
+	if *outputDir != "" {
+		if !*jsonOut && !*protobufOut {
+			logrus.Fatal("--output-dir requires --json or --protobuf")
+		}
+		if *outputPath != "" || *jsonLegacy || *groupResults {
+			logrus.Fatal("--output-dir can't be used with --output-file, --json-legacy, or --group")
+		}
+	}
+	var signer crypto.Signer
+	var signedStdout *output.SignedWriter
+	if *signKey != "" {
+		if !*jsonOut && !*jsonLegacy && !*protobufOut && !*sarifOut && !*junitOut && outputTemplate == nil {
+			logrus.Fatal("--sign-key requires --json, --protobuf, --sarif, --junit, or --output-template")
+		}
+		key, err := output.LoadSigningKey(*signKey)
+		if err != nil {
+			logrus.WithError(err).Fatal("could not load signing key")
+		}
+		signer = key
+		if *outputPath == "" && *outputDir == "" {
+			if *signatureFile == "" {
+				logrus.Fatal("--sign-key requires --output-file, --output-dir, or --signature-file")
+			}
+			signedStdout = output.NewSignedWriter(os.Stdout)
+			jsonWriter = output.NewLineWriter(signedStdout)
+			protoWriter = output.NewProtoWriter(signedStdout)
+			sarifWriter = signedStdout
+			junitWriter = signedStdout
+			templateWriter = signedStdout
+		}
+	}
+
+	var outputFile *output.OutputFile
+	if *outputPath != "" {
+		if !*jsonOut && !*jsonLegacy && !*protobufOut && !*sarifOut && !*junitOut && outputTemplate == nil {
+			logrus.Fatal("--output-file requires --json, --protobuf, --sarif, --junit, or --output-template")
+		}
+		file, err := output.CreateOutputFile(*outputPath, int64(*outputMaxSize))
+		if err != nil {
+			logrus.WithError(err).Fatal("could not create output file")
+		}
+		outputFile = file
+		if signer != nil {
+			outputFile.SignWith(signer)
+		}
+		jsonWriter = output.NewLineWriter(outputFile)
+		protoWriter = output.NewProtoWriter(outputFile)
+		sarifWriter = outputFile
+		junitWriter = outputFile
+		templateWriter = outputFile
+	}
+
+	// Suppressed results are written whole, like --json output, so the file is as sensitive as the results.
+	var suppressedFile *output.OutputFile
+	var suppressedWriter *output.LineWriter
+	if *suppressedPath != "" {
+		file, err := output.CreateOutputFile(*suppressedPath, 0)
+		if err != nil {
+			logrus.WithError(err).Fatal("could not create suppressed results file")
+		}
+		suppressedFile = file
+		if signer != nil {
+			suppressedFile.SignWith(signer)
+		}
+		suppressedWriter = output.NewLineWriter(suppressedFile)
+	}
+
+	// The scan ID tags every log line and result of the scan, so that they can be correlated.
+	scanID := output.NewScanID()
+	ctx = logging.WithScanID(ctx, scanID)
+
+	var manifest *output.Manifest
+	if *jsonManifest {
+		manifest = newManifest(cmd, scanID)
+	}
+
+	if cmd == githubScan.FullCommand() && *githubScanToken == "" {
+		*githubScanToken = resolveCredential(ctx, credentials.Request{
+			Source:      "github",
+			Host:        credentialHost(*githubScanEndpoint),
+			PasswordEnv: []string{"GITHUB_TOKEN", "GH_TOKEN"},
+		}).Password
+	}
+	if cmd == githubAuditScan.FullCommand() && *githubAuditToken == "" {
+		*githubAuditToken = resolveCredential(ctx, credentials.Request{
+			Source:      "github",
+			Host:        credentialHost(*githubAuditEndpoint),
+			PasswordEnv: []string{"GITHUB_TOKEN", "GH_TOKEN"},
+		}).Password
+	}
+
+	resultFilters, err := resultFilterOptions()
+	if err != nil {
+		logrus.WithError(err).Fatal("invalid result filter")
+	}
+	if len(*rulesFiles) > 0 {
+		rules, err := loadCustomRules(*rulesFiles)
+		if err != nil {
+			logrus.WithError(err).Fatal("could not import custom rules")
+		}
+		resultFilters = append(resultFilters, engine.WithDetectors(false, custom.Detectors(rules)...))
+	}
+
+	secretNames := secretstore.NewNames()
+	if len(*secretStores) > 0 {
+		inv, err := loadSecretInventory(ctx)
+		if err != nil {
+			logrus.WithError(err).Fatal("could not load secret store inventory")
+		}
+		resultFilters = append(resultFilters, engine.WithSecretInventory(inv))
+		secretNames.Merge(inv.Names())
+	}
+
+	if cmd == githubScan.FullCommand() && *githubSecretNames {
+		names, err := loadGitHubSecretNames(ctx)
+		if err != nil {
+			logrus.WithError(err).Fatal("could not list GitHub secret names")
+		}
+		secretNames.Merge(names)
+	}
+	if secretNames.Len() > 0 {
+		resultFilters = append(resultFilters, engine.WithSecretNames(secretNames))
+	}
+	if suppressedWriter != nil {
+		resultFilters = append(resultFilters, engine.WithSuppressedResults(func(r detectors.ResultWithMetadata) {
+			if err := suppressedWriter.WriteJSON(r); err != nil {
+				logrus.WithError(err).Fatal("could not write suppressed result")
+			}
+		}))
+	}
+
+	var db *store.Store
+	var suppressed map[string]store.State
+	if *resultsDB != "" {
+		db, err = openResultsDB(ctx)
+		if err != nil {
+			logrus.WithError(err).Fatal("could not open results database")
+		}
+		defer db.Close()
+		scan := &store.Scan{ID: scanID, Command: cmd, Version: version.BuildVersion, Start: time.Now().UTC()}
+		if err := db.StartScan(ctx, scan); err != nil {
+			logrus.WithError(err).Fatal("could not record scan")
+		}
+		if suppressed, err = db.Suppressed(ctx); err != nil {
+			logrus.WithError(err).Fatal("could not read triaged findings")
+		}
+	}
+
+	var configuredSources []config.Source
+	if cmd == multiScan.FullCommand() {
+		if loadedConfig == nil || len(loadedConfig.Sources) == 0 {
+			logrus.Fatal("No sources to scan. Add them to the sources list of the file given with --config.")
+		}
+		configuredSources = loadedConfig.Sources
+	}
+
+	e := engine.Start(logging.WithScanID(ctx, scanID), append([]engine.EngineOption{
+		engine.WithSources(len(configuredSources)),
 		engine.WithConcurrency(*concurrency),
 		engine.WithDecoders(decoders.DefaultDecoders()...),
 		engine.WithDetectors(!*noVerification, engine.DefaultDetectors()...),
-	)
+		engine.WithContextLines(*contextLines),
+	}, resultFilters...)...)
 
 	filter, err := common.FilterFromFiles(*gitScanIncludePaths, *gitScanExcludePaths)
 	if err != nil {
 		logrus.WithError(err).Fatal("could not create filter")
 	}
+	ctx = engine.WithRepoLimits(ctx, git.RepoLimits{
+		MaxSize:    int64(*maxRepoSize),
+		Timeout:    *repoTimeout,
+		MaxObjects: *maxRepoObjects,
+	})
 
 	var repoPath string
 	var remote bool
+	var headChecker *git.HeadChecker
+	var ageEstimator *git.AgeEstimator
 	switch cmd {
 	case gitScan.FullCommand():
 		repoPath, remote, err = git.PrepareRepo(*gitScanURI)
@@ -184,20 +908,59 @@ func run(state overseer.State) {
 		if remote {
 			defer os.RemoveAll(repoPath)
 		}
+		if repo, err := git.RepoFromPath(repoPath); err != nil {
+			logrus.WithError(err).Warn("could not open repository to check whether results are present at HEAD")
+		} else {
+			if headChecker, err = git.NewHeadChecker(repo); err != nil {
+				logrus.WithError(err).Warn("could not read branches to check whether results are present at HEAD")
+			}
+			if *gitScanSecretAge {
+				ageEstimator = git.NewAgeEstimator(repo)
+			}
+		}
 		err = e.ScanGit(ctx, repoPath, *gitScanBranch, *gitScanSinceCommit, *gitScanMaxDepth, filter)
 		if err != nil {
 			logrus.WithError(err).Fatal("Failed to scan git.")
 		}
 	case githubScan.FullCommand():
 		if len(*githubScanOrgs) == 0 && len(*githubScanRepos) == 0 {
-			log.Fatal("You must specify at least one organization or repository.")
+			logrus.Fatal("You must specify at least one organization or repository.")
+		}
+		includeRepos, excludeRepos, err := repoPatterns(*githubIncludeRepos, *githubExcludeRepos)
+		if err != nil {
+			logrus.WithError(err).Fatal("invalid repository filter")
+		}
+		if *githubEnumerateOnly && *githubSnapshot == "" {
+			logrus.Fatal("--enumerate-only requires --snapshot.")
 		}
-		err = e.ScanGitHub(ctx, *githubScanEndpoint, *githubScanRepos, *githubScanOrgs, *githubScanToken, *githubIncludeForks, filter, *concurrency, *githubIncludeMembers)
+		if *githubSnapshot != "" {
+			ctx = engine.WithGitHubSnapshot(ctx, githubsource.SnapshotOptions{
+				Path:          *githubSnapshot,
+				MaxAge:        *githubSnapshotMaxAge,
+				Rebuild:       *githubReenumerate,
+				EnumerateOnly: *githubEnumerateOnly,
+			})
+		}
+		err = e.ScanGitHub(ctx, *githubScanEndpoint, *githubScanRepos, *githubScanOrgs, *githubScanToken, *githubIncludeForks, filter, *concurrency, *githubIncludeMembers, includeRepos, excludeRepos)
 		if err != nil {
 			logrus.WithError(err).Fatal("Failed to scan git.")
 		}
 	case gitlabScan.FullCommand():
-		err := e.ScanGitLab(ctx, *gitlabScanEndpoint, *gitlabScanToken, *gitlabScanRepos)
+		includeRepos, excludeRepos, err := repoPatterns(*gitlabIncludeRepos, *gitlabExcludeRepos)
+		if err != nil {
+			logrus.WithError(err).Fatal("invalid repository filter")
+		}
+		if *gitlabScanToken == "" {
+			*gitlabScanToken = resolveCredential(ctx, credentials.Request{
+				Source:      "gitlab",
+				Host:        credentialHost(*gitlabScanEndpoint),
+				PasswordEnv: []string{"GITLAB_TOKEN"},
+			}).Password
+			if *gitlabScanToken == "" {
+				logrus.Fatal("No GitLab token found. Set --token, $GITLAB_TOKEN, or a credential helper.")
+			}
+		}
+		err = e.ScanGitLab(ctx, *gitlabScanEndpoint, *gitlabScanToken, *gitlabScanRepos, includeRepos, excludeRepos)
 		if err != nil {
 			logrus.WithError(err).Fatal("Failed to scan GitLab.")
 		}
@@ -207,74 +970,1505 @@ func run(state overseer.State) {
 			logrus.WithError(err).Fatal("Failed to scan filesystem")
 		}
 	case s3Scan.FullCommand():
+		if *s3ScanKey == "" && *s3ScanSecret == "" && !*s3ScanCloudEnv {
+			cred := resolveCredential(ctx, credentials.Request{
+				Source:      "s3",
+				Host:        "s3.amazonaws.com",
+				UsernameEnv: []string{"AWS_ACCESS_KEY_ID"},
+				PasswordEnv: []string{"AWS_SECRET_ACCESS_KEY"},
+			})
+			*s3ScanKey, *s3ScanSecret = cred.Username, cred.Password
+		}
 		err := e.ScanS3(ctx, *s3ScanKey, *s3ScanSecret, *s3ScanCloudEnv, *s3ScanBuckets)
 		if err != nil {
 			logrus.WithError(err).Fatal("Failed to scan S3.")
 		}
+	case awsScan.FullCommand():
+		if *awsScanKey == "" && *awsScanSecret == "" {
+			cred := resolveCredential(ctx, credentials.Request{
+				Source:      "aws",
+				Host:        "sts.amazonaws.com",
+				UsernameEnv: []string{"AWS_ACCESS_KEY_ID"},
+				PasswordEnv: []string{"AWS_SECRET_ACCESS_KEY"},
+			})
+			*awsScanKey, *awsScanSecret = cred.Username, cred.Password
+		}
+		err := e.ScanAWSAccount(ctx, &awsaccount.Source{
+			Key:     *awsScanKey,
+			Secret:  *awsScanSecret,
+			Regions: *awsScanRegions,
+			Verify:  !*noVerification,
+		})
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to sweep AWS account.")
+		}
+	case gcpScan.FullCommand():
+		if len(*gcpScanProjects) == 0 && *gcpScanOrganization == "" {
+			logrus.Fatal("You must specify at least one project or an organization.")
+		}
+		err := e.ScanGCPProject(ctx, &gcpproject.Source{
+			Projects:        *gcpScanProjects,
+			Organization:    *gcpScanOrganization,
+			CredentialsFile: *gcpScanCredentials,
+			Services:        *gcpScanServices,
+			Verify:          !*noVerification,
+		})
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to sweep GCP projects.")
+		}
+	case azureScan.FullCommand():
+		err := e.ScanAzureSubscription(ctx, &azuresubscription.Source{
+			Subscriptions: *azureScanSubscriptions,
+			Services:      *azureScanServices,
+			Verify:        !*noVerification,
+		})
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to sweep Azure subscriptions.")
+		}
+	case sshScan.FullCommand():
+		err := e.ScanSSH(ctx, &sshremote.Source{
+			Hosts:                 *sshScanHosts,
+			Paths:                 *sshScanPaths,
+			User:                  *sshScanUser,
+			IdentityFiles:         *sshScanIdentities,
+			KnownHostsFiles:       *sshScanKnownHosts,
+			InsecureIgnoreHostKey: *sshScanInsecure,
+			Jump:                  *sshScanJump,
+			Sudo:                  *sshScanSudo,
+			Concurrency:           *concurrency,
+			Verify:                !*noVerification,
+		})
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to scan SSH hosts.")
+		}
 	case syslogScan.FullCommand():
 		err := e.ScanSyslog(ctx, *syslogAddress, *syslogProtocol, *syslogTLSCert, *syslogTLSKey, *syslogFormat, *concurrency)
 		if err != nil {
 			logrus.WithError(err).Fatal("Failed to scan syslog.")
 		}
+		checker.SetReady(true)
+	case githubAuditScan.FullCommand():
+		if len(*githubAuditOrgs) == 0 && len(*githubAuditEnterprises) == 0 {
+			logrus.Fatal("You must specify at least one organization or enterprise.")
+		}
+		if *githubAuditToken == "" {
+			logrus.Fatal("No GitHub token found. Set --token, $GITHUB_TOKEN, or a credential helper.")
+		}
+		err := e.ScanGitHubAuditLog(ctx, &githubsource.AuditLog{
+			Endpoint:    *githubAuditEndpoint,
+			Token:       *githubAuditToken,
+			Orgs:        *githubAuditOrgs,
+			Enterprises: *githubAuditEnterprises,
+			Phrase:      *githubAuditPhrase,
+			Since:       *githubAuditSince,
+			Interval:    *githubAuditInterval,
+			Verify:      !*noVerification,
+		})
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to scan GitHub audit log.")
+		}
+		checker.SetReady(true)
+	case multiScan.FullCommand():
+		for i := range configuredSources {
+			src := &configuredSources[i]
+			clone, err := startConfiguredSource(ctx, e, resolveSourceCredentials(ctx, src), filter)
+			if clone != "" {
+				defer os.RemoveAll(clone)
+			}
+			if err != nil {
+				logrus.WithError(err).WithField("source", src.DisplayName()).Fatal("Failed to scan source.")
+			}
+		}
 	}
 
-	if !*jsonLegacy && !*jsonOut {
+	if *groupResults && *jsonLegacy {
+		logrus.Fatal("--group can't be used with --json-legacy")
+	}
+
+	if !*jsonLegacy && !*jsonOut && !*protobufOut && !*sarifOut && !*junitOut && outputTemplate == nil {
 		fmt.Fprintf(os.Stderr, "🐷🔑🐷  TruffleHog. Unearth your secrets. 🐷🔑🐷\n\n")
 	}
 
-	foundResults := false
-	for r := range e.ResultsChan() {
-		if *onlyVerified && !r.Verified {
-			continue
-		}
-		foundResults = true
+	if manifest != nil {
+		printJSON(output.ManifestLine{Manifest: manifest})
+	}
 
-		switch {
-		case *jsonLegacy:
-			repoPath, remote, err = git.PrepareRepo(r.SourceMetadata.GetGithub().Repository)
-			if err != nil || repoPath == "" {
-				logrus.WithError(err).Fatal("error preparing git repo for scanning")
-			}
-			legacy := output.ConvertToLegacyJSON(&r, repoPath)
-			out, err := json.Marshal(legacy)
-			if err != nil {
-				logrus.WithError(err).Fatal("could not marshal result")
+	var resultCount uint64
+	var primary output.Sink
+	var grouped *output.GroupedSink
+	switch {
+	case *outputDir != "":
+		partitioned := output.NewPartitionedSink(*outputDir, *protobufOut)
+		if signer != nil {
+			partitioned.SignWith(signer)
+		}
+		primary = partitioned
+	case *groupResults:
+		var w *output.LineWriter
+		if *jsonOut {
+			w = jsonWriter
+		}
+		grouped = output.NewGroupedSink(w)
+		primary = grouped
+	case *jsonLegacy:
+		primary = output.NewLegacyJSONSink(jsonWriter, git.PrepareRepo)
+	case *jsonOut:
+		primary = output.NewJSONSink(jsonWriter)
+	case *protobufOut:
+		primary = output.NewProtoSink(protoWriter)
+	case *sarifOut:
+		primary = output.NewSARIFSink(sarifWriter, version.BuildVersion)
+	case *junitOut:
+		primary = output.NewJUnitSink(junitWriter, version.BuildVersion)
+	case outputTemplate != nil:
+		primary = output.NewTemplateSink(templateWriter, outputTemplate)
+	default:
+		primary = output.NewPlainSink()
+	}
+	sinks := []output.Sink{primary}
+	sinkNames := []string{"output"}
+	if *htmlReport != "" {
+		sinks = append(sinks, output.NewHTMLSink(*htmlReport, version.BuildVersion))
+		sinkNames = append(sinkNames, "html-report")
+	}
+	for _, spec := range *sinkSpecs {
+		// Sinks are logged by name, since their options can hold credentials.
+		name, _, err := output.ParseSinkSpec(spec)
+		if err != nil {
+			logrus.WithError(err).Fatal("could not parse sink")
+		}
+		sink, err := output.NewSink(spec)
+		if err != nil {
+			logrus.WithError(err).WithField("sink", name).Fatal("could not create sink")
+		}
+		sinks = append(sinks, sink)
+		sinkNames = append(sinkNames, name)
+	}
+	closeSinks := func() {
+		for i, sink := range sinks {
+			if err := sink.Close(); err != nil {
+				logrus.WithError(err).WithField("sink", sinkNames[i]).Error("could not close sink")
 			}
-			fmt.Println(string(out))
+		}
+	}
+	for i, sink := range sinks {
+		if err := sink.Start(ctx); err != nil {
+			closeSinks()
+			logrus.WithError(err).WithField("sink", sinkNames[i]).Fatal("could not start sink")
+		}
+	}
 
-			if remote {
-				os.RemoveAll(repoPath)
+	results := e.ResultsChan()
+	if *deterministic {
+		results = sortedResults(results)
+	}
+	var triaged, policyFailures uint64
+	for r := range results {
+		if db != nil && recordResult(storeCtx, db, scanID, &r, suppressed) {
+			triaged++
+			if suppressedWriter != nil {
+				writeSuppressed(suppressedWriter, r, suppressedTriaged)
 			}
-		case *jsonOut:
-			out, err := json.Marshal(r)
-			if err != nil {
-				logrus.WithError(err).Fatal("could not marshal result")
+			continue
+		}
+		if !*groupResults {
+			resultCount++
+		}
+		if policy.ActionOf(&r) == policy.ActionFail {
+			policyFailures++
+		}
+		if headChecker != nil {
+			setPresentAtHead(headChecker, &r)
+		}
+		if ageEstimator != nil {
+			setSecretAge(ageEstimator, &r)
+		}
+		for i, sink := range sinks {
+			if err := sink.Write(ctx, &r); err != nil {
+				if ctx.Err() != nil {
+					// Sinks that send results elsewhere stop with the scan, which is reported below.
+					logrus.WithError(err).WithField("sink", sinkNames[i]).Error("could not write output")
+					continue
+				}
+				closeSinks()
+				logrus.WithError(err).WithField("sink", sinkNames[i]).Fatal("could not write output")
 			}
-			fmt.Println(string(out))
-		default:
-			output.PrintPlainOutput(&r)
 		}
 	}
-	logrus.Debugf("scanned %d chunks", e.ChunksScanned())
-
-	if *printAvgDetectorTime {
-		printAverageDetectorTime(e)
+	for i, sink := range sinks {
+		if err := sink.Flush(ctx); err != nil {
+			if ctx.Err() != nil {
+				logrus.WithError(err).WithField("sink", sinkNames[i]).Error("could not flush output")
+				continue
+			}
+			closeSinks()
+			logrus.WithError(err).WithField("sink", sinkNames[i]).Fatal("could not flush output")
+		}
 	}
-
-	if foundResults && *fail {
-		logrus.Debug("exiting with code 183 because results were found")
-		os.Exit(183)
+	closeSinks()
+	if grouped != nil {
+		resultCount = uint64(grouped.Groups())
 	}
-}
-
-func printAverageDetectorTime(e *engine.Engine) {
-	fmt.Fprintln(os.Stderr, "Average detector time is the measurement of average time spent on each detector when results are returned.")
-	for detectorName, durations := range e.DetectorAvgTime() {
-		var total time.Duration
-		for _, d := range durations {
-			total += d
+	summary := e.Summary()
+	summary.Cancelled = ctx.Err() != nil
+	if triaged > 0 {
+		if summary.Suppressed == nil {
+			summary.Suppressed = map[string]uint64{}
+		}
+		summary.Suppressed[suppressedTriaged] = triaged
+	}
+	if manifest != nil {
+		printJSON(output.ScanEndLine{ScanEnd: &output.ScanEnd{
+			ScanID:     manifest.ScanID,
+			EndTime:    time.Now().UTC(),
+			Results:    resultCount,
+			Cancelled:  summary.Cancelled,
+			Suppressed: summary.Suppressed,
+		}})
+	}
+	if db != nil {
+		if err := db.EndScan(storeCtx, scanID, time.Now().UTC(), int(resultCount)); err != nil {
+			logrus.WithError(err).Error("could not record the end of the scan")
+		}
+		if *purgeResolvedAfter > 0 {
+			if err := purgeResolved(storeCtx, db); err != nil {
+				logrus.WithError(err).Error("could not purge resolved findings")
+			}
+		}
+	}
+	if outputFile != nil {
+		if err := outputFile.Close(); err != nil {
+			logrus.WithError(err).Fatal("could not complete output file")
+		}
+	}
+	if signedStdout != nil {
+		sig, err := signedStdout.Sign(signer)
+		if err != nil {
+			logrus.WithError(err).Fatal("could not sign output")
+		}
+		if err := os.WriteFile(*signatureFile, append(sig, '\n'), 0o644); err != nil {
+			logrus.WithError(err).Fatal("could not write output signature")
+		}
+	}
+	if suppressedFile != nil {
+		if err := suppressedFile.Close(); err != nil {
+			logrus.WithError(err).Fatal("could not complete suppressed results file")
+		}
+	}
+	if len(summary.Suppressed) > 0 {
+		fields := logrus.Fields{}
+		for reason, count := range summary.Suppressed {
+			fields[reason] = count
+		}
+		logrus.WithFields(fields).Info("suppressed results")
+	}
+	logrus.Debugf("scanned %d chunks", e.ChunksScanned())
+
+	if *telemetryEndpoint != "" {
+		report := telemetry.NewReport(&summary, version.BuildVersion, cmd, resultCount)
+		report.CI = telemetry.CISystem(os.Getenv)
+		client := &telemetry.Client{Endpoint: *telemetryEndpoint, Token: *telemetryToken}
+		// Telemetry is best effort, so a failure never fails the scan.
+		if err := client.Send(context.Background(), report); err != nil {
+			logrus.WithError(err).Warn("could not send telemetry")
+		}
+	}
+
+	if *printAvgDetectorTime {
+		printAverageDetectorTime(e)
+	}
+
+	if *printSummary {
+		printScanSummary(summary)
+	}
+
+	if summary.Cancelled {
+		progress := logrus.WithFields(logrus.Fields{
+			"duration": summary.Duration.Round(time.Millisecond).String(),
+			"chunks":   summary.ChunksScanned,
+			"bytes":    summary.BytesScanned,
+			"results":  resultCount,
+		})
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			progress.WithField("timeout", scanTimeout.String()).Error("scan timed out, results are incomplete")
+		} else {
+			progress.Error("scan cancelled, results are incomplete")
+		}
+		os.Exit(1)
+	}
+
+	if resultCount > 0 && *fail {
+		logrus.Debug("exiting with code 183 because results were found")
+		os.Exit(183)
+	}
+	if policyFailures > 0 {
+		logrus.WithField("results", policyFailures).Error("results matched a policy rule that fails the scan")
+		os.Exit(183)
+	}
+}
+
+// newManifest describes the scan about to run for the --manifest envelope.
+func newManifest(command, scanID string) *output.Manifest {
+	manifest := &output.Manifest{
+		ScanID:    scanID,
+		StartTime: time.Now().UTC(),
+		Version:   version.BuildVersion,
+		Command:   command,
+		Options:   map[string]string{},
+	}
+
+	addFlags := func(flags []*kingpin.FlagModel) {
+		for _, flag := range flags {
+			value := flag.Value.String()
+			if flag.Name == "help" || value == "" || value == "false" || value == "[]" {
+				continue
+			}
+			manifest.Options[flag.Name] = value
+		}
+	}
+	model := cli.Model()
+	addFlags(model.Flags)
+	for _, c := range model.FlattenedCommands() {
+		if c.FullCommand != command {
+			continue
+		}
+		addFlags(c.Flags)
+		for _, arg := range c.Args {
+			if value := arg.Value.String(); value != "" {
+				manifest.Options[arg.Name] = value
+			}
+		}
+	}
+	manifest.Options = output.RedactOptions(manifest.Options)
+
+	for _, d := range engine.DefaultDetectors() {
+		manifest.Detectors = append(manifest.Detectors, engine.DetectorName(d))
+	}
+	sort.Strings(manifest.Detectors)
+	return manifest
+}
+
+// sortedResults buffers the results until the scan finishes, and returns them sorted for --deterministic.
+func sortedResults(in chan detectors.ResultWithMetadata) chan detectors.ResultWithMetadata {
+	var results []detectors.ResultWithMetadata
+	for r := range in {
+		results = append(results, r)
+	}
+	output.SortResults(results)
+
+	out := make(chan detectors.ResultWithMetadata, len(results))
+	for _, r := range results {
+		out <- r
+	}
+	close(out)
+	return out
+}
+
+// openResultsDB opens the --results-db database.
+func openResultsDB(ctx context.Context) (*store.Store, error) {
+	if *resultsDB == "" {
+		return nil, errors.New("--results-db is required")
+	}
+	return store.Open(ctx, *resultsDB)
+}
+
+// runVerifySignature checks the signature of a results file written with --sign-key.
+func runVerifySignature() error {
+	key, err := output.LoadVerifyingKey(*verifySignatureKey)
+	if err != nil {
+		return err
+	}
+	sigPath := *verifySignatureSig
+	if sigPath == "" {
+		sigPath = *verifySignatureFile + output.SignatureSuffix
+	}
+	if err := output.VerifyFile(key, *verifySignatureFile, sigPath); err != nil {
+		return err
+	}
+	logrus.WithField("file", *verifySignatureFile).Info("signature verified")
+	return nil
+}
+
+// purgeResolved deletes the findings resolved more than --purge-resolved-after days ago, appending them to
+// --purge-export first if it's set.
+func purgeResolved(ctx context.Context, db *store.Store) error {
+	var export func(*store.Finding, []store.Occurrence) error
+	if *purgeExport != "" {
+		file, err := os.OpenFile(*purgeExport, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		writer := output.NewLineWriter(file)
+		export = func(f *store.Finding, occurrences []store.Occurrence) error {
+			return writer.WriteJSON(struct {
+				store.Finding
+				Occurrences []store.Occurrence
+			}{*f, occurrences})
+		}
+	}
+
+	before := time.Now().UTC().AddDate(0, 0, -*purgeResolvedAfter)
+	purged, err := db.PurgeResolved(ctx, before, export)
+	if purged > 0 {
+		logrus.WithFields(logrus.Fields{"findings": purged, "resolved_before": before.Format(time.RFC3339)}).Info("purged resolved findings")
+	}
+	return err
+}
+
+// suppressedTriaged is the reason counted for results dropped because their finding was triaged in the results
+// database.
+const suppressedTriaged = "triaged"
+
+// writeSuppressed writes a suppressed result to the --suppressed-output file, with the reason it was suppressed.
+func writeSuppressed(w *output.LineWriter, r detectors.ResultWithMetadata, reason string) {
+	extra := make(map[string]string, len(r.ExtraData)+1)
+	for k, v := range r.ExtraData {
+		extra[k] = v
+	}
+	extra["suppressed"] = reason
+	r.ExtraData = extra
+	if err := w.WriteJSON(r); err != nil {
+		logrus.WithError(err).Fatal("could not write suppressed result")
+	}
+}
+
+// recordResult adds a result to the results database. It returns true if the result's finding was triaged as a false
+// positive or resolved, so it shouldn't be reported.
+func recordResult(ctx context.Context, db *store.Store, scanID string, r *detectors.ResultWithMetadata, suppressed map[string]store.State) bool {
+	finding, err := findings.FindingFromResult(r)
+	if err == nil {
+		err = db.AddFinding(ctx, scanID, &finding, time.Now().UTC())
+	}
+	if err != nil {
+		logrus.WithError(err).Fatal("could not record result")
+	}
+	state, ok := suppressed[finding.Fingerprint()]
+	if ok {
+		logrus.WithFields(logrus.Fields{"detector": finding.DetectorType, "state": state}).Debug("suppressed triaged result")
+	}
+	return ok
+}
+
+// printJSON writes a line of --json output.
+func printJSON(v interface{}) {
+	if err := jsonWriter.WriteJSON(v); err != nil {
+		logrus.WithError(err).Fatal("could not write output")
+	}
+}
+
+// loadCustomRules imports the rules of each rules file, logging the rules and settings that couldn't be imported.
+func loadCustomRules(paths []string) ([]custom.Rule, error) {
+	var rules []custom.Rule
+	for _, path := range paths {
+		imported, warnings, err := custom.Import(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, warning := range warnings {
+			logrus.WithField("file", path).Warn(warning)
+		}
+		logrus.Debugf("imported %d custom rules from %s", len(imported), path)
+		rules = append(rules, imported...)
+	}
+	return rules, nil
+}
+
+// loadSecretInventory lists the secrets in the --secret-store secret managers.
+func loadSecretInventory(ctx context.Context) (*secretstore.Inventory, error) {
+	var stores []secretstore.Store
+	for _, name := range *secretStores {
+		switch name {
+		case "vault":
+			if *vaultAddr == "" {
+				return nil, errors.New("--secret-store=vault requires --vault-addr")
+			}
+			token := resolveCredential(ctx, credentials.Request{
+				Source:      "vault",
+				Host:        credentialHost(*vaultAddr),
+				PasswordEnv: []string{"VAULT_TOKEN"},
+			}).Password
+			if token == "" {
+				return nil, errors.New("no Vault token found, set $VAULT_TOKEN or a credential helper")
+			}
+			stores = append(stores, &secretstore.Vault{Address: *vaultAddr, Token: token, Mounts: *vaultMounts})
+		case "aws-secrets-manager":
+			regions := *awsRegions
+			if len(regions) == 0 {
+				regions = []string{""}
+			}
+			for _, region := range regions {
+				store, err := secretstore.NewAWSSecretsManager(region)
+				if err != nil {
+					return nil, err
+				}
+				stores = append(stores, store)
+			}
+		}
+	}
+
+	inv, err := secretstore.Collect(ctx, stores...)
+	if err != nil {
+		return nil, err
+	}
+	logrus.WithFields(logrus.Fields{"hashes": inv.Len(), "names": inv.Names().Len()}).Info("loaded secret store inventory")
+	return inv, nil
+}
+
+// runSprawl reports how much of the secret material in a results file lives outside managed storage.
+func runSprawl(ctx context.Context) error {
+	findings, err := loadFindings(*sprawlResults, *onlyVerified)
+	if err != nil {
+		return err
+	}
+	var inv *secretstore.Inventory
+	if len(*secretStores) > 0 {
+		if inv, err = loadSecretInventory(ctx); err != nil {
+			return err
+		}
+	}
+	report := sprawl.Build(findings, inv)
+	if *jsonOut {
+		return json.NewEncoder(os.Stdout).Encode(report)
+	}
+	return report.WriteText(os.Stdout)
+}
+
+// loadGitHubSecretNames lists the names of the Actions and Dependabot secrets of the --org organizations.
+func loadGitHubSecretNames(ctx context.Context) (*secretstore.Names, error) {
+	if len(*githubScanOrgs) == 0 {
+		return nil, errors.New("--correlate-secret-names requires --org")
+	}
+	if *githubScanToken == "" {
+		return nil, errors.New("--correlate-secret-names requires a token")
+	}
+	names := secretstore.NewNames()
+	err := githubsource.ListSecretNames(ctx, *githubScanEndpoint, *githubScanToken, *githubScanOrgs, names.Add)
+	if err != nil {
+		return nil, err
+	}
+	logrus.WithField("names", names.Len()).Info("loaded GitHub secret names")
+	return names, nil
+}
+
+// resolveCredential looks up a source credential that wasn't given as a flag.
+func resolveCredential(ctx context.Context, req credentials.Request) credentials.Credential {
+	cred, _ := credentials.New(credentials.WithHelper(*credentialHelper)).Resolve(ctx, req)
+	return cred
+}
+
+// credentialHost returns the host credentials for an API endpoint are stored under. Tokens for api.github.com are
+// stored under github.com by git and gh.
+func credentialHost(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(u.Hostname(), "api.")
+}
+
+// startConfiguredSource starts scanning a configured source, naming its results after it. Credentials aren't looked up,
+// so sources from the config file should be passed through resolveSourceCredentials first. It returns the path of a
+// repository it cloned, which should be removed once the scan is done.
+func startConfiguredSource(ctx context.Context, e *engine.Engine, src *config.Source, filter *common.Filter) (string, error) {
+	ctx = engine.WithSourceName(ctx, src.DisplayName())
+	switch src.Type {
+	case "git":
+		repoPath, remote, err := git.PrepareRepo(src.URI)
+		if err != nil || repoPath == "" {
+			return "", fmt.Errorf("could not prepare git repo: %v", err)
+		}
+		var clone string
+		if remote {
+			clone = repoPath
+		}
+		return clone, e.ScanGit(ctx, repoPath, src.Branch, src.SinceCommit, src.MaxDepth, filter)
+	case "github":
+		includeRepos, excludeRepos, err := repoPatterns(src.IncludeRepos, src.ExcludeRepos)
+		if err != nil {
+			return "", err
+		}
+		endpoint := src.Endpoint
+		if endpoint == "" {
+			endpoint = "https://api.github.com"
+		}
+		if src.Snapshot != "" {
+			ctx = engine.WithGitHubSnapshot(ctx, githubsource.SnapshotOptions{Path: src.Snapshot})
+		}
+		return "", e.ScanGitHub(ctx, endpoint, src.Repos, src.Orgs, src.Token, src.IncludeForks, filter, *concurrency, src.IncludeMembers, includeRepos, excludeRepos)
+	case "gitlab":
+		includeRepos, excludeRepos, err := repoPatterns(src.IncludeRepos, src.ExcludeRepos)
+		if err != nil {
+			return "", err
+		}
+		endpoint := src.Endpoint
+		if endpoint == "" {
+			endpoint = "https://gitlab.com"
+		}
+		return "", e.ScanGitLab(ctx, endpoint, src.Token, src.Repos, includeRepos, excludeRepos)
+	case "filesystem":
+		return "", e.ScanFileSystem(ctx, src.Directories)
+	case "s3":
+		return "", e.ScanS3(ctx, src.Key, src.Secret, src.CloudEnvironment, src.Buckets)
+	default:
+		return "", fmt.Errorf("unknown source type %q", src.Type)
+	}
+}
+
+// runServer serves the scan API until ctx is cancelled, then waits for running scans to stop.
+func runServer(ctx context.Context, checker *health.Checker) error {
+	tokens, err := server.LoadTokens(*serverTokens)
+	if err != nil {
+		return err
+	}
+	if *serverRedactRaw {
+		logrus.Warn("--redact-raw is deprecated: raw secrets are always replaced by their fingerprints")
+	}
+	db, err := openResultsDB(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	filters, err := resultFilterOptions()
+	if err != nil {
+		return err
+	}
+	if len(*rulesFiles) > 0 {
+		rules, err := loadCustomRules(*rulesFiles)
+		if err != nil {
+			return err
+		}
+		filters = append(filters, engine.WithDetectors(false, custom.Detectors(rules)...))
+	}
+
+	retention := server.Retention{MaxAge: *serverRetention}
+	if *serverRetentionExport != "" {
+		file, err := os.OpenFile(*serverRetentionExport, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		writer := output.NewLineWriter(file)
+		retention.Export = func(tenant string, scan server.Scan, results []json.RawMessage) error {
+			return writer.WriteJSON(struct {
+				Tenant  string
+				Scan    server.Scan
+				Results []json.RawMessage
+			}{tenant, scan, results})
+		}
+	}
+	hosts := server.NewHostPolicy(*serverAllowedHosts, *serverAllowPrivate)
+	srv, err := server.New(ctx, tokens, db, scanServerSource(filters), *serverMaxConcurrentScans, retention, hosts)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", srv.Handler())
+	mux.Handle("/", checker.Handler())
+	httpServer := &http.Server{
+		Addr:              *serverAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			logrus.WithError(err).Debug("could not shut down the API server cleanly")
+		}
+	}()
+
+	checker.SetReady(true)
+	logrus.Infof("serving the scan API on %s", *serverAddress)
+	if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	srv.Wait()
+	return nil
+}
+
+// scanServerSource returns the function that runs scans started through the server's API, with the result filters
+// of the scan command. Unlike sources from the config file, the source's credentials aren't looked up, so that tenants
+// can't scan with the server's own, and snapshots aren't used, so that tenants can't read or write the server's files.
+func scanServerSource(filters []engine.EngineOption) server.ScanFunc {
+	return func(ctx context.Context, src config.Source) ([]detectors.ResultWithMetadata, error) {
+		src.Snapshot = ""
+		opts := []engine.EngineOption{
+			engine.WithConcurrency(*concurrency),
+			engine.WithDecoders(decoders.DefaultDecoders()...),
+			engine.WithDetectors(!*noVerification, engine.DefaultDetectors()...),
+		}
+		e := engine.Start(ctx, append(opts, filters...)...)
+		clone, err := startConfiguredSource(ctx, e, &src, common.FilterEmpty())
+		if clone != "" {
+			defer os.RemoveAll(clone)
+		}
+		if err != nil {
+			// Nothing was started, so the engine workers need to be released here.
+			close(e.ChunksChan())
+			return nil, err
+		}
+
+		var results []detectors.ResultWithMetadata
+		for r := range e.ResultsChan() {
+			results = append(results, r)
+		}
+		return results, nil
+	}
+}
+
+// resolveSourceCredentials returns a copy of a source from the config file with the credentials it doesn't set looked
+// up the same way as for the matching command.
+func resolveSourceCredentials(ctx context.Context, src *config.Source) *config.Source {
+	resolved := *src
+	switch src.Type {
+	case "github":
+		endpoint := src.Endpoint
+		if endpoint == "" {
+			endpoint = "https://api.github.com"
+		}
+		if resolved.Token == "" {
+			resolved.Token = resolveCredential(ctx, credentials.Request{
+				Source:      "github",
+				Host:        credentialHost(endpoint),
+				PasswordEnv: []string{"GITHUB_TOKEN", "GH_TOKEN"},
+			}).Password
+		}
+	case "gitlab":
+		endpoint := src.Endpoint
+		if endpoint == "" {
+			endpoint = "https://gitlab.com"
+		}
+		if resolved.Token == "" {
+			resolved.Token = resolveCredential(ctx, credentials.Request{
+				Source:      "gitlab",
+				Host:        credentialHost(endpoint),
+				PasswordEnv: []string{"GITLAB_TOKEN"},
+			}).Password
+		}
+	case "s3":
+		if src.Key == "" && src.Secret == "" && !src.CloudEnvironment {
+			cred := resolveCredential(ctx, credentials.Request{
+				Source:      "s3",
+				Host:        "s3.amazonaws.com",
+				UsernameEnv: []string{"AWS_ACCESS_KEY_ID"},
+				PasswordEnv: []string{"AWS_SECRET_ACCESS_KEY"},
+			})
+			resolved.Key, resolved.Secret = cred.Username, cred.Password
+		}
+	}
+	return &resolved
+}
+
+// runTestSource checks that the sources in the config file, or the named ones, can be scanned. It returns true if any
+// check failed.
+func runTestSource(ctx context.Context) (bool, error) {
+	if loadedConfig == nil || len(loadedConfig.Sources) == 0 {
+		return false, fmt.Errorf("no sources to test, add them to the sources list of the file given with --config")
+	}
+	only := map[string]bool{}
+	for _, name := range *testSourceNames {
+		only[name] = true
+	}
+	var srcs []*config.Source
+	for i := range loadedConfig.Sources {
+		src := &loadedConfig.Sources[i]
+		if len(only) == 0 || only[src.DisplayName()] {
+			srcs = append(srcs, src)
+		}
+	}
+	for _, src := range srcs {
+		delete(only, src.DisplayName())
+	}
+	for _, name := range *testSourceNames {
+		if only[name] {
+			return false, fmt.Errorf("no source named %q", name)
+		}
+	}
+
+	checker := sourcecheck.New()
+	failed := false
+	for _, src := range srcs {
+		fmt.Printf("%s (%s)\n", src.DisplayName(), src.Type)
+		for _, r := range checker.Check(ctx, resolveSourceCredentials(ctx, src)) {
+			switch {
+			case r.Err == nil:
+				fmt.Printf("  ok    %s", r.Check)
+				if r.Detail != "" {
+					fmt.Printf(": %s", r.Detail)
+				}
+				fmt.Println()
+				continue
+			case r.Warning:
+				fmt.Printf("  WARN  %s: %v\n", r.Check, r.Err)
+			default:
+				failed = true
+				fmt.Printf("  FAIL  %s: %v\n", r.Check, r.Err)
+			}
+			if r.Hint != "" {
+				fmt.Printf("        %s\n", r.Hint)
+			}
+		}
+	}
+	return failed, nil
+}
+
+// repoPatterns reads the repository filter patterns given on the command line, expanding "@file" values.
+func repoPatterns(include, exclude []string) ([]string, []string, error) {
+	includeRepos, err := common.ExpandRepoPatterns(include)
+	if err != nil {
+		return nil, nil, err
+	}
+	excludeRepos, err := common.ExpandRepoPatterns(exclude)
+	if err != nil {
+		return nil, nil, err
+	}
+	return includeRepos, excludeRepos, nil
+}
+
+// setPresentAtHead records whether a git result's secret is still in its file at the tip of any branch.
+func setPresentAtHead(checker *git.HeadChecker, r *detectors.ResultWithMetadata) {
+	file := r.SourceMetadata.GetGit().GetFile()
+	if file == "" {
+		return
+	}
+	present, err := checker.PresentAtHead(file, r.Raw)
+	if err != nil {
+		logrus.WithError(err).Debug("could not check whether result is present at HEAD")
+		return
+	}
+	r.PresentAtHead = &present
+}
+
+// setSecretAge records how long a git result's secret has been in the history of its file.
+func setSecretAge(estimator *git.AgeEstimator, r *detectors.ResultWithMetadata) {
+	file := r.SourceMetadata.GetGit().GetFile()
+	if file == "" {
+		return
+	}
+	age, err := estimator.Age(file, r.Raw)
+	if err != nil {
+		logrus.WithError(err).Debug("could not estimate the age of result")
+		return
+	}
+	r.Age = age
+}
+
+// daemonContext returns a context that is cancelled on SIGINT, SIGTERM, or an overseer restart, marking the process
+// as not ready first so that load balancers stop sending it work while the scan stops. If addr is set, the
+// health endpoints are served there.
+func daemonContext(parent context.Context, state overseer.State, checker *health.Checker, addr string) context.Context {
+	interrupted := interruptContext(parent)
+	ctx, cancel := context.WithCancel(parent)
+	go func() {
+		select {
+		case <-interrupted.Done():
+		case <-state.GracefulShutdown:
+			logrus.Info("shutting down for restart")
+		}
+		checker.SetReady(false)
+		cancel()
+	}()
+
+	if addr != "" {
+		go func() {
+			if err := health.Serve(ctx, addr, checker); err != nil {
+				logrus.WithError(err).Error("health server stopped")
+			}
+		}()
+	}
+	return ctx
+}
+
+// interruptContext returns a context that is cancelled on SIGINT or SIGTERM, so that sources and detectors stop and
+// the results found so far are written out. A second signal exits immediately. Signals in the second after the first are ignored,
+// since overseer forwards the interrupt from a terminal to a process that already received it.
+func interruptContext(parent context.Context) context.Context {
+	ctx, cancel := context.WithCancel(parent)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		logrus.Warnf("received %s, stopping the scan and writing the results found so far. Send it again to exit immediately.", sig)
+		cancel()
+		stopped := time.Now()
+		for sig := range signals {
+			if time.Since(stopped) < time.Second {
+				continue
+			}
+			logrus.Errorf("received %s again, exiting without waiting for the scan to stop", sig)
+			os.Exit(1)
+		}
+	}()
+	return ctx
+}
+
+// configPath returns the value of --config from the arguments, or from TRUFFLEHOG_CONFIG if it isn't set.
+func configPath(args []string) string {
+	for i, arg := range args {
+		switch {
+		case arg == "--":
+			return os.Getenv("TRUFFLEHOG_CONFIG")
+		case arg == "--config" && i+1 < len(args):
+			return args[i+1]
+		case strings.HasPrefix(arg, "--config="):
+			return strings.TrimPrefix(arg, "--config=")
+		}
+	}
+	return os.Getenv("TRUFFLEHOG_CONFIG")
+}
+
+// applyConfig loads the config file at path and sets its values as the defaults of the matching global flags.
+func applyConfig(path string) error {
+	c, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	for name, values := range c.FlagDefaults() {
+		flag := cli.GetFlag(name)
+		if flag == nil {
+			return fmt.Errorf("%s: no flag for config value %q", path, name)
+		}
+		flag.Default(values...)
+	}
+	loadedConfig = c
+	return nil
+}
+
+func runConfigValidate() {
+	path := *configValidatePath
+	if path == "" {
+		path = *configFile
+	}
+	if path == "" {
+		path = os.Getenv("TRUFFLEHOG_CONFIG")
+	}
+	if path == "" {
+		fmt.Fprintln(os.Stderr, "No config file to validate. Pass a path or set --config.")
+		os.Exit(1)
+	}
+
+	if _, err := config.Load(path); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "%s is valid.\n", path)
+}
+
+// runConfigShow prints the configuration in effect, in the config file format, so it can be saved as a starting point.
+func runConfigShow() error {
+	c := &config.Config{
+		Concurrency:       concurrency,
+		NoVerification:    noVerification,
+		VerifyConnections: verifyConns,
+		VaultAddr:         *vaultAddr,
+		ConsulAddr:        *consulAddr,
+		OnlyVerified:      onlyVerified,
+		MinSeverity:       *minSeverity,
+		Detectors:         *detectorFilter,
+		Allowlist:         *allowlistFile,
+		AllowlistTag:      allowlistTag,
+		JSON:              jsonOut,
+		Manifest:          jsonManifest,
+		Deterministic:     deterministic,
+		EgressAuditLog:    *egressAuditLog,
+		APICacheDir:       *apiCacheDir,
+		CredentialHelper:  *credentialHelper,
+		ContextLines:      contextLines,
+		ExtractStrings:    extractStrings,
+		Log: config.Log{
+			Level:  *logLevel,
+			Format: *logFormat,
+		},
+	}
+	if loadedConfig != nil {
+		c.FalsePositives = loadedConfig.FalsePositives
+		c.Sources = loadedConfig.Sources
+	}
+	c.FalsePositives.Disabled = noFPFilter
+	if *scanTimeout > 0 {
+		c.ScanTimeout = scanTimeout.String()
+	}
+	for _, module := range *logModules {
+		split := strings.SplitN(module, "=", 2)
+		if len(split) != 2 {
+			continue
+		}
+		if c.Log.Modules == nil {
+			c.Log.Modules = map[string]string{}
+		}
+		c.Log.Modules[split[0]] = split[1]
+	}
+
+	out, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(out)
+	return err
+}
+
+// runDetectorsTest runs the shipped fixtures, and those in --fixtures, against the default detectors. It returns true if
+// any fixture failed.
+func runDetectorsTest(ctx context.Context) (bool, error) {
+	sets, err := detectortest.ShippedFixtures()
+	if err != nil {
+		return false, err
+	}
+	if *detectorsTestFixtures != "" {
+		extra, err := detectortest.LoadFixtures(os.DirFS(*detectorsTestFixtures), ".")
+		if err != nil {
+			return false, err
+		}
+		sets = append(sets, extra...)
+	}
+
+	byName := map[string]detectors.Detector{}
+	for _, d := range engine.DefaultDetectors() {
+		byName[engine.DetectorName(d)] = d
+	}
+	only := map[string]bool{}
+	for _, name := range *detectorsTestNames {
+		if _, ok := byName[name]; !ok {
+			return false, fmt.Errorf("unknown detector %q", name)
+		}
+		only[name] = true
+	}
+
+	var passed, failed int
+	for _, set := range sets {
+		if len(only) > 0 && !only[set.Detector] {
+			continue
+		}
+		d, ok := byName[set.Detector]
+		if !ok {
+			return false, fmt.Errorf("fixtures for unknown detector %q", set.Detector)
+		}
+		for _, f := range set.Fixtures {
+			if err := detectortest.Run(ctx, d, f); err != nil {
+				failed++
+				fmt.Printf("FAIL %s/%s: %v\n", set.Detector, f.Name, err)
+				continue
+			}
+			passed++
+			fmt.Printf("ok   %s/%s\n", set.Detector, f.Name)
+		}
+	}
+	fmt.Printf("%d passed, %d failed\n", passed, failed)
+	return failed > 0, nil
+}
+
+// firstNonEmpty returns the first of values that isn't empty.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// runGenerateDetector scaffolds a detector package and prints the steps left to add it.
+func runGenerateDetector() error {
+	if _, ok := detectorspb.DetectorType_value[*generateName]; ok {
+		return fmt.Errorf("detector %s already exists", *generateName)
+	}
+	paths, err := scaffold.Write(*generateDetectorDir, scaffold.Options{
+		Name:      *generateName,
+		KeyRegex:  *generateKeyRegex,
+		Keywords:  *generateKeywords,
+		VerifyURL: *generateVerifyURL,
+		Example:   *generateExample,
+	})
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		fmt.Printf("wrote %s\n", path)
+	}
+
+	next := int32(0)
+	for _, n := range detectorspb.DetectorType_value {
+		if n >= next {
+			next = n + 1
+		}
+	}
+	fmt.Printf(`
+Next steps:
+  1. Add %s = %d; to the DetectorType enum in proto/detectors.proto and run make protos.
+  2. Register &%s.Scanner{} in DefaultDetectors in pkg/engine/defaults.go.
+  3. Replace the verification request with a non-destructive API call, and add the test secrets %s and %s_INACTIVE.
+`, *generateName, next, scaffold.PackageName(*generateName), strings.ToUpper(*generateName), strings.ToUpper(*generateName))
+	return nil
+}
+
+func runTUI() error {
+	file, err := os.Open(*tuiResults)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	results, err := findings.LoadFindings(file)
+	if err != nil {
+		return err
+	}
+
+	opts := []tui.Option{tui.WithBaseline(*tuiBaseline)}
+	if *tuiRepo != "" {
+		opts = append(opts, tui.WithRepo(*tuiRepo))
+	}
+	browser, err := tui.NewBrowser(results, os.Stdin, os.Stdout, opts...)
+	if err != nil {
+		return err
+	}
+	return browser.Run()
+}
+
+func runDefectDojo(ctx context.Context) error {
+	file, err := os.Open(*defectdojoResults)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	results, err := findings.LoadFindings(file)
+	if err != nil {
+		return err
+	}
+	report := defectdojo.NewReport(results)
+	if *defectdojoDryRun {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	if *defectdojoURL == "" {
+		return errors.New("--url is required unless --dry-run is set")
+	}
+	if *defectdojoToken == "" {
+		*defectdojoToken = resolveCredential(ctx, credentials.Request{
+			Source:      "defectdojo",
+			Host:        credentialHost(*defectdojoURL),
+			PasswordEnv: []string{"DEFECTDOJO_API_KEY"},
+		}).Password
+	}
+	client := &defectdojo.Client{URL: *defectdojoURL, Token: *defectdojoToken}
+	result, err := client.Import(ctx, report, defectdojo.ImportOptions{
+		Product:     *defectdojoProduct,
+		ProductType: *defectdojoProductType,
+		Engagement:  *defectdojoEngagement,
+		CloseOld:    *defectdojoCloseOld,
+		Tags:        *defectdojoTags,
+	})
+	if err != nil {
+		return err
+	}
+	logrus.WithFields(logrus.Fields{
+		"findings":   len(report.Findings),
+		"engagement": result.EngagementID,
+		"test":       result.TestID,
+	}).Info("pushed findings to DefectDojo")
+	return nil
+}
+
+func runScans(ctx context.Context) error {
+	db, err := openResultsDB(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if cmd == scansList.FullCommand() {
+		scans, err := db.Scans(ctx, *scansListLimit)
+		if err != nil {
+			return err
+		}
+		if *jsonOut {
+			return json.NewEncoder(os.Stdout).Encode(scans)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tCOMMAND\tSTARTED\tDURATION\tRESULTS")
+		for _, scan := range scans {
+			duration := "running"
+			if !scan.End.IsZero() {
+				duration = scan.End.Sub(scan.Start).Round(time.Second).String()
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n", scan.ID, scan.Command, scan.Start.Format(time.RFC3339), duration, scan.Results)
+		}
+		return w.Flush()
+	}
+
+	if _, err := db.Scan(ctx, *scansShowID); err != nil {
+		return err
+	}
+	findings, err := db.ScanFindings(ctx, *scansShowID)
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		return json.NewEncoder(os.Stdout).Encode(findings)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "FINGERPRINT\tDETECTOR\tSEVERITY\tVERIFIED\tSTATE\tFIRST SEEN\tSECRET")
+	for _, f := range findings {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\t%s\t%s\n", f.Fingerprint[:12], f.DetectorType, f.Severity, f.Verified, f.State, f.FirstSeen.Format(time.RFC3339), f.Redacted)
+	}
+	return w.Flush()
+}
+
+// runHeatmap aggregates the findings seen in the last --days days into a heatmap report.
+func runHeatmap(ctx context.Context) error {
+	if *reportHeatmapDays <= 0 {
+		return errors.New("--days must be positive")
+	}
+	db, err := openResultsDB(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	now := time.Now().UTC()
+	since := now.AddDate(0, 0, -*reportHeatmapDays)
+	sightings, err := db.Sightings(ctx, since)
+	if err != nil {
+		return err
+	}
+	report := heatmap.Build(sightings, heatmap.Options{
+		Period: heatmap.Period(*reportHeatmapPeriod),
+		Since:  since,
+		Until:  now,
+		Top:    *reportHeatmapTop,
+	}, now)
+
+	if *reportHeatmapJSON == "" && *reportHeatmapHTML == "" {
+		return report.WriteJSON(os.Stdout)
+	}
+	if *reportHeatmapJSON != "" {
+		if err := writeReportFile(*reportHeatmapJSON, report.WriteJSON); err != nil {
+			return err
+		}
+	}
+	if *reportHeatmapHTML != "" {
+		if err := writeReportFile(*reportHeatmapHTML, report.WriteHTML); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeReportFile writes a report to a file, which is renamed into place once it's complete.
+func writeReportFile(path string, write func(io.Writer) error) error {
+	file, err := output.CreateOutputFile(path, 0)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func runFindings(ctx context.Context) error {
+	db, err := openResultsDB(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	switch cmd {
+	case findingsList.FullCommand():
+		findings, err := db.Findings(ctx, store.FindingFilter{
+			State:         store.State(*findingsListState),
+			DetectorTypes: *detectorFilter,
+			Limit:         *findingsListLimit,
+		})
+		if err != nil {
+			return err
+		}
+		if *jsonOut {
+			return json.NewEncoder(os.Stdout).Encode(findings)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "FINGERPRINT\tDETECTOR\tSEVERITY\tVERIFIED\tSTATE\tLAST SEEN\tSECRET")
+		for _, f := range findings {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\t%s\t%s\n", f.Fingerprint[:12], f.DetectorType, f.Severity, f.Verified, f.State, f.LastSeen.Format(time.RFC3339), f.Redacted)
+		}
+		return w.Flush()
+
+	case findingsPurge.FullCommand():
+		if *purgeResolvedAfter <= 0 {
+			return errors.New("--purge-resolved-after is required")
+		}
+		return purgeResolved(ctx, db)
+
+	case findingsShow.FullCommand():
+		finding, err := db.Finding(ctx, *findingsShowID)
+		if err != nil {
+			return err
+		}
+		occurrences, err := db.Occurrences(ctx, finding.Fingerprint)
+		if err != nil {
+			return err
+		}
+		if *jsonOut {
+			return json.NewEncoder(os.Stdout).Encode(struct {
+				store.Finding
+				Occurrences []store.Occurrence
+			}{*finding, occurrences})
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(w, "Fingerprint:\t%s\n", finding.Fingerprint)
+		fmt.Fprintf(w, "Detector:\t%s\n", finding.DetectorType)
+		fmt.Fprintf(w, "Secret:\t%s\n", finding.Redacted)
+		fmt.Fprintf(w, "Severity:\t%s\n", finding.Severity)
+		fmt.Fprintf(w, "Verified:\t%t\n", finding.Verified)
+		fmt.Fprintf(w, "State:\t%s\n", finding.State)
+		if !finding.StateUpdatedAt.IsZero() {
+			fmt.Fprintf(w, "State changed:\t%s\n", finding.StateUpdatedAt.Format(time.RFC3339))
+		}
+		if finding.StateReason != "" {
+			fmt.Fprintf(w, "Reason:\t%s\n", finding.StateReason)
+		}
+		fmt.Fprintf(w, "First seen:\t%s in scan %s\n", finding.FirstSeen.Format(time.RFC3339), finding.FirstScan)
+		fmt.Fprintf(w, "Last seen:\t%s in scan %s\n", finding.LastSeen.Format(time.RFC3339), finding.LastScan)
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		fmt.Printf("\nFound %d times:\n", len(occurrences))
+		for _, o := range occurrences {
+			location := o.Location
+			if o.Link != "" {
+				location = o.Link
+			}
+			fmt.Printf("  %s  %s  %s\n", o.ScanID, o.SourceName, location)
+		}
+		return nil
+	}
+
+	var fingerprint, reason string
+	var state store.State
+	switch cmd {
+	case findingsResolve.FullCommand():
+		fingerprint, reason, state = *findingsResolveID, *findingsResolveReason, store.StateResolved
+	case findingsFP.FullCommand():
+		fingerprint, reason, state = *findingsFPID, *findingsFPReason, store.StateFalsePositive
+	case findingsReopen.FullCommand():
+		fingerprint, reason, state = *findingsReopenID, *findingsReopenReason, store.StateOpen
+	}
+	finding, err := db.Finding(ctx, fingerprint)
+	if err != nil {
+		return err
+	}
+	if err := db.SetState(ctx, finding.Fingerprint, state, reason, time.Now().UTC()); err != nil {
+		return err
+	}
+	logrus.WithFields(logrus.Fields{"fingerprint": finding.Fingerprint, "detector": finding.DetectorType, "state": state}).Info("changed finding state")
+	return nil
+}
+
+// loadFindings reads the results of a --json scan, keeping only the verified findings if onlyVerified is set.
+func loadFindings(path string, onlyVerified bool) ([]findings.Finding, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	results, err := findings.LoadFindings(file)
+	if err != nil || !onlyVerified {
+		return results, err
+	}
+	verified := results[:0]
+	for _, finding := range results {
+		if finding.Verified {
+			verified = append(verified, finding)
+		}
+	}
+	return verified, nil
+}
+
+func printAverageDetectorTime(e *engine.Engine) {
+	fmt.Fprintln(os.Stderr, "Average detector time is the measurement of average time spent on each detector when results are returned.")
+	for detectorName, durations := range e.DetectorAvgTime() {
+		var total time.Duration
+		for _, d := range durations {
+			total += d
 		}
 		avgDuration := total / time.Duration(len(durations))
 		fmt.Fprintf(os.Stderr, "%s: %s\n", detectorName, avgDuration)
 	}
 }
+
+func printScanSummary(summary engine.Summary) {
+	if *jsonOut {
+		out, err := json.Marshal(summary)
+		if err != nil {
+			logrus.WithError(err).Error("could not marshal scan summary")
+			return
+		}
+		fmt.Fprintln(os.Stderr, string(out))
+		return
+	}
+
+	if summary.ScanID != "" {
+		fmt.Fprintf(os.Stderr, "Scan ID: %s\n", summary.ScanID)
+	}
+	if summary.Cancelled {
+		fmt.Fprintln(os.Stderr, "The scan was cancelled before its sources finished, so results are incomplete.")
+	}
+	fmt.Fprintf(os.Stderr, "Scanned %d chunks (%d bytes) in %s.\n", summary.ChunksScanned, summary.BytesScanned, summary.Duration.Round(time.Millisecond))
+	if api := summary.API; api != nil {
+		fmt.Fprintf(os.Stderr, "Made %d source API requests, %d answered from cache. Retried %d throttled requests after waiting %s.\n",
+			api.Requests, api.NotModified, api.Retries, api.Waited.Round(time.Second))
+	}
+
+	names := make([]string, 0, len(summary.Detectors))
+	for name := range summary.Detectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	w := tabwriter.NewWriter(os.Stderr, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "DETECTOR\tCHUNKS\tDURATION\tRESULTS\tVERIFIED")
+	for _, name := range names {
+		stats := summary.Detectors[name]
+		fmt.Fprintf(w, "%s\t%d\t%s\t%d\t%d\n", name, stats.Calls, stats.Duration.Round(time.Microsecond), stats.Results, stats.Verified)
+	}
+	w.Flush()
+
+	if len(summary.Suppressed) > 0 {
+		reasons := make([]string, 0, len(summary.Suppressed))
+		var total uint64
+		for reason, count := range summary.Suppressed {
+			reasons = append(reasons, reason)
+			total += count
+		}
+		sort.Strings(reasons)
+		fmt.Fprintf(os.Stderr, "Suppressed %d results:\n", total)
+		for _, reason := range reasons {
+			fmt.Fprintf(os.Stderr, "  %s: %d\n", reason, summary.Suppressed[reason])
+		}
+	}
+
+	if len(summary.SkippedFiles) > 0 {
+		reasons := map[string]int{}
+		for _, skipped := range summary.SkippedFiles {
+			reasons[skipped.Reason]++
+			logrus.WithField("reason", skipped.Reason).Debugf("skipped %s", skipped.Name)
+		}
+		sorted := make([]string, 0, len(reasons))
+		for reason := range reasons {
+			sorted = append(sorted, reason)
+		}
+		sort.Strings(sorted)
+		fmt.Fprintf(os.Stderr, "Skipped %d files:\n", len(summary.SkippedFiles))
+		for _, reason := range sorted {
+			fmt.Fprintf(os.Stderr, "  %s: %d\n", reason, reasons[reason])
+		}
+	}
+
+	if len(summary.SkippedRepos) > 0 {
+		fmt.Fprintf(os.Stderr, "Skipped %d repos:\n", len(summary.SkippedRepos))
+		for _, skipped := range summary.SkippedRepos {
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", skipped.Name, skipped.Reason)
+		}
+	}
+}
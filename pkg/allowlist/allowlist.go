@@ -0,0 +1,94 @@
+// Package allowlist matches results against SHA-256 hashes of accepted secrets, such as test keys or values that were
+// rotated but remain in history, so the secrets themselves never need to be written down.
+package allowlist
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Allowlist is a set of secret hashes.
+type Allowlist struct {
+	hashes map[string]struct{}
+}
+
+// Hash returns the hex encoded SHA-256 of a raw secret, as used in allowlist files. It matches the output of
+// `printf %s "$SECRET" | sha256sum`.
+func Hash(raw []byte) string {
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// New returns an allowlist of the provided hashes.
+func New(hashes ...string) *Allowlist {
+	a := &Allowlist{hashes: make(map[string]struct{}, len(hashes))}
+	for _, h := range hashes {
+		a.Add(h)
+	}
+	return a
+}
+
+// Load reads an allowlist file. A missing file is treated as empty so that a file can be configured before anything
+// has been accepted.
+func Load(path string) (*Allowlist, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	a, err := Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return a, nil
+}
+
+// Parse reads an allowlist with one hash per line. Anything after a # is a comment, and blank lines are ignored.
+func Parse(r io.Reader) (*Allowlist, error) {
+	a := New()
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if b, err := hex.DecodeString(line); err != nil || len(b) != sha256.Size {
+			return nil, fmt.Errorf("line %d: expected a hex encoded SHA-256 hash", n)
+		}
+		a.Add(line)
+	}
+	return a, scanner.Err()
+}
+
+// Add adds a hex encoded hash to the allowlist.
+func (a *Allowlist) Add(hash string) {
+	a.hashes[strings.ToLower(hash)] = struct{}{}
+}
+
+// Contains returns true if the hash of the raw secret is in the allowlist.
+func (a *Allowlist) Contains(raw []byte) bool {
+	return a.ContainsHash(Hash(raw))
+}
+
+// ContainsHash returns true if the hex encoded hash is in the allowlist.
+func (a *Allowlist) ContainsHash(hash string) bool {
+	_, ok := a.hashes[strings.ToLower(hash)]
+	return ok
+}
+
+// Len returns the number of hashes in the allowlist.
+func (a *Allowlist) Len() int {
+	return len(a.hashes)
+}
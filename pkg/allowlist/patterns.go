@@ -0,0 +1,49 @@
+package allowlist
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Patterns matches secrets against regular expressions, such as an organization's placeholder conventions like
+// EXAMPLE.* or 00000000.*. Unlike hashes, they accept values that were never seen.
+type Patterns struct {
+	patterns []string
+	res      []*regexp.Regexp
+}
+
+// NewPatterns compiles regular expressions that must match the whole secret, so EXAMPLE.* accepts EXAMPLEKEY but not
+// AKIAEXAMPLEKEY. Use .*EXAMPLE.* to accept secrets containing a value.
+func NewPatterns(patterns []string) (*Patterns, error) {
+	p := &Patterns{}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(`^(?:` + pattern + `)$`)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowlist pattern %q: %w", pattern, err)
+		}
+		p.patterns = append(p.patterns, pattern)
+		p.res = append(p.res, re)
+	}
+	return p, nil
+}
+
+// Match returns the first pattern matching the raw secret.
+func (p *Patterns) Match(raw []byte) (string, bool) {
+	if p == nil {
+		return "", false
+	}
+	for i, re := range p.res {
+		if re.Match(raw) {
+			return p.patterns[i], true
+		}
+	}
+	return "", false
+}
+
+// Len returns the number of patterns.
+func (p *Patterns) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.res)
+}
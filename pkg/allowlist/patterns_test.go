@@ -0,0 +1,36 @@
+package allowlist
+
+import "testing"
+
+func TestPatterns(t *testing.T) {
+	p, err := NewPatterns([]string{"EXAMPLE.*", "0{8}.*", "sk_test_[a-z0-9]+"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]struct {
+		raw  string
+		want string
+	}{
+		"placeholder":    {raw: "EXAMPLEKEY123", want: "EXAMPLE.*"},
+		"zeroes":         {raw: "00000000-0000-0000-0000-000000000000", want: "0{8}.*"},
+		"test key":       {raw: "sk_test_abc123", want: "sk_test_[a-z0-9]+"},
+		"contains":       {raw: "AKIAEXAMPLEKEY"},
+		"trailing text":  {raw: "sk_test_abc123-prod"},
+		"too few zeroes": {raw: "0000000"},
+		"unrelated":      {raw: "hunter2"},
+	}
+	for name, test := range tests {
+		got, ok := p.Match([]byte(test.raw))
+		if ok != (test.want != "") || got != test.want {
+			t.Errorf("%s: unexpected match. Got: %q, %t, Expected: %q", name, got, ok, test.want)
+		}
+	}
+
+	if _, err := NewPatterns([]string{"EXAMPLE("}); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+	var none *Patterns
+	if _, ok := none.Match([]byte("EXAMPLEKEY")); ok {
+		t.Error("unexpected match from nil patterns")
+	}
+}
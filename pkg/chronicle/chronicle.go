@@ -0,0 +1,240 @@
+// Package chronicle sends the findings of a scan to Google Chronicle as Unified Data Model (UDM) events, using the
+// Chronicle Ingestion API. Importing it registers the "chronicle" output sink.
+package chronicle
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/allowlist"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+)
+
+const (
+	// scope is the OAuth scope of the Ingestion API.
+	scope = "https://www.googleapis.com/auth/malachite-ingestion"
+	// maxBatchSize is the number of events sent per request, which keeps requests well under the API's 1MB limit.
+	maxBatchSize = 100
+)
+
+// Endpoint returns the Ingestion API endpoint of a Chronicle region, such as "us", "europe", or "asia-southeast1".
+func Endpoint(region string) string {
+	if region == "" || region == "us" {
+		return "https://malachiteingestion-pa.googleapis.com"
+	}
+	return fmt.Sprintf("https://%s-malachiteingestion-pa.googleapis.com", region)
+}
+
+// Client sends UDM events to a Chronicle instance.
+type Client struct {
+	// Endpoint is the Ingestion API endpoint, see Endpoint.
+	Endpoint string
+	// CustomerID is the UUID of the Chronicle instance.
+	CustomerID string
+	// BatchSize is the number of events sent per request, at most 100.
+	BatchSize int
+
+	client *http.Client
+}
+
+// New returns a Client that authenticates with the service account key in credentialsFile, or with the application
+// default credentials if it's empty.
+func New(ctx context.Context, region, customerID, credentialsFile string) (*Client, error) {
+	var creds *google.Credentials
+	var err error
+	if credentialsFile != "" {
+		var data []byte
+		data, err = os.ReadFile(credentialsFile)
+		if err != nil {
+			return nil, err
+		}
+		creds, err = google.CredentialsFromJSON(ctx, data, scope)
+	} else {
+		creds, err = google.FindDefaultCredentials(ctx, scope)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not load Google credentials: %w", err)
+	}
+	return NewClient(Endpoint(region), customerID, oauth2.NewClient(ctx, creds.TokenSource)), nil
+}
+
+// NewClient returns a Client that sends requests with the given HTTP client, which must authenticate them.
+func NewClient(endpoint, customerID string, client *http.Client) *Client {
+	return &Client{Endpoint: endpoint, CustomerID: customerID, BatchSize: maxBatchSize, client: client}
+}
+
+// Send sends an event for each finding in batches, returning the number sent.
+func (c *Client) Send(ctx context.Context, results []findings.Finding) (int, error) {
+	now := time.Now().UTC()
+	batchSize := c.BatchSize
+	if batchSize <= 0 || batchSize > maxBatchSize {
+		batchSize = maxBatchSize
+	}
+
+	sent := 0
+	for start := 0; start < len(results); start += batchSize {
+		end := start + batchSize
+		if end > len(results) {
+			end = len(results)
+		}
+		events := make([]Event, 0, end-start)
+		for i := start; i < end; i++ {
+			events = append(events, NewEvent(&results[i], now))
+		}
+		if err := c.batchCreate(ctx, events); err != nil {
+			return sent, err
+		}
+		sent += len(events)
+	}
+	return sent, nil
+}
+
+func (c *Client) batchCreate(ctx context.Context, events []Event) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"customer_id": c.CustomerID,
+		"events":      events,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(c.Endpoint, "/")+"/v2/udmevents:batchCreate", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("unexpected status %d: %s", res.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	return nil
+}
+
+// Event is a UDM event.
+type Event struct {
+	Metadata        Metadata         `json:"metadata"`
+	Target          *Target          `json:"target,omitempty"`
+	SecurityResults []SecurityResult `json:"security_result"`
+}
+
+// Metadata is the metadata of a UDM event.
+type Metadata struct {
+	EventTimestamp string `json:"event_timestamp"`
+	EventType      string `json:"event_type"`
+	ProductName    string `json:"product_name"`
+	VendorName     string `json:"vendor_name"`
+	ProductLogID   string `json:"product_log_id"`
+	Description    string `json:"description,omitempty"`
+}
+
+// Target is the UDM noun for where a finding is.
+type Target struct {
+	URL  string `json:"url,omitempty"`
+	File *File  `json:"file,omitempty"`
+}
+
+// File is a UDM file.
+type File struct {
+	FullPath string `json:"full_path"`
+}
+
+// SecurityResult is the UDM description of a finding.
+type SecurityResult struct {
+	RuleName        string   `json:"rule_name"`
+	Summary         string   `json:"summary"`
+	Description     string   `json:"description,omitempty"`
+	Severity        string   `json:"severity"`
+	CategoryDetails []string `json:"category_details"`
+	// DetectionFields are the finding's other attributes.
+	DetectionFields []Label `json:"detection_fields"`
+}
+
+// Label is a UDM key and value.
+type Label struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// NewEvent converts a finding to a UDM event. The raw secret is never included.
+func NewEvent(f *findings.Finding, now time.Time) Event {
+	location := f.Location()
+	event := Event{
+		Metadata: Metadata{
+			EventTimestamp: now.Format(time.RFC3339),
+			EventType:      "GENERIC_EVENT",
+			ProductName:    "TruffleHog",
+			VendorName:     "Truffle Security",
+			ProductLogID:   allowlist.Hash([]byte(f.DetectorType + "\x00" + f.Raw + "\x00" + location)),
+			Description:    fmt.Sprintf("Leaked %s secret found in %s", f.DetectorType, f.SourceName),
+		},
+	}
+
+	var target Target
+	if link, ok := f.Metadata["link"].(string); ok && link != "" {
+		target.URL = link
+	}
+	if file, ok := f.Metadata["file"].(string); ok && file != "" {
+		target.File = &File{FullPath: file}
+	}
+	if target != (Target{}) {
+		event.Target = &target
+	}
+
+	result := SecurityResult{
+		RuleName:        f.DetectorType,
+		Summary:         fmt.Sprintf("Leaked %s secret", f.DetectorType),
+		Severity:        severity(f.Severity),
+		CategoryDetails: []string{"Leaked credential"},
+		DetectionFields: []Label{
+			{Key: "verified", Value: fmt.Sprint(f.Verified)},
+			{Key: "source", Value: f.SourceName},
+			{Key: "fingerprint", Value: f.Fingerprint()},
+		},
+	}
+	if location != "" {
+		result.DetectionFields = append(result.DetectionFields, Label{Key: "location", Value: location})
+	}
+	if f.Redacted != "" {
+		result.DetectionFields = append(result.DetectionFields, Label{Key: "redacted", Value: f.Redacted})
+	}
+	if dt, err := detectors.ParseDetectorType(f.DetectorType); err == nil {
+		if remediation := detectors.RemediationFor(dt); remediation != nil {
+			result.Description = remediation.Guidance
+		}
+	}
+	event.SecurityResults = []SecurityResult{result}
+	return event
+}
+
+// severity returns the UDM severity of a severity.
+func severity(s detectors.Severity) string {
+	switch s {
+	case detectors.SeverityCritical:
+		return "CRITICAL"
+	case detectors.SeverityHigh:
+		return "HIGH"
+	case detectors.SeverityMedium:
+		return "MEDIUM"
+	case detectors.SeverityLow:
+		return "LOW"
+	default:
+		return "UNKNOWN_SEVERITY"
+	}
+}
@@ -0,0 +1,108 @@
+package chronicle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+)
+
+func testFindings(n int) []findings.Finding {
+	results := make([]findings.Finding, n)
+	for i := range results {
+		results[i] = findings.Finding{
+			DetectorType: "AWS",
+			Verified:     i == 0,
+			Raw:          fmt.Sprintf("AKIAEXAMPLE%d", i),
+			Redacted:     "AKIAEXAMPLE",
+			Severity:     detectors.SeverityHigh,
+			SourceName:   "trufflehog - github",
+			Metadata: map[string]interface{}{
+				"file": "deploy.sh",
+				"line": float64(i + 1),
+				"link": fmt.Sprintf("https://github.com/org/repo/blob/abc/deploy.sh#L%d", i+1),
+			},
+		}
+	}
+	return results
+}
+
+func TestEndpoint(t *testing.T) {
+	if got := Endpoint("us"); got != "https://malachiteingestion-pa.googleapis.com" {
+		t.Errorf("unexpected US endpoint: %s", got)
+	}
+	if got := Endpoint("europe"); got != "https://europe-malachiteingestion-pa.googleapis.com" {
+		t.Errorf("unexpected Europe endpoint: %s", got)
+	}
+}
+
+func TestNewEvent(t *testing.T) {
+	findings := testFindings(1)
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	event := NewEvent(&findings[0], now)
+
+	if event.Metadata.EventTimestamp != "2024-01-02T03:04:05Z" || event.Metadata.EventType != "GENERIC_EVENT" {
+		t.Errorf("unexpected metadata: %+v", event.Metadata)
+	}
+	if event.Target == nil || event.Target.File.FullPath != "deploy.sh" || event.Target.URL == "" {
+		t.Errorf("unexpected target: %+v", event.Target)
+	}
+	result := event.SecurityResults[0]
+	if result.Severity != "HIGH" || result.RuleName != "AWS" || result.Description == "" {
+		t.Errorf("unexpected security result: %+v", result)
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), findings[0].Raw) {
+		t.Error("the event includes the raw secret")
+	}
+	if again := NewEvent(&findings[0], now.Add(time.Hour)); again.Metadata.ProductLogID != event.Metadata.ProductLogID {
+		t.Error("the log ID of the same finding changed")
+	}
+}
+
+func TestSend(t *testing.T) {
+	var batches [][]Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/udmevents:batchCreate" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body struct {
+			CustomerID string  `json:"customer_id"`
+			Events     []Event `json:"events"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.CustomerID != "customer" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		batches = append(batches, body.Events)
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "customer", server.Client())
+	client.BatchSize = 2
+	sent, err := client.Send(context.Background(), testFindings(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent != 5 || len(batches) != 3 || len(batches[2]) != 1 {
+		t.Errorf("got %d sent in %d batches, want 5 in 3", sent, len(batches))
+	}
+
+	client = NewClient(server.URL, "other", server.Client())
+	if _, err := client.Send(context.Background(), testFindings(1)); err == nil {
+		t.Error("expected an error for a rejected request")
+	}
+}
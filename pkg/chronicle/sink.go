@@ -0,0 +1,42 @@
+package chronicle
+
+import (
+	"context"
+	"errors"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/output"
+)
+
+func init() {
+	output.RegisterSink("chronicle", newSink)
+}
+
+// newSink sends events to the Chronicle instance of the customer-id option, in the region option, authenticating
+// with the service account key in the credentials option or the application default credentials.
+func newSink(options map[string]string) (output.Sink, error) {
+	if err := output.CheckSinkOptions(options, "customer-id", "region", "credentials"); err != nil {
+		return nil, err
+	}
+	if options["customer-id"] == "" {
+		return nil, errors.New("the customer-id option is required")
+	}
+	client, err := New(context.Background(), options["region"], options["customer-id"], options["credentials"])
+	if err != nil {
+		return nil, err
+	}
+	return NewSink(client), nil
+}
+
+// NewSink returns a sink that sends the findings of a scan with client, a batch at a time.
+func NewSink(client *Client) *output.FindingsSink {
+	return &output.FindingsSink{
+		BatchSize: client.BatchSize,
+		Send: func(ctx context.Context, batch []findings.Finding) error {
+			sent, err := client.Send(ctx, batch)
+			logging.FromContext(ctx).WithField("findings", sent).Debug("sent findings to Chronicle")
+			return err
+		},
+	}
+}
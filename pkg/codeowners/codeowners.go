@@ -0,0 +1,188 @@
+// Package codeowners parses CODEOWNERS files in the GitHub and GitLab formats, so that findings can be attributed to
+// the team that owns the file they were found in.
+package codeowners
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Paths are the locations of a CODEOWNERS file in a repository, in the order GitHub and GitLab look for them. Only the
+// first that exists is used.
+var Paths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}
+
+// File is a parsed CODEOWNERS file.
+type File struct {
+	sections []*section
+
+	mu sync.Mutex
+	// owners caches the owners of each path looked up.
+	owners map[string][]string
+}
+
+// section is a GitLab section, or the whole file for GitHub, which has none. The last rule of a section that matches
+// a path decides its owners in that section.
+type section struct {
+	name          string
+	defaultOwners []string
+	rules         []rule
+}
+
+type rule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// sectionHeader matches GitLab section headers such as "[Docs]", "^[Optional]", and "[Backend][2] @backend-team".
+var sectionHeader = regexp.MustCompile(`^\^?\[([^\]]+)\](?:\[\d+\])?(.*)$`)
+
+// Parse parses a CODEOWNERS file. Lines that can't be parsed are skipped, like GitHub and GitLab do.
+func Parse(data []byte) *File {
+	f := &File{owners: map[string][]string{}}
+	current := &section{}
+	f.sections = append(f.sections, current)
+	sections := map[string]*section{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if match := sectionHeader.FindStringSubmatch(line); match != nil {
+			// Sections with the same name are combined, ignoring case.
+			name := strings.ToLower(strings.TrimSpace(match[1]))
+			if existing, ok := sections[name]; ok {
+				current = existing
+			} else {
+				current = &section{name: name}
+				sections[name] = current
+				f.sections = append(f.sections, current)
+			}
+			if owners := strings.Fields(match[2]); len(owners) > 0 {
+				current.defaultOwners = owners
+			}
+			continue
+		}
+
+		fields := splitFields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		pattern, err := compile(fields[0])
+		if err != nil {
+			continue
+		}
+		owners := fields[1:]
+		if len(owners) == 0 {
+			owners = current.defaultOwners
+		}
+		current.rules = append(current.rules, rule{pattern: pattern, owners: owners})
+	}
+	return f
+}
+
+// Owners returns the owners of the file at path, relative to the root of the repository. They're the owners of the
+// last matching rule of each section, in the order of the file. It returns nil if the file has no owners.
+func (f *File) Owners(path string) []string {
+	if f == nil {
+		return nil
+	}
+	path = strings.TrimPrefix(path, "/")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if owners, ok := f.owners[path]; ok {
+		return owners
+	}
+
+	var owners []string
+	seen := map[string]struct{}{}
+	for _, s := range f.sections {
+		var matched []string
+		for _, r := range s.rules {
+			if r.pattern.MatchString(path) {
+				matched = r.owners
+			}
+		}
+		for _, owner := range matched {
+			if _, ok := seen[owner]; !ok {
+				seen[owner] = struct{}{}
+				owners = append(owners, owner)
+			}
+		}
+	}
+	f.owners[path] = owners
+	return owners
+}
+
+// splitFields splits a line on whitespace that isn't escaped with a backslash, so that patterns can contain spaces.
+func splitFields(line string) []string {
+	var fields []string
+	var field strings.Builder
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			field.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ' ' || r == '\t':
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+		default:
+			field.WriteRune(r)
+		}
+	}
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
+
+// compile turns a pattern into a regular expression over paths. Patterns follow gitignore: a pattern with a leading or
+// inner slash is relative to the root, and any other matches at any depth. A pattern matches everything in the
+// directories it matches, except that "dir/*" only matches the files directly in dir.
+func compile(pattern string) (*regexp.Regexp, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var expr strings.Builder
+	if anchored {
+		expr.WriteString("^")
+	} else {
+		expr.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	switch {
+	case dirOnly:
+		expr.WriteString("/.*$")
+	case strings.HasSuffix(pattern, "/*"):
+		expr.WriteString("$")
+	default:
+		expr.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(expr.String())
+}
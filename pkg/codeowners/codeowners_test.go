@@ -0,0 +1,63 @@
+package codeowners
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOwners(t *testing.T) {
+	github := Parse([]byte(`# Default owners
+*                 @acme/platform
+*.js              @acme/frontend
+/docs/            @acme/docs
+apps/             @octocat
+/build/logs/*     @acme/ci
+**/secrets        @acme/security
+config/prod\ keys @acme/sre
+/vendor/
+`))
+	gitlab := Parse([]byte(`* @acme/platform
+
+[Backend][2] @acme/backend
+internal/
+*.sql @dba@example.com
+
+^[Docs]
+docs/ @acme/docs
+
+[backend]
+internal/billing/ @acme/payments
+`))
+
+	tests := []struct {
+		file *File
+		path string
+		want string
+	}{
+		{github, "main.go", "@acme/platform"},
+		{github, "web/app.js", "@acme/frontend"},
+		{github, "docs/setup.md", "@acme/docs"},
+		{github, "src/docs/setup.md", "@acme/platform"},
+		{github, "services/apps/api/.env", "@octocat"},
+		{github, "build/logs/out.txt", "@acme/ci"},
+		{github, "build/logs/2022/out.txt", "@acme/platform"},
+		{github, "deploy/secrets/prod.env", "@acme/security"},
+		{github, "config/prod keys/aws.txt", "@acme/sre"},
+		{github, "vendor/lib/key.pem", ""},
+		{gitlab, "main.go", "@acme/platform"},
+		{gitlab, "internal/api/server.go", "@acme/platform @acme/backend"},
+		{gitlab, "internal/billing/stripe.go", "@acme/platform @acme/payments"},
+		{gitlab, "db/schema.sql", "@acme/platform @dba@example.com"},
+		{gitlab, "docs/setup.md", "@acme/platform @acme/docs"},
+	}
+	for _, test := range tests {
+		if got := strings.Join(test.file.Owners(test.path), " "); got != test.want {
+			t.Errorf("%s: got owners %q, expected %q", test.path, got, test.want)
+		}
+	}
+
+	var missing *File
+	if owners := missing.Owners("main.go"); owners != nil {
+		t.Errorf("expected no owners without a CODEOWNERS file, got %v", owners)
+	}
+}
@@ -0,0 +1,408 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultAPIMaxPerHost is the number of concurrent requests an APITransport sends to a host.
+	DefaultAPIMaxPerHost = 8
+	// DefaultAPIMaxRetries is the number of times an APITransport retries a throttled request.
+	DefaultAPIMaxRetries = 5
+	// DefaultAPIMaxWait is the longest an APITransport waits before retrying a throttled request. Longer waits are
+	// returned as the throttled response.
+	DefaultAPIMaxWait = 5 * time.Minute
+
+	// maxCachedBody keeps large responses, which are rarely unchanged, out of the cache.
+	maxCachedBody = 10 << 20
+)
+
+// APIStats counts the requests of an APITransport.
+type APIStats struct {
+	Requests uint64
+	// NotModified is the number of requests answered from the cache after a conditional request.
+	NotModified uint64
+	// Retries is the number of throttled requests that were retried.
+	Retries uint64
+	// Waited is the total time spent waiting to retry.
+	Waited time.Duration
+}
+
+// APITransport is a RoundTripper for the APIs that sources enumerate, such as GitHub's and GitLab's. It limits the
+// concurrent requests to each host, waits and retries when a request is throttled with Retry-After or an exhausted
+// rate limit, and makes GET requests conditional on the ETag or Last-Modified of the previous response, so that
+// unchanged listings are answered from its cache.
+type APITransport struct {
+	T http.RoundTripper
+	// MaxPerHost limits the concurrent requests to each host.
+	MaxPerHost int
+	// MaxRetries limits the retries of a throttled request.
+	MaxRetries int
+	// MaxWait is the longest wait before a retry.
+	MaxWait time.Duration
+
+	cache *apiCache
+
+	hostsMu sync.Mutex
+	hosts   map[string]chan struct{}
+
+	requests, notModified, retries, waited uint64
+
+	// sleep waits for d or until ctx is done. It's replaced in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewAPITransport returns an APITransport that sends requests with t, or a default transport if t is nil, and caches
+// responses in memory.
+func NewAPITransport(t http.RoundTripper) *APITransport {
+	if t == nil {
+		t = &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: 60 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		}
+	}
+	return &APITransport{
+		T:          t,
+		MaxPerHost: DefaultAPIMaxPerHost,
+		MaxRetries: DefaultAPIMaxRetries,
+		MaxWait:    DefaultAPIMaxWait,
+		cache:      &apiCache{entries: map[string]*cachedResponse{}},
+		hosts:      map[string]chan struct{}{},
+		sleep:      sleepContext,
+	}
+}
+
+// SetCacheDir persists cached responses in dir, so that they're reused by later scans. An empty dir only caches in
+// memory.
+func (t *APITransport) SetCacheDir(dir string) error {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return err
+		}
+	}
+	t.cache.setDir(dir)
+	return nil
+}
+
+// Stats returns the counts of the requests made so far.
+func (t *APITransport) Stats() APIStats {
+	return APIStats{
+		Requests:    atomic.LoadUint64(&t.requests),
+		NotModified: atomic.LoadUint64(&t.notModified),
+		Retries:     atomic.LoadUint64(&t.retries),
+		Waited:      time.Duration(atomic.LoadUint64(&t.waited)),
+	}
+}
+
+func (t *APITransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := t.acquire(req)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var key string
+	var cached *cachedResponse
+	if req.Method == http.MethodGet && req.Header.Get("Range") == "" {
+		key = cacheKey(req)
+		if cached = t.cache.get(key); cached != nil {
+			req = req.Clone(req.Context())
+			if etag := cached.Header.Get("ETag"); etag != "" {
+				req.Header.Set("If-None-Match", etag)
+			}
+			if modified := cached.Header.Get("Last-Modified"); modified != "" {
+				req.Header.Set("If-Modified-Since", modified)
+			}
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		atomic.AddUint64(&t.requests, 1)
+		res, err := t.T.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode == http.StatusNotModified && cached != nil {
+			atomic.AddUint64(&t.notModified, 1)
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+			return cached.response(req, res.Header), nil
+		}
+
+		wait, throttled := retryAfter(res, time.Now())
+		if throttled && attempt < t.MaxRetries && wait <= t.MaxWait && replayable(req) {
+			_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 4096))
+			res.Body.Close()
+			atomic.AddUint64(&t.retries, 1)
+			atomic.AddUint64(&t.waited, uint64(wait))
+			if err := t.sleep(req.Context(), wait); err != nil {
+				return nil, err
+			}
+			if req.GetBody != nil {
+				if req.Body, err = req.GetBody(); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		if key != "" && res.StatusCode == http.StatusOK && (res.Header.Get("ETag") != "" || res.Header.Get("Last-Modified") != "") {
+			res.Body = t.cache.tee(key, res)
+		}
+		return res, nil
+	}
+}
+
+// acquire waits for a slot for the request's host, returning a function that releases it.
+func (t *APITransport) acquire(req *http.Request) (func(), error) {
+	if t.MaxPerHost <= 0 {
+		return func() {}, nil
+	}
+	t.hostsMu.Lock()
+	slots, ok := t.hosts[req.URL.Host]
+	if !ok {
+		slots = make(chan struct{}, t.MaxPerHost)
+		t.hosts[req.URL.Host] = slots
+	}
+	t.hostsMu.Unlock()
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+}
+
+// replayable reports whether a request can be sent again.
+func replayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// retryAfter reports whether a response was throttled, and how long to wait before retrying it. Throttling is a 429
+// or 503 with Retry-After, or a 403 or 429 that exhausted a GitHub or GitLab style rate limit.
+func retryAfter(res *http.Response, now time.Time) (time.Duration, bool) {
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusForbidden:
+	default:
+		return 0, false
+	}
+	if value := res.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			return nonNegative(at.Sub(now)), true
+		}
+	}
+	if res.StatusCode == http.StatusServiceUnavailable {
+		return 0, false
+	}
+	remaining := res.Header.Get("X-RateLimit-Remaining")
+	if remaining == "" {
+		remaining = res.Header.Get("RateLimit-Remaining")
+	}
+	if remaining != "0" {
+		return 0, false
+	}
+	reset := res.Header.Get("X-RateLimit-Reset")
+	if reset == "" {
+		reset = res.Header.Get("RateLimit-Reset")
+	}
+	if epoch, err := strconv.ParseInt(reset, 10, 64); err == nil {
+		return nonNegative(time.Unix(epoch, 0).Sub(now)), true
+	}
+	return 0, false
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// cacheKey identifies a request by its URL and credentials, so that responses are never shared between tokens that
+// may see different data.
+func cacheKey(req *http.Request) string {
+	h := sha256.New()
+	h.Write([]byte(req.URL.String()))
+	for _, name := range []string{"Authorization", "Private-Token", "Accept"} {
+		h.Write([]byte{0})
+		h.Write([]byte(req.Header.Get(name)))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cachedResponse is a response saved for conditional requests.
+type cachedResponse struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// response returns the cached response for req, with the headers of the 304 that confirmed it, such as the current
+// rate limit, taking precedence.
+func (c *cachedResponse) response(req *http.Request, fresh http.Header) *http.Response {
+	header := c.Header.Clone()
+	for name, values := range fresh {
+		header[name] = values
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(c.Status) + " " + http.StatusText(c.Status),
+		StatusCode:    c.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       req,
+	}
+}
+
+// apiCache holds responses in memory, and in a directory if one is set.
+type apiCache struct {
+	mu      sync.Mutex
+	dir     string
+	entries map[string]*cachedResponse
+}
+
+func (c *apiCache) setDir(dir string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dir = dir
+}
+
+func (c *apiCache) get(key string) *cachedResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[key]; ok {
+		return entry
+	}
+	if c.dir == "" {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(c.dir, key))
+	if err != nil {
+		return nil
+	}
+	var entry cachedResponse
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil
+	}
+	c.entries[key] = &entry
+	return &entry
+}
+
+func (c *apiCache) put(key string, entry *cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = entry
+	if c.dir == "" {
+		return
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	// Write errors are ignored, a missing entry only costs a full request next time.
+	tmp := filepath.Join(c.dir, key+".tmp")
+	if err := os.WriteFile(tmp, data, 0600); err == nil {
+		_ = os.Rename(tmp, filepath.Join(c.dir, key))
+	}
+}
+
+// tee returns a body that reads res.Body and caches the response once it has been read completely.
+func (c *apiCache) tee(key string, res *http.Response) io.ReadCloser {
+	return &cachingBody{
+		body:  res.Body,
+		cache: c,
+		key:   key,
+		entry: &cachedResponse{Status: res.StatusCode, Header: res.Header.Clone()},
+	}
+}
+
+type cachingBody struct {
+	body  io.ReadCloser
+	cache *apiCache
+	key   string
+	entry *cachedResponse
+	buf   bytes.Buffer
+	// skip is set once the body is too large to cache.
+	skip bool
+}
+
+func (b *cachingBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	if !b.skip {
+		if b.buf.Len()+n > maxCachedBody {
+			b.skip = true
+			b.buf = bytes.Buffer{}
+		} else {
+			b.buf.Write(p[:n])
+		}
+	}
+	if err == io.EOF && !b.skip {
+		b.entry.Body = b.buf.Bytes()
+		b.cache.put(b.key, b.entry)
+		b.skip = true
+	}
+	return n, err
+}
+
+func (b *cachingBody) Close() error {
+	return b.body.Close()
+}
+
+var sharedAPITransport = NewAPITransport(nil)
+
+// APIHttpClient returns a client for source API requests. All the clients share one APITransport, so the per-host
+// limits apply across sources.
+func APIHttpClient() *http.Client {
+	return &http.Client{Transport: NewCustomTransport(sharedAPITransport)}
+}
+
+// SetAPICacheDir persists the responses cached by APIHttpClient clients in dir, see APITransport.SetCacheDir.
+func SetAPICacheDir(dir string) error {
+	return sharedAPITransport.SetCacheDir(dir)
+}
+
+// SourceAPIStats returns the counts of the requests made by APIHttpClient clients.
+func SourceAPIStats() APIStats {
+	return sharedAPITransport.Stats()
+}
@@ -0,0 +1,155 @@
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAPITransportConditionalRequests(t *testing.T) {
+	var full, conditional int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&conditional, 1)
+			w.Header().Set("X-RateLimit-Remaining", "4999")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(&full, 1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("X-RateLimit-Remaining", "5000")
+		_, _ = w.Write([]byte(`[{"name": "repo"}]`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	get := func(transport *APITransport, token string) (string, *http.Response) {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/orgs/acme/repos", nil)
+		req.Header.Set("Authorization", "token "+token)
+		res, err := (&http.Client{Transport: transport}).Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(body), res
+	}
+
+	transport := NewAPITransport(nil)
+	if err := transport.SetCacheDir(dir); err != nil {
+		t.Fatal(err)
+	}
+	get(transport, "a")
+	body, res := get(transport, "a")
+	if body != `[{"name": "repo"}]` || res.StatusCode != http.StatusOK || res.Header.Get("X-RateLimit-Remaining") != "4999" {
+		t.Errorf("unexpected cached response: %d %q %v", res.StatusCode, body, res.Header)
+	}
+	// Another token doesn't get the cached response.
+	get(transport, "b")
+	if full != 2 || conditional != 1 {
+		t.Errorf("got %d full and %d conditional requests, want 2 and 1", full, conditional)
+	}
+
+	// A later scan reuses the responses saved in the directory.
+	later := NewAPITransport(nil)
+	if err := later.SetCacheDir(dir); err != nil {
+		t.Fatal(err)
+	}
+	if body, _ := get(later, "a"); body != `[{"name": "repo"}]` || conditional != 2 {
+		t.Errorf("expected a conditional request answered from the saved cache, got %q", body)
+	}
+	if stats := later.Stats(); stats.Requests != 1 || stats.NotModified != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestAPITransportRetryAfter(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&attempts, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			_, _ = w.Write([]byte("ok"))
+		}
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	transport := NewAPITransport(nil)
+	transport.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	res, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK || len(waits) != 2 || waits[0] != 3*time.Second || waits[1] < 50*time.Second {
+		t.Errorf("unexpected status %d after waits %v", res.StatusCode, waits)
+	}
+	if stats := transport.Stats(); stats.Retries != 2 || stats.Requests != 3 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	// A wait longer than MaxWait returns the throttled response.
+	atomic.StoreInt32(&attempts, 0)
+	transport.MaxWait = time.Second
+	res, err = (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected the throttled response, got %d", res.StatusCode)
+	}
+}
+
+func TestAPITransportMaxPerHost(t *testing.T) {
+	var current, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&current, -1)
+	}))
+	defer server.Close()
+
+	transport := NewAPITransport(nil)
+	transport.MaxPerHost = 2
+	client := &http.Client{Transport: transport}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if res, err := client.Get(server.URL); err == nil {
+				res.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+	if peak > 2 {
+		t.Errorf("got %d concurrent requests, want at most 2", peak)
+	}
+}
@@ -1,6 +1,9 @@
 package common
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 func IsDone(ctx context.Context) bool {
 	select {
@@ -10,3 +13,33 @@ func IsDone(ctx context.Context) bool {
 		return false
 	}
 }
+
+// Sleep pauses for the duration, returning early if the context is done. It returns false if the context is done, so
+// that waits such as rate limit backoffs don't delay cancellation.
+func Sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+type scanCtxKey struct{}
+
+// WithScanContext stores the scan's context in a context derived from it, such as a detector's, which the engine
+// limits to a timeout for verification. Detectors check the scan's context between matches, so that a slow detector's
+// remaining matches are still reported when the timeout passes, but not after the scan is cancelled.
+func WithScanContext(ctx, scanCtx context.Context) context.Context {
+	return context.WithValue(ctx, scanCtxKey{}, scanCtx)
+}
+
+// ScanContext returns the scan's context stored by WithScanContext, or ctx if there is none.
+func ScanContext(ctx context.Context) context.Context {
+	if scanCtx, ok := ctx.Value(scanCtxKey{}).(context.Context); ok {
+		return scanCtx
+	}
+	return ctx
+}
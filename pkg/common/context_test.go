@@ -0,0 +1,26 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestScanContext(t *testing.T) {
+	scanCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	detectorCtx, cancelDetector := context.WithTimeout(WithScanContext(scanCtx, scanCtx), time.Millisecond)
+	defer cancelDetector()
+	<-detectorCtx.Done()
+	if IsDone(ScanContext(detectorCtx)) {
+		t.Error("expected the scan's context to outlive the detector's timeout")
+	}
+	cancel()
+	if !IsDone(ScanContext(detectorCtx)) {
+		t.Error("expected the scan's context to be done once the scan is cancelled")
+	}
+	if ScanContext(scanCtx) != scanCtx {
+		t.Error("expected a context without a scan context to be returned as is")
+	}
+}
@@ -0,0 +1,138 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// EgressRecord describes a single outbound request made while verifying a result. Only the host is recorded, never
+// the path, query, headers, or body, since any of those may contain the secret being verified. For connections opened
+// with a Dialer, Method is the network, such as "tcp", and Host is the address.
+type EgressRecord struct {
+	Time      time.Time `json:"time"`
+	Detector  string    `json:"detector"`
+	Method    string    `json:"method"`
+	Host      string    `json:"host"`
+	Status    int       `json:"status,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	LatencyMS int64     `json:"latency_ms"`
+}
+
+type detectorCtxKey struct{}
+
+// WithDetector marks requests made with the returned context as verification requests of the named detector, so they
+// are included in the egress audit log.
+func WithDetector(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, detectorCtxKey{}, name)
+}
+
+// DetectorFromContext returns the detector set by WithDetector.
+func DetectorFromContext(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(detectorCtxKey{}).(string)
+	return name, ok
+}
+
+var egressAudit struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// SetEgressAuditLog writes a JSON line for every verification request to w. A nil writer disables the audit log.
+func SetEgressAuditLog(w io.Writer) {
+	egressAudit.mu.Lock()
+	defer egressAudit.mu.Unlock()
+	if w == nil {
+		egressAudit.enc = nil
+		return
+	}
+	egressAudit.enc = json.NewEncoder(w)
+}
+
+func egressAuditEnabled() bool {
+	egressAudit.mu.Lock()
+	defer egressAudit.mu.Unlock()
+	return egressAudit.enc != nil
+}
+
+func recordEgress(record EgressRecord) {
+	egressAudit.mu.Lock()
+	defer egressAudit.mu.Unlock()
+	if egressAudit.enc == nil {
+		return
+	}
+	// Write errors are ignored, the audit log must never fail verification.
+	_ = egressAudit.enc.Encode(record)
+}
+
+// auditRoundTrip performs the request with rt, recording it in the egress audit log if the request was made by a
+// detector.
+func auditRoundTrip(rt http.RoundTripper, req *http.Request) (*http.Response, error) {
+	detector, ok := DetectorFromContext(req.Context())
+	if !ok || !egressAuditEnabled() {
+		return rt.RoundTrip(req)
+	}
+
+	start := time.Now()
+	res, err := rt.RoundTrip(req)
+	record := EgressRecord{
+		Time:      start.UTC(),
+		Detector:  detector,
+		Method:    req.Method,
+		Host:      req.URL.Host,
+		LatencyMS: time.Since(start).Milliseconds(),
+	}
+	if res != nil {
+		record.Status = res.StatusCode
+	}
+	if err != nil {
+		record.Error = egressError(err)
+	}
+	recordEgress(record)
+	return res, err
+}
+
+// Dialer opens connections for detectors that verify over protocols other than HTTP, such as database wire protocols.
+// Connections opened by a detector are recorded in the egress audit log, like the requests of this package's HTTP
+// clients.
+type Dialer struct {
+	net.Dialer
+}
+
+// DialContext connects to the address on the named network.
+func (d *Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	detector, ok := DetectorFromContext(ctx)
+	if !ok || !egressAuditEnabled() {
+		return d.Dialer.DialContext(ctx, network, address)
+	}
+
+	start := time.Now()
+	c, err := d.Dialer.DialContext(ctx, network, address)
+	record := EgressRecord{
+		Time:      start.UTC(),
+		Detector:  detector,
+		Method:    network,
+		Host:      address,
+		LatencyMS: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	recordEgress(record)
+	return c, err
+}
+
+// egressError strips the URL from request errors, since it may contain the secret.
+func egressError(err error) string {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err.Error()
+	}
+	return err.Error()
+}
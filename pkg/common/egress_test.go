@@ -0,0 +1,101 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestEgressAuditLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	SetEgressAuditLog(&buf)
+	defer SetEgressAuditLog(nil)
+
+	const secret = "sk_live_abcdef0123456789"
+	client := SaneHttpClient()
+
+	// Requests that weren't made by a detector aren't audited.
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/?key="+secret, nil)
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if buf.Len() != 0 {
+		t.Fatalf("unexpected audit record for a non-verification request: %s", buf.String())
+	}
+
+	ctx := WithDetector(context.Background(), "stripe")
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/?key="+secret, nil)
+	req.Header.Set("Authorization", "Bearer "+secret)
+	res, err = client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if strings.Contains(buf.String(), secret) {
+		t.Fatalf("audit log contains the secret: %s", buf.String())
+	}
+	var record EgressRecord
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("could not decode audit record %q: %s", buf.String(), err)
+	}
+	u, _ := url.Parse(server.URL)
+	if record.Detector != "stripe" || record.Host != u.Host || record.Method != http.MethodGet || record.Status != http.StatusUnauthorized {
+		t.Errorf("unexpected audit record: %+v", record)
+	}
+}
+
+func TestEgressError(t *testing.T) {
+	err := &url.Error{Op: "Get", URL: "https://api.example.com/?token=secret", Err: context.DeadlineExceeded}
+	if got := egressError(err); strings.Contains(got, "secret") {
+		t.Errorf("egress error contains the URL: %s", got)
+	}
+}
+
+func TestEgressAuditDialer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	var buf bytes.Buffer
+	SetEgressAuditLog(&buf)
+	defer SetEgressAuditLog(nil)
+
+	var dialer Dialer
+	c, err := dialer.DialContext(context.Background(), "tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Close()
+	if buf.Len() != 0 {
+		t.Fatalf("unexpected audit record for a connection not made by a detector: %s", buf.String())
+	}
+
+	c, err = dialer.DialContext(WithDetector(context.Background(), "connectionstring"), "tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Close()
+	var record EgressRecord
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("could not decode audit record %q: %s", buf.String(), err)
+	}
+	if record.Detector != "connectionstring" || record.Method != "tcp" || record.Host != listener.Addr().String() || record.Error != "" {
+		t.Errorf("unexpected audit record: %+v", record)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -81,7 +82,30 @@ type CustomTransport struct {
 
 func (t *CustomTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	req.Header.Add("User-Agent", "TruffleHog")
-	return t.T.RoundTrip(req)
+	rt := t.T
+	if override := transportOverride(); override != nil {
+		rt = override
+	}
+	return auditRoundTrip(rt, req)
+}
+
+var overrideTransport struct {
+	mu sync.RWMutex
+	rt http.RoundTripper
+}
+
+// OverrideTransport sends the requests of every client created by this package to rt instead of the network, so that
+// detectors can be run against mock servers. A nil RoundTripper restores the network.
+func OverrideTransport(rt http.RoundTripper) {
+	overrideTransport.mu.Lock()
+	defer overrideTransport.mu.Unlock()
+	overrideTransport.rt = rt
+}
+
+func transportOverride() http.RoundTripper {
+	overrideTransport.mu.RLock()
+	defer overrideTransport.mu.RUnlock()
+	return overrideTransport.rt
 }
 
 func NewCustomTransport(T http.RoundTripper) *CustomTransport {
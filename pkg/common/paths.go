@@ -0,0 +1,66 @@
+package common
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const (
+	// extendedPrefix marks a Windows path as extended-length, which lifts the MAX_PATH limit of 260 characters.
+	extendedPrefix = `\\?\`
+	// extendedUNCPrefix is the extended-length form of a network share's \\ prefix.
+	extendedUNCPrefix = `\\?\UNC\`
+)
+
+// LongPath returns a path that can be opened regardless of its length. On Windows, that's the absolute path in the
+// extended-length form. Elsewhere the path is returned unchanged.
+func LongPath(path string) string {
+	if runtime.GOOS != "windows" {
+		return path
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	return extendedLengthPath(abs)
+}
+
+// extendedLengthPath converts an absolute Windows path to the extended-length form.
+func extendedLengthPath(abs string) string {
+	switch {
+	case strings.HasPrefix(abs, extendedPrefix):
+		return abs
+	case strings.HasPrefix(abs, `\\`):
+		return extendedUNCPrefix + abs[2:]
+	default:
+		return extendedPrefix + abs
+	}
+}
+
+// DisplayPath returns a path as it's reported in results: without an extended-length prefix, and with forward slashes,
+// so that results, links, and allowlists are the same on every platform.
+func DisplayPath(path string) string {
+	switch {
+	case strings.HasPrefix(path, extendedUNCPrefix):
+		path = `\\` + path[len(extendedUNCPrefix):]
+	case strings.HasPrefix(path, extendedPrefix):
+		path = path[len(extendedPrefix):]
+	}
+	return filepath.ToSlash(path)
+}
+
+// FileURIPath returns the local path of a file:// URI from its host and path. Windows drive letters may be given as
+// the host (file://C:/repo) or at the start of the path (file:///C:/repo).
+func FileURIPath(host, path string) string {
+	p := host + path
+	// url.Parse leaves a slash before the drive letter of file:///C:/repo.
+	if len(p) >= 3 && p[0] == '/' && isDriveLetter(p[1]) && p[2] == ':' {
+		p = p[1:]
+	}
+	return filepath.FromSlash(p)
+}
+
+func isDriveLetter(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
@@ -0,0 +1,57 @@
+package common
+
+import "testing"
+
+func TestExtendedLengthPath(t *testing.T) {
+	tests := map[string]string{
+		`C:\Users\dev\repo`:        `\\?\C:\Users\dev\repo`,
+		`\\server\share\dir`:       `\\?\UNC\server\share\dir`,
+		`\\?\C:\already\extended`:  `\\?\C:\already\extended`,
+		`\\?\UNC\server\share\dir`: `\\?\UNC\server\share\dir`,
+	}
+	for in, want := range tests {
+		if got := extendedLengthPath(in); got != want {
+			t.Errorf("extendedLengthPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDisplayPath(t *testing.T) {
+	tests := map[string]string{
+		`\\?\C:\Users\dev\repo`: `C:\Users\dev\repo`,
+		`\\?\UNC\server\share`:  `\\server\share`,
+		"dir/file.txt":          "dir/file.txt",
+	}
+	for in, want := range tests {
+		// Backslashes are only separators on Windows, so elsewhere they're kept.
+		if got := DisplayPath(in); got != want && got != toSlashWindows(want) {
+			t.Errorf("DisplayPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func toSlashWindows(path string) string {
+	out := []byte(path)
+	for i := range out {
+		if out[i] == '\\' {
+			out[i] = '/'
+		}
+	}
+	return string(out)
+}
+
+func TestFileURIPath(t *testing.T) {
+	tests := []struct {
+		host, path, want string
+	}{
+		{"", "/tmp/repo", "/tmp/repo"},
+		{"", "/C:/src/repo", "C:/src/repo"},
+		{"C:", "/src/repo", "C:/src/repo"},
+		{"", "relative/repo", "relative/repo"},
+	}
+	for _, tt := range tests {
+		if got := toSlashWindows(FileURIPath(tt.host, tt.path)); got != tt.want {
+			t.Errorf("FileURIPath(%q, %q) = %q, want %q", tt.host, tt.path, got, tt.want)
+		}
+	}
+}
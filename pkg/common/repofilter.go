@@ -0,0 +1,105 @@
+package common
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+)
+
+// RepoFilter decides which enumerated repositories are scanned. Patterns are repository names or globs, matched
+// case insensitively. Patterns with a slash, like "org/*", match the full name. Patterns without one, like
+// "*-archive", match the last part of the name.
+type RepoFilter struct {
+	include []string
+	exclude []string
+}
+
+// NewRepoFilter returns a RepoFilter. With no include patterns, every repository not excluded passes.
+func NewRepoFilter(include, exclude []string) (*RepoFilter, error) {
+	f := &RepoFilter{}
+	for _, list := range []struct {
+		patterns []string
+		dst      *[]string
+	}{{include, &f.include}, {exclude, &f.exclude}} {
+		for _, pattern := range list.patterns {
+			pattern = strings.ToLower(strings.Trim(strings.TrimSpace(pattern), "/"))
+			if pattern == "" {
+				continue
+			}
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid repository pattern %q: %w", pattern, err)
+			}
+			*list.dst = append(*list.dst, pattern)
+		}
+	}
+	return f, nil
+}
+
+// Pass returns whether the repository should be scanned. name is the repository's full name, such as "org/repo", or
+// its clone URL.
+func (f *RepoFilter) Pass(name string) bool {
+	if f == nil {
+		return true
+	}
+	name = repoFullName(name)
+	if len(f.include) > 0 && !matchesAny(f.include, name) {
+		return false
+	}
+	return !matchesAny(f.exclude, name)
+}
+
+func matchesAny(patterns []string, name string) bool {
+	base := path.Base(name)
+	for _, pattern := range patterns {
+		target := name
+		if !strings.Contains(pattern, "/") {
+			target = base
+		}
+		if ok, _ := path.Match(pattern, target); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// repoFullName turns a clone URL into the repository's full name, e.g.
+// https://github.com/org/repo.git into org/repo.
+func repoFullName(name string) string {
+	if u, err := url.Parse(name); err == nil && u.Host != "" {
+		name = u.Path
+	}
+	return strings.ToLower(strings.TrimSuffix(strings.Trim(name, "/"), ".git"))
+}
+
+// ExpandRepoPatterns returns the patterns, replacing each "@path" with the patterns listed in that file, one per
+// line. Blank lines and lines starting with # are ignored.
+func ExpandRepoPatterns(values []string) ([]string, error) {
+	var patterns []string
+	for _, value := range values {
+		if !strings.HasPrefix(value, "@") {
+			patterns = append(patterns, value)
+			continue
+		}
+		file, err := os.Open(strings.TrimPrefix(value, "@"))
+		if err != nil {
+			return nil, fmt.Errorf("could not open repository list: %w", err)
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			patterns = append(patterns, line)
+		}
+		err = scanner.Err()
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("could not read repository list: %w", err)
+		}
+	}
+	return patterns, nil
+}
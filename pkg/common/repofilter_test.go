@@ -0,0 +1,64 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRepoFilter(t *testing.T) {
+	tests := map[string]struct {
+		include, exclude []string
+		repo             string
+		pass             bool
+	}{
+		"no patterns":             {repo: "acme/api", pass: true},
+		"included by full name":   {include: []string{"acme/api"}, repo: "https://github.com/acme/api.git", pass: true},
+		"not included":            {include: []string{"acme/api"}, repo: "https://github.com/acme/web.git", pass: false},
+		"included by org glob":    {include: []string{"acme/*"}, repo: "acme/web", pass: true},
+		"other org":               {include: []string{"acme/*"}, repo: "other/web", pass: false},
+		"excluded by name glob":   {exclude: []string{"*-archive"}, repo: "https://github.com/acme/logs-archive.git", pass: false},
+		"exclude wins":            {include: []string{"acme/*"}, exclude: []string{"legacy-*"}, repo: "acme/legacy-billing", pass: false},
+		"case insensitive":        {include: []string{"ACME/API"}, repo: "acme/Api", pass: true},
+		"nested gitlab group":     {include: []string{"group/sub/*"}, repo: "https://gitlab.com/group/sub/repo.git", pass: true},
+		"glob stays in one level": {include: []string{"group/*"}, repo: "group/sub/repo", pass: false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, err := NewRepoFilter(tt.include, tt.exclude)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := f.Pass(tt.repo); got != tt.pass {
+				t.Errorf("Pass(%q) got: %t want: %t", tt.repo, got, tt.pass)
+			}
+		})
+	}
+}
+
+func TestRepoFilterInvalidPattern(t *testing.T) {
+	if _, err := NewRepoFilter([]string{"acme/[api"}, nil); err == nil {
+		t.Error("expected an error for an invalid glob")
+	}
+}
+
+func TestExpandRepoPatterns(t *testing.T) {
+	list := filepath.Join(t.TempDir(), "repos.txt")
+	if err := os.WriteFile(list, []byte("# noisy archives\nacme/old-*\n\nacme/fixtures\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ExpandRepoPatterns([]string{"acme/scratch", "@" + list})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"acme/scratch", "acme/old-*", "acme/fixtures"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v want: %v", got, want)
+	}
+
+	if _, err := ExpandRepoPatterns([]string{"@" + list + ".missing"}); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
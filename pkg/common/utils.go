@@ -1,5 +1,7 @@
 package common
 
+import "bytes"
+
 func AddStringSliceItem(item string, slice *[]string) {
 	for _, i := range *slice {
 		if i == item {
@@ -17,3 +19,12 @@ func RemoveStringSliceItem(item string, slice *[]string) {
 		}
 	}
 }
+
+// NormalizeLineEndings converts CRLF line endings to LF, so that patterns matching the end of a line, or \s around a
+// secret, match files written on Windows the same as elsewhere. Line numbers are unchanged.
+func NormalizeLineEndings(data []byte) []byte {
+	if bytes.IndexByte(data, '\r') < 0 {
+		return data
+	}
+	return bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+}
@@ -74,3 +74,16 @@ func TestRemoveItem(t *testing.T) {
 		}
 	}
 }
+
+func TestNormalizeLineEndings(t *testing.T) {
+	tests := map[string]string{
+		"key=abc\r\nsecret=def\r\n": "key=abc\nsecret=def\n",
+		"no carriage returns\n":     "no carriage returns\n",
+		"lone\rcarriage return":     "lone\rcarriage return",
+	}
+	for in, want := range tests {
+		if got := string(NormalizeLineEndings([]byte(in))); got != want {
+			t.Errorf("NormalizeLineEndings(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
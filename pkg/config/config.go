@@ -0,0 +1,600 @@
+// Package config loads trufflehog's YAML configuration file. Values in the file act as defaults for the matching
+// command line flags, which can still be overridden by environment variables and the flags themselves.
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/allowlist"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/policy"
+)
+
+// Config is the contents of a configuration file. Pointers distinguish unset values from zero values, so that only
+// values present in the file override flag defaults.
+type Config struct {
+	Concurrency       *int           `yaml:"concurrency,omitempty"`
+	NoVerification    *bool          `yaml:"no-verification,omitempty"`
+	VerifyConnections *bool          `yaml:"verify-connections,omitempty"`
+	VaultAddr         string         `yaml:"vault-addr,omitempty"`
+	ConsulAddr        string         `yaml:"consul-addr,omitempty"`
+	OnlyVerified      *bool          `yaml:"only-verified,omitempty"`
+	MinSeverity       string         `yaml:"min-severity,omitempty"`
+	Detectors         []string       `yaml:"detectors,omitempty"`
+	Allowlist         string         `yaml:"allowlist,omitempty"`
+	AllowlistTag      *bool          `yaml:"allowlist-tag,omitempty"`
+	AllowlistPatterns []string       `yaml:"allowlist-patterns,omitempty"`
+	JSON              *bool          `yaml:"json,omitempty"`
+	Manifest          *bool          `yaml:"manifest,omitempty"`
+	Deterministic     *bool          `yaml:"deterministic,omitempty"`
+	ScanTimeout       string         `yaml:"scan-timeout,omitempty"`
+	EgressAuditLog    string         `yaml:"egress-audit-log,omitempty"`
+	APICacheDir       string         `yaml:"api-cache-dir,omitempty"`
+	CredentialHelper  string         `yaml:"credential-helper,omitempty"`
+	ContextLines      *int           `yaml:"context-lines,omitempty"`
+	ExtractStrings    *bool          `yaml:"extract-strings,omitempty"`
+	Log               Log            `yaml:"log,omitempty"`
+	FalsePositives    FalsePositives `yaml:"false-positives,omitempty"`
+	// PathRules change the severity of results by the path of their file. The first matching rule applies.
+	PathRules []PathRule `yaml:"path-rules,omitempty"`
+	// Policy classifies results into actions, such as failing the scan. The first matching rule applies.
+	Policy []PolicyRule `yaml:"policy,omitempty"`
+	// Sources are scanned together by the scan command.
+	Sources []Source `yaml:"sources,omitempty"`
+
+	// path and root are kept to report the location of invalid values.
+	path string
+	root *yaml.Node
+}
+
+// Log configures logging.
+type Log struct {
+	Level   string            `yaml:"level,omitempty"`
+	Format  string            `yaml:"format,omitempty"`
+	Modules map[string]string `yaml:"modules,omitempty"`
+}
+
+// FalsePositives configures false positive filtering of unverified results.
+type FalsePositives struct {
+	// Disabled turns off all false positive filtering, including the checks built into detectors.
+	Disabled   *bool    `yaml:"disabled,omitempty"`
+	Words      []string `yaml:"words,omitempty"`
+	MinEntropy *float64 `yaml:"min-entropy,omitempty"`
+	// Detectors is keyed by detector type.
+	Detectors map[string]DetectorFalsePositives `yaml:"detectors,omitempty"`
+}
+
+// DetectorFalsePositives overrides false positive filtering for one detector.
+type DetectorFalsePositives struct {
+	Disabled   bool     `yaml:"disabled,omitempty"`
+	Words      []string `yaml:"words,omitempty"`
+	MinEntropy *float64 `yaml:"min-entropy,omitempty"`
+}
+
+// PathRule sets or adjusts the severity of results in files matching any of its paths. See detectors.PathRule for
+// the pattern syntax.
+type PathRule struct {
+	Paths []string `yaml:"paths"`
+	// Severity replaces the severity of results, e.g. "low" for test fixtures.
+	Severity string `yaml:"severity,omitempty"`
+	// Adjust raises or lowers the severity by that many levels, e.g. 1 for production configuration.
+	Adjust int `yaml:"adjust,omitempty"`
+}
+
+// PolicyRule takes an action on the results its expression matches. See policy.Compile for the expression syntax.
+type PolicyRule struct {
+	Name string `yaml:"name,omitempty"`
+	// When is the expression, e.g. `verified && severity >= HIGH`.
+	When string `yaml:"when"`
+	// Action is one of fail, ticket, notify, or ignore.
+	Action string `yaml:"action"`
+}
+
+// Source is a source scanned by the scan command, or registered with the server command's API. Its fields match the
+// flags of the command of its type, and only those of that type may be set.
+type Source struct {
+	// Name identifies the source in results. It defaults to the type.
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	// Type is one of git, github, gitlab, filesystem, or s3.
+	Type string `yaml:"type" json:"type"`
+
+	// git
+	URI         string `yaml:"uri,omitempty" json:"uri,omitempty"`
+	Branch      string `yaml:"branch,omitempty" json:"branch,omitempty"`
+	SinceCommit string `yaml:"since-commit,omitempty" json:"since-commit,omitempty"`
+	MaxDepth    int    `yaml:"max-depth,omitempty" json:"max-depth,omitempty"`
+
+	// github and gitlab. Token defaults to the credential helper or the usual environment variables.
+	Endpoint     string   `yaml:"endpoint,omitempty" json:"endpoint,omitempty"`
+	Token        string   `yaml:"token,omitempty" json:"token,omitempty"`
+	Repos        []string `yaml:"repos,omitempty" json:"repos,omitempty"`
+	IncludeRepos []string `yaml:"include-repos,omitempty" json:"include-repos,omitempty"`
+	ExcludeRepos []string `yaml:"exclude-repos,omitempty" json:"exclude-repos,omitempty"`
+
+	// github
+	Orgs           []string `yaml:"orgs,omitempty" json:"orgs,omitempty"`
+	IncludeForks   bool     `yaml:"include-forks,omitempty" json:"include-forks,omitempty"`
+	IncludeMembers bool     `yaml:"include-members,omitempty" json:"include-members,omitempty"`
+	// Snapshot is where the repositories listed from Orgs are saved for later scans to reuse, like --snapshot.
+	Snapshot string `yaml:"snapshot,omitempty" json:"snapshot,omitempty"`
+
+	// filesystem
+	Directories []string `yaml:"directories,omitempty" json:"directories,omitempty"`
+
+	// s3. Key and Secret default to the credential helper or $AWS_ACCESS_KEY_ID and $AWS_SECRET_ACCESS_KEY.
+	Key              string   `yaml:"key,omitempty" json:"key,omitempty"`
+	Secret           string   `yaml:"secret,omitempty" json:"secret,omitempty"`
+	CloudEnvironment bool     `yaml:"cloud-environment,omitempty" json:"cloud-environment,omitempty"`
+	Buckets          []string `yaml:"buckets,omitempty" json:"buckets,omitempty"`
+}
+
+// SourceTypes are the types of source that can be configured.
+var SourceTypes = []string{"git", "github", "gitlab", "filesystem", "s3"}
+
+// DisplayName returns the name of the source, or its type if it has none.
+func (s *Source) DisplayName() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Type
+}
+
+// fields returns the YAML keys of the source's type-specific fields that are set.
+func (s *Source) fields() map[string]bool {
+	return map[string]bool{
+		"uri":               s.URI != "",
+		"branch":            s.Branch != "",
+		"since-commit":      s.SinceCommit != "",
+		"max-depth":         s.MaxDepth != 0,
+		"endpoint":          s.Endpoint != "",
+		"token":             s.Token != "",
+		"repos":             len(s.Repos) > 0,
+		"include-repos":     len(s.IncludeRepos) > 0,
+		"exclude-repos":     len(s.ExcludeRepos) > 0,
+		"orgs":              len(s.Orgs) > 0,
+		"include-forks":     s.IncludeForks,
+		"include-members":   s.IncludeMembers,
+		"snapshot":          s.Snapshot != "",
+		"directories":       len(s.Directories) > 0,
+		"key":               s.Key != "",
+		"secret":            s.Secret != "",
+		"cloud-environment": s.CloudEnvironment,
+		"buckets":           len(s.Buckets) > 0,
+	}
+}
+
+// sourceFields are the type-specific fields allowed for each source type.
+var sourceFields = map[string][]string{
+	"git":        {"uri", "branch", "since-commit", "max-depth"},
+	"github":     {"endpoint", "token", "repos", "include-repos", "exclude-repos", "orgs", "include-forks", "include-members", "snapshot"},
+	"gitlab":     {"endpoint", "token", "repos", "include-repos", "exclude-repos"},
+	"filesystem": {"directories"},
+	"s3":         {"key", "secret", "cloud-environment", "buckets"},
+}
+
+// Error is a problem with a configuration file, located by line when possible.
+type Error struct {
+	Path string
+	Line int
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", e.Path, e.Line, e.Msg)
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Msg)
+}
+
+// Errors is a list of problems found in a configuration file.
+type Errors []*Error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Load reads and validates the configuration file at path.
+func Load(path string) (*Config, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Parse(path, file)
+}
+
+// Parse reads and validates a configuration file. The path is only used in errors.
+func Parse(path string, r io.Reader) (*Config, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	c := &Config{path: path}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return c, nil
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, yamlErrors(path, err)
+	}
+	c.root = &root
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil {
+		return nil, yamlErrors(path, err)
+	}
+	if errs := c.Validate(); len(errs) > 0 {
+		return nil, errs
+	}
+	return c, nil
+}
+
+// Validate checks values that YAML decoding can't, such as severities and durations.
+func (c *Config) Validate() Errors {
+	var errs Errors
+	if c.Concurrency != nil && *c.Concurrency < 1 {
+		errs = append(errs, c.errorAt("concurrency must be at least 1", "concurrency"))
+	}
+	if c.ContextLines != nil && *c.ContextLines < 0 {
+		errs = append(errs, c.errorAt("context-lines can't be negative", "context-lines"))
+	}
+	if c.MinSeverity != "" {
+		if _, err := detectors.ParseSeverity(c.MinSeverity); err != nil {
+			errs = append(errs, c.errorAt(err.Error(), "min-severity"))
+		}
+	}
+	for i, name := range c.Detectors {
+		if _, err := detectors.ParseDetectorType(name); err != nil {
+			errs = append(errs, c.errorAt(err.Error(), "detectors", strconv.Itoa(i)))
+		}
+	}
+	for i, pattern := range c.AllowlistPatterns {
+		if _, err := allowlist.NewPatterns([]string{pattern}); err != nil {
+			errs = append(errs, c.errorAt(err.Error(), "allowlist-patterns", strconv.Itoa(i)))
+		}
+	}
+	if c.ScanTimeout != "" {
+		if _, err := time.ParseDuration(c.ScanTimeout); err != nil {
+			errs = append(errs, c.errorAt(fmt.Sprintf("invalid duration %q", c.ScanTimeout), "scan-timeout"))
+		}
+	}
+	if c.Log.Level != "" {
+		if _, err := logging.ParseLevel(c.Log.Level); err != nil {
+			errs = append(errs, c.errorAt(err.Error(), "log", "level"))
+		}
+	}
+	if c.Log.Format != "" && c.Log.Format != "text" && c.Log.Format != "json" {
+		errs = append(errs, c.errorAt(fmt.Sprintf("unknown log format %q, expected text or json", c.Log.Format), "log", "format"))
+	}
+	for _, module := range c.Log.modules() {
+		if _, err := logging.ParseLevel(c.Log.Modules[module]); err != nil {
+			errs = append(errs, c.errorAt(err.Error(), "log", "modules", module))
+		}
+	}
+	if fp := c.FalsePositives; fp.MinEntropy != nil && *fp.MinEntropy < 0 {
+		errs = append(errs, c.errorAt("min-entropy can't be negative", "false-positives", "min-entropy"))
+	}
+	for _, name := range c.FalsePositives.detectors() {
+		if _, err := detectors.ParseDetectorType(name); err != nil {
+			errs = append(errs, c.errorAt(err.Error(), "false-positives", "detectors", name))
+		}
+		if minEntropy := c.FalsePositives.Detectors[name].MinEntropy; minEntropy != nil && *minEntropy < 0 {
+			errs = append(errs, c.errorAt("min-entropy can't be negative", "false-positives", "detectors", name, "min-entropy"))
+		}
+	}
+	for i, rule := range c.PathRules {
+		index := strconv.Itoa(i)
+		if len(rule.Paths) == 0 {
+			errs = append(errs, c.errorAt("path rules require paths", "path-rules", index))
+		} else if _, err := detectors.NewPathRules([]detectors.PathRule{{Patterns: rule.Paths}}); err != nil {
+			errs = append(errs, c.errorAt(err.Error(), "path-rules", index, "paths"))
+		}
+		if rule.Severity != "" {
+			if _, err := detectors.ParseSeverity(rule.Severity); err != nil {
+				errs = append(errs, c.errorAt(err.Error(), "path-rules", index, "severity"))
+			}
+		} else if rule.Adjust == 0 {
+			errs = append(errs, c.errorAt("path rules require a severity or an adjustment", "path-rules", index))
+		}
+	}
+	for i, rule := range c.Policy {
+		index := strconv.Itoa(i)
+		if rule.When == "" {
+			errs = append(errs, c.errorAt("policy rules require an expression in when", "policy", index))
+		} else if _, err := policy.Compile(rule.When); err != nil {
+			errs = append(errs, c.errorAt(err.Error(), "policy", index, "when"))
+		}
+		if _, err := policy.ParseAction(rule.Action); err != nil {
+			errs = append(errs, c.errorAt(err.Error(), "policy", index, "action"))
+		}
+	}
+	names := map[string]bool{}
+	for i := range c.Sources {
+		errs = append(errs, c.validateSource(i)...)
+		name := c.Sources[i].DisplayName()
+		if names[name] {
+			errs = append(errs, c.errorAt(fmt.Sprintf("duplicate source name %q", name), "sources", strconv.Itoa(i)))
+		}
+		names[name] = true
+	}
+	return errs
+}
+
+// validateSource checks the source at index i of Sources.
+func (c *Config) validateSource(i int) Errors {
+	s := &c.Sources[i]
+	index := strconv.Itoa(i)
+	allowed, ok := sourceFields[s.Type]
+	if !ok {
+		msg := fmt.Sprintf("unknown source type %q, expected one of %s", s.Type, strings.Join(SourceTypes, ", "))
+		return Errors{c.errorAt(msg, "sources", index, "type")}
+	}
+
+	var errs Errors
+	set := s.fields()
+	for _, field := range allowed {
+		delete(set, field)
+	}
+	for _, field := range sortedKeys(set) {
+		if set[field] {
+			errs = append(errs, c.errorAt(fmt.Sprintf("%s can't be set on a %s source", field, s.Type), "sources", index, field))
+		}
+	}
+
+	switch s.Type {
+	case "git":
+		if s.URI == "" {
+			errs = append(errs, c.errorAt("git sources require a uri", "sources", index))
+		}
+		if s.MaxDepth < 0 {
+			errs = append(errs, c.errorAt("max-depth can't be negative", "sources", index, "max-depth"))
+		}
+	case "github":
+		if len(s.Orgs) == 0 && len(s.Repos) == 0 {
+			errs = append(errs, c.errorAt("github sources require orgs or repos", "sources", index))
+		}
+	case "filesystem":
+		if len(s.Directories) == 0 {
+			errs = append(errs, c.errorAt("filesystem sources require directories", "sources", index))
+		}
+	case "s3":
+		if s.CloudEnvironment && (s.Key != "" || s.Secret != "") {
+			errs = append(errs, c.errorAt("cloud-environment can't be used with a key and secret", "sources", index, "cloud-environment"))
+		}
+	}
+	return errs
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// FalsePositiveFilter returns the false positive filter configured in the file, or nil if there is none.
+func (c *Config) FalsePositiveFilter() (*detectors.FalsePositiveFilter, error) {
+	fp := c.FalsePositives
+	if len(fp.Words) == 0 && fp.MinEntropy == nil && len(fp.Detectors) == 0 {
+		return nil, nil
+	}
+	filter := &detectors.FalsePositiveFilter{Words: falsePositiveWords(fp.Words)}
+	if fp.MinEntropy != nil {
+		filter.MinEntropy = *fp.MinEntropy
+	}
+	for _, name := range fp.detectors() {
+		detectorType, err := detectors.ParseDetectorType(name)
+		if err != nil {
+			return nil, err
+		}
+		if filter.Overrides == nil {
+			filter.Overrides = map[detectorspb.DetectorType]detectors.FalsePositiveOverride{}
+		}
+		override := fp.Detectors[name]
+		filter.Overrides[detectorType] = detectors.FalsePositiveOverride{
+			Disabled:   override.Disabled,
+			Words:      falsePositiveWords(override.Words),
+			MinEntropy: override.MinEntropy,
+		}
+	}
+	return filter, nil
+}
+
+// PathRuleSet returns the path rules configured in the file, or nil if there are none.
+func (c *Config) PathRuleSet() (*detectors.PathRules, error) {
+	if len(c.PathRules) == 0 {
+		return nil, nil
+	}
+	rules := make([]detectors.PathRule, len(c.PathRules))
+	for i, rule := range c.PathRules {
+		rules[i] = detectors.PathRule{Patterns: rule.Paths, Adjust: rule.Adjust}
+		if rule.Severity != "" {
+			severity, err := detectors.ParseSeverity(rule.Severity)
+			if err != nil {
+				return nil, err
+			}
+			rules[i].Severity = severity
+		}
+	}
+	return detectors.NewPathRules(rules)
+}
+
+// PolicySet returns the policy configured in the file, or nil if there is none.
+func (c *Config) PolicySet() (*policy.Policy, error) {
+	if len(c.Policy) == 0 {
+		return nil, nil
+	}
+	rules := make([]policy.Rule, len(c.Policy))
+	for i, rule := range c.Policy {
+		action, err := policy.ParseAction(rule.Action)
+		if err != nil {
+			return nil, err
+		}
+		rules[i] = policy.Rule{Name: rule.Name, When: rule.When, Action: action}
+	}
+	return policy.New(rules)
+}
+
+func falsePositiveWords(words []string) []detectors.FalsePositive {
+	fps := make([]detectors.FalsePositive, len(words))
+	for i, word := range words {
+		fps[i] = detectors.FalsePositive(word)
+	}
+	return fps
+}
+
+// FlagDefaults returns the values set in the file keyed by the name of the flag they provide a default for.
+func (c *Config) FlagDefaults() map[string][]string {
+	defaults := map[string][]string{}
+	setBool := func(name string, v *bool) {
+		if v != nil {
+			defaults[name] = []string{strconv.FormatBool(*v)}
+		}
+	}
+	setString := func(name, v string) {
+		if v != "" {
+			defaults[name] = []string{v}
+		}
+	}
+
+	if c.Concurrency != nil {
+		defaults["concurrency"] = []string{strconv.Itoa(*c.Concurrency)}
+	}
+	setBool("no-verification", c.NoVerification)
+	setBool("verify-connections", c.VerifyConnections)
+	setString("vault-addr", c.VaultAddr)
+	setString("consul-addr", c.ConsulAddr)
+	setBool("only-verified", c.OnlyVerified)
+	setString("min-severity", c.MinSeverity)
+	if len(c.Detectors) > 0 {
+		defaults["detector"] = c.Detectors
+	}
+	setString("allowlist", c.Allowlist)
+	setBool("allowlist-tag", c.AllowlistTag)
+	if len(c.AllowlistPatterns) > 0 {
+		defaults["allowlist-pattern"] = c.AllowlistPatterns
+	}
+	setBool("json", c.JSON)
+	setBool("manifest", c.Manifest)
+	setBool("deterministic", c.Deterministic)
+	setString("scan-timeout", c.ScanTimeout)
+	setString("egress-audit-log", c.EgressAuditLog)
+	setString("api-cache-dir", c.APICacheDir)
+	setString("credential-helper", c.CredentialHelper)
+	if c.ContextLines != nil {
+		defaults["context-lines"] = []string{strconv.Itoa(*c.ContextLines)}
+	}
+	setBool("extract-strings", c.ExtractStrings)
+	setString("log-level", c.Log.Level)
+	setString("log-format", c.Log.Format)
+	setBool("no-fp-filter", c.FalsePositives.Disabled)
+	for _, module := range c.Log.modules() {
+		defaults["log-module"] = append(defaults["log-module"], module+"="+c.Log.Modules[module])
+	}
+	return defaults
+}
+
+// modules returns the names of modules with a log level, sorted.
+func (l *Log) modules() []string {
+	names := make([]string, 0, len(l.Modules))
+	for name := range l.Modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// detectors returns the names of detectors with overrides, sorted.
+func (f *FalsePositives) detectors() []string {
+	names := make([]string, 0, len(f.Detectors))
+	for name := range f.Detectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// errorAt returns an error located at the value found by following keys, or sequence indexes, from the root.
+func (c *Config) errorAt(msg string, keys ...string) *Error {
+	return &Error{Path: c.path, Line: lineOf(c.root, keys...), Msg: msg}
+}
+
+// lineOf returns the line of the value at the key path, or 0 if it can't be found.
+func lineOf(node *yaml.Node, keys ...string) int {
+	if node == nil {
+		return 0
+	}
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	for _, key := range keys {
+		switch node.Kind {
+		case yaml.MappingNode:
+			var found *yaml.Node
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == key {
+					found = node.Content[i+1]
+					break
+				}
+			}
+			if found == nil {
+				return 0
+			}
+			node = found
+		case yaml.SequenceNode:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node.Content) {
+				return 0
+			}
+			node = node.Content[i]
+		default:
+			return 0
+		}
+	}
+	return node.Line
+}
+
+// yamlErrors converts errors from the YAML package, which already include line numbers, into Errors.
+func yamlErrors(path string, err error) error {
+	var msgs []string
+	if typeErr, ok := err.(*yaml.TypeError); ok {
+		msgs = typeErr.Errors
+	} else {
+		msgs = []string{strings.TrimPrefix(err.Error(), "yaml: ")}
+	}
+
+	errs := make(Errors, 0, len(msgs))
+	for _, msg := range msgs {
+		e := &Error{Path: path, Msg: msg}
+		// Messages look like "line 3: field foo not found in type config.Config".
+		if strings.HasPrefix(msg, "line ") {
+			if i := strings.Index(msg, ":"); i > 0 {
+				if line, convErr := strconv.Atoi(msg[len("line "):i]); convErr == nil {
+					e.Line = line
+					e.Msg = strings.TrimSpace(msg[i+1:])
+				}
+			}
+		}
+		errs = append(errs, e)
+	}
+	return errs
+}
@@ -0,0 +1,283 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/policy"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		want     map[string][]string
+		wantErrs []string
+	}{
+		"valid": {
+			input: `concurrency: 4
+only-verified: true
+manifest: true
+verify-connections: true
+vault-addr: https://vault.internal:8200
+min-severity: high
+detectors: [aws, github]
+scan-timeout: 30m
+context-lines: 2
+credential-helper: git credential-osxkeychain
+log:
+  level: debug
+  modules:
+    sources.git: trace
+    engine: info
+`,
+			want: map[string][]string{
+				"concurrency":        {"4"},
+				"only-verified":      {"true"},
+				"manifest":           {"true"},
+				"verify-connections": {"true"},
+				"vault-addr":         {"https://vault.internal:8200"},
+				"min-severity":       {"high"},
+				"detector":           {"aws", "github"},
+				"scan-timeout":       {"30m"},
+				"context-lines":      {"2"},
+				"credential-helper":  {"git credential-osxkeychain"},
+				"log-level":          {"debug"},
+				"log-module":         {"engine=info", "sources.git=trace"},
+			},
+		},
+		"allowlist patterns": {
+			input: `allowlist-tag: true
+allowlist-patterns:
+  - EXAMPLE.*
+  - 0{8}.*
+`,
+			want: map[string][]string{
+				"allowlist-tag":     {"true"},
+				"allowlist-pattern": {"EXAMPLE.*", "0{8}.*"},
+			},
+		},
+		"false positives": {
+			input: `false-positives:
+  disabled: true
+  words: [dummy]
+  min-entropy: 3
+  detectors:
+    github:
+      min-entropy: 2.5
+`,
+			want: map[string][]string{
+				"no-fp-filter": {"true"},
+			},
+		},
+		"sources": {
+			input: `sources:
+  - name: payments
+    type: github
+    orgs: [payments]
+    include-forks: true
+  - type: s3
+    buckets: [logs, backups]
+  - type: filesystem
+    directories: [/srv]
+`,
+			want: map[string][]string{},
+		},
+		"invalid sources": {
+			input: `sources:
+  - type: git
+    buckets: [logs]
+  - type: svn
+  - name: git
+    type: filesystem
+    directories: [/srv]
+`,
+			wantErrs: []string{
+				"trufflehog.yaml:3: buckets can't be set on a git source",
+				"trufflehog.yaml:2: git sources require a uri",
+				`trufflehog.yaml:4: unknown source type "svn"`,
+				`trufflehog.yaml:5: duplicate source name "git"`,
+			},
+		},
+		"empty": {
+			input: "\n",
+			want:  map[string][]string{},
+		},
+		"unknown field": {
+			input:    "concurrency: 4\nverify: false\n",
+			wantErrs: []string{"trufflehog.yaml:2: field verify not found"},
+		},
+		"wrong type": {
+			input:    "concurrency: lots\n",
+			wantErrs: []string{"trufflehog.yaml:1: cannot unmarshal"},
+		},
+		"invalid values": {
+			input: `min-severity: urgent
+detectors:
+  - aws
+  - nope
+scan-timeout: soon
+`,
+			wantErrs: []string{
+				`trufflehog.yaml:1: unknown severity "urgent"`,
+				`trufflehog.yaml:4: unknown detector type "nope"`,
+				`trufflehog.yaml:5: invalid duration "soon"`,
+			},
+		},
+		"invalid allowlist patterns": {
+			input: `allowlist-patterns:
+  - EXAMPLE.*
+  - EXAMPLE(
+`,
+			wantErrs: []string{`trufflehog.yaml:3: invalid allowlist pattern "EXAMPLE("`},
+		},
+		"invalid false positives": {
+			input: `false-positives:
+  min-entropy: -1
+  detectors:
+    nope:
+      words: [dummy]
+`,
+			wantErrs: []string{
+				"trufflehog.yaml:2: min-entropy can't be negative",
+				`trufflehog.yaml:5: unknown detector type "nope"`,
+			},
+		},
+		"invalid path rules": {
+			input: `path-rules:
+  - paths: ["test/**"]
+    severity: none
+  - paths: ["prod/**"]
+  - severity: low
+`,
+			wantErrs: []string{
+				`trufflehog.yaml:3: unknown severity "none"`,
+				"trufflehog.yaml:4: path rules require a severity or an adjustment",
+				"trufflehog.yaml:5: path rules require paths",
+			},
+		},
+		"invalid policy": {
+			input: `policy:
+  - when: verified &&
+    action: fail
+  - when: verified
+    action: page
+  - action: notify
+`,
+			wantErrs: []string{
+				"trufflehog.yaml:2: column 12: unexpected end of expression",
+				`trufflehog.yaml:5: unknown policy action "page"`,
+				"trufflehog.yaml:6: policy rules require an expression in when",
+			},
+		},
+	}
+	for name, test := range tests {
+		c, err := Parse("trufflehog.yaml", strings.NewReader(test.input))
+		if len(test.wantErrs) > 0 {
+			errs, ok := err.(Errors)
+			if !ok {
+				t.Errorf("%s: expected Errors, got: %v", name, err)
+				continue
+			}
+			if len(errs) != len(test.wantErrs) {
+				t.Errorf("%s: unexpected number of errors. Got: %v, Expected: %v", name, errs, test.wantErrs)
+				continue
+			}
+			for i, want := range test.wantErrs {
+				if !strings.HasPrefix(errs[i].Error(), want) {
+					t.Errorf("%s: unexpected error. Got: %q, Expected prefix: %q", name, errs[i].Error(), want)
+				}
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if got := c.FlagDefaults(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: unexpected flag defaults. Got: %v, Expected: %v", name, got, test.want)
+		}
+	}
+}
+
+func TestFalsePositiveFilter(t *testing.T) {
+	c, err := Parse("trufflehog.yaml", strings.NewReader(`false-positives:
+  words: [dummy]
+  min-entropy: 3
+  detectors:
+    github:
+      disabled: true
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter, err := c.FalsePositiveFilter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &detectors.FalsePositiveFilter{
+		Words:      []detectors.FalsePositive{"dummy"},
+		MinEntropy: 3,
+		Overrides: map[detectorspb.DetectorType]detectors.FalsePositiveOverride{
+			detectorspb.DetectorType_Github: {Disabled: true, Words: []detectors.FalsePositive{}},
+		},
+	}
+	if !reflect.DeepEqual(filter, want) {
+		t.Errorf("unexpected filter. Got: %+v, Expected: %+v", filter, want)
+	}
+
+	c, err = Parse("trufflehog.yaml", strings.NewReader("concurrency: 4\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filter, err := c.FalsePositiveFilter(); err != nil || filter != nil {
+		t.Errorf("expected no filter, got: %+v, %v", filter, err)
+	}
+}
+
+func TestPathRuleSet(t *testing.T) {
+	c, err := Parse("trufflehog.yaml", strings.NewReader(`path-rules:
+  - paths: ["test/fixtures/**"]
+    severity: low
+  - paths: ["*.tfvars"]
+    adjust: 1
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules, err := c.PathRuleSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := detectors.Result{Severity: detectors.SeverityHigh}
+	if !rules.Apply(&result, "test/fixtures/aws.json") || result.Severity != detectors.SeverityLow {
+		t.Errorf("expected the fixture rule to make the result low, got %s", result.Severity)
+	}
+	result = detectors.Result{Severity: detectors.SeverityMedium}
+	if !rules.Apply(&result, "infra/prod.tfvars") || result.Severity != detectors.SeverityHigh {
+		t.Errorf("expected the tfvars rule to raise the result to high, got %s", result.Severity)
+	}
+}
+
+func TestPolicySet(t *testing.T) {
+	c, err := Parse("trufflehog.yaml", strings.NewReader(`policy:
+  - name: live production secrets
+    when: verified && file.startsWith("deploy/")
+    action: fail
+  - when: severity >= MEDIUM
+    action: Ticket
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := c.PolicySet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule, ok := p.Evaluate(&detectors.ResultWithMetadata{Result: detectors.Result{Severity: detectors.SeverityHigh}})
+	if !ok || rule.Name != "rule 2" || rule.Action != policy.ActionTicket {
+		t.Errorf("expected the second rule to open a ticket, got %+v", rule)
+	}
+}
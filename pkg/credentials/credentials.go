@@ -0,0 +1,239 @@
+// Package credentials finds the credentials sources authenticate with, so they don't have to be passed on the command
+// line, where they'd end up in shell history and process listings.
+//
+// Credentials are looked up, in order, in:
+//   - TRUFFLEHOG_<SOURCE>_TOKEN, then the environment variables the provider's own tools use, such as GITHUB_TOKEN
+//   - a credential helper command, speaking git's credential helper protocol
+//   - the netrc file, $NETRC or ~/.netrc
+//   - the OS keychain, under the service "trufflehog" and the source's name as the account
+package credentials
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
+)
+
+// keychainService is the service name credentials are stored under in the OS keychain.
+const keychainService = "trufflehog"
+
+// helperTimeout bounds how long a credential helper or keychain command may take, since some prompt the user.
+const helperTimeout = time.Minute
+
+// Request describes the credential a source needs.
+type Request struct {
+	// Source is the source's name, such as "github".
+	Source string
+	// Host is the host the credential is for, such as "github.com". Credential helpers and netrc are keyed by host.
+	Host string
+	// UsernameEnv and PasswordEnv are environment variables the provider's own tools read the credential from.
+	UsernameEnv []string
+	PasswordEnv []string
+}
+
+// Credential is a resolved credential. Tokens are in Password.
+type Credential struct {
+	Username string
+	Password string
+	// From describes where the credential was found, for logging. It never includes the credential.
+	From string
+}
+
+// Resolver looks up credentials.
+type Resolver struct {
+	helper    string
+	netrcPath string
+	keychain  bool
+
+	getenv func(string) string
+	run    func(ctx context.Context, stdin string, name string, args ...string) ([]byte, error)
+}
+
+// Option configures a Resolver.
+type Option func(*Resolver)
+
+// WithHelper sets a credential helper command. It's run with "get" appended and a request on stdin, like git runs
+// its credential helpers, so helpers such as "git credential-osxkeychain" can be used directly.
+func WithHelper(command string) Option {
+	return func(r *Resolver) {
+		r.helper = strings.TrimSpace(command)
+	}
+}
+
+// WithNetrc sets the netrc file to read. It defaults to $NETRC, or .netrc in the home directory.
+func WithNetrc(path string) Option {
+	return func(r *Resolver) {
+		r.netrcPath = path
+	}
+}
+
+// WithoutKeychain stops the OS keychain from being searched.
+func WithoutKeychain() Option {
+	return func(r *Resolver) {
+		r.keychain = false
+	}
+}
+
+// New returns a Resolver.
+func New(opts ...Option) *Resolver {
+	r := &Resolver{
+		keychain: true,
+		getenv:   os.Getenv,
+		run:      runCommand,
+	}
+	if path := os.Getenv("NETRC"); path != "" {
+		r.netrcPath = path
+	} else if home, err := os.UserHomeDir(); err == nil {
+		r.netrcPath = filepath.Join(home, netrcName())
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Resolve returns the first credential found for the request, and false if there is none. Errors from helpers and
+// unreadable files are logged and skipped, so that a broken helper doesn't hide a credential in the environment.
+func (r *Resolver) Resolve(ctx context.Context, req Request) (Credential, bool) {
+	lookups := []func(context.Context, Request) (Credential, bool, error){
+		r.fromEnv,
+		r.fromHelper,
+		r.fromNetrc,
+		r.fromKeychain,
+	}
+	for _, lookup := range lookups {
+		cred, ok, err := lookup(ctx, req)
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).WithField("source", req.Source).Warn("could not look up credential")
+			continue
+		}
+		if ok {
+			logging.FromContext(ctx).WithField("source", req.Source).Debugf("using credential from %s", cred.From)
+			return cred, true
+		}
+	}
+	return Credential{}, false
+}
+
+func (r *Resolver) fromEnv(_ context.Context, req Request) (Credential, bool, error) {
+	envName := "TRUFFLEHOG_" + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(req.Source)) + "_TOKEN"
+	for _, name := range append([]string{envName}, req.PasswordEnv...) {
+		password := r.getenv(name)
+		if password == "" {
+			continue
+		}
+		cred := Credential{Password: password, From: "$" + name}
+		for _, usernameEnv := range req.UsernameEnv {
+			if username := r.getenv(usernameEnv); username != "" {
+				cred.Username = username
+				break
+			}
+		}
+		return cred, true, nil
+	}
+	return Credential{}, false, nil
+}
+
+func (r *Resolver) fromHelper(ctx context.Context, req Request) (Credential, bool, error) {
+	if r.helper == "" || req.Host == "" {
+		return Credential{}, false, nil
+	}
+	input := fmt.Sprintf("protocol=https\nhost=%s\n\n", req.Host)
+	var out []byte
+	var err error
+	if runtime.GOOS == "windows" {
+		out, err = r.run(ctx, input, "cmd", "/C", r.helper+" get")
+	} else {
+		out, err = r.run(ctx, input, "sh", "-c", r.helper+" get")
+	}
+	if err != nil {
+		// The helper's output may contain the credential, so it isn't included.
+		return Credential{}, false, fmt.Errorf("credential helper failed: %w", err)
+	}
+
+	cred := Credential{From: "credential helper"}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		key, value, ok := cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "username":
+			cred.Username = value
+		case "password":
+			cred.Password = value
+		}
+	}
+	return cred, cred.Password != "", nil
+}
+
+func (r *Resolver) fromNetrc(_ context.Context, req Request) (Credential, bool, error) {
+	if r.netrcPath == "" || req.Host == "" {
+		return Credential{}, false, nil
+	}
+	data, err := os.ReadFile(r.netrcPath)
+	if os.IsNotExist(err) {
+		return Credential{}, false, nil
+	}
+	if err != nil {
+		return Credential{}, false, err
+	}
+	machine, ok := parseNetrc(string(data), req.Host)
+	if !ok || machine.password == "" {
+		return Credential{}, false, nil
+	}
+	return Credential{Username: machine.login, Password: machine.password, From: r.netrcPath}, true, nil
+}
+
+func (r *Resolver) fromKeychain(ctx context.Context, req Request) (Credential, bool, error) {
+	if !r.keychain {
+		return Credential{}, false, nil
+	}
+	var name string
+	var args []string
+	switch runtime.GOOS {
+	case "darwin":
+		name, args = "security", []string{"find-generic-password", "-s", keychainService, "-a", req.Source, "-w"}
+	case "linux", "freebsd", "openbsd":
+		name, args = "secret-tool", []string{"lookup", "service", keychainService, "account", req.Source}
+	default:
+		return Credential{}, false, nil
+	}
+	if _, err := exec.LookPath(name); err != nil {
+		return Credential{}, false, nil
+	}
+	out, err := r.run(ctx, "", name, args...)
+	if err != nil {
+		// Both tools exit non-zero when there's no entry.
+		return Credential{}, false, nil
+	}
+	password := strings.TrimRight(string(out), "\r\n")
+	return Credential{Password: password, From: "OS keychain"}, password != "", nil
+}
+
+func runCommand(ctx context.Context, stdin string, name string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, helperTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Stderr = os.Stderr
+	return cmd.Output()
+}
+
+// cut is strings.Cut, which needs Go 1.18.
+func cut(s, sep string) (before, after string, found bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
@@ -0,0 +1,153 @@
+package credentials
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func testResolver(t *testing.T, env map[string]string, opts ...Option) *Resolver {
+	t.Helper()
+	r := New(append([]Option{WithNetrc(""), WithoutKeychain()}, opts...)...)
+	r.getenv = func(name string) string { return env[name] }
+	return r
+}
+
+func TestResolve_Env(t *testing.T) {
+	req := Request{Source: "github", Host: "github.com", PasswordEnv: []string{"GITHUB_TOKEN", "GH_TOKEN"}}
+
+	tests := map[string]struct {
+		env      map[string]string
+		want     string
+		wantFrom string
+	}{
+		"provider variable": {
+			env:      map[string]string{"GH_TOKEN": "gh"},
+			want:     "gh",
+			wantFrom: "$GH_TOKEN",
+		},
+		"provider variables in order": {
+			env:      map[string]string{"GITHUB_TOKEN": "github", "GH_TOKEN": "gh"},
+			want:     "github",
+			wantFrom: "$GITHUB_TOKEN",
+		},
+		"trufflehog variable first": {
+			env:      map[string]string{"TRUFFLEHOG_GITHUB_TOKEN": "th", "GITHUB_TOKEN": "github"},
+			want:     "th",
+			wantFrom: "$TRUFFLEHOG_GITHUB_TOKEN",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cred, ok := testResolver(t, tt.env).Resolve(context.Background(), req)
+			if !ok {
+				t.Fatal("expected a credential")
+			}
+			if cred.Password != tt.want || cred.From != tt.wantFrom {
+				t.Errorf("got: %q from %s, want: %q from %s", cred.Password, cred.From, tt.want, tt.wantFrom)
+			}
+		})
+	}
+
+	if _, ok := testResolver(t, nil).Resolve(context.Background(), req); ok {
+		t.Error("expected no credential")
+	}
+}
+
+func TestResolve_EnvUsername(t *testing.T) {
+	req := Request{
+		Source:      "s3",
+		UsernameEnv: []string{"AWS_ACCESS_KEY_ID"},
+		PasswordEnv: []string{"AWS_SECRET_ACCESS_KEY"},
+	}
+	env := map[string]string{"AWS_ACCESS_KEY_ID": "AKIAEXAMPLE", "AWS_SECRET_ACCESS_KEY": "secret"}
+	cred, ok := testResolver(t, env).Resolve(context.Background(), req)
+	if !ok || cred.Username != "AKIAEXAMPLE" || cred.Password != "secret" {
+		t.Errorf("got: %+v", cred)
+	}
+}
+
+func TestResolve_Helper(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("helper script needs sh")
+	}
+	dir := t.TempDir()
+	helper := filepath.Join(dir, "helper")
+	// The helper echoes the host back as the username, to check the request it was sent.
+	script := "#!/bin/sh\n[ \"$1\" = get ] || exit 1\nwhile read -r line && [ -n \"$line\" ]; do\n" +
+		"  case \"$line\" in host=*) host=${line#host=};; esac\ndone\n" +
+		"echo \"username=$host\"\necho \"password=from-helper\"\n"
+	if err := os.WriteFile(helper, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	r := testResolver(t, nil, WithHelper(helper))
+	cred, ok := r.Resolve(context.Background(), Request{Source: "gitlab", Host: "gitlab.example.com"})
+	if !ok {
+		t.Fatal("expected a credential")
+	}
+	if cred.Username != "gitlab.example.com" || cred.Password != "from-helper" || cred.From != "credential helper" {
+		t.Errorf("got: %+v", cred)
+	}
+
+	// A failing helper is skipped.
+	r = testResolver(t, nil, WithHelper("false"))
+	if _, ok := r.Resolve(context.Background(), Request{Source: "gitlab", Host: "gitlab.example.com"}); ok {
+		t.Error("expected no credential")
+	}
+}
+
+func TestResolve_Netrc(t *testing.T) {
+	netrc := filepath.Join(t.TempDir(), ".netrc")
+	data := "machine example.com login other password nope\n" +
+		"machine gitlab.example.com\n  login oauth2\n  password from-netrc\n"
+	if err := os.WriteFile(netrc, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r := testResolver(t, nil, WithNetrc(netrc))
+	cred, ok := r.Resolve(context.Background(), Request{Source: "gitlab", Host: "gitlab.example.com"})
+	if !ok || cred.Username != "oauth2" || cred.Password != "from-netrc" || cred.From != netrc {
+		t.Errorf("got: %+v", cred)
+	}
+
+	// The environment takes precedence.
+	r = testResolver(t, map[string]string{"GITLAB_TOKEN": "from-env"}, WithNetrc(netrc))
+	cred, _ = r.Resolve(context.Background(), Request{Source: "gitlab", Host: "gitlab.example.com", PasswordEnv: []string{"GITLAB_TOKEN"}})
+	if cred.Password != "from-env" {
+		t.Errorf("got: %q, want the environment variable", cred.Password)
+	}
+}
+
+func TestParseNetrc(t *testing.T) {
+	data := "# comment\n" +
+		"machine a.example.com login alice password pa account acct\n" +
+		"macdef init\n  machine b.example.com password macro\n\n" +
+		"machine b.example.com login bob password pb\n" +
+		"default login anon password pd\n"
+
+	tests := map[string]struct {
+		host string
+		want netrcMachine
+		ok   bool
+	}{
+		"single line":      {host: "a.example.com", want: netrcMachine{"alice", "pa"}, ok: true},
+		"after macro":      {host: "b.example.com", want: netrcMachine{"bob", "pb"}, ok: true},
+		"case insensitive": {host: "A.EXAMPLE.COM", want: netrcMachine{"alice", "pa"}, ok: true},
+		"default fallback": {host: "c.example.com", want: netrcMachine{"anon", "pd"}, ok: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := parseNetrc(data, tt.host)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("got: %+v %t, want: %+v %t", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+
+	if _, ok := parseNetrc("machine a.example.com password pa\n", "b.example.com"); ok {
+		t.Error("expected no entry without a default")
+	}
+}
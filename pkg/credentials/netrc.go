@@ -0,0 +1,84 @@
+package credentials
+
+import (
+	"runtime"
+	"strings"
+)
+
+type netrcMachine struct {
+	login    string
+	password string
+}
+
+// netrcName is the name of the netrc file in the home directory. curl and git look for _netrc on Windows.
+func netrcName() string {
+	if runtime.GOOS == "windows" {
+		return "_netrc"
+	}
+	return ".netrc"
+}
+
+// parseNetrc returns the entry for host, falling back to the default entry. Macro definitions are skipped.
+func parseNetrc(data, host string) (netrcMachine, bool) {
+	var (
+		found, fallback   netrcMachine
+		hasFound, hasDflt bool
+		current           *netrcMachine
+		inMacro           bool
+	)
+
+	lines := strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n")
+	for _, line := range lines {
+		if inMacro {
+			// A macro runs until the next blank line.
+			if strings.TrimSpace(line) == "" {
+				inMacro = false
+			}
+			continue
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		for i := 0; i < len(fields); i++ {
+			var value string
+			if i+1 < len(fields) {
+				value = fields[i+1]
+			}
+			switch fields[i] {
+			case "machine":
+				current = nil
+				if strings.EqualFold(value, host) && !hasFound {
+					current, hasFound = &found, true
+				}
+				i++
+			case "default":
+				current = nil
+				if !hasDflt {
+					current, hasDflt = &fallback, true
+				}
+			case "login":
+				if current != nil {
+					current.login = value
+				}
+				i++
+			case "password":
+				if current != nil {
+					current.password = value
+				}
+				i++
+			case "account":
+				i++
+			case "macdef":
+				current = nil
+				inMacro = true
+				i = len(fields)
+			}
+		}
+	}
+
+	if hasFound {
+		return found, true
+	}
+	return fallback, hasDflt
+}
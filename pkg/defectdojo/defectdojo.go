@@ -0,0 +1,233 @@
+// Package defectdojo pushes findings from trufflehog's --json output to DefectDojo.
+//
+// Findings are converted to DefectDojo's Generic Findings Import format, which other vulnerability management tools
+// such as ThreadFix can also import, and uploaded with the import-scan API. DefectDojo creates the product and
+// engagement if they don't exist, and deduplicates findings across imports by their unique ID.
+package defectdojo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/allowlist"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+)
+
+// CWE798 is "Use of Hard-coded Credentials", the weakness every finding is reported as.
+const CWE798 = 798
+
+// scanType is the DefectDojo parser for the Generic Findings Import format.
+const scanType = "Generic Findings Import"
+
+// Report is a file in the Generic Findings Import format.
+type Report struct {
+	Findings []Finding `json:"findings"`
+}
+
+// Finding is a finding in the Generic Findings Import format. It never includes the raw secret.
+type Finding struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// Severity is one of Critical, High, Medium, Low, or Info.
+	Severity   string `json:"severity"`
+	CWE        int    `json:"cwe"`
+	FilePath   string `json:"file_path,omitempty"`
+	Line       int64  `json:"line,omitempty"`
+	Mitigation string `json:"mitigation,omitempty"`
+	References string `json:"references,omitempty"`
+	// UniqueIDFromTool identifies the same secret at the same location across imports.
+	UniqueIDFromTool string `json:"unique_id_from_tool"`
+	VulnIDFromTool   string `json:"vuln_id_from_tool"`
+	Active           bool   `json:"active"`
+	// Verified is true for live credentials.
+	Verified bool `json:"verified"`
+}
+
+// NewReport converts findings read from --json output.
+func NewReport(results []findings.Finding) *Report {
+	report := &Report{Findings: make([]Finding, 0, len(results))}
+	for i := range results {
+		report.Findings = append(report.Findings, convert(&results[i]))
+	}
+	return report
+}
+
+func convert(f *findings.Finding) Finding {
+	location := f.Location()
+	finding := Finding{
+		Title:            fmt.Sprintf("%s secret in %s", f.DetectorType, location),
+		Severity:         severity(f.Severity),
+		CWE:              CWE798,
+		UniqueIDFromTool: allowlist.Hash([]byte(f.DetectorType + "\x00" + f.Raw + "\x00" + location)),
+		VulnIDFromTool:   f.DetectorType,
+		Active:           true,
+		Verified:         f.Verified,
+	}
+	if location == "" {
+		finding.Title = fmt.Sprintf("%s secret in %s", f.DetectorType, f.SourceName)
+	}
+	finding.FilePath, _ = f.Metadata["file"].(string)
+	if line, ok := f.Metadata["line"].(float64); ok {
+		finding.Line = int64(line)
+	}
+
+	var description strings.Builder
+	fmt.Fprintf(&description, "TruffleHog found a %s secret", f.DetectorType)
+	if f.Verified {
+		description.WriteString(" and verified that it's live")
+	}
+	description.WriteString(".\n\n")
+	if f.Redacted != "" {
+		fmt.Fprintf(&description, "**Secret:** %s\n", f.Redacted)
+	}
+	fmt.Fprintf(&description, "**Source:** %s\n", f.SourceName)
+	details := make(map[string]string, len(f.Metadata)+len(f.ExtraData))
+	for key, value := range f.Metadata {
+		details[key] = fmt.Sprint(value)
+	}
+	for key, value := range f.ExtraData {
+		details[key] = value
+	}
+	keys := make([]string, 0, len(details))
+	for key := range details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&description, "**%s:** %s\n", key, details[key])
+	}
+	finding.Description = description.String()
+
+	if dt, err := detectors.ParseDetectorType(f.DetectorType); err == nil {
+		if remediation := detectors.RemediationFor(dt); remediation != nil {
+			finding.Mitigation = remediation.Guidance
+			finding.References = remediation.URL
+		}
+	}
+	return finding
+}
+
+// severity returns the DefectDojo name of a severity.
+func severity(s detectors.Severity) string {
+	switch s {
+	case detectors.SeverityCritical:
+		return "Critical"
+	case detectors.SeverityHigh:
+		return "High"
+	case detectors.SeverityMedium:
+		return "Medium"
+	case detectors.SeverityLow:
+		return "Low"
+	default:
+		return "Info"
+	}
+}
+
+// Client uploads reports to a DefectDojo instance.
+type Client struct {
+	// URL is the instance's address, such as https://defectdojo.example.com.
+	URL string
+	// Token is an API v2 key.
+	Token string
+
+	client *http.Client
+}
+
+// ImportOptions say where an import goes.
+type ImportOptions struct {
+	Product string
+	// ProductType is used when the product doesn't exist yet and is created.
+	ProductType string
+	Engagement  string
+	// CloseOld closes findings of earlier imports into the engagement that aren't in this one.
+	CloseOld bool
+	Tags     []string
+}
+
+// ImportResult identifies what an import created.
+type ImportResult struct {
+	ProductID    int `json:"product_id"`
+	EngagementID int `json:"engagement_id"`
+	TestID       int `json:"test_id"`
+}
+
+// Import uploads the report, creating the product and engagement if they don't exist.
+func (c *Client) Import(ctx context.Context, report *Report, opts ImportOptions) (*ImportResult, error) {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	fields := map[string]string{
+		"scan_type":           scanType,
+		"product_name":        opts.Product,
+		"product_type_name":   opts.ProductType,
+		"engagement_name":     opts.Engagement,
+		"auto_create_context": "true",
+		"active":              "true",
+		"minimum_severity":    "Info",
+		"close_old_findings":  strconv.FormatBool(opts.CloseOld),
+	}
+	for name, value := range fields {
+		if value == "" {
+			continue
+		}
+		if err := form.WriteField(name, value); err != nil {
+			return nil, err
+		}
+	}
+	for _, tag := range opts.Tags {
+		if err := form.WriteField("tags", tag); err != nil {
+			return nil, err
+		}
+	}
+	file, err := form.CreateFormFile("file", "trufflehog.json")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.Write(data); err != nil {
+		return nil, err
+	}
+	if err := form.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(c.URL, "/")+"/api/v2/import-scan/", &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Token "+c.Token)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("Accept", "application/json")
+
+	client := c.client
+	if client == nil {
+		client = common.SaneHttpClient()
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return nil, fmt.Errorf("DefectDojo import failed with status %d: %s", res.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	var result ImportResult
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("could not decode DefectDojo response: %w", err)
+	}
+	return &result, nil
+}
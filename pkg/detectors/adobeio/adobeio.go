@@ -4,7 +4,6 @@ import (
 	"context"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -34,49 +33,43 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	idMatches := idPat.FindAllStringSubmatch(dataStr, -1)
+	matches := detectors.FindMatches(keyPat, dataStr)
+	idMatches := detectors.FindMatches(idPat, dataStr)
 
-	for _, match := range matches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(matches, idMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resMatch := strings.TrimSpace(match[1])
-		for _, idMatch := range idMatches {
-			if len(idMatch) != 2 {
-				continue
-			}
-			resIdMatch := strings.TrimSpace(idMatch[1])
+		resMatch := parts[0].Value
+		resIdMatch := parts[1].Value
 
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_AdobeIO,
-				Raw:          []byte(resMatch),
-			}
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_AdobeIO,
+			Raw:          []byte(resMatch),
+		}
 
-			if verify {
-				req, err := http.NewRequestWithContext(ctx, "GET", "https://stock.adobe.io/Rest/Media/1/Search/Files?locale=en_US%2526search_parameters%255Bwords%255D=kittens", nil)
-				if err != nil {
-					continue
-				}
-				req.Header.Add("x-api-key", resMatch)
-				req.Header.Add("x-product", resIdMatch)
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					} else {
-						//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-						if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
+		if verify {
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://stock.adobe.io/Rest/Media/1/Search/Files?locale=en_US%2526search_parameters%255Bwords%255D=kittens", nil)
+			if err != nil {
+				continue
+			}
+			req.Header.Add("x-api-key", resMatch)
+			req.Header.Add("x-product", resIdMatch)
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
+						continue
 					}
 				}
 			}
-
-			results = append(results, s1)
 		}
 
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
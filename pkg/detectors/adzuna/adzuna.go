@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -34,47 +33,41 @@ func (s Scanner) Keywords() []string {
 // FromData will find and optionally verify Adzuna secrets in a given set of bytes.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	idMatches := idPat.FindAllStringSubmatch(dataStr, -1)
+	matches := detectors.FindMatches(keyPat, dataStr)
+	idMatches := detectors.FindMatches(idPat, dataStr)
 
-	for _, match := range matches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(matches, idMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resMatch := strings.TrimSpace(match[1])
+		resMatch := parts[0].Value
+		resIdMatch := parts[1].Value
 
-		for _, idMatch := range idMatches {
-			if len(idMatch) != 2 {
-				continue
-			}
-			resIdMatch := strings.TrimSpace(idMatch[1])
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Adzuna,
+			Raw:          []byte(resMatch),
+		}
 
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_Adzuna,
-				Raw:          []byte(resMatch),
+		if verify {
+			req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://api.adzuna.com/v1/api/jobs/gb/search/1?app_id=%s&app_key=%s", resIdMatch, resMatch), nil)
+			if err != nil {
+				continue
 			}
-
-			if verify {
-				req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://api.adzuna.com/v1/api/jobs/gb/search/1?app_id=%s&app_key=%s", resIdMatch, resMatch), nil)
-				if err != nil {
-					continue
-				}
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					} else {
-						//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-						if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
+						continue
 					}
 				}
 			}
-
-			results = append(results, s1)
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
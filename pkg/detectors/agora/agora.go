@@ -4,7 +4,6 @@ import (
 	"context"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -34,50 +33,43 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	secretMatches := secretPat.FindAllStringSubmatch(dataStr, -1)
+	matches := detectors.FindMatches(keyPat, dataStr)
+	secretMatches := detectors.FindMatches(secretPat, dataStr)
 
-	for _, match := range matches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(matches, secretMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
+		resMatch := parts[0].Value
+		resSecret := parts[1].Value
 
-		resMatch := strings.TrimSpace(match[1])
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Agora,
+			Raw:          []byte(resMatch),
+		}
 
-		for _, secret := range secretMatches {
-			if len(secret) != 2 {
+		if verify {
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://api.agora.io/dev/v1/projects", nil)
+			if err != nil {
 				continue
 			}
-			resSecret := strings.TrimSpace(secret[1])
-
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_Agora,
-				Raw:          []byte(resMatch),
-			}
-
-			if verify {
-				req, err := http.NewRequestWithContext(ctx, "GET", "https://api.agora.io/dev/v1/projects", nil)
-				if err != nil {
-					continue
-				}
-				req.SetBasicAuth(resSecret, resMatch)
-				res, err := client.Do(req)
-
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					} else {
-						//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-						if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
+			req.SetBasicAuth(resSecret, resMatch)
+			res, err := client.Do(req)
+
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
+						continue
 					}
 				}
 			}
-
-			results = append(results, s1)
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
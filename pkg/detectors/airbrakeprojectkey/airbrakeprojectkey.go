@@ -34,54 +34,45 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	idMatches := idPat.FindAllStringSubmatch(dataStr, -1)
+	matches := detectors.FindMatches(keyPat, dataStr)
+	idMatches := detectors.FindMatches(idPat, dataStr)
 
-	for _, match := range matches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(matches, idMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resMatch := strings.TrimSpace(match[1])
+		resMatch := parts[0].Value
+		resIdMatch := parts[1].Value
 
-		for _, idMatch := range idMatches {
-			if len(idMatch) != 2 {
-				continue
-			}
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_AirbrakeProjectKey,
+			Raw:          []byte(resMatch),
+		}
 
-			resIdMatch := strings.TrimSpace(idMatch[1])
+		if verify {
+			payload := strings.NewReader(`{"environment":"production","username":"john","email":"john@smith.com","repository":"https://github.com/airbrake/airbrake","revision":"38748467ea579e7ae64f7815452307c9d05e05c5","version":"v2.0"}`)
 
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_AirbrakeProjectKey,
-				Raw:          []byte(resMatch),
+			req, err := http.NewRequestWithContext(ctx, "POST", "https://api.airbrake.io/api/v4/projects/"+resIdMatch+"/deploys?key="+resMatch, payload)
+			if err != nil {
+				continue
 			}
-
-			if verify {
-				payload := strings.NewReader(`{"environment":"production","username":"john","email":"john@smith.com","repository":"https://github.com/airbrake/airbrake","revision":"38748467ea579e7ae64f7815452307c9d05e05c5","version":"v2.0"}`)
-
-				req, err := http.NewRequestWithContext(ctx, "POST", "https://api.airbrake.io/api/v4/projects/"+resIdMatch+"/deploys?key="+resMatch, payload)
-				if err != nil {
-					continue
-				}
-				req.Header.Add("Content-Type", "application/json")
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					}
+			req.Header.Add("Content-Type", "application/json")
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
 				}
 			}
+		}
 
-			if !s1.Verified {
-				if detectors.IsKnownFalsePositive(string(s1.Raw), detectors.DefaultFalsePositives, true) {
-					continue
-				}
+		if !s1.Verified {
+			if detectors.IsKnownFalsePositive(string(s1.Raw), detectors.DefaultFalsePositives, true) {
+				continue
 			}
-
-			results = append(results, s1)
-
 		}
 
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
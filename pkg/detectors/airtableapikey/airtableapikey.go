@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -34,48 +33,42 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	appMatches := appPat.FindAllStringSubmatch(dataStr, -1)
-	keyMatches := keyPat.FindAllStringSubmatch(dataStr, -1)
+	appMatches := detectors.FindMatches(appPat, dataStr)
+	keyMatches := detectors.FindMatches(keyPat, dataStr)
 
-	for _, keyMatch := range keyMatches {
-		if len(keyMatch) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(keyMatches, appMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		keyRes := strings.TrimSpace(keyMatch[1])
+		keyRes := parts[0].Value
+		appRes := parts[1].Value
 
-		for _, appMatch := range appMatches {
-			if len(appMatch) != 2 {
-				continue
-			}
-			appRes := strings.TrimSpace(appMatch[1])
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_AirtableApiKey,
+			Redacted:     appRes,
+			Raw:          []byte(keyRes),
+		}
 
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_AirtableApiKey,
-				Redacted:     appRes,
-				Raw:          []byte(keyRes),
+		if verify {
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://api.airtable.com/v0/"+appRes+"/Projects", nil)
+			if err != nil {
+				continue
 			}
-
-			if verify {
-				req, err := http.NewRequestWithContext(ctx, "GET", "https://api.airtable.com/v0/"+appRes+"/Projects", nil)
-				if err != nil {
-					continue
-				}
-				req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", keyRes))
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					} else {
-						if detectors.IsKnownFalsePositive(keyRes, detectors.DefaultFalsePositives, true) {
-							continue
-						}
+			req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", keyRes))
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					if detectors.IsKnownFalsePositive(keyRes, detectors.DefaultFalsePositives, true) {
+						continue
 					}
 				}
 			}
-
-			results = append(results, s1)
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
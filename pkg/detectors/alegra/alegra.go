@@ -4,7 +4,6 @@ import (
 	"context"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -34,49 +33,42 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	idMatches := idPat.FindAllStringSubmatch(dataStr, -1)
+	matches := detectors.FindMatches(keyPat, dataStr)
+	idMatches := detectors.FindMatches(idPat, dataStr)
 
-	for _, match := range matches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(matches, idMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		tokenPatMatch := strings.TrimSpace(match[1])
+		tokenPatMatch := parts[0].Value
+		userPatMatch := parts[1].Value
 
-		for _, idMatch := range idMatches {
-			if len(idMatch) != 2 {
-				continue
-			}
-
-			userPatMatch := strings.TrimSpace(idMatch[1])
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Alegra,
+			Raw:          []byte(tokenPatMatch),
+		}
 
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_Alegra,
-				Raw:          []byte(tokenPatMatch),
+		if verify {
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://api.alegra.com/api/v1/users", nil)
+			if err != nil {
+				continue
 			}
-
-			if verify {
-				req, err := http.NewRequestWithContext(ctx, "GET", "https://api.alegra.com/api/v1/users", nil)
-				if err != nil {
-					continue
-				}
-				req.SetBasicAuth(userPatMatch, tokenPatMatch)
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					} else {
-						//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-						if detectors.IsKnownFalsePositive(tokenPatMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
+			req.SetBasicAuth(userPatMatch, tokenPatMatch)
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(tokenPatMatch, detectors.DefaultFalsePositives, true) {
+						continue
 					}
 				}
 			}
-
-			results = append(results, s1)
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
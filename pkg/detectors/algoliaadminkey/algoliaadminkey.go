@@ -2,6 +2,7 @@ package algoliaadminkey
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"regexp"
 	"strings"
@@ -24,6 +25,9 @@ var (
 	idPat  = regexp.MustCompile(detectors.PrefixRegex([]string{"algolia"}) + `\b([A-Z0-9]{10})\b`)
 )
 
+// searchACLs are the permissions of keys that are safe to ship to browsers.
+var searchACLs = map[string]bool{"search": true, "listIndexes": true, "settings": true}
+
 // Keywords are used for efficiently pre-filtering chunks.
 // Use identifiers in the secret preferably, or the provider name.
 func (s Scanner) Keywords() []string {
@@ -31,51 +35,89 @@ func (s Scanner) Keywords() []string {
 }
 
 // FromData will find and optionally verify AlgoliaAdminKey secrets in a given set of bytes.
+// Keys are paired with the closest application ID. Verified admin keys are high severity, and search-only keys, which
+// are meant to be public, are low.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	idMatches := idPat.FindAllStringSubmatch(dataStr, -1)
+	keys := detectors.FindMatches(keyPat, dataStr)
+	ids := detectors.FindMatches(idPat, dataStr)
 
-	for _, match := range matches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(keys, ids) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resMatch := strings.TrimSpace(match[1])
-		for _, idMatch := range idMatches {
-			if len(idMatch) != 2 {
-				continue
-			}
-			resIdMatch := strings.TrimSpace(idMatch[1])
+		key, appID := parts[0].Value, parts[1].Value
 
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_AlgoliaAdminKey,
-				Raw:          []byte(resMatch),
-			}
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_AlgoliaAdminKey,
+			Raw:          []byte(key),
+			Redacted:     appID,
+		}
 
-			if verify {
-				req, err := http.NewRequestWithContext(ctx, "GET", "https://"+resIdMatch+"-dsn.algolia.net/1/keys", nil)
-				if err != nil {
-					continue
-				}
-				req.Header.Add("X-Algolia-Application-Id", resIdMatch)
-				req.Header.Add("X-Algolia-API-Key", resMatch)
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					} else {
-						//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-						if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
+		if verify {
+			if isAdminKey(ctx, appID, key) {
+				s1.Verified = true
+				s1.ExtraData = map[string]string{"app_id": appID, "key_type": "admin"}
+				s1.Severity = detectors.SeverityHigh
+			} else if acl, ok := keyACL(ctx, appID, key); ok {
+				s1.Verified = true
+				s1.ExtraData = map[string]string{"app_id": appID, "key_type": "search", "acl": strings.Join(acl, ",")}
+				s1.Severity = detectors.SeverityLow
+				for _, permission := range acl {
+					if !searchACLs[permission] {
+						s1.ExtraData["key_type"] = "write"
+						s1.Severity = detectors.SeverityMedium
 					}
 				}
 			}
+		}
 
-			results = append(results, s1)
+		if !s1.Verified && detectors.IsKnownFalsePositive(key, detectors.DefaultFalsePositives, true) {
+			continue
 		}
+
+		results = append(results, s1)
 	}
+
 	return detectors.CleanResults(results), nil
 }
+
+// isAdminKey checks whether the key can list the application's API keys, which only the admin key may do.
+func isAdminKey(ctx context.Context, appID, key string) bool {
+	res, err := get(ctx, appID, key, "/1/keys")
+	if err != nil {
+		return false
+	}
+	res.Body.Close()
+	return res.StatusCode == http.StatusOK
+}
+
+// keyACL returns the permissions of any other key, which every key may read for itself.
+func keyACL(ctx context.Context, appID, key string) ([]string, bool) {
+	res, err := get(ctx, appID, key, "/1/keys/"+key)
+	if err != nil {
+		return nil, false
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, false
+	}
+	var permissions struct {
+		ACL []string `json:"acl"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&permissions); err != nil {
+		return nil, false
+	}
+	return permissions.ACL, true
+}
+
+func get(ctx context.Context, appID, key, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://"+appID+"-dsn.algolia.net"+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("X-Algolia-Application-Id", appID)
+	req.Header.Add("X-Algolia-API-Key", key)
+	return client.Do(req)
+}
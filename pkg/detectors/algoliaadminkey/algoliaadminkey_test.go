@@ -10,6 +10,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
 )
 
@@ -93,6 +94,9 @@ func TestAlgoliaAdminKey_FromChunk(t *testing.T) {
 					t.Fatalf("no raw secret present: \n %+v", got[i])
 				}
 				got[i].Raw = nil
+				got[i].Redacted = ""
+				got[i].ExtraData = nil
+				got[i].Severity = detectors.SeverityUnknown
 			}
 			if diff := pretty.Compare(got, tt.want); diff != "" {
 				t.Errorf("AlgoliaAdminKey.FromData() %s diff: (-got +want)\n%s", tt.name, diff)
@@ -115,3 +119,59 @@ func BenchmarkFromData(benchmark *testing.B) {
 		})
 	}
 }
+
+func TestAlgoliaAdminKey_Scope(t *testing.T) {
+	const (
+		appID = "LATENCY042"
+		key   = "b7e4c9a2f1d8e3b6a5c0f9d2e7b4a1c8"
+	)
+	data := "ALGOLIA_APP_ID=" + appID + "\nALGOLIA_API_KEY=" + key
+
+	tests := map[string]struct {
+		mocks        []detectortest.Mock
+		wantType     string
+		wantSeverity detectors.Severity
+	}{
+		"admin key": {
+			mocks:        []detectortest.Mock{{Host: appID + "-dsn.algolia.net", Path: "/1/keys", Status: 200, Body: `{"keys": []}`}},
+			wantType:     "admin",
+			wantSeverity: detectors.SeverityHigh,
+		},
+		"search-only key": {
+			mocks: []detectortest.Mock{
+				{Host: appID + "-dsn.algolia.net", Path: "/1/keys", Status: 403, Body: `{"message": "Not enough rights", "status": 403}`},
+				{Host: appID + "-dsn.algolia.net", Path: "/1/keys/" + key, Status: 200, Body: `{"value": "` + key + `", "acl": ["search"]}`},
+			},
+			wantType:     "search",
+			wantSeverity: detectors.SeverityLow,
+		},
+		"write key": {
+			mocks: []detectortest.Mock{
+				{Host: appID + "-dsn.algolia.net", Path: "/1/keys", Status: 403, Body: `{"message": "Not enough rights", "status": 403}`},
+				{Host: appID + "-dsn.algolia.net", Path: "/1/keys/" + key, Status: 200, Body: `{"value": "` + key + `", "acl": ["search", "addObject", "deleteObject"]}`},
+			},
+			wantType:     "write",
+			wantSeverity: detectors.SeverityMedium,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			common.OverrideTransport(detectortest.NewMockTransport(tt.mocks...))
+			defer common.OverrideTransport(nil)
+
+			got, err := Scanner{}.FromData(context.Background(), true, []byte(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 1 || !got[0].Verified {
+				t.Fatalf("expected one verified result, got: %+v", got)
+			}
+			if got[0].Redacted != appID || got[0].ExtraData["key_type"] != tt.wantType {
+				t.Errorf("got app %q and key type %q, want %q and %q", got[0].Redacted, got[0].ExtraData["key_type"], appID, tt.wantType)
+			}
+			if got[0].Severity != tt.wantSeverity {
+				t.Errorf("got severity %v, want %v", got[0].Severity, tt.wantSeverity)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
 	log "github.com/sirupsen/logrus"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
 )
@@ -30,55 +31,53 @@ func (s Scanner) Keywords() []string {
 // FromData will find and optionally verify Alibaba secrets in a given set of bytes.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	for _, match := range matches {
-		//Plausible key pat found, look for secrets match
-		secMatches := secretPat.FindAllStringSubmatch(dataStr, -1)
+	matches := detectors.FindMatches(keyPat, dataStr)
+	secMatches := detectors.FindMatches(secretPat, dataStr)
 
-		for _, secMatch := range secMatches {
+	for _, parts := range detectors.PairMatches(matches, secMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		key := parts[0].Value
+		secret := parts[1].Value
 
-			if len(match) != 2 {
-				continue
-			}
+		s := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Alibaba,
+			Raw:          []byte(key),
+			Redacted:     key,
+		}
 
-			s := detectors.Result{
-				DetectorType: detectorspb.DetectorType_Alibaba,
-				Raw:          []byte(match[1]),
-				Redacted:     match[1],
+		if verify {
+			ecsClient, err := ecs.NewClientWithAccessKey(
+				"us-east-1", // your region ID
+				key,         // your AccessKey ID
+				secret)      // your AccessKey Secret
+			if err != nil {
+				log.WithError(err).Debug("error creating alibaba client, skipping")
+				continue
 			}
+			// Create an API request and set parameters
+			request := ecs.CreateDescribeInstancesRequest()
+			request.ConnectTimeout = time.Duration(5) * time.Second
+			request.Scheme = "https"
+			request.Domain = "ecs.aliyuncs.com"
+			// Initiate the request and handle exceptions
+			_, err = ecsClient.DescribeInstances(request)
+			if err != nil {
+				s.Verified = false
 
-			if verify {
-				ecsClient, err := ecs.NewClientWithAccessKey(
-					"us-east-1", // your region ID
-					match[1],    // your AccessKey ID
-					secMatch[1]) // your AccessKey Secret
-				if err != nil {
-					log.WithError(err).Debug("error creating alibaba client, skipping")
-					continue
-				}
-				// Create an API request and set parameters
-				request := ecs.CreateDescribeInstancesRequest()
-				request.ConnectTimeout = time.Duration(5) * time.Second
-				request.Scheme = "https"
-				request.Domain = "ecs.aliyuncs.com"
-				// Initiate the request and handle exceptions
-				_, err = ecsClient.DescribeInstances(request)
-				if err != nil {
-					s.Verified = false
-
-				} else {
-					s.Verified = true
-				}
+			} else {
+				s.Verified = true
 			}
+		}
 
-			if !s.Verified {
-				if detectors.IsKnownFalsePositive(string(s.Raw), detectors.DefaultFalsePositives, true) {
-					continue
-				}
+		if !s.Verified {
+			if detectors.IsKnownFalsePositive(string(s.Raw), detectors.DefaultFalsePositives, true) {
+				continue
 			}
-
-			results = append(results, s)
 		}
+
+		results = append(results, s)
 	}
 	return
 }
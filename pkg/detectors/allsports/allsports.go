@@ -40,6 +40,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
 
 	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
 		if len(match) != 2 {
 			continue
 		}
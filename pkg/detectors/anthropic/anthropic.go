@@ -0,0 +1,112 @@
+package anthropic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	client = common.SaneHttpClient()
+
+	keyPat = regexp.MustCompile(`\b(sk-ant-(api|admin)\d{2}-[A-Za-z0-9_-]{80,100}AA)`)
+
+	apiURL = "https://api.anthropic.com"
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"sk-ant-"}
+}
+
+// FromData will find and optionally verify Anthropic secrets in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
+
+	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		if len(match) != 3 {
+			continue
+		}
+		key := match[1]
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Anthropic,
+			Raw:          []byte(key),
+			ExtraData:    map[string]string{"key_type": match[2]},
+		}
+
+		if verify {
+			if match[2] == "admin" {
+				var organization string
+				organization, s1.Verified = verifyAdminKey(ctx, key)
+				if organization != "" {
+					s1.ExtraData["organization"] = organization
+				}
+			} else {
+				s1.Verified = verifyAPIKey(ctx, key)
+			}
+		}
+
+		if !s1.Verified && detectors.IsKnownFalsePositive(key, detectors.DefaultFalsePositives, true) {
+			continue
+		}
+
+		results = append(results, s1)
+	}
+
+	return detectors.CleanResults(results), nil
+}
+
+// verifyAPIKey lists models, which is free. Keys of accounts without credit are still valid, so rate limiting counts
+// as verified.
+func verifyAPIKey(ctx context.Context, key string) bool {
+	res, err := get(ctx, key, "/v1/models?limit=1")
+	if err != nil {
+		return false
+	}
+	defer res.Body.Close()
+	return res.StatusCode == http.StatusOK || res.StatusCode == http.StatusTooManyRequests
+}
+
+// verifyAdminKey returns the organization that the admin key manages.
+func verifyAdminKey(ctx context.Context, key string) (organization string, verified bool) {
+	res, err := get(ctx, key, "/v1/organizations/me")
+	if err != nil {
+		return "", false
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", false
+	}
+	var org struct {
+		Name string `json:"name"`
+	}
+	_ = json.NewDecoder(res.Body).Decode(&org)
+	return org.Name, true
+}
+
+func get(ctx context.Context, key, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("x-api-key", key)
+	req.Header.Add("anthropic-version", "2023-06-01")
+	return client.Do(req)
+}
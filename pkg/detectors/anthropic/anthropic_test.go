@@ -0,0 +1,62 @@
+package anthropic
+
+import (
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
+)
+
+func TestAnthropic_FromData(t *testing.T) {
+	const (
+		apiKey   = "sk-ant-REDACTED"
+		adminKey = "sk-ant-REDACTED"
+	)
+
+	detectortest.Test(t, Scanner{},
+		detectortest.Fixture{
+			Name:   "api key",
+			Data:   "ANTHROPIC_API_KEY=" + apiKey,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "api.anthropic.com",
+				Path:   "/v1/models",
+				Header: map[string]string{"x-api-key": apiKey},
+				Status: 200,
+				Body:   `{"data": [{"type": "model", "id": "claude-sonnet-4-20250514"}], "has_more": true}`,
+			}},
+			Want: []detectortest.Want{{Raw: apiKey, Verified: true, ExtraData: map[string]string{"key_type": "api"}}},
+		},
+		detectortest.Fixture{
+			Name:   "admin key",
+			Data:   `{"anthropic_admin_key": "` + adminKey + `"}`,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "api.anthropic.com",
+				Path:   "/v1/organizations/me",
+				Header: map[string]string{"x-api-key": adminKey},
+				Status: 200,
+				Body:   `{"id": "12345678-1234-5678-1234-567812345678", "type": "organization", "name": "Acme Research"}`,
+			}},
+			Want: []detectortest.Want{{
+				Raw:       adminKey,
+				Verified:  true,
+				ExtraData: map[string]string{"key_type": "admin", "organization": "Acme Research"},
+			}},
+		},
+		detectortest.Fixture{
+			Name:   "revoked key",
+			Data:   "ANTHROPIC_API_KEY=" + apiKey,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "api.anthropic.com",
+				Status: 401,
+				Body:   `{"type": "error", "error": {"type": "authentication_error", "message": "invalid x-api-key"}}`,
+			}},
+			Want: []detectortest.Want{{Raw: apiKey}},
+		},
+		detectortest.Fixture{
+			Name: "truncated key",
+			Data: "ANTHROPIC_API_KEY=sk-ant-REDACTED",
+		},
+	)
+}
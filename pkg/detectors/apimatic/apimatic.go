@@ -4,7 +4,6 @@ import (
 	"context"
 	"net/http"
 	"regexp"
-	"strings"
 	"time"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
@@ -35,51 +34,44 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	passMatches := passPat.FindAllStringSubmatch(dataStr, -1)
+	matches := detectors.FindMatches(keyPat, dataStr)
+	passMatches := detectors.FindMatches(passPat, dataStr)
 
-	for _, match := range matches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(matches, passMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		userPatMatch := strings.TrimSpace(match[1])
+		userPatMatch := parts[0].Value
+		passPatMatch := parts[1].Value
 
-		for _, idMatch := range passMatches {
-			if len(idMatch) != 2 {
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_APIMatic,
+			Raw:          []byte(userPatMatch),
+		}
+		if verify {
+			timeout := 10 * time.Second
+			client.Timeout = timeout
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://www.apimatic.io/api/code-generations", nil)
+			if err != nil {
 				continue
 			}
+			req.SetBasicAuth(userPatMatch, passPatMatch)
+			res, err := client.Do(req)
 
-			passPatMatch := strings.TrimSpace(idMatch[1])
-
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_APIMatic,
-				Raw:          []byte(userPatMatch),
-			}
-			if verify {
-				timeout := 10 * time.Second
-				client.Timeout = timeout
-				req, err := http.NewRequestWithContext(ctx, "GET", "https://www.apimatic.io/api/code-generations", nil)
-				if err != nil {
-					continue
-				}
-				req.SetBasicAuth(userPatMatch, passPatMatch)
-				res, err := client.Do(req)
-
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					} else {
-						//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-						if detectors.IsKnownFalsePositive(passPatMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(passPatMatch, detectors.DefaultFalsePositives, true) {
+						continue
 					}
 				}
 			}
-
-			results = append(results, s1)
 		}
+
+		results = append(results, s1)
 	}
 	return detectors.CleanResults(results), nil
 }
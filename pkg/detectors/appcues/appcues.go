@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -36,57 +35,43 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	userMatches := userPat.FindAllStringSubmatch(dataStr, -1)
-	idMatches := idPat.FindAllStringSubmatch(dataStr, -1)
+	matches := detectors.FindMatches(keyPat, dataStr)
+	userMatches := detectors.FindMatches(userPat, dataStr)
+	idMatches := detectors.FindMatches(idPat, dataStr)
 
-	for _, match := range matches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(matches, userMatches, idMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resMatch := strings.TrimSpace(match[1])
+		resMatch := parts[0].Value
+		resUserMatch := parts[1].Value
+		resIdMatch := parts[2].Value
 
-		for _, userMatch := range userMatches {
-			if len(userMatch) != 2 {
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Appcues,
+			Raw:          []byte(resMatch),
+		}
+		if verify {
+			req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://api.appcues.com/v2/accounts/%s/flows", resIdMatch), nil)
+			if err != nil {
 				continue
 			}
-
-			resUserMatch := strings.TrimSpace(userMatch[1])
-
-			for _, idMatch := range idMatches {
-				if len(idMatch) != 2 {
-					continue
-				}
-
-				resIdMatch := strings.TrimSpace(idMatch[1])
-
-				s1 := detectors.Result{
-					DetectorType: detectorspb.DetectorType_Appcues,
-					Raw:          []byte(resMatch),
-				}
-				if verify {
-					req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://api.appcues.com/v2/accounts/%s/flows", resIdMatch), nil)
-					if err != nil {
+			req.SetBasicAuth(resUserMatch, resMatch)
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
 						continue
 					}
-					req.SetBasicAuth(resUserMatch, resMatch)
-					res, err := client.Do(req)
-					if err == nil {
-						defer res.Body.Close()
-						if res.StatusCode >= 200 && res.StatusCode < 300 {
-							s1.Verified = true
-						} else {
-							//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-							if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
-								continue
-							}
-						}
-					}
 				}
-
-				results = append(results, s1)
 			}
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
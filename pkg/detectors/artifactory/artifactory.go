@@ -4,7 +4,6 @@ import (
 	"context"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -33,23 +32,20 @@ func (s Scanner) Keywords() []string {
 // FromData will find and optionally verify Artifactory secrets in a given set of bytes.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
-	URLmatches := URLPat.FindAllStringSubmatch(dataStr, -1)
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
 
-	resURLMatch := ""
-	for _, URLmatch := range URLmatches {
-		if len(URLmatch) != 2 {
-			continue
-		}
-		resURLMatch = strings.TrimSpace(URLmatch[1])
-
-	}
+	matches := detectors.FindMatches(keyPat, dataStr)
+	URLmatches := detectors.FindMatches(URLPat, dataStr)
 
 	for _, match := range matches {
-		if len(match) != 2 {
-			continue
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		resMatch := match.Value
+		// The token is verified with the closest instance URL, if there is one.
+		resURLMatch := ""
+		for _, parts := range detectors.PairMatches([]detectors.Match{match}, URLmatches) {
+			resURLMatch = parts[1].Value
 		}
-		resMatch := strings.TrimSpace(match[1])
 
 		s1 := detectors.Result{
 			DetectorType: detectorspb.DetectorType_ArtifactoryAccessToken,
@@ -2,10 +2,14 @@ package auth0managementapitoken
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -26,6 +30,15 @@ var (
 	domainPat             = regexp.MustCompile(`([a-zA-Z0-9\-]{2,16}\.[a-zA-Z0-9_-]{2,3}\.auth0.com)`) // could be part of url
 )
 
+// claims are the parts of a Management API token used to find its tenant and what it may do.
+type claims struct {
+	Issuer    string          `json:"iss"`
+	Audience  json.RawMessage `json:"aud"`
+	Scope     string          `json:"scope"`
+	ExpiresAt int64           `json:"exp"`
+	ClientID  string          `json:"azp"`
+}
+
 // Keywords are used for efficiently pre-filtering chunks.
 // Use identifiers in the secret preferably, or the provider name.
 func (s Scanner) Keywords() []string {
@@ -36,56 +49,119 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	managementApiTokenMatches := managementApiTokenPat.FindAllStringSubmatch(dataStr, -1)
-	domainMatches := domainPat.FindAllStringSubmatch(dataStr, -1)
+	domainMatches := detectors.FindMatches(domainPat, dataStr)
 
-	for _, managementApiTokenMatch := range managementApiTokenMatches {
-		if len(managementApiTokenMatch) != 2 {
-			continue
+	for _, tokenMatch := range detectors.FindMatches(managementApiTokenPat, dataStr) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		managementApiTokenRes := strings.TrimSpace(managementApiTokenMatch[1])
-		if len(managementApiTokenRes) < 2000 || len(managementApiTokenRes) > 5000 {
+		managementApiTokenRes := tokenMatch.Value
+		tokenClaims, ok := parseClaims(managementApiTokenRes)
+		if !ok {
 			continue
 		}
 
-		for _, domainMatch := range domainMatches {
-			if len(domainMatch) != 2 {
-				continue
-			}
-			domainRes := strings.TrimSpace(domainMatch[1])
+		// The issuer is the tenant the token was issued by, which is the only one it's valid for. Without one, the
+		// closest tenant domain is used.
+		domainRes := issuerHost(tokenClaims.Issuer)
+		if domainRes == "" && len(domainMatches) > 0 {
+			domainRes = detectors.PairMatches([]detectors.Match{tokenMatch}, domainMatches)[0][1].Value
+		}
+		if domainRes == "" {
+			continue
+		}
 
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_Auth0ManagementApiToken,
-				Redacted:     domainRes,
-				Raw:          []byte(managementApiTokenRes),
-			}
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Auth0ManagementApiToken,
+			Redacted:     domainRes,
+			Raw:          []byte(managementApiTokenRes),
+			ExtraData:    extraData(domainRes, tokenClaims),
+		}
 
-			if verify {
-				/*
-				   curl -H "Authorization: Bearer $token" https://domain/api/v2/users
-				*/
+		if verify {
+			/*
+			   curl -H "Authorization: Bearer $token" https://domain/api/v2/users
+			*/
 
-				req, err := http.NewRequestWithContext(ctx, "GET", "https://"+domainRes+"/api/v2/users", nil)
-				if err != nil {
-					continue
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://"+domainRes+"/api/v2/users?per_page=1", nil)
+			if err != nil {
+				continue
+			}
+			req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", managementApiTokenRes))
+			res, err := client.Do(req)
+			if err == nil {
+				var body struct {
+					ErrorCode string `json:"errorCode"`
 				}
-				req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", managementApiTokenRes))
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					} else {
-						if detectors.IsKnownFalsePositive(managementApiTokenRes, detectors.DefaultFalsePositives, true) {
-							continue
-						}
-					}
+				_ = json.NewDecoder(res.Body).Decode(&body)
+				res.Body.Close()
+				// Tokens without the read:users scope are valid, but forbidden from listing users.
+				if (res.StatusCode >= 200 && res.StatusCode < 300) || body.ErrorCode == "insufficient_scope" {
+					s1.Verified = true
 				}
 			}
+		}
 
-			results = append(results, s1)
+		if !s1.Verified && detectors.IsKnownFalsePositive(managementApiTokenRes, detectors.DefaultFalsePositives, true) {
+			continue
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
 }
+
+// parseClaims decodes the token's claims without checking its signature, and returns false unless it's for the
+// Management API, whose audience is the tenant's /api/v2/ URL.
+func parseClaims(token string) (claims, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return claims{}, false
+	}
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return claims{}, false
+	}
+
+	var audiences []string
+	if err := json.Unmarshal(c.Audience, &audiences); err != nil {
+		var audience string
+		if err := json.Unmarshal(c.Audience, &audience); err != nil {
+			return claims{}, false
+		}
+		audiences = []string{audience}
+	}
+	for _, audience := range audiences {
+		if strings.HasSuffix(audience, "/api/v2/") {
+			return c, true
+		}
+	}
+	return claims{}, false
+}
+
+func issuerHost(issuer string) string {
+	u, err := url.Parse(issuer)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+func extraData(domain string, c claims) map[string]string {
+	extraData := map[string]string{"tenant": domain}
+	if c.ClientID != "" {
+		extraData["client_id"] = c.ClientID
+	}
+	if c.Scope != "" {
+		extraData["scopes"] = strings.ReplaceAll(c.Scope, " ", ",")
+	}
+	if c.ExpiresAt != 0 {
+		extraData["expires"] = time.Unix(c.ExpiresAt, 0).UTC().Format(time.RFC3339)
+	}
+	return extraData
+}
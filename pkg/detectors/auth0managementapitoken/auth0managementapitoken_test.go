@@ -2,12 +2,14 @@ package auth0managementapitoken
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
@@ -97,6 +99,7 @@ func TestAuth0ManagementApiToken_FromChunk(t *testing.T) {
 					t.Fatalf("no raw secret present: \n %+v", got[i])
 				}
 				got[i].Raw = nil
+				got[i].ExtraData = nil
 			}
 			if diff := pretty.Compare(got, tt.want); diff != "" {
 				t.Errorf("Auth0ManagementApiToken.FromData() %s diff: (-got +want)\n%s", tt.name, diff)
@@ -105,6 +108,65 @@ func TestAuth0ManagementApiToken_FromChunk(t *testing.T) {
 	}
 }
 
+func TestAuth0ManagementApiToken_Claims(t *testing.T) {
+	token := testToken(`{"iss": "https://acme.us.auth0.com/", "aud": "https://acme.us.auth0.com/api/v2/",
+		"azp": "Xk2qLm8Rt4Wz9Kb3Nc7Vp1Hd6Jf0Gs5Y", "scope": "read:users update:users", "exp": 4102444800}`)
+	otherAudience := testToken(`{"iss": "https://acme.us.auth0.com/", "aud": "https://api.acme.com/", "exp": 4102444800}`)
+
+	detectortest.Test(t, Scanner{},
+		detectortest.Fixture{
+			Name:   "management API token",
+			Data:   "AUTH0_TOKEN=" + token,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "acme.us.auth0.com",
+				Path:   "/api/v2/users",
+				Header: map[string]string{"Authorization": "Bearer " + token},
+				Status: 200,
+				Body:   `[]`,
+			}},
+			Want: []detectortest.Want{{
+				Raw:      token,
+				Verified: true,
+				ExtraData: map[string]string{
+					"tenant":    "acme.us.auth0.com",
+					"client_id": "Xk2qLm8Rt4Wz9Kb3Nc7Vp1Hd6Jf0Gs5Y",
+					"scopes":    "read:users,update:users",
+					"expires":   "2100-01-01T00:00:00Z",
+				},
+			}},
+		},
+		detectortest.Fixture{
+			Name:   "token without read:users",
+			Data:   "AUTH0_TOKEN=" + token,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "acme.us.auth0.com",
+				Status: 403,
+				Body:   `{"statusCode": 403, "error": "Forbidden", "message": "Insufficient scope, expected any of: read:users", "errorCode": "insufficient_scope"}`,
+			}},
+			Want: []detectortest.Want{{Raw: token, Verified: true}},
+		},
+		detectortest.Fixture{
+			Name:   "expired token",
+			Data:   "AUTH0_TOKEN=" + token,
+			Verify: true,
+			Mocks:  []detectortest.Mock{{Host: "acme.us.auth0.com", Status: 401, Body: `{"statusCode": 401, "error": "Unauthorized", "message": "Expired token received for JSON Web Token validation"}`}},
+			Want:   []detectortest.Want{{Raw: token}},
+		},
+		detectortest.Fixture{
+			Name: "access token for another API",
+			Data: "AUTH0_TOKEN=" + otherAudience,
+		},
+	)
+}
+
+// testToken returns an unsigned JWT with the claims.
+func testToken(claims string) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	return encode([]byte(`{"alg":"RS256","typ":"JWT"}`)) + "." + encode([]byte(claims)) + ".c2lnbmF0dXJlLW5vdC1jaGVja2Vk"
+}
+
 func BenchmarkFromData(benchmark *testing.B) {
 	ctx := context.Background()
 	s := Scanner{}
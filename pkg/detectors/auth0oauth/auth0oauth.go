@@ -39,81 +39,70 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	clientIdMatches := clientIdPat.FindAllStringSubmatch(dataStr, -1)
-	clientSecretMatches := clientSecretPat.FindAllStringSubmatch(dataStr, -1)
-	domainMatches := domainPat.FindAllStringSubmatch(dataStr, -1)
+	clientIdMatches := detectors.FindMatches(clientIdPat, dataStr)
+	clientSecretMatches := detectors.FindMatches(clientSecretPat, dataStr)
+	domainMatches := detectors.FindMatches(domainPat, dataStr)
 
-	for _, clientIdMatch := range clientIdMatches {
-		if len(clientIdMatch) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(clientSecretMatches, clientIdMatches, domainMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		clientSecretRes := parts[0].Value
+		clientIdRes := parts[1].Value
+		domainRes := parts[2].Value
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Auth0oauth,
+			Redacted:     clientIdRes,
+			Raw:          []byte(clientSecretRes),
+			ExtraData:    map[string]string{"client_id": clientIdRes, "tenant": domainRes},
 		}
-		clientIdRes := strings.TrimSpace(clientIdMatch[1])
 
-		for _, clientSecretMatch := range clientSecretMatches {
-			if len(clientSecretMatch) != 2 {
+		if verify {
+			/*
+			   curl --request POST \
+			     --url 'https://YOUR_DOMAIN/oauth/token' \
+			     --header 'content-type: application/x-www-form-urlencoded' \
+			     --data 'grant_type=authorization_code&client_id=W44JmL3qD6LxHeEJyKe9lMuhcwvPOaOq&client_secret=YOUR_CLIENT_SECRET&code=AUTHORIZATION_CODE&redirect_uri=undefined'
+			*/
+
+			data := url.Values{}
+			data.Set("grant_type", "authorization_code")
+			data.Set("client_id", clientIdRes)
+			data.Set("client_secret", clientSecretRes)
+			data.Set("code", "AUTHORIZATION_CODE")
+			data.Set("redirect_uri", "undefined")
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+domainRes+"/oauth/token", strings.NewReader(data.Encode())) // URL-encoded payload
+			if err != nil {
 				continue
 			}
-			clientSecretRes := strings.TrimSpace(clientSecretMatch[1])
-
-			for _, domainMatch := range domainMatches {
-				if len(domainMatch) != 2 {
+			req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				bodyBytes, err := ioutil.ReadAll(res.Body)
+				if err != nil {
 					continue
 				}
-				domainRes := strings.TrimSpace(domainMatch[1])
+				body := string(bodyBytes)
 
-				s1 := detectors.Result{
-					DetectorType: detectorspb.DetectorType_Auth0oauth,
-					Redacted:     clientIdRes,
-					Raw:          []byte(clientSecretRes),
-				}
+				// if client_id and client_secret is valid -> 403 {"error":"invalid_grant","error_description":"Invalid authorization code"}
+				// if invalid -> 401 {"error":"access_denied","error_description":"Unauthorized"}
+				// ingenious!
 
-				if verify {
-					/*
-					   curl --request POST \
-					     --url 'https://YOUR_DOMAIN/oauth/token' \
-					     --header 'content-type: application/x-www-form-urlencoded' \
-					     --data 'grant_type=authorization_code&client_id=W44JmL3qD6LxHeEJyKe9lMuhcwvPOaOq&client_secret=YOUR_CLIENT_SECRET&code=AUTHORIZATION_CODE&redirect_uri=undefined'
-					*/
-
-					data := url.Values{}
-					data.Set("grant_type", "authorization_code")
-					data.Set("client_id", clientIdRes)
-					data.Set("client_secret", clientSecretRes)
-					data.Set("code", "AUTHORIZATION_CODE")
-					data.Set("redirect_uri", "undefined")
-
-					req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+domainRes+"/oauth/token", strings.NewReader(data.Encode())) // URL-encoded payload
-					if err != nil {
+				if !strings.Contains(body, "access_denied") {
+					s1.Verified = true
+				} else {
+					if detectors.IsKnownFalsePositive(clientIdRes, detectors.DefaultFalsePositives, true) {
 						continue
 					}
-					req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-					res, err := client.Do(req)
-					if err == nil {
-						defer res.Body.Close()
-						bodyBytes, err := ioutil.ReadAll(res.Body)
-						if err != nil {
-							continue
-						}
-						body := string(bodyBytes)
-
-						// if client_id and client_secret is valid -> 403 {"error":"invalid_grant","error_description":"Invalid authorization code"}
-						// if invalid -> 401 {"error":"access_denied","error_description":"Unauthorized"}
-						// ingenious!
-
-						if !strings.Contains(body, "access_denied") {
-							s1.Verified = true
-						} else {
-							if detectors.IsKnownFalsePositive(clientIdRes, detectors.DefaultFalsePositives, true) {
-								continue
-							}
-						}
-
-					}
 				}
 
-				results = append(results, s1)
 			}
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
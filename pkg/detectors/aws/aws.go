@@ -3,8 +3,10 @@ package aws
 import (
 	"context"
 	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 
@@ -22,8 +24,27 @@ var (
 	// Key types are from this list https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_identifiers.html#identifiers-unique-ids
 	keyPat    = regexp.MustCompile(`\b((?:AKIA|ABIA|ACCA|ASIA)[0-9A-Z]{16})\b`)
 	secretPat = regexp.MustCompile(`\b([A-Za-z0-9+/]{40})\b`)
+	// Temporary credentials from STS also need a session token, usually found under one of these names.
+	sessionTokenPat = regexp.MustCompile(`(?i)(?:aws.?session.?token|aws.?security.?token|x-amz-security-token)["'\s:=]{1,10}([A-Za-z0-9/+=]{100,})`)
+
+	region      = defaultRegion
+	stsEndpoint string
 )
 
+const defaultRegion = "us-west-2"
+
+// SetEndpoint sets the region to verify credentials in, us-west-2 if it's empty, and the URL of the STS endpoint to
+// call instead of the region's public one, such as an interface VPC endpoint, so that credentials can be verified from
+// private networks. Requests are always signed with only the credentials found, never with the SDK's default
+// credential chain. It must be called before scanning starts.
+func SetEndpoint(r, endpoint string) {
+	region = r
+	if region == "" {
+		region = defaultRegion
+	}
+	stsEndpoint = endpoint
+}
+
 // Keywords are used for efficiently pre-filtering chunks.
 // Use identifiers in the secret preferably, or the provider name.
 func (s Scanner) Keywords() []string {
@@ -35,42 +56,82 @@ func (s Scanner) Keywords() []string {
 	}
 }
 
+// KeywordAliases are the other names keys are found under, such as in an amazon.credentials file or an
+// AMAZON_ACCESS_KEY_ID variable, and the prefix of long-term access key IDs.
+func (s Scanner) KeywordAliases() []string {
+	return []string{"amazon", "AKIA"}
+}
+
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) ([]detectors.Result, error) {
 	dataStr := string(data)
 	var results []detectors.Result
 
-	keyMatches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	secretMatches := secretPat.FindAllStringSubmatch(dataStr, -1)
+	keyMatches := detectors.FindMatches(keyPat, dataStr)
+	secretMatches := detectors.FindMatches(secretPat, dataStr)
+	sessionTokens := detectors.FindMatches(sessionTokenPat, dataStr)
+	profiles := credentialsFileProfiles(dataStr)
 
 	for _, keyMatch := range keyMatches {
-		if len(keyMatch) != 2 {
-			continue
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-
-		key := strings.TrimSpace(keyMatch[1])
+		key := keyMatch.Value
 
 		s := detectors.Result{
 			DetectorType: detectorspb.DetectorType_AWS,
 			Raw:          []byte(key),
 			Redacted:     key,
 		}
+		// The key is tried with the closest secret, rather than every secret in the chunk.
+		var secrets []string
+		for _, parts := range detectors.PairMatches([]detectors.Match{keyMatch}, secretMatches) {
+			secrets = append(secrets, parts[1].Value)
+		}
+		// Keys starting with ASIA are temporary credentials, which are only valid with their session token.
+		tokens := []string{""}
+		if strings.HasPrefix(key, "ASIA") {
+			s.DetectorType = detectorspb.DetectorType_AWSSessionKey
+			tokens = nil
+			for _, parts := range detectors.PairMatches([]detectors.Match{keyMatch}, sessionTokens) {
+				tokens = append(tokens, parts[1].Value)
+			}
+		}
+		// A key in a credentials file is most likely valid with the secret in its own profile, so it's tried first.
+		if p, ok := profiles[key]; ok {
+			s.ExtraData = map[string]string{"profile": p.name}
+			if p.region != "" {
+				s.ExtraData["region"] = p.region
+			}
+			if p.secret != "" {
+				secrets = prepend(p.secret, secrets)
+			}
+			if p.sessionToken != "" && s.DetectorType == detectorspb.DetectorType_AWSSessionKey {
+				tokens = prepend(p.sessionToken, tokens)
+			}
+		}
 		// TODO: Remove possible matches if they verify positive.
 		if verify {
-			for _, secretMatch := range secretMatches {
-				if len(secretMatch) != 2 {
-					continue
-				}
-
-				secret := strings.TrimSpace(secretMatch[1])
-
-				result, err := callerIdentity(ctx, key, secret)
-				if err != nil {
-					// It also errors for signature mismatches on the client side before sending, and it's quite noisy.
-					continue
-				}
-				if result != nil && result.Account != nil {
-					s.Verified = true
-					break
+		secrets:
+			for _, secret := range secrets {
+				for _, token := range tokens {
+					result, err := callerIdentity(ctx, key, secret, token)
+					if err != nil {
+						// It also errors for signature mismatches on the client side before sending, and it's quite noisy.
+						continue
+					}
+					if result != nil && result.Account != nil {
+						s.Verified = true
+						if s.ExtraData == nil {
+							s.ExtraData = map[string]string{}
+						}
+						for k, v := range identityExtraData(result) {
+							s.ExtraData[k] = v
+						}
+						if isRootARN(aws.ToString(result.Arn)) {
+							s.Severity = detectors.SeverityCritical
+						}
+						break secrets
+					}
 				}
 			}
 		}
@@ -89,13 +150,45 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) ([]dete
 	return detectors.CleanResults(results), nil
 }
 
-func callerIdentity(ctx context.Context, key, secret string) (*sts.GetCallerIdentityOutput, error) {
-	svc := sts.New(sts.Options{
+// prepend moves or adds value to the front of values.
+func prepend(value string, values []string) []string {
+	result := []string{value}
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// callerIdentity returns the account and principal of the credentials. The session token is empty for long-term keys.
+func callerIdentity(ctx context.Context, key, secret, sessionToken string) (*sts.GetCallerIdentityOutput, error) {
+	opts := sts.Options{
 		HTTPClient:  common.SaneHttpClient(),
 		Logger:      nil,
-		Region:      "us-west-2",
-		Credentials: credentials.NewStaticCredentialsProvider(key, secret, ""),
-	})
+		Region:      region,
+		Credentials: credentials.NewStaticCredentialsProvider(key, secret, sessionToken),
+	}
+	if stsEndpoint != "" {
+		opts.EndpointResolver = sts.EndpointResolverFromURL(stsEndpoint)
+	}
+	svc := sts.New(opts)
 	result, err := svc.GetCallerIdentity(ctx, nil)
 	return result, err
 }
+
+// identityExtraData describes the principal that owns the credentials. Root credentials have unrestricted access to
+// the account, so they are flagged.
+func identityExtraData(identity *sts.GetCallerIdentityOutput) map[string]string {
+	return map[string]string{
+		"account": aws.ToString(identity.Account),
+		"arn":     aws.ToString(identity.Arn),
+		"user_id": aws.ToString(identity.UserId),
+		"is_root": strconv.FormatBool(isRootARN(aws.ToString(identity.Arn))),
+	}
+}
+
+// isRootARN returns true for the ARN of an account's root user, e.g. arn:aws:iam::123456789012:root.
+func isRootARN(arn string) bool {
+	return strings.HasPrefix(arn, "arn:aws") && strings.HasSuffix(arn, ":root")
+}
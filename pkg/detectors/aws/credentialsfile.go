@@ -0,0 +1,57 @@
+package aws
+
+import (
+	"bufio"
+	"strings"
+)
+
+// profile is a section of an AWS shared credentials or config file, such as ~/.aws/credentials.
+type profile struct {
+	name         string
+	secret       string
+	sessionToken string
+	region       string
+}
+
+// credentialsFileProfiles returns the profiles in data, by access key ID, if it's in the format of an AWS shared
+// credentials file. Profiles tie a key to its secret exactly, rather than by proximity.
+func credentialsFileProfiles(data string) map[string]profile {
+	profiles := map[string]profile{}
+	var name, key string
+	var current profile
+	flush := func() {
+		if key != "" {
+			current.name = name
+			profiles[key] = current
+		}
+		key, current = "", profile{}
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			flush()
+			// The config file prefixes names with "profile ", except for the default profile.
+			name = strings.TrimSpace(strings.TrimPrefix(strings.Trim(line, "[]"), "profile "))
+			continue
+		}
+		split := strings.SplitN(line, "=", 2)
+		if name == "" || len(split) != 2 {
+			continue
+		}
+		value := strings.TrimSpace(split[1])
+		switch strings.ToLower(strings.TrimSpace(split[0])) {
+		case "aws_access_key_id":
+			key = value
+		case "aws_secret_access_key":
+			current.secret = value
+		case "aws_session_token", "aws_security_token":
+			current.sessionToken = value
+		case "region":
+			current.region = value
+		}
+	}
+	flush()
+	return profiles
+}
@@ -2,12 +2,15 @@ package azure
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
 
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
 )
@@ -23,65 +26,142 @@ func mustFmtPat(id, pat string) *regexp.Regexp {
 }
 
 var (
-	// TODO: Azure storage access keys and investigate other types of creds.
+	client = common.SaneHttpClient()
+
+	// Storage account keys and SAS tokens are found by the azurestorage and azuresas detectors.
 
 	// Azure App Oauth
-	idPatFmt    = `(?i)(%s).{0,20}([a-f0-9]{8}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{12})`
+	idPatFmt    = `(?i)(?:%s).{0,20}([a-f0-9]{8}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{12})`
 	clientIDPat = mustFmtPat("client_id", idPatFmt)
 	tenantIDPat = mustFmtPat("tenant_id", idPatFmt)
 
 	// TODO: support old patterns
-	secretPatFmt    = `(?i)(%s).{0,20}([a-z0-9_\.\-~]{34})`
+	secretPatFmt    = `(?i)(?:%s).{0,20}([a-z0-9_\.\-~]{34})`
 	clientSecretPat = mustFmtPat("client_secret", secretPatFmt)
+
+	authorityHost   = defaultAuthorityHost
+	resourceManager = defaultResourceManager
+)
+
+const (
+	defaultAuthorityHost   = "https://login.microsoftonline.com/"
+	defaultResourceManager = "https://management.azure.com/"
 )
 
+// SetEndpoints sets the Entra ID authority host to request tokens from and the Resource Manager endpoint to list
+// subscriptions with, such as those of a sovereign cloud or private endpoints, so that secrets can be verified from
+// private networks. Empty values keep the public cloud's endpoints. Tokens are always requested with only the
+// credentials found. It must be called before scanning starts.
+func SetEndpoints(authority, resourceManagerURL string) {
+	authorityHost = withTrailingSlash(authority, defaultAuthorityHost)
+	resourceManager = withTrailingSlash(resourceManagerURL, defaultResourceManager)
+}
+
+func withTrailingSlash(url, fallback string) string {
+	if url == "" {
+		return fallback
+	}
+	return strings.TrimSuffix(url, "/") + "/"
+}
+
 // Keywords are used for efficiently pre-filtering chunks.
 // Use identifiers in the secret preferably, or the provider name.
 func (s Scanner) Keywords() []string {
 	return []string{"azure"}
 }
 
+// KeywordAliases are the other names app registration credentials are found under, such as the Entra ID brand, the
+// MSAL library, and the login.microsoftonline.com authority.
+func (s Scanner) KeywordAliases() []string {
+	return []string{"entra", "msal", "microsoftonline"}
+}
+
 // FromData will find and optionally verify Azure secrets in a given set of bytes.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	clientSecretMatches := clientSecretPat.FindAllStringSubmatch(dataStr, -1)
-	for _, clientSecret := range clientSecretMatches {
-		tenantIDMatches := tenantIDPat.FindAllStringSubmatch(dataStr, -1)
-		for _, tenantID := range tenantIDMatches {
-			clientIDMatches := clientIDPat.FindAllStringSubmatch(dataStr, -1)
-			for _, clientID := range clientIDMatches {
-				s := detectors.Result{
-					DetectorType: detectorspb.DetectorType_Azure,
-					Raw:          []byte(clientSecret[2]),
-					Redacted:     clientID[2],
-				}
+	clientSecretMatches := detectors.FindMatches(clientSecretPat, dataStr)
+	tenantIDMatches := detectors.FindMatches(tenantIDPat, dataStr)
+	clientIDMatches := detectors.FindMatches(clientIDPat, dataStr)
 
-				if verify {
-					cred := auth.NewClientCredentialsConfig(clientID[2], clientSecret[2], tenantID[2])
-					token, err := cred.ServicePrincipalToken()
-					if err != nil {
-						continue
-					}
-					err = token.Refresh()
-					if err == nil {
-						s.Verified = true
-					}
-				}
+	for _, parts := range detectors.PairMatches(clientSecretMatches, tenantIDMatches, clientIDMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		clientSecret := parts[0].Value
+		tenantID := parts[1].Value
+		clientID := parts[2].Value
+
+		s := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Azure,
+			Raw:          []byte(clientSecret),
+			Redacted:     clientID,
+			ExtraData: map[string]string{
+				"tenant_id": tenantID,
+				"client_id": clientID,
+			},
+		}
 
-				if !s.Verified {
-					if detectors.IsKnownFalsePositive(s.Redacted, detectors.DefaultFalsePositives, true) {
-						continue
-					}
-					if detectors.IsKnownFalsePositive(string(s.Raw), detectors.DefaultFalsePositives, true) {
-						continue
-					}
+		if verify {
+			cred := auth.NewClientCredentialsConfig(clientID, clientSecret, tenantID)
+			cred.AADEndpoint = authorityHost
+			cred.Resource = resourceManager
+			token, err := cred.ServicePrincipalToken()
+			if err == nil {
+				token.SetSender(client)
+				err = token.RefreshWithContext(ctx)
+			}
+			if err == nil {
+				s.Verified = true
+				// The service principal may have no role on any subscription, which is still a valid secret.
+				if subscriptions, err := listSubscriptions(ctx, token.OAuthToken()); err == nil && len(subscriptions) > 0 {
+					s.ExtraData["subscriptions"] = strings.Join(subscriptions, ",")
 				}
+			}
+		}
 
-				results = append(results, s)
+		if !s.Verified {
+			if detectors.IsKnownFalsePositive(s.Redacted, detectors.DefaultFalsePositives, true) {
+				continue
+			}
+			if detectors.IsKnownFalsePositive(string(s.Raw), detectors.DefaultFalsePositives, true) {
+				continue
 			}
 		}
+
+		results = append(results, s)
 	}
 
 	return detectors.CleanResults(results), nil
 }
+
+// listSubscriptions returns the IDs of the subscriptions the token's principal has access to.
+func listSubscriptions(ctx context.Context, accessToken string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", resourceManager+"subscriptions?api-version=2020-01-01", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Authorization", "Bearer "+accessToken)
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status listing subscriptions: %d", res.StatusCode)
+	}
+
+	var body struct {
+		Value []struct {
+			SubscriptionID string `json:"subscriptionId"`
+		} `json:"value"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	var subscriptions []string
+	for _, sub := range body.Value {
+		subscriptions = append(subscriptions, sub.SubscriptionID)
+	}
+	return subscriptions, nil
+}
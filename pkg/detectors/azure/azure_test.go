@@ -9,6 +9,7 @@ import (
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
 )
 
@@ -101,6 +102,8 @@ func TestAzure_FromChunk(t *testing.T) {
 			}
 			for i := range got {
 				got[i].Raw = nil
+				// Subscriptions depend on the test principal's role assignments.
+				got[i].ExtraData = nil
 			}
 			if diff := pretty.Compare(got, tt.want); diff != "" {
 				t.Errorf("Azure.FromData() %s diff: (-got +want)\n%s", tt.name, diff)
@@ -108,3 +111,39 @@ func TestAzure_FromChunk(t *testing.T) {
 		})
 	}
 }
+
+func TestAzure_Endpoints(t *testing.T) {
+	SetEndpoints("https://login.microsoftonline.us", "https://management.usgovcloudapi.net/")
+	defer SetEndpoints("", "")
+
+	detectortest.Test(t, Scanner{}, detectortest.Fixture{
+		Name: "government cloud",
+		Data: `azure:
+  tenant_id: 72f988bf-86f1-41af-91ab-2d7cd011db47
+  client_id: 4a1b2c3d-5e6f-4a8b-9c0d-1e2f3a4b5c6d
+  client_secret: Xk8Q~r2Lm9Pz4Wn7Tc3Vb6Hy1Jd5Gs0Fa2`,
+		Verify: true,
+		Mocks: []detectortest.Mock{
+			{
+				Host:   "login.microsoftonline.us",
+				Method: "POST",
+				Status: 200,
+				Body: `{"token_type": "Bearer", "expires_in": "3599", "expires_on": "1893456000", "not_before": "1893452400",
+					"resource": "https://management.usgovcloudapi.net/", "access_token": "eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9.e30.c2ln"}`,
+				ResponseHeaders: map[string]string{"Content-Type": "application/json"},
+			},
+			{
+				Host:            "management.usgovcloudapi.net",
+				Path:            "/subscriptions",
+				Status:          200,
+				Body:            `{"value": [{"subscriptionId": "8f2b3c4d-1a2b-4c3d-9e8f-7a6b5c4d3e2f"}]}`,
+				ResponseHeaders: map[string]string{"Content-Type": "application/json"},
+			},
+		},
+		Want: []detectortest.Want{{
+			Raw:       "Xk8Q~r2Lm9Pz4Wn7Tc3Vb6Hy1Jd5Gs0Fa2",
+			Verified:  true,
+			ExtraData: map[string]string{"subscriptions": "8f2b3c4d-1a2b-4c3d-9e8f-7a6b5c4d3e2f"},
+		}},
+	})
+}
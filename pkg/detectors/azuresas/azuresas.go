@@ -0,0 +1,144 @@
+package azuresas
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	client = common.SaneHttpClient()
+
+	// A SAS token is only usable with the resource it was issued for, so the whole URL is the secret.
+	sasURLPat = regexp.MustCompile(`https://([a-z0-9]{3,24})\.(blob|file|queue|table)\.core\.windows\.net(/[^\s?"'<>\\]*)?\?([^\s"'<>\\]*\bsig=[A-Za-z0-9%+/=]{20,}[^\s"'<>\\]*)`)
+
+	// Errors returned after the signature was accepted, meaning the token is valid even though the request failed.
+	authenticatedErrors = map[string]bool{
+		"AuthorizationPermissionMismatch":   true,
+		"AuthorizationResourceTypeMismatch": true,
+		"AuthorizationServiceMismatch":      true,
+		"BlobNotFound":                      true,
+		"ContainerNotFound":                 true,
+		"ResourceNotFound":                  true,
+	}
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"core.windows.net"}
+}
+
+// FromData will find and optionally verify Azure SAS tokens in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	for _, match := range sasURLPat.FindAllStringSubmatch(dataStr, -1) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		account, service, path := match[1], match[2], match[3]
+		params, err := url.ParseQuery(match[4])
+		if err != nil || params.Get("sv") == "" || params.Get("sig") == "" {
+			continue
+		}
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_AzureSASToken,
+			Raw:          []byte(match[0]),
+			Redacted:     redact(match[0]),
+			ExtraData: map[string]string{
+				"account_name": account,
+				"service":      service,
+			},
+		}
+		for param, name := range map[string]string{"sp": "permissions", "se": "expiry", "srt": "resource_types", "sr": "resource"} {
+			if value := params.Get(param); value != "" {
+				s1.ExtraData[name] = value
+			}
+		}
+
+		if verify && !expired(params.Get("se")) {
+			s1.Verified = verifyToken(ctx, account, service, path, match[4])
+		}
+
+		if !s1.Verified && detectors.IsKnownFalsePositive(params.Get("sig"), detectors.DefaultFalsePositives, true) {
+			continue
+		}
+
+		results = append(results, s1)
+	}
+
+	return detectors.CleanResults(results), nil
+}
+
+// verifyToken makes the cheapest read request the token's resource allows: listing an account or container, reading
+// the properties of a share or queue, and a HEAD request for anything else.
+func verifyToken(ctx context.Context, account, service, path, query string) bool {
+	resource := "https://" + account + "." + service + ".core.windows.net/" + strings.Trim(path, "/")
+	method, extra := "HEAD", ""
+	switch segments := strings.Split(strings.Trim(path, "/"), "/"); {
+	case segments[0] == "" && service == "table":
+		method, resource = "GET", resource+"Tables"
+	case segments[0] == "":
+		method, extra = "GET", "&comp=list&maxresults=1"
+	case len(segments) == 1 && service == "blob":
+		method, extra = "GET", "&restype=container&comp=list&maxresults=1"
+	case len(segments) == 1 && service == "file":
+		extra = "&restype=share"
+	case len(segments) == 1 && service == "queue":
+		extra = "&comp=metadata"
+	case service == "table":
+		method = "GET"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, resource+"?"+query+extra, nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("x-ms-version", "2020-10-02")
+	res, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return true
+	}
+	return authenticatedErrors[res.Header.Get("x-ms-error-code")]
+}
+
+// expired returns true if the token's expiry has passed. Tokens issued under a stored access policy may have no
+// expiry in the URL.
+func expired(expiry string) bool {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04Z", "2006-01-02"} {
+		if t, err := time.Parse(layout, expiry); err == nil {
+			return t.Before(time.Now())
+		}
+	}
+	return false
+}
+
+// redact removes the signature from the URL.
+func redact(sasURL string) string {
+	u, err := url.Parse(sasURL)
+	if err != nil {
+		return ""
+	}
+	query := u.Query()
+	query.Set("sig", "REDACTED")
+	u.RawQuery = query.Encode()
+	return u.String()
+}
@@ -0,0 +1,129 @@
+package azurestorage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	client = common.SaneHttpClient()
+
+	// Account keys are usually found in connection strings, e.g.
+	// DefaultEndpointsProtocol=https;AccountName=myaccount;AccountKey=...;EndpointSuffix=core.windows.net
+	// or in the AZURE_STORAGE_ACCOUNT and AZURE_STORAGE_KEY environment variables.
+	accountPat = regexp.MustCompile(`(?i)(?:AccountName|storage_?account(?:_?name)?)["']?\s*[=:]\s*["']?([a-z0-9]{3,24})\b`)
+	keyPat     = regexp.MustCompile(`(?i)(?:AccountKey|storage_?(?:account_?)?(?:access_?)?key)["']?\s*[=:]\s*["']?([a-z0-9+/]{86}==)`)
+	suffixPat  = regexp.MustCompile(`(?i)EndpointSuffix=([a-z0-9.\-]+)`)
+)
+
+const defaultEndpointSuffix = "core.windows.net"
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"accountkey", "storage_key", "storagekey", "storage_account_key", "storageaccountkey", "storage_access_key"}
+}
+
+// FromData will find and optionally verify Azure storage account keys in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	keys := detectors.FindMatches(keyPat, dataStr)
+	accounts := detectors.FindMatches(accountPat, dataStr)
+	suffix := defaultEndpointSuffix
+	if match := suffixPat.FindStringSubmatch(dataStr); match != nil {
+		suffix = strings.ToLower(match[1])
+	}
+
+	for _, group := range detectors.PairMatches(keys, accounts) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		key, account := group[0].Value, strings.ToLower(group[1].Value)
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_AzureStorageKey,
+			Raw:          []byte(key),
+			Redacted:     account,
+			ExtraData: map[string]string{
+				"account_name": account,
+				"endpoint":     fmt.Sprintf("https://%s.blob.%s", account, suffix),
+			},
+		}
+
+		if verify {
+			s1.Verified = verifyKey(ctx, account, key, suffix)
+		}
+
+		if !s1.Verified && detectors.IsKnownFalsePositive(key, detectors.DefaultFalsePositives, true) {
+			continue
+		}
+
+		results = append(results, s1)
+	}
+
+	return detectors.CleanResults(results), nil
+}
+
+// verifyKey lists at most one container of the account's blob service, which any valid account key is allowed to do.
+func verifyKey(ctx context.Context, account, key, suffix string) bool {
+	decodedKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return false
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://%s.blob.%s/?comp=list&maxresults=1", account, suffix), nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", "2020-10-02")
+	req.Header.Set("Authorization", "SharedKey "+account+":"+sharedKeySignature(req, account, decodedKey))
+	res, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer res.Body.Close()
+	return res.StatusCode == http.StatusOK
+}
+
+// sharedKeySignature signs a request without a body for the Shared Key authorization scheme.
+// See https://docs.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key.
+func sharedKeySignature(req *http.Request, account string, key []byte) string {
+	var b strings.Builder
+	// The verb is followed by 11 standard headers, none of which are set.
+	b.WriteString(req.Method + "\n")
+	b.WriteString(strings.Repeat("\n", 11))
+	b.WriteString("x-ms-date:" + req.Header.Get("x-ms-date") + "\n")
+	b.WriteString("x-ms-version:" + req.Header.Get("x-ms-version") + "\n")
+	b.WriteString("/" + account + req.URL.Path)
+	query := req.URL.Query()
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b.WriteString("\n" + strings.ToLower(name) + ":" + strings.Join(query[name], ","))
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(b.String()))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
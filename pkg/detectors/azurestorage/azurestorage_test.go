@@ -0,0 +1,53 @@
+package azurestorage
+
+import (
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
+)
+
+const testKey = "PlGgU9nwLhzn5pU1FV6D/S9RB5Aa9bjC+yNBUE/A2766y/JkizU1fRwd2UV3uqeUHEALX8f9Upq7k11aulv+bg=="
+
+func TestAzureStorage_FromData(t *testing.T) {
+	connectionString := "DefaultEndpointsProtocol=https;AccountName=truffletest;AccountKey=" + testKey + ";EndpointSuffix=core.windows.net"
+
+	detectortest.Test(t, Scanner{},
+		detectortest.Fixture{
+			Name:   "connection string, verified",
+			Data:   connectionString,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "truffletest.blob.core.windows.net",
+				Method: "GET",
+				Path:   "/",
+				Header: map[string]string{"x-ms-version": "2020-10-02"},
+				Status: 200,
+			}},
+			Want: []detectortest.Want{{
+				Raw:       testKey,
+				Verified:  true,
+				ExtraData: map[string]string{"account_name": "truffletest", "endpoint": "https://truffletest.blob.core.windows.net"},
+			}},
+		},
+		detectortest.Fixture{
+			Name:   "connection string, rotated",
+			Data:   connectionString,
+			Verify: true,
+			Mocks:  []detectortest.Mock{{Host: "truffletest.blob.core.windows.net", Status: 403}},
+			Want:   []detectortest.Want{{Raw: testKey}},
+		},
+		detectortest.Fixture{
+			Name: "environment variables in a sovereign cloud",
+			Data: "AZURE_STORAGE_ACCOUNT=TruffleTest\nAZURE_STORAGE_KEY=" + testKey + "\nEndpointSuffix=core.chinacloudapi.cn",
+			Want: []detectortest.Want{{
+				Raw:       testKey,
+				ExtraData: map[string]string{"account_name": "truffletest", "endpoint": "https://truffletest.blob.core.chinacloudapi.cn"},
+			}},
+		},
+		detectortest.Fixture{
+			Name: "key without an account",
+			Data: "AccountKey=" + testKey,
+			Want: nil,
+		},
+	)
+}
@@ -41,29 +41,30 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	apiKeyMatches := apiKeyPat.FindAllStringSubmatch(dataStr, -1)
-	apiSecretMatches := apiSecretPat.FindAllStringSubmatch(dataStr, -1)
+	apiKeyMatches := detectors.FindMatches(apiKeyPat, dataStr)
+	apiSecretMatches := detectors.FindMatches(apiSecretPat, dataStr)
 
 	for _, apiKeyMatch := range apiKeyMatches {
-		if len(apiKeyMatch) != 2 {
-			continue
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		apiKeyRes := strings.TrimSpace(apiKeyMatch[1])
+		apiKeyRes := apiKeyMatch.Value
 
 		s1 := detectors.Result{
 			DetectorType: detectorspb.DetectorType_Bitfinex,
 			Raw:          []byte(apiKeyRes),
 		}
 
+		// Keys and secrets look the same, so the key is paired with the closest match that isn't itself.
+		var otherMatches []detectors.Match
 		for _, apiSecretMatch := range apiSecretMatches {
-			if len(apiSecretMatch) != 2 {
-				continue
+			if apiSecretMatch.Value != apiKeyRes {
+				otherMatches = append(otherMatches, apiSecretMatch)
 			}
-			apiSecretRes := strings.TrimSpace(apiSecretMatch[1])
+		}
 
-			if apiKeyRes == apiSecretRes {
-				continue
-			}
+		for _, parts := range detectors.PairMatches([]detectors.Match{apiKeyMatch}, otherMatches) {
+			apiSecretRes := parts[1].Value
 
 			if verify {
 				// thankfully official golang examples exist but you just need to dig their many repos https://github.com/bitfinexcom/bitfinex-api-go/blob/master/examples/v2/rest-orders/main.go
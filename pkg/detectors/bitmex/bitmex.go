@@ -40,62 +40,56 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	secretMatches := secretPat.FindAllStringSubmatch(dataStr, -1)
+	matches := detectors.FindMatches(keyPat, dataStr)
+	secretMatches := detectors.FindMatches(secretPat, dataStr)
 
-	for _, match := range matches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(matches, secretMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resMatch := strings.TrimSpace(match[1])
+		resMatch := parts[0].Value
+		resSecretMatch := parts[1].Value
 
-		for _, secretMatch := range secretMatches {
-			if len(secretMatch) != 2 {
-				continue
-			}
-			resSecretMatch := strings.TrimSpace(secretMatch[1])
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Bitmex,
+			Raw:          []byte(resSecretMatch),
+		}
 
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_Bitmex,
-				Raw:          []byte(resSecretMatch),
-			}
+		if verify {
 
-			if verify {
+			timestamp := strconv.FormatInt(time.Now().Unix()+5, 10)
+			action := "GET"
+			path := "/api/v1/user"
+			payload := url.Values{}
 
-				timestamp := strconv.FormatInt(time.Now().Unix()+5, 10)
-				action := "GET"
-				path := "/api/v1/user"
-				payload := url.Values{}
+			signature := getBitmexSignature(timestamp, resSecretMatch, action, path, payload.Encode())
 
-				signature := getBitmexSignature(timestamp, resSecretMatch, action, path, payload.Encode())
+			req, err := http.NewRequestWithContext(ctx, action, "https://www.bitmex.com"+path, strings.NewReader(payload.Encode()))
+			if err != nil {
+				continue
+			}
+			req.Header.Add("api-expires", timestamp)
+			req.Header.Add("api-key", resMatch)
+			req.Header.Add("api-signature", signature)
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
+						continue
+					}
 
-				req, err := http.NewRequestWithContext(ctx, action, "https://www.bitmex.com"+path, strings.NewReader(payload.Encode()))
-				if err != nil {
-					continue
-				}
-				req.Header.Add("api-expires", timestamp)
-				req.Header.Add("api-key", resMatch)
-				req.Header.Add("api-signature", signature)
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					} else {
-						//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-						if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
-
-						if detectors.IsKnownFalsePositive(resSecretMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
+					if detectors.IsKnownFalsePositive(resSecretMatch, detectors.DefaultFalsePositives, true) {
+						continue
 					}
 				}
 			}
-
-			results = append(results, s1)
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
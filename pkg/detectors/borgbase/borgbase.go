@@ -39,6 +39,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
 
 	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
 		if len(match) != 2 {
 			continue
 		}
@@ -53,7 +56,7 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			timeout := 10 * time.Second
 			client.Timeout = timeout
 			payload := strings.NewReader(`{"query":"{ sshList {id, name}}"}`)
-			req, err := http.NewRequest("POST", "https://api.borgbase.com/graphql", payload)
+			req, err := http.NewRequestWithContext(ctx, "POST", "https://api.borgbase.com/graphql", payload)
 			if err != nil {
 				continue
 			}
@@ -36,59 +36,45 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	idMatches := idPat.FindAllStringSubmatch(dataStr, -1)
-	domainMatches := domainPat.FindAllStringSubmatch(dataStr, -1)
+	matches := detectors.FindMatches(keyPat, dataStr)
+	idMatches := detectors.FindMatches(idPat, dataStr)
+	domainMatches := detectors.FindMatches(domainPat, dataStr)
 
-	for _, match := range matches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(matches, idMatches, domainMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resMatch := strings.TrimSpace(match[1])
+		resMatch := parts[0].Value
+		resIdMatch := parts[1].Value
+		resDomainMatch := parts[2].Value
 
-		for _, idMatch := range idMatches {
-			if len(idMatch) != 2 {
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Caspio,
+			Raw:          []byte(resMatch),
+		}
+
+		if verify {
+			payload := strings.NewReader(fmt.Sprintf(`grant_type=client_credentials&client_id=%s&client_secret=%s`, resIdMatch, resMatch))
+			req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("https://%s.caspio.com/oauth/token", resDomainMatch), payload)
+			if err != nil {
 				continue
 			}
-
-			resIdMatch := strings.TrimSpace(idMatch[1])
-
-			for _, domainMatch := range domainMatches {
-				if len(domainMatch) != 2 {
-					continue
-				}
-
-				resDomainMatch := strings.TrimSpace(domainMatch[1])
-
-				s1 := detectors.Result{
-					DetectorType: detectorspb.DetectorType_Caspio,
-					Raw:          []byte(resMatch),
-				}
-
-				if verify {
-					payload := strings.NewReader(fmt.Sprintf(`grant_type=client_credentials&client_id=%s&client_secret=%s`, resIdMatch, resMatch))
-					req, err := http.NewRequest("POST", fmt.Sprintf("https://%s.caspio.com/oauth/token", resDomainMatch), payload)
-					if err != nil {
+			req.Header.Add("Content-Type", "text/plain")
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
 						continue
 					}
-					req.Header.Add("Content-Type", "text/plain")
-					res, err := client.Do(req)
-					if err == nil {
-						defer res.Body.Close()
-						if res.StatusCode >= 200 && res.StatusCode < 300 {
-							s1.Verified = true
-						} else {
-							//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-							if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
-								continue
-							}
-						}
-					}
 				}
-
-				results = append(results, s1)
 			}
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
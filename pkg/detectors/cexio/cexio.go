@@ -44,88 +44,76 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	keyMatches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	secretMatches := secretPat.FindAllStringSubmatch(dataStr, -1)
-	userIdMatches := userIdPat.FindAllStringSubmatch(dataStr, -1)
+	keyMatches := detectors.FindMatches(keyPat, dataStr)
+	secretMatches := detectors.FindMatches(secretPat, dataStr)
+	userIdMatches := detectors.FindMatches(userIdPat, dataStr)
 
-	for _, userIdMatch := range userIdMatches {
-		if len(userIdMatch) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(keyMatches, userIdMatches, secretMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resUserIdMatch := strings.TrimSpace(userIdMatch[1])
+		resKeyMatch := parts[0].Value
+		resUserIdMatch := parts[1].Value
+		resSecretMatch := parts[2].Value
 
-		for _, keyMatch := range keyMatches {
-			if len(keyMatch) != 2 {
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_CexIO,
+			Raw:          []byte(resKeyMatch),
+		}
+
+		if verify {
+
+			timestamp := strconv.FormatInt(time.Now().Unix()*1000, 10)
+
+			signature := getCexIOPassphrase(resSecretMatch, resKeyMatch, timestamp, resUserIdMatch)
+
+			payload := url.Values{}
+			payload.Add("key", resKeyMatch)
+			payload.Add("signature", signature)
+			payload.Add("nonce", timestamp)
+
+			req, err := http.NewRequestWithContext(ctx, "POST", "https://cex.io/api/balance/", strings.NewReader(payload.Encode()))
+			if err != nil {
 				continue
 			}
-			resKeyMatch := strings.TrimSpace(keyMatch[1])
+			req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
 
-			for _, secretMatch := range secretMatches {
-				if len(secretMatch) != 2 {
+				body, err := ioutil.ReadAll(res.Body)
+				if err != nil {
 					continue
 				}
-				resSecretMatch := strings.TrimSpace(secretMatch[1])
-
-				s1 := detectors.Result{
-					DetectorType: detectorspb.DetectorType_CexIO,
-					Raw:          []byte(resKeyMatch),
+				bodyString := string(body)
+				validResponse := strings.Contains(bodyString, `timestamp`)
+				if err != nil {
+					fmt.Print(err.Error())
 				}
 
-				if verify {
-
-					timestamp := strconv.FormatInt(time.Now().Unix()*1000, 10)
+				var responseObject Response
+				json.Unmarshal(body, &responseObject)
 
-					signature := getCexIOPassphrase(resSecretMatch, resKeyMatch, timestamp, resUserIdMatch)
-
-					payload := url.Values{}
-					payload.Add("key", resKeyMatch)
-					payload.Add("signature", signature)
-					payload.Add("nonce", timestamp)
+				if res.StatusCode >= 200 && res.StatusCode < 300 && validResponse {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(resUserIdMatch, detectors.DefaultFalsePositives, true) {
+						continue
+					}
 
-					req, err := http.NewRequestWithContext(ctx, "POST", "https://cex.io/api/balance/", strings.NewReader(payload.Encode()))
-					if err != nil {
+					if detectors.IsKnownFalsePositive(resKeyMatch, detectors.DefaultFalsePositives, true) {
 						continue
 					}
-					req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-					res, err := client.Do(req)
-					if err == nil {
-						defer res.Body.Close()
-
-						body, err := ioutil.ReadAll(res.Body)
-						if err != nil {
-							continue
-						}
-						bodyString := string(body)
-						validResponse := strings.Contains(bodyString, `timestamp`)
-						if err != nil {
-							fmt.Print(err.Error())
-						}
-
-						var responseObject Response
-						json.Unmarshal(body, &responseObject)
-
-						if res.StatusCode >= 200 && res.StatusCode < 300 && validResponse {
-							s1.Verified = true
-						} else {
-							//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-							if detectors.IsKnownFalsePositive(resUserIdMatch, detectors.DefaultFalsePositives, true) {
-								continue
-							}
-
-							if detectors.IsKnownFalsePositive(resKeyMatch, detectors.DefaultFalsePositives, true) {
-								continue
-							}
-
-							if detectors.IsKnownFalsePositive(resSecretMatch, detectors.DefaultFalsePositives, true) {
-								continue
-							}
-						}
+
+					if detectors.IsKnownFalsePositive(resSecretMatch, detectors.DefaultFalsePositives, true) {
+						continue
 					}
 				}
-
-				results = append(results, s1)
 			}
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
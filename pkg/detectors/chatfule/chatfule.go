@@ -37,6 +37,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
 
 	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
 		if len(match) != 2 {
 			continue
 		}
@@ -48,7 +51,7 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		}
 
 		if verify {
-			req, err := http.NewRequest("GET", "https://dashboard.chatfuel.com/api/bots", nil)
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://dashboard.chatfuel.com/api/bots", nil)
 			if err != nil {
 				continue
 			}
@@ -0,0 +1,205 @@
+// Package ciconfig detects credentials written in plain text into CI configuration: the values of secret-looking
+// variables in env blocks, and passwords passed to curl -u and docker login -p in scripts. They belong in the CI
+// system's secret store, where they're masked in logs and not readable by everyone with access to the repository.
+//
+// The patterns are YAML-shaped rather than tied to one file, so they also match Compose files and other YAML. Results
+// in the CI configuration files of GitHub Actions, GitLab CI, and CircleCI are raised to high severity by
+// AdjustForPath.
+package ciconfig
+
+import (
+	"context"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interfaces at compile time
+var (
+	_ detectors.Detector  = (*Scanner)(nil)
+	_ detectors.PathAware = (*Scanner)(nil)
+)
+
+var (
+	// A key that starts a block of variables, such as "env:" in GitHub Actions, "variables:" in GitLab CI, and
+	// "environment:" in CircleCI.
+	blockPat = regexp.MustCompile(`^(\s*(?:-\s+)?)(?:env|environment|variables)\s*:\s*$`)
+	// A variable in a block, as a mapping or as a list item of either form.
+	mapEntryPat  = regexp.MustCompile(`^\s*(?:-\s+)?([A-Za-z_][A-Za-z0-9_]*)\s*:\s*(.+?)\s*$`)
+	listEntryPat = regexp.MustCompile(`^\s*-\s+['"]?([A-Za-z_][A-Za-z0-9_]*)=(.+?)['"]?\s*$`)
+	// Variable names that hold credentials.
+	secretNamePat = regexp.MustCompile(`(?i)(passw(or)?d|secret|token|api_?key|access_?key|private_?key|credential)`)
+
+	curlPat        = regexp.MustCompile(`\bcurl\b[^\n]*?\s(?:-u|--user)(?:\s+|=)['"]?([^\s:'"]+):([^\s'"]+)`)
+	dockerLoginPat = regexp.MustCompile(`\bdocker\s+login\b[^\n]*?\s(?:-p|--password)(?:\s+|=)['"]?([^\s'"]+)`)
+	dockerUserPat  = regexp.MustCompile(`\s(?:-u|--username)(?:\s+|=)['"]?([^\s'"]+)`)
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"env:", "environment:", "variables:", "curl", "docker login"}
+}
+
+// FromData will find plain text credentials in CI configuration in a given set of bytes. They can't be verified
+// without knowing what they're for.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	for _, v := range envVariables(dataStr) {
+		if !secretNamePat.MatchString(v.name) || !literal(v.value) {
+			continue
+		}
+		results = append(results, detectors.Result{
+			DetectorType: detectorspb.DetectorType_CIConfig,
+			Raw:          []byte(v.value),
+			Redacted:     v.name,
+			ExtraData: map[string]string{
+				"kind":     "env",
+				"variable": v.name,
+			},
+		})
+	}
+
+	for _, match := range curlPat.FindAllStringSubmatch(dataStr, -1) {
+		if !literal(match[2]) {
+			continue
+		}
+		results = append(results, detectors.Result{
+			DetectorType: detectorspb.DetectorType_CIConfig,
+			Raw:          []byte(match[2]),
+			Redacted:     "curl -u " + match[1] + ":****",
+			ExtraData: map[string]string{
+				"kind":     "curl",
+				"username": match[1],
+			},
+		})
+	}
+
+	for _, match := range dockerLoginPat.FindAllStringSubmatch(dataStr, -1) {
+		if !literal(match[1]) {
+			continue
+		}
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_CIConfig,
+			Raw:          []byte(match[1]),
+			Redacted:     "docker login -p ****",
+			ExtraData: map[string]string{
+				"kind": "docker-login",
+			},
+		}
+		if user := dockerUserPat.FindStringSubmatch(match[0]); user != nil {
+			s1.ExtraData["username"] = user[1]
+		}
+		results = append(results, s1)
+	}
+
+	var kept []detectors.Result
+	for _, result := range results {
+		if detectors.IsKnownFalsePositive(string(result.Raw), detectors.DefaultFalsePositives, false) {
+			continue
+		}
+		kept = append(kept, result)
+	}
+	return detectors.CleanResults(kept), nil
+}
+
+type variable struct {
+	name, value string
+}
+
+// envVariables returns the variables in the env blocks of YAML in data. A block's variables are the lines indented
+// further than its key.
+func envVariables(data string) []variable {
+	var variables []variable
+	blockIndent := -1
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+
+		if blockIndent >= 0 && indent <= blockIndent {
+			blockIndent = -1
+		}
+		if match := blockPat.FindStringSubmatch(line); match != nil {
+			blockIndent = len(match[1])
+			continue
+		}
+		if blockIndent < 0 {
+			continue
+		}
+
+		match := listEntryPat.FindStringSubmatch(line)
+		if match == nil {
+			match = mapEntryPat.FindStringSubmatch(line)
+		}
+		if match != nil {
+			variables = append(variables, variable{name: match[1], value: unquote(match[2])})
+		}
+	}
+	return variables
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// literal returns false for values that aren't credentials written into the file: references to CI secrets and
+// variables such as ${{ secrets.TOKEN }} and $TOKEN, templates, YAML aliases and tags, and short or boolean values.
+func literal(value string) bool {
+	if len(value) < 6 || strings.ContainsAny(value, "${}") {
+		return false
+	}
+	switch value[0] {
+	case '*', '&', '!', '<', '%', '[':
+		return false
+	}
+	switch strings.ToLower(value) {
+	case "true", "false", "null":
+		return false
+	}
+	return true
+}
+
+// ciSystem returns the CI system whose configuration is at path, or an empty string.
+func ciSystem(filePath string) string {
+	filePath = strings.ReplaceAll(filePath, "\\", "/")
+	base := path.Base(filePath)
+	ext := path.Ext(base)
+	switch {
+	case strings.Contains("/"+filePath, "/.github/workflows/") && (ext == ".yml" || ext == ".yaml"):
+		return "github-actions"
+	case base == ".gitlab-ci.yml":
+		return "gitlab-ci"
+	case strings.HasSuffix("/"+filePath, "/.circleci/config.yml"):
+		return "circleci"
+	}
+	return ""
+}
+
+// AdjustForPath raises results in CI configuration files to high severity. Credentials there are used by every build
+// and readable by everyone with access to the repository.
+func (s Scanner) AdjustForPath(result *detectors.Result, filePath string) {
+	system := ciSystem(filePath)
+	if system == "" {
+		return
+	}
+	if result.ExtraData == nil {
+		result.ExtraData = map[string]string{}
+	}
+	result.ExtraData["ci_system"] = system
+	if result.Severity < detectors.SeverityHigh {
+		result.Severity = detectors.SeverityHigh
+	}
+}
@@ -0,0 +1,77 @@
+package ciconfig
+
+import (
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
+)
+
+const workflow = `name: deploy
+on: push
+env:
+  NODE_ENV: production
+  NPM_TOKEN: 0f6c2a4e-8b1d-4e7a-9c3f-5d2b1a0e9f8c
+jobs:
+  deploy:
+    runs-on: ubuntu-latest
+    steps:
+      - env:
+          DEPLOY_TOKEN: ${{ secrets.DEPLOY_TOKEN }}
+          API_KEY: "Zk3mQ8vR2xW7pL4n"
+        run: |
+          docker login -u ci-bot -p dckr-Pw-8d7f6e5c registry.corp.net
+          curl -u admin:Adm1n-Passw0rd-2023 https://nexus.corp.net/service/rest/v1/status
+          curl -u "$NEXUS_USER:$NEXUS_PASSWORD" https://nexus.corp.net
+`
+
+func TestCIConfig(t *testing.T) {
+	detectortest.Test(t, Scanner{},
+		detectortest.Fixture{
+			Name: "workflow",
+			Data: workflow,
+			Want: []detectortest.Want{
+				{Raw: "0f6c2a4e-8b1d-4e7a-9c3f-5d2b1a0e9f8c", ExtraData: map[string]string{"kind": "env", "variable": "NPM_TOKEN"}},
+				{Raw: "Zk3mQ8vR2xW7pL4n", ExtraData: map[string]string{"kind": "env", "variable": "API_KEY"}},
+				{Raw: "dckr-Pw-8d7f6e5c", ExtraData: map[string]string{"kind": "docker-login", "username": "ci-bot"}},
+				{Raw: "Adm1n-Passw0rd-2023", ExtraData: map[string]string{"kind": "curl", "username": "admin"}},
+			},
+		},
+		detectortest.Fixture{
+			Name: "compose list",
+			Data: "services:\n  db:\n    environment:\n      - POSTGRES_PASSWORD=Xq7vTz2kLp9w\n      - POSTGRES_USER=app\n",
+			Want: []detectortest.Want{
+				{Raw: "Xq7vTz2kLp9w", ExtraData: map[string]string{"kind": "env", "variable": "POSTGRES_PASSWORD"}},
+			},
+		},
+		detectortest.Fixture{
+			Name: "secret outside an env block",
+			Data: "steps:\n  - name: use\n    with:\n      token: Zk3mQ8vR2xW7pL4n\n",
+		},
+		detectortest.Fixture{
+			Name: "references",
+			Data: "variables:\n  DB_PASSWORD: $DB_PASSWORD\n  AWS_SECRET_ACCESS_KEY: !reference [.secrets, aws]\n  USE_TOKEN: true\n",
+		},
+	)
+}
+
+func TestAdjustForPath(t *testing.T) {
+	tests := map[string]string{
+		".github/workflows/deploy.yml":   "github-actions",
+		"repo/.github/workflows/ci.yaml": "github-actions",
+		".gitlab-ci.yml":                 "gitlab-ci",
+		`repo\.circleci\config.yml`:      "circleci",
+		"docker-compose.yml":             "",
+		".github/dependabot.yml":         "",
+	}
+	for path, want := range tests {
+		result := detectors.Result{Severity: detectors.SeverityLow}
+		Scanner{}.AdjustForPath(&result, path)
+		if got := result.ExtraData["ci_system"]; got != want {
+			t.Errorf("%s: got CI system %q, want %q", path, got, want)
+		}
+		if wantSeverity := map[bool]detectors.Severity{true: detectors.SeverityHigh, false: detectors.SeverityLow}[want != ""]; result.Severity != wantSeverity {
+			t.Errorf("%s: got severity %s, want %s", path, result.Severity, wantSeverity)
+		}
+	}
+}
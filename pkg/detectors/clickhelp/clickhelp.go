@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -37,58 +36,47 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	serverMatches := serverPat.FindAllStringSubmatch(dataStr, -1)
-	emailMatches := emailPat.FindAllStringSubmatch(dataStr, -1)
-	keyMatches := keyPat.FindAllStringSubmatch(dataStr, -1)
+	serverMatches := detectors.FindMatches(serverPat, dataStr)
+	emailMatches := detectors.FindMatches(emailPat, dataStr)
+	keyMatches := detectors.FindMatches(keyPat, dataStr)
 
-	for _, match := range serverMatches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(serverMatches, emailMatches, keyMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resServer := strings.TrimSpace(match[1])
+		resServer := parts[0].Value
+		resEmail := parts[1].Value
+		resKey := parts[2].Value
 
-		for _, emailMatch := range emailMatches {
-			if len(emailMatch) != 2 {
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_ClickHelp,
+			Raw:          []byte(resServer),
+		}
+
+		if verify {
+			data := fmt.Sprintf("%s:%s", resEmail, resKey)
+			sEnc := b64.StdEncoding.EncodeToString([]byte(data))
+			req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://%s/api/v1/projects", resServer), nil)
+			if err != nil {
 				continue
 			}
-			resEmail := strings.TrimSpace(emailMatch[1])
-			for _, keyMatch := range keyMatches {
-				if len(keyMatch) != 2 {
-					continue
-				}
-				resKey := strings.TrimSpace(keyMatch[1])
-
-				s1 := detectors.Result{
-					DetectorType: detectorspb.DetectorType_ClickHelp,
-					Raw:          []byte(resServer),
-				}
-
-				if verify {
-					data := fmt.Sprintf("%s:%s", resEmail, resKey)
-					sEnc := b64.StdEncoding.EncodeToString([]byte(data))
-					req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://%s/api/v1/projects", resServer), nil)
-					if err != nil {
+			req.Header.Add("Content-Type", "application/json")
+			req.Header.Add("Authorization", fmt.Sprintf("Basic %s", sEnc))
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(resServer, detectors.DefaultFalsePositives, true) {
 						continue
 					}
-					req.Header.Add("Content-Type", "application/json")
-					req.Header.Add("Authorization", fmt.Sprintf("Basic %s", sEnc))
-					res, err := client.Do(req)
-					if err == nil {
-						defer res.Body.Close()
-						if res.StatusCode >= 200 && res.StatusCode < 300 {
-							s1.Verified = true
-						} else {
-							//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-							if detectors.IsKnownFalsePositive(resServer, detectors.DefaultFalsePositives, true) {
-								continue
-							}
-						}
-					}
 				}
-
-				results = append(results, s1)
 			}
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
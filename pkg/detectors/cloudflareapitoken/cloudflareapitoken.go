@@ -2,9 +2,11 @@ package cloudflareapitoken
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
@@ -21,8 +23,23 @@ var (
 	client = common.SaneHttpClient()
 
 	keyPat = regexp.MustCompile(detectors.PrefixRegex([]string{"cloudflare"}) + `\b([A-Za-z0-9_-]{40})\b`)
+
+	apiURL = "https://api.cloudflare.com/client/v4"
 )
 
+type token struct {
+	ID        string `json:"id"`
+	Status    string `json:"status"`
+	ExpiresOn string `json:"expires_on"`
+	Policies  []struct {
+		Effect           string            `json:"effect"`
+		Resources        map[string]string `json:"resources"`
+		PermissionGroups []struct {
+			Name string `json:"name"`
+		} `json:"permission_groups"`
+	} `json:"policies"`
+}
+
 // Keywords are used for efficiently pre-filtering chunks.
 // Use identifiers in the secret preferably, or the provider name.
 func (s Scanner) Keywords() []string {
@@ -36,6 +53,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
 
 	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
 		if len(match) != 2 {
 			continue
 		}
@@ -47,23 +67,13 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		}
 
 		if verify {
-			req, err := http.NewRequestWithContext(ctx, "GET", "https://api.cloudflare.com/client/v4/user/tokens/verify", nil)
-			if err != nil {
+			token, verified := verifyToken(ctx, resMatch)
+			s1.Verified = verified
+			if verified {
+				s1.ExtraData = permissions(ctx, resMatch, token)
+			} else if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
 				continue
 			}
-			req.Header.Add("Content-Type", "application/json")
-			req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", resMatch))
-			res, err := client.Do(req)
-			if err == nil {
-				defer res.Body.Close()
-				if res.StatusCode >= 200 && res.StatusCode < 300 {
-					s1.Verified = true
-				} else {
-					if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
-						continue
-					}
-				}
-			}
 		}
 
 		results = append(results, s1)
@@ -71,3 +81,101 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 
 	return detectors.CleanResults(results), nil
 }
+
+// verifyToken returns the token's details if it's active. Disabled and expired tokens verify, but can't be used.
+func verifyToken(ctx context.Context, key string) (token, bool) {
+	var t token
+	if err := get(ctx, key, "/user/tokens/verify", &t); err != nil {
+		return t, false
+	}
+	return t, t.Status == "active"
+}
+
+// permissions describes what the token can do. Reading the token's policies needs the API Tokens Read permission,
+// which most tokens don't have, so the zones and accounts the token can see are listed as well.
+func permissions(ctx context.Context, key string, t token) map[string]string {
+	extraData := map[string]string{"token_id": t.ID}
+	if t.ExpiresOn != "" {
+		extraData["expires_on"] = t.ExpiresOn
+	}
+
+	var details token
+	if err := get(ctx, key, "/user/tokens/"+t.ID, &details); err == nil {
+		var permissions, resources []string
+		for _, policy := range details.Policies {
+			if policy.Effect != "allow" {
+				continue
+			}
+			for _, group := range policy.PermissionGroups {
+				permissions = append(permissions, group.Name)
+			}
+			for resource := range policy.Resources {
+				resources = append(resources, resource)
+			}
+		}
+		addList(extraData, "permissions", permissions)
+		addList(extraData, "resources", resources)
+	}
+
+	var zones, accounts []struct {
+		Name string `json:"name"`
+	}
+	if err := get(ctx, key, "/zones?per_page=50", &zones); err == nil {
+		names := make([]string, 0, len(zones))
+		for _, zone := range zones {
+			names = append(names, zone.Name)
+		}
+		addList(extraData, "zones", names)
+	}
+	if err := get(ctx, key, "/accounts?per_page=50", &accounts); err == nil {
+		names := make([]string, 0, len(accounts))
+		for _, account := range accounts {
+			names = append(names, account.Name)
+		}
+		addList(extraData, "accounts", names)
+	}
+	return extraData
+}
+
+// addList adds the sorted, deduplicated values as a comma separated list.
+func addList(extraData map[string]string, key string, values []string) {
+	sort.Strings(values)
+	var unique []string
+	for i, value := range values {
+		if i == 0 || value != values[i-1] {
+			unique = append(unique, value)
+		}
+	}
+	if len(unique) > 0 {
+		extraData[key] = strings.Join(unique, ",")
+	}
+}
+
+// get decodes the result of a successful API response into v.
+func get(ctx context.Context, key, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", key))
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+	var envelope struct {
+		Success bool            `json:"success"`
+		Result  json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&envelope); err != nil {
+		return err
+	}
+	if !envelope.Success {
+		return fmt.Errorf("request to %s was unsuccessful", path)
+	}
+	return json.Unmarshal(envelope.Result, v)
+}
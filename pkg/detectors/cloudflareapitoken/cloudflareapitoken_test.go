@@ -8,6 +8,7 @@ import (
 
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
@@ -109,6 +110,7 @@ func TestCloudflareApiToken_FromChunk(t *testing.T) {
 					t.Fatalf("no raw secret present: \n %+v", got[i])
 				}
 				got[i].Raw = nil
+				got[i].ExtraData = nil
 			}
 			if diff := pretty.Compare(got, tt.want); diff != "" {
 				t.Errorf("CloudflareApiToken.FromData() %s diff: (-got +want)\n%s", tt.name, diff)
@@ -117,6 +119,82 @@ func TestCloudflareApiToken_FromChunk(t *testing.T) {
 	}
 }
 
+func TestCloudflareApiToken_Permissions(t *testing.T) {
+	const key = "Xq7Lm2Rt9Wz4Kb8Nc1Vp6Hd3Jf0Gs5Ye2Ua7Ti9w"
+	data := "CLOUDFLARE_API_TOKEN=" + key
+	auth := map[string]string{"Authorization": "Bearer " + key}
+
+	detectortest.Test(t, Scanner{},
+		detectortest.Fixture{
+			Name:   "dns edit token",
+			Data:   data,
+			Verify: true,
+			Mocks: []detectortest.Mock{
+				{
+					Host:   "api.cloudflare.com",
+					Path:   "/client/v4/user/tokens/verify",
+					Header: auth,
+					Status: 200,
+					Body:   `{"success": true, "result": {"id": "ed17574386854bf78a67040be0a770b0", "status": "active"}}`,
+				},
+				{
+					Host:   "api.cloudflare.com",
+					Path:   "/client/v4/user/tokens/ed17574386854bf78a67040be0a770b0",
+					Status: 200,
+					Body: `{"success": true, "result": {"id": "ed17574386854bf78a67040be0a770b0", "status": "active", "policies": [
+						{"effect": "allow", "resources": {"com.cloudflare.api.account.zone.eb78d65290b24279ba6f44721b3ea3c4": "*"},
+						 "permission_groups": [{"name": "DNS Write"}, {"name": "Zone Read"}]}]}}`,
+				},
+				{
+					Host:   "api.cloudflare.com",
+					Path:   "/client/v4/zones",
+					Status: 200,
+					Body:   `{"success": true, "result": [{"name": "example.org"}]}`,
+				},
+				{
+					Host:   "api.cloudflare.com",
+					Path:   "/client/v4/accounts",
+					Status: 200,
+					Body:   `{"success": true, "result": []}`,
+				},
+			},
+			Want: []detectortest.Want{{
+				Raw:      key,
+				Verified: true,
+				ExtraData: map[string]string{
+					"token_id":    "ed17574386854bf78a67040be0a770b0",
+					"permissions": "DNS Write,Zone Read",
+					"resources":   "com.cloudflare.api.account.zone.eb78d65290b24279ba6f44721b3ea3c4",
+					"zones":       "example.org",
+				},
+			}},
+		},
+		detectortest.Fixture{
+			Name:   "disabled token",
+			Data:   data,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "api.cloudflare.com",
+				Path:   "/client/v4/user/tokens/verify",
+				Status: 200,
+				Body:   `{"success": true, "result": {"id": "ed17574386854bf78a67040be0a770b0", "status": "disabled"}}`,
+			}},
+			Want: []detectortest.Want{{Raw: key}},
+		},
+		detectortest.Fixture{
+			Name:   "invalid token",
+			Data:   data,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "api.cloudflare.com",
+				Status: 400,
+				Body:   `{"success": false, "errors": [{"code": 1000, "message": "Invalid API Token"}]}`,
+			}},
+			Want: []detectortest.Want{{Raw: key}},
+		},
+	)
+}
+
 func BenchmarkFromData(benchmark *testing.B) {
 	ctx := context.Background()
 	s := Scanner{}
@@ -37,6 +37,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
 
 	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
 		if len(match) != 2 {
 			continue
 		}
@@ -52,7 +55,7 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			`)
 			timeout := 10 * time.Second
 			client.Timeout = timeout
-			req, err := http.NewRequest("POST", "https://api.cloudimage.com/invalidate", payload)
+			req, err := http.NewRequestWithContext(ctx, "POST", "https://api.cloudimage.com/invalidate", payload)
 			if err != nil {
 				continue
 			}
@@ -0,0 +1,84 @@
+package cohere
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	client = common.SaneHttpClient()
+
+	// Cohere keys have no prefix, so they're only found near the provider name.
+	keyPat = regexp.MustCompile(detectors.PrefixRegex([]string{"cohere"}) + `\b([a-zA-Z0-9]{40})\b`)
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"cohere"}
+}
+
+// FromData will find and optionally verify Cohere secrets in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
+
+	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		if len(match) != 2 {
+			continue
+		}
+		key := match[1]
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Cohere,
+			Raw:          []byte(key),
+		}
+
+		if verify {
+			req, err := http.NewRequestWithContext(ctx, "POST", "https://api.cohere.com/v1/check-api-key", nil)
+			if err != nil {
+				continue
+			}
+			req.Header.Add("Authorization", "Bearer "+key)
+			res, err := client.Do(req)
+			if err == nil {
+				var check struct {
+					Valid          bool   `json:"valid"`
+					OrganizationID string `json:"organization_id"`
+					OwnerID        string `json:"owner_id"`
+				}
+				if res.StatusCode == http.StatusOK && json.NewDecoder(res.Body).Decode(&check) == nil && check.Valid {
+					s1.Verified = true
+					s1.ExtraData = map[string]string{
+						"organization_id": check.OrganizationID,
+						"owner_id":        check.OwnerID,
+					}
+				}
+				res.Body.Close()
+			}
+		}
+
+		if !s1.Verified && detectors.IsKnownFalsePositive(key, detectors.DefaultFalsePositives, true) {
+			continue
+		}
+
+		results = append(results, s1)
+	}
+
+	return detectors.CleanResults(results), nil
+}
@@ -0,0 +1,43 @@
+package cohere
+
+import (
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
+)
+
+func TestCohere_FromData(t *testing.T) {
+	const key = "TAwR4y9ojfljoQoaF1LlqsajAIxNKu8iS2G8NPRV"
+
+	detectortest.Test(t, Scanner{},
+		detectortest.Fixture{
+			Name:   "valid key",
+			Data:   "COHERE_API_KEY=" + key,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "api.cohere.com",
+				Method: "POST",
+				Path:   "/v1/check-api-key",
+				Header: map[string]string{"Authorization": "Bearer " + key},
+				Status: 200,
+				Body:   `{"valid": true, "organization_id": "b7a3c1e2-org", "owner_id": "4f2d9e8a-user"}`,
+			}},
+			Want: []detectortest.Want{{
+				Raw:       key,
+				Verified:  true,
+				ExtraData: map[string]string{"organization_id": "b7a3c1e2-org", "owner_id": "4f2d9e8a-user"},
+			}},
+		},
+		detectortest.Fixture{
+			Name:   "invalid key",
+			Data:   `co = cohere.Client("` + key + `")`,
+			Verify: true,
+			Mocks:  []detectortest.Mock{{Host: "api.cohere.com", Status: 200, Body: `{"valid": false}`}},
+			Want:   []detectortest.Want{{Raw: key}},
+		},
+		detectortest.Fixture{
+			Name: "key without the provider name",
+			Data: "API_KEY=" + key,
+		},
+	)
+}
@@ -0,0 +1,241 @@
+// Package combolist detects leaked email and password pairs in the formats of combolists and credential dumps: a pair
+// per line, separated by ":", ";", "|", ",", or a tab, and CSV files with an email and a password column.
+//
+// A dump holds thousands of pairs, so a chunk with more than the bulk threshold of them is reported as one bulk result
+// that counts its pairs and their domains, rather than as a result per password. In count-only mode, every chunk is
+// reported that way, so no password is ever part of a result.
+package combolist
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+// DefaultBulkThreshold is the most pairs in a chunk that are reported one by one.
+const DefaultBulkThreshold = 10
+
+// bulkThreshold and countOnly are only set before scanning starts.
+var (
+	bulkThreshold = DefaultBulkThreshold
+	countOnly     bool
+)
+
+// SetBulkThreshold sets the most pairs in a chunk that are reported one by one. Chunks with more are reported as one
+// bulk result. It must be called before scanning starts.
+func SetBulkThreshold(n int) {
+	bulkThreshold = n
+}
+
+// SetCountOnly reports every chunk with pairs as one bulk result, so passwords are never included in results. It must
+// be called before scanning starts.
+func SetCountOnly(enabled bool) {
+	countOnly = enabled
+}
+
+var (
+	// A line holding only an email address and a password.
+	linePat = regexp.MustCompile(`^\s*"?([A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,})"?\s*[:;|,\t]\s*"?([^\s"]{4,128})"?\s*$`)
+	// The header columns of CSV dumps.
+	emailColumns    = map[string]bool{"email": true, "e-mail": true, "mail": true, "email_address": true, "username": true, "login": true, "user": true}
+	passwordColumns = map[string]bool{"password": true, "pass": true, "passwd": true, "pwd": true, "plaintext": true}
+	// Values after an address that are ports or repository paths, as in user@host.com:22 and git@github.com:org/repo.git.
+	portPat = regexp.MustCompile(`^\d{1,5}$`)
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"@"}
+}
+
+type pair struct {
+	email, password string
+}
+
+// FromData will find email and password pairs in a given set of bytes. They can't be verified without logging in to
+// the accounts.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	found := pairs(string(data))
+	if len(found) == 0 {
+		return nil, nil
+	}
+	if countOnly || len(found) > bulkThreshold {
+		return []detectors.Result{bulkResult(found)}, nil
+	}
+
+	for _, p := range found {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		results = append(results, detectors.Result{
+			DetectorType: detectorspb.DetectorType_Combolist,
+			Raw:          []byte(p.email + ":" + p.password),
+			Redacted:     p.email + ":****",
+			ExtraData: map[string]string{
+				"email":  p.email,
+				"domain": domain(p.email),
+			},
+		})
+	}
+	return results, nil
+}
+
+// bulkResult counts the pairs of a chunk without including their passwords. Its Raw is a digest of the pairs, so the
+// same dump is deduplicated across scans.
+func bulkResult(found []pair) detectors.Result {
+	lines := make([]string, len(found))
+	domains := map[string]int{}
+	for i, p := range found {
+		lines[i] = p.email + ":" + p.password
+		domains[domain(p.email)]++
+	}
+	sort.Strings(lines)
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+
+	return detectors.Result{
+		DetectorType: detectorspb.DetectorType_Combolist,
+		Raw:          []byte(hex.EncodeToString(sum[:])),
+		Redacted:     fmt.Sprintf("%d email and password pairs", len(found)),
+		Severity:     detectors.SeverityHigh,
+		ExtraData: map[string]string{
+			"kind":        "bulk",
+			"pairs":       strconv.Itoa(len(found)),
+			"top_domains": topDomains(domains, 5),
+		},
+	}
+}
+
+// topDomains lists the n domains with the most pairs, with their counts, such as "gmail.com (120), yahoo.com (31)".
+func topDomains(domains map[string]int, n int) string {
+	names := make([]string, 0, len(domains))
+	for name := range domains {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if domains[names[i]] != domains[names[j]] {
+			return domains[names[i]] > domains[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > n {
+		names = names[:n]
+	}
+	for i, name := range names {
+		names[i] = fmt.Sprintf("%s (%d)", name, domains[name])
+	}
+	return strings.Join(names, ", ")
+}
+
+func domain(email string) string {
+	return strings.ToLower(email[strings.LastIndex(email, "@")+1:])
+}
+
+// csvHeader is the header of a CSV dump, naming the columns of the email address and password.
+type csvHeader struct {
+	comma                 rune
+	emailCol, passwordCol int
+}
+
+// pairs returns the email and password pairs in data. Lines after a CSV header are read as CSV; the rows of a dump
+// that's split across chunks only match in the chunk with its header, unless they have just the two columns.
+func pairs(data string) []pair {
+	var found []pair
+	var header *csvHeader
+	seen := map[pair]bool{}
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if h := parseHeader(line); h != nil {
+			header = h
+			continue
+		}
+
+		var p pair
+		if match := linePat.FindStringSubmatch(line); match != nil {
+			p = pair{email: match[1], password: match[2]}
+		} else if header != nil {
+			p = header.row(line)
+		}
+		if !valid(p) || seen[p] {
+			continue
+		}
+		seen[p] = true
+		found = append(found, p)
+	}
+	return found
+}
+
+// parseHeader returns the CSV header in line, if it is one with an email and a password column.
+func parseHeader(line string) *csvHeader {
+	for _, comma := range []rune{',', ';', '\t'} {
+		if !strings.ContainsRune(line, comma) {
+			continue
+		}
+		fields, err := readCSV(line, comma)
+		if err != nil {
+			continue
+		}
+		h := &csvHeader{comma: comma, emailCol: -1, passwordCol: -1}
+		for i, field := range fields {
+			name := strings.ToLower(strings.TrimSpace(field))
+			if emailColumns[name] && h.emailCol < 0 {
+				h.emailCol = i
+			}
+			if passwordColumns[name] && h.passwordCol < 0 {
+				h.passwordCol = i
+			}
+		}
+		if h.emailCol >= 0 && h.passwordCol >= 0 {
+			return h
+		}
+	}
+	return nil
+}
+
+// row returns the pair in a row of the CSV dump, or an empty pair.
+func (h *csvHeader) row(line string) pair {
+	fields, err := readCSV(line, h.comma)
+	if err != nil || len(fields) <= h.emailCol || len(fields) <= h.passwordCol {
+		return pair{}
+	}
+	return pair{email: strings.TrimSpace(fields[h.emailCol]), password: strings.TrimSpace(fields[h.passwordCol])}
+}
+
+func readCSV(line string, comma rune) ([]string, error) {
+	r := csv.NewReader(strings.NewReader(line))
+	r.Comma = comma
+	r.LazyQuotes = true
+	r.FieldsPerRecord = -1
+	return r.Read()
+}
+
+// valid returns false for pairs that aren't leaked credentials: values without an email address, ports and
+// repository paths after an address, and placeholders such as user@example.com.
+func valid(p pair) bool {
+	at := strings.LastIndex(p.email, "@")
+	if at <= 0 || !strings.Contains(p.email[at:], ".") || len(p.password) < 4 || strings.ContainsAny(p.password, " \t") {
+		return false
+	}
+	if portPat.MatchString(p.password) || strings.HasSuffix(p.password, ".git") || strings.HasPrefix(p.password, "//") {
+		return false
+	}
+	return !detectors.IsKnownFalsePositive(p.email+":"+p.password, detectors.DefaultFalsePositives, false)
+}
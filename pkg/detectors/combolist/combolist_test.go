@@ -0,0 +1,92 @@
+package combolist
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCombolist_Pattern(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		pairs []string
+	}{
+		{
+			name:  "combolist",
+			data:  "alice@mail.com:hunter2!\nbob@corp.io;S3cr3tPass\r\ncarol@uni.edu|pa55word\n",
+			pairs: []string{"alice@mail.com:hunter2!", "bob@corp.io:S3cr3tPass", "carol@uni.edu:pa55word"},
+		},
+		{
+			name:  "csv dump",
+			data:  "id,username,name,password\n1,dave@shop.com,Dave,\"qw3rty,1\"\n2,not-an-email,Eve,letmein1\n",
+			pairs: []string{"dave@shop.com:qw3rty,1"},
+		},
+		{
+			name:  "ports, repositories, and placeholders",
+			data:  "git@github.com:org/repo.git\nadmin@db.internal:5432\nuser@example.com:hunter2!\n",
+			pairs: nil,
+		},
+		{
+			name:  "prose",
+			data:  "Contact alice@mail.com: she knows the password.\n",
+			pairs: nil,
+		},
+	}
+	for _, test := range tests {
+		var got []string
+		for _, p := range pairs(test.data) {
+			got = append(got, p.email+":"+p.password)
+		}
+		if strings.Join(got, "\n") != strings.Join(test.pairs, "\n") {
+			t.Errorf("%s: expected %v, got %v", test.name, test.pairs, got)
+		}
+	}
+}
+
+func TestCombolist_FromData(t *testing.T) {
+	defer SetCountOnly(false)
+	s := Scanner{}
+	ctx := context.Background()
+
+	results, err := s.FromData(ctx, false, []byte("alice@mail.com:hunter2!\nbob@corp.io:S3cr3tPass\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || string(results[0].Raw) != "alice@mail.com:hunter2!" || results[0].Redacted != "alice@mail.com:****" {
+		t.Errorf("expected a result per pair, got %+v", results)
+	}
+
+	var dump strings.Builder
+	for i := 0; i <= DefaultBulkThreshold; i++ {
+		domain := "gmail.com"
+		if i%3 == 0 {
+			domain = "yahoo.com"
+		}
+		fmt.Fprintf(&dump, "user%d@%s:Passw0rd%d\n", i, domain, i)
+	}
+	results, err = s.FromData(ctx, false, []byte(dump.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected one bulk result, got %d", len(results))
+	}
+	bulk := results[0]
+	if bulk.ExtraData["pairs"] != "11" || bulk.ExtraData["top_domains"] != "gmail.com (7), yahoo.com (4)" {
+		t.Errorf("unexpected bulk result: %+v", bulk.ExtraData)
+	}
+	if strings.Contains(string(bulk.Raw), "Passw0rd") || strings.Contains(bulk.Redacted, "Passw0rd") {
+		t.Error("expected the bulk result not to include passwords")
+	}
+
+	SetCountOnly(true)
+	results, err = s.FromData(ctx, false, []byte("alice@mail.com:hunter2!\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].ExtraData["pairs"] != "1" || strings.Contains(string(results[0].Raw), "hunter2") {
+		t.Errorf("expected a count in count-only mode, got %+v", results)
+	}
+}
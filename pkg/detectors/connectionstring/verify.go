@@ -0,0 +1,166 @@
+package connectionstring
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/lib/pq"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+)
+
+const dialTimeout = 5 * time.Second
+
+// verifyConnection connects to the host in the connection string. PostgreSQL and Redis credentials are verified by
+// authenticating. For other protocols, only whether the host is reachable is reported.
+func verifyConnection(ctx context.Context, conn connection) (verified, reachable bool) {
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+
+	address, err := resolve(ctx, conn)
+	if err != nil {
+		return false, false
+	}
+	dialer := &trackingDialer{}
+	switch conn.scheme {
+	case "postgres", "postgresql":
+		err = authPostgres(ctx, dialer, conn, address)
+	case "redis", "rediss":
+		err = authRedis(ctx, dialer, conn, address)
+	default:
+		c, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return false, false
+		}
+		c.Close()
+		return false, true
+	}
+	return err == nil, dialer.connected
+}
+
+// resolve returns the address to connect to. mongodb+srv URIs name a DNS SRV record rather than a host.
+func resolve(ctx context.Context, conn connection) (string, error) {
+	if conn.scheme != "mongodb+srv" {
+		return net.JoinHostPort(conn.host, conn.port), nil
+	}
+	_, records, err := net.DefaultResolver.LookupSRV(ctx, "mongodb", "tcp", conn.host)
+	if err != nil {
+		return "", err
+	}
+	if len(records) == 0 {
+		return "", fmt.Errorf("no SRV records for %s", conn.host)
+	}
+	return net.JoinHostPort(strings.TrimSuffix(records[0].Target, "."), strconv.Itoa(int(records[0].Port))), nil
+}
+
+// trackingDialer opens connections with common.Dialer, so they're recorded in the egress audit log, and remembers
+// whether one was made, so that a host that rejects the credentials is still reported as reachable.
+type trackingDialer struct {
+	common.Dialer
+	connected bool
+}
+
+func (d *trackingDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	c, err := d.Dialer.DialContext(ctx, network, address)
+	if err == nil {
+		d.connected = true
+	}
+	return c, err
+}
+
+// Dial and DialTimeout are required by pq.Dialer, though pq only uses DialContext when a dialer has it.
+func (d *trackingDialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, address)
+}
+
+func (d *trackingDialer) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return d.DialContext(ctx, network, address)
+}
+
+// authRedis authenticates with AUTH, with the username if there is one. Servers before Redis 6 don't have users, and
+// reject AUTH with two arguments, so the password is then tried alone.
+func authRedis(ctx context.Context, dialer *trackingDialer, conn connection, address string) error {
+	err := pingRedis(ctx, dialer, conn, conn.username, address)
+	if err != nil && conn.username != "" && strings.Contains(err.Error(), "wrong number of arguments") {
+		err = pingRedis(ctx, dialer, conn, "", address)
+	}
+	return err
+}
+
+func pingRedis(ctx context.Context, dialer *trackingDialer, conn connection, username, address string) error {
+	client := redis.NewClient(&redis.Options{
+		Addr:     address,
+		Username: username,
+		Password: conn.password,
+		Dialer: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			c, err := dialer.DialContext(ctx, network, addr)
+			if err != nil || conn.scheme != "rediss" {
+				return c, err
+			}
+			return tls.Client(c, &tls.Config{ServerName: conn.host}), nil
+		},
+		MaxRetries: -1,
+		PoolSize:   1,
+	})
+	defer client.Close()
+
+	err := client.Ping(ctx).Err()
+	// A user may be allowed to authenticate but not to run PING.
+	if err != nil && strings.HasPrefix(err.Error(), "NOPERM") {
+		return nil
+	}
+	return err
+}
+
+// authPostgres authenticates with the password. It requires TLS, without verifying the server's certificate, so the
+// password is never sent to servers that don't support it, and their credentials are left unverified.
+func authPostgres(ctx context.Context, dialer *trackingDialer, conn connection, address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	database := conn.database
+	if database == "" {
+		database = conn.username
+	}
+	// These settings take precedence over the PG environment variables, which are meant for the user's own databases.
+	connector, err := pq.NewConnector(postgresDSN(map[string]string{
+		"host":            host,
+		"port":            port,
+		"user":            conn.username,
+		"password":        conn.password,
+		"dbname":          database,
+		"sslmode":         "require",
+		"sslrootcert":     "",
+		"connect_timeout": strconv.Itoa(int(dialTimeout / time.Second)),
+	}))
+	if err != nil {
+		return err
+	}
+	connector.Dialer(dialer)
+	c, err := connector.Connect(ctx)
+	if err != nil {
+		return err
+	}
+	// The credentials were accepted once the connection is open, however it's closed.
+	_ = c.Close()
+	return nil
+}
+
+// postgresDSN returns a key/value connection string, quoting the values.
+func postgresDSN(settings map[string]string) string {
+	quote := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	var dsn []string
+	for key, value := range settings {
+		dsn = append(dsn, key+"='"+quote.Replace(value)+"'")
+	}
+	return strings.Join(dsn, " ")
+}
@@ -0,0 +1,130 @@
+// Package consul detects HashiCorp Consul ACL tokens.
+//
+// Consul is always self-hosted, so tokens are only verified against the server set with SetAddress.
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	client = common.SaneHttpClient()
+
+	// Token secret IDs are UUIDs, so they're only found near the provider name.
+	keyPat = regexp.MustCompile(detectors.PrefixRegex([]string{"consul"}) + `\b([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})\b`)
+
+	address string
+)
+
+// SetAddress sets the address of the Consul server to verify tokens against, e.g. https://consul.internal:8501.
+// Without it, tokens are never verified. It must be called before scanning starts.
+func SetAddress(addr string) {
+	address = strings.TrimSuffix(addr, "/")
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"consul"}
+}
+
+// FromData will find and optionally verify Consul tokens in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
+
+	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		if len(match) != 2 {
+			continue
+		}
+		token := match[1]
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Consul,
+			Raw:          []byte(token),
+		}
+
+		if verify && address != "" {
+			extraData, management := readSelf(ctx, token)
+			if extraData != nil {
+				s1.Verified = true
+				s1.ExtraData = extraData
+				if management {
+					s1.Severity = detectors.SeverityCritical
+				}
+			}
+		}
+
+		results = append(results, s1)
+	}
+
+	return detectors.CleanResults(results), nil
+}
+
+// readSelf returns the token's policies and roles, and whether it has the global-management policy, which can do
+// anything.
+func readSelf(ctx context.Context, token string) (extraData map[string]string, management bool) {
+	req, err := http.NewRequestWithContext(ctx, "GET", address+"/v1/acl/token/self", nil)
+	if err != nil {
+		return nil, false
+	}
+	req.Header.Add("X-Consul-Token", token)
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, false
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, false
+	}
+
+	type link struct {
+		Name string `json:"Name"`
+	}
+	var self struct {
+		AccessorID  string `json:"AccessorID"`
+		Description string `json:"Description"`
+		Policies    []link `json:"Policies"`
+		Roles       []link `json:"Roles"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&self); err != nil {
+		return nil, false
+	}
+	names := func(links []link) string {
+		var names []string
+		for _, l := range links {
+			names = append(names, l.Name)
+		}
+		return strings.Join(names, ",")
+	}
+	extraData = map[string]string{
+		"address":     address,
+		"accessor_id": self.AccessorID,
+		"description": self.Description,
+		"policies":    names(self.Policies),
+		"roles":       names(self.Roles),
+	}
+	for _, policy := range self.Policies {
+		if policy.Name == "global-management" {
+			management = true
+		}
+	}
+	return extraData, management
+}
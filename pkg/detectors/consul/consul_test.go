@@ -0,0 +1,51 @@
+package consul
+
+import (
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
+)
+
+func TestConsul_FromData(t *testing.T) {
+	const token = "6a1f3c2e-8b4d-4e7a-9c5f-2d8e1b7a3f60"
+	SetAddress("https://consul.corp.net:8501")
+	defer SetAddress("")
+
+	detectortest.Test(t, Scanner{},
+		detectortest.Fixture{
+			Name:   "valid token",
+			Data:   "CONSUL_HTTP_TOKEN=" + token,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "consul.corp.net",
+				Path:   "/v1/acl/token/self",
+				Header: map[string]string{"X-Consul-Token": token},
+				Status: 200,
+				Body: `{"AccessorID": "e8b6c2a1-4d3f-4b9e-8a7c-1f2e3d4c5b6a", "SecretID": "` + token + `",
+					"Description": "ci deploys", "Policies": [{"ID": "1", "Name": "kv-write"}], "Roles": [{"ID": "2", "Name": "deployer"}]}`,
+			}},
+			Want: []detectortest.Want{{
+				Raw:      token,
+				Verified: true,
+				ExtraData: map[string]string{
+					"address":     "https://consul.corp.net:8501",
+					"accessor_id": "e8b6c2a1-4d3f-4b9e-8a7c-1f2e3d4c5b6a",
+					"description": "ci deploys",
+					"policies":    "kv-write",
+					"roles":       "deployer",
+				},
+			}},
+		},
+		detectortest.Fixture{
+			Name:   "unknown token",
+			Data:   `consul { token = "` + token + `" }`,
+			Verify: true,
+			Mocks:  []detectortest.Mock{{Host: "consul.corp.net", Status: 403, Body: "ACL not found"}},
+			Want:   []detectortest.Want{{Raw: token}},
+		},
+		detectortest.Fixture{
+			Name: "UUID without the provider name",
+			Data: "request_id: " + token,
+		},
+	)
+}
@@ -0,0 +1,139 @@
+// Package custom runs detectors defined by regular expression rules, such as those imported from gitleaks and semgrep
+// rule packs by Import. Rules can't be verified, since nothing is known about the credentials they find.
+package custom
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+// Rule finds secrets with a regular expression.
+type Rule struct {
+	ID          string
+	Description string
+	// Source is the format the rule was imported from, such as "gitleaks".
+	Source string
+	Regex  *regexp.Regexp
+	// SecretGroup is the capture group holding the secret. Zero is the whole match.
+	SecretGroup int
+	// Keywords pre-filter chunks. A rule without keywords runs on every chunk.
+	Keywords []string
+	// Entropy is the least Shannon entropy a secret must have, if it's set.
+	Entropy float64
+	// Allow drops secrets that match any of the expressions, and AllowMatch matches that do.
+	Allow      []*regexp.Regexp
+	AllowMatch []*regexp.Regexp
+	// StopWords drops secrets that contain any of the words, case insensitively.
+	StopWords []string
+	Severity  detectors.Severity
+}
+
+// Scanner is the detector of one rule.
+type Scanner struct {
+	Rule Rule
+}
+
+// Ensure the Scanner satisfies the interfaces at compile time
+var (
+	_ detectors.Detector   = (*Scanner)(nil)
+	_ detectors.Unfiltered = (*Scanner)(nil)
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+func (s Scanner) Keywords() []string {
+	return s.Rule.Keywords
+}
+
+// Unfiltered runs rules without keywords on every chunk.
+func (s Scanner) Unfiltered() bool {
+	return len(s.Rule.Keywords) == 0
+}
+
+// FromData will find the secrets the rule matches in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	rule := &s.Rule
+	for _, match := range rule.Regex.FindAllSubmatch(data, -1) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		if rule.SecretGroup >= len(match) {
+			continue
+		}
+		secret := string(match[rule.SecretGroup])
+		if secret == "" || !rule.allowed(string(match[0]), secret) {
+			continue
+		}
+		result := detectors.Result{
+			DetectorType: detectorspb.DetectorType_CustomRegex,
+			Raw:          []byte(secret),
+			Redacted:     rule.ID,
+			Severity:     rule.Severity,
+			ExtraData: map[string]string{
+				"rule":        rule.ID,
+				"rule_source": rule.Source,
+			},
+		}
+		if rule.Description != "" {
+			result.ExtraData["rule_description"] = rule.Description
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// allowed returns false if the rule's entropy, allowlists, or stop words drop the secret.
+func (r *Rule) allowed(match, secret string) bool {
+	if r.Entropy > 0 && detectors.ShannonEntropy(secret) < r.Entropy {
+		return false
+	}
+	for _, allow := range r.Allow {
+		if allow.MatchString(secret) {
+			return false
+		}
+	}
+	for _, allow := range r.AllowMatch {
+		if allow.MatchString(match) {
+			return false
+		}
+	}
+	lower := strings.ToLower(secret)
+	for _, word := range r.StopWords {
+		if strings.Contains(lower, strings.ToLower(word)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Detectors returns a detector for each rule.
+func Detectors(rules []Rule) []detectors.Detector {
+	ds := make([]detectors.Detector, len(rules))
+	for i := range rules {
+		ds[i] = &Scanner{Rule: rules[i]}
+	}
+	return ds
+}
+
+// Import reads the rules of a gitleaks configuration, by its .toml extension, or of a semgrep rules file, by its .yml
+// or .yaml extension. Warnings describe the rules and settings that couldn't be imported.
+func Import(path string) ([]Rule, []string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		return ParseGitleaks(data)
+	case ".yml", ".yaml":
+		return ParseSemgrep(data)
+	}
+	return nil, nil, fmt.Errorf("unknown rules format of %s, expected a gitleaks .toml or semgrep .yaml file", path)
+}
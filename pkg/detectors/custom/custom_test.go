@@ -0,0 +1,168 @@
+package custom
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+const gitleaksConfig = `
+title = "acme rules"
+
+[extend]
+useDefault = true
+
+[allowlist]
+description = "global allowlist"
+stopwords = ["placeholder"]
+
+[[rules]]
+id = "acme-api-key"
+description = "Acme API key"
+regex = '''(?i)acme[_-]?key\s*[=:]\s*["']?(acme_[a-z0-9]{16})["']?'''
+keywords = [
+    "acme", # the provider
+    "acme_",
+]
+entropy = 3.0
+
+[rules.allowlist]
+regexes = ['''acme_0{16}''']
+
+[[rules]]
+id = "acme-webhook"
+description = "Acme webhook URL"
+regex = "https://hooks\\.acme\\.io/[A-Za-z0-9]{8}"
+secretGroup = 0
+path = '''\.env$'''
+
+[[rules]]
+id = "broken"
+regex = "(unclosed"
+`
+
+func TestParseTOML(t *testing.T) {
+	config, err := parseTOML(`
+a = "x\tyé"
+b.c = 'lit\eral'
+d = """
+multi \
+  line"""
+e = [1, 2.5, true, { f = "g" }]
+[t.u]
+v = -3
+[[arr]]
+w = 1
+[[arr]]
+w = 2
+[arr.sub]
+x = "y"
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"a": "x\tyé",
+		"b": map[string]interface{}{"c": `lit\eral`},
+		"d": "multi line",
+		"e": []interface{}{int64(1), 2.5, true, map[string]interface{}{"f": "g"}},
+		"t": map[string]interface{}{"u": map[string]interface{}{"v": int64(-3)}},
+		"arr": []interface{}{
+			map[string]interface{}{"w": int64(1)},
+			map[string]interface{}{"w": int64(2), "sub": map[string]interface{}{"x": "y"}},
+		},
+	}
+	if !reflect.DeepEqual(config, expected) {
+		t.Errorf("expected %v, got %v", expected, config)
+	}
+
+	for _, invalid := range []string{`a = "unterminated`, `a = 1` + "\n" + `a = 2`, `= 1`, `a = [1 2]`} {
+		if _, err := parseTOML(invalid); err == nil {
+			t.Errorf("expected %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestParseGitleaks(t *testing.T) {
+	rules, warnings, err := ParseGitleaks([]byte(gitleaksConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules))
+	}
+	if len(warnings) != 3 {
+		t.Errorf("expected warnings for extend, the path, and the broken rule, got %q", warnings)
+	}
+	key := rules[0]
+	if key.ID != "acme-api-key" || key.SecretGroup != 1 || key.Entropy != 3 ||
+		!reflect.DeepEqual(key.Keywords, []string{"acme", "acme_"}) || len(key.Allow) != 1 ||
+		!reflect.DeepEqual(key.StopWords, []string{"placeholder"}) {
+		t.Errorf("unexpected rule: %+v", key)
+	}
+
+	s := Scanner{Rule: key}
+	results, err := s.FromData(context.Background(), false, []byte(`
+ACME_KEY = "acme_9f8a7b6c5d4e3f21"
+acme-key: acme_0000000000000000
+acme_key=acme_aaaaaaaaaaaaaaaa
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || string(results[0].Raw) != "acme_9f8a7b6c5d4e3f21" || results[0].ExtraData["rule"] != "acme-api-key" {
+		t.Errorf("expected one result with the secret group, got %+v", results)
+	}
+
+	webhook := Scanner{Rule: rules[1]}
+	if !webhook.Unfiltered() || webhook.Rule.SecretGroup != 0 {
+		t.Errorf("expected a rule without keywords to run on every chunk, got %+v", webhook.Rule)
+	}
+}
+
+func TestParseSemgrep(t *testing.T) {
+	rules, warnings, err := ParseSemgrep([]byte(`
+rules:
+  - id: acme-token
+    message: Acme token
+    severity: ERROR
+    languages: [generic]
+    patterns:
+      - pattern-regex: acmetok_[A-Za-z0-9]{20}
+      - pattern-not-regex: acmetok_TEST
+  - id: acme-either
+    severity: WARNING
+    pattern-either:
+      - pattern-regex: acmeA_[0-9]{8}
+      - pattern-regex: acmeB_[0-9]{8}
+  - id: structural
+    pattern: password = "..."
+  - id: lookahead
+    pattern-regex: (?=secret)secret
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 2 || len(warnings) != 2 {
+		t.Fatalf("expected 2 rules and 2 warnings, got %d and %q", len(rules), warnings)
+	}
+	if rules[0].Severity != detectors.SeverityHigh || rules[1].Severity != detectors.SeverityMedium {
+		t.Errorf("unexpected severities %s and %s", rules[0].Severity, rules[1].Severity)
+	}
+
+	s := Scanner{Rule: rules[0]}
+	results, err := s.FromData(context.Background(), false, []byte("acmetok_TESTTESTTESTTESTTEST acmetok_a1b2c3d4e5f6g7h8i9j0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || string(results[0].Raw) != "acmetok_a1b2c3d4e5f6g7h8i9j0" {
+		t.Errorf("expected the not-regex to drop the test token, got %+v", results)
+	}
+
+	s = Scanner{Rule: rules[1]}
+	if results, _ := s.FromData(context.Background(), false, []byte("acmeA_12345678 acmeB_87654321")); len(results) != 2 {
+		t.Errorf("expected both alternatives to match, got %d results", len(results))
+	}
+}
@@ -0,0 +1,160 @@
+package custom
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ParseGitleaks converts the rules of a gitleaks configuration. Each rule's regex, secretGroup, entropy, keywords, and
+// allowlists are converted, with the configuration's global allowlist added to every rule. Allowlists by path or
+// commit, and rules that only match paths, can't be applied to a detector, so they're skipped and described in the
+// returned warnings.
+func ParseGitleaks(data []byte) ([]Rule, []string, error) {
+	config, err := parseTOML(string(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse gitleaks configuration: %w", err)
+	}
+	var warnings []string
+	if extend, ok := config["extend"].(map[string]interface{}); ok && len(extend) > 0 {
+		warnings = append(warnings, "[extend] is ignored; only the rules in the file are imported")
+	}
+
+	var global gitleaksAllowlist
+	if allowlist, ok := config["allowlist"].(map[string]interface{}); ok {
+		if global, err = parseGitleaksAllowlist(allowlist, "global allowlist", &warnings); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	rawRules, _ := config["rules"].([]interface{})
+	var rules []Rule
+	for i, raw := range rawRules {
+		table, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("rule %d is not a table", i+1)
+		}
+		id := tomlString(table, "id")
+		if id == "" {
+			id = fmt.Sprintf("rule-%d", i+1)
+		}
+		pattern := tomlString(table, "regex")
+		if pattern == "" {
+			warnings = append(warnings, fmt.Sprintf("%s: skipped, since it has no regex", id))
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: skipped, since its regex is invalid: %v", id, err))
+			continue
+		}
+		if tomlString(table, "path") != "" {
+			warnings = append(warnings, fmt.Sprintf("%s: its path is ignored, so it applies to every file", id))
+		}
+
+		rule := Rule{
+			ID:          id,
+			Description: tomlString(table, "description"),
+			Source:      "gitleaks",
+			Regex:       re,
+			Keywords:    tomlStrings(table, "keywords"),
+		}
+		if entropy, ok := tomlFloat(table, "entropy"); ok {
+			rule.Entropy = entropy
+		}
+		// gitleaks uses the first group of regexes that have one, unless secretGroup says otherwise.
+		if group, ok := tomlFloat(table, "secretGroup"); ok && group > 0 {
+			rule.SecretGroup = int(group)
+		} else if re.NumSubexp() > 0 {
+			rule.SecretGroup = 1
+		}
+		if rule.SecretGroup > re.NumSubexp() {
+			warnings = append(warnings, fmt.Sprintf("%s: skipped, since its regex has no group %d", id, rule.SecretGroup))
+			continue
+		}
+
+		allowlists := []gitleaksAllowlist{global}
+		// Rules have an allowlist table in older versions of gitleaks, and an array of them in newer ones.
+		var tables []interface{}
+		if t, ok := table["allowlist"].(map[string]interface{}); ok {
+			tables = append(tables, t)
+		}
+		if ts, ok := table["allowlists"].([]interface{}); ok {
+			tables = append(tables, ts...)
+		}
+		for _, t := range tables {
+			t, ok := t.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			allowlist, err := parseGitleaksAllowlist(t, id, &warnings)
+			if err != nil {
+				return nil, nil, err
+			}
+			allowlists = append(allowlists, allowlist)
+		}
+		for _, allowlist := range allowlists {
+			rule.Allow = append(rule.Allow, allowlist.secret...)
+			rule.AllowMatch = append(rule.AllowMatch, allowlist.match...)
+			rule.StopWords = append(rule.StopWords, allowlist.stopWords...)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, warnings, nil
+}
+
+// gitleaksAllowlist holds the regexes of an allowlist by what they're matched against.
+type gitleaksAllowlist struct {
+	secret, match []*regexp.Regexp
+	stopWords     []string
+}
+
+func parseGitleaksAllowlist(table map[string]interface{}, name string, warnings *[]string) (gitleaksAllowlist, error) {
+	var allowlist gitleaksAllowlist
+	target := tomlString(table, "regexTarget")
+	for _, pattern := range tomlStrings(table, "regexes") {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return allowlist, fmt.Errorf("%s: invalid allowlist regex %q: %w", name, pattern, err)
+		}
+		// Detectors don't see whole lines, so line targets are matched against the match instead.
+		if target == "match" || target == "line" {
+			allowlist.match = append(allowlist.match, re)
+		} else {
+			allowlist.secret = append(allowlist.secret, re)
+		}
+	}
+	allowlist.stopWords = tomlStrings(table, "stopwords")
+	for _, key := range []string{"paths", "commits"} {
+		if len(tomlStrings(table, key)) > 0 {
+			*warnings = append(*warnings, fmt.Sprintf("%s: allowlist %s are ignored", name, key))
+		}
+	}
+	return allowlist, nil
+}
+
+func tomlString(table map[string]interface{}, key string) string {
+	s, _ := table[key].(string)
+	return strings.TrimSpace(s)
+}
+
+func tomlStrings(table map[string]interface{}, key string) []string {
+	values, _ := table[key].([]interface{})
+	var strs []string
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
+
+func tomlFloat(table map[string]interface{}, key string) (float64, bool) {
+	switch v := table[key].(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
@@ -0,0 +1,115 @@
+package custom
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+// semgrepRule is the part of a semgrep rule that secret rules use.
+type semgrepRule struct {
+	ID            string           `yaml:"id"`
+	Message       string           `yaml:"message"`
+	Severity      string           `yaml:"severity"`
+	PatternRegex  string           `yaml:"pattern-regex"`
+	Patterns      []semgrepPattern `yaml:"patterns"`
+	PatternEither []semgrepPattern `yaml:"pattern-either"`
+}
+
+type semgrepPattern struct {
+	PatternRegex    string `yaml:"pattern-regex"`
+	PatternNotRegex string `yaml:"pattern-not-regex"`
+}
+
+// semgrepSeverities maps semgrep's severities to trufflehog's.
+var semgrepSeverities = map[string]detectors.Severity{
+	"ERROR":    detectors.SeverityHigh,
+	"WARNING":  detectors.SeverityMedium,
+	"INFO":     detectors.SeverityLow,
+	"CRITICAL": detectors.SeverityCritical,
+	"HIGH":     detectors.SeverityHigh,
+	"MEDIUM":   detectors.SeverityMedium,
+	"LOW":      detectors.SeverityLow,
+}
+
+// ParseSemgrep converts the regular expression rules of a semgrep rules file: rules with a pattern-regex, a
+// pattern-either of them, or patterns with one pattern-regex and any number of pattern-not-regex. The secret is the
+// whole match. Rules that match code structurally rather than with regexes, and regexes that Go can't compile, such as
+// those with lookarounds, are skipped and described in the returned warnings.
+func ParseSemgrep(data []byte) ([]Rule, []string, error) {
+	var file struct {
+		Rules []semgrepRule `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, nil, fmt.Errorf("could not parse semgrep rules: %w", err)
+	}
+
+	var rules []Rule
+	var warnings []string
+	for i, r := range file.Rules {
+		id := r.ID
+		if id == "" {
+			id = fmt.Sprintf("rule-%d", i+1)
+		}
+		var patterns, notPatterns []string
+		switch {
+		case r.PatternRegex != "":
+			patterns = []string{r.PatternRegex}
+		case len(r.PatternEither) > 0:
+			for _, p := range r.PatternEither {
+				patterns = append(patterns, p.PatternRegex)
+			}
+		case len(r.Patterns) > 0:
+			for _, p := range r.Patterns {
+				if p.PatternRegex != "" {
+					patterns = append(patterns, p.PatternRegex)
+				}
+				if p.PatternNotRegex != "" {
+					notPatterns = append(notPatterns, p.PatternNotRegex)
+				}
+			}
+			if len(patterns) != 1 || len(notPatterns)+1 != len(r.Patterns) {
+				patterns = nil
+			}
+		}
+		if len(patterns) == 0 || containsEmpty(patterns) {
+			warnings = append(warnings, fmt.Sprintf("%s: skipped, since only regex rules can be imported", id))
+			continue
+		}
+
+		re, err := regexp.Compile("(?:" + strings.Join(patterns, ")|(?:") + ")")
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: skipped, since its regex is invalid: %v", id, err))
+			continue
+		}
+		rule := Rule{
+			ID:          id,
+			Description: strings.TrimSpace(r.Message),
+			Source:      "semgrep",
+			Regex:       re,
+			Severity:    semgrepSeverities[strings.ToUpper(r.Severity)],
+		}
+		for _, pattern := range notPatterns {
+			not, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: invalid pattern-not-regex %q: %w", id, pattern, err)
+			}
+			rule.AllowMatch = append(rule.AllowMatch, not)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, warnings, nil
+}
+
+func containsEmpty(strs []string) bool {
+	for _, s := range strs {
+		if s == "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,399 @@
+package custom
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// parseTOML parses the subset of TOML that gitleaks configurations use: tables, arrays of tables, strings of every
+// kind, integers, floats, booleans, arrays, and inline tables. Dates aren't supported. Tables are
+// map[string]interface{} and arrays are []interface{}.
+func parseTOML(data string) (map[string]interface{}, error) {
+	p := &tomlParser{data: data, line: 1}
+	root := map[string]interface{}{}
+	table := root
+	for {
+		p.skipSpace(true)
+		if p.eof() {
+			return root, nil
+		}
+		var err error
+		switch {
+		case strings.HasPrefix(p.rest(), "[["):
+			p.pos += 2
+			table, err = p.header(root, "]]", true)
+		case p.peek() == '[':
+			p.pos++
+			table, err = p.header(root, "]", false)
+		default:
+			err = p.keyValue(table)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", p.line, err)
+		}
+		p.skipSpace(false)
+		if !p.eof() && p.peek() != '\n' {
+			return nil, fmt.Errorf("line %d: unexpected %q", p.line, p.peek())
+		}
+	}
+}
+
+type tomlParser struct {
+	data string
+	pos  int
+	line int
+}
+
+func (p *tomlParser) eof() bool    { return p.pos >= len(p.data) }
+func (p *tomlParser) peek() byte   { return p.data[p.pos] }
+func (p *tomlParser) rest() string { return p.data[p.pos:] }
+
+// skipSpace skips spaces and comments, and newlines too if multiLine is set.
+func (p *tomlParser) skipSpace(multiLine bool) {
+	for !p.eof() {
+		switch c := p.peek(); {
+		case c == ' ' || c == '\t' || c == '\r':
+			p.pos++
+		case c == '\n' && multiLine:
+			p.pos++
+			p.line++
+		case c == '#':
+			for !p.eof() && p.peek() != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// header reads the name of a table or array of tables, and returns the table it opens.
+func (p *tomlParser) header(root map[string]interface{}, end string, array bool) (map[string]interface{}, error) {
+	keys, err := p.key()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace(false)
+	if !strings.HasPrefix(p.rest(), end) {
+		return nil, fmt.Errorf("expected %q after table name", end)
+	}
+	p.pos += len(end)
+
+	table := root
+	for i, key := range keys {
+		last := i == len(keys)-1
+		switch v := table[key].(type) {
+		case nil:
+			if last && array {
+				next := map[string]interface{}{}
+				table[key] = []interface{}{next}
+				return next, nil
+			}
+			next := map[string]interface{}{}
+			table[key] = next
+			table = next
+		case map[string]interface{}:
+			if last && array {
+				return nil, fmt.Errorf("%s is a table, not an array of tables", strings.Join(keys, "."))
+			}
+			table = v
+		case []interface{}:
+			if last && array {
+				next := map[string]interface{}{}
+				table[key] = append(v, next)
+				return next, nil
+			}
+			next, ok := v[len(v)-1].(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s is not a table", strings.Join(keys[:i+1], "."))
+			}
+			table = next
+		default:
+			return nil, fmt.Errorf("%s is not a table", strings.Join(keys[:i+1], "."))
+		}
+	}
+	return table, nil
+}
+
+// keyValue reads a key and its value into table.
+func (p *tomlParser) keyValue(table map[string]interface{}) error {
+	keys, err := p.key()
+	if err != nil {
+		return err
+	}
+	p.skipSpace(false)
+	if p.eof() || p.peek() != '=' {
+		return fmt.Errorf("expected = after %s", strings.Join(keys, "."))
+	}
+	p.pos++
+	p.skipSpace(false)
+	value, err := p.value()
+	if err != nil {
+		return err
+	}
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := table[key].(map[string]interface{})
+		if !ok {
+			if table[key] != nil {
+				return fmt.Errorf("%s is not a table", key)
+			}
+			next = map[string]interface{}{}
+			table[key] = next
+		}
+		table = next
+	}
+	key := keys[len(keys)-1]
+	if _, ok := table[key]; ok {
+		return fmt.Errorf("%s is defined twice", strings.Join(keys, "."))
+	}
+	table[key] = value
+	return nil
+}
+
+// key reads a dotted key of bare and quoted parts.
+func (p *tomlParser) key() ([]string, error) {
+	var keys []string
+	for {
+		p.skipSpace(false)
+		if p.eof() {
+			return nil, fmt.Errorf("expected a key")
+		}
+		var key string
+		switch p.peek() {
+		case '"', '\'':
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			key = v.(string)
+		default:
+			start := p.pos
+			for !p.eof() && isBareKeyChar(p.peek()) {
+				p.pos++
+			}
+			if p.pos == start {
+				return nil, fmt.Errorf("unexpected %q in key", p.peek())
+			}
+			key = p.data[start:p.pos]
+		}
+		keys = append(keys, key)
+		p.skipSpace(false)
+		if p.eof() || p.peek() != '.' {
+			return keys, nil
+		}
+		p.pos++
+	}
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+func (p *tomlParser) value() (interface{}, error) {
+	if p.eof() {
+		return nil, fmt.Errorf("expected a value")
+	}
+	rest := p.rest()
+	switch {
+	case strings.HasPrefix(rest, `"""`):
+		return p.multiLineString(`"""`, true)
+	case strings.HasPrefix(rest, "'''"):
+		return p.multiLineString("'''", false)
+	case rest[0] == '"':
+		return p.basicString()
+	case rest[0] == '\'':
+		end := strings.IndexAny(rest[1:], "'\n")
+		if end < 0 || rest[1+end] != '\'' {
+			return nil, fmt.Errorf("unterminated string")
+		}
+		p.pos += end + 2
+		return rest[1 : 1+end], nil
+	case rest[0] == '[':
+		return p.array()
+	case rest[0] == '{':
+		return p.inlineTable()
+	case strings.HasPrefix(rest, "true"):
+		p.pos += 4
+		return true, nil
+	case strings.HasPrefix(rest, "false"):
+		p.pos += 5
+		return false, nil
+	}
+
+	start := p.pos
+	for !p.eof() && strings.IndexByte("+-_.eE0123456789xobabcdefABCDEFinf", p.peek()) >= 0 {
+		p.pos++
+	}
+	literal := strings.ReplaceAll(p.data[start:p.pos], "_", "")
+	if literal == "" {
+		return nil, fmt.Errorf("unexpected %q in value", p.peek())
+	}
+	if n, err := strconv.ParseInt(literal, 0, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(literal, 64); err == nil {
+		return f, nil
+	}
+	return nil, fmt.Errorf("invalid value %q", literal)
+}
+
+func (p *tomlParser) basicString() (string, error) {
+	p.pos++
+	var b strings.Builder
+	for !p.eof() {
+		c := p.peek()
+		switch c {
+		case '"':
+			p.pos++
+			return b.String(), nil
+		case '\n':
+			return "", fmt.Errorf("unterminated string")
+		case '\\':
+			if err := p.escape(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	return "", fmt.Errorf("unterminated string")
+}
+
+// multiLineString reads a string delimited by three double or single quotes. A newline right after the opening delimiter is trimmed, and
+// in basic strings, a backslash at the end of a line trims the newline and the whitespace after it.
+func (p *tomlParser) multiLineString(delim string, basic bool) (string, error) {
+	p.pos += len(delim)
+	if strings.HasPrefix(p.rest(), "\r\n") {
+		p.pos += 2
+		p.line++
+	} else if strings.HasPrefix(p.rest(), "\n") {
+		p.pos++
+		p.line++
+	}
+	var b strings.Builder
+	for !p.eof() {
+		// Up to two quotes may end the content, as in """a"""".
+		if strings.HasPrefix(p.rest(), delim) && !strings.HasPrefix(p.data[p.pos+1:], delim) {
+			p.pos += len(delim)
+			return b.String(), nil
+		}
+		c := p.peek()
+		switch {
+		case c == '\n':
+			p.line++
+			b.WriteByte(c)
+			p.pos++
+		case c == '\\' && basic:
+			trimmed := strings.TrimLeft(p.data[p.pos+1:], " \t\r")
+			if strings.HasPrefix(trimmed, "\n") {
+				p.pos = len(p.data) - len(trimmed)
+				for !p.eof() && strings.IndexByte(" \t\r\n", p.peek()) >= 0 {
+					if p.peek() == '\n' {
+						p.line++
+					}
+					p.pos++
+				}
+				continue
+			}
+			if err := p.escape(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	return "", fmt.Errorf("unterminated string")
+}
+
+func (p *tomlParser) escape(b *strings.Builder) error {
+	if p.pos+1 >= len(p.data) {
+		return fmt.Errorf("unterminated escape")
+	}
+	c := p.data[p.pos+1]
+	p.pos += 2
+	switch c {
+	case 'b':
+		b.WriteByte('\b')
+	case 't':
+		b.WriteByte('\t')
+	case 'n':
+		b.WriteByte('\n')
+	case 'f':
+		b.WriteByte('\f')
+	case 'r':
+		b.WriteByte('\r')
+	case '"', '\\':
+		b.WriteByte(c)
+	case 'u', 'U':
+		size := 4
+		if c == 'U' {
+			size = 8
+		}
+		if p.pos+size > len(p.data) {
+			return fmt.Errorf("invalid unicode escape")
+		}
+		n, err := strconv.ParseUint(p.data[p.pos:p.pos+size], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(n)) {
+			return fmt.Errorf("invalid unicode escape")
+		}
+		b.WriteRune(rune(n))
+		p.pos += size
+	default:
+		return fmt.Errorf("invalid escape \\%c", c)
+	}
+	return nil
+}
+
+func (p *tomlParser) array() ([]interface{}, error) {
+	p.pos++
+	values := []interface{}{}
+	for {
+		p.skipSpace(true)
+		if p.eof() {
+			return nil, fmt.Errorf("unterminated array")
+		}
+		if p.peek() == ']' {
+			p.pos++
+			return values, nil
+		}
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+		p.skipSpace(true)
+		if !p.eof() && p.peek() == ',' {
+			p.pos++
+		} else if p.eof() || p.peek() != ']' {
+			return nil, fmt.Errorf("expected , or ] in array")
+		}
+	}
+}
+
+func (p *tomlParser) inlineTable() (map[string]interface{}, error) {
+	p.pos++
+	table := map[string]interface{}{}
+	for {
+		p.skipSpace(false)
+		if p.eof() {
+			return nil, fmt.Errorf("unterminated inline table")
+		}
+		if p.peek() == '}' {
+			p.pos++
+			return table, nil
+		}
+		if err := p.keyValue(table); err != nil {
+			return nil, err
+		}
+		p.skipSpace(false)
+		if !p.eof() && p.peek() == ',' {
+			p.pos++
+		} else if p.eof() || p.peek() != '}' {
+			return nil, fmt.Errorf("expected , or } in inline table")
+		}
+	}
+}
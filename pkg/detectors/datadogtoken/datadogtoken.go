@@ -2,9 +2,9 @@ package datadogtoken
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -20,66 +20,150 @@ var (
 	client = common.SaneHttpClient()
 
 	//Make sure that your group is surrounded in boundry characters such as below to reduce false positives
-	keyPat = regexp.MustCompile(detectors.PrefixRegex([]string{"datadog"}) + `\b([a-zA-Z-0-9]{40})\b`)
-	apiPat = regexp.MustCompile(detectors.PrefixRegex([]string{"datadog"}) + `\b([a-zA-Z-0-9]{32})\b`)
+	keyPat = regexp.MustCompile(detectors.PrefixRegex([]string{"datadog", "dd_app"}) + `\b([a-zA-Z-0-9]{40})\b`)
+	apiPat = regexp.MustCompile(detectors.PrefixRegex([]string{"datadog", "dd_api"}) + `\b([a-zA-Z-0-9]{32})\b`)
+	// Accounts outside the US1 site have to use their site's API, which is usually named next to the keys.
+	sitePat = regexp.MustCompile(`\b((?:us3\.|us5\.|ap1\.)?datadoghq\.(?:com|eu)|ddog-gov\.com)\b`)
 )
 
+const defaultSite = "datadoghq.com"
+
 // Keywords are used for efficiently pre-filtering chunks.
 // Use identifiers in the secret preferably, or the provider name.
 func (s Scanner) Keywords() []string {
-	return []string{"datadog"}
+	return []string{"datadog", "dd_app", "dd_api"}
 }
 
 // FromData will find and optionally verify DatadogToken secrets in a given set of bytes.
+// Application keys are paired with the closest API key, since they can only be used together. API keys that aren't
+// paired are reported on their own, as are application keys if there are no API keys.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	apiMatches := apiPat.FindAllStringSubmatch(dataStr, -1)
+	appKeys := detectors.FindMatches(keyPat, dataStr)
+	apiKeys := detectors.FindMatches(apiPat, dataStr)
+	site := defaultSite
+	if match := sitePat.FindStringSubmatch(dataStr); match != nil {
+		site = match[1]
+	}
+
+	paired := map[string]bool{}
+	for _, parts := range detectors.PairMatches(appKeys, apiKeys) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		appKey, apiKey := parts[0].Value, parts[1].Value
+		paired[apiKey] = true
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_DatadogToken,
+			Raw:          []byte(appKey),
+			ExtraData:    map[string]string{"key_type": "application", "site": site},
+		}
 
-	for _, match := range matches {
-		if len(match) != 2 {
+		if verify {
+			if org, ok := currentOrg(ctx, site, apiKey, appKey); ok {
+				s1.Verified = true
+				if org.Name != "" {
+					s1.ExtraData["org"] = org.Name
+					s1.ExtraData["org_id"] = org.PublicID
+				}
+			}
+		}
+
+		if !s1.Verified && detectors.IsKnownFalsePositive(appKey, detectors.DefaultFalsePositives, true) {
 			continue
 		}
-		resMatch := strings.TrimSpace(match[1])
-		for _, apimatch := range apiMatches {
-			if len(apimatch) != 2 {
+
+		results = append(results, s1)
+	}
+
+	if len(apiKeys) == 0 {
+		for _, appKey := range appKeys {
+			if detectors.IsKnownFalsePositive(appKey.Value, detectors.DefaultFalsePositives, true) {
 				continue
 			}
-			resApiMatch := strings.TrimSpace(apimatch[1])
-
-			s1 := detectors.Result{
+			results = append(results, detectors.Result{
 				DetectorType: detectorspb.DetectorType_DatadogToken,
-				Raw:          []byte(resMatch),
-			}
+				Raw:          []byte(appKey.Value),
+				ExtraData:    map[string]string{"key_type": "application", "site": site},
+			})
+		}
+	}
 
-			if verify {
+	for _, apiKey := range apiKeys {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		if paired[apiKey.Value] {
+			continue
+		}
 
-				req, err := http.NewRequestWithContext(ctx, "GET", "https://api.datadoghq.com/api/v2/users", nil)
-				if err != nil {
-					continue
-				}
-				req.Header.Add("Content-Type", "application/json")
-				req.Header.Add("DD-API-KEY", resApiMatch)
-				req.Header.Add("DD-APPLICATION-KEY", resMatch)
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					} else {
-						//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-						if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
-					}
-				}
-			}
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_DatadogToken,
+			Raw:          []byte(apiKey.Value),
+			ExtraData:    map[string]string{"key_type": "api", "site": site},
+		}
+
+		if verify {
+			s1.Verified = validateAPIKey(ctx, site, apiKey.Value)
+		}
 
-			results = append(results, s1)
+		if !s1.Verified && detectors.IsKnownFalsePositive(apiKey.Value, detectors.DefaultFalsePositives, true) {
+			continue
 		}
 
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+type org struct {
+	Name     string `json:"name"`
+	PublicID string `json:"public_id"`
+}
+
+// currentOrg verifies a key pair by reading the organization it belongs to.
+func currentOrg(ctx context.Context, site, apiKey, appKey string) (org, bool) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api."+site+"/api/v1/org", nil)
+	if err != nil {
+		return org{}, false
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("DD-API-KEY", apiKey)
+	req.Header.Add("DD-APPLICATION-KEY", appKey)
+	res, err := client.Do(req)
+	if err != nil {
+		return org{}, false
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return org{}, false
+	}
+	var orgs struct {
+		Orgs []org `json:"orgs"`
 	}
+	if err := json.NewDecoder(res.Body).Decode(&orgs); err != nil || len(orgs.Orgs) == 0 {
+		return org{}, true
+	}
+	return orgs.Orgs[0], true
+}
 
-	return detectors.CleanResults(results), nil
+// validateAPIKey verifies an API key on its own. API keys can submit metrics, logs, and events, but not read data.
+func validateAPIKey(ctx context.Context, site, apiKey string) bool {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api."+site+"/api/v1/validate", nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Add("DD-API-KEY", apiKey)
+	res, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer res.Body.Close()
+	var validation struct {
+		Valid bool `json:"valid"`
+	}
+	return res.StatusCode == http.StatusOK && json.NewDecoder(res.Body).Decode(&validation) == nil && validation.Valid
 }
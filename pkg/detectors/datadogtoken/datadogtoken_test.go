@@ -8,6 +8,7 @@ import (
 
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
@@ -93,6 +94,7 @@ func TestDatadogToken_FromChunk(t *testing.T) {
 					t.Fatalf("no raw secret present: \n %+v", got[i])
 				}
 				got[i].Raw = nil
+				got[i].ExtraData = nil
 			}
 			if diff := pretty.Compare(got, tt.want); diff != "" {
 				t.Errorf("DatadogToken.FromData() %s diff: (-got +want)\n%s", tt.name, diff)
@@ -101,6 +103,59 @@ func TestDatadogToken_FromChunk(t *testing.T) {
 	}
 }
 
+func TestDatadogToken_Pairs(t *testing.T) {
+	const (
+		appKey      = "8b0e7153bf7c3706d85c524e440066559a6656c9"
+		apiKey      = "0bd5482a90a29b9fa5ff5180bc0dbc0e"
+		otherAPIKey = "15637e0b8e3b91d26a04a829a95249f5"
+	)
+
+	detectortest.Test(t, Scanner{},
+		detectortest.Fixture{
+			Name:   "key pair",
+			Data:   "DD_SITE=datadoghq.eu\nDD_API_KEY=" + apiKey + "\nDD_APP_KEY=" + appKey + "\n",
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "api.datadoghq.eu",
+				Path:   "/api/v1/org",
+				Header: map[string]string{"DD-API-KEY": apiKey, "DD-APPLICATION-KEY": appKey},
+				Status: 200,
+				Body:   `{"orgs": [{"name": "Acme Observability", "public_id": "a1b2c3d4e5"}]}`,
+			}},
+			Want: []detectortest.Want{{
+				Raw:       appKey,
+				Verified:  true,
+				ExtraData: map[string]string{"key_type": "application", "site": "datadoghq.eu", "org": "Acme Observability"},
+			}},
+		},
+		detectortest.Fixture{
+			Name:   "unpaired API keys",
+			Data:   "DD_API_KEY=" + apiKey + "\nDATADOG_BACKUP_API_KEY=" + otherAPIKey + "\n",
+			Verify: true,
+			Mocks: []detectortest.Mock{
+				{
+					Host:   "api.datadoghq.com",
+					Path:   "/api/v1/validate",
+					Header: map[string]string{"DD-API-KEY": apiKey},
+					Status: 200,
+					Body:   `{"valid": true}`,
+				},
+				{Host: "api.datadoghq.com", Status: 403, Body: `{"errors": ["Forbidden"]}`},
+			},
+			Want: []detectortest.Want{
+				{Raw: apiKey, Verified: true, ExtraData: map[string]string{"key_type": "api"}},
+				{Raw: otherAPIKey},
+			},
+		},
+		detectortest.Fixture{
+			Name:   "application key without an API key",
+			Data:   "DD_APP_KEY=" + appKey,
+			Verify: true,
+			Want:   []detectortest.Want{{Raw: appKey, ExtraData: map[string]string{"key_type": "application"}}},
+		},
+	)
+}
+
 func BenchmarkFromData(benchmark *testing.B) {
 	ctx := context.Background()
 	s := Scanner{}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
@@ -34,6 +35,10 @@ type Result struct {
 	Redacted       string
 	ExtraData      map[string]string
 	StructuredData *detectorspb.StructuredData
+	// Severity is optional. The engine sets DefaultSeverity if a detector leaves it unset.
+	Severity Severity
+	// Remediation is optional. The engine sets RemediationFor the detector type if a detector leaves it unset.
+	Remediation *Remediation `json:",omitempty"`
 }
 
 type ResultWithMetadata struct {
@@ -45,9 +50,35 @@ type ResultWithMetadata struct {
 	SourceType sourcespb.SourceType
 	// SourceName is the name of the Source.
 	SourceName string
+	// PresentAtHead is set for git results when the repository is available locally. It is true if the secret is
+	// still in its file at the tip of any branch, rather than only in history.
+	PresentAtHead *bool `json:",omitempty"`
+	// Age is set for git results when their age is estimated. It's how long the secret has been in history.
+	Age *Age `json:",omitempty"`
+	// Entropy is the Shannon entropy of Raw in bits per character. Low values suggest a placeholder.
+	Entropy float64
+	// Context is the lines of the chunk around the secret, if the engine was configured to include them.
+	Context string `json:",omitempty"`
+	// Provenance names the containers the secret was extracted from, outermost first, and then its file. It's only set
+	// for secrets in nested containers, such as archives.
+	Provenance []string `json:",omitempty"`
+	// ScanID is the ID of the scan, and UnitID the correlation ID of the repository or object the secret was found in.
+	// They match the scan_id and unit_id fields of the scan's logs.
+	ScanID string `json:",omitempty"`
+	UnitID string `json:",omitempty"`
 	Result
 }
 
+// Age is how long a secret found in git history has existed.
+type Age struct {
+	// Introduced is the date of the commit that first added the secret to its file.
+	Introduced time.Time
+	// Removed is the date of the commit that removed the secret from its file, if it has been.
+	Removed *time.Time `json:",omitempty"`
+	// Days is the number of days from Introduced to Removed, or to now if the secret hasn't been removed.
+	Days int
+}
+
 // CopyMetadata returns a detector result with included metadata from the source chunk.
 func CopyMetadata(chunk *sources.Chunk, result Result) ResultWithMetadata {
 	return ResultWithMetadata{
@@ -55,6 +86,8 @@ func CopyMetadata(chunk *sources.Chunk, result Result) ResultWithMetadata {
 		SourceID:       chunk.SourceID,
 		SourceType:     chunk.SourceType,
 		SourceName:     chunk.SourceName,
+		Provenance:     chunk.Provenance,
+		UnitID:         chunk.UnitID,
 		Result:         result,
 	}
 }
@@ -34,3 +34,25 @@ func BenchmarkPrefixRegex(b *testing.B) {
 		PrefixRegex(kws)
 	}
 }
+
+func TestParseSeverity(t *testing.T) {
+	tests := map[string]struct {
+		want    Severity
+		wantErr bool
+	}{
+		"low":      {want: SeverityLow},
+		"HIGH":     {want: SeverityHigh},
+		"Critical": {want: SeverityCritical},
+		"severe":   {wantErr: true},
+	}
+	for name, tt := range tests {
+		got, err := ParseSeverity(name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSeverity(%q) unexpected error: %v", name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSeverity(%q) got: %v want: %v", name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Package detectortest runs detectors against fixtures, with verification requests served by mocks so that no live
+// credentials are needed. Fixtures for the built-in detectors ship with the package and are run by
+// `trufflehog detectors test`; detector tests can use Test with their own fixtures.
+package detectortest
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+//go:embed fixtures/*.json
+var shipped embed.FS
+
+// FixtureSet is the fixtures of one detector, stored as a JSON file.
+type FixtureSet struct {
+	// Detector is the package name of the detector, e.g. "stripe".
+	Detector string    `json:"detector"`
+	Fixtures []Fixture `json:"fixtures"`
+}
+
+// Fixture is data to scan and the results a detector is expected to find in it. A fixture that expects no results is
+// a false positive the detector must ignore.
+type Fixture struct {
+	Name   string `json:"name"`
+	Data   string `json:"data"`
+	Verify bool   `json:"verify,omitempty"`
+	// Mocks serve the verification requests. Requests that no mock matches fail without leaving the machine.
+	Mocks []Mock `json:"mocks,omitempty"`
+	Want  []Want `json:"want"`
+}
+
+// Want is an expected result.
+type Want struct {
+	Raw      string `json:"raw"`
+	Verified bool   `json:"verified,omitempty"`
+	// ExtraData, if set, must be included in the result's extra data.
+	ExtraData map[string]string `json:"extra_data,omitempty"`
+}
+
+func (w Want) String() string {
+	if w.Verified {
+		return w.Raw + " (verified)"
+	}
+	return w.Raw
+}
+
+// ShippedFixtures returns the fixtures for the built-in detectors.
+func ShippedFixtures() ([]FixtureSet, error) {
+	return LoadFixtures(shipped, "fixtures")
+}
+
+// LoadFixtures reads every .json file in dir of fsys as a FixtureSet, ordered by file name.
+func LoadFixtures(fsys fs.FS, dir string) ([]FixtureSet, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	var sets []FixtureSet
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var set FixtureSet
+		if err := json.Unmarshal(data, &set); err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		if set.Detector == "" {
+			return nil, fmt.Errorf("%s: missing detector", entry.Name())
+		}
+		sets = append(sets, set)
+	}
+	return sets, nil
+}
+
+// runMu serializes runs, since mocks replace the transport of every client in the process.
+var runMu sync.Mutex
+
+// Run scans the fixture's data with the detector and returns an error describing any difference from the expected
+// results. Verification requests made with clients from pkg/common are served by the fixture's mocks.
+func Run(ctx context.Context, d detectors.Detector, f Fixture) error {
+	if len(f.Want) > 0 && !hasKeyword(d, f.Data) {
+		return fmt.Errorf("data doesn't contain any of the detector's keywords %v, so it would never be scanned", detectors.Keywords(d))
+	}
+
+	runMu.Lock()
+	defer runMu.Unlock()
+	common.OverrideTransport(NewMockTransport(f.Mocks...))
+	defer common.OverrideTransport(nil)
+
+	results, err := d.FromData(ctx, f.Verify, []byte(f.Data))
+	if err != nil {
+		return fmt.Errorf("FromData: %w", err)
+	}
+
+	got := make([]string, 0, len(results))
+	for _, r := range results {
+		got = append(got, Want{Raw: string(r.Raw), Verified: r.Verified}.String())
+	}
+	want := make([]string, 0, len(f.Want))
+	for _, w := range f.Want {
+		want = append(want, w.String())
+	}
+	sort.Strings(got)
+	sort.Strings(want)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		return fmt.Errorf("unexpected results. Got: %v, Expected: %v", got, want)
+	}
+
+	for _, w := range f.Want {
+		for _, r := range results {
+			if string(r.Raw) != w.Raw {
+				continue
+			}
+			for k, v := range w.ExtraData {
+				if r.ExtraData[k] != v {
+					return fmt.Errorf("unexpected extra data %q for %s. Got: %q, Expected: %q", k, w.Raw, r.ExtraData[k], v)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Test runs each fixture as a subtest.
+func Test(t *testing.T, d detectors.Detector, fixtures ...Fixture) {
+	t.Helper()
+	for _, f := range fixtures {
+		f := f
+		t.Run(f.Name, func(t *testing.T) {
+			if err := Run(context.Background(), d, f); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// hasKeyword reports whether the engine would scan the data with the detector, by matching its keywords and keyword
+// aliases the way chunks are pre-filtered.
+func hasKeyword(d detectors.Detector, data string) bool {
+	if detectors.IsUnfiltered(d) {
+		return true
+	}
+	folded := detectors.FoldCase(data)
+	for _, kw := range detectors.Keywords(d) {
+		if strings.Contains(folded, kw) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package detectortest_test
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+func TestShippedFixtures(t *testing.T) {
+	sets, err := detectortest.ShippedFixtures()
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := map[string]detectors.Detector{}
+	for _, d := range engine.DefaultDetectors() {
+		byName[engine.DetectorName(d)] = d
+	}
+	for _, set := range sets {
+		d, ok := byName[set.Detector]
+		if !ok {
+			t.Errorf("fixtures for unknown detector %q", set.Detector)
+			continue
+		}
+		t.Run(set.Detector, func(t *testing.T) {
+			detectortest.Test(t, d, set.Fixtures...)
+		})
+	}
+}
+
+// aliasedDetector finds tokens after "token=", and is only run on chunks with its keyword or alias.
+type aliasedDetector struct{}
+
+var tokenPat = regexp.MustCompile(`token=(\w+)`)
+
+func (aliasedDetector) Keywords() []string       { return []string{"Acme"} }
+func (aliasedDetector) KeywordAliases() []string { return []string{"WIDGETCO"} }
+
+func (aliasedDetector) FromData(ctx context.Context, verify bool, data []byte) ([]detectors.Result, error) {
+	var results []detectors.Result
+	for _, match := range tokenPat.FindAllStringSubmatch(string(data), -1) {
+		results = append(results, detectors.Result{DetectorType: detectorspb.DetectorType_CustomRegex, Raw: []byte(match[1])})
+	}
+	return results, nil
+}
+
+func TestRunKeywords(t *testing.T) {
+	want := []detectortest.Want{{Raw: "abc123"}}
+	detectortest.Test(t, aliasedDetector{},
+		detectortest.Fixture{Name: "keyword", Data: "ACME_API token=abc123", Want: want},
+		detectortest.Fixture{Name: "alias", Data: "widgetco token=abc123", Want: want},
+	)
+
+	err := detectortest.Run(context.Background(), aliasedDetector{}, detectortest.Fixture{Data: "token=abc123", Want: want})
+	if err == nil || !strings.Contains(err.Error(), "keywords [acme widgetco]") {
+		t.Errorf("expected an error for data without a keyword or alias, got %v", err)
+	}
+}
+
+func TestMockTransport(t *testing.T) {
+	transport := detectortest.NewMockTransport(detectortest.Mock{
+		Host:   "api.example.com",
+		Method: "GET",
+		Path:   "/user",
+		Header: map[string]string{"Authorization": "Bearer good"},
+		Body:   "ok",
+	})
+	client := &http.Client{Transport: transport}
+
+	tests := map[string]struct {
+		method, url, auth string
+		wantStatus        int
+	}{
+		"match":        {method: "GET", url: "https://api.example.com/user", auth: "Bearer good", wantStatus: http.StatusOK},
+		"wrong header": {method: "GET", url: "https://api.example.com/user", auth: "Bearer bad"},
+		"wrong method": {method: "POST", url: "https://api.example.com/user", auth: "Bearer good"},
+		"wrong path":   {method: "GET", url: "https://api.example.com/repos", auth: "Bearer good"},
+		"wrong host":   {method: "GET", url: "https://example.com/user", auth: "Bearer good"},
+	}
+	for name, test := range tests {
+		req, err := http.NewRequest(test.method, test.url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", test.auth)
+		res, err := client.Do(req)
+		if test.wantStatus == 0 {
+			if err == nil {
+				res.Body.Close()
+				t.Errorf("%s: expected no mock to match", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		res.Body.Close()
+		if res.StatusCode != test.wantStatus {
+			t.Errorf("%s: unexpected status. Got: %d, Expected: %d", name, res.StatusCode, test.wantStatus)
+		}
+	}
+}
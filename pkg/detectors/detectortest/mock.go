@@ -0,0 +1,84 @@
+package detectortest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Mock is a canned response to verification requests.
+type Mock struct {
+	// Host must match the request's host, e.g. "api.stripe.com".
+	Host string `json:"host"`
+	// Method and Path, if set, must also match.
+	Method string `json:"method,omitempty"`
+	Path   string `json:"path,omitempty"`
+	// Header, if set, must be present on the request with this value, e.g. to only accept the right credential.
+	Header map[string]string `json:"header,omitempty"`
+
+	Status          int               `json:"status"`
+	Body            string            `json:"body,omitempty"`
+	ResponseHeaders map[string]string `json:"response_headers,omitempty"`
+}
+
+func (m Mock) matches(req *http.Request) bool {
+	if !strings.EqualFold(m.Host, req.URL.Hostname()) {
+		return false
+	}
+	if m.Method != "" && !strings.EqualFold(m.Method, req.Method) {
+		return false
+	}
+	if m.Path != "" && m.Path != req.URL.Path {
+		return false
+	}
+	for name, value := range m.Header {
+		if req.Header.Get(name) != value {
+			return false
+		}
+	}
+	return true
+}
+
+// MockTransport is an http.RoundTripper that answers requests with the first matching mock. It never makes network
+// requests.
+type MockTransport struct {
+	mocks []Mock
+}
+
+// NewMockTransport returns a transport serving the mocks.
+func NewMockTransport(mocks ...Mock) *MockTransport {
+	return &MockTransport{mocks: mocks}
+}
+
+func (t *MockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		defer req.Body.Close()
+	}
+	for _, m := range t.mocks {
+		if !m.matches(req) {
+			continue
+		}
+		status := m.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		res := &http.Response{
+			Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+			StatusCode:    status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{},
+			Body:          io.NopCloser(strings.NewReader(m.Body)),
+			ContentLength: int64(len(m.Body)),
+			Request:       req,
+		}
+		for name, value := range m.ResponseHeaders {
+			res.Header.Set(name, value)
+		}
+		return res, nil
+	}
+	// Only the host is reported, since the path or query may contain the secret.
+	return nil, fmt.Errorf("no mock for %s request to %s", req.Method, req.URL.Host)
+}
@@ -2,9 +2,12 @@ package discordbottoken
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
@@ -19,8 +22,10 @@ var _ detectors.Detector = (*Scanner)(nil)
 
 var (
 	client = common.SaneHttpClient()
-	idPat  = regexp.MustCompile(detectors.PrefixRegex([]string{"discord"}) + `\b([0-9]{17})\b`)
-	keyPat = regexp.MustCompile(detectors.PrefixRegex([]string{"discord"}) + `\b([A-Za-z0-9_-]{24}\.[A-Za-z0-9_-]{6}\.[A-Za-z0-9_-]{27})\b`)
+	idPat  = regexp.MustCompile(detectors.PrefixRegex([]string{"discord"}) + `\b([0-9]{17,19})\b`)
+	// The first part of a token is the bot's user ID, base64 encoded. IDs grew past 17 digits, so it's 24 to 26
+	// characters long.
+	keyPat = regexp.MustCompile(detectors.PrefixRegex([]string{"discord"}) + `\b([A-Za-z0-9_-]{24,26}\.[A-Za-z0-9_-]{6}\.[A-Za-z0-9_-]{27,38})\b`)
 )
 
 // Keywords are used for efficiently pre-filtering chunks.
@@ -33,49 +38,88 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	idMatch := idPat.FindAllStringSubmatch(dataStr, -1)
+	ids := detectors.FindMatches(idPat, dataStr)
 
-	for _, match := range matches {
-		if len(match) != 2 {
-			continue
+	for _, match := range detectors.FindMatches(keyPat, dataStr) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resMatch := strings.TrimSpace(match[1])
+		token := match.Value
 
-		for _, idmatch := range idMatch {
-			if len(match) != 2 {
+		// Tokens carry their bot's ID, so one named next to them is only needed for tokens that don't decode.
+		botID, ok := tokenBotID(token)
+		if !ok {
+			if len(ids) == 0 {
 				continue
 			}
-			resId := strings.TrimSpace(idmatch[1])
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_DiscordBotToken,
-				Redacted:     resId,
-				Raw:          []byte(resMatch),
-			}
+			botID = detectors.PairMatches([]detectors.Match{match}, ids)[0][1].Value
+		}
 
-			if verify {
-				req, err := http.NewRequestWithContext(ctx, "GET", "https://discord.com/api/v8/users/"+resId, nil)
-				if err != nil {
-					continue
-				}
-				req.Header.Add("Authorization", fmt.Sprintf("Bot %s", resMatch))
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					} else {
-						if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
-
-					}
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_DiscordBotToken,
+			Redacted:     botID,
+			Raw:          []byte(token),
+		}
+
+		if verify {
+			if bot, ok := currentUser(ctx, token); ok {
+				s1.Verified = true
+				s1.ExtraData = map[string]string{
+					"bot_id":   bot.ID,
+					"username": bot.Username,
 				}
 			}
-			results = append(results, s1)
 		}
 
+		if !s1.Verified && detectors.IsKnownFalsePositive(token, detectors.DefaultFalsePositives, true) {
+			continue
+		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
 }
+
+// tokenBotID decodes the bot's user ID from the first part of the token.
+func tokenBotID(token string) (string, bool) {
+	encoded := strings.TrimRight(strings.SplitN(token, ".", 2)[0], "=")
+	decoded, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		decoded, err = base64.RawURLEncoding.DecodeString(encoded)
+		if err != nil {
+			return "", false
+		}
+	}
+	id := string(decoded)
+	if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+		return "", false
+	}
+	return id, true
+}
+
+type user struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// currentUser returns the bot the token belongs to.
+func currentUser(ctx context.Context, token string) (user, bool) {
+	// https://discord.com/developers/docs/resources/user#get-current-user
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://discord.com/api/v10/users/@me", nil)
+	if err != nil {
+		return user{}, false
+	}
+	req.Header.Add("Authorization", fmt.Sprintf("Bot %s", token))
+	res, err := client.Do(req)
+	if err != nil {
+		return user{}, false
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return user{}, false
+	}
+	var bot user
+	_ = json.NewDecoder(res.Body).Decode(&bot)
+	return bot, true
+}
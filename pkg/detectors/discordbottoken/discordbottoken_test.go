@@ -10,6 +10,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
 )
 
@@ -95,6 +96,7 @@ func TestDiscordBotToken_FromChunk(t *testing.T) {
 					t.Fatalf("no raw secret present: \n %+v", got[i])
 				}
 				got[i].Raw = nil
+				got[i].ExtraData = nil
 			}
 			if diff := pretty.Compare(got, tt.want); diff != "" {
 				t.Errorf("DiscordBotToken.FromData() %s diff: (-got +want)\n%s", tt.name, diff)
@@ -117,3 +119,34 @@ func BenchmarkFromData(benchmark *testing.B) {
 		})
 	}
 }
+
+func TestDiscordBotToken_CurrentUser(t *testing.T) {
+	const token = "MTAyOTM4NDc1NjEwMjkzODQ3NQ.GhT3xk.q8Zt1Lw0vKpRj5sYdN2mXcB7uEaH4fGi9oTy"
+
+	detectortest.Test(t, Scanner{},
+		detectortest.Fixture{
+			Name:   "valid token",
+			Data:   "DISCORD_TOKEN=" + token,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "discord.com",
+				Path:   "/api/v10/users/@me",
+				Header: map[string]string{"Authorization": "Bot " + token},
+				Status: 200,
+				Body:   `{"id": "1029384756102938475", "username": "modbot", "bot": true}`,
+			}},
+			Want: []detectortest.Want{{
+				Raw:       token,
+				Verified:  true,
+				ExtraData: map[string]string{"bot_id": "1029384756102938475", "username": "modbot"},
+			}},
+		},
+		detectortest.Fixture{
+			Name:   "reset token",
+			Data:   "discord: " + token,
+			Verify: true,
+			Mocks:  []detectortest.Mock{{Host: "discord.com", Status: 401, Body: `{"message": "401: Unauthorized", "code": 0}`}},
+			Want:   []detectortest.Want{{Raw: token}},
+		},
+	)
+}
@@ -0,0 +1,188 @@
+// Package dockerconfig detects registry credentials in Docker's config.json, and the files of other tools in the same
+// format such as Kubernetes' .dockerconfigjson secrets and Podman's auth.json.
+//
+// Credentials are verified by authenticating to the registry they're recorded for the way docker login does, which
+// only reads the registry's API version. Registries on loopback and private addresses are skipped.
+package dockerconfig
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	client = common.SaneHttpClient()
+
+	// An entry of the auths object: the registry, and its object of credentials. Entries are matched one at a time,
+	// rather than parsing the whole file, so that configs split across chunks are still found.
+	entryPat = regexp.MustCompile(`"([^"\s]+)"\s*:\s*(\{[^{}]*"(?:auth|identitytoken)"\s*:\s*"[^"]+"[^{}]*\})`)
+
+	challengeParamPat = regexp.MustCompile(`(\w+)="([^"]*)"`)
+)
+
+// authEntry is the credentials of a registry. See
+// https://docs.docker.com/engine/reference/commandline/login/#credentials-store.
+type authEntry struct {
+	// Auth is the base64 encoding of username:password.
+	Auth string `json:"auth"`
+	// IdentityToken is a refresh token for registries that use OAuth, such as Azure Container Registry.
+	IdentityToken string `json:"identitytoken"`
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{`"auths"`}
+}
+
+// FromData will find and optionally verify Docker registry credentials in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	for _, match := range entryPat.FindAllStringSubmatch(string(data), -1) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		var entry authEntry
+		if err := json.Unmarshal([]byte(match[2]), &entry); err != nil {
+			continue
+		}
+		registry := registryHost(match[1])
+
+		if entry.IdentityToken != "" {
+			results = append(results, detectors.Result{
+				DetectorType: detectorspb.DetectorType_Docker,
+				Raw:          []byte(entry.IdentityToken),
+				Redacted:     registry,
+				ExtraData: map[string]string{
+					"registry": registry,
+					"auth":     "identitytoken",
+				},
+			})
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+		if err != nil {
+			continue
+		}
+		credentials := strings.SplitN(string(decoded), ":", 2)
+		if len(credentials) != 2 || credentials[1] == "" {
+			continue
+		}
+		username, password := credentials[0], credentials[1]
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Docker,
+			Raw:          []byte(password),
+			Redacted:     username + "@" + registry,
+			ExtraData: map[string]string{
+				"registry": registry,
+				"username": username,
+				"auth":     "basic",
+			},
+		}
+
+		if verify && verifiable(registry) {
+			s1.Verified = verifyLogin(ctx, registry, username, password)
+		}
+
+		if !s1.Verified && detectors.IsKnownFalsePositive(password, detectors.DefaultFalsePositives, false) {
+			continue
+		}
+
+		results = append(results, s1)
+	}
+
+	return detectors.CleanResults(results), nil
+}
+
+// registryHost returns the host of an auths key, which may be a URL such as Docker Hub's https://index.docker.io/v1/.
+func registryHost(key string) string {
+	host := key
+	if u, err := url.Parse(key); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	host = strings.ToLower(strings.SplitN(host, "/", 2)[0])
+	switch host {
+	case "docker.io", "index.docker.io":
+		// Docker Hub's API is served from its own host.
+		return "registry-1.docker.io"
+	}
+	return host
+}
+
+// verifiable returns false for registries that can't be reached from outside the machine or its network.
+func verifiable(registry string) bool {
+	host := registry
+	if h, _, err := net.SplitHostPort(registry); err == nil {
+		host = h
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified()
+	}
+	return strings.Contains(host, ".") && !strings.HasSuffix(host, ".localhost") && !strings.HasSuffix(host, ".local")
+}
+
+// verifyLogin pings the registry's API with the credentials. Registries that use token authentication, such as Docker
+// Hub and GHCR, refuse the ping with a challenge naming their token service, which is then asked for a token instead.
+func verifyLogin(ctx context.Context, registry, username, password string) bool {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://"+registry+"/v2/", nil)
+	if err != nil {
+		return false
+	}
+	req.SetBasicAuth(username, password)
+	res, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	res.Body.Close()
+	if res.StatusCode == http.StatusOK {
+		return true
+	}
+	if res.StatusCode != http.StatusUnauthorized {
+		return false
+	}
+
+	challenge := res.Header.Get("WWW-Authenticate")
+	if !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+		return false
+	}
+	params := map[string]string{}
+	for _, param := range challengeParamPat.FindAllStringSubmatch(challenge, -1) {
+		params[strings.ToLower(param[1])] = param[2]
+	}
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Scheme != "https" {
+		return false
+	}
+	query := realm.Query()
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+	realm.RawQuery = query.Encode()
+
+	req, err = http.NewRequestWithContext(ctx, "GET", realm.String(), nil)
+	if err != nil {
+		return false
+	}
+	req.SetBasicAuth(username, password)
+	res, err = client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer res.Body.Close()
+	return res.StatusCode == http.StatusOK
+}
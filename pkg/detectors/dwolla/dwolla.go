@@ -36,56 +36,48 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	idMatches := idPat.FindAllStringSubmatch(dataStr, -1)
-	secretMatches := secretPat.FindAllStringSubmatch(dataStr, -1)
+	idMatches := detectors.FindMatches(idPat, dataStr)
+	secretMatches := detectors.FindMatches(secretPat, dataStr)
 
-	for _, match := range idMatches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(idMatches, secretMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
+		idMatch := parts[0].Value
+		secretMatch := parts[1].Value
 
-		idMatch := strings.TrimSpace(match[1])
-
-		for _, secret := range secretMatches {
-			if len(secret) != 2 {
-				continue
-			}
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Dwolla,
+			Raw:          []byte(idMatch),
+		}
 
-			secretMatch := strings.TrimSpace(secret[1])
+		if verify {
+			data := fmt.Sprintf("%s:%s", idMatch, secretMatch)
+			encoded := b64.StdEncoding.EncodeToString([]byte(data))
+			payload := strings.NewReader("grant_type=client_credentials")
 
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_Dwolla,
-				Raw:          []byte(idMatch),
+			req, err := http.NewRequestWithContext(ctx, "POST", "https://api-sandbox.dwolla.com/token", payload)
+			if err != nil {
+				continue
 			}
-
-			if verify {
-				data := fmt.Sprintf("%s:%s", idMatch, secretMatch)
-				encoded := b64.StdEncoding.EncodeToString([]byte(data))
-				payload := strings.NewReader("grant_type=client_credentials")
-
-				req, err := http.NewRequestWithContext(ctx, "POST", "https://api-sandbox.dwolla.com/token", payload)
-				if err != nil {
-					continue
-				}
-				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-				req.Header.Add("Authorization", fmt.Sprintf("Basic %s", encoded))
-
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					} else {
-						//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-						if detectors.IsKnownFalsePositive(idMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
+			req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Add("Authorization", fmt.Sprintf("Basic %s", encoded))
+
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(idMatch, detectors.DefaultFalsePositives, true) {
+						continue
 					}
 				}
 			}
-
-			results = append(results, s1)
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -35,48 +34,42 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	apiIdMatches := apiIdPat.FindAllStringSubmatch(dataStr, -1)
-	apiSecretMatches := apiSecretPat.FindAllStringSubmatch(dataStr, -1)
+	apiIdMatches := detectors.FindMatches(apiIdPat, dataStr)
+	apiSecretMatches := detectors.FindMatches(apiSecretPat, dataStr)
 
-	for _, apiIdMatch := range apiIdMatches {
-		if len(apiIdMatch) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(apiIdMatches, apiSecretMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		apiIdRes := strings.TrimSpace(apiIdMatch[1])
+		apiIdRes := parts[0].Value
+		apiSecretRes := parts[1].Value
 
-		for _, apiSecretMatch := range apiSecretMatches {
-			if len(apiSecretMatch) != 2 {
-				continue
-			}
-			apiSecretRes := strings.TrimSpace(apiSecretMatch[1])
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_FacebookOAuth,
+			Redacted:     apiIdRes,
+			Raw:          []byte(apiSecretRes),
+		}
 
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_FacebookOAuth,
-				Redacted:     apiIdRes,
-				Raw:          []byte(apiSecretRes),
+		if verify {
+			// thanks https://stackoverflow.com/questions/15621471/validate-a-facebook-app-id-and-app-secret
+			req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://graph.facebook.com/%s?fields=roles&access_token=%s|%s", apiIdRes, apiIdRes, apiSecretRes), nil)
+			if err != nil {
+				continue
 			}
-
-			if verify {
-				// thanks https://stackoverflow.com/questions/15621471/validate-a-facebook-app-id-and-app-secret
-				req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://graph.facebook.com/%s?fields=roles&access_token=%s|%s", apiIdRes, apiIdRes, apiSecretRes), nil)
-				if err != nil {
-					continue
-				}
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					} else {
-						if detectors.IsKnownFalsePositive(apiIdRes, detectors.DefaultFalsePositives, true) {
-							continue
-						}
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					if detectors.IsKnownFalsePositive(apiIdRes, detectors.DefaultFalsePositives, true) {
+						continue
 					}
 				}
 			}
-
-			results = append(results, s1)
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
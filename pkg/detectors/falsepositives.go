@@ -2,8 +2,11 @@ package detectors
 
 import (
 	_ "embed"
+	"math"
 	"strings"
 	"unicode"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
 )
 
 var DefaultFalsePositives = []FalsePositive{"example", "xxxxxx", "aaaaaa", "abcde", "00000", "sample"}
@@ -35,6 +38,9 @@ var FalsePositiveWordlists = Wordlists{
 //Currently that includes: No number, english word in key, or matches common example pattens.
 //Only the secret key material should be passed into this function
 func IsKnownFalsePositive(match string, falsePositives []FalsePositive, wordCheck bool) bool {
+	if falsePositiveFilterDisabled {
+		return false
+	}
 
 	for _, fp := range falsePositives {
 		if strings.Contains(strings.ToLower(match), string(fp)) {
@@ -61,6 +67,81 @@ func IsKnownFalsePositive(match string, falsePositives []FalsePositive, wordChec
 	return false
 }
 
+// falsePositiveFilterDisabled turns off IsKnownFalsePositive. It is only set before scanning starts.
+var falsePositiveFilterDisabled bool
+
+// DisableFalsePositiveFilter makes IsKnownFalsePositive report nothing as a false positive, so that every match is
+// returned. It is meant for forensic scans and must be called before scanning starts.
+func DisableFalsePositiveFilter() {
+	falsePositiveFilterDisabled = true
+}
+
+// FalsePositiveFilter is a user configured false positive check that is applied to the unverified results of every
+// detector, in addition to the checks detectors make with IsKnownFalsePositive.
+type FalsePositiveFilter struct {
+	// Words are substrings that mark a secret as a false positive. Matching ignores case.
+	Words []FalsePositive
+	// MinEntropy is the Shannon entropy, in bits per character, below which a secret is a false positive. Zero
+	// disables the check.
+	MinEntropy float64
+	// Overrides change the filter for individual detectors.
+	Overrides map[detectorspb.DetectorType]FalsePositiveOverride
+}
+
+// FalsePositiveOverride changes a FalsePositiveFilter for one detector.
+type FalsePositiveOverride struct {
+	// Disabled skips the filter for the detector.
+	Disabled bool
+	// Words are checked in addition to the filter's words.
+	Words []FalsePositive
+	// MinEntropy replaces the filter's threshold if set.
+	MinEntropy *float64
+}
+
+// IsFalsePositive returns true if the secret found by the detector type matches the filter.
+func (f *FalsePositiveFilter) IsFalsePositive(detectorType detectorspb.DetectorType, secret string) bool {
+	if f == nil || falsePositiveFilterDisabled {
+		return false
+	}
+	words, minEntropy := f.Words, f.MinEntropy
+	if override, ok := f.Overrides[detectorType]; ok {
+		if override.Disabled {
+			return false
+		}
+		words = append(words[:len(words):len(words)], override.Words...)
+		if override.MinEntropy != nil {
+			minEntropy = *override.MinEntropy
+		}
+	}
+
+	lower := strings.ToLower(secret)
+	for _, word := range words {
+		if strings.Contains(lower, strings.ToLower(string(word))) {
+			return true
+		}
+	}
+	return minEntropy > 0 && ShannonEntropy(secret) < minEntropy
+}
+
+// ShannonEntropy returns the Shannon entropy of s in bits per character.
+func ShannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+	var entropy float64
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
 func hasDictWord(wordList []string, token string) bool {
 	lower := strings.ToLower(token)
 	for _, word := range wordList {
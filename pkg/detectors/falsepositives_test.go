@@ -1,6 +1,10 @@
 package detectors
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
 
 func TestIsFalsePositive(t *testing.T) {
 	type args struct {
@@ -37,3 +41,50 @@ func TestIsFalsePositive(t *testing.T) {
 		})
 	}
 }
+
+func TestFalsePositiveFilter(t *testing.T) {
+	lowEntropy := 1.0
+	filter := &FalsePositiveFilter{
+		Words:      []FalsePositive{"Dummy"},
+		MinEntropy: 3,
+		Overrides: map[detectorspb.DetectorType]FalsePositiveOverride{
+			detectorspb.DetectorType_Github: {Words: []FalsePositive{"ghp_test"}, MinEntropy: &lowEntropy},
+			detectorspb.DetectorType_AWS:    {Disabled: true},
+		},
+	}
+	tests := []struct {
+		name         string
+		detectorType detectorspb.DetectorType
+		secret       string
+		want         bool
+	}{
+		{name: "word ignores case", detectorType: detectorspb.DetectorType_Stripe, secret: "sk_live_DUMMY8f3kq9Zx7Lm2", want: true},
+		{name: "random", detectorType: detectorspb.DetectorType_Stripe, secret: "sk_live_8f3kq9Zx7Lm2Pw4", want: false},
+		{name: "low entropy", detectorType: detectorspb.DetectorType_Stripe, secret: "sk_live_aaaaaaaaaaaaaaaa", want: true},
+		{name: "override word", detectorType: detectorspb.DetectorType_Github, secret: "ghp_test8f3kq9Zx7Lm2Pw4", want: true},
+		{name: "override keeps filter words", detectorType: detectorspb.DetectorType_Github, secret: "ghp_dummy8f3kq9Zx7Lm2", want: true},
+		{name: "override entropy", detectorType: detectorspb.DetectorType_Github, secret: "ghp_aaaaaaaaaaaaaaaa", want: false},
+		{name: "override disabled", detectorType: detectorspb.DetectorType_AWS, secret: "dummy", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := filter.IsFalsePositive(tt.detectorType, tt.secret); got != tt.want {
+				t.Errorf("IsFalsePositive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShannonEntropy(t *testing.T) {
+	tests := map[string]float64{
+		"":         0,
+		"aaaa":     0,
+		"abab":     1,
+		"abcdefgh": 3,
+	}
+	for s, want := range tests {
+		if got := ShannonEntropy(s); got != want {
+			t.Errorf("ShannonEntropy(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package firebasecloudmessaging
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	client = common.SaneHttpClient()
+
+	// Legacy server keys are the project's key ID, a colon, and a 140 character secret.
+	keyPat = regexp.MustCompile(`\b(AAAA[a-zA-Z0-9_-]{7}:[a-zA-Z0-9_-]{140})\b`)
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"AAAA"}
+}
+
+// FromData will find and optionally verify FirebaseCloudMessaging secrets in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
+
+	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		key := match[1]
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_FirebaseCloudMessaging,
+			Raw:          []byte(key),
+		}
+
+		if verify {
+			// A dry run to a made up device is rejected per device, with a 200, if the key is valid. Nothing is sent.
+			payload := strings.NewReader(`{"registration_ids": ["trufflehog"], "dry_run": true}`)
+			req, err := http.NewRequestWithContext(ctx, "POST", "https://fcm.googleapis.com/fcm/send", payload)
+			if err != nil {
+				continue
+			}
+			req.Header.Add("Authorization", "key="+key)
+			req.Header.Add("Content-Type", "application/json")
+			res, err := client.Do(req)
+			if err == nil {
+				res.Body.Close()
+				if res.StatusCode == http.StatusOK {
+					s1.Verified = true
+				}
+			}
+		}
+
+		if !s1.Verified && detectors.IsKnownFalsePositive(key, detectors.DefaultFalsePositives, true) {
+			continue
+		}
+
+		results = append(results, s1)
+	}
+
+	return detectors.CleanResults(results), nil
+}
@@ -0,0 +1,39 @@
+package firebasecloudmessaging
+
+import (
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
+)
+
+func TestFirebaseCloudMessaging_FromData(t *testing.T) {
+	const key = "AAAAu8jzPde:APA91bmUhBel31iEl2hpChYgCfrL1spNxnyVmihA-2O76UMFxFkM-R5Kjp1vRt_1fjORS-6ilI8ihN5KXSc7Tvo-hBKqFYY-kv5ZJr3J1TWDtkwtDDb_xHKas1VOqg6YYZYn9ZhyiA4u"
+
+	detectortest.Test(t, Scanner{},
+		detectortest.Fixture{
+			Name:   "valid server key",
+			Data:   "FCM_SERVER_KEY=" + key,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "fcm.googleapis.com",
+				Method: "POST",
+				Path:   "/fcm/send",
+				Header: map[string]string{"Authorization": "key=" + key},
+				Status: 200,
+				Body:   `{"multicast_id": -1, "success": 0, "failure": 1, "results": [{"error": "InvalidRegistration"}]}`,
+			}},
+			Want: []detectortest.Want{{Raw: key, Verified: true}},
+		},
+		detectortest.Fixture{
+			Name:   "revoked server key",
+			Data:   "firebase:\n  server_key: " + key,
+			Verify: true,
+			Mocks:  []detectortest.Mock{{Host: "fcm.googleapis.com", Status: 401, Body: "Unauthorized"}},
+			Want:   []detectortest.Want{{Raw: key}},
+		},
+		detectortest.Fixture{
+			Name: "too short",
+			Data: "FCM_SERVER_KEY=AAAAu8jzPde:APA91bmUhBel31iEl2hpChYgCfrL1spNxnyVmihA",
+		},
+	)
+}
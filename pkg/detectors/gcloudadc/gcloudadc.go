@@ -0,0 +1,145 @@
+package gcloudadc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	client = common.SaneHttpClient()
+
+	// Application default credentials written by `gcloud auth application-default login` are a flat JSON object.
+	credentialsPat = regexp.MustCompile(`\{[^{}]*"authorized_user"[^{}]*\}`)
+
+	tokenURL     = "https://oauth2.googleapis.com/token"
+	tokenInfoURL = "https://oauth2.googleapis.com/tokeninfo"
+)
+
+// credentials is the format of ~/.config/gcloud/application_default_credentials.json for user accounts.
+type credentials struct {
+	Type           string `json:"type"`
+	ClientID       string `json:"client_id"`
+	ClientSecret   string `json:"client_secret"`
+	RefreshToken   string `json:"refresh_token"`
+	QuotaProjectID string `json:"quota_project_id"`
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"authorized_user"}
+}
+
+// FromData will find and optionally verify gcloud application default credentials in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	for _, match := range credentialsPat.FindAllString(string(data), -1) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		var creds credentials
+		if err := json.Unmarshal([]byte(match), &creds); err != nil {
+			continue
+		}
+		if creds.Type != "authorized_user" || creds.RefreshToken == "" || creds.ClientID == "" {
+			continue
+		}
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_GCPApplicationDefaultCredentials,
+			Raw:          []byte(creds.RefreshToken),
+			Redacted:     creds.ClientID,
+			ExtraData:    map[string]string{"client_id": creds.ClientID},
+		}
+		if creds.QuotaProjectID != "" {
+			s1.ExtraData["quota_project_id"] = creds.QuotaProjectID
+		}
+
+		if verify {
+			accessToken := refresh(ctx, creds)
+			if accessToken != "" {
+				s1.Verified = true
+				addTokenInfo(ctx, s1.ExtraData, accessToken)
+			}
+		}
+
+		if !s1.Verified && detectors.IsKnownFalsePositive(creds.RefreshToken, detectors.DefaultFalsePositives, true) {
+			continue
+		}
+
+		results = append(results, s1)
+	}
+
+	return detectors.CleanResults(results), nil
+}
+
+// refresh exchanges the refresh token for an access token, and returns it if the credentials are valid.
+func refresh(ctx context.Context, creds credentials) string {
+	form := url.Values{
+		"client_id":     {creds.ClientID},
+		"client_secret": {creds.ClientSecret},
+		"refresh_token": {creds.RefreshToken},
+		"grant_type":    {"refresh_token"},
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return ""
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res, err := client.Do(req)
+	if err != nil {
+		return ""
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return ""
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&token); err != nil {
+		return ""
+	}
+	return token.AccessToken
+}
+
+// addTokenInfo adds the account the credentials belong to and the scopes they were granted.
+func addTokenInfo(ctx context.Context, extraData map[string]string, accessToken string) {
+	req, err := http.NewRequestWithContext(ctx, "GET", tokenInfoURL+"?access_token="+url.QueryEscape(accessToken), nil)
+	if err != nil {
+		return
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return
+	}
+	var info struct {
+		Email string `json:"email"`
+		Scope string `json:"scope"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&info); err != nil {
+		return
+	}
+	if info.Email != "" {
+		extraData["account"] = info.Email
+	}
+	if info.Scope != "" {
+		extraData["scopes"] = strings.ReplaceAll(info.Scope, " ", ",")
+	}
+}
@@ -0,0 +1,69 @@
+package gcloudadc
+
+import (
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
+)
+
+func TestGCloudADC_FromData(t *testing.T) {
+	const (
+		refreshToken = "1//0gLx7Qm2vR9kZCgYIARAAGBASNwF-L9IrHk3cVw8pT5nYqJzXb2sMa4dE6fUo1iWgK0lhP"
+		credentials  = `{
+  "client_id": "764086051850-6qr4p6gpi6hn506pt8ejuq83di341hur.apps.googleusercontent.com",
+  "client_secret": "d-FL95Q19q7MQmFpd7hHD0Ty",
+  "quota_project_id": "billing-prod-3141",
+  "refresh_token": "` + refreshToken + `",
+  "type": "authorized_user"
+}`
+	)
+
+	detectortest.Test(t, Scanner{},
+		detectortest.Fixture{
+			Name:   "valid",
+			Data:   credentials,
+			Verify: true,
+			Mocks: []detectortest.Mock{
+				{
+					Host:   "oauth2.googleapis.com",
+					Method: "POST",
+					Path:   "/token",
+					Status: 200,
+					Body:   `{"access_token": "ya29.a0AfH6SMBx", "expires_in": 3599, "token_type": "Bearer"}`,
+				},
+				{
+					Host:   "oauth2.googleapis.com",
+					Method: "GET",
+					Path:   "/tokeninfo",
+					Status: 200,
+					Body:   `{"email": "dev@example.com", "scope": "openid https://www.googleapis.com/auth/cloud-platform"}`,
+				},
+			},
+			Want: []detectortest.Want{{
+				Raw:      refreshToken,
+				Verified: true,
+				ExtraData: map[string]string{
+					"quota_project_id": "billing-prod-3141",
+					"account":          "dev@example.com",
+					"scopes":           "openid,https://www.googleapis.com/auth/cloud-platform",
+				},
+			}},
+		},
+		detectortest.Fixture{
+			Name:   "revoked",
+			Data:   credentials,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "oauth2.googleapis.com",
+				Path:   "/token",
+				Status: 400,
+				Body:   `{"error": "invalid_grant", "error_description": "Token has been expired or revoked."}`,
+			}},
+			Want: []detectortest.Want{{Raw: refreshToken}},
+		},
+		detectortest.Fixture{
+			Name: "service account key",
+			Data: `{"type": "service_account", "project_id": "billing-prod-3141", "client_id": "1234"}`,
+		},
+	)
+}
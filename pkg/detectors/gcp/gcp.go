@@ -6,8 +6,10 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 )
 
@@ -17,9 +19,21 @@ type Scanner struct{}
 var _ detectors.Detector = (*Scanner)(nil)
 
 var (
-	keyPat = regexp.MustCompile(`\{[^{]+auth_provider_x509_cert_url[^}]+\}`)
+	// Service account keys are JSON objects. Keys that were trimmed down to the fields needed to authenticate may be
+	// missing the certificate URLs, but always have the private key.
+	keyPat = regexp.MustCompile(`\{[^{]+(?:auth_provider_x509_cert_url|private_key)[^}]+\}`)
+
+	tokenURL string
 )
 
+// SetTokenURL sets the OAuth token endpoint to verify keys against instead of the token_uri in each key, such as a
+// Private Service Connect endpoint like https://oauth2-internal.p.googleapis.com/token, so that keys can be verified
+// from private networks. Tokens are always requested with only the key found. It must be called before scanning
+// starts.
+func SetTokenURL(url string) {
+	tokenURL = url
+}
+
 type gcpKey struct {
 	Type                    string `json:"type"`
 	ProjectID               string `json:"project_id"`
@@ -42,7 +56,7 @@ func trimCarrots(s string) string {
 // Keywords are used for efficiently pre-filtering chunks.
 // Use identifiers in the secret preferably, or the provider name.
 func (s Scanner) Keywords() []string {
-	return []string{"provider_x509"}
+	return []string{"provider_x509", "service_account", "private_key_id"}
 }
 
 // FromData will find and optionally verify GCP secrets in a given set of bytes.
@@ -52,6 +66,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	matches := keyPat.FindAllString(dataStr, -1)
 
 	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
 		key := match
 
 		key = strings.ReplaceAll(key, `,\\n`, `\n`)
@@ -63,6 +80,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		if err != nil {
 			continue
 		}
+		if creds.PrivateKey == "" && !strings.Contains(key, "auth_provider_x509_cert_url") {
+			// Some other JSON object with a private_key field name in it.
+			continue
+		}
 
 		// for Slack mangling (mailto scheme and hyperlinks)
 		if strings.Contains(creds.ClientEmail, `<mailto:`) {
@@ -83,20 +104,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			DetectorType: detectorspb.DetectorType_GCP,
 			Raw:          raw,
 			Redacted:     creds.ClientEmail,
+			ExtraData:    extraData(creds),
 		}
 
 		if verify {
-			credBytes, _ := json.Marshal(creds)
-			credentials, err := google.CredentialsFromJSON(ctx, credBytes, "https://www.googleapis.com/auth/cloud-platform")
-			if err != nil {
-				continue
-			}
-			if credentials != nil {
-				_, err = credentials.TokenSource.Token()
-				if err == nil {
-					s.Verified = true
-				}
-			}
+			s.Verified = verifyKey(ctx, creds)
 		}
 
 		results = append(results, s)
@@ -104,3 +116,40 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 
 	return
 }
+
+// extraData reports the project and service account a key belongs to.
+func extraData(creds gcpKey) map[string]string {
+	extra := map[string]string{}
+	if creds.ProjectID != "" {
+		extra["project_id"] = creds.ProjectID
+	}
+	if creds.ClientEmail != "" {
+		extra["service_account"] = creds.ClientEmail
+	}
+	if creds.PrivateKeyID != "" {
+		extra["private_key_id"] = creds.PrivateKeyID
+	}
+	if len(extra) == 0 {
+		return nil
+	}
+	return extra
+}
+
+// verifyKey returns true if the key can mint an access token. Tokens are requested with the common HTTP client, so
+// verification requests are audited like other detectors'.
+func verifyKey(ctx context.Context, creds gcpKey) bool {
+	if tokenURL != "" {
+		creds.TokenURI = tokenURL
+	}
+	credBytes, err := json.Marshal(creds)
+	if err != nil {
+		return false
+	}
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, common.SaneHttpClient())
+	credentials, err := google.CredentialsFromJSON(ctx, credBytes, "https://www.googleapis.com/auth/cloud-platform")
+	if err != nil || credentials == nil {
+		return false
+	}
+	_, err = credentials.TokenSource.Token()
+	return err == nil
+}
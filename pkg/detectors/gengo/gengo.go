@@ -11,7 +11,6 @@ import (
 	"net/http"
 	"regexp"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
@@ -43,63 +42,57 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	secretMatches := secretPat.FindAllStringSubmatch(dataStr, -1)
+	matches := detectors.FindMatches(keyPat, dataStr)
+	secretMatches := detectors.FindMatches(secretPat, dataStr)
 
-	for _, match := range matches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(matches, secretMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resMatch := strings.TrimSpace(match[1])
+		resMatch := parts[0].Value
+		resSecretMatch := parts[1].Value
 
-		for _, secretMatch := range secretMatches {
-			if len(secretMatch) != 2 {
-				continue
-			}
-			resSecretMatch := strings.TrimSpace(secretMatch[1])
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Gengo,
+			Raw:          []byte(resSecretMatch),
+		}
 
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_Gengo,
-				Raw:          []byte(resSecretMatch),
-			}
+		if verify {
 
-			if verify {
+			timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+			signature := getGengoSignature(timestamp, resSecretMatch)
 
-				timestamp := strconv.FormatInt(time.Now().Unix(), 10)
-				signature := getGengoSignature(timestamp, resSecretMatch)
+			req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://api.gengo.com/v2/account/me?ts=%s&api_key=%s&api_sig=%s", timestamp, resMatch, signature), nil)
+			if err != nil {
+				continue
+			}
+			req.Header.Add("Accept", "application/json")
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				body, errBody := ioutil.ReadAll(res.Body)
+
+				if errBody == nil {
+					var response Response
+					json.Unmarshal(body, &response)
+
+					if res.StatusCode >= 200 && res.StatusCode < 300 && response.OpStat == "ok" {
+						s1.Verified = true
+					} else {
+						//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+						if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
+							continue
+						}
 
-				req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://api.gengo.com/v2/account/me?ts=%s&api_key=%s&api_sig=%s", timestamp, resMatch, signature), nil)
-				if err != nil {
-					continue
-				}
-				req.Header.Add("Accept", "application/json")
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					body, errBody := ioutil.ReadAll(res.Body)
-
-					if errBody == nil {
-						var response Response
-						json.Unmarshal(body, &response)
-
-						if res.StatusCode >= 200 && res.StatusCode < 300 && response.OpStat == "ok" {
-							s1.Verified = true
-						} else {
-							//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-							if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
-								continue
-							}
-
-							if detectors.IsKnownFalsePositive(resSecretMatch, detectors.DefaultFalsePositives, true) {
-								continue
-							}
+						if detectors.IsKnownFalsePositive(resSecretMatch, detectors.DefaultFalsePositives, true) {
+							continue
 						}
 					}
 				}
 			}
-
-			results = append(results, s1)
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
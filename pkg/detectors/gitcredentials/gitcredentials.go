@@ -0,0 +1,143 @@
+// Package gitcredentials detects the entries of git credential store files, ~/.git-credentials, which hold a URL with
+// the username and password or token of each host git has logged in to.
+//
+// Credentials are only verified against the APIs of the hosting providers in VerifyHost. Logging in to other hosts
+// could lock the account out or alert its owners, and the host may not be reachable from the scanning machine.
+package gitcredentials
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	client = common.SaneHttpClient()
+
+	// Each line of the store is a URL with credentials and nothing else, which tells it apart from a URL in text.
+	// See https://git-scm.com/docs/git-credential-store#_storage_format.
+	linePat = regexp.MustCompile(`(?m)^[ \t]*(https?://[^:@/\s]+:[^@/\s]+@[^/\s?#]+)/?[ \t]*\r?$`)
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"https://", "http://"}
+}
+
+// FromData will find and optionally verify git credential store entries in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	for _, match := range linePat.FindAllStringSubmatch(string(data), -1) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		// Parsing decodes the percent-encoding of the username and password in the store.
+		u, err := url.Parse(match[1])
+		if err != nil {
+			continue
+		}
+		password, _ := u.User.Password()
+		if password == "" || strings.ContainsAny(password[:1], "$<{") {
+			continue
+		}
+		host := strings.ToLower(u.Host)
+		username := u.User.Username()
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_GitCredentials,
+			Raw:          []byte(password),
+			Redacted:     u.Scheme + "://" + username + "@" + host,
+			ExtraData: map[string]string{
+				"host":     host,
+				"username": username,
+			},
+		}
+
+		if verify {
+			s1.Verified, _ = VerifyHost(ctx, host, username, password)
+		}
+
+		if !s1.Verified && detectors.IsKnownFalsePositive(password, detectors.DefaultFalsePositives, false) {
+			continue
+		}
+
+		results = append(results, s1)
+	}
+
+	return detectors.CleanResults(results), nil
+}
+
+// hostVerifiers make a request that succeeds only with valid credentials, by the host they're recorded for.
+var hostVerifiers = map[string]func(ctx context.Context, username, password string) (*http.Request, error){
+	"github.com":     githubRequest,
+	"api.github.com": githubRequest,
+	"gitlab.com": func(ctx context.Context, _, password string) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", "https://gitlab.com/api/v4/user", nil)
+		if err != nil {
+			return nil, err
+		}
+		// Personal access tokens and OAuth tokens, which git stores with the username oauth2, are both bearer tokens.
+		req.Header.Set("Authorization", "Bearer "+password)
+		return req, nil
+	},
+	"bitbucket.org": func(ctx context.Context, username, password string) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", "https://api.bitbucket.org/2.0/user", nil)
+		if err != nil {
+			return nil, err
+		}
+		req.SetBasicAuth(username, password)
+		return req, nil
+	},
+	// The Heroku CLI stores its API key in .netrc for both hosts.
+	"api.heroku.com": herokuRequest,
+	"git.heroku.com": herokuRequest,
+}
+
+func githubRequest(ctx context.Context, _, password string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.github.com/user", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "token "+password)
+	return req, nil
+}
+
+func herokuRequest(ctx context.Context, _, password string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.heroku.com/account", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.heroku+json; version=3")
+	req.Header.Set("Authorization", "Bearer "+password)
+	return req, nil
+}
+
+// VerifyHost checks credentials recorded for a host, such as github.com, against the API of its provider. It returns
+// false for ok if the host isn't one it can verify credentials for.
+func VerifyHost(ctx context.Context, host, username, password string) (verified, ok bool) {
+	newRequest, ok := hostVerifiers[strings.ToLower(host)]
+	if !ok {
+		return false, false
+	}
+	req, err := newRequest(ctx, username, password)
+	if err != nil {
+		return false, true
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return false, true
+	}
+	defer res.Body.Close()
+	return res.StatusCode == http.StatusOK, true
+}
@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt"
@@ -39,65 +38,59 @@ func (s Scanner) Keywords() []string {
 // FromData will find and optionally verify GitHubApp secrets in a given set of bytes.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	appMatches := appPat.FindAllStringSubmatch(dataStr, -1)
+	matches := detectors.FindMatches(keyPat, dataStr)
+	appMatches := detectors.FindMatches(appPat, dataStr)
 
-	for _, match := range matches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(matches, appMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
+		resMatch := parts[0].Value
+		appResMatch := parts[1].Value
 
-		resMatch := strings.TrimSpace(match[1])
-		for _, appMatch := range appMatches {
-			if len(appMatch) != 2 {
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_GitHubApp,
+			Raw:          []byte(resMatch),
+		}
+
+		if verify {
+			signKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(resMatch))
+			if err != nil {
 				continue
 			}
-			appResMatch := strings.TrimSpace(appMatch[1])
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_GitHubApp,
-				Raw:          []byte(resMatch),
+			//issued at time
+			iat := time.Now().Add(-60 * time.Second)
+			exp := time.Now().Add(9 * 60 * time.Second)
+
+			iss := appResMatch
+			token := jwt.New(jwt.SigningMethodRS256)
+			claims := token.Claims.(jwt.MapClaims)
+			claims["iat"] = iat.Unix()
+			claims["exp"] = exp.Unix()
+			claims["iss"] = iss
+			tokenString, err := token.SignedString(signKey)
+			if err != nil {
+				continue
 			}
+			//end get token
 
-			if verify {
-				signKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(resMatch))
-				if err != nil {
-					continue
-				}
-				//issued at time
-				iat := time.Now().Add(-60 * time.Second)
-				exp := time.Now().Add(9 * 60 * time.Second)
-
-				iss := appResMatch
-				token := jwt.New(jwt.SigningMethodRS256)
-				claims := token.Claims.(jwt.MapClaims)
-				claims["iat"] = iat.Unix()
-				claims["exp"] = exp.Unix()
-				claims["iss"] = iss
-				tokenString, err := token.SignedString(signKey)
-				if err != nil {
-					continue
-				}
-				//end get token
-
-				req, err := http.NewRequestWithContext(ctx, "GET", "https://api.github.com/app", nil)
-				if err != nil {
-					continue
-				}
-				req.Header.Add("Accept", "application/vnd.github.v3+json")
-				req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenString))
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					}
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://api.github.com/app", nil)
+			if err != nil {
+				continue
+			}
+			req.Header.Add("Accept", "application/vnd.github.v3+json")
+			req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenString))
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
 				}
-
 			}
 
-			results = append(results, s1)
 		}
 
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
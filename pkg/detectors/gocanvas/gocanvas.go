@@ -38,60 +38,54 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	emailMatches := emailPat.FindAllStringSubmatch(dataStr, -1)
+	matches := detectors.FindMatches(keyPat, dataStr)
+	emailMatches := detectors.FindMatches(emailPat, dataStr)
 
-	for _, emailMatch := range emailMatches {
-		if len(emailMatch) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(emailMatches, matches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resEmailMatch := strings.TrimSpace(emailMatch[1])
+		resEmailMatch := parts[0].Value
+		resMatch := parts[1].Value
 
-		for _, match := range matches {
-			if len(match) != 2 {
-				continue
-			}
-			resMatch := strings.TrimSpace(match[1])
-
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_GoCanvas,
-				Raw:          []byte(resMatch),
-			}
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_GoCanvas,
+			Raw:          []byte(resMatch),
+		}
 
-			if verify {
+		if verify {
 
-				payload := url.Values{}
-				payload.Add("username", resEmailMatch)
+			payload := url.Values{}
+			payload.Add("username", resEmailMatch)
 
-				req, err := http.NewRequest("GET", "https://www.gocanvas.com/apiv2/forms.xml", strings.NewReader(payload.Encode()))
-				if err != nil {
-					continue
-				}
-				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-				req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", resMatch))
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					body, errBody := ioutil.ReadAll(res.Body)
-
-					if errBody == nil {
-						response := Response{}
-						xml.Unmarshal(body, &response)
-
-						if res.StatusCode >= 200 && res.StatusCode < 300 && response.Error == nil {
-							s1.Verified = true
-						} else {
-							//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-							if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
-								continue
-							}
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://www.gocanvas.com/apiv2/forms.xml", strings.NewReader(payload.Encode()))
+			if err != nil {
+				continue
+			}
+			req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", resMatch))
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				body, errBody := ioutil.ReadAll(res.Body)
+
+				if errBody == nil {
+					response := Response{}
+					xml.Unmarshal(body, &response)
+
+					if res.StatusCode >= 200 && res.StatusCode < 300 && response.Error == nil {
+						s1.Verified = true
+					} else {
+						//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+						if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
+							continue
 						}
 					}
 				}
 			}
-
-			results = append(results, s1)
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -36,53 +35,45 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	unameMatches := unamePat.FindAllStringSubmatch(dataStr, -1)
-	keyMatches := keyPat.FindAllStringSubmatch(dataStr, -1)
+	unameMatches := detectors.FindMatches(unamePat, dataStr)
+	keyMatches := detectors.FindMatches(keyPat, dataStr)
 
-	for _, match := range unameMatches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(unameMatches, keyMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
+		unameMatch := parts[0].Value
+		keyMatch := parts[1].Value
 
-		unameMatch := strings.TrimSpace(match[1])
-
-		for _, secret := range keyMatches {
-			if len(secret) != 2 {
-				continue
-			}
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Guru,
+			Raw:          []byte(unameMatch),
+		}
 
-			keyMatch := strings.TrimSpace(secret[1])
+		if verify {
+			data := fmt.Sprintf("%s:%s", unameMatch, keyMatch)
+			encoded := b64.StdEncoding.EncodeToString([]byte(data))
 
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_Guru,
-				Raw:          []byte(unameMatch),
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://api.getguru.com/api/v1/teams/teamId/stats", nil)
+			if err != nil {
+				continue
 			}
-
-			if verify {
-				data := fmt.Sprintf("%s:%s", unameMatch, keyMatch)
-				encoded := b64.StdEncoding.EncodeToString([]byte(data))
-
-				req, err := http.NewRequestWithContext(ctx, "GET", "https://api.getguru.com/api/v1/teams/teamId/stats", nil)
-				if err != nil {
-					continue
-				}
-				req.Header.Add("Authorization", fmt.Sprintf("Basic %s", encoded))
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					} else {
-						//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-						if detectors.IsKnownFalsePositive(keyMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
+			req.Header.Add("Authorization", fmt.Sprintf("Basic %s", encoded))
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(keyMatch, detectors.DefaultFalsePositives, true) {
+						continue
 					}
 				}
 			}
-
-			results = append(results, s1)
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
@@ -0,0 +1,114 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	client = common.SaneHttpClient()
+
+	// User access tokens and organization API tokens.
+	keyPat = regexp.MustCompile(`\b((?:hf|api_org)_[a-zA-Z]{34})\b`)
+)
+
+// whoami is the response of the whoami-v2 endpoint.
+type whoami struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Orgs []struct {
+		Name string `json:"name"`
+	} `json:"orgs"`
+	Auth struct {
+		AccessToken struct {
+			DisplayName string `json:"displayName"`
+			Role        string `json:"role"`
+		} `json:"accessToken"`
+	} `json:"auth"`
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"hf_", "api_org_"}
+}
+
+// FromData will find and optionally verify Hugging Face secrets in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
+
+	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		if len(match) != 2 {
+			continue
+		}
+		token := match[1]
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_HuggingFace,
+			Raw:          []byte(token),
+		}
+
+		if verify {
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://huggingface.co/api/whoami-v2", nil)
+			if err != nil {
+				continue
+			}
+			req.Header.Add("Authorization", "Bearer "+token)
+			res, err := client.Do(req)
+			if err == nil {
+				var user whoami
+				if res.StatusCode == http.StatusOK && json.NewDecoder(res.Body).Decode(&user) == nil {
+					s1.Verified = true
+					s1.ExtraData = extraData(user)
+				}
+				res.Body.Close()
+			}
+		}
+
+		if !s1.Verified && detectors.IsKnownFalsePositive(token, detectors.DefaultFalsePositives, true) {
+			continue
+		}
+
+		results = append(results, s1)
+	}
+
+	return detectors.CleanResults(results), nil
+}
+
+// extraData reports the account and what the token may do. Write tokens can push to every repository of the user
+// and their organizations.
+func extraData(user whoami) map[string]string {
+	extraData := map[string]string{
+		"username": user.Name,
+		"type":     user.Type,
+	}
+	if token := user.Auth.AccessToken; token.Role != "" {
+		extraData["token_name"] = token.DisplayName
+		extraData["role"] = token.Role
+	}
+	if len(user.Orgs) > 0 {
+		orgs := make([]string, 0, len(user.Orgs))
+		for _, org := range user.Orgs {
+			orgs = append(orgs, org.Name)
+		}
+		extraData["orgs"] = strings.Join(orgs, ",")
+	}
+	return extraData
+}
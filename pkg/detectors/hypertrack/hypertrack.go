@@ -4,7 +4,6 @@ import (
 	"context"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -34,54 +33,47 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	accMatches := accPat.FindAllStringSubmatch(dataStr, -1)
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
+	accMatches := detectors.FindMatches(accPat, dataStr)
+	matches := detectors.FindMatches(keyPat, dataStr)
 
-	for _, accMatch := range accMatches {
+	for _, parts := range detectors.PairMatches(accMatches, matches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		resAccMatch := parts[0].Value
+		resMatch := parts[1].Value
 
-		if len(accMatch) != 2 {
-			continue
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Hypertrack,
+			Raw:          []byte(resMatch),
 		}
-		resAccMatch := strings.TrimSpace(accMatch[1])
 
-		for _, match := range matches {
-			if len(match) != 2 {
+		if verify {
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://v3.api.hypertrack.com/trips/", nil)
+			if err != nil {
 				continue
 			}
-			resMatch := strings.TrimSpace(match[1])
-
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_Hypertrack,
-				Raw:          []byte(resMatch),
-			}
-
-			if verify {
-				req, err := http.NewRequestWithContext(ctx, "GET", "https://v3.api.hypertrack.com/trips/", nil)
-				if err != nil {
-					continue
-				}
-				req.SetBasicAuth(resAccMatch, resMatch)
-				req.Header.Add("Content-Type", "application/json")
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					} else {
-						//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-						if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
+			req.SetBasicAuth(resAccMatch, resMatch)
+			req.Header.Add("Content-Type", "application/json")
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
+						continue
+					}
 
-						if detectors.IsKnownFalsePositive(resAccMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
+					if detectors.IsKnownFalsePositive(resAccMatch, detectors.DefaultFalsePositives, true) {
+						continue
 					}
 				}
 			}
-
-			results = append(results, s1)
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
@@ -0,0 +1,108 @@
+package iac
+
+import (
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// secretNamePat matches the logical IDs and names of SSM parameters that hold secrets.
+var secretNamePat = regexp.MustCompile(`(?i)(password|secret|token|api_?key|credential)`)
+
+// cloudFormationFindings returns the secrets in a CloudFormation template in YAML or JSON: NoEcho parameters with
+// defaults, secret resource properties, SSM parameters, and user data scripts. Data that isn't a whole template isn't
+// parsed.
+func cloudFormationFindings(data []byte) []finding {
+	var root yaml.Node
+	if yaml.Unmarshal(data, &root) != nil || len(root.Content) == 0 {
+		return nil
+	}
+	template := root.Content[0]
+	if mappingValue(template, "Resources") == nil {
+		return nil
+	}
+
+	var findings []finding
+	if parameters := mappingValue(template, "Parameters"); parameters != nil {
+		for i := 0; i+1 < len(parameters.Content); i += 2 {
+			name, parameter := parameters.Content[i].Value, parameters.Content[i+1]
+			noEcho := mappingValue(parameter, "NoEcho")
+			defaultValue := mappingValue(parameter, "Default")
+			if noEcho == nil || !strings.EqualFold(noEcho.Value, "true") || !isString(defaultValue) {
+				continue
+			}
+			findings = append(findings, finding{
+				format:    "cloudformation",
+				block:     "Parameters." + name,
+				attribute: "Default",
+				value:     defaultValue.Value,
+			})
+		}
+	}
+
+	resources := mappingValue(template, "Resources")
+	for i := 0; i+1 < len(resources.Content); i += 2 {
+		logicalID, resource := resources.Content[i].Value, resources.Content[i+1]
+		f := finding{format: "cloudformation", block: "Resources." + logicalID}
+		if resourceType := mappingValue(resource, "Type"); resourceType != nil {
+			f.resourceType = resourceType.Value
+		}
+		properties := mappingValue(resource, "Properties")
+		if properties == nil || properties.Kind != yaml.MappingNode {
+			continue
+		}
+
+		for j := 0; j+1 < len(properties.Content); j += 2 {
+			name, value := properties.Content[j].Value, properties.Content[j+1]
+			switch {
+			case name == "UserData":
+				findings = append(findings, scriptFindings(strings.Join(scalars(value), "\n"), withAttribute(f, name, ""))...)
+			case secretAttributePat.MatchString(name) && isString(value):
+				findings = append(findings, withAttribute(f, name, value.Value))
+			}
+		}
+
+		if f.resourceType == "AWS::SSM::Parameter" {
+			value := mappingValue(properties, "Value")
+			parameterName := ""
+			if name := mappingValue(properties, "Name"); name != nil {
+				parameterName = name.Value
+			}
+			if isString(value) && (secretNamePat.MatchString(logicalID) || secretNamePat.MatchString(parameterName)) {
+				findings = append(findings, withAttribute(f, "Value", value.Value))
+			}
+		}
+	}
+	return findings
+}
+
+// mappingValue returns the value of the key in a mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// isString returns true for plain strings, rather than intrinsic functions such as !Ref and {"Ref": ...}.
+func isString(node *yaml.Node) bool {
+	return node != nil && node.Kind == yaml.ScalarNode && node.Tag == "!!str"
+}
+
+// scalars returns the scalar values under node, such as the lines of a script wrapped in Fn::Base64 and Fn::Join.
+func scalars(node *yaml.Node) []string {
+	if node.Kind == yaml.ScalarNode {
+		return []string{node.Value}
+	}
+	var values []string
+	for _, child := range node.Content {
+		values = append(values, scalars(child)...)
+	}
+	return values
+}
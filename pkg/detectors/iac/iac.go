@@ -0,0 +1,104 @@
+// Package iac detects credentials written into infrastructure as code: inline access keys in Terraform provider blocks,
+// secrets in the user data scripts of instances, and the values of SSM parameters, sensitive variables, and NoEcho
+// CloudFormation parameters. State and plans are kept in many places, so these end up far beyond the repository.
+//
+// Each result describes the block the secret is in, so that it can be moved to a secret store without searching for
+// it: "format" is terraform or cloudformation, "block" names the block, such as resource "aws_instance" "web" or
+// Resources.WebServer, "resource_type" is the provider's resource type, and "attribute" is the attribute holding the
+// secret. Secrets in scripts also have the "variable" they're assigned to.
+package iac
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	// Attributes of resources and properties of CloudFormation resources that hold secrets, such as master_password
+	// and MasterUserPassword.
+	secretAttributePat = regexp.MustCompile(`(?i)(password|secret_?key|client_?secret|api_?key|api_?token|auth_?token|private_?key)$`)
+	// Provider blocks also configure tokens, such as the github provider's token.
+	providerSecretAttributePat = regexp.MustCompile(`(?i)(^token$|password|secret|api_?key|private_?key)`)
+	// An assignment of a secret to a shell variable in a user data script.
+	scriptSecretPat = regexp.MustCompile(`(?im)^[ \t]*(?:export[ \t]+)?([A-Za-z_][A-Za-z0-9_]*(?:password|passwd|secret|token|api_?key|access_?key)[A-Za-z0-9_]*)=['"]?([^\s'"]+)`)
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{`provider "`, "user_data", "aws_ssm_parameter", "sensitive", "userdata", "aws::ssm::parameter", "noecho", "password"}
+}
+
+// FromData will find credentials in Terraform and CloudFormation in a given set of bytes. They aren't verified, since
+// they may be for any provider. Access keys are also found, and verified, by the aws detector.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	var findings []finding
+	if strings.Contains(dataStr, "{") && strings.Contains(dataStr, "=") {
+		findings = append(findings, terraformFindings(dataStr)...)
+	}
+	if strings.Contains(dataStr, "Resources") || strings.Contains(dataStr, "Parameters") {
+		findings = append(findings, cloudFormationFindings(data)...)
+	}
+
+	for _, f := range findings {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		if !literal(f.value) || detectors.IsKnownFalsePositive(f.value, detectors.DefaultFalsePositives, false) {
+			continue
+		}
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_IaC,
+			Raw:          []byte(f.value),
+			Redacted:     f.block + " " + f.attribute,
+			ExtraData: map[string]string{
+				"format":    f.format,
+				"block":     f.block,
+				"attribute": f.attribute,
+			},
+		}
+		if f.resourceType != "" {
+			s1.ExtraData["resource_type"] = f.resourceType
+		}
+		if f.variable != "" {
+			s1.ExtraData["variable"] = f.variable
+			s1.Redacted += " " + f.variable
+		}
+		results = append(results, s1)
+	}
+
+	return detectors.CleanResults(results), nil
+}
+
+// finding is a secret in a block of infrastructure as code.
+type finding struct {
+	format, block, resourceType, attribute, variable, value string
+}
+
+// scriptFindings returns the secrets assigned to variables in a user data script.
+func scriptFindings(script string, f finding) []finding {
+	var findings []finding
+	for _, match := range scriptSecretPat.FindAllStringSubmatch(script, -1) {
+		f.variable, f.value = match[1], match[2]
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// literal returns false for values that aren't secrets written into the file: references to variables, resources,
+// and secret stores such as ${var.password}, $DB_PASSWORD, and {{resolve:secretsmanager:...}}, and short values.
+func literal(value string) bool {
+	return len(value) >= 6 && !strings.ContainsAny(value, "${}")
+}
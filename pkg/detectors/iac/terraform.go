@@ -0,0 +1,122 @@
+package iac
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// The header of a top level Terraform block, with its labels.
+	blockHeaderPat = regexp.MustCompile(`(?m)^[ \t]*(provider|resource|variable)((?:[ \t]+"[^"\n]*")+)[ \t]*\{`)
+	// A string attribute on a line of its own.
+	attributePat = regexp.MustCompile(`(?m)^[ \t]*([A-Za-z_][A-Za-z0-9_-]*)[ \t]*=[ \t]*"([^"\n]*)"[ \t]*\r?$`)
+	// A boolean attribute.
+	sensitivePat = regexp.MustCompile(`(?m)^[ \t]*sensitive[ \t]*=[ \t]*true\b`)
+	// The start of a heredoc user data script, and its terminator.
+	userDataHeredocPat = regexp.MustCompile(`(?m)^[ \t]*user_data(?:_base64)?[ \t]*=[ \t]*<<-?[ \t]*([A-Za-z_]+)[ \t]*\r?$`)
+)
+
+// terraformFindings returns the secrets in the provider, resource, and variable blocks of Terraform configuration.
+// Blocks are found by their headers and matching braces, so a block that's cut off at the end of the data is still
+// checked up to the end.
+func terraformFindings(data string) []finding {
+	var findings []finding
+	for _, loc := range blockHeaderPat.FindAllStringSubmatchIndex(data, -1) {
+		kind := data[loc[2]:loc[3]]
+		labels := strings.Fields(data[loc[4]:loc[5]])
+		body := blockBody(data[loc[1]:])
+		f := finding{format: "terraform", block: kind + " " + strings.Join(labels, " ")}
+		if kind == "resource" && len(labels) > 0 {
+			f.resourceType = strings.Trim(labels[0], `"`)
+		}
+
+		matches := attributePat.FindAllStringSubmatch(body, -1)
+		attributes := make(map[string]string, len(matches))
+		for _, match := range matches {
+			attributes[match[1]] = match[2]
+		}
+
+		switch kind {
+		case "provider":
+			for _, match := range matches {
+				if providerSecretAttributePat.MatchString(match[1]) {
+					findings = append(findings, withAttribute(f, match[1], match[2]))
+				}
+			}
+		case "variable":
+			if value, ok := attributes["default"]; ok && sensitivePat.MatchString(body) {
+				findings = append(findings, withAttribute(f, "default", value))
+			}
+		case "resource":
+			for _, match := range matches {
+				if secretAttributePat.MatchString(match[1]) {
+					findings = append(findings, withAttribute(f, match[1], match[2]))
+				}
+			}
+			if f.resourceType == "aws_ssm_parameter" && attributes["type"] == "SecureString" {
+				if value, ok := attributes["value"]; ok {
+					findings = append(findings, withAttribute(f, "value", value))
+				}
+			}
+			if script, ok := attributes["user_data"]; ok {
+				findings = append(findings, scriptFindings(unescape(script), withAttribute(f, "user_data", ""))...)
+			}
+			if script, ok := heredoc(body); ok {
+				findings = append(findings, scriptFindings(script, withAttribute(f, "user_data", ""))...)
+			}
+		}
+	}
+	return findings
+}
+
+func withAttribute(f finding, attribute, value string) finding {
+	f.attribute, f.value = attribute, value
+	return f
+}
+
+// blockBody returns the data up to the brace that closes the block that data starts in. Braces in strings aren't
+// counted, but interpolations and the braces of heredoc scripts are, since they're balanced.
+func blockBody(data string) string {
+	depth := 1
+	inString := false
+	for i := 0; i < len(data); i++ {
+		switch c := data[i]; {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case c == '\n':
+			inString = false
+		case !inString && c == '{':
+			depth++
+		case !inString && c == '}':
+			depth--
+			if depth == 0 {
+				return data[:i]
+			}
+		}
+	}
+	return data
+}
+
+// heredoc returns the user data script written as a heredoc in the block body.
+func heredoc(body string) (string, bool) {
+	loc := userDataHeredocPat.FindStringSubmatchIndex(body)
+	if loc == nil {
+		return "", false
+	}
+	terminator := body[loc[2]:loc[3]]
+	var script []string
+	for _, line := range strings.Split(body[loc[1]:], "\n") {
+		if strings.TrimSpace(line) == terminator {
+			break
+		}
+		script = append(script, line)
+	}
+	return strings.Join(script, "\n"), true
+}
+
+// unescape turns the escaped newlines of a one line script into lines.
+func unescape(script string) string {
+	return strings.NewReplacer(`\n`, "\n", `\"`, `"`).Replace(script)
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -37,61 +36,49 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	tokens := tokenPat.FindAllStringSubmatch(dataStr, -1)
-	domains := domainPat.FindAllStringSubmatch(dataStr, -1)
-	emails := emailPat.FindAllStringSubmatch(dataStr, -1)
+	tokens := detectors.FindMatches(tokenPat, dataStr)
+	domains := detectors.FindMatches(domainPat, dataStr)
+	emails := detectors.FindMatches(emailPat, dataStr)
 
-	for _, email := range emails {
-		if len(email) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(tokens, emails, domains) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resEmail := strings.TrimSpace(email[1])
-		for _, token := range tokens {
-			if len(token) != 2 {
-				continue
-			}
-			resToken := strings.TrimSpace(token[1])
-			for _, domain := range domains {
-				if len(domain) != 2 {
-					continue
-				}
-				resDomain := strings.TrimSpace(domain[1])
+		resToken := parts[0].Value
+		resEmail := parts[1].Value
+		resDomain := parts[2].Value
 
-				s1 := detectors.Result{
-					DetectorType: detectorspb.DetectorType_JiraToken,
-					Raw:          []byte(resToken),
-				}
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_JiraToken,
+			Raw:          []byte(resToken),
+		}
 
-				if verify {
-
-					data := fmt.Sprintf("%s:%s", resEmail, resToken)
-					sEnc := b64.StdEncoding.EncodeToString([]byte(data))
-					req, err := http.NewRequestWithContext(ctx, "GET", "https://"+resDomain+"/rest/api/3/dashboard", nil)
-					if err != nil {
-						continue
-					}
-					req.Header.Add("Accept", "application/json")
-					req.Header.Add("Authorization", fmt.Sprintf("Basic %s", sEnc))
-					res, err := client.Do(req)
-					if err == nil {
-						defer res.Body.Close()
-						if res.StatusCode >= 200 && res.StatusCode < 300 {
-							s1.Verified = true
-						}
-					}
-				}
+		if verify {
 
-				if !s1.Verified {
-					if detectors.IsKnownFalsePositive(string(s1.Raw), detectors.DefaultFalsePositives, true) {
-						continue
-					}
+			data := fmt.Sprintf("%s:%s", resEmail, resToken)
+			sEnc := b64.StdEncoding.EncodeToString([]byte(data))
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://"+resDomain+"/rest/api/3/dashboard", nil)
+			if err != nil {
+				continue
+			}
+			req.Header.Add("Accept", "application/json")
+			req.Header.Add("Authorization", fmt.Sprintf("Basic %s", sEnc))
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
 				}
-
-				results = append(results, s1)
-
 			}
+		}
 
+		if !s1.Verified {
+			if detectors.IsKnownFalsePositive(string(s1.Raw), detectors.DefaultFalsePositives, true) {
+				continue
+			}
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
@@ -0,0 +1,58 @@
+package detectors
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+)
+
+// KeywordAliaser is implemented by detectors whose credentials also appear near other names for their provider, such
+// as a former brand name, a product name, or a translation. Aliases pre-filter chunks the same way keywords do.
+type KeywordAliaser interface {
+	KeywordAliases() []string
+}
+
+// Unfiltered is implemented by detectors that run on every chunk, such as custom rules that only have a regular
+// expression. Detectors should have keywords whenever they can, since running on every chunk is slow.
+type Unfiltered interface {
+	Unfiltered() bool
+}
+
+// IsUnfiltered returns true if the detector runs on every chunk rather than those with its keywords.
+func IsUnfiltered(d Detector) bool {
+	u, ok := d.(Unfiltered)
+	return ok && u.Unfiltered()
+}
+
+// Keywords returns the case folded keywords and keyword aliases of a detector, without duplicates. Chunks are
+// pre-filtered by checking whether their FoldCase contains any of them.
+func Keywords(d Detector) []string {
+	all := d.Keywords()
+	if a, ok := d.(KeywordAliaser); ok {
+		all = append(all[:len(all):len(all)], a.KeywordAliases()...)
+	}
+
+	seen := make(map[string]struct{}, len(all))
+	keywords := make([]string, 0, len(all))
+	for _, kw := range all {
+		kw = FoldCase(kw)
+		if _, ok := seen[kw]; ok || kw == "" {
+			continue
+		}
+		seen[kw] = struct{}{}
+		keywords = append(keywords, kw)
+	}
+	return keywords
+}
+
+// FoldCase returns s with its case folded, so that caseless comparisons match across scripts, such as "STRASSE" and
+// "straße" or "ΣΊΣΥΦΟΣ" and "σίσυφος". ASCII is only lowercased, which is the same and much faster.
+func FoldCase(s string) string {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return cases.Fold().String(s)
+		}
+	}
+	return strings.ToLower(s)
+}
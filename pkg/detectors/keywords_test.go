@@ -0,0 +1,60 @@
+package detectors
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type keywordDetector struct {
+	keywords, aliases []string
+}
+
+func (d keywordDetector) FromData(context.Context, bool, []byte) ([]Result, error) { return nil, nil }
+func (d keywordDetector) Keywords() []string                                       { return d.keywords }
+
+type aliasedDetector struct{ keywordDetector }
+
+func (d aliasedDetector) KeywordAliases() []string { return d.aliases }
+
+func TestKeywords(t *testing.T) {
+	tests := []struct {
+		name     string
+		detector Detector
+		want     []string
+	}{
+		{
+			name:     "folded",
+			detector: keywordDetector{keywords: []string{"AKIA", "Straße"}},
+			want:     []string{"akia", "strasse"},
+		},
+		{
+			name:     "aliases",
+			detector: aliasedDetector{keywordDetector{keywords: []string{"aws"}, aliases: []string{"Amazon", "AWS"}}},
+			want:     []string{"aws", "amazon"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Keywords(tt.detector); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Keywords() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFoldCase(t *testing.T) {
+	tests := []struct {
+		data, keyword string
+	}{
+		{data: "export AWS_KEY=...", keyword: "aws"},
+		{data: "STRASSE", keyword: "straße"},
+		{data: "ΣΊΣΥΦΟΣ", keyword: "σίσυφος"},
+	}
+	for _, tt := range tests {
+		if !strings.Contains(FoldCase(tt.data), FoldCase(tt.keyword)) {
+			t.Errorf("%q doesn't contain %q once folded", tt.data, tt.keyword)
+		}
+	}
+}
@@ -44,63 +44,56 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	privKeyMatches := privKeyPat.FindAllStringSubmatch(dataStr, -1)
+	matches := detectors.FindMatches(keyPat, dataStr)
+	privKeyMatches := detectors.FindMatches(privKeyPat, dataStr)
 
-	for _, match := range matches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(matches, privKeyMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resMatch := strings.TrimSpace(match[1])
+		resMatch := parts[0].Value
+		resPrivKeyMatch := parts[1].Value
 
-		for _, privKeyMatch := range privKeyMatches {
-			if len(privKeyMatch) != 2 {
-				continue
-			}
-			resPrivKeyMatch := strings.TrimSpace(privKeyMatch[1])
-
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_Kraken,
-				Raw:          []byte(resMatch),
-			}
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Kraken,
+			Raw:          []byte(resMatch),
+		}
 
-			if verify {
+		if verify {
 
-				//Increasing 64-bit integer, for each request that is made with a particular API key.
-				apiNonce := strconv.FormatInt(time.Now().Unix(), 10)
+			//Increasing 64-bit integer, for each request that is made with a particular API key.
+			apiNonce := strconv.FormatInt(time.Now().Unix(), 10)
 
-				payload := url.Values{}
-				payload.Add("nonce", apiNonce)
+			payload := url.Values{}
+			payload.Add("nonce", apiNonce)
 
-				b64DecodedSecret, _ := base64.StdEncoding.DecodeString(resPrivKeyMatch)
-				signature := getKrakenSignature("/0/private/Balance", payload, b64DecodedSecret)
+			b64DecodedSecret, _ := base64.StdEncoding.DecodeString(resPrivKeyMatch)
+			signature := getKrakenSignature("/0/private/Balance", payload, b64DecodedSecret)
 
-				req, err := http.NewRequestWithContext(ctx, "POST", "https://api.kraken.com/0/private/Balance", strings.NewReader(payload.Encode()))
-				if err != nil {
-					continue
-				}
-				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-				req.Header.Add("API-Key", resMatch)
-				req.Header.Add("API-Sign", signature)
-
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					out, _ := io.ReadAll(res.Body)
-					if !strings.Contains(string(out), "Invalid key") {
-						s1.Verified = true
-					} else {
-						// This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-						if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
+			req, err := http.NewRequestWithContext(ctx, "POST", "https://api.kraken.com/0/private/Balance", strings.NewReader(payload.Encode()))
+			if err != nil {
+				continue
+			}
+			req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Add("API-Key", resMatch)
+			req.Header.Add("API-Sign", signature)
+
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				out, _ := io.ReadAll(res.Body)
+				if !strings.Contains(string(out), "Invalid key") {
+					s1.Verified = true
+				} else {
+					// This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
+						continue
 					}
 				}
 			}
-
-			results = append(results, s1)
 		}
 
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
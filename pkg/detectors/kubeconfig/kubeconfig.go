@@ -0,0 +1,235 @@
+package kubeconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+// config is the part of a kubeconfig file needed to find credentials and the clusters they're for. See
+// https://kubernetes.io/docs/concepts/configuration/organize-cluster-access-kubeconfig/.
+type config struct {
+	Clusters []struct {
+		Name    string  `yaml:"name"`
+		Cluster cluster `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User user   `yaml:"user"`
+	} `yaml:"users"`
+}
+
+type cluster struct {
+	Server                   string `yaml:"server"`
+	CertificateAuthorityData string `yaml:"certificate-authority-data"`
+	InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+}
+
+type user struct {
+	Token                 string `yaml:"token"`
+	ClientCertificateData string `yaml:"client-certificate-data"`
+	ClientKeyData         string `yaml:"client-key-data"`
+	Username              string `yaml:"username"`
+	Password              string `yaml:"password"`
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"clusters:", `"clusters"`}
+}
+
+// FromData will find and optionally verify credentials in kubeconfig files in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	var kubeconfig config
+	// Kubeconfig files may also be JSON, which is valid YAML. Anything else isn't a kubeconfig file.
+	if yaml.Unmarshal(data, &kubeconfig) != nil || len(kubeconfig.Users) == 0 {
+		return nil, nil
+	}
+
+	for _, u := range kubeconfig.Users {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		secret, auth := credential(u.User)
+		if secret == "" {
+			continue
+		}
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_KubeConfig,
+			Raw:          []byte(secret),
+			Redacted:     u.Name,
+			ExtraData: map[string]string{
+				"user": u.Name,
+				"auth": auth,
+			},
+		}
+		var admin bool
+		if auth == "client-certificate" {
+			admin = addCertificateData(s1.ExtraData, u.User.ClientCertificateData)
+		}
+
+		contextName, clusterName, c := clusterFor(kubeconfig, u.Name)
+		if contextName != "" {
+			s1.ExtraData["context"] = contextName
+			s1.ExtraData["cluster"] = clusterName
+			s1.ExtraData["server"] = c.Server
+		}
+
+		if verify && c.Server != "" {
+			s1.Verified = verifyCredential(ctx, c, u.User, auth)
+			if s1.Verified && admin {
+				s1.Severity = detectors.SeverityCritical
+			}
+		}
+
+		if !s1.Verified && detectors.IsKnownFalsePositive(secret, detectors.DefaultFalsePositives, false) {
+			continue
+		}
+
+		results = append(results, s1)
+	}
+
+	return detectors.CleanResults(results), nil
+}
+
+// credential returns the static credential of the user and its kind. Users authenticating with exec plugins or auth
+// providers have none.
+func credential(u user) (secret, auth string) {
+	switch {
+	case usable(u.Token):
+		return u.Token, "token"
+	case usable(u.ClientKeyData) && u.ClientCertificateData != "":
+		return u.ClientKeyData, "client-certificate"
+	case usable(u.Password):
+		return u.Password, "basic"
+	}
+	return "", ""
+}
+
+// usable returns false for empty values and template placeholders such as ${TOKEN}.
+func usable(value string) bool {
+	return value != "" && !strings.ContainsAny(value[:1], "$<{")
+}
+
+// clusterFor returns the first context that uses the user, and its cluster.
+func clusterFor(kubeconfig config, userName string) (contextName, clusterName string, c cluster) {
+	for _, kubeContext := range kubeconfig.Contexts {
+		if kubeContext.Context.User != userName {
+			continue
+		}
+		for _, kubeCluster := range kubeconfig.Clusters {
+			if kubeCluster.Name == kubeContext.Context.Cluster {
+				return kubeContext.Name, kubeCluster.Name, kubeCluster.Cluster
+			}
+		}
+	}
+	return "", "", cluster{}
+}
+
+// addCertificateData adds the identity in the client certificate to the extra data. Kubernetes uses the common name
+// as the user name and the organizations as groups. It returns true for members of system:masters, which bypasses
+// authorization entirely.
+func addCertificateData(extraData map[string]string, certData string) (admin bool) {
+	cert, err := parseCertificate(certData)
+	if err != nil {
+		return false
+	}
+	extraData["subject"] = cert.Subject.CommonName
+	extraData["expires"] = cert.NotAfter.UTC().Format(time.RFC3339)
+	if len(cert.Subject.Organization) > 0 {
+		extraData["groups"] = strings.Join(cert.Subject.Organization, ",")
+	}
+	for _, group := range cert.Subject.Organization {
+		if group == "system:masters" {
+			return true
+		}
+	}
+	return false
+}
+
+func parseCertificate(certData string) (*x509.Certificate, error) {
+	decoded, err := base64.StdEncoding.DecodeString(certData)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(decoded)
+	if block == nil {
+		return nil, errors.New("client certificate isn't PEM encoded")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// verifyCredential lists the API groups of the cluster, which every authenticated user may do.
+func verifyCredential(ctx context.Context, c cluster, u user, auth string) bool {
+	tlsConfig := &tls.Config{InsecureSkipVerify: c.InsecureSkipTLSVerify}
+	if c.CertificateAuthorityData != "" {
+		ca, err := base64.StdEncoding.DecodeString(c.CertificateAuthorityData)
+		if err != nil {
+			return false
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		tlsConfig.RootCAs.AppendCertsFromPEM(ca)
+	}
+	if auth == "client-certificate" {
+		certPEM, err := base64.StdEncoding.DecodeString(u.ClientCertificateData)
+		if err != nil {
+			return false
+		}
+		keyPEM, err := base64.StdEncoding.DecodeString(u.ClientKeyData)
+		if err != nil {
+			return false
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return false
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	// The transport is only used for one request, since the TLS configuration is specific to the credential.
+	transport := &http.Transport{TLSClientConfig: tlsConfig, Proxy: http.ProxyFromEnvironment}
+	defer transport.CloseIdleConnections()
+	client := &http.Client{Timeout: 5 * time.Second, Transport: common.NewCustomTransport(transport)}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", strings.TrimSuffix(c.Server, "/")+"/api", nil)
+	if err != nil {
+		return false
+	}
+	switch auth {
+	case "token":
+		req.Header.Set("Authorization", "Bearer "+u.Token)
+	case "basic":
+		req.SetBasicAuth(u.Username, u.Password)
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer res.Body.Close()
+	return res.StatusCode == http.StatusOK
+}
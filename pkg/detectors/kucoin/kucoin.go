@@ -8,7 +8,6 @@ import (
 	"net/http"
 	"regexp"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
@@ -40,75 +39,63 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	keyMatches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	secretMatches := secretPat.FindAllStringSubmatch(dataStr, -1)
-	passphraseMatches := passphrasePat.FindAllStringSubmatch(dataStr, -1)
+	keyMatches := detectors.FindMatches(keyPat, dataStr)
+	secretMatches := detectors.FindMatches(secretPat, dataStr)
+	passphraseMatches := detectors.FindMatches(passphrasePat, dataStr)
 
-	for _, keyMatch := range keyMatches {
-		if len(keyMatch) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(keyMatches, secretMatches, passphraseMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resKeyMatch := strings.TrimSpace(keyMatch[1])
+		resKeyMatch := parts[0].Value
+		resSecretMatch := parts[1].Value
+		resPassphraseMatch := parts[2].Value
 
-		for _, secretMatch := range secretMatches {
-			if len(secretMatch) != 2 {
-				continue
-			}
-			resSecretMatch := strings.TrimSpace(secretMatch[1])
-
-			for _, passphraseMatch := range passphraseMatches {
-				if len(passphraseMatch) != 2 {
-					continue
-				}
-				resPassphraseMatch := strings.TrimSpace(passphraseMatch[1])
-
-				s1 := detectors.Result{
-					DetectorType: detectorspb.DetectorType_KuCoin,
-					Raw:          []byte(resKeyMatch),
-				}
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_KuCoin,
+			Raw:          []byte(resKeyMatch),
+		}
 
-				if verify {
+		if verify {
 
-					timestamp := strconv.FormatInt(time.Now().Unix()*1000, 10)
-					method := "GET"
-					endpoint := "/api/v1/accounts"
-					bodyStr := ""
-					apiVersion := "2"
+			timestamp := strconv.FormatInt(time.Now().Unix()*1000, 10)
+			method := "GET"
+			endpoint := "/api/v1/accounts"
+			bodyStr := ""
+			apiVersion := "2"
 
-					signature := getKucoinSignature(resSecretMatch, timestamp, method, endpoint, bodyStr)
-					passPhrase := getKucoinPassphrase(resSecretMatch, resPassphraseMatch)
+			signature := getKucoinSignature(resSecretMatch, timestamp, method, endpoint, bodyStr)
+			passPhrase := getKucoinPassphrase(resSecretMatch, resPassphraseMatch)
 
-					req, err := http.NewRequest(method, "https://api.kucoin.com"+endpoint, nil)
-					if err != nil {
+			req, err := http.NewRequestWithContext(ctx, method, "https://api.kucoin.com"+endpoint, nil)
+			if err != nil {
+				continue
+			}
+			req.Header.Add("KC-API-KEY", resKeyMatch)
+			req.Header.Add("KC-API-SIGN", signature)
+			req.Header.Add("KC-API-TIMESTAMP", timestamp)
+			req.Header.Add("KC-API-PASSPHRASE", passPhrase)
+			req.Header.Add("KC-API-KEY-VERSION", apiVersion)
+
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(resKeyMatch, detectors.DefaultFalsePositives, true) {
 						continue
 					}
-					req.Header.Add("KC-API-KEY", resKeyMatch)
-					req.Header.Add("KC-API-SIGN", signature)
-					req.Header.Add("KC-API-TIMESTAMP", timestamp)
-					req.Header.Add("KC-API-PASSPHRASE", passPhrase)
-					req.Header.Add("KC-API-KEY-VERSION", apiVersion)
-
-					res, err := client.Do(req)
-					if err == nil {
-						defer res.Body.Close()
-						if res.StatusCode >= 200 && res.StatusCode < 300 {
-							s1.Verified = true
-						} else {
-							//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-							if detectors.IsKnownFalsePositive(resKeyMatch, detectors.DefaultFalsePositives, true) {
-								continue
-							}
-
-							if detectors.IsKnownFalsePositive(resSecretMatch, detectors.DefaultFalsePositives, true) {
-								continue
-							}
-						}
+
+					if detectors.IsKnownFalsePositive(resSecretMatch, detectors.DefaultFalsePositives, true) {
+						continue
 					}
 				}
-
-				results = append(results, s1)
 			}
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
@@ -4,7 +4,6 @@ import (
 	"context"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -34,53 +33,47 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	apiKeyMatches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	emailMatches := emailPat.FindAllStringSubmatch(dataStr, -1)
+	apiKeyMatches := detectors.FindMatches(keyPat, dataStr)
+	emailMatches := detectors.FindMatches(emailPat, dataStr)
 
-	for _, keyMatch := range apiKeyMatches {
-		if len(keyMatch) != 2 {
+	for _, parts := range detectors.PairMatches(apiKeyMatches, emailMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		apiKeyRes := parts[0].Value
+		emailRes := parts[1].Value
+
+		if detectors.IsKnownFalsePositive(apiKeyRes, detectors.DefaultFalsePositives, true) { // wait- (apiKeyRes, email) might be false positive does not mean (apiKeyRes, another_email) is ?
 			continue
 		}
-		apiKeyRes := strings.TrimSpace(keyMatch[1])
 
-		for _, emailMatch := range emailMatches {
-			if len(emailMatch) != 2 {
-				continue
-			}
-			emailRes := strings.TrimSpace(emailMatch[1])
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_MagicBell,
+			Raw:          []byte(apiKeyRes),
+		}
 
-			if detectors.IsKnownFalsePositive(apiKeyRes, detectors.DefaultFalsePositives, true) { // wait- (apiKeyRes, email) might be false positive does not mean (apiKeyRes, another_email) is ?
+		if verify {
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://api.magicbell.com/notification_preferences", nil)
+			if err != nil {
 				continue
 			}
-
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_MagicBell,
-				Raw:          []byte(apiKeyRes),
-			}
-
-			if verify {
-				req, err := http.NewRequestWithContext(ctx, "GET", "https://api.magicbell.com/notification_preferences", nil)
-				if err != nil {
-					continue
-				}
-				req.Header.Add("X-MAGICBELL-API-KEY", apiKeyRes)
-				req.Header.Add("X-MAGICBELL-USER-EMAIL", emailRes)
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					} else {
-						//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-						if detectors.IsKnownFalsePositive(apiKeyRes, detectors.DefaultFalsePositives, true) {
-							continue
-						}
+			req.Header.Add("X-MAGICBELL-API-KEY", apiKeyRes)
+			req.Header.Add("X-MAGICBELL-USER-EMAIL", emailRes)
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(apiKeyRes, detectors.DefaultFalsePositives, true) {
+						continue
 					}
 				}
 			}
-
-			results = append(results, s1)
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
@@ -2,8 +2,10 @@ package mapbox
 
 import (
 	"context"
-	// "log"
+	"encoding/base64"
+	"encoding/json"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
 
@@ -19,7 +21,7 @@ var _ detectors.Detector = (*Scanner)(nil)
 
 var (
 	client = common.SaneHttpClient()
-	idPat  = regexp.MustCompile(`([a-zA-Z-0-9]{4,32})`)
+	// Secret tokens start with "sk."; public tokens, which start with "pk.", are meant to be shipped to browsers.
 	keyPat = regexp.MustCompile(`\b(sk\.[a-zA-Z-0-9\.]{80,240})\b`)
 )
 
@@ -34,45 +36,73 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	dataStr := string(data)
 
 	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	idMatches := idPat.FindAllStringSubmatch(dataStr, -1)
 	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
 
 		resMatch := strings.TrimSpace(match[1])
-		for i, idMatch := range idMatches {
-			if i == 11 {
-				if len(idMatch) != 2 {
-					continue
-				}
-				resId := strings.TrimSpace(idMatch[1])
 
-				s1 := detectors.Result{
-					DetectorType: detectorspb.DetectorType_MapBox,
-					Raw:          []byte(resMatch),
-				}
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_MapBox,
+			Raw:          []byte(resMatch),
+		}
+		// The token names the account it belongs to.
+		if user := tokenUser(resMatch); user != "" {
+			s1.Redacted = user
+			s1.ExtraData = map[string]string{"user": user}
+		}
 
-				if verify {
-					req, err := http.NewRequestWithContext(ctx, "GET", "https://api.mapbox.com/tokens/v2/"+resId+"?access_token="+resMatch, nil)
-					if err != nil {
-						continue
-					}
-					res, err := client.Do(req)
-					if err == nil {
-						defer res.Body.Close()
-						if res.StatusCode >= 200 && res.StatusCode < 300 {
-							s1.Verified = true
-						} else {
-							if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
-								continue
-							}
-						}
+		if verify {
+			// https://docs.mapbox.com/api/accounts/tokens/#retrieve-a-token
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://api.mapbox.com/tokens/v2?access_token="+url.QueryEscape(resMatch), nil)
+			if err != nil {
+				continue
+			}
+			res, err := client.Do(req)
+			if err == nil {
+				var body struct {
+					Code  string `json:"code"`
+					Token struct {
+						User string `json:"user"`
+					} `json:"token"`
+				}
+				if res.StatusCode >= 200 && res.StatusCode < 300 && json.NewDecoder(res.Body).Decode(&body) == nil && body.Code == "TokenValid" {
+					s1.Verified = true
+					if body.Token.User != "" {
+						s1.Redacted = body.Token.User
+						s1.ExtraData = map[string]string{"user": body.Token.User}
 					}
 				}
-				results = append(results, s1)
+				res.Body.Close()
 			}
+		}
 
+		if !s1.Verified && detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
+			continue
 		}
 
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
 }
+
+// tokenUser decodes the username from the token's payload, e.g. {"u":"username","a":"token-id"}.
+func tokenUser(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) < 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return ""
+	}
+	var claims struct {
+		User string `json:"u"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	return claims.User
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
 )
 
@@ -93,6 +94,8 @@ func TestMapBox_FromChunk(t *testing.T) {
 					t.Fatalf("no raw secret present: \n %+v", got[i])
 				}
 				got[i].Raw = nil
+				got[i].Redacted = ""
+				got[i].ExtraData = nil
 			}
 			if diff := pretty.Compare(got, tt.want); diff != "" {
 				t.Errorf("MapBox.FromData() %s diff: (-got +want)\n%s", tt.name, diff)
@@ -101,6 +104,32 @@ func TestMapBox_FromChunk(t *testing.T) {
 	}
 }
 
+func TestMapBox_TokenUser(t *testing.T) {
+	const token = "sk.eyJ1IjoiYWNtZS1tYXBzIiwiYSI6ImNscTh4Mms5cDBhYmMxMjNkZWY0NTZnaGkifQ.Xy9kLmN3pQ7rTzV2bW8cFg"
+
+	detectortest.Test(t, Scanner{},
+		detectortest.Fixture{
+			Name:   "valid token",
+			Data:   "MAPBOX_ACCESS_TOKEN=" + token,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "api.mapbox.com",
+				Path:   "/tokens/v2",
+				Status: 200,
+				Body:   `{"code": "TokenValid", "token": {"usage": "sk", "user": "acme-maps", "authorization": "clq8x2k9p0abc123def456ghi"}}`,
+			}},
+			Want: []detectortest.Want{{Raw: token, Verified: true, ExtraData: map[string]string{"user": "acme-maps"}}},
+		},
+		detectortest.Fixture{
+			Name:   "revoked token",
+			Data:   "mapbox: " + token,
+			Verify: true,
+			Mocks:  []detectortest.Mock{{Host: "api.mapbox.com", Status: 401, Body: `{"code": "TokenRevoked"}`}},
+			Want:   []detectortest.Want{{Raw: token, ExtraData: map[string]string{"user": "acme-maps"}}},
+		},
+	)
+}
+
 func BenchmarkFromData(benchmark *testing.B) {
 	ctx := context.Background()
 	s := Scanner{}
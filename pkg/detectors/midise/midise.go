@@ -34,6 +34,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	matches := secretKey.FindAllString(dataStr, -1)
 
 	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
 
 		s := detectors.Result{
 			DetectorType: detectorspb.DetectorType_Midise,
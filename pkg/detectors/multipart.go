@@ -0,0 +1,88 @@
+package detectors
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Match is one part of a credential found in a chunk, such as a key, an ID, or a URL.
+type Match struct {
+	// Value is the trimmed first capture group of the match.
+	Value string
+	// Start is the byte offset of the value in the chunk.
+	Start int
+	// Line is the line of the value in the chunk, starting at 1.
+	Line int
+}
+
+// FindMatches returns the first capture group of every match of pat in data, with its position.
+func FindMatches(pat *regexp.Regexp, data string) []Match {
+	var matches []Match
+	line, counted := 1, 0
+	for _, loc := range pat.FindAllStringSubmatchIndex(data, -1) {
+		if len(loc) < 4 || loc[2] < 0 {
+			continue
+		}
+		// Matches are in order, so lines only need to be counted since the previous match.
+		line += strings.Count(data[counted:loc[2]], "\n")
+		counted = loc[2]
+		matches = append(matches, Match{
+			Value: strings.TrimSpace(data[loc[2]:loc[3]]),
+			Start: loc[2],
+			Line:  line,
+		})
+	}
+	return matches
+}
+
+// PairMatches groups each primary match with the closest match of every other part of a credential, returning one
+// group per primary match with the primary first and the other parts in the order they were passed. Matches on the
+// same line are closest, then the nearest lines, then the nearest offsets. If any part has no matches there are no
+// groups.
+//
+// Detectors for credentials made of several parts should use this rather than trying every combination of matches,
+// which multiplies results and verifies parts that don't belong together.
+func PairMatches(primary []Match, parts ...[]Match) [][]Match {
+	for _, part := range parts {
+		if len(part) == 0 {
+			return nil
+		}
+	}
+
+	groups := make([][]Match, 0, len(primary))
+	for _, p := range primary {
+		group := make([]Match, 0, len(parts)+1)
+		group = append(group, p)
+		for _, part := range parts {
+			group = append(group, closest(p, part))
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// closest returns the candidate closest to the match. Ties go to the earliest candidate.
+func closest(m Match, candidates []Match) Match {
+	best := candidates[0]
+	for _, c := range candidates[1:] {
+		if lineDistance(m, c) < lineDistance(m, best) ||
+			(lineDistance(m, c) == lineDistance(m, best) && offsetDistance(m, c) < offsetDistance(m, best)) {
+			best = c
+		}
+	}
+	return best
+}
+
+func lineDistance(a, b Match) int {
+	if a.Line > b.Line {
+		return a.Line - b.Line
+	}
+	return b.Line - a.Line
+}
+
+func offsetDistance(a, b Match) int {
+	if a.Start > b.Start {
+		return a.Start - b.Start
+	}
+	return b.Start - a.Start
+}
@@ -0,0 +1,48 @@
+package detectors
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestPairMatches(t *testing.T) {
+	keyPat := regexp.MustCompile(`key=([a-z0-9]+)`)
+	idPat := regexp.MustCompile(`id=([a-z0-9]+)`)
+	urlPat := regexp.MustCompile(`url=([a-z.]+)`)
+
+	data := `url=shared.example.com
+key=key1 id=id1 extra
+key=key2
+id=id2
+
+id=id3
+key=key3`
+
+	keys := FindMatches(keyPat, data)
+	if len(keys) != 3 || keys[0].Value != "key1" || keys[0].Line != 2 || keys[2].Line != 7 {
+		t.Fatalf("unexpected key matches: %+v", keys)
+	}
+
+	groups := PairMatches(keys, FindMatches(idPat, data), FindMatches(urlPat, data))
+	want := [][]string{
+		// Same line.
+		{"key1", "id1", "shared.example.com"},
+		// id1 and id2 are both one line away, so the nearest offset wins.
+		{"key2", "id2", "shared.example.com"},
+		{"key3", "id3", "shared.example.com"},
+	}
+	if len(groups) != len(want) {
+		t.Fatalf("unexpected number of groups. Got: %d, Expected: %d", len(groups), len(want))
+	}
+	for i, group := range groups {
+		for j, match := range group {
+			if match.Value != want[i][j] {
+				t.Errorf("group %d part %d: got %s, expected %s", i, j, match.Value, want[i][j])
+			}
+		}
+	}
+
+	if groups := PairMatches(keys, nil); groups != nil {
+		t.Errorf("expected no groups when a part has no matches, got: %+v", groups)
+	}
+}
@@ -0,0 +1,108 @@
+// Package netrc detects the entries of .netrc files, which curl, git, ftp, and the Heroku CLI read the login and
+// password of each host from.
+//
+// Like git credential store entries, credentials are only verified for the hosts gitcredentials.VerifyHost knows.
+package netrc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/gitcredentials"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"machine"}
+}
+
+// entry is a machine in a .netrc file. See https://www.gnu.org/software/inetutils/manual/html_node/The-_002enetrc-file.html.
+type entry struct {
+	machine, login, password string
+}
+
+// FromData will find and optionally verify .netrc entries in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	for _, e := range parse(string(data)) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		// Entries need a host name, rather than a word that follows "machine" in prose.
+		if e.password == "" || !strings.Contains(e.machine, ".") || strings.ContainsAny(e.password[:1], "$<{") {
+			continue
+		}
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Netrc,
+			Raw:          []byte(e.password),
+			Redacted:     e.login + "@" + e.machine,
+			ExtraData: map[string]string{
+				"host":  e.machine,
+				"login": e.login,
+			},
+		}
+
+		if verify {
+			s1.Verified, _ = gitcredentials.VerifyHost(ctx, e.machine, e.login, e.password)
+		}
+
+		if !s1.Verified && detectors.IsKnownFalsePositive(e.password, detectors.DefaultFalsePositives, false) {
+			continue
+		}
+
+		results = append(results, s1)
+	}
+
+	return detectors.CleanResults(results), nil
+}
+
+// parse returns the machine entries in data. Tokens may be split across lines in any way. The default entry and macro
+// definitions are skipped.
+func parse(data string) []entry {
+	var entries []entry
+	current := -1
+	keyword := ""
+	lines := strings.Split(data, "\n")
+	for i := 0; i < len(lines); i++ {
+	tokens:
+		for _, token := range strings.Fields(lines[i]) {
+			if keyword != "" {
+				value := strings.Trim(token, `"`)
+				switch {
+				case keyword == "machine":
+					entries = append(entries, entry{machine: strings.ToLower(value)})
+					current = len(entries) - 1
+				case keyword == "login" && current >= 0:
+					entries[current].login = value
+				case keyword == "password" && current >= 0:
+					entries[current].password = value
+				}
+				keyword = ""
+				continue
+			}
+			switch token {
+			case "machine", "login", "password", "account":
+				keyword = token
+			case "default":
+				current = -1
+			case "macdef":
+				// A macro's body runs to the next empty line.
+				for i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+					i++
+				}
+				current = -1
+				break tokens
+			}
+		}
+	}
+	return entries
+}
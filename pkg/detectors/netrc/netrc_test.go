@@ -0,0 +1,72 @@
+package netrc
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
+)
+
+func TestNetrc(t *testing.T) {
+	const apiKey = "4e7f3c1a-9b2d-4c8e-a6f5-0d1b2c3e4f5a"
+	data := `machine api.heroku.com
+  login ops@corp.net
+  password ` + apiKey + `
+machine ftp.corp.net login backup password "S3cr3t-Backup-Pw"
+`
+	want := detectortest.Want{
+		Raw:       apiKey,
+		ExtraData: map[string]string{"host": "api.heroku.com", "login": "ops@corp.net"},
+	}
+	verified := want
+	verified.Verified = true
+	ftp := detectortest.Want{
+		Raw:       "S3cr3t-Backup-Pw",
+		ExtraData: map[string]string{"host": "ftp.corp.net", "login": "backup"},
+	}
+
+	detectortest.Test(t, Scanner{},
+		detectortest.Fixture{
+			Name:   "valid API key",
+			Data:   data,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "api.heroku.com",
+				Path:   "/account",
+				Header: map[string]string{"Authorization": "Bearer " + apiKey},
+				Status: 200,
+			}},
+			Want: []detectortest.Want{verified, ftp},
+		},
+		detectortest.Fixture{
+			Name:   "revoked API key",
+			Data:   data,
+			Verify: true,
+			Mocks:  []detectortest.Mock{{Host: "api.heroku.com", Status: 401}},
+			Want:   []detectortest.Want{want, ftp},
+		},
+		detectortest.Fixture{
+			Name: "prose",
+			Data: "Log in to the build machine with the password from the vault.",
+		},
+	)
+}
+
+func TestParse(t *testing.T) {
+	data := `default login anonymous password guest@
+machine a.corp.net
+login alice
+macdef init
+cd /pub
+password inside-macro
+
+machine b.corp.net account ops login bob password hunter22`
+	want := []entry{
+		{machine: "a.corp.net", login: "alice"},
+		{machine: "b.corp.net", login: "bob", password: "hunter22"},
+	}
+	if diff := pretty.Compare(parse(data), want); diff != "" {
+		t.Errorf("(-got +want)\n%s", diff)
+	}
+}
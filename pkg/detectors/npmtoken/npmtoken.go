@@ -0,0 +1,120 @@
+package npmtoken
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	client = common.SaneHttpClient()
+
+	// Automation, publish, and granular access tokens all share the npm_ prefix.
+	keyPat = regexp.MustCompile(`\b(npm_[a-zA-Z0-9]{36})\b`)
+
+	registryURL = "https://registry.npmjs.org"
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"npm_"}
+}
+
+// FromData will find and optionally verify npm tokens in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
+
+	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		if len(match) != 2 {
+			continue
+		}
+		token := match[1]
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_NpmToken,
+			Raw:          []byte(token),
+		}
+
+		if verify {
+			username := whoami(ctx, token)
+			if username != "" {
+				s1.Verified = true
+				s1.ExtraData = map[string]string{"username": username}
+				if packages := writablePackages(ctx, token, username); len(packages) > 0 {
+					s1.ExtraData["packages"] = strings.Join(packages, ",")
+				}
+			}
+		}
+
+		if !s1.Verified && detectors.IsKnownFalsePositive(token, detectors.DefaultFalsePositives, true) {
+			continue
+		}
+
+		results = append(results, s1)
+	}
+
+	return detectors.CleanResults(results), nil
+}
+
+// whoami returns the user the token belongs to, or an empty string if it isn't valid.
+func whoami(ctx context.Context, token string) string {
+	var user struct {
+		Username string `json:"username"`
+	}
+	if !get(ctx, token, registryURL+"/-/whoami", &user) {
+		return ""
+	}
+	return user.Username
+}
+
+// writablePackages returns the packages the user can publish, which is what a leaked token puts at risk.
+func writablePackages(ctx context.Context, token, username string) []string {
+	var access map[string]string
+	if !get(ctx, token, registryURL+"/-/user/"+url.PathEscape(username)+"/package", &access) {
+		return nil
+	}
+	var packages []string
+	for name, permission := range access {
+		if permission == "write" {
+			packages = append(packages, name)
+		}
+	}
+	sort.Strings(packages)
+	return packages
+}
+
+func get(ctx context.Context, token, u string, v interface{}) bool {
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Add("Authorization", "Bearer "+token)
+	res, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return false
+	}
+	return json.NewDecoder(res.Body).Decode(v) == nil
+}
@@ -2,9 +2,12 @@ package okta
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -17,8 +20,10 @@ type Scanner struct{}
 var _ detectors.Detector = (*Scanner)(nil)
 
 var (
-	domainPat = regexp.MustCompile(`[a-z0-9-]{1,40}\.okta(?:preview|-emea){0,1}\.com`)
-	tokenPat  = regexp.MustCompile(`00[a-zA-Z0-9_-]{40}`)
+	client = common.SaneHttpClient()
+
+	domainPat = regexp.MustCompile(`\b([a-z0-9-]{1,40}\.okta(?:preview|-emea|-gov)?\.com)\b`)
+	tokenPat  = regexp.MustCompile(`\b(00[a-zA-Z0-9_-]{40})\b`)
 	// TODO: Oauth client secrets
 )
 
@@ -30,53 +35,96 @@ func (s Scanner) Keywords() []string {
 
 // FromData will find and optionally verify Okta secrets in a given set of bytes.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
-	for _, tokenMatch := range tokenPat.FindAll(data, -1) {
-		token := string(tokenMatch)
+	dataStr := string(data)
 
-		for _, domainMatch := range domainPat.FindAll(data, -1) {
-			domain := string(domainMatch)
+	tokens := detectors.FindMatches(tokenPat, dataStr)
+	domains := detectors.FindMatches(domainPat, dataStr)
 
-			s := detectors.Result{
-				DetectorType: detectorspb.DetectorType_Okta,
-				Raw:          []byte(token),
-			}
+	for _, parts := range detectors.PairMatches(tokens, domains) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		token := parts[0].Value
+		domain := parts[1].Value
 
-			if verify {
-				// curl -v -X GET \
-				// -H "Accept: application/json" \
-				// -H "Content-Type: application/json" \
-				// -H "Authorization: Bearer token" \
-				// "https://subdomain.okta.com/api/v1/groups?limit=1"
-				//
-
-				url := fmt.Sprintf("https://%s/api/v1/groups?limit=1", domain)
-				req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-				if err != nil {
-					return results, err
-				}
-				req.Header.Set("Accept", "application/json")
-				req.Header.Set("Content-Type", "application/json")
-				req.Header.Set("Authorization", fmt.Sprintf("SSWS %s", token))
+		s := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Okta,
+			Raw:          []byte(token),
+			Redacted:     domain,
+			ExtraData:    map[string]string{"org": domain},
+		}
 
-				resp, err := common.SaneHttpClient().Do(req)
-				if err != nil {
-					return results, err
+		if verify {
+			if login, ok := currentUser(ctx, domain, token); ok {
+				s.Verified = true
+				s.ExtraData["user"] = login
+				roles := adminRoles(ctx, domain, token)
+				if len(roles) > 0 {
+					s.ExtraData["roles"] = strings.Join(roles, ",")
 				}
-				defer resp.Body.Close()
-				if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-					s.Verified = true
+				for _, role := range roles {
+					if role == "SUPER_ADMIN" || role == "ORG_ADMIN" {
+						s.Severity = detectors.SeverityCritical
+					}
 				}
 			}
+		}
 
-			if !s.Verified {
-				if detectors.IsKnownFalsePositive(string(s.Raw), detectors.DefaultFalsePositives, true) {
-					continue
-				}
+		if !s.Verified {
+			if detectors.IsKnownFalsePositive(string(s.Raw), detectors.DefaultFalsePositives, true) {
+				continue
 			}
-
-			results = append(results, s)
 		}
+
+		results = append(results, s)
 	}
 
 	return
 }
+
+// currentUser returns the login of the admin who created the token. API tokens act with their creator's permissions.
+func currentUser(ctx context.Context, domain, token string) (string, bool) {
+	var user struct {
+		Profile struct {
+			Login string `json:"login"`
+		} `json:"profile"`
+	}
+	if !get(ctx, domain, token, "/api/v1/users/me", &user) {
+		return "", false
+	}
+	return user.Profile.Login, true
+}
+
+// adminRoles returns the administrator roles of the token's creator, sorted.
+func adminRoles(ctx context.Context, domain, token string) []string {
+	var assignments []struct {
+		Type string `json:"type"`
+	}
+	if !get(ctx, domain, token, "/api/v1/users/me/roles", &assignments) {
+		return nil
+	}
+	roles := make([]string, 0, len(assignments))
+	for _, assignment := range assignments {
+		roles = append(roles, assignment.Type)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+func get(ctx context.Context, domain, token, path string, v interface{}) bool {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://"+domain+path, nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("SSWS %s", token))
+	res, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return false
+	}
+	return json.NewDecoder(res.Body).Decode(v) == nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
 )
 
@@ -92,6 +93,9 @@ func TestOkta_FromChunk(t *testing.T) {
 					t.Fatal("no raw secret present")
 				}
 				got[i].Raw = nil
+				got[i].Redacted = ""
+				got[i].ExtraData = nil
+				got[i].Severity = detectors.SeverityUnknown
 			}
 			if diff := pretty.Compare(got, tt.want); diff != "" {
 				t.Errorf("Okta.FromData) %s diff: (-got +want)\n%s", tt.name, diff)
@@ -114,3 +118,51 @@ func BenchmarkFromData(benchmark *testing.B) {
 		})
 	}
 }
+
+func TestOkta_Metadata(t *testing.T) {
+	const token = "00Qk7Lm2Rt9Wz4Kb8Nc1Vp6Hd3Jf0Gs5Ye2Ua7Ti9X"
+	data := "OKTA_ORG_URL=https://acme.okta.com\nOKTA_API_TOKEN=" + token + "\n"
+
+	detectortest.Test(t, Scanner{},
+		detectortest.Fixture{
+			Name:   "admin token",
+			Data:   data,
+			Verify: true,
+			Mocks: []detectortest.Mock{
+				{
+					Host:   "acme.okta.com",
+					Path:   "/api/v1/users/me",
+					Header: map[string]string{"Authorization": "SSWS " + token},
+					Status: 200,
+					Body:   `{"id": "00u1a2b3c4", "profile": {"login": "it-automation@acme.com"}}`,
+				},
+				{
+					Host:   "acme.okta.com",
+					Path:   "/api/v1/users/me/roles",
+					Status: 200,
+					Body:   `[{"type": "USER_ADMIN"}, {"type": "APP_ADMIN"}]`,
+				},
+			},
+			Want: []detectortest.Want{{
+				Raw:      token,
+				Verified: true,
+				ExtraData: map[string]string{
+					"org":   "acme.okta.com",
+					"user":  "it-automation@acme.com",
+					"roles": "APP_ADMIN,USER_ADMIN",
+				},
+			}},
+		},
+		detectortest.Fixture{
+			Name:   "revoked token",
+			Data:   data,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "acme.okta.com",
+				Status: 401,
+				Body:   `{"errorCode": "E0000011", "errorSummary": "Invalid token provided"}`,
+			}},
+			Want: []detectortest.Want{{Raw: token, ExtraData: map[string]string{"org": "acme.okta.com"}}},
+		},
+	)
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
 )
@@ -38,47 +39,47 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	for _, clientID := range oauthClientIDPat.FindAllStringSubmatch(dataStr, -1) {
-		if len(clientID) != 2 {
-			continue
+	clientIDMatches := detectors.FindMatches(oauthClientIDPat, dataStr)
+	clientSecretMatches := detectors.FindMatches(oauthClientSecretPat, dataStr)
+
+	for _, parts := range detectors.PairMatches(clientIDMatches, clientSecretMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		for _, clientSecret := range oauthClientSecretPat.FindAllStringSubmatch(dataStr, -1) {
-			if len(clientSecret) != 2 {
-				continue
-			}
+		clientID := parts[0].Value
+		clientSecret := parts[1].Value
 
-			s := detectors.Result{
-				DetectorType: detectorspb.DetectorType_OneLogin,
-				Raw:          []byte(clientID[1]),
-				Redacted:     clientID[1],
-			}
+		s := detectors.Result{
+			DetectorType: detectorspb.DetectorType_OneLogin,
+			Raw:          []byte(clientID),
+			Redacted:     clientID,
+		}
 
-			if verify {
-				for _, domain := range apiDomains {
-					tokenURL := fmt.Sprintf("https://%s/auth/oauth2/v2/token", domain)
-					req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(`{"grant_type":"client_credentials"}`))
-					if err != nil {
-						continue
-					}
-					req.Header.Add("Authorization", fmt.Sprintf("client_id:%s, client_secret:%s", clientID[1], clientSecret[1]))
-					req.Header.Add("Content-Type", "application/json; charset=utf-8")
-					res, err := client.Do(req)
-					if err == nil {
-						res.Body.Close() // The request body is unused.
-
-						if res.StatusCode >= 200 && res.StatusCode < 300 {
-							s.Verified = true
-						}
+		if verify {
+			for _, domain := range apiDomains {
+				tokenURL := fmt.Sprintf("https://%s/auth/oauth2/v2/token", domain)
+				req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(`{"grant_type":"client_credentials"}`))
+				if err != nil {
+					continue
+				}
+				req.Header.Add("Authorization", fmt.Sprintf("client_id:%s, client_secret:%s", clientID, clientSecret))
+				req.Header.Add("Content-Type", "application/json; charset=utf-8")
+				res, err := client.Do(req)
+				if err == nil {
+					res.Body.Close() // The request body is unused.
+
+					if res.StatusCode >= 200 && res.StatusCode < 300 {
+						s.Verified = true
 					}
 				}
 			}
+		}
 
-			if !s.Verified && detectors.IsKnownFalsePositive(string(s.Raw), detectors.DefaultFalsePositives, true) {
-				continue
-			}
-
-			results = append(results, s)
+		if !s.Verified && detectors.IsKnownFalsePositive(string(s.Raw), detectors.DefaultFalsePositives, true) {
+			continue
 		}
+
+		results = append(results, s)
 	}
 
 	return detectors.CleanResults(results), nil
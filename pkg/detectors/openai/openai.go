@@ -0,0 +1,100 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	client = common.SaneHttpClient()
+
+	// Every OpenAI key contains "T3BlbkFJ", which is "OpenAI" in base64. Project, service account, and admin keys have
+	// an extra prefix.
+	keyPat = regexp.MustCompile(`\b(sk-(?:(proj|svcacct|admin)-)?[A-Za-z0-9_-]{20,80}T3BlbkFJ[A-Za-z0-9_-]{20,80})`)
+
+	keyTypes = map[string]string{
+		"":        "user",
+		"proj":    "project",
+		"svcacct": "service_account",
+		"admin":   "admin",
+	}
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"T3BlbkFJ"}
+}
+
+// FromData will find and optionally verify OpenAI secrets in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
+
+	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		if len(match) != 3 {
+			continue
+		}
+		key := match[1]
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_OpenAI,
+			Raw:          []byte(key),
+			ExtraData:    map[string]string{"key_type": keyTypes[match[2]]},
+		}
+
+		if verify {
+			organization, verified := verifyKey(ctx, key, match[2] == "admin")
+			s1.Verified = verified
+			if organization != "" {
+				s1.ExtraData["organization"] = organization
+			}
+		}
+
+		if !s1.Verified && detectors.IsKnownFalsePositive(key, detectors.DefaultFalsePositives, true) {
+			continue
+		}
+
+		results = append(results, s1)
+	}
+
+	return detectors.CleanResults(results), nil
+}
+
+// verifyKey lists models, or projects for admin keys, which can't use the API otherwise. Keys without quota left are
+// rate limited, but still valid: whoever has the key can use it once the account is paid for.
+func verifyKey(ctx context.Context, key string, admin bool) (organization string, verified bool) {
+	endpoint := "https://api.openai.com/v1/models"
+	if admin {
+		endpoint = "https://api.openai.com/v1/organization/projects?limit=1"
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return "", false
+	}
+	req.Header.Add("Authorization", "Bearer "+key)
+	res, err := client.Do(req)
+	if err != nil {
+		return "", false
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusTooManyRequests {
+		return "", false
+	}
+	return strings.TrimSpace(res.Header.Get("openai-organization")), true
+}
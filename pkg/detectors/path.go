@@ -0,0 +1,32 @@
+package detectors
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+// PathAware is implemented by detectors whose results mean more in some files than others, such as credentials in CI
+// configuration. The engine calls AdjustForPath for each result found in a file, after the default severity is set.
+type PathAware interface {
+	// AdjustForPath updates a result found in the file at path, e.g. by raising its severity.
+	AdjustForPath(result *Result, path string)
+}
+
+// FilePath returns the file of the source metadata, for sources that have files, or an empty string.
+func FilePath(metadata *source_metadatapb.MetaData) string {
+	if metadata == nil {
+		return ""
+	}
+	m := metadata.ProtoReflect()
+	field := m.WhichOneof(m.Descriptor().Oneofs().ByName("data"))
+	if field == nil || field.Kind() != protoreflect.MessageKind {
+		return ""
+	}
+	source := m.Get(field).Message()
+	file := source.Descriptor().Fields().ByName("file")
+	if file == nil || file.Kind() != protoreflect.StringKind {
+		return ""
+	}
+	return source.Get(file).String()
+}
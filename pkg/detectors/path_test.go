@@ -0,0 +1,34 @@
+package detectors
+
+import (
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+func TestFilePath(t *testing.T) {
+	tests := map[string]struct {
+		metadata *source_metadatapb.MetaData
+		want     string
+	}{
+		"git": {
+			metadata: &source_metadatapb.MetaData{Data: &source_metadatapb.MetaData_Git{Git: &source_metadatapb.Git{File: ".gitlab-ci.yml"}}},
+			want:     ".gitlab-ci.yml",
+		},
+		"s3": {
+			metadata: &source_metadatapb.MetaData{Data: &source_metadatapb.MetaData_S3{S3: &source_metadatapb.S3{File: "configs/prod.env"}}},
+			want:     "configs/prod.env",
+		},
+		"no file": {
+			metadata: &source_metadatapb.MetaData{Data: &source_metadatapb.MetaData_Jira{Jira: &source_metadatapb.Jira{}}},
+		},
+		"no metadata": {},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := FilePath(tt.metadata); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package detectors
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// PathRule changes the severity of results found in files matching any of its patterns, such as lowering it for
+// test fixtures or raising it for production configuration.
+//
+// Patterns are globs in which * matches within a directory and ** matches across directories. Patterns without a
+// slash, like "*.tfvars", match the file name. Patterns with one, like "test/fixtures/**", match the end of the path
+// from any directory, since sources report paths under the repository or directory that was scanned. A leading slash
+// matches from the start of the path instead.
+type PathRule struct {
+	Patterns []string
+	// Severity, if set, replaces the severity of the result.
+	Severity Severity
+	// Adjust raises or lowers the severity by that many levels, after Severity is applied. It stops at low and
+	// critical.
+	Adjust int
+}
+
+// PathRules applies the first matching PathRule to results.
+type PathRules struct {
+	rules []compiledPathRule
+}
+
+type compiledPathRule struct {
+	PathRule
+	patterns []*regexp.Regexp
+}
+
+// NewPathRules returns rules that apply in the order given.
+func NewPathRules(rules []PathRule) (*PathRules, error) {
+	p := &PathRules{}
+	for _, rule := range rules {
+		compiled := compiledPathRule{PathRule: rule}
+		for _, pattern := range rule.Patterns {
+			re, err := globRegexp(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid path pattern %q: %w", pattern, err)
+			}
+			compiled.patterns = append(compiled.patterns, re)
+		}
+		p.rules = append(p.rules, compiled)
+	}
+	return p, nil
+}
+
+// Apply changes the severity of a result found in the file at path by the first rule with a matching pattern, and
+// records the pattern in its "path_rule" extra data. It returns false if no rule matched.
+func (p *PathRules) Apply(result *Result, path string) bool {
+	if p == nil || path == "" {
+		return false
+	}
+	path = strings.ReplaceAll(path, "\\", "/")
+	for _, rule := range p.rules {
+		for i, re := range rule.patterns {
+			if !re.MatchString(path) {
+				continue
+			}
+			if rule.Severity != SeverityUnknown {
+				result.Severity = rule.Severity
+			}
+			result.Severity += Severity(rule.Adjust)
+			if result.Severity < SeverityLow {
+				result.Severity = SeverityLow
+			}
+			if result.Severity > SeverityCritical {
+				result.Severity = SeverityCritical
+			}
+			if result.ExtraData == nil {
+				result.ExtraData = map[string]string{}
+			}
+			result.ExtraData["path_rule"] = rule.Patterns[i]
+			return true
+		}
+	}
+	return false
+}
+
+// globRegexp returns a regular expression matching paths that end with the glob, starting at a directory.
+func globRegexp(pattern string) (*regexp.Regexp, error) {
+	pattern = strings.ReplaceAll(strings.TrimSpace(pattern), "\\", "/")
+	if pattern == "" || pattern == "/" {
+		return nil, fmt.Errorf("empty pattern")
+	}
+
+	var b strings.Builder
+	if strings.HasPrefix(pattern, "/") {
+		b.WriteString("^")
+		pattern = pattern[1:]
+	} else {
+		b.WriteString("(?:^|/)")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			b.WriteString("[^/]*")
+		case pattern[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,51 @@
+package detectors
+
+import "testing"
+
+func TestPathRules(t *testing.T) {
+	rules, err := NewPathRules([]PathRule{
+		{Patterns: []string{"test/fixtures/**", "**/testdata/**"}, Severity: SeverityLow},
+		{Patterns: []string{"*.tfvars", "prod/**"}, Adjust: 1},
+		{Patterns: []string{"/vendor/**"}, Adjust: -2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		path         string
+		severity     Severity
+		wantSeverity Severity
+		wantRule     string
+	}{
+		"fixture":            {path: "repo/test/fixtures/keys.json", severity: SeverityHigh, wantSeverity: SeverityLow, wantRule: "test/fixtures/**"},
+		"nested testdata":    {path: "pkg/aws/testdata/creds", severity: SeverityMedium, wantSeverity: SeverityLow, wantRule: "**/testdata/**"},
+		"tfvars":             {path: "infra/prod.tfvars", severity: SeverityLow, wantSeverity: SeverityMedium, wantRule: "*.tfvars"},
+		"windows path":       {path: `C:\src\app\prod\config.yml`, severity: SeverityHigh, wantSeverity: SeverityCritical, wantRule: "prod/**"},
+		"capped":             {path: "prod/app.env", severity: SeverityCritical, wantSeverity: SeverityCritical, wantRule: "prod/**"},
+		"anchored":           {path: "vendor/lib/key.pem", severity: SeverityMedium, wantSeverity: SeverityLow, wantRule: "/vendor/**"},
+		"anchored elsewhere": {path: "app/vendor/lib/key.pem", severity: SeverityMedium, wantSeverity: SeverityMedium},
+		"partial directory":  {path: "preprod/app.env", severity: SeverityMedium, wantSeverity: SeverityMedium},
+		"no rule":            {path: "src/main.go", severity: SeverityHigh, wantSeverity: SeverityHigh},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := Result{Severity: tt.severity}
+			matched := rules.Apply(&result, tt.path)
+			if matched != (tt.wantRule != "") || result.ExtraData["path_rule"] != tt.wantRule {
+				t.Errorf("got rule %q, want %q", result.ExtraData["path_rule"], tt.wantRule)
+			}
+			if result.Severity != tt.wantSeverity {
+				t.Errorf("got severity %s, want %s", result.Severity, tt.wantSeverity)
+			}
+		})
+	}
+
+	var nilRules *PathRules
+	if nilRules.Apply(&Result{}, "prod/app.env") {
+		t.Error("nil rules shouldn't match")
+	}
+	if _, err := NewPathRules([]PathRule{{Patterns: []string{" "}}}); err == nil {
+		t.Error("expected an error for an empty pattern")
+	}
+}
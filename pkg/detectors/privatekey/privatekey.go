@@ -41,6 +41,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) ([]dete
 	matches := keyPat.FindAllString(dataStr, -1)
 
 	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
 
 		token := normalize(match)
 
@@ -60,7 +63,7 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) ([]dete
 		}
 
 		if verify {
-			data, err := lookupFingerprint(fingerprint, s.IncludeExpired)
+			data, err := lookupFingerprint(ctx, fingerprint, s.IncludeExpired)
 			if err == nil {
 				secret.StructuredData = data
 				if data != nil {
@@ -77,8 +80,8 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) ([]dete
 	return results, nil
 }
 
-func lookupFingerprint(publicKeyFingerprintInHex string, includeExpired bool) (data *detectorspb.StructuredData, err error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("https://keychecker.trufflesecurity.com/fingerprint/%s", publicKeyFingerprintInHex), nil)
+func lookupFingerprint(ctx context.Context, publicKeyFingerprintInHex string, includeExpired bool) (data *detectorspb.StructuredData, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://keychecker.trufflesecurity.com/fingerprint/%s", publicKeyFingerprintInHex), nil)
 	if err != nil {
 		return
 	}
@@ -47,83 +47,69 @@ const (
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	keyMatches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	appMatches := appIdPat.FindAllStringSubmatch(dataStr, -1)
-	secretMatches := secretPat.FindAllStringSubmatch(dataStr, -1)
+	keyMatches := detectors.FindMatches(keyPat, dataStr)
+	appMatches := detectors.FindMatches(appIdPat, dataStr)
+	secretMatches := detectors.FindMatches(secretPat, dataStr)
 
-	for _, appMatch := range appMatches {
-		if len(appMatch) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(appMatches, keyMatches, secretMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resappMatch := strings.TrimSpace(appMatch[1])
+		resappMatch := parts[0].Value
+		reskeyMatch := parts[1].Value
+		ressecretMatch := parts[2].Value
 
-		for _, keyMatch := range keyMatches {
-			if len(keyMatch) != 2 {
-				continue
-			}
-			reskeyMatch := strings.TrimSpace(keyMatch[1])
-
-			for _, secretMatch := range secretMatches {
-				if len(secretMatch) != 2 {
-					continue
-				}
-				ressecretMatch := strings.TrimSpace(secretMatch[1])
-
-				s1 := detectors.Result{
-					DetectorType: detectorspb.DetectorType_PusherChannelKey,
-					Raw:          []byte(resappMatch),
-				}
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_PusherChannelKey,
+			Raw:          []byte(resappMatch),
+		}
 
-				if verify {
+		if verify {
 
-					method := "POST"
-					path := "/apps/" + resappMatch + "/events"
+			method := "POST"
+			path := "/apps/" + resappMatch + "/events"
 
-					stringPayload := `{"channels":["my-channel"],"data":"{\"message\":\"hello world\"}","name":"my_event"}`
-					payload := strings.NewReader(stringPayload)
-					_bodyMD5 := md5.New()
-					_bodyMD5.Write([]byte(stringPayload))
-					md5 := hex.EncodeToString(_bodyMD5.Sum(nil))
+			stringPayload := `{"channels":["my-channel"],"data":"{\"message\":\"hello world\"}","name":"my_event"}`
+			payload := strings.NewReader(stringPayload)
+			_bodyMD5 := md5.New()
+			_bodyMD5.Write([]byte(stringPayload))
+			md5 := hex.EncodeToString(_bodyMD5.Sum(nil))
 
-					timestamp := strconv.FormatInt(time.Now().Unix(), 10)
-					params := url.Values{
-						"auth_key":       {reskeyMatch},
-						"auth_timestamp": {timestamp},
-						"auth_version":   {auth_version},
-						"body_md5":       {md5},
-					}
+			timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+			params := url.Values{
+				"auth_key":       {reskeyMatch},
+				"auth_timestamp": {timestamp},
+				"auth_version":   {auth_version},
+				"body_md5":       {md5},
+			}
 
-					usecd, _ := url.QueryUnescape(params.Encode())
+			usecd, _ := url.QueryUnescape(params.Encode())
 
-					stringToSign := strings.Join([]string{method, path, usecd}, "\n")
-					signature := hex.EncodeToString(hmacBytes([]byte(stringToSign), []byte(ressecretMatch)))
+			stringToSign := strings.Join([]string{method, path, usecd}, "\n")
+			signature := hex.EncodeToString(hmacBytes([]byte(stringToSign), []byte(ressecretMatch)))
 
-					md5Str := "https://api-ap1.pusher.com/apps/" + resappMatch + "/events?auth_key=" + reskeyMatch + "&auth_signature=" + signature + "&auth_timestamp=" + timestamp + "&auth_version=1.0&body_md5=" + md5
+			md5Str := "https://api-ap1.pusher.com/apps/" + resappMatch + "/events?auth_key=" + reskeyMatch + "&auth_signature=" + signature + "&auth_timestamp=" + timestamp + "&auth_version=1.0&body_md5=" + md5
 
-					req, err := http.NewRequestWithContext(ctx, method, md5Str, payload)
-					if err != nil {
+			req, err := http.NewRequestWithContext(ctx, method, md5Str, payload)
+			if err != nil {
+				continue
+			}
+			req.Header.Add("Content-Type", "application/json")
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(ressecretMatch, detectors.DefaultFalsePositives, true) {
 						continue
 					}
-					req.Header.Add("Content-Type", "application/json")
-					res, err := client.Do(req)
-					if err == nil {
-						defer res.Body.Close()
-						if res.StatusCode >= 200 && res.StatusCode < 300 {
-							s1.Verified = true
-						} else {
-							//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-							if detectors.IsKnownFalsePositive(ressecretMatch, detectors.DefaultFalsePositives, true) {
-								continue
-							}
-						}
-					}
 				}
-
-				results = append(results, s1)
 			}
-
 		}
 
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
@@ -0,0 +1,138 @@
+package pypi
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Field types of the macaroon v2 binary format. See
+// https://github.com/rescrv/libmacaroons/blob/master/doc/format.txt.
+const (
+	fieldEOS        = 0
+	fieldLocation   = 1
+	fieldIdentifier = 2
+)
+
+var errInvalidMacaroon = errors.New("invalid macaroon")
+
+// macaroon is the part of a PyPI token that says what it may be used for. PyPI tokens are "pypi-" followed by a
+// serialized macaroon, whose caveats restrict the token to projects or a time window.
+type macaroon struct {
+	location   string
+	identifier string
+	caveats    []string
+}
+
+func parseMacaroon(token string) (macaroon, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(strings.TrimPrefix(token, "pypi-"), "="))
+	if err != nil {
+		return macaroon{}, err
+	}
+	if len(data) == 0 || data[0] != 2 {
+		return macaroon{}, errInvalidMacaroon
+	}
+	r := &fieldReader{data: data[1:]}
+
+	var m macaroon
+	section, err := r.section()
+	if err != nil {
+		return macaroon{}, err
+	}
+	m.location = string(section[fieldLocation])
+	m.identifier = string(section[fieldIdentifier])
+	for {
+		section, err := r.section()
+		if err != nil {
+			return macaroon{}, err
+		}
+		if len(section) == 0 {
+			return m, nil
+		}
+		m.caveats = append(m.caveats, string(section[fieldIdentifier]))
+	}
+}
+
+// fieldReader reads the fields of a macaroon.
+type fieldReader struct {
+	data []byte
+}
+
+// section reads fields up to the next end of section marker.
+func (r *fieldReader) section() (map[uint64][]byte, error) {
+	fields := map[uint64][]byte{}
+	for {
+		fieldType, n := binary.Uvarint(r.data)
+		if n <= 0 {
+			return nil, errInvalidMacaroon
+		}
+		r.data = r.data[n:]
+		if fieldType == fieldEOS {
+			return fields, nil
+		}
+		length, n := binary.Uvarint(r.data)
+		if n <= 0 || uint64(len(r.data)-n) < length {
+			return nil, errInvalidMacaroon
+		}
+		fields[fieldType] = r.data[n : n+int(length)]
+		r.data = r.data[n+int(length):]
+	}
+}
+
+// scope describes the caveats of the token. Tokens without project caveats can upload to every project of the user.
+type scope struct {
+	projects []string
+	userID   string
+	expires  time.Time
+}
+
+// scope interprets PyPI's caveats. Older tokens use JSON objects, newer ones JSON arrays tagged by type. See
+// https://github.com/pypi/warehouse/blob/main/warehouse/macaroons/caveats/__init__.py.
+func (m macaroon) scope() scope {
+	var s scope
+	for _, caveat := range m.caveats {
+		var legacy struct {
+			Permissions json.RawMessage `json:"permissions"`
+			Expires     int64           `json:"exp"`
+		}
+		if json.Unmarshal([]byte(caveat), &legacy) == nil {
+			var permissions struct {
+				Projects []string `json:"projects"`
+			}
+			if json.Unmarshal(legacy.Permissions, &permissions) == nil {
+				s.projects = append(s.projects, permissions.Projects...)
+			}
+			if legacy.Expires != 0 {
+				s.expires = time.Unix(legacy.Expires, 0)
+			}
+			continue
+		}
+
+		var tagged []json.RawMessage
+		if json.Unmarshal([]byte(caveat), &tagged) != nil || len(tagged) < 2 {
+			continue
+		}
+		var tag int
+		if json.Unmarshal(tagged[0], &tag) != nil {
+			continue
+		}
+		switch tag {
+		case 0: // Expiration: [0, expires, not before]
+			var expires int64
+			if json.Unmarshal(tagged[1], &expires) == nil {
+				s.expires = time.Unix(expires, 0)
+			}
+		case 1: // ProjectName: [1, [names]]
+			var names []string
+			if json.Unmarshal(tagged[1], &names) == nil {
+				s.projects = append(s.projects, names...)
+			}
+		case 3: // RequestUser: [3, user ID]
+			_ = json.Unmarshal(tagged[1], &s.userID)
+		}
+	}
+	return s
+}
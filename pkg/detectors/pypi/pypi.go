@@ -0,0 +1,125 @@
+package pypi
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	client = common.SaneHttpClient()
+
+	// "Ag" is the version byte of the serialized macaroon.
+	keyPat = regexp.MustCompile(`\b(pypi-Ag[A-Za-z0-9_-]{70,})`)
+
+	// uploadURLs are the upload endpoints of the indexes tokens are issued by, by macaroon location.
+	uploadURLs = map[string]string{
+		"pypi.org":      "https://upload.pypi.org/legacy/",
+		"test.pypi.org": "https://test.pypi.org/legacy/",
+	}
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"pypi-"}
+}
+
+// FromData will find and optionally verify PyPI API tokens in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
+
+	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		if len(match) != 2 {
+			continue
+		}
+		token := match[1]
+		m, err := parseMacaroon(token)
+		if err != nil {
+			continue
+		}
+		uploadURL, ok := uploadURLs[m.location]
+		if !ok {
+			continue
+		}
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_PyPI,
+			Raw:          []byte(token),
+			Redacted:     m.identifier,
+			ExtraData:    extraData(m),
+		}
+
+		if verify {
+			s1.Verified = verifyToken(ctx, uploadURL, token)
+		}
+
+		results = append(results, s1)
+	}
+
+	return detectors.CleanResults(results), nil
+}
+
+func extraData(m macaroon) map[string]string {
+	scope := m.scope()
+	extraData := map[string]string{
+		"index":      m.location,
+		"token_id":   m.identifier,
+		"token_type": "user",
+	}
+	if len(scope.projects) > 0 {
+		extraData["token_type"] = "project"
+		extraData["projects"] = strings.Join(scope.projects, ",")
+	}
+	if scope.userID != "" {
+		extraData["user_id"] = scope.userID
+	}
+	if !scope.expires.IsZero() {
+		extraData["expires"] = scope.expires.UTC().Format(time.RFC3339)
+	}
+	return extraData
+}
+
+// verifyToken starts an upload without a distribution file. PyPI authenticates the upload before validating it, so
+// valid tokens are rejected as a bad request, and invalid ones as forbidden. Nothing is uploaded either way.
+func verifyToken(ctx context.Context, uploadURL, token string) bool {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	_ = form.WriteField(":action", "file_upload")
+	_ = form.WriteField("protocol_version", "1")
+	_ = form.WriteField("name", "trufflehog-verification")
+	if err := form.Close(); err != nil {
+		return false
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", uploadURL, &body)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.SetBasicAuth("__token__", token)
+	res, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer res.Body.Close()
+	return res.StatusCode == http.StatusBadRequest
+}
@@ -5,6 +5,7 @@ import (
 	"regexp"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
 
@@ -18,7 +19,7 @@ var _ detectors.Detector = (*Scanner)(nil)
 
 //The (`) character adds secondary encoding to parsed strings by Golang which also allows for escape sequences
 var (
-	keyPat    = regexp.MustCompile(`(?i)\brzp_\w{2,6}_\w{10,20}\b`)
+	keyPat    = regexp.MustCompile(`(?i)\b(rzp_\w{2,6}_\w{10,20})\b`)
 	secretPat = regexp.MustCompile(`(?:razor|secret|rzp|key)[-\w]*[\" :=']*([A-Za-z0-9]{20,50})`)
 )
 
@@ -32,10 +33,14 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllString(dataStr, -1)
+	matches := detectors.FindMatches(keyPat, dataStr)
+	secMatches := detectors.FindMatches(secretPat, dataStr)
 
 	for _, match := range matches {
-		token := match
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		token := match.Value
 
 		s := detectors.Result{
 			DetectorType: detectorspb.DetectorType_RazorPay,
@@ -46,16 +51,15 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		if verify {
 			//https://dashboard.razorpay.com/#/access/signin
 			//https://gitlab.com/trufflesec/trufflehog/-/blob/master/webapi/secrets/razorpay.py
-			secMatches := secretPat.FindAllStringSubmatch(dataStr, -1)
 			if len(secMatches) == 0 {
 				//no secret keys were found. Declare unverified (This is how AWS secret handles the same logic)
 				//TODO determine if key alone without secret is reportable
 				s.Verified = false
 				return
 			}
-			//we only want the secret, not its surrounding info - grabbing capture groups
-			for _, secMatch := range secMatches {
-				client := razorpay.NewClient(token, secMatch[1])
+			// The key is verified with the closest secret, rather than every secret in the chunk.
+			for _, parts := range detectors.PairMatches([]detectors.Match{match}, secMatches) {
+				client := razorpay.NewClient(token, parts[1].Value)
 				resp, err := client.Order.All(nil, nil)
 				//TODO Error handling is broken in SDK, fixed by https://github.com/razorpay/razorpay-go/pull/23
 				//waiting to be reviewed and merged
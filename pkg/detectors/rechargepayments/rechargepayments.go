@@ -38,6 +38,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	dataStr := string(data)
 
 	for _, tokenPat := range tokenPats {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
 		tokens := tokenPat.FindAllString(dataStr, -1)
 
 		for _, token := range tokens {
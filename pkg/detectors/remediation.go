@@ -0,0 +1,161 @@
+package detectors
+
+import (
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+// Remediation tells the recipient of a result how to revoke the credential.
+type Remediation struct {
+	// URL is the provider's documentation for rotating or revoking the credential.
+	URL string `json:",omitempty"`
+	// Guidance is a one line summary of what to do.
+	Guidance string `json:",omitempty"`
+}
+
+// remediations are the known rotation procedures, by detector type.
+var remediations = map[detectorspb.DetectorType]Remediation{
+	detectorspb.DetectorType_AWS: {
+		URL:      "https://docs.aws.amazon.com/IAM/latest/UserGuide/id_credentials_access-keys.html#rotating_access_keys_console",
+		Guidance: "Create a new access key for the IAM user, deploy it, then deactivate and delete the leaked key.",
+	},
+	detectorspb.DetectorType_Github: {
+		URL:      "https://docs.github.com/en/authentication/keeping-your-account-and-data-secure/token-expiration-and-revocation",
+		Guidance: "Delete the token from Settings > Developer settings, or its owner's OAuth or GitHub App authorization.",
+	},
+	detectorspb.DetectorType_Gitlab: {
+		URL:      "https://docs.gitlab.com/ee/user/profile/personal_access_tokens.html#revoke-a-personal-access-token",
+		Guidance: "Revoke the token from the owner's Access Tokens page, or rotate it with the API.",
+	},
+	detectorspb.DetectorType_Slack: {
+		URL:      "https://api.slack.com/authentication/rotation",
+		Guidance: "Revoke the token with auth.revoke, or reinstall the app to issue a new one.",
+	},
+	detectorspb.DetectorType_SlackWebhook: {
+		URL:      "https://api.slack.com/messaging/webhooks",
+		Guidance: "Remove the webhook from the app's Incoming Webhooks page and create a new one.",
+	},
+	detectorspb.DetectorType_Stripe: {
+		URL:      "https://stripe.com/docs/keys#rolling-keys",
+		Guidance: "Roll the key from the Dashboard's API keys page, choosing to expire the old key now.",
+	},
+	detectorspb.DetectorType_Twilio: {
+		URL:      "https://www.twilio.com/docs/iam/api-keys#revoking-an-api-key",
+		Guidance: "Delete the API key, or promote a secondary auth token to replace a leaked primary one.",
+	},
+	detectorspb.DetectorType_SendGrid: {
+		URL:      "https://docs.sendgrid.com/ui/account-and-settings/api-keys#delete-an-api-key",
+		Guidance: "Delete the key from Settings > API Keys and create a replacement.",
+	},
+	detectorspb.DetectorType_Mailgun: {
+		URL:      "https://help.mailgun.com/hc/en-us/articles/203380100-Where-Can-I-Find-My-API-Key-and-SMTP-Credentials",
+		Guidance: "Delete the key from the API Security page and create a replacement.",
+	},
+	detectorspb.DetectorType_GCP: {
+		URL:      "https://cloud.google.com/iam/docs/keys-disable-enable#disable",
+		Guidance: "Disable, then delete, the service account key in IAM > Service Accounts > Keys.",
+	},
+	detectorspb.DetectorType_GCPApplicationDefaultCredentials: {
+		URL:      "https://cloud.google.com/sdk/gcloud/reference/auth/application-default/revoke",
+		Guidance: "Run gcloud auth application-default revoke, or remove the app's access from the Google account.",
+	},
+	detectorspb.DetectorType_Azure: {
+		URL:      "https://learn.microsoft.com/en-us/entra/identity-platform/howto-create-service-principal-portal#set-up-authentication",
+		Guidance: "Delete the client secret from the app registration's Certificates & secrets page.",
+	},
+	detectorspb.DetectorType_Heroku: {
+		URL:      "https://devcenter.heroku.com/articles/authentication#revoking-tokens",
+		Guidance: "Run heroku authorizations:revoke, or regenerate the API key from Account settings.",
+	},
+	detectorspb.DetectorType_NpmToken: {
+		URL:      "https://docs.npmjs.com/revoking-access-tokens",
+		Guidance: "Run npm token revoke, or delete the token from the Access Tokens page.",
+	},
+	detectorspb.DetectorType_PyPI: {
+		URL:      "https://pypi.org/help/#apitoken",
+		Guidance: "Remove the token from Account settings > API tokens.",
+	},
+	detectorspb.DetectorType_RubyGems: {
+		URL:      "https://guides.rubygems.org/api-key-scopes/",
+		Guidance: "Delete the key from the rubygems.org API keys page.",
+	},
+	detectorspb.DetectorType_OpenAI: {
+		URL:      "https://help.openai.com/en/articles/9047852-how-can-i-delete-my-api-key",
+		Guidance: "Delete the key from the project's API keys page.",
+	},
+	detectorspb.DetectorType_Anthropic: {
+		URL:      "https://docs.anthropic.com/en/api/admin-api/apikeys/update-api-key",
+		Guidance: "Disable or delete the key from the Console's API keys page.",
+	},
+	detectorspb.DetectorType_HuggingFace: {
+		URL:      "https://huggingface.co/docs/hub/security-tokens",
+		Guidance: "Invalidate or delete the token from Settings > Access Tokens.",
+	},
+	detectorspb.DetectorType_CloudflareApiToken: {
+		URL:      "https://developers.cloudflare.com/fundamentals/api/how-to/roll-token/",
+		Guidance: "Roll or delete the token from My Profile > API Tokens.",
+	},
+	detectorspb.DetectorType_DigitalOceanToken: {
+		URL:      "https://docs.digitalocean.com/reference/api/create-personal-access-token/#delete-a-token",
+		Guidance: "Delete the token from API > Tokens and generate a replacement.",
+	},
+	detectorspb.DetectorType_DatadogToken: {
+		URL:      "https://docs.datadoghq.com/account_management/api-app-keys/",
+		Guidance: "Revoke the key from Organization Settings > API Keys or Application Keys.",
+	},
+	detectorspb.DetectorType_Okta: {
+		URL:      "https://help.okta.com/en-us/content/topics/security/api.htm",
+		Guidance: "Revoke the token from Security > API > Tokens.",
+	},
+	detectorspb.DetectorType_Auth0ManagementApiToken: {
+		URL:      "https://auth0.com/docs/secure/tokens/access-tokens/management-api-access-tokens",
+		Guidance: "Rotate the signing key or the client secret that issued the token; tokens can't be revoked individually.",
+	},
+	detectorspb.DetectorType_SalesforceOauth2: {
+		URL:      "https://help.salesforce.com/s/articleView?id=sf.connected_app_rotate_consumer_details.htm",
+		Guidance: "Rotate the connected app's consumer secret, and revoke sessions from Session Management.",
+	},
+	detectorspb.DetectorType_Shopify: {
+		URL:      "https://shopify.dev/docs/apps/build/authentication-authorization/access-tokens/rotate-revoke-client-credentials",
+		Guidance: "Rotate the app's client credentials, or uninstall and reinstall a custom app to revoke its token.",
+	},
+	detectorspb.DetectorType_TelegramBotToken: {
+		URL:      "https://core.telegram.org/bots/features#botfather",
+		Guidance: "Send /revoke to @BotFather and choose the bot.",
+	},
+	detectorspb.DetectorType_DiscordBotToken: {
+		URL:      "https://discord.com/developers/docs/topics/oauth2#bots",
+		Guidance: "Reset the token from the application's Bot page in the Developer Portal.",
+	},
+	detectorspb.DetectorType_Twitch: {
+		URL:      "https://dev.twitch.tv/docs/authentication/revoke-tokens/",
+		Guidance: "Revoke the token with the oauth2/revoke endpoint, or generate a new client secret in the console.",
+	},
+	detectorspb.DetectorType_Vault: {
+		URL:      "https://developer.hashicorp.com/vault/docs/commands/token/revoke",
+		Guidance: "Run vault token revoke on the token or its accessor.",
+	},
+	detectorspb.DetectorType_Consul: {
+		URL:      "https://developer.hashicorp.com/consul/commands/acl/token/delete",
+		Guidance: "Run consul acl token delete with the token's accessor ID.",
+	},
+	detectorspb.DetectorType_MapBox: {
+		URL:      "https://docs.mapbox.com/accounts/guides/tokens/#rotating-tokens",
+		Guidance: "Delete the token from the Access tokens page and create a replacement.",
+	},
+	detectorspb.DetectorType_AlgoliaAdminKey: {
+		URL:      "https://www.algolia.com/doc/guides/security/api-keys/",
+		Guidance: "Regenerate the admin key, or delete the leaked key from the API Keys page.",
+	},
+	detectorspb.DetectorType_PrivateKey: {
+		Guidance: "Remove the public key from everywhere it's trusted, and replace the key pair.",
+	},
+}
+
+// RemediationFor returns how to revoke credentials of the detector type, or nil if it isn't known.
+func RemediationFor(detectorType detectorspb.DetectorType) *Remediation {
+	remediation, ok := remediations[detectorType]
+	if !ok {
+		return nil
+	}
+	return &remediation
+}
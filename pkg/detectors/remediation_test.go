@@ -0,0 +1,31 @@
+package detectors
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+func TestRemediations(t *testing.T) {
+	for detectorType, remediation := range remediations {
+		if remediation.Guidance == "" {
+			t.Errorf("%s: missing guidance", detectorType)
+		}
+		if remediation.URL == "" {
+			continue
+		}
+		if u, err := url.Parse(remediation.URL); err != nil || u.Scheme != "https" || u.Host == "" {
+			t.Errorf("%s: invalid URL %q", detectorType, remediation.URL)
+		}
+	}
+}
+
+func TestRemediationFor(t *testing.T) {
+	if got := RemediationFor(detectorspb.DetectorType_AWS); got == nil || got.URL == "" {
+		t.Errorf("expected AWS remediation, got: %+v", got)
+	}
+	if got := RemediationFor(detectorspb.DetectorType_Abstract); got != nil {
+		t.Errorf("expected no remediation for an unlisted detector, got: %+v", got)
+	}
+}
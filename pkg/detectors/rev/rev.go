@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -35,52 +34,46 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	userMatches := userKeyPat.FindAllStringSubmatch(dataStr, -1)
-	clientMatches := clientKeyPat.FindAllStringSubmatch(dataStr, -1)
+	userMatches := detectors.FindMatches(userKeyPat, dataStr)
+	clientMatches := detectors.FindMatches(clientKeyPat, dataStr)
 
-	for _, userMatch := range userMatches {
-		if len(userMatch) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(userMatches, clientMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resUserMatch := strings.TrimSpace(userMatch[1])
+		resUserMatch := parts[0].Value
+		resClientMatch := parts[1].Value
 
-		for _, clientMatch := range clientMatches {
-			if len(clientMatch) != 2 {
-				continue
-			}
-			resClientMatch := strings.TrimSpace(clientMatch[1])
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Rev,
+			Raw:          []byte(resUserMatch),
+		}
 
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_Rev,
-				Raw:          []byte(resUserMatch),
+		if verify {
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://www.rev.com/api/v1/orders", nil)
+			if err != nil {
+				continue
 			}
+			req.Header.Add("Authorization", fmt.Sprintf("Rev %s:%s", resClientMatch, resUserMatch))
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(resUserMatch, detectors.DefaultFalsePositives, true) {
+						continue
+					}
 
-			if verify {
-				req, err := http.NewRequestWithContext(ctx, "GET", "https://www.rev.com/api/v1/orders", nil)
-				if err != nil {
-					continue
-				}
-				req.Header.Add("Authorization", fmt.Sprintf("Rev %s:%s", resClientMatch, resUserMatch))
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					} else {
-						//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-						if detectors.IsKnownFalsePositive(resUserMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
-
-						if detectors.IsKnownFalsePositive(resClientMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
+					if detectors.IsKnownFalsePositive(resClientMatch, detectors.DefaultFalsePositives, true) {
+						continue
 					}
 				}
 			}
-
-			results = append(results, s1)
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
@@ -36,51 +36,45 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	clientMatches := clientPat.FindAllStringSubmatch(dataStr, -1)
-	secretMatches := secretPat.FindAllStringSubmatch(dataStr, -1)
+	clientMatches := detectors.FindMatches(clientPat, dataStr)
+	secretMatches := detectors.FindMatches(secretPat, dataStr)
 
-	for _, clientMatch := range clientMatches {
-		if len(clientMatch) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(clientMatches, secretMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resClient := strings.TrimSpace(clientMatch[1])
+		resClient := parts[0].Value
+		resSecret := parts[1].Value
 
-		for _, secretMatch := range secretMatches {
-			if len(secretMatch) != 2 {
-				continue
-			}
-			resSecret := strings.TrimSpace(secretMatch[1])
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Roaring,
+			Raw:          []byte(resClient),
+		}
 
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_Roaring,
-				Raw:          []byte(resClient),
+		if verify {
+			data := fmt.Sprintf("%s:%s", resClient, resSecret)
+			sEnc := b64.StdEncoding.EncodeToString([]byte(data))
+			payload := strings.NewReader("grant_type=client_credentials")
+			req, err := http.NewRequestWithContext(ctx, "POST", "https://api.roaring.io/token", payload)
+			if err != nil {
+				continue
 			}
-
-			if verify {
-				data := fmt.Sprintf("%s:%s", resClient, resSecret)
-				sEnc := b64.StdEncoding.EncodeToString([]byte(data))
-				payload := strings.NewReader("grant_type=client_credentials")
-				req, err := http.NewRequestWithContext(ctx, "POST", "https://api.roaring.io/token", payload)
-				if err != nil {
-					continue
-				}
-				req.Header.Add("Authorization", fmt.Sprintf("Basic %s", sEnc))
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					} else {
-						//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-						if detectors.IsKnownFalsePositive(resSecret, detectors.DefaultFalsePositives, true) {
-							continue
-						}
+			req.Header.Add("Authorization", fmt.Sprintf("Basic %s", sEnc))
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(resSecret, detectors.DefaultFalsePositives, true) {
+						continue
 					}
 				}
 			}
-
-			results = append(results, s1)
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
@@ -4,7 +4,6 @@ import (
 	"context"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -35,59 +34,46 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	idMatches := idPat.FindAllStringSubmatch(dataStr, -1)
-	keyMatches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	secretMatches := secretPat.FindAllStringSubmatch(dataStr, -1)
+	idMatches := detectors.FindMatches(idPat, dataStr)
+	keyMatches := detectors.FindMatches(keyPat, dataStr)
+	secretMatches := detectors.FindMatches(secretPat, dataStr)
 
-	for _, idMatch := range idMatches {
-		if len(idMatch) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(keyMatches, idMatches, secretMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resId := strings.TrimSpace(idMatch[1])
+		keyMatch := parts[0].Value
+		resId := parts[1].Value
+		secretMatch := parts[2].Value
 
-		for _, match := range keyMatches {
-			if len(match) != 2 {
-				continue
-			}
-			keyMatch := strings.TrimSpace(match[1])
-
-			for _, secret := range secretMatches {
-				if len(secret) != 2 {
-					continue
-				}
-
-				secretMatch := strings.TrimSpace(secret[1])
-
-				s1 := detectors.Result{
-					DetectorType: detectorspb.DetectorType_Rownd,
-					Raw:          []byte(keyMatch),
-				}
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Rownd,
+			Raw:          []byte(keyMatch),
+		}
 
-				if verify {
+		if verify {
 
-					req, err := http.NewRequestWithContext(ctx, "GET", "https://api.rownd.io/applications/"+resId+"/users/data", nil)
-					if err != nil {
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://api.rownd.io/applications/"+resId+"/users/data", nil)
+			if err != nil {
+				continue
+			}
+			req.Header.Add("x-rownd-app-key", keyMatch)
+			req.Header.Add("x-rownd-app-secret", secretMatch)
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(keyMatch, detectors.DefaultFalsePositives, true) {
 						continue
 					}
-					req.Header.Add("x-rownd-app-key", keyMatch)
-					req.Header.Add("x-rownd-app-secret", secretMatch)
-					res, err := client.Do(req)
-					if err == nil {
-						defer res.Body.Close()
-						if res.StatusCode >= 200 && res.StatusCode < 300 {
-							s1.Verified = true
-						} else {
-							//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-							if detectors.IsKnownFalsePositive(keyMatch, detectors.DefaultFalsePositives, true) {
-								continue
-							}
-						}
-					}
 				}
-
-				results = append(results, s1)
 			}
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
@@ -2,6 +2,7 @@ package rubygems
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"regexp"
 	"strings"
@@ -36,6 +37,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
 
 	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
 		if len(match) != 2 {
 			continue
 		}
@@ -58,6 +62,17 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				defer res.Body.Close()
 				if res.StatusCode >= 200 && res.StatusCode < 300 {
 					s1.Verified = true
+					// The response lists the gems the token's owner can push new versions of.
+					var gems []struct {
+						Name string `json:"name"`
+					}
+					if err := json.NewDecoder(res.Body).Decode(&gems); err == nil && len(gems) > 0 {
+						names := make([]string, 0, len(gems))
+						for _, gem := range gems {
+							names = append(names, gem.Name)
+						}
+						s1.ExtraData = map[string]string{"gems": strings.Join(names, ",")}
+					}
 				} else {
 					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
 					if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
@@ -0,0 +1,169 @@
+package salesforce
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	client = common.SaneHttpClient()
+
+	// Session IDs and OAuth access tokens start with the org ID.
+	sessionPat  = regexp.MustCompile(`\b(00D[a-zA-Z0-9]{12,15}![a-zA-Z0-9_.]{90,110})`)
+	consumerPat = regexp.MustCompile(`\b(3MVG9[a-zA-Z0-9._]{75,90})\b`)
+	secretPat   = regexp.MustCompile(detectors.PrefixRegex([]string{"secret"}) + `\b([A-F0-9]{64})\b`)
+	instancePat = regexp.MustCompile(`\b([a-zA-Z0-9-]+(?:\.(?:sandbox|develop|scratch))?\.my\.salesforce\.com|(?:login|test)\.salesforce\.com)\b`)
+)
+
+const defaultInstance = "login.salesforce.com"
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"salesforce", "force.com", "3MVG9"}
+}
+
+// FromData will find and optionally verify Salesforce secrets in a given set of bytes.
+// Consumer secrets are paired with the closest consumer key. Both kinds are verified against the closest instance.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	instances := detectors.FindMatches(instancePat, dataStr)
+	closestInstance := func(m detectors.Match) string {
+		if len(instances) == 0 {
+			return ""
+		}
+		return strings.ToLower(detectors.PairMatches([]detectors.Match{m}, instances)[0][1].Value)
+	}
+
+	for _, match := range detectors.FindMatches(sessionPat, dataStr) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		token := match.Value
+		orgID := token[:strings.Index(token, "!")]
+		instance := closestInstance(match)
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_SalesforceOauth2,
+			Raw:          []byte(token),
+			Redacted:     orgID,
+			ExtraData:    map[string]string{"token_type": "session", "org_id": orgID},
+		}
+		if instance != "" {
+			s1.ExtraData["instance"] = instance
+		}
+
+		if verify {
+			if instance == "" {
+				instance = defaultInstance
+			}
+			if user, ok := userInfo(ctx, instance, token); ok {
+				s1.Verified = true
+				s1.ExtraData["username"] = user.Username
+				s1.ExtraData["user_id"] = user.UserID
+			}
+		}
+
+		// Org IDs are zero padded, so sessions would always look like false positives.
+		results = append(results, s1)
+	}
+
+	for _, parts := range detectors.PairMatches(detectors.FindMatches(secretPat, dataStr), detectors.FindMatches(consumerPat, dataStr)) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		secret, consumerKey := parts[0].Value, parts[1].Value
+		instance := closestInstance(parts[0])
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_SalesforceOauth2,
+			Raw:          []byte(secret),
+			Redacted:     consumerKey,
+			ExtraData:    map[string]string{"token_type": "client_secret", "client_id": consumerKey},
+		}
+
+		// The client credentials flow is only served by an org's own My Domain.
+		if instance != "" {
+			s1.ExtraData["instance"] = instance
+			if verify {
+				s1.Verified = verifyClient(ctx, instance, consumerKey, secret)
+			}
+		}
+
+		if !s1.Verified && detectors.IsKnownFalsePositive(secret, detectors.DefaultFalsePositives, true) {
+			continue
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+type user struct {
+	Username string `json:"preferred_username"`
+	UserID   string `json:"user_id"`
+}
+
+// userInfo verifies a session by reading the user it belongs to.
+func userInfo(ctx context.Context, instance, token string) (user, bool) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://"+instance+"/services/oauth2/userinfo", nil)
+	if err != nil {
+		return user{}, false
+	}
+	req.Header.Add("Authorization", "Bearer "+token)
+	res, err := client.Do(req)
+	if err != nil {
+		return user{}, false
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return user{}, false
+	}
+	var u user
+	_ = json.NewDecoder(res.Body).Decode(&u)
+	return u, true
+}
+
+// verifyClient checks a consumer key and secret with the client credentials flow. Apps that don't allow the flow
+// still check the secret before refusing the grant, so only a bad client or secret means it isn't verified.
+func verifyClient(ctx context.Context, instance, consumerKey, secret string) bool {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", consumerKey)
+	form.Set("client_secret", secret)
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://"+instance+"/services/oauth2/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return false
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	res, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusOK {
+		return true
+	}
+	var body struct {
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return false
+	}
+	return res.StatusCode == http.StatusBadRequest && body.Error != "" && body.Error != "invalid_client_id" && body.Error != "invalid_client"
+}
@@ -0,0 +1,82 @@
+package salesforce
+
+import (
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
+)
+
+func TestSalesforce_FromData(t *testing.T) {
+	const (
+		session     = "00D5e000000AbCd!AREqV8ib8HDy88YtDtXbiufMdI8X2Y4rUmer.BH3M1XS0DRdJuPnBIKpi99lSi0tcL21pffWQJEeNajnez0LHtfROUrWW6Xnx"
+		consumerKey = "3MVG9I3EM3HMRb1OcWrhQ7TTJ.chcVG6MOwUxOVHMWndqNCIEPx3mnPQC4vkRB5ICpeyOxJRkSq1LI7S1L10eQ"
+		secret      = "7E0A971B8341C3D1BB07BA9DF31A7BD4E29B510DDF12EA96B2127B94ABEBDBC9"
+		instance    = "acme.my.salesforce.com"
+	)
+
+	detectortest.Test(t, Scanner{},
+		detectortest.Fixture{
+			Name:   "valid session",
+			Data:   "SF_INSTANCE_URL=https://" + instance + "\nSF_ACCESS_TOKEN=" + session,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   instance,
+				Path:   "/services/oauth2/userinfo",
+				Header: map[string]string{"Authorization": "Bearer " + session},
+				Status: 200,
+				Body:   `{"user_id": "0055e000001XyZaAAK", "organization_id": "00D5e000000AbCdEAK", "preferred_username": "integration@acme.com"}`,
+			}},
+			Want: []detectortest.Want{{
+				Raw:      session,
+				Verified: true,
+				ExtraData: map[string]string{
+					"token_type": "session",
+					"org_id":     "00D5e000000AbCd",
+					"instance":   instance,
+					"username":   "integration@acme.com",
+					"user_id":    "0055e000001XyZaAAK",
+				},
+			}},
+		},
+		detectortest.Fixture{
+			Name:   "expired session without an instance",
+			Data:   "salesforce sid: " + session,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   defaultInstance,
+				Path:   "/services/oauth2/userinfo",
+				Status: 403,
+				Body:   "Bad_OAuth_Token",
+			}},
+			Want: []detectortest.Want{{Raw: session, ExtraData: map[string]string{"org_id": "00D5e000000AbCd"}}},
+		},
+		detectortest.Fixture{
+			Name:   "connected app without the client credentials flow",
+			Data:   "instance: " + instance + "\nconsumer_key: " + consumerKey + "\nconsumer_secret: " + secret,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   instance,
+				Method: "POST",
+				Path:   "/services/oauth2/token",
+				Status: 400,
+				Body:   `{"error": "invalid_grant", "error_description": "no client credentials user enabled"}`,
+			}},
+			Want: []detectortest.Want{{
+				Raw:       secret,
+				Verified:  true,
+				ExtraData: map[string]string{"token_type": "client_secret", "client_id": consumerKey, "instance": instance},
+			}},
+		},
+		detectortest.Fixture{
+			Name:   "wrong consumer secret",
+			Data:   "instance: " + instance + "\nconsumer_key: " + consumerKey + "\nconsumer_secret: " + secret,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   instance,
+				Status: 400,
+				Body:   `{"error": "invalid_client", "error_description": "invalid client credentials"}`,
+			}},
+			Want: []detectortest.Want{{Raw: secret, ExtraData: map[string]string{"client_id": consumerKey}}},
+		},
+	)
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -37,61 +36,48 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	emailmatches := emailPat.FindAllStringSubmatch(dataStr, -1)
-	passmatches := passPat.FindAllStringSubmatch(dataStr, -1)
+	matches := detectors.FindMatches(keyPat, dataStr)
+	emailmatches := detectors.FindMatches(emailPat, dataStr)
+	passmatches := detectors.FindMatches(passPat, dataStr)
 
-	for _, match := range matches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(matches, emailmatches, passmatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resMatch := strings.TrimSpace(match[1])
+		resMatch := parts[0].Value
+		resEmailMatch := parts[1].Value
+		resPassMatch := parts[2].Value
 
-		for _, emailmatch := range emailmatches {
-			if len(emailmatch) != 2 {
-				continue
-			}
-			resEmailMatch := strings.TrimSpace(emailmatch[1])
-
-			for _, passmatch := range passmatches {
-				if len(passmatch) != 2 {
-					continue
-				}
-				resPassMatch := strings.TrimSpace(passmatch[1])
-
-				s1 := detectors.Result{
-					DetectorType: detectorspb.DetectorType_SatismeterProjectkey,
-					Raw:          []byte(resMatch),
-				}
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_SatismeterProjectkey,
+			Raw:          []byte(resMatch),
+		}
 
-				if verify {
+		if verify {
 
-					data := fmt.Sprintf("%s:%s", resEmailMatch, resPassMatch)
-					sEnc := b64.StdEncoding.EncodeToString([]byte(data))
+			data := fmt.Sprintf("%s:%s", resEmailMatch, resPassMatch)
+			sEnc := b64.StdEncoding.EncodeToString([]byte(data))
 
-					req, err := http.NewRequestWithContext(ctx, "GET", "https://app.satismeter.com/api/users?project="+resMatch, nil)
-					if err != nil {
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://app.satismeter.com/api/users?project="+resMatch, nil)
+			if err != nil {
+				continue
+			}
+			req.Header.Add("Authorization", fmt.Sprintf("Basic %s", sEnc))
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
 						continue
 					}
-					req.Header.Add("Authorization", fmt.Sprintf("Basic %s", sEnc))
-					res, err := client.Do(req)
-					if err == nil {
-						defer res.Body.Close()
-						if res.StatusCode >= 200 && res.StatusCode < 300 {
-							s1.Verified = true
-						} else {
-							//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-							if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
-								continue
-							}
-						}
-					}
 				}
-
-				results = append(results, s1)
 			}
 		}
 
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -36,53 +35,45 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	idMatches := idPat.FindAllStringSubmatch(dataStr, -1)
-	keyMatches := keyPat.FindAllStringSubmatch(dataStr, -1)
+	idMatches := detectors.FindMatches(idPat, dataStr)
+	keyMatches := detectors.FindMatches(keyPat, dataStr)
 
-	for _, match := range idMatches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(idMatches, keyMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
+		idMatch := parts[0].Value
+		keyMatch := parts[1].Value
 
-		idMatch := strings.TrimSpace(match[1])
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_SauceLabs,
+			Raw:          []byte(idMatch),
+		}
 
-		for _, secret := range keyMatches {
-			if len(secret) != 2 {
+		if verify {
+			data := fmt.Sprintf("%s:%s", idMatch, keyMatch)
+			encoded := b64.StdEncoding.EncodeToString([]byte(data))
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://api.eu-central-1.saucelabs.com/team-management/v1/teams", nil)
+			if err != nil {
 				continue
 			}
-
-			keyMatch := strings.TrimSpace(secret[1])
-
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_SauceLabs,
-				Raw:          []byte(idMatch),
-			}
-
-			if verify {
-				data := fmt.Sprintf("%s:%s", idMatch, keyMatch)
-				encoded := b64.StdEncoding.EncodeToString([]byte(data))
-				req, err := http.NewRequestWithContext(ctx, "GET", "https://api.eu-central-1.saucelabs.com/team-management/v1/teams", nil)
-				if err != nil {
-					continue
-				}
-				req.Header.Add("Authorization", fmt.Sprintf("Basic %s", encoded))
-				//req.SetBasicAuth(idMatch, keyMatch)
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					} else {
-						//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-						if detectors.IsKnownFalsePositive(keyMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
+			req.Header.Add("Authorization", fmt.Sprintf("Basic %s", encoded))
+			//req.SetBasicAuth(idMatch, keyMatch)
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(keyMatch, detectors.DefaultFalsePositives, true) {
+						continue
 					}
 				}
 			}
-
-			results = append(results, s1)
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
@@ -0,0 +1,201 @@
+// Package scaffold generates the package of a new detector from a name and the regex of its secrets, with the standard
+// structure: keywords, the pattern, a verification stub, and tests. It backs `trufflehog generate detector`.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+// namePat matches DetectorType enum names, such as AcmeCloud.
+var namePat = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// Options describe the detector to generate.
+type Options struct {
+	// Name is the detector's DetectorType enum name, such as AcmeCloud. The package is named after it in lower case.
+	Name string
+	// KeyRegex matches the secret. Its only capture group, if it has one, is the secret; otherwise the whole match is.
+	KeyRegex string
+	// Keywords pre-filter chunks. They default to the package name.
+	Keywords []string
+	// VerifyURL is requested with the secret as a bearer token to verify it. If it's empty, the verification request is
+	// a stub to fill in.
+	VerifyURL string
+	// Example, if set, is a secret KeyRegex matches, used to generate tests that run without live credentials.
+	Example string
+}
+
+// File is a generated file, named relative to the detectors directory.
+type File struct {
+	Path string
+	Data []byte
+}
+
+type templateData struct {
+	Options
+	Package     string
+	Upper       string
+	Pattern     string
+	KeywordList string
+	ExampleData string
+	VerifyHost  string
+}
+
+// Generate returns the detector's source and test files, formatted with gofmt.
+func Generate(opts Options) ([]File, error) {
+	if !namePat.MatchString(opts.Name) {
+		return nil, fmt.Errorf("invalid detector name %q, expected a DetectorType enum name such as AcmeCloud", opts.Name)
+	}
+	data := templateData{
+		Options: opts,
+		Package: PackageName(opts.Name),
+		Upper:   strings.ToUpper(opts.Name),
+	}
+	data.Keywords = append([]string(nil), opts.Keywords...)
+	if len(data.Keywords) == 0 {
+		data.Keywords = []string{data.Package}
+	}
+	for i, kw := range data.Keywords {
+		data.Keywords[i] = strings.ToLower(strings.TrimSpace(kw))
+		if data.Keywords[i] == "" {
+			return nil, fmt.Errorf("empty keyword")
+		}
+	}
+	quoted := make([]string, len(data.Keywords))
+	for i, kw := range data.Keywords {
+		quoted[i] = strconv.Quote(kw)
+	}
+	data.KeywordList = strings.Join(quoted, ", ")
+
+	keyRegex, prefixed, err := keyPattern(opts.KeyRegex, data.Keywords)
+	if err != nil {
+		return nil, err
+	}
+	pattern := keyRegex
+	data.Pattern = goString(keyRegex)
+	if prefixed {
+		pattern = detectors.PrefixRegex(data.Keywords) + keyRegex
+		data.Pattern = "detectors.PrefixRegex([]string{" + data.KeywordList + "}) + " + data.Pattern
+	}
+
+	data.VerifyHost = "api.example.com"
+	if opts.VerifyURL != "" {
+		u, err := url.Parse(opts.VerifyURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return nil, fmt.Errorf("invalid verification URL %q", opts.VerifyURL)
+		}
+		data.VerifyHost = u.Hostname()
+	}
+
+	if opts.Example != "" {
+		// The keyword leads the example, as it would in a config file, so that the chunk passes the keyword filter.
+		data.ExampleData = data.Keywords[0] + "_key = "
+		match := regexp.MustCompile(pattern).FindStringSubmatch(data.ExampleData + opts.Example)
+		if len(match) != 2 || strings.TrimSpace(match[1]) != opts.Example {
+			return nil, fmt.Errorf("the key regex doesn't match the example %q", opts.Example)
+		}
+		if detectors.IsKnownFalsePositive(opts.Example, detectors.DefaultFalsePositives, true) {
+			return nil, fmt.Errorf("the example %q looks like a false positive, so unverified results wouldn't be reported; use a random looking secret with a digit", opts.Example)
+		}
+	}
+
+	var files []File
+	for _, f := range []struct{ template, name string }{
+		{"detector.go.tmpl", data.Package + ".go"},
+		{"detector_test.go.tmpl", data.Package + "_test.go"},
+	} {
+		var buf bytes.Buffer
+		if err := templates.ExecuteTemplate(&buf, f.template, data); err != nil {
+			return nil, err
+		}
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("generated invalid %s: %w", f.name, err)
+		}
+		files = append(files, File{Path: filepath.Join(data.Package, f.name), Data: src})
+	}
+	return files, nil
+}
+
+// PackageName returns the package name of the detector with the DetectorType enum name, such as acmecloud for
+// AcmeCloud.
+func PackageName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// keyPattern returns the key regex with its secret in the only capture group, and whether it must be prefixed with the
+// keywords. Secrets that don't contain a keyword themselves are only matched shortly after one, like those of the
+// built-in detectors.
+func keyPattern(keyRegex string, keywords []string) (string, bool, error) {
+	if keyRegex == "" {
+		return "", false, fmt.Errorf("missing key regex")
+	}
+	re, err := regexp.Compile(keyRegex)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid key regex: %w", err)
+	}
+	switch re.NumSubexp() {
+	case 0:
+		keyRegex = "(" + keyRegex + ")"
+	case 1:
+	default:
+		return "", false, fmt.Errorf("the key regex has %d capture groups, expected at most one for the secret; use (?:...) for the others", re.NumSubexp())
+	}
+
+	lower := strings.ToLower(keyRegex)
+	for _, kw := range keywords {
+		if strings.Contains(lower, kw) {
+			return keyRegex, false, nil
+		}
+	}
+	return keyRegex, true, nil
+}
+
+// goString returns s as a Go string literal, raw unless it contains a backquote.
+func goString(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
+// Write generates the detector into a new directory of detectorsDir, such as pkg/detectors, and returns the paths of
+// the files it wrote. It fails if the detector's directory exists.
+func Write(detectorsDir string, opts Options) ([]string, error) {
+	files, err := Generate(opts)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(detectorsDir, filepath.Dir(files[0].Path))
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("%s already exists", dir)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, f := range files {
+		path := filepath.Join(detectorsDir, f.Path)
+		if err := os.WriteFile(path, f.Data, 0644); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
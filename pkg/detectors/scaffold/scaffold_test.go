@@ -0,0 +1,93 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	files, err := Generate(Options{
+		Name:      "AcmeCloud",
+		KeyRegex:  `\b[a-f0-9]{32}\b`,
+		VerifyURL: "https://api.acmecloud.io/v1/me",
+		Example:   "9f8a7b6c5d4e3f2109f8a7b6c5d4e3f2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || files[0].Path != filepath.Join("acmecloud", "acmecloud.go") || files[1].Path != filepath.Join("acmecloud", "acmecloud_test.go") {
+		t.Fatalf("unexpected files %v", files)
+	}
+	src, test := string(files[0].Data), string(files[1].Data)
+	for _, want := range []string{
+		"package acmecloud",
+		`keyPat = regexp.MustCompile(detectors.PrefixRegex([]string{"acmecloud"}) + ` + "`" + `(\b[a-f0-9]{32}\b)` + "`)",
+		`return []string{"acmecloud"}`,
+		"detectorspb.DetectorType_AcmeCloud",
+		`"https://api.acmecloud.io/v1/me"`,
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("expected the detector to contain %s, got:\n%s", want, src)
+		}
+	}
+	for _, want := range []string{
+		"func TestAcmeCloud_FromChunk(t *testing.T)",
+		`testSecrets.MustGetField("ACMECLOUD_INACTIVE")`,
+		`const secret = "9f8a7b6c5d4e3f2109f8a7b6c5d4e3f2"`,
+		`Mocks:  []detectortest.Mock{{Host: "api.acmecloud.io", Status: 401}}`,
+	} {
+		if !strings.Contains(test, want) {
+			t.Errorf("expected the test to contain %s, got:\n%s", want, test)
+		}
+	}
+
+	// Secrets that contain a keyword aren't prefixed with one, and the verification is a stub without a URL.
+	files, err = Generate(Options{Name: "Acme_Tokens", KeyRegex: "acme_`?([a-z0-9]{20})", Keywords: []string{" ACME_ "}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	src = string(files[0].Data)
+	if !strings.Contains(src, `regexp.MustCompile("acme_`+"`"+`?([a-z0-9]{20})")`) || !strings.Contains(src, "// TODO:") ||
+		!strings.Contains(src, `[]string{"acme_"}`) || files[0].Path != filepath.Join("acmetokens", "acmetokens.go") {
+		t.Errorf("unexpected detector:\n%s", src)
+	}
+	if strings.Contains(string(files[1].Data), "detectortest") {
+		t.Errorf("expected no fixtures without an example")
+	}
+}
+
+func TestGenerate_Invalid(t *testing.T) {
+	for name, opts := range map[string]Options{
+		"name":        {Name: "acme-cloud", KeyRegex: "x"},
+		"no regex":    {Name: "Acme"},
+		"bad regex":   {Name: "Acme", KeyRegex: "("},
+		"groups":      {Name: "Acme", KeyRegex: "(a)(b)"},
+		"url":         {Name: "Acme", KeyRegex: "x", VerifyURL: "api.acme.io"},
+		"mismatch":    {Name: "Acme", KeyRegex: "[0-9]{8}", Example: "abcdefgh"},
+		"false match": {Name: "Acme", KeyRegex: "[a-z]{8}", Example: "password"},
+	} {
+		if _, err := Generate(opts); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	opts := Options{Name: "Acme", KeyRegex: "[a-z0-9]{24}"}
+	paths, err := Write(dir, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 2 || paths[0] != filepath.Join(dir, "acme", "acme.go") {
+		t.Fatalf("unexpected paths %v", paths)
+	}
+	if _, err := os.Stat(paths[1]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Write(dir, opts); err == nil {
+		t.Error("expected an existing detector not to be overwritten")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -34,54 +33,46 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	tokenMatches := tokenPat.FindAllStringSubmatch(dataStr, -1)
-	keyMatches := keyPat.FindAllStringSubmatch(dataStr, -1)
+	tokenMatches := detectors.FindMatches(tokenPat, dataStr)
+	keyMatches := detectors.FindMatches(keyPat, dataStr)
 
-	for _, match := range tokenMatches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(tokenMatches, keyMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
+		tokenMatch := parts[0].Value
+		keyMatch := parts[1].Value
 
-		tokenMatch := strings.TrimSpace(match[1])
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Sentiment,
+			Raw:          []byte(tokenMatch),
+		}
 
-		for _, secret := range keyMatches {
-			if len(secret) != 2 {
+		if verify {
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://api.sentimentinvestor.com/v4/parsed?symbol=AAPL&token="+tokenMatch+"&key="+keyMatch, nil)
+			if err != nil {
 				continue
 			}
 
-			keyMatch := strings.TrimSpace(secret[1])
-
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_Sentiment,
-				Raw:          []byte(tokenMatch),
-			}
-
-			if verify {
-				req, err := http.NewRequestWithContext(ctx, "GET", "https://api.sentimentinvestor.com/v4/parsed?symbol=AAPL&token="+tokenMatch+"&key="+keyMatch, nil)
-				if err != nil {
-					continue
-				}
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
+					if detectors.IsKnownFalsePositive(tokenMatch, detectors.DefaultFalsePositives, true) {
+						continue
+					}
 
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					} else {
-						//This function will check false positives for common test words, but also it will make sure the key appears 'random' enough to be a real key
-						if detectors.IsKnownFalsePositive(tokenMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
-
-						if detectors.IsKnownFalsePositive(keyMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
+					if detectors.IsKnownFalsePositive(keyMatch, detectors.DefaultFalsePositives, true) {
+						continue
 					}
 				}
 			}
-
-			results = append(results, s1)
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
@@ -0,0 +1,82 @@
+package detectors
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+// Severity ranks how urgently a result should be addressed.
+type Severity int
+
+const (
+	// SeverityUnknown is the zero value, used when a detector doesn't set a severity. See DefaultSeverity.
+	SeverityUnknown Severity = iota
+	SeverityLow
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+var severityNames = map[Severity]string{
+	SeverityUnknown:  "unknown",
+	SeverityLow:      "low",
+	SeverityMedium:   "medium",
+	SeverityHigh:     "high",
+	SeverityCritical: "critical",
+}
+
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// MarshalText encodes the severity by name, so it reads naturally in JSON output.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText parses a severity name.
+func (s *Severity) UnmarshalText(text []byte) error {
+	severity, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = severity
+	return nil
+}
+
+// ParseSeverity parses a severity name such as "high".
+func ParseSeverity(name string) (Severity, error) {
+	for severity, n := range severityNames {
+		if strings.EqualFold(n, name) {
+			return severity, nil
+		}
+	}
+	return SeverityUnknown, fmt.Errorf("unknown severity %q, expected low, medium, high, or critical", name)
+}
+
+// DefaultSeverity is the severity of results from detectors that don't set one. Verified secrets are live
+// credentials, so they rank high, while unverified results are often test data or already rotated.
+func DefaultSeverity(verified bool) Severity {
+	if verified {
+		return SeverityHigh
+	}
+	return SeverityLow
+}
+
+// ParseDetectorType parses a detector type name, such as "aws" or "AWS", case insensitively.
+func ParseDetectorType(name string) (detectorspb.DetectorType, error) {
+	if dt, ok := detectorspb.DetectorType_value[name]; ok {
+		return detectorspb.DetectorType(dt), nil
+	}
+	for n, dt := range detectorspb.DetectorType_value {
+		if strings.EqualFold(n, name) {
+			return detectorspb.DetectorType(dt), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown detector type %q", name)
+}
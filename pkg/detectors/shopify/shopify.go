@@ -0,0 +1,141 @@
+package shopify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	client = common.SaneHttpClient()
+
+	// shpat_ are custom app tokens, shpca_ custom app tokens made in the admin, and shppa_ private app passwords.
+	tokenPat = regexp.MustCompile(`\b(shp(?:at|ca|pa)_[a-fA-F0-9]{32})\b`)
+	// App shared secrets only sign requests, so they can't be verified.
+	secretPat = regexp.MustCompile(`\b(shpss_[a-fA-F0-9]{32})\b`)
+	shopPat   = regexp.MustCompile(`\b([a-zA-Z0-9][a-zA-Z0-9-]*\.myshopify\.com)\b`)
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"shpat_", "shpca_", "shppa_", "shpss_"}
+}
+
+// FromData will find and optionally verify Shopify secrets in a given set of bytes.
+// Access tokens only work for the shop that installed the app, so they're verified against the closest shop domain.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	shops := detectors.FindMatches(shopPat, dataStr)
+
+	for _, match := range detectors.FindMatches(tokenPat, dataStr) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		token := match.Value
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Shopify,
+			Raw:          []byte(token),
+			ExtraData:    map[string]string{"token_type": "access_token"},
+		}
+
+		if len(shops) > 0 {
+			shop := strings.ToLower(detectors.PairMatches([]detectors.Match{match}, shops)[0][1].Value)
+			s1.Redacted = shop
+			s1.ExtraData["shop"] = shop
+
+			if verify {
+				if info, ok := shopInfo(ctx, shop, token); ok {
+					s1.Verified = true
+					s1.ExtraData["shop_name"] = info.Name
+					s1.ExtraData["plan"] = info.PlanName
+					if scopes := accessScopes(ctx, shop, token); len(scopes) > 0 {
+						s1.ExtraData["scopes"] = strings.Join(scopes, ",")
+					}
+				}
+			}
+		}
+
+		if !s1.Verified && detectors.IsKnownFalsePositive(token, detectors.DefaultFalsePositives, true) {
+			continue
+		}
+
+		results = append(results, s1)
+	}
+
+	for _, match := range secretPat.FindAllStringSubmatch(dataStr, -1) {
+		if detectors.IsKnownFalsePositive(match[1], detectors.DefaultFalsePositives, true) {
+			continue
+		}
+		results = append(results, detectors.Result{
+			DetectorType: detectorspb.DetectorType_Shopify,
+			Raw:          []byte(match[1]),
+			ExtraData:    map[string]string{"token_type": "shared_secret"},
+		})
+	}
+
+	return results, nil
+}
+
+type shop struct {
+	Name     string `json:"name"`
+	PlanName string `json:"plan_name"`
+}
+
+// shopInfo verifies a token by reading the shop it was issued for.
+func shopInfo(ctx context.Context, domain, token string) (shop, bool) {
+	var body struct {
+		Shop shop `json:"shop"`
+	}
+	if !get(ctx, domain, token, "/admin/api/2024-01/shop.json", &body) {
+		return shop{}, false
+	}
+	return body.Shop, true
+}
+
+// accessScopes returns what the token was granted when the app was installed.
+func accessScopes(ctx context.Context, domain, token string) []string {
+	var body struct {
+		AccessScopes []struct {
+			Handle string `json:"handle"`
+		} `json:"access_scopes"`
+	}
+	if !get(ctx, domain, token, "/admin/oauth/access_scopes.json", &body) {
+		return nil
+	}
+	scopes := make([]string, 0, len(body.AccessScopes))
+	for _, scope := range body.AccessScopes {
+		scopes = append(scopes, scope.Handle)
+	}
+	return scopes
+}
+
+func get(ctx context.Context, domain, token, path string, v interface{}) bool {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://"+domain+path, nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Add("X-Shopify-Access-Token", token)
+	res, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return false
+	}
+	return json.NewDecoder(res.Body).Decode(v) == nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
@@ -37,6 +38,36 @@ type authRes struct {
 	UserID string `json:"user_id"`
 	BotID  string `json:"bot_id"`
 	Error  string `json:"error"`
+
+	EnterpriseID        string `json:"enterprise_id"`
+	IsEnterpriseInstall bool   `json:"is_enterprise_install"`
+}
+
+// extraData describes the workspace and identity a token acts as, and the scopes it was granted, so the impact of a
+// leak is known without querying Slack again.
+func (r authRes) extraData(tokenType, scopes string) map[string]string {
+	extra := map[string]string{
+		"token_type":   tokenType,
+		"workspace":    r.Team,
+		"workspace_id": r.TeamID,
+		"url":          r.URL,
+		"user":         r.User,
+		"user_id":      r.UserID,
+	}
+	if r.BotID != "" {
+		extra["bot_id"] = r.BotID
+	}
+	if r.EnterpriseID != "" {
+		extra["enterprise_id"] = r.EnterpriseID
+	}
+	if r.IsEnterpriseInstall {
+		extra["is_enterprise_install"] = "true"
+	}
+	if scopes != "" {
+		// Slack separates scopes with commas, which may be followed by spaces.
+		extra["scopes"] = strings.ReplaceAll(scopes, " ", "")
+	}
+	return extra
 }
 
 // Keywords are used for efficiently pre-filtering chunks.
@@ -49,7 +80,10 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	for _, tokenPat := range tokenPats {
+	for tokenType, tokenPat := range tokenPats {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
 		tokens := tokenPat.FindAllString(dataStr, -1)
 
 		for _, token := range tokens {
@@ -71,6 +105,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 					var authResponse authRes
 					json.NewDecoder(res.Body).Decode(&authResponse)
 					s.Verified = authResponse.Ok
+					if s.Verified {
+						s.ExtraData = authResponse.extraData(tokenType, res.Header.Get("X-OAuth-Scopes"))
+					}
 				}
 			}
 
@@ -0,0 +1,43 @@
+package detectors
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Snippet returns the line of data containing the first occurrence of secret, with up to lines lines before and after
+// it. It returns an empty string if the secret isn't in the data, as happens when a detector reports an ID or joins
+// several parts into one raw value.
+func Snippet(data, secret []byte, lines int) string {
+	if len(secret) == 0 {
+		return ""
+	}
+	idx := bytes.Index(data, secret)
+	if idx < 0 {
+		return ""
+	}
+
+	// Walk back to the start of the secret's line, then one line further for each line of context.
+	start := bytes.LastIndexByte(data[:idx], '\n') + 1
+	for i := 0; i < lines && start > 0; i++ {
+		start = bytes.LastIndexByte(data[:start-1], '\n') + 1
+	}
+
+	// Walk forward to the end of the secret's line, then one line further for each line of context.
+	end := idx + len(secret)
+	for i := 0; i <= lines && end < len(data); i++ {
+		from := end
+		if i > 0 {
+			// Skip the newline ending the previous line.
+			from++
+		}
+		next := bytes.IndexByte(data[from:], '\n')
+		if next < 0 {
+			end = len(data)
+			break
+		}
+		end = from + next
+	}
+
+	return strings.TrimRight(strings.ReplaceAll(string(data[start:end]), "\r\n", "\n"), "\r\n")
+}
@@ -0,0 +1,26 @@
+package detectors
+
+import "testing"
+
+func TestSnippet(t *testing.T) {
+	data := []byte("one\r\ntwo\r\nkey = secret123\r\nfour\r\nfive\r\n")
+	tests := map[string]struct {
+		data   []byte
+		secret string
+		lines  int
+		want   string
+	}{
+		"line only":       {data: data, secret: "secret123", lines: 0, want: "key = secret123"},
+		"one line around": {data: data, secret: "secret123", lines: 1, want: "two\nkey = secret123\nfour"},
+		"past the ends":   {data: data, secret: "secret123", lines: 5, want: "one\ntwo\nkey = secret123\nfour\nfive"},
+		"first line":      {data: []byte("secret123\nnext"), secret: "secret123", lines: 1, want: "secret123\nnext"},
+		"last line":       {data: []byte("prev\nsecret123"), secret: "secret123", lines: 1, want: "prev\nsecret123"},
+		"not found":       {data: data, secret: "other", lines: 1, want: ""},
+		"empty secret":    {data: data, secret: "", lines: 1, want: ""},
+	}
+	for name, test := range tests {
+		if got := Snippet(test.data, []byte(test.secret), test.lines); got != test.want {
+			t.Errorf("%s: unexpected snippet. Got: %q, Expected: %q", name, got, test.want)
+		}
+	}
+}
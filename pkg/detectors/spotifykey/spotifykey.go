@@ -5,7 +5,6 @@ import (
 	"golang.org/x/oauth2"
 
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -38,41 +37,36 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 
 	dataStr := string(data)
 
-	matches := secretPat.FindAllStringSubmatch(dataStr, -1)
-	idMatches := idPat.FindAllStringSubmatch(dataStr, -1)
+	matches := detectors.FindMatches(secretPat, dataStr)
+	idMatches := detectors.FindMatches(idPat, dataStr)
 
-	for _, match := range matches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(matches, idMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resMatch := strings.TrimSpace(match[1])
-		for _, idMatch := range idMatches {
-			if len(idMatch) != 2 {
-				continue
-			}
-			idresMatch := strings.TrimSpace(idMatch[1])
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_SpotifyKey,
-				Raw:          []byte(resMatch),
-			}
+		resMatch := parts[0].Value
+		idresMatch := parts[1].Value
 
-			if verify {
-				config := &clientcredentials.Config{
-					ClientID:     idresMatch,
-					ClientSecret: resMatch,
-					TokenURL:     "https://accounts.spotify.com/api/token",
-				}
-				token, err := config.Token(ctx)
-				if err == nil {
-					if token.Type() == "Bearer" {
-						s1.Verified = true
-					}
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_SpotifyKey,
+			Raw:          []byte(resMatch),
+		}
+
+		if verify {
+			config := &clientcredentials.Config{
+				ClientID:     idresMatch,
+				ClientSecret: resMatch,
+				TokenURL:     "https://accounts.spotify.com/api/token",
+			}
+			token, err := config.Token(ctx)
+			if err == nil {
+				if token.Type() == "Bearer" {
+					s1.Verified = true
 				}
 			}
-
-			results = append(results, s1)
 		}
 
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
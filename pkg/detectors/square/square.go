@@ -40,6 +40,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 
 	secMatches := secretPat.FindAllStringSubmatch(dataStr, -1)
 	for _, secMatch := range secMatches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
 		if len(secMatch) != 2 {
 			continue
 		}
@@ -17,14 +17,34 @@ type Scanner struct{}
 var _ detectors.Detector = (*Scanner)(nil)
 
 var (
-	//doesnt include test keys with "sk_test"
-	secretKey = regexp.MustCompile(`[rs]k_live_[a-zA-Z0-9]{20,30}`)
+	// Secret (sk) and restricted (rk) keys for live and test mode. Publishable (pk) keys are meant to be public.
+	secretKey = regexp.MustCompile(`([rs])k_(live|test)_[a-zA-Z0-9]{20,30}`)
 )
 
 // Keywords are used for efficiently pre-filtering chunks.
 // Use identifiers in the secret preferably, or the provider name.
 func (s Scanner) Keywords() []string {
-	return []string{"k_live"}
+	return []string{"k_live", "k_test"}
+}
+
+// classify describes a key by its prefix. Test mode keys can't move money or read live data, so they're low severity
+// whether or not they verify. Live restricted keys are limited to the permissions they were created with.
+func classify(prefix, mode string) (extraData map[string]string, severity detectors.Severity) {
+	keyType := "secret"
+	if prefix == "r" {
+		keyType = "restricted"
+	}
+	extraData = map[string]string{"mode": mode, "key_type": keyType}
+
+	switch {
+	case mode == "test":
+		severity = detectors.SeverityLow
+	case keyType == "restricted":
+		severity = detectors.SeverityHigh
+	default:
+		severity = detectors.SeverityCritical
+	}
+	return extraData, severity
 }
 
 // FromData will find and optionally verify Stripe secrets in a given set of bytes.
@@ -32,13 +52,19 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 
 	dataStr := string(data)
 
-	matches := secretKey.FindAllString(dataStr, -1)
+	matches := secretKey.FindAllStringSubmatch(dataStr, -1)
 
-	for _, match := range matches {
+	for _, submatch := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		match := submatch[0]
+		extraData, severity := classify(submatch[1], submatch[2])
 
 		s := detectors.Result{
 			DetectorType: detectorspb.DetectorType_Stripe,
 			Raw:          []byte(match),
+			ExtraData:    extraData,
 		}
 
 		if verify {
@@ -58,6 +84,7 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			if err == nil {
 				res.Body.Close() // The request body is unused.
 
+				// Restricted keys without permission to read charges are still valid.
 				if res.StatusCode == http.StatusOK || res.StatusCode == http.StatusForbidden {
 					s.Verified = true
 				}
@@ -67,6 +94,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		if !s.Verified && detectors.IsKnownFalsePositive(string(s.Raw), detectors.DefaultFalsePositives, true) {
 			continue
 		}
+		// Unverified live keys keep the default severity, since they're most likely revoked.
+		if s.Verified || severity == detectors.SeverityLow {
+			s.Severity = severity
+		}
 
 		results = append(results, s)
 	}
@@ -2,9 +2,11 @@ package telegrambottoken
 
 import (
 	"context"
+	"encoding/json"
 	//	"fmt"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
@@ -38,6 +40,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
 
 	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
 		if len(match) != 2 {
 			continue
 		}
@@ -56,17 +61,32 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			}
 			res, err := client.Do(req)
 			if err == nil {
-				defer res.Body.Close()
+				var me struct {
+					Result struct {
+						ID                      int64  `json:"id"`
+						Username                string `json:"username"`
+						CanReadAllGroupMessages bool   `json:"can_read_all_group_messages"`
+					} `json:"result"`
+				}
 				if res.StatusCode >= 200 && res.StatusCode < 300 {
 					s1.Verified = true
-				} else {
-					if detectors.IsKnownFalsePositive(key, detectors.DefaultFalsePositives, true) {
-						continue
+					if err := json.NewDecoder(res.Body).Decode(&me); err == nil {
+						s1.ExtraData = map[string]string{
+							"bot_id":   strconv.FormatInt(me.Result.ID, 10),
+							"username": me.Result.Username,
+							// Bots with privacy mode off receive every message in their groups.
+							"can_read_all_group_messages": strconv.FormatBool(me.Result.CanReadAllGroupMessages),
+						}
 					}
 				}
+				res.Body.Close()
 			}
 		}
 
+		if !s1.Verified && detectors.IsKnownFalsePositive(key, detectors.DefaultFalsePositives, true) {
+			continue
+		}
+
 		results = append(results, s1)
 	}
 
@@ -10,6 +10,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
 )
 
@@ -92,6 +93,7 @@ func TestTelegramBotToken_FromChunk(t *testing.T) {
 					t.Fatalf("no raw secret present: \n %+v", got[i])
 				}
 				got[i].Raw = nil
+				got[i].ExtraData = nil
 			}
 			if diff := pretty.Compare(got, tt.want); diff != "" {
 				t.Errorf("TelegramBotToken.FromData() %s diff: (-got +want)\n%s", tt.name, diff)
@@ -114,3 +116,38 @@ func BenchmarkFromData(benchmark *testing.B) {
 		})
 	}
 }
+
+func TestTelegramBotToken_GetMe(t *testing.T) {
+	const token = "5432198765:AAHk3v9Xq2LmT8rZpW4sYbN7cJdF1gE6uQo"
+
+	detectortest.Test(t, Scanner{},
+		detectortest.Fixture{
+			Name:   "valid token",
+			Data:   "TELEGRAM_BOT_TOKEN=" + token,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "api.telegram.org",
+				Path:   "/bot" + token + "/getMe",
+				Status: 200,
+				Body: `{"ok": true, "result": {"id": 5432198765, "is_bot": true, "first_name": "Alerts",
+					"username": "acme_alerts_bot", "can_join_groups": true, "can_read_all_group_messages": false}}`,
+			}},
+			Want: []detectortest.Want{{
+				Raw:      token,
+				Verified: true,
+				ExtraData: map[string]string{
+					"bot_id":                      "5432198765",
+					"username":                    "acme_alerts_bot",
+					"can_read_all_group_messages": "false",
+				},
+			}},
+		},
+		detectortest.Fixture{
+			Name:   "revoked token",
+			Data:   "telegram: " + token,
+			Verify: true,
+			Mocks:  []detectortest.Mock{{Host: "api.telegram.org", Status: 401, Body: `{"ok": false, "error_code": 401, "description": "Unauthorized"}`}},
+			Want:   []detectortest.Want{{Raw: token}},
+		},
+	)
+}
@@ -36,57 +36,51 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	domainMatches := domainPat.FindAllStringSubmatch(dataStr, -1)
+	matches := detectors.FindMatches(keyPat, dataStr)
+	domainMatches := detectors.FindMatches(domainPat, dataStr)
 
-	for _, match := range matches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(matches, domainMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resMatch := strings.TrimSpace(match[1])
-		for _, domainMatch := range domainMatches {
+		resMatch := parts[0].Value
+		resDomainMatch := parts[1].Value
 
-			if len(domainMatch) != 2 {
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Thinkific,
+			Raw:          []byte(resMatch),
+		}
+
+		if verify {
+			domainRes := fmt.Sprintf("%s-s-school", resDomainMatch)
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://api.thinkific.com/api/public/v1/collections", nil)
+			if err != nil {
 				continue
 			}
-			resDomainMatch := strings.TrimSpace(domainMatch[1])
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_Thinkific,
-				Raw:          []byte(resMatch),
-			}
-
-			if verify {
-				domainRes := fmt.Sprintf("%s-s-school", resDomainMatch)
-				req, err := http.NewRequestWithContext(ctx, "GET", "https://api.thinkific.com/api/public/v1/collections", nil)
+			req.Header.Add("X-Auth-API-Key", resMatch)
+			req.Header.Add("X-Auth-Subdomain", domainRes)
+			req.Header.Add("Content-Type", "application/json")
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				bodyBytes, err := ioutil.ReadAll(res.Body)
 				if err != nil {
 					continue
 				}
-				req.Header.Add("X-Auth-API-Key", resMatch)
-				req.Header.Add("X-Auth-Subdomain", domainRes)
-				req.Header.Add("Content-Type", "application/json")
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					bodyBytes, err := ioutil.ReadAll(res.Body)
-					if err != nil {
+				body := string(bodyBytes)
+
+				if strings.Contains(body, "API Access is not available") {
+					s1.Verified = true
+				} else {
+					if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
 						continue
 					}
-					body := string(bodyBytes)
-
-					if strings.Contains(body, "API Access is not available") {
-						s1.Verified = true
-					} else {
-						if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
-							continue
-						}
-					}
-
 				}
-			}
 
-			results = append(results, s1)
+			}
 		}
 
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
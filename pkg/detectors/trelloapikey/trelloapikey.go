@@ -5,7 +5,6 @@ import (
 	// "log"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -33,47 +32,40 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	tokenMatches := tokenPat.FindAllStringSubmatch(dataStr, -1)
+	matches := detectors.FindMatches(keyPat, dataStr)
+	tokenMatches := detectors.FindMatches(tokenPat, dataStr)
 
-	for i, match := range matches {
-		if i == 0 {
-			resMatch := strings.TrimSpace(match[1])
-			for _, tokenMatch := range tokenMatches {
-				if len(tokenMatch) != 2 {
-					continue
-				}
-
-				token := strings.TrimSpace(tokenMatch[1])
+	for _, parts := range detectors.PairMatches(matches, tokenMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		resMatch := parts[0].Value
+		token := parts[1].Value
 
-				s1 := detectors.Result{
-					DetectorType: detectorspb.DetectorType_TrelloApiKey,
-					Redacted:     resMatch,
-					Raw:          []byte(resMatch),
-				}
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_TrelloApiKey,
+			Redacted:     resMatch,
+			Raw:          []byte(resMatch),
+		}
 
-				if verify {
-					req, err := http.NewRequestWithContext(ctx, "GET", "https://api.trello.com/1/members/me?key="+resMatch+"&token="+token, nil)
-					if err != nil {
+		if verify {
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://api.trello.com/1/members/me?key="+resMatch+"&token="+token, nil)
+			if err != nil {
+				continue
+			}
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
+				} else {
+					if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
 						continue
 					}
-					res, err := client.Do(req)
-					if err == nil {
-						defer res.Body.Close()
-						if res.StatusCode >= 200 && res.StatusCode < 300 {
-							s1.Verified = true
-						} else {
-							if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
-								continue
-							}
-						}
-					}
 				}
-				results = append(results, s1)
-
 			}
 		}
-
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
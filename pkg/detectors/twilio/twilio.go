@@ -19,8 +19,8 @@ var _ detectors.Detector = (*Scanner)(nil)
 
 var (
 	identifierPat = regexp.MustCompile(`(?i)sid.{0,20}AC[0-9a-f]{32}`) // Should we have this? Seems restrictive.
-	sidPat        = regexp.MustCompile(`\bAC[0-9a-f]{32}\b`)
-	keyPat        = regexp.MustCompile(`\b[0-9a-f]{32}\b`)
+	sidPat        = regexp.MustCompile(`\b(AC[0-9a-f]{32})\b`)
+	keyPat        = regexp.MustCompile(`\b([0-9a-f]{32})\b`)
 )
 
 // Keywords are used for efficiently pre-filtering chunks.
@@ -39,10 +39,14 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		return
 	}
 
-	keyMatches := keyPat.FindAllString(dataStr, -1)
-	sidMatches := sidPat.FindAllString(dataStr, -1)
+	keyMatches := detectors.FindMatches(keyPat, dataStr)
+	sidMatches := detectors.FindMatches(sidPat, dataStr)
 
-	for _, sid := range sidMatches {
+	for _, sidMatch := range sidMatches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		sid := sidMatch.Value
 
 		s := detectors.Result{
 			DetectorType: detectorspb.DetectorType_Twilio,
@@ -52,7 +56,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 
 		if verify {
 			client := common.SaneHttpClient()
-			for _, key := range keyMatches {
+			// The SID is verified with the closest key, rather than every key in the chunk.
+			for _, parts := range detectors.PairMatches([]detectors.Match{sidMatch}, keyMatches) {
+				key := parts[1].Value
 
 				form := url.Values{}
 				form.Add("FriendlyName", "MyServiceName")
@@ -0,0 +1,183 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	client = common.SaneHttpClient()
+
+	// Client IDs, client secrets, and OAuth tokens all look the same, so they're told apart by what they're named.
+	// Chat bots configure their tokens with an "oauth:" prefix.
+	tokenPat  = regexp.MustCompile(`(?:` + detectors.PrefixRegex([]string{"twitch"}) + `|oauth:)\b([a-z0-9]{30})\b`)
+	idPat     = regexp.MustCompile(detectors.PrefixRegex([]string{"client_id", "clientid", "client-id", "client id"}) + `\b([a-z0-9]{30})\b`)
+	secretPat = regexp.MustCompile(detectors.PrefixRegex([]string{"secret"}) + `\b([a-z0-9]{30})\b`)
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"twitch"}
+}
+
+// FromData will find and optionally verify Twitch secrets in a given set of bytes.
+// Client secrets are paired with the closest client ID, or reported on their own if there are none. Anything else
+// near the provider name is treated as an OAuth token.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	ids := detectors.FindMatches(idPat, dataStr)
+	secrets := detectors.FindMatches(secretPat, dataStr)
+
+	named := map[string]bool{}
+	for _, id := range ids {
+		named[id.Value] = true
+	}
+	for _, secret := range secrets {
+		named[secret.Value] = true
+	}
+
+	for _, parts := range detectors.PairMatches(secrets, ids) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		secret, id := parts[0].Value, parts[1].Value
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Twitch,
+			Raw:          []byte(secret),
+			Redacted:     id,
+			ExtraData:    map[string]string{"type": "client_secret", "client_id": id},
+		}
+
+		if verify {
+			s1.Verified = verifyClient(ctx, id, secret)
+		}
+
+		if !s1.Verified && detectors.IsKnownFalsePositive(secret, detectors.DefaultFalsePositives, true) {
+			continue
+		}
+
+		results = append(results, s1)
+	}
+
+	if len(ids) == 0 {
+		for _, secret := range secrets {
+			if detectors.IsKnownFalsePositive(secret.Value, detectors.DefaultFalsePositives, true) {
+				continue
+			}
+			results = append(results, detectors.Result{
+				DetectorType: detectorspb.DetectorType_Twitch,
+				Raw:          []byte(secret.Value),
+				ExtraData:    map[string]string{"type": "client_secret"},
+			})
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, match := range detectors.FindMatches(tokenPat, dataStr) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		token := match.Value
+		if named[token] || seen[token] {
+			continue
+		}
+		seen[token] = true
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Twitch,
+			Raw:          []byte(token),
+			ExtraData:    map[string]string{"type": "oauth_token"},
+		}
+
+		if verify {
+			if info, ok := validate(ctx, token); ok {
+				s1.Verified = true
+				s1.ExtraData["client_id"] = info.ClientID
+				if info.Login != "" {
+					s1.ExtraData["login"] = info.Login
+					s1.ExtraData["user_id"] = info.UserID
+				}
+				if len(info.Scopes) > 0 {
+					s1.ExtraData["scopes"] = strings.Join(info.Scopes, ",")
+				}
+				if info.ExpiresIn > 0 {
+					s1.ExtraData["expires_in"] = strconv.Itoa(info.ExpiresIn)
+				}
+			}
+		}
+
+		if !s1.Verified && detectors.IsKnownFalsePositive(token, detectors.DefaultFalsePositives, true) {
+			continue
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+type tokenInfo struct {
+	ClientID  string   `json:"client_id"`
+	Login     string   `json:"login"`
+	UserID    string   `json:"user_id"`
+	Scopes    []string `json:"scopes"`
+	ExpiresIn int      `json:"expires_in"`
+}
+
+// validate returns who an OAuth token was issued to and what it may do. App access tokens have no user.
+func validate(ctx context.Context, token string) (tokenInfo, bool) {
+	// https://dev.twitch.tv/docs/authentication/validate-tokens/
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://id.twitch.tv/oauth2/validate", nil)
+	if err != nil {
+		return tokenInfo{}, false
+	}
+	req.Header.Add("Authorization", "OAuth "+token)
+	res, err := client.Do(req)
+	if err != nil {
+		return tokenInfo{}, false
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return tokenInfo{}, false
+	}
+	var info tokenInfo
+	_ = json.NewDecoder(res.Body).Decode(&info)
+	return info, true
+}
+
+// verifyClient checks a client ID and secret by requesting an app access token with them.
+func verifyClient(ctx context.Context, id, secret string) bool {
+	// https://dev.twitch.tv/docs/authentication/getting-tokens-oauth/#client-credentials-grant-flow
+	form := url.Values{}
+	form.Set("client_id", id)
+	form.Set("client_secret", secret)
+	form.Set("grant_type", "client_credentials")
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://id.twitch.tv/oauth2/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return false
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	res, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer res.Body.Close()
+	return res.StatusCode == http.StatusOK
+}
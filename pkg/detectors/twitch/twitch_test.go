@@ -0,0 +1,71 @@
+package twitch
+
+import (
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
+)
+
+func TestTwitch_FromData(t *testing.T) {
+	const (
+		token    = "k3y9q2m8x7v1b4n6c5z0l2p9w8e7r6"
+		clientID = "gp762nuuoqcoxypju8c569th9wz7q5"
+		secret   = "a1b2c3d4e5f6g7h8i9j0k1l2m3n4o5"
+	)
+
+	detectortest.Test(t, Scanner{},
+		detectortest.Fixture{
+			Name:   "chat bot token",
+			Data:   "TWITCH_BOT_PASS=oauth:" + token,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "id.twitch.tv",
+				Path:   "/oauth2/validate",
+				Header: map[string]string{"Authorization": "OAuth " + token},
+				Status: 200,
+				Body: `{"client_id": "` + clientID + `", "login": "spambot", "user_id": "141981764",
+					"scopes": ["chat:edit", "chat:read"], "expires_in": 5520838}`,
+			}},
+			Want: []detectortest.Want{{
+				Raw:      token,
+				Verified: true,
+				ExtraData: map[string]string{
+					"type":       "oauth_token",
+					"client_id":  clientID,
+					"login":      "spambot",
+					"user_id":    "141981764",
+					"scopes":     "chat:edit,chat:read",
+					"expires_in": "5520838",
+				},
+			}},
+		},
+		detectortest.Fixture{
+			Name:   "client credentials",
+			Data:   "TWITCH_CLIENT_ID=" + clientID + "\nTWITCH_CLIENT_SECRET=" + secret,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "id.twitch.tv",
+				Method: "POST",
+				Path:   "/oauth2/token",
+				Status: 200,
+				Body:   `{"access_token": "jostpf5q0uzmxmkba9iyug38kjtgh", "expires_in": 5011271, "token_type": "bearer"}`,
+			}},
+			Want: []detectortest.Want{{
+				Raw:       secret,
+				Verified:  true,
+				ExtraData: map[string]string{"type": "client_secret", "client_id": clientID},
+			}},
+		},
+		detectortest.Fixture{
+			Name:   "invalid token",
+			Data:   "twitch_token: " + token,
+			Verify: true,
+			Mocks:  []detectortest.Mock{{Host: "id.twitch.tv", Status: 401, Body: `{"status": 401, "message": "invalid access token"}`}},
+			Want:   []detectortest.Want{{Raw: token, ExtraData: map[string]string{"type": "oauth_token"}}},
+		},
+		detectortest.Fixture{
+			Name: "value without the provider name",
+			Data: "session: " + token,
+		},
+	)
+}
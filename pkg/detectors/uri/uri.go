@@ -23,7 +23,11 @@ type Scanner struct {
 var _ detectors.Detector = (*Scanner)(nil)
 
 var (
-	keyPat = regexp.MustCompile(`\b[a-zA-Z]{1,10}:?\/\/[-.%\w{}]{1,50}:([-.%\S]{3,50})@[-.%\w\/:]+\b`)
+	keyPat = regexp.MustCompile(`\b[a-zA-Z][a-zA-Z0-9+.\-]{0,15}:?\/\/[-.%\w{}]{1,50}:([-.%\S]{3,50})@[-.%\w\/:]+\b`)
+
+	// Hosts reserved for documentation and testing by RFC 2606 and RFC 6761, and loopback addresses.
+	exampleDomains = []string{"example.com", "example.net", "example.org", "example", "test", "invalid", "localhost"}
+	loopbackHosts  = map[string]bool{"localhost": true, "127.0.0.1": true, "0.0.0.0": true, "::1": true}
 )
 
 type proxyRes struct {
@@ -33,13 +37,38 @@ type proxyRes struct {
 // Keywords are used for efficiently pre-filtering chunks.
 // Use identifiers in the secret preferably, or the provider name.
 func (s Scanner) Keywords() []string {
-	return []string{"http"}
+	return []string{"://"}
+}
+
+// skippedProtos have their own detector, or can't carry credentials.
+func skippedProtos(scheme string) bool {
+	switch scheme {
+	case "file",
+		// Found by the connectionstring detector.
+		"postgres", "postgresql", "mysql", "mongodb", "mongodb+srv", "redis", "rediss", "amqp", "amqps", "sqlserver":
+		return true
+	}
+	return false
 }
 
-func allowlistedProtos(scheme string) bool {
-	allowlisted := []string{"http", "https", "mongodb", "redis", "ftp"}
-	for _, s := range allowlisted {
-		if s == scheme {
+// verifiableProtos are the protocols the verification proxy can log in with.
+func verifiableProtos(scheme string) bool {
+	switch scheme {
+	case "http", "https", "ftp":
+		return true
+	}
+	return false
+}
+
+// isExampleHost returns true for hosts that can't be reached from outside the machine, or that only appear in
+// documentation.
+func isExampleHost(host string) bool {
+	host = strings.ToLower(host)
+	if loopbackHosts[host] || strings.HasPrefix(host, "127.") {
+		return true
+	}
+	for _, domain := range exampleDomains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
 			return true
 		}
 	}
@@ -58,6 +87,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
 
 	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
 
 		if !s.allowKnownTestSites {
 			if strings.Contains(match[0], "httpbin.org") {
@@ -72,7 +104,8 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		password := match[1]
 
 		// Skip findings where the password starts with a `$` - it's almost certainly a variable.
-		if strings.HasPrefix(password, "$") {
+		// Likewise for template placeholders such as {password} or <password>.
+		if strings.HasPrefix(password, "$") || strings.HasPrefix(password, "{") || strings.HasPrefix(password, "<") {
 			continue
 		}
 
@@ -83,7 +116,8 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		if _, ok := parsedURL.User.Password(); !ok {
 			continue
 		}
-		if !allowlistedProtos(parsedURL.Scheme) {
+		scheme := strings.ToLower(parsedURL.Scheme)
+		if skippedProtos(scheme) || isExampleHost(parsedURL.Hostname()) {
 			continue
 		}
 
@@ -93,9 +127,14 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			DetectorType: detectorspb.DetectorType_URI,
 			Raw:          []byte(urlMatch),
 			Redacted:     redact,
+			ExtraData: map[string]string{
+				"scheme":   scheme,
+				"host":     parsedURL.Hostname(),
+				"username": parsedURL.User.Username(),
+			},
 		}
 
-		if verify {
+		if verify && verifiableProtos(scheme) {
 			client := common.SaneHttpClient()
 			// whitelist protocols
 
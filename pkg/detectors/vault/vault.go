@@ -0,0 +1,132 @@
+// Package vault detects HashiCorp Vault tokens.
+//
+// Vault is always self-hosted, so tokens are only verified against the server set with SetAddress.
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	client = common.SaneHttpClient()
+
+	// Service, batch, and recovery tokens since Vault 1.10.
+	keyPat = regexp.MustCompile(`\b(hv[sbr]\.[A-Za-z0-9_-]{24,})`)
+	// Tokens before Vault 1.10 only have a one letter prefix, so they're only found near the provider name.
+	legacyKeyPat = regexp.MustCompile(detectors.PrefixRegex([]string{"vault"}) + `\b([sb]\.[A-Za-z0-9]{24})\b`)
+
+	address string
+)
+
+// SetAddress sets the address of the Vault server to verify tokens against, e.g. https://vault.internal:8200.
+// Without it, tokens are never verified. It must be called before scanning starts.
+func SetAddress(addr string) {
+	address = strings.TrimSuffix(addr, "/")
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"hvs.", "hvb.", "hvr.", "vault"}
+}
+
+// FromData will find and optionally verify Vault tokens in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
+	matches = append(matches, legacyKeyPat.FindAllStringSubmatch(dataStr, -1)...)
+
+	for _, match := range matches {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		if len(match) != 2 {
+			continue
+		}
+		token := match[1]
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Vault,
+			Raw:          []byte(token),
+		}
+
+		if verify && address != "" {
+			extraData, root := lookupSelf(ctx, token)
+			if extraData != nil {
+				s1.Verified = true
+				s1.ExtraData = extraData
+				if root {
+					s1.Severity = detectors.SeverityCritical
+				}
+			}
+		}
+
+		if !s1.Verified && detectors.IsKnownFalsePositive(token, detectors.DefaultFalsePositives, true) {
+			continue
+		}
+
+		results = append(results, s1)
+	}
+
+	return detectors.CleanResults(results), nil
+}
+
+// lookupSelf returns the token's policies and identity, and whether it has the root policy, which can do anything.
+// Every token may look itself up unless that's explicitly denied.
+func lookupSelf(ctx context.Context, token string) (extraData map[string]string, root bool) {
+	req, err := http.NewRequestWithContext(ctx, "GET", address+"/v1/auth/token/lookup-self", nil)
+	if err != nil {
+		return nil, false
+	}
+	req.Header.Add("X-Vault-Token", token)
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, false
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, false
+	}
+
+	var lookup struct {
+		Data struct {
+			DisplayName string   `json:"display_name"`
+			Policies    []string `json:"policies"`
+			ExpireTime  string   `json:"expire_time"`
+			Type        string   `json:"type"`
+			Orphan      bool     `json:"orphan"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&lookup); err != nil {
+		return nil, false
+	}
+	extraData = map[string]string{
+		"address":      address,
+		"display_name": lookup.Data.DisplayName,
+		"policies":     strings.Join(lookup.Data.Policies, ","),
+		"type":         lookup.Data.Type,
+	}
+	if lookup.Data.ExpireTime != "" {
+		extraData["expire_time"] = lookup.Data.ExpireTime
+	}
+	for _, policy := range lookup.Data.Policies {
+		if policy == "root" {
+			root = true
+		}
+	}
+	return extraData, root
+}
@@ -0,0 +1,97 @@
+package vault
+
+import (
+	"context"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/detectortest"
+)
+
+const (
+	serviceToken = "hvs.CAESI575yx8xm5MslfY5ubiheyEd7P4zDL-ak6J0kGODKdinZnLXicaBAg8WY1jzIRlNQb0prFmbh7_wy5yq1XoY1Ba"
+	legacyToken  = "s.r3ZLt4bnlz2MPKgcjnCqaXNv"
+)
+
+func TestVault_FromData(t *testing.T) {
+	SetAddress("https://vault.corp.net:8200/")
+	defer SetAddress("")
+
+	detectortest.Test(t, Scanner{},
+		detectortest.Fixture{
+			Name:   "service token",
+			Data:   "export VAULT_TOKEN=" + serviceToken,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "vault.corp.net",
+				Path:   "/v1/auth/token/lookup-self",
+				Header: map[string]string{"X-Vault-Token": serviceToken},
+				Status: 200,
+				Body: `{"data": {"display_name": "approle-deployer", "policies": ["default", "deploy"],
+					"expire_time": "2030-01-01T00:00:00Z", "type": "service"}}`,
+			}},
+			Want: []detectortest.Want{{
+				Raw:      serviceToken,
+				Verified: true,
+				ExtraData: map[string]string{
+					"address":      "https://vault.corp.net:8200",
+					"display_name": "approle-deployer",
+					"policies":     "default,deploy",
+					"expire_time":  "2030-01-01T00:00:00Z",
+					"type":         "service",
+				},
+			}},
+		},
+		detectortest.Fixture{
+			Name:   "revoked legacy token",
+			Data:   "vault login " + legacyToken,
+			Verify: true,
+			Mocks: []detectortest.Mock{{
+				Host:   "vault.corp.net",
+				Status: 403,
+				Body:   `{"errors": ["permission denied"]}`,
+			}},
+			Want: []detectortest.Want{{Raw: legacyToken}},
+		},
+		detectortest.Fixture{
+			Name: "legacy token without the provider name",
+			Data: "token: " + legacyToken,
+		},
+	)
+}
+
+func TestVault_RootToken(t *testing.T) {
+	SetAddress("https://vault.corp.net:8200")
+	defer SetAddress("")
+	common.OverrideTransport(detectortest.NewMockTransport(detectortest.Mock{
+		Host:   "vault.corp.net",
+		Status: 200,
+		Body:   `{"data": {"display_name": "root", "policies": ["root"], "type": "service"}}`,
+	}))
+	defer common.OverrideTransport(nil)
+
+	got, err := Scanner{}.FromData(context.Background(), true, []byte(serviceToken))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || !got[0].Verified {
+		t.Fatalf("expected one verified result, got: %+v", got)
+	}
+	if got[0].Severity != detectors.SeverityCritical {
+		t.Errorf("expected root tokens to be critical, got: %v", got[0].Severity)
+	}
+}
+
+func TestVault_NoAddress(t *testing.T) {
+	got, err := Scanner{}.FromData(context.Background(), true, []byte(serviceToken))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Verified {
+		t.Fatalf("expected one unverified result without an address, got: %+v", got)
+	}
+	if got[0].Severity != detectors.SeverityUnknown {
+		t.Errorf("unexpected severity: %v", got[0].Severity)
+	}
+}
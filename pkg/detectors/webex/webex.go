@@ -33,59 +33,53 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	idMatches := idPat.FindAllStringSubmatch(dataStr, -1)
+	matches := detectors.FindMatches(keyPat, dataStr)
+	idMatches := detectors.FindMatches(idPat, dataStr)
 
-	for _, match := range matches {
-		if len(match) != 2 {
-			continue
+	for _, parts := range detectors.PairMatches(matches, idMatches) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
 		}
-		resMatch := strings.TrimSpace(match[1])
-		for _, idMatch := range idMatches {
+		resMatch := parts[0].Value
+		id := parts[1].Value
 
-			if len(idMatch) != 2 {
-				continue
-			}
-			id := strings.TrimSpace(idMatch[1])
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Webex,
+			Raw:          []byte(resMatch),
+		}
 
-			s1 := detectors.Result{
-				DetectorType: detectorspb.DetectorType_Webex,
-				Raw:          []byte(resMatch),
+		if verify {
+			payload := strings.NewReader("grant_type=authorization_code&code=362ad374-735c-4f69-aa8e-bf384f8602de&client_id=" + id + "&client_secret=" + resMatch + "&redirect_uri=http%3A%2F%2Flocalhost.com%2Fb")
+			req, err := http.NewRequestWithContext(ctx, "POST", "https://webexapis.com/v1/access_token", payload)
+			if err != nil {
+				continue
 			}
-
-			if verify {
-				payload := strings.NewReader("grant_type=authorization_code&code=362ad374-735c-4f69-aa8e-bf384f8602de&client_id=" + id + "&client_secret=" + resMatch + "&redirect_uri=http%3A%2F%2Flocalhost.com%2Fb")
-				req, err := http.NewRequestWithContext(ctx, "POST", "https://webexapis.com/v1/access_token", payload)
-				if err != nil {
-					continue
-				}
-				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-				client := common.SaneHttpClient()
-				res, err := client.Do(req)
+			req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			client := common.SaneHttpClient()
+			res, err := client.Do(req)
+			if err == nil {
+				body, err := io.ReadAll(res.Body)
+				res.Body.Close()
 				if err == nil {
-					body, err := io.ReadAll(res.Body)
-					res.Body.Close()
-					if err == nil {
-						var message struct {
-							Message string `json:"message"`
-						}
-						if err := json.Unmarshal(body, &message); err == nil {
-							var getError = regexp.MustCompile(detectors.PrefixRegex([]string{"error"}) + `(redirect_uri_mismatch)`)
-							result := getError.FindAllStringSubmatch(message.Message, -1)
-							if len(result) > 0 {
-								s1.Verified = true
-							}
+					var message struct {
+						Message string `json:"message"`
+					}
+					if err := json.Unmarshal(body, &message); err == nil {
+						var getError = regexp.MustCompile(detectors.PrefixRegex([]string{"error"}) + `(redirect_uri_mismatch)`)
+						result := getError.FindAllStringSubmatch(message.Message, -1)
+						if len(result) > 0 {
+							s1.Verified = true
 						}
 					}
 				}
 			}
+		}
 
-			if !s1.Verified && detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
-				continue
-			}
-
-			results = append(results, s1)
+		if !s1.Verified && detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
+			continue
 		}
+
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
@@ -4,7 +4,6 @@ import (
 	"context"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -35,23 +34,20 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	appIDmatches := appIDPat.FindAllStringSubmatch(dataStr, -1)
-
-	resAppIDMatch := ""
-	for _, appIDMatch := range appIDmatches {
-		if len(appIDMatch) != 2 {
-			continue
-		}
-		resAppIDMatch = strings.TrimSpace(appIDMatch[1])
-
-	}
+	matches := detectors.FindMatches(keyPat, dataStr)
+	appIDmatches := detectors.FindMatches(appIDPat, dataStr)
 
 	for _, match := range matches {
-		if len(match) != 2 {
-			continue
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		resMatch := match.Value
+		// The token is verified with the closest app ID, if there is one.
+		resAppIDMatch := ""
+		for _, parts := range detectors.PairMatches([]detectors.Match{match}, appIDmatches) {
+			resAppIDMatch = parts[1].Value
 		}
-		resMatch := strings.TrimSpace(match[1])
+
 		s1 := detectors.Result{
 			DetectorType: detectorspb.DetectorType_WePay,
 			Raw:          []byte(resMatch),
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -36,60 +35,45 @@ func (s Scanner) Keywords() []string {
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	tokens := token.FindAllStringSubmatch(dataStr, -1)
-	domains := domain.FindAllStringSubmatch(dataStr, -1)
-	emails := email.FindAllStringSubmatch(dataStr, -1)
+	tokens := detectors.FindMatches(token, dataStr)
+	domains := detectors.FindMatches(domain, dataStr)
+	emails := detectors.FindMatches(email, dataStr)
 
-	for _, token := range tokens {
-		if len(token) != 2 {
+	for _, parts := range detectors.PairMatches(tokens, domains, emails) {
+		if common.IsDone(common.ScanContext(ctx)) {
+			break
+		}
+		resMatch := parts[0].Value
+		resDomain := parts[1].Value
+		resEmail := parts[2].Value
+
+		if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
 			continue
 		}
-		resMatch := strings.TrimSpace(token[1])
 
-		var resDomain string
-		for _, domain := range domains {
-			if len(domain) != 2 {
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_ZendeskApi,
+			Raw:          []byte(resMatch),
+		}
+
+		if verify {
+			data := fmt.Sprintf("%s/token:%s", resEmail, resMatch)
+			sEnc := b64.StdEncoding.EncodeToString([]byte(data))
+			req, err := http.NewRequestWithContext(ctx, "GET", "https://"+resDomain+"/api/v2/users.json", nil)
+			if err != nil {
 				continue
 			}
-			resDomain = strings.TrimSpace(domain[1])
-
-			for _, email := range emails {
-				if len(email) != 2 {
-					continue
-				}
-				resEmail := strings.TrimSpace(email[1])
-
-				if detectors.IsKnownFalsePositive(resMatch, detectors.DefaultFalsePositives, true) {
-					continue
+			req.Header.Add("Authorization", fmt.Sprintf("Basic %s", sEnc))
+			res, err := client.Do(req)
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode >= 200 && res.StatusCode < 300 {
+					s1.Verified = true
 				}
-
-				s1 := detectors.Result{
-					DetectorType: detectorspb.DetectorType_ZendeskApi,
-					Raw:          []byte(resMatch),
-				}
-
-				if verify {
-					data := fmt.Sprintf("%s/token:%s", resEmail, resMatch)
-					sEnc := b64.StdEncoding.EncodeToString([]byte(data))
-					req, err := http.NewRequestWithContext(ctx, "GET", "https://"+resDomain+"/api/v2/users.json", nil)
-					if err != nil {
-						continue
-					}
-					req.Header.Add("Authorization", fmt.Sprintf("Basic %s", sEnc))
-					res, err := client.Do(req)
-					if err == nil {
-						defer res.Body.Close()
-						if res.StatusCode >= 200 && res.StatusCode < 300 {
-							s1.Verified = true
-						}
-					}
-				}
-
-				results = append(results, s1)
-
 			}
 		}
 
+		results = append(results, s1)
 	}
 
 	return detectors.CleanResults(results), nil
@@ -0,0 +1,149 @@
+// Package diff compares the findings of two scans, such as a baseline from the main branch and a scan of a pull
+// request, to track which secrets were introduced or removed.
+package diff
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+)
+
+// Report is the result of comparing two scans. Each secret is listed once, using its first occurrence in the scan it
+// was found in, with Occurrences counting all of them.
+type Report struct {
+	// New findings are in the new scan but not the old one.
+	New []Entry `json:"new"`
+	// Resolved findings are in the old scan but not the new one.
+	Resolved []Entry `json:"resolved"`
+	// Persisting findings are in both scans.
+	Persisting []Entry `json:"persisting"`
+}
+
+// Entry is a unique secret in a Report.
+type Entry struct {
+	Fingerprint  string `json:"fingerprint"`
+	DetectorType string `json:"detector_type"`
+	Verified     bool   `json:"verified"`
+	Redacted     string `json:"redacted,omitempty"`
+	SourceName   string `json:"source_name"`
+	Location     string `json:"location"`
+	Occurrences  int    `json:"occurrences"`
+}
+
+// Compare compares findings by fingerprint.
+func Compare(before, after []findings.Finding) *Report {
+	oldEntries, oldOrder := index(before)
+	newEntries, newOrder := index(after)
+
+	report := &Report{New: []Entry{}, Resolved: []Entry{}, Persisting: []Entry{}}
+	for _, fingerprint := range newOrder {
+		if _, ok := oldEntries[fingerprint]; ok {
+			report.Persisting = append(report.Persisting, *newEntries[fingerprint])
+		} else {
+			report.New = append(report.New, *newEntries[fingerprint])
+		}
+	}
+	for _, fingerprint := range oldOrder {
+		if _, ok := newEntries[fingerprint]; !ok {
+			report.Resolved = append(report.Resolved, *oldEntries[fingerprint])
+		}
+	}
+	return report
+}
+
+// CompareFiles loads two files written with --json and compares them.
+func CompareFiles(oldPath, newPath string) (*Report, error) {
+	before, err := load(oldPath)
+	if err != nil {
+		return nil, err
+	}
+	after, err := load(newPath)
+	if err != nil {
+		return nil, err
+	}
+	return Compare(before, after), nil
+}
+
+// WriteText writes a human readable report.
+func (r *Report) WriteText(w io.Writer) error {
+	sections := []struct {
+		title   string
+		entries []Entry
+	}{
+		{"New", r.New},
+		{"Resolved", r.Resolved},
+		{"Persisting", r.Persisting},
+	}
+	for _, section := range sections {
+		if _, err := fmt.Fprintf(w, "%s findings: %d\n", section.title, len(section.entries)); err != nil {
+			return err
+		}
+		for _, entry := range section.entries {
+			verified := "unverified"
+			if entry.Verified {
+				verified = "verified"
+			}
+			if _, err := fmt.Fprintf(w, "  %s (%s) %s %s", entry.DetectorType, verified, entry.SourceName, entry.Location); err != nil {
+				return err
+			}
+			if entry.Occurrences > 1 {
+				if _, err := fmt.Fprintf(w, " and %d more", entry.Occurrences-1); err != nil {
+					return err
+				}
+			}
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// index returns the entries by fingerprint and the fingerprints sorted by detector type and location.
+func index(results []findings.Finding) (map[string]*Entry, []string) {
+	entries := map[string]*Entry{}
+	var order []string
+	for i := range results {
+		finding := &results[i]
+		fingerprint := finding.Fingerprint()
+		if entry, ok := entries[fingerprint]; ok {
+			entry.Occurrences++
+			entry.Verified = entry.Verified || finding.Verified
+			continue
+		}
+		entries[fingerprint] = &Entry{
+			Fingerprint:  fingerprint,
+			DetectorType: finding.DetectorType,
+			Verified:     finding.Verified,
+			Redacted:     finding.Redacted,
+			SourceName:   finding.SourceName,
+			Location:     finding.Location(),
+			Occurrences:  1,
+		}
+		order = append(order, fingerprint)
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := entries[order[i]], entries[order[j]]
+		if a.DetectorType != b.DetectorType {
+			return a.DetectorType < b.DetectorType
+		}
+		return a.Location < b.Location
+	})
+	return entries, order
+}
+
+func load(path string) ([]findings.Finding, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	results, err := findings.LoadFindings(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return results, nil
+}
@@ -0,0 +1,114 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// Source is anything that sends chunks of data to scan. Sources that implement sources.Source must be initialized
+// with Init before they're added. Chunks must return once it has sent its last chunk, or once ctx is done.
+type Source interface {
+	Chunks(ctx context.Context, chunksChan chan *sources.Chunk) error
+}
+
+// ErrFinished is returned by AddSource once Finish has been called.
+var ErrFinished = errors.New("engine: no more sources can be added after Finish")
+
+// New starts an engine for scanning the sources added with AddSource, for programs that embed trufflehog rather than
+// running the CLI. Results are sent on Results until Finish has been called and every added source is done, at which
+// point the channel is closed. Results must be received, or the scan blocks. Cancelling ctx stops the scan. Detectors
+// and decoders default to those of the CLI. The Scan methods are for engines started with Start, and can't be used.
+//
+// New, AddSource, Finish, Results, Stats, and the options they accept are stable: they only change in backwards
+// compatible ways within a major version. The rest of the package is used by the CLI and may change.
+func New(ctx context.Context, options ...EngineOption) *Engine {
+	// The engine holds one source slot until Finish, so that results aren't closed while sources may still be added.
+	return Start(ctx, append(options, WithSources(1))...)
+}
+
+// AddSource starts scanning src in the background. Chunks are scanned as src sends them, concurrently with other added
+// sources. An error returned by src is logged, and doesn't stop the scan of other sources.
+func (e *Engine) AddSource(ctx context.Context, src Source) error {
+	e.addMu.Lock()
+	defer e.addMu.Unlock()
+	if e.finished {
+		return ErrFinished
+	}
+	atomic.AddInt32(&e.sources, 1)
+	atomic.AddInt32(&e.sourcesAdded, 1)
+	if p, ok := src.(interface{ GetProgress() *sources.Progress }); ok {
+		e.trackProgress(p.GetProgress())
+	}
+
+	go func() {
+		defer e.sourceDone()
+		if err := src.Chunks(ctx, e.ChunksChan()); err != nil {
+			logging.FromContext(ctx).WithError(err).Error("could not scan source")
+		}
+	}()
+	return nil
+}
+
+// Finish marks that no more sources will be added. Results is closed once the added sources are done and their chunks
+// have been scanned. Finish may be called more than once.
+func (e *Engine) Finish() {
+	e.addMu.Lock()
+	defer e.addMu.Unlock()
+	if e.finished {
+		return
+	}
+	e.finished = true
+	e.sourceDone()
+}
+
+// Results returns the channel results are sent on.
+func (e *Engine) Results() <-chan detectors.ResultWithMetadata {
+	return e.results
+}
+
+// Stats are the progress of a scan so far.
+type Stats struct {
+	// SourcesAdded is the number of sources added with AddSource, and SourcesRunning the number that aren't done.
+	SourcesAdded   int
+	SourcesRunning int
+	ChunksScanned  uint64
+	BytesScanned   uint64
+	// Results is the number of results sent on Results, of which VerifiedResults were verified.
+	Results         uint64
+	VerifiedResults uint64
+	// Elapsed is the time since the engine started, or the duration of the scan once it's done.
+	Elapsed time.Duration
+}
+
+// Stats returns the progress of the scan. Unlike Summary, it can be called while the scan runs.
+func (e *Engine) Stats() Stats {
+	e.addMu.Lock()
+	running := atomic.LoadInt32(&e.sources)
+	if !e.finished {
+		running--
+	}
+	e.addMu.Unlock()
+	if running < 0 {
+		running = 0
+	}
+
+	elapsed := time.Duration(atomic.LoadInt64((*int64)(&e.duration)))
+	if elapsed == 0 {
+		elapsed = time.Since(e.start)
+	}
+	return Stats{
+		SourcesAdded:    int(atomic.LoadInt32(&e.sourcesAdded)),
+		SourcesRunning:  int(running),
+		ChunksScanned:   atomic.LoadUint64(&e.chunksScanned),
+		BytesScanned:    atomic.LoadUint64(&e.bytesScanned),
+		Results:         atomic.LoadUint64(&e.resultsSent),
+		VerifiedResults: atomic.LoadUint64(&e.verifiedSent),
+		Elapsed:         elapsed,
+	}
+}
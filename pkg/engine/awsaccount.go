@@ -0,0 +1,23 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/awsaccount"
+)
+
+// ScanAWSAccount sweeps the resources of an AWS account that commonly embed credentials.
+func (e *Engine) ScanAWSAccount(ctx context.Context, src *awsaccount.Source) error {
+	ctx = logging.WithModule(ctx, "sources.awsaccount")
+	src.SourceName = sourceName(ctx, "trufflehog - aws")
+
+	go func() {
+		err := src.Chunks(ctx, e.ChunksChan())
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("could not sweep aws account")
+		}
+		e.sourceDone()
+	}()
+	return nil
+}
@@ -0,0 +1,23 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/azuresubscription"
+)
+
+// ScanAzureSubscription sweeps the resources of Azure subscriptions that commonly embed credentials.
+func (e *Engine) ScanAzureSubscription(ctx context.Context, src *azuresubscription.Source) error {
+	ctx = logging.WithModule(ctx, "sources.azuresubscription")
+	src.SourceName = sourceName(ctx, "trufflehog - azure")
+
+	go func() {
+		err := src.Chunks(ctx, e.ChunksChan())
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("could not sweep azure subscriptions")
+		}
+		e.sourceDone()
+	}()
+	return nil
+}
@@ -3,7 +3,9 @@ package engine
 import (
 	"bytes"
 	"context"
+	"reflect"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -11,10 +13,17 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/trufflesecurity/trufflehog/v3/pkg/allowlist"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/decoders"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/lexer"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/policy"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/secretstore"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
 )
 
@@ -24,8 +33,102 @@ type Engine struct {
 	results         chan detectors.ResultWithMetadata
 	decoders        []decoders.Decoder
 	detectors       map[bool][]detectors.Detector
+	keywords        map[bool][][]string // the folded keywords of each detector, in the same order as detectors
 	chunksScanned   uint64
+	bytesScanned    uint64
 	detectorAvgTime sync.Map
+	detectorStats   sync.Map
+	start           time.Time
+	scanID          string
+	duration        time.Duration
+	progressMu      sync.Mutex
+	progress        []*sources.Progress
+	// sources is the number of sources that have yet to finish. The chunks channel is closed when it reaches zero.
+	sources int32
+	// resultsSent and verifiedSent count the results sent on the results channel.
+	resultsSent  uint64
+	verifiedSent uint64
+
+	// The state of scans started with New, see AddSource and Finish.
+	addMu        sync.Mutex
+	finished     bool
+	sourcesAdded int32
+
+	// Filters applied to results before they are sent on the results channel.
+	onlyVerified   bool
+	minSeverity    detectors.Severity
+	detectorTypes  map[detectorspb.DetectorType]struct{}
+	allowlist      *allowlist.Allowlist
+	allowPatterns  *allowlist.Patterns
+	tagAllowed     bool
+	falsePositives *detectors.FalsePositiveFilter
+	// secrets holds the secrets of secret managers. Results found in it are tagged as managed and made critical.
+	secrets *secretstore.Inventory
+	// secretNames holds the names of secrets configured in CI systems and secret managers. Results assigned to one are
+	// tagged and made critical.
+	secretNames *secretstore.Names
+	// pathRules change the severity of results by the path of their file.
+	pathRules *detectors.PathRules
+	// policy classifies the results that pass the filters into actions.
+	policy *policy.Policy
+	// extractStrings suppresses unverified results found outside the string literals and comments of source code.
+	extractStrings bool
+	// suppressed counts suppressed results by reason, as *uint64, and onSuppressed is passed each of them.
+	suppressed   sync.Map
+	onSuppressed func(detectors.ResultWithMetadata)
+
+	// contextLines is the number of lines around a secret to include in its result.
+	contextLines int
+}
+
+// Summary describes a completed scan.
+type Summary struct {
+	// ScanID is the ID of the scan, set on the engine's context with logging.WithScanID.
+	ScanID string `json:",omitempty"`
+	// Cancelled is set by the caller if it stopped the sources before they finished, so the counts are partial.
+	Cancelled     bool `json:",omitempty"`
+	Duration      time.Duration
+	ChunksScanned uint64
+	BytesScanned  uint64
+	// Detectors is keyed by detector name and only includes detectors that were run on at least one chunk.
+	Detectors    map[string]DetectorStats
+	SkippedFiles []sources.SkippedFile
+	// SkippedRepos are the repositories skipped for being over the limits set with WithRepoLimits.
+	SkippedRepos []sources.SkippedRepo `json:",omitempty"`
+	// API counts the requests sources made to APIs such as GitHub's, if they made any.
+	API *common.APIStats `json:",omitempty"`
+	// Suppressed counts the results dropped by allowlists and false positive filters, by the reason they were
+	// dropped, such as SuppressedAllowlist.
+	Suppressed map[string]uint64 `json:",omitempty"`
+}
+
+// Reasons results are suppressed, as counted in Summary.Suppressed and set as "suppressed" in the extra data of the
+// results passed to the WithSuppressedResults callback.
+const (
+	// SuppressedAllowlist results have a secret in the allowlist, such as a baseline of false positives.
+	SuppressedAllowlist = "allowlist"
+	// SuppressedAllowlistPattern results have a secret that matches an allowlist pattern.
+	SuppressedAllowlistPattern = "allowlist_pattern"
+	// SuppressedFalsePositive results were dropped by the false positive filter.
+	SuppressedFalsePositive = "false_positive"
+	// SuppressedCode results are unverified and were found in the code of a source file, such as in an identifier or
+	// hash, rather than in a string literal or comment.
+	SuppressedCode = "code"
+	// SuppressedPolicy results matched a policy rule with the ignore action.
+	SuppressedPolicy = "policy"
+)
+
+// DetectorStats holds the statistics of a single detector.
+type DetectorStats struct {
+	// Calls is the number of chunks the detector ran on, i.e. that contained one of its keywords.
+	Calls    uint64
+	Duration time.Duration
+	Results  uint64
+	Verified uint64
+}
+
+type detectorCounters struct {
+	calls, nanos, results, verified uint64
 }
 
 type EngineOption func(*Engine)
@@ -49,6 +152,128 @@ func WithDetectors(verify bool, d ...detectors.Detector) EngineOption {
 	}
 }
 
+// WithOnlyVerified drops unverified results.
+func WithOnlyVerified(onlyVerified bool) EngineOption {
+	return func(e *Engine) {
+		e.onlyVerified = onlyVerified
+	}
+}
+
+// WithMinSeverity drops results below the severity.
+func WithMinSeverity(severity detectors.Severity) EngineOption {
+	return func(e *Engine) {
+		e.minSeverity = severity
+	}
+}
+
+// WithDetectorTypes drops results from any detector type not provided. All results are kept if none are provided.
+func WithDetectorTypes(types ...detectorspb.DetectorType) EngineOption {
+	return func(e *Engine) {
+		if len(types) == 0 {
+			return
+		}
+		e.detectorTypes = make(map[detectorspb.DetectorType]struct{}, len(types))
+		for _, t := range types {
+			e.detectorTypes[t] = struct{}{}
+		}
+	}
+}
+
+// WithAllowlist drops results whose secret is in the allowlist, or if tag is true, keeps them with "allowlisted" set
+// in their extra data.
+func WithAllowlist(a *allowlist.Allowlist, tag bool) EngineOption {
+	return func(e *Engine) {
+		e.allowlist = a
+		e.tagAllowed = tag
+	}
+}
+
+// WithAllowlistPatterns drops unverified results whose secret matches one of the patterns, or if tag is true, keeps
+// them with "allowlisted" and "allowlist_pattern" set in their extra data. A verified secret is live whatever it looks
+// like, so verified results are kept.
+func WithAllowlistPatterns(p *allowlist.Patterns, tag bool) EngineOption {
+	return func(e *Engine) {
+		e.allowPatterns = p
+		e.tagAllowed = tag
+	}
+}
+
+// WithSuppressedResults passes the results dropped by allowlists and false positive filters to fn, with the reason
+// they were dropped set as "suppressed" in their extra data, so that suppressions can be audited. fn is called by the
+// scanning workers, so it must be safe for concurrent use, and should be quick.
+func WithSuppressedResults(fn func(detectors.ResultWithMetadata)) EngineOption {
+	return func(e *Engine) {
+		e.onSuppressed = fn
+	}
+}
+
+// WithFalsePositiveFilter drops unverified results that match the filter.
+func WithFalsePositiveFilter(f *detectors.FalsePositiveFilter) EngineOption {
+	return func(e *Engine) {
+		e.falsePositives = f
+	}
+}
+
+// WithSecretInventory tags results whose secret is held in one of the inventory's secret managers with
+// "managed_secret" and "secret_store" in their extra data, and raises them to critical severity. A managed secret is
+// in use, so finding it outside its store is a leak even when it can't be verified.
+func WithSecretInventory(inv *secretstore.Inventory) EngineOption {
+	return func(e *Engine) {
+		e.secrets = inv
+	}
+}
+
+// WithSecretNames tags results assigned to a variable named after a CI or managed secret with "secret_name" and
+// "secret_name_scope" in their extra data, and raises them to critical severity. A variable named after a production
+// secret likely holds its real value.
+func WithSecretNames(names *secretstore.Names) EngineOption {
+	return func(e *Engine) {
+		e.secretNames = names
+	}
+}
+
+// WithPathRules changes the severity of results found in files matching the rules, before results are filtered by
+// severity.
+func WithPathRules(rules *detectors.PathRules) EngineOption {
+	return func(e *Engine) {
+		e.pathRules = rules
+	}
+}
+
+// WithPolicy tags results with the action of the first policy rule that matches them, and the rule's name, as
+// "policy_action" and "policy_rule" in their extra data. Results a rule ignores are suppressed. The policy is
+// evaluated after the other filters, so it only sees results that would be sent.
+func WithPolicy(p *policy.Policy) EngineOption {
+	return func(e *Engine) {
+		e.policy = p
+	}
+}
+
+// WithStringExtraction lexes files with a known source code extension, such as .go or .py, to find their string
+// literals and comments. Results found in one are tagged with "found_in" in their extra data, and unverified results
+// found only in the code itself are suppressed. Other files are scanned as before.
+func WithStringExtraction(extract bool) EngineOption {
+	return func(e *Engine) {
+		e.extractStrings = extract
+	}
+}
+
+// WithContextLines includes the given number of lines before and after each secret in its result. Zero omits the
+// context.
+func WithContextLines(lines int) EngineOption {
+	return func(e *Engine) {
+		e.contextLines = lines
+	}
+}
+
+// WithSources sets the number of sources that will be scanned, so that the chunks channel is only closed once all of
+// them are done. The default is one.
+func WithSources(n int) EngineOption {
+	return func(e *Engine) {
+		e.sources = int32(n)
+	}
+}
+
 func WithDecoders(decoders ...decoders.Decoder) EngineOption {
 	return func(e *Engine) {
 		e.decoders = decoders
@@ -56,10 +281,14 @@ func WithDecoders(decoders ...decoders.Decoder) EngineOption {
 }
 
 func Start(ctx context.Context, options ...EngineOption) *Engine {
+	ctx = logging.WithModule(ctx, "engine")
+	logger := logging.FromContext(ctx)
 	e := &Engine{
 		chunks:          make(chan *sources.Chunk),
 		results:         make(chan detectors.ResultWithMetadata),
 		detectorAvgTime: sync.Map{},
+		start:           time.Now(),
+		scanID:          logging.ScanID(ctx),
 	}
 
 	for _, option := range options {
@@ -68,12 +297,16 @@ func Start(ctx context.Context, options ...EngineOption) *Engine {
 
 	// Set defaults.
 
+	if e.sources < 1 {
+		e.sources = 1
+	}
+
 	if e.concurrency == 0 {
 		numCPU := runtime.NumCPU()
-		logrus.Warn("No concurrency specified, defaulting to ", numCPU)
+		logger.Warn("No concurrency specified, defaulting to ", numCPU)
 		e.concurrency = numCPU
 	}
-	logrus.Debugf("running with up to %d workers", e.concurrency)
+	logger.Debugf("running with up to %d workers", e.concurrency)
 
 	var workerWg sync.WaitGroup
 	for i := 0; i < e.concurrency; i++ {
@@ -94,8 +327,15 @@ func Start(ctx context.Context, options ...EngineOption) *Engine {
 		e.detectors[false] = []detectors.Detector{}
 	}
 
-	logrus.Debugf("loaded %d decoders", len(e.decoders))
-	logrus.Debugf("loaded %d detectors total, %d with verification enabled. %d with verification disabled",
+	e.keywords = make(map[bool][][]string, len(e.detectors))
+	for verify, detectorsSet := range e.detectors {
+		for _, detector := range detectorsSet {
+			e.keywords[verify] = append(e.keywords[verify], detectors.Keywords(detector))
+		}
+	}
+
+	logger.Debugf("loaded %d decoders", len(e.decoders))
+	logger.Debugf("loaded %d detectors total, %d with verification enabled. %d with verification disabled",
 		len(e.detectors[true])+len(e.detectors[false]),
 		len(e.detectors[true]),
 		len(e.detectors[false]))
@@ -104,9 +344,16 @@ func Start(ctx context.Context, options ...EngineOption) *Engine {
 	go func() {
 		// close results chan when all workers are done
 		workerWg.Wait()
-		// not entirely sure why results don't get processed without this pause
-		// since we've put all results on the channel at this point.
-		time.Sleep(time.Second)
+		if ctx.Err() != nil {
+			// The workers stopped early, so keep receiving chunks to unblock sources until they notice the
+			// cancellation themselves.
+			go func() {
+				for range e.chunks {
+				}
+			}()
+		}
+		// Results are sent by the workers, so they've all been received once the workers are done.
+		atomic.StoreInt64((*int64)(&e.duration), int64(time.Since(e.start)))
 		close(e.ResultsChan())
 	}()
 
@@ -117,6 +364,29 @@ func (e *Engine) ChunksChan() chan *sources.Chunk {
 	return e.chunks
 }
 
+// sourceDone closes the chunks channel once the last source has finished sending chunks.
+func (e *Engine) sourceDone() {
+	if atomic.AddInt32(&e.sources, -1) == 0 {
+		close(e.chunks)
+	}
+}
+
+type sourceNameKey struct{}
+
+// WithSourceName returns a context that makes the scan started with it name its source, and so its results, name
+// rather than the default, such as "trufflehog - git".
+func WithSourceName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, sourceNameKey{}, name)
+}
+
+// sourceName returns the source name set with WithSourceName, or def if there is none.
+func sourceName(ctx context.Context, def string) string {
+	if name, ok := ctx.Value(sourceNameKey{}).(string); ok && name != "" {
+		return name
+	}
+	return def
+}
+
 func (e *Engine) ResultsChan() chan detectors.ResultWithMetadata {
 	return e.results
 }
@@ -125,6 +395,68 @@ func (e *Engine) ChunksScanned() uint64 {
 	return e.chunksScanned
 }
 
+// Summary returns statistics of the scan. It should be called after the results channel has been drained.
+func (e *Engine) Summary() Summary {
+	summary := Summary{
+		ScanID:        e.scanID,
+		Duration:      time.Duration(atomic.LoadInt64((*int64)(&e.duration))),
+		ChunksScanned: atomic.LoadUint64(&e.chunksScanned),
+		BytesScanned:  atomic.LoadUint64(&e.bytesScanned),
+		Detectors:     map[string]DetectorStats{},
+	}
+	e.suppressed.Range(func(k, v interface{}) bool {
+		if summary.Suppressed == nil {
+			summary.Suppressed = map[string]uint64{}
+		}
+		summary.Suppressed[k.(string)] = atomic.LoadUint64(v.(*uint64))
+		return true
+	})
+	e.detectorStats.Range(func(k, v interface{}) bool {
+		counters := v.(*detectorCounters)
+		summary.Detectors[k.(string)] = DetectorStats{
+			Calls:    atomic.LoadUint64(&counters.calls),
+			Duration: time.Duration(atomic.LoadUint64(&counters.nanos)),
+			Results:  atomic.LoadUint64(&counters.results),
+			Verified: atomic.LoadUint64(&counters.verified),
+		}
+		return true
+	})
+
+	e.progressMu.Lock()
+	defer e.progressMu.Unlock()
+	for _, progress := range e.progress {
+		summary.SkippedFiles = append(summary.SkippedFiles, progress.SkippedFiles()...)
+		summary.SkippedRepos = append(summary.SkippedRepos, progress.SkippedRepos()...)
+	}
+	if api := common.SourceAPIStats(); api.Requests > 0 {
+		summary.API = &api
+	}
+	return summary
+}
+
+// trackProgress includes the source's progress, such as skipped files, in the scan summary.
+func (e *Engine) trackProgress(progress *sources.Progress) {
+	e.progressMu.Lock()
+	defer e.progressMu.Unlock()
+	e.progress = append(e.progress, progress)
+}
+
+func (e *Engine) recordDetectorRun(name string, elapsed time.Duration, results []detectors.Result) {
+	v, ok := e.detectorStats.Load(name)
+	if !ok {
+		v, _ = e.detectorStats.LoadOrStore(name, &detectorCounters{})
+	}
+	counters := v.(*detectorCounters)
+	atomic.AddUint64(&counters.calls, 1)
+	atomic.AddUint64(&counters.nanos, uint64(elapsed))
+	atomic.AddUint64(&counters.results, uint64(len(results)))
+	for _, r := range results {
+		if r.Verified {
+			atomic.AddUint64(&counters.verified, 1)
+		}
+	}
+}
+
 func (e *Engine) DetectorAvgTime() map[string][]time.Duration {
 	avgTime := map[string][]time.Duration{}
 	e.detectorAvgTime.Range(func(k, v interface{}) bool {
@@ -146,20 +478,41 @@ func (e *Engine) DetectorAvgTime() map[string][]time.Duration {
 }
 
 func (e *Engine) detectorWorker(ctx context.Context) {
-	for chunk := range e.chunks {
+	for {
+		var chunk *sources.Chunk
+		select {
+		case <-ctx.Done():
+			return
+		case c, ok := <-e.chunks:
+			if !ok {
+				return
+			}
+			chunk = c
+		}
+		chunk.Data = common.NormalizeLineEndings(chunk.Data)
 		fragStart, mdLine := fragmentFirstLine(chunk)
 		for _, decoder := range e.decoders {
 			decoded := decoder.FromChunk(chunk)
 			if decoded == nil {
 				continue
 			}
-			dataLower := strings.ToLower(string(decoded.Data))
+			dataFolded := detectors.FoldCase(string(decoded.Data))
+			var lang *lexer.Language
+			if e.extractStrings {
+				lang = lexer.ForPath(detectors.FilePath(chunk.SourceMetadata))
+			}
+			// spans are only lexed once a detector finds a result in the chunk.
+			var spans []lexer.Span
+			lexed := false
 			for verify, detectorsSet := range e.detectors {
-				for _, detector := range detectorsSet {
+				for i, detector := range detectorsSet {
+					if common.IsDone(ctx) {
+						return
+					}
 					start := time.Now()
-					foundKeyword := false
-					for _, kw := range detector.Keywords() {
-						if strings.Contains(dataLower, strings.ToLower(kw)) {
+					foundKeyword := detectors.IsUnfiltered(detector)
+					for _, kw := range e.keywords[verify][i] {
+						if strings.Contains(dataFolded, kw) {
 							foundKeyword = true
 							break
 						}
@@ -167,23 +520,107 @@ func (e *Engine) detectorWorker(ctx context.Context) {
 					if !foundKeyword {
 						continue
 					}
-					ctx, cancel := context.WithTimeout(ctx, time.Second*10)
-					defer cancel()
-					results, err := detector.FromData(ctx, verify, decoded.Data)
+					detectorCtx, cancel := context.WithTimeout(common.WithScanContext(ctx, ctx), time.Second*10)
+					if verify {
+						detectorCtx = common.WithDetector(detectorCtx, DetectorName(detector))
+					}
+					results, err := detector.FromData(detectorCtx, verify, decoded.Data)
+					cancel()
+					e.recordDetectorRun(DetectorName(detector), time.Since(start), results)
 					if err != nil {
-						logrus.WithFields(logrus.Fields{
+						if common.IsDone(ctx) {
+							// The scan was canceled, so the error is expected.
+							return
+						}
+						logging.FromContext(ctx).WithFields(logrus.Fields{
 							"source_type": decoded.SourceType.String(),
 							"metadata":    decoded.SourceMetadata,
+							"detector":    DetectorName(detector),
+							"unit_id":     chunk.UnitID,
 						}).WithError(err).Error("could not scan chunk")
 						continue
 					}
 					for _, result := range results {
+						if result.Severity == detectors.SeverityUnknown {
+							result.Severity = detectors.DefaultSeverity(result.Verified)
+						}
+						if result.Remediation == nil {
+							result.Remediation = detectors.RemediationFor(result.DetectorType)
+						}
+						for k, v := range chunk.ExtraData {
+							if result.ExtraData == nil {
+								result.ExtraData = map[string]string{}
+							}
+							if _, ok := result.ExtraData[k]; !ok {
+								result.ExtraData[k] = v
+							}
+						}
+						if path := detectors.FilePath(chunk.SourceMetadata); path != "" {
+							if pathAware, ok := detector.(detectors.PathAware); ok {
+								pathAware.AdjustForPath(&result, path)
+							}
+							e.pathRules.Apply(&result, path)
+						}
+						e.tagSecretName(&result, decoded.Data)
+						tagOriginal(&result, chunk)
+						inCode := false
+						if lang != nil {
+							if !lexed {
+								spans, lexed = lang.Spans(decoded.Data), true
+							}
+							// Secrets that don't occur in the data as is, such as those a detector assembled from
+							// parts, are neither tagged nor suppressed.
+							if kind, found := lexer.Locate(spans, decoded.Data, result.Raw); found && kind != lexer.Code {
+								if result.ExtraData == nil {
+									result.ExtraData = map[string]string{}
+								}
+								result.ExtraData["found_in"] = kind.String()
+							} else if found {
+								inCode = true
+							}
+						}
+						keep, suppressed := e.keep(&result)
+						if keep && inCode && !result.Verified {
+							keep, suppressed = false, SuppressedCode
+						}
+						if !keep && suppressed == "" {
+							continue
+						}
 						if isGitSource(chunk.SourceType) {
 							offset := FragmentLineOffset(chunk, &result)
 							*mdLine = fragStart + offset
 						}
-						e.results <- detectors.CopyMetadata(chunk, result)
-
+						r := detectors.CopyMetadata(chunk, result)
+						r.ScanID = e.scanID
+						r.Entropy = detectors.ShannonEntropy(string(result.Raw))
+						if e.contextLines > 0 {
+							r.Context = detectors.Snippet(decoded.Data, result.Raw, e.contextLines)
+						}
+						if !keep {
+							e.suppress(r, suppressed)
+							continue
+						}
+						if rule, ok := e.policy.Evaluate(&r); ok {
+							if r.ExtraData == nil {
+								r.ExtraData = map[string]string{}
+							}
+							r.ExtraData[policy.ActionKey] = string(rule.Action)
+							r.ExtraData[policy.RuleKey] = rule.Name
+							if rule.Action == policy.ActionIgnore {
+								e.suppress(r, SuppressedPolicy)
+								continue
+							}
+						}
+						select {
+						case e.results <- r:
+						case <-ctx.Done():
+							// Nothing is receiving results after a canceled scan.
+							return
+						}
+						atomic.AddUint64(&e.resultsSent, 1)
+						if r.Verified {
+							atomic.AddUint64(&e.verifiedSent, 1)
+						}
 					}
 					if len(results) > 0 {
 						elapsed := time.Since(start)
@@ -203,7 +640,121 @@ func (e *Engine) detectorWorker(ctx context.Context) {
 			}
 		}
 		atomic.AddUint64(&e.chunksScanned, 1)
+		atomic.AddUint64(&e.bytesScanned, uint64(len(chunk.Data)))
+	}
+}
+
+// tagSecretName tags the result if it's assigned to a variable named after a CI or managed secret in the data.
+func (e *Engine) tagSecretName(result *detectors.Result, data []byte) {
+	name, scope, ok := e.secretNames.Near(data, result.Raw)
+	if !ok {
+		return
+	}
+	result.Severity = detectors.SeverityCritical
+	if result.ExtraData == nil {
+		result.ExtraData = map[string]string{}
+	}
+	result.ExtraData["secret_name"] = name
+	result.ExtraData["secret_name_scope"] = scope
+}
+
+// tagOriginal tags the result with the file and line of the original source it was found in, if the chunk was
+// generated from one.
+func tagOriginal(result *detectors.Result, chunk *sources.Chunk) {
+	if chunk.Original == nil {
+		return
+	}
+	i := bytes.Index(chunk.Data, result.Raw)
+	if i < 0 || len(result.Raw) == 0 {
+		return
+	}
+	line := bytes.Count(chunk.Data[:i], []byte("\n"))
+	column := i - (bytes.LastIndexByte(chunk.Data[:i], '\n') + 1)
+	file, originalLine, ok := chunk.Original.OriginalPosition(line, column)
+	if !ok {
+		return
+	}
+	if result.ExtraData == nil {
+		result.ExtraData = map[string]string{}
+	}
+	result.ExtraData["original_file"] = file
+	result.ExtraData["original_line"] = strconv.Itoa(originalLine)
+}
+
+// keep returns true if the result passes the engine's filters. Managed and allowlisted results may be tagged. If the
+// result is dropped by an allowlist or false positive filter, rather than a filter such as the minimum severity, the
+// reason it's suppressed is also returned.
+func (e *Engine) keep(result *detectors.Result) (bool, string) {
+	if store, ok := e.secrets.Lookup(result.Raw); ok {
+		result.Severity = detectors.SeverityCritical
+		if result.ExtraData == nil {
+			result.ExtraData = map[string]string{}
+		}
+		result.ExtraData["managed_secret"] = "true"
+		result.ExtraData["secret_store"] = store
+	}
+	if e.onlyVerified && !result.Verified {
+		return false, ""
+	}
+	if result.Severity < e.minSeverity {
+		return false, ""
+	}
+	if e.detectorTypes != nil {
+		if _, ok := e.detectorTypes[result.DetectorType]; !ok {
+			return false, ""
+		}
+	}
+	if !result.Verified && e.falsePositives.IsFalsePositive(result.DetectorType, string(result.Raw)) {
+		return false, SuppressedFalsePositive
+	}
+	if e.allowlist != nil && e.allowlist.Contains(result.Raw) {
+		if !e.tagAllowed {
+			return false, SuppressedAllowlist
+		}
+		if result.ExtraData == nil {
+			result.ExtraData = map[string]string{}
+		}
+		result.ExtraData["allowlisted"] = "true"
+	}
+	if pattern, ok := e.allowPatterns.Match(result.Raw); ok && !result.Verified {
+		if !e.tagAllowed {
+			return false, SuppressedAllowlistPattern
+		}
+		if result.ExtraData == nil {
+			result.ExtraData = map[string]string{}
+		}
+		result.ExtraData["allowlisted"] = "true"
+		result.ExtraData["allowlist_pattern"] = pattern
+	}
+	return true, ""
+}
+
+// suppress counts a result dropped by an allowlist or false positive filter, and passes it to onSuppressed.
+func (e *Engine) suppress(r detectors.ResultWithMetadata, reason string) {
+	v, ok := e.suppressed.Load(reason)
+	if !ok {
+		v, _ = e.suppressed.LoadOrStore(reason, new(uint64))
+	}
+	atomic.AddUint64(v.(*uint64), 1)
+	if e.onSuppressed == nil {
+		return
+	}
+	extra := make(map[string]string, len(r.ExtraData)+1)
+	for k, v := range r.ExtraData {
+		extra[k] = v
+	}
+	extra["suppressed"] = reason
+	r.ExtraData = extra
+	e.onSuppressed(r)
+}
+
+// DetectorName returns the package name of a detector, e.g. "aws" for *aws.Scanner.
+func DetectorName(d detectors.Detector) string {
+	name := strings.TrimPrefix(reflect.TypeOf(d).String(), "*")
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[:i]
 	}
+	return name
 }
 
 // gitSources is a list of sources that utilize the Git source. It is stored this way because slice consts are not
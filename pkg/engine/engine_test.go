@@ -0,0 +1,560 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/allowlist"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/decoders"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/policy"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/secretstore"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// fakeDetector finds every occurrence of "secret=" and considers it verified if verification is enabled.
+type fakeDetector struct{}
+
+func (fakeDetector) Keywords() []string {
+	return []string{"secret"}
+}
+
+func (fakeDetector) FromData(_ context.Context, verify bool, data []byte) ([]detectors.Result, error) {
+	var results []detectors.Result
+	for i := 0; i < bytes.Count(data, []byte("secret=")); i++ {
+		results = append(results, detectors.Result{
+			DetectorType: detectorspb.DetectorType_Generic,
+			Raw:          []byte("secret"),
+			Verified:     verify,
+		})
+	}
+	return results, nil
+}
+
+func TestEngineSummary(t *testing.T) {
+	ctx := context.Background()
+	e := Start(ctx,
+		WithConcurrency(1),
+		WithDecoders(&decoders.Plain{}),
+		WithDetectors(true, fakeDetector{}),
+	)
+
+	chunks := []string{"secret=1 secret=2", "nothing to see here", "secret=3"}
+	go func() {
+		for _, data := range chunks {
+			e.ChunksChan() <- &sources.Chunk{Data: []byte(data)}
+		}
+		close(e.ChunksChan())
+	}()
+	for range e.ResultsChan() {
+	}
+
+	summary := e.Summary()
+	if summary.ChunksScanned != uint64(len(chunks)) {
+		t.Errorf("unexpected chunks scanned. Got: %d, Expected: %d", summary.ChunksScanned, len(chunks))
+	}
+	var wantBytes uint64
+	for _, data := range chunks {
+		wantBytes += uint64(len(data))
+	}
+	if summary.BytesScanned != wantBytes {
+		t.Errorf("unexpected bytes scanned. Got: %d, Expected: %d", summary.BytesScanned, wantBytes)
+	}
+	if summary.Duration <= 0 {
+		t.Errorf("expected a scan duration, got %s", summary.Duration)
+	}
+
+	stats, ok := summary.Detectors["engine"]
+	if !ok {
+		t.Fatalf("missing detector stats: %+v", summary.Detectors)
+	}
+	if stats.Calls != 2 {
+		t.Errorf("unexpected detector calls. Got: %d, Expected: %d", stats.Calls, 2)
+	}
+	if stats.Results != 3 || stats.Verified != 3 {
+		t.Errorf("unexpected detector results. Got: %d (%d verified), Expected: 3 (3 verified)", stats.Results, stats.Verified)
+	}
+}
+
+func TestEngineFilters(t *testing.T) {
+	managed := secretstore.NewInventory()
+	managed.Add("vault", []byte("secret"))
+	placeholders, err := allowlist.NewPatterns([]string{"sec.*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		verify  bool
+		options []EngineOption
+		want    int
+	}{
+		"no filters": {
+			want: 2,
+		},
+		"only verified drops unverified": {
+			options: []EngineOption{WithOnlyVerified(true)},
+			want:    0,
+		},
+		"only verified keeps verified": {
+			verify:  true,
+			options: []EngineOption{WithOnlyVerified(true)},
+			want:    2,
+		},
+		"unverified results default to low severity": {
+			options: []EngineOption{WithMinSeverity(detectors.SeverityMedium)},
+			want:    0,
+		},
+		"verified results default to high severity": {
+			verify:  true,
+			options: []EngineOption{WithMinSeverity(detectors.SeverityHigh)},
+			want:    2,
+		},
+		"matching detector type": {
+			options: []EngineOption{WithDetectorTypes(detectorspb.DetectorType_Generic)},
+			want:    2,
+		},
+		"other detector type": {
+			options: []EngineOption{WithDetectorTypes(detectorspb.DetectorType_AWS)},
+			want:    0,
+		},
+		"allowlisted": {
+			options: []EngineOption{WithAllowlist(allowlist.New(allowlist.Hash([]byte("secret"))), false)},
+			want:    0,
+		},
+		"allowlisted and tagged": {
+			options: []EngineOption{WithAllowlist(allowlist.New(allowlist.Hash([]byte("secret"))), true)},
+			want:    2,
+		},
+		"allowlist pattern": {
+			options: []EngineOption{WithAllowlistPatterns(placeholders, false)},
+			want:    0,
+		},
+		"allowlist pattern keeps verified": {
+			verify:  true,
+			options: []EngineOption{WithAllowlistPatterns(placeholders, false)},
+			want:    2,
+		},
+		"managed secrets are critical": {
+			options: []EngineOption{WithSecretInventory(managed), WithMinSeverity(detectors.SeverityCritical)},
+			want:    2,
+		},
+	}
+	for name, test := range tests {
+		options := append([]EngineOption{
+			WithConcurrency(1),
+			WithDecoders(&decoders.Plain{}),
+			WithDetectors(test.verify, fakeDetector{}),
+		}, test.options...)
+		e := Start(context.Background(), options...)
+		go func() {
+			e.ChunksChan() <- &sources.Chunk{Data: []byte("secret=1 secret=2")}
+			close(e.ChunksChan())
+		}()
+		got := 0
+		for range e.ResultsChan() {
+			got++
+		}
+		if got != test.want {
+			t.Errorf("%s: unexpected number of results. Got: %d, Expected: %d", name, got, test.want)
+		}
+	}
+}
+
+func TestEngineSuppressed(t *testing.T) {
+	placeholders, err := allowlist.NewPatterns([]string{"sec.*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	var suppressed []detectors.ResultWithMetadata
+	e := Start(context.Background(),
+		WithConcurrency(1),
+		WithDecoders(&decoders.Plain{}),
+		WithDetectors(false, fakeDetector{}),
+		WithAllowlistPatterns(placeholders, false),
+		WithMinSeverity(detectors.SeverityLow),
+		WithSuppressedResults(func(r detectors.ResultWithMetadata) {
+			mu.Lock()
+			defer mu.Unlock()
+			suppressed = append(suppressed, r)
+		}),
+	)
+	go func() {
+		e.ChunksChan() <- &sources.Chunk{Data: []byte("secret=1 secret=2"), ExtraData: map[string]string{"codeowners": "@acme"}}
+		close(e.ChunksChan())
+	}()
+	for r := range e.ResultsChan() {
+		t.Errorf("expected every result to be suppressed, got %v", r)
+	}
+
+	if got := e.Summary().Suppressed; len(got) != 1 || got[SuppressedAllowlistPattern] != 2 {
+		t.Errorf("unexpected suppressed counts: %v", got)
+	}
+	if len(suppressed) != 2 {
+		t.Fatalf("got %d suppressed results, want 2", len(suppressed))
+	}
+	if extra := suppressed[0].ExtraData; extra["suppressed"] != SuppressedAllowlistPattern || extra["codeowners"] != "@acme" {
+		t.Errorf("unexpected extra data of suppressed result: %v", extra)
+	}
+}
+
+func TestEngineSecretNames(t *testing.T) {
+	names := secretstore.NewNames()
+	names.Add("actions:trufflesecurity", "APP_SECRET")
+
+	e := Start(context.Background(),
+		WithConcurrency(1),
+		WithDecoders(&decoders.Plain{}),
+		WithDetectors(false, fakeDetector{}),
+		WithSecretNames(names),
+	)
+	go func() {
+		e.ChunksChan() <- &sources.Chunk{Data: []byte("APP_SECRET=secret=1")}
+		close(e.ChunksChan())
+	}()
+
+	var results []detectors.ResultWithMetadata
+	for r := range e.ResultsChan() {
+		results = append(results, r)
+	}
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	r := results[0]
+	if r.Severity != detectors.SeverityCritical || r.ExtraData["secret_name"] != "APP_SECRET" ||
+		r.ExtraData["secret_name_scope"] != "actions:trufflesecurity" {
+		t.Errorf("got severity %s and extra data %v", r.Severity, r.ExtraData)
+	}
+}
+
+func TestEnginePathRules(t *testing.T) {
+	rules, err := detectors.NewPathRules([]detectors.PathRule{
+		{Patterns: []string{"prod/**"}, Severity: detectors.SeverityHigh},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := Start(context.Background(),
+		WithConcurrency(1),
+		WithDecoders(&decoders.Plain{}),
+		WithDetectors(false, fakeDetector{}),
+		WithPathRules(rules),
+		WithMinSeverity(detectors.SeverityMedium),
+	)
+	go func() {
+		for _, file := range []string{"repo/prod/app.env", "repo/dev/app.env"} {
+			e.ChunksChan() <- &sources.Chunk{
+				Data: []byte("secret=1"),
+				SourceMetadata: &source_metadatapb.MetaData{
+					Data: &source_metadatapb.MetaData_Filesystem{Filesystem: &source_metadatapb.Filesystem{File: file}},
+				},
+			}
+		}
+		close(e.ChunksChan())
+	}()
+
+	var results []detectors.ResultWithMetadata
+	for r := range e.ResultsChan() {
+		results = append(results, r)
+	}
+	// The unverified result in dev is low severity, and is filtered out.
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	r := results[0]
+	if r.SourceMetadata.GetFilesystem().GetFile() != "repo/prod/app.env" || r.Severity != detectors.SeverityHigh ||
+		r.ExtraData["path_rule"] != "prod/**" {
+		t.Errorf("got %s with severity %s and extra data %v", r.SourceMetadata.GetFilesystem().GetFile(), r.Severity, r.ExtraData)
+	}
+}
+
+func TestEnginePolicy(t *testing.T) {
+	p, err := policy.New([]policy.Rule{
+		{Name: "fixtures", When: `file.startsWith("test/")`, Action: policy.ActionIgnore},
+		{Name: "production", When: `file.contains("prod")`, Action: policy.ActionFail},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var suppressed []detectors.ResultWithMetadata
+	e := Start(context.Background(),
+		WithConcurrency(1),
+		WithDecoders(&decoders.Plain{}),
+		WithDetectors(false, fakeDetector{}),
+		WithPolicy(p),
+		WithSuppressedResults(func(r detectors.ResultWithMetadata) {
+			suppressed = append(suppressed, r)
+		}),
+	)
+	go func() {
+		for _, file := range []string{"test/app.env", "prod/app.env", "dev/app.env"} {
+			e.ChunksChan() <- &sources.Chunk{
+				Data: []byte("secret=1"),
+				SourceMetadata: &source_metadatapb.MetaData{
+					Data: &source_metadatapb.MetaData_Filesystem{Filesystem: &source_metadatapb.Filesystem{File: file}},
+				},
+			}
+		}
+		close(e.ChunksChan())
+	}()
+
+	actions := map[string]string{}
+	for r := range e.ResultsChan() {
+		actions[r.SourceMetadata.GetFilesystem().GetFile()] = r.ExtraData[policy.ActionKey] + "/" + r.ExtraData[policy.RuleKey]
+	}
+	if len(actions) != 2 || actions["prod/app.env"] != "fail/production" || actions["dev/app.env"] != "/" {
+		t.Errorf("unexpected actions: %v", actions)
+	}
+	if len(suppressed) != 1 || suppressed[0].ExtraData["suppressed"] != SuppressedPolicy || suppressed[0].ExtraData[policy.RuleKey] != "fixtures" {
+		t.Errorf("unexpected suppressed results: %v", suppressed)
+	}
+	if got := e.Summary().Suppressed; got[SuppressedPolicy] != 1 {
+		t.Errorf("unexpected suppressed counts: %v", got)
+	}
+}
+
+func TestEngineStringExtraction(t *testing.T) {
+	e := Start(context.Background(),
+		WithConcurrency(1),
+		WithDecoders(&decoders.Plain{}),
+		WithDetectors(false, fakeDetector{}),
+		WithStringExtraction(true),
+	)
+	chunks := []struct{ file, data string }{
+		{"cmd/main.go", "secret=1"},
+		{"cmd/config.go", `x := "secret=1"`},
+		{"config/app.env", "secret=1"},
+	}
+	go func() {
+		for _, c := range chunks {
+			e.ChunksChan() <- &sources.Chunk{
+				Data: []byte(c.data),
+				SourceMetadata: &source_metadatapb.MetaData{
+					Data: &source_metadatapb.MetaData_Filesystem{Filesystem: &source_metadatapb.Filesystem{File: c.file}},
+				},
+			}
+		}
+		close(e.ChunksChan())
+	}()
+
+	found := map[string]string{}
+	for r := range e.ResultsChan() {
+		found[r.SourceMetadata.GetFilesystem().GetFile()] = r.ExtraData["found_in"]
+	}
+	if _, ok := found["cmd/main.go"]; ok {
+		t.Error("expected the result in code to be suppressed")
+	}
+	if found["cmd/config.go"] != "string" {
+		t.Errorf("expected the result in a string to be tagged, got %v", found)
+	}
+	if in, ok := found["config/app.env"]; !ok || in != "" {
+		t.Errorf("expected the result in a file without a lexer to be kept as is, got %v", found)
+	}
+	if got := e.Summary().Suppressed; got[SuppressedCode] != 1 {
+		t.Errorf("unexpected suppressed counts: %v", got)
+	}
+}
+
+// lineMapper maps every line of a chunk to the same line of original.js.
+type lineMapper struct{}
+
+func (lineMapper) OriginalPosition(line, _ int) (string, int, bool) {
+	return "original.js", line + 1, true
+}
+
+func TestEngineOriginalPosition(t *testing.T) {
+	e := Start(context.Background(),
+		WithConcurrency(1),
+		WithDecoders(&decoders.Plain{}),
+		WithDetectors(false, fakeDetector{}),
+	)
+	go func() {
+		e.ChunksChan() <- &sources.Chunk{Data: []byte("a\nb\nsecret=1"), Original: lineMapper{}}
+		close(e.ChunksChan())
+	}()
+
+	var results []detectors.ResultWithMetadata
+	for r := range e.ResultsChan() {
+		results = append(results, r)
+	}
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	if extra := results[0].ExtraData; extra["original_file"] != "original.js" || extra["original_line"] != "3" {
+		t.Errorf("unexpected extra data: %v", extra)
+	}
+}
+
+func TestEngineCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	e := Start(ctx,
+		WithConcurrency(2),
+		WithDecoders(&decoders.Plain{}),
+		WithDetectors(false, fakeDetector{}),
+	)
+
+	// The chunks channel is never closed, as happens when a source is stuck on a pathological repo.
+	e.ChunksChan() <- &sources.Chunk{Data: []byte("secret=1")}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		for range e.ResultsChan() {
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("results channel was not closed after the context was cancelled")
+	}
+
+	// Sources blocked on sending chunks are released.
+	select {
+	case e.ChunksChan() <- &sources.Chunk{Data: []byte("secret=2")}:
+	case <-time.After(5 * time.Second):
+		t.Fatal("chunks are not drained after the context was cancelled")
+	}
+}
+
+func TestEngineCancelUnreadResults(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	e := Start(ctx,
+		WithConcurrency(1),
+		WithDecoders(&decoders.Plain{}),
+		WithDetectors(false, fakeDetector{}),
+	)
+
+	// Nothing receives the results, so the worker blocks sending the first one until the context is cancelled.
+	e.ChunksChan() <- &sources.Chunk{Data: []byte("secret=1 secret=2")}
+	cancel()
+
+	deadline := time.After(5 * time.Second)
+	for e.Summary().Duration == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("workers did not stop after the context was cancelled")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if _, ok := <-e.ResultsChan(); ok {
+		t.Error("expected the results channel to be closed")
+	}
+}
+
+func TestEngineResultContext(t *testing.T) {
+	e := Start(context.Background(),
+		WithConcurrency(1),
+		WithDecoders(&decoders.Plain{}),
+		WithDetectors(false, fakeDetector{}),
+		WithContextLines(1),
+	)
+	go func() {
+		e.ChunksChan() <- &sources.Chunk{Data: []byte("first\nsecond\nsecret=1\nfourth\nfifth")}
+		close(e.ChunksChan())
+	}()
+
+	var results []detectors.ResultWithMetadata
+	for r := range e.ResultsChan() {
+		results = append(results, r)
+	}
+	if len(results) != 1 {
+		t.Fatalf("unexpected number of results. Got: %d, Expected: 1", len(results))
+	}
+	if want := "second\nsecret=1\nfourth"; results[0].Context != want {
+		t.Errorf("unexpected context. Got: %q, Expected: %q", results[0].Context, want)
+	}
+	// The entropy is compared with a tolerance, as it may be computed with different rounding than here.
+	if want := detectors.ShannonEntropy("secret"); math.Abs(results[0].Entropy-want) > 1e-9 {
+		t.Errorf("unexpected entropy. Got: %v, Expected: %v", results[0].Entropy, want)
+	}
+}
+
+// fakeSource sends a chunk for each of its strings.
+type fakeSource []string
+
+func (s fakeSource) Chunks(ctx context.Context, chunksChan chan *sources.Chunk) error {
+	for _, data := range s {
+		select {
+		case chunksChan <- &sources.Chunk{Data: []byte(data)}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func TestEngineAPI(t *testing.T) {
+	ctx := context.Background()
+	e := New(ctx,
+		WithConcurrency(1),
+		WithDecoders(&decoders.Plain{}),
+		WithDetectors(true, fakeDetector{}),
+	)
+
+	if err := e.AddSource(ctx, fakeSource{"secret=1", "nothing to see here"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.AddSource(ctx, fakeSource{"secret=2 secret=3"}); err != nil {
+		t.Fatal(err)
+	}
+	e.Finish()
+	e.Finish()
+	if err := e.AddSource(ctx, fakeSource{"secret=4"}); err != ErrFinished {
+		t.Errorf("expected ErrFinished after Finish, got: %v", err)
+	}
+
+	got := 0
+	for range e.Results() {
+		got++
+	}
+	if got != 3 {
+		t.Errorf("unexpected number of results. Got: %d, Expected: 3", got)
+	}
+
+	stats := e.Stats()
+	want := Stats{SourcesAdded: 2, ChunksScanned: 3, BytesScanned: 44, Results: 3, VerifiedResults: 3}
+	elapsed := stats.Elapsed
+	stats.Elapsed = 0
+	if stats != want {
+		t.Errorf("unexpected stats. Got: %+v, Expected: %+v", stats, want)
+	}
+	if elapsed <= 0 {
+		t.Errorf("expected a scan duration, got %s", elapsed)
+	}
+}
+
+func TestEngineCorrelationIDs(t *testing.T) {
+	ctx := logging.WithScanID(context.Background(), "scan-1")
+	e := Start(ctx,
+		WithConcurrency(1),
+		WithDecoders(&decoders.Plain{}),
+		WithDetectors(false, fakeDetector{}),
+	)
+	go func() {
+		e.ChunksChan() <- &sources.Chunk{Data: []byte("secret=1"), UnitID: "unit-1"}
+		close(e.ChunksChan())
+	}()
+
+	got := 0
+	for r := range e.ResultsChan() {
+		got++
+		if r.ScanID != "scan-1" || r.UnitID != "unit-1" {
+			t.Errorf("unexpected IDs. Got scan ID %q and unit ID %q", r.ScanID, r.UnitID)
+		}
+	}
+	if got != 1 {
+		t.Errorf("unexpected number of results. Got: %d, Expected: 1", got)
+	}
+	if summary := e.Summary(); summary.ScanID != "scan-1" {
+		t.Errorf("unexpected summary scan ID %q", summary.ScanID)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/go-errors/errors"
 	"github.com/sirupsen/logrus"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/filesystem"
 	"google.golang.org/protobuf/proto"
@@ -12,6 +13,7 @@ import (
 )
 
 func (e *Engine) ScanFileSystem(ctx context.Context, directories []string) error {
+	ctx = logging.WithModule(ctx, "sources.filesystem")
 	connection := &sourcespb.Filesystem{
 		Directories: directories,
 	}
@@ -23,16 +25,17 @@ func (e *Engine) ScanFileSystem(ctx context.Context, directories []string) error
 	}
 
 	fileSystemSource := filesystem.Source{}
-	err = fileSystemSource.Init(ctx, "trufflehog - filesystem", 0, int64(sourcespb.SourceType_SOURCE_TYPE_FILESYSTEM), true, &conn, runtime.NumCPU())
+	err = fileSystemSource.Init(ctx, sourceName(ctx, "trufflehog - filesystem"), 0, int64(sourcespb.SourceType_SOURCE_TYPE_FILESYSTEM), true, &conn, runtime.NumCPU())
 	if err != nil {
 		return errors.WrapPrefix(err, "could not init filesystem source", 0)
 	}
+	e.trackProgress(fileSystemSource.GetProgress())
 	go func() {
 		err := fileSystemSource.Chunks(ctx, e.ChunksChan())
 		if err != nil {
-			logrus.WithError(err).Error("error scanning filesystem")
+			logging.FromContext(ctx).WithError(err).Error("error scanning filesystem")
 		}
-		close(e.ChunksChan())
+		e.sourceDone()
 	}()
 	return nil
 }
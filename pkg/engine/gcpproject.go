@@ -0,0 +1,23 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/gcpproject"
+)
+
+// ScanGCPProject sweeps the resources of GCP projects that commonly embed credentials.
+func (e *Engine) ScanGCPProject(ctx context.Context, src *gcpproject.Source) error {
+	ctx = logging.WithModule(ctx, "sources.gcpproject")
+	src.SourceName = sourceName(ctx, "trufflehog - gcp")
+
+	go func() {
+		err := src.Chunks(ctx, e.ChunksChan())
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("could not sweep gcp projects")
+		}
+		e.sourceDone()
+	}()
+	return nil
+}
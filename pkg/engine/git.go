@@ -9,14 +9,29 @@ import (
 
 	gogit "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
-	"github.com/sirupsen/logrus"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/git"
 )
 
+type repoLimitsKey struct{}
+
+// WithRepoLimits returns a context that makes the scans started with it skip the repositories over the limits, such
+// as those of a GitHub organization, and report them in the summary.
+func WithRepoLimits(ctx context.Context, limits git.RepoLimits) context.Context {
+	return context.WithValue(ctx, repoLimitsKey{}, limits)
+}
+
+// repoLimits returns the limits set with WithRepoLimits, or no limits if there are none.
+func repoLimits(ctx context.Context) git.RepoLimits {
+	limits, _ := ctx.Value(repoLimitsKey{}).(git.RepoLimits)
+	return limits
+}
+
 func (e *Engine) ScanGit(ctx context.Context, repoPath, headRef, baseRef string, maxDepth int, filter *common.Filter) error {
+	ctx = logging.WithModule(ctx, "sources.git")
 	logOptions := &gogit.LogOptions{}
 	opts := []git.ScanOption{
 		git.ScanOptionFilter(filter),
@@ -86,17 +101,21 @@ func (e *Engine) ScanGit(ctx context.Context, repoPath, headRef, baseRef string,
 	}
 	scanOptions := git.NewScanOptions(opts...)
 
-	gitSource := git.NewGit(sourcespb.SourceType_SOURCE_TYPE_GIT, 0, 0, "trufflehog - git", true, runtime.NumCPU(),
-		func(file, email, commit, timestamp, repository string, line int64) *source_metadatapb.MetaData {
+	gitSource := git.NewGit(sourcespb.SourceType_SOURCE_TYPE_GIT, 0, 0, sourceName(ctx, "trufflehog - git"), true, runtime.NumCPU(),
+		func(file, email, commit, timestamp, repository string, line int64, authorName, committerName, committerEmail, commitTimestamp string) *source_metadatapb.MetaData {
 			return &source_metadatapb.MetaData{
 				Data: &source_metadatapb.MetaData_Git{
 					Git: &source_metadatapb.Git{
-						Commit:     commit,
-						File:       file,
-						Email:      email,
-						Repository: repository,
-						Timestamp:  timestamp,
-						Line:       line,
+						Commit:          commit,
+						File:            file,
+						Email:           email,
+						Repository:      repository,
+						Timestamp:       timestamp,
+						Line:            line,
+						AuthorName:      authorName,
+						CommitterName:   committerName,
+						CommitterEmail:  committerEmail,
+						CommitTimestamp: commitTimestamp,
 					},
 				},
 			}
@@ -105,9 +124,9 @@ func (e *Engine) ScanGit(ctx context.Context, repoPath, headRef, baseRef string,
 	go func() {
 		err := gitSource.ScanRepo(ctx, repo, repoPath, scanOptions, e.ChunksChan())
 		if err != nil {
-			logrus.WithError(err).Fatal("could not scan repo")
+			logging.FromContext(ctx).WithError(err).Error("could not scan repo")
 		}
-		close(e.ChunksChan())
+		e.sourceDone()
 	}()
 	return nil
 }
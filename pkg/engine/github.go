@@ -8,17 +8,29 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/github"
 )
 
-func (e *Engine) ScanGitHub(ctx context.Context, endpoint string, repos, orgs []string, token string, includeForks bool, filter *common.Filter, concurrency int, includeMembers bool) error {
+type githubSnapshotKey struct{}
+
+// WithGitHubSnapshot returns a context that makes the GitHub scan started with it list organizations' repositories
+// through the snapshot, reusing the repositories listed by earlier scans.
+func WithGitHubSnapshot(ctx context.Context, opts github.SnapshotOptions) context.Context {
+	return context.WithValue(ctx, githubSnapshotKey{}, opts)
+}
+
+func (e *Engine) ScanGitHub(ctx context.Context, endpoint string, repos, orgs []string, token string, includeForks bool, filter *common.Filter, concurrency int, includeMembers bool, includeRepos, excludeRepos []string) error {
+	ctx = logging.WithModule(ctx, "sources.github")
 	source := github.Source{}
 	connection := sourcespb.GitHub{
 		Endpoint:      endpoint,
 		Organizations: orgs,
 		Repositories:  repos,
 		ScanUsers:     includeMembers,
+		IncludeRepos:  includeRepos,
+		ExcludeRepos:  excludeRepos,
 	}
 	if len(token) > 0 {
 		connection.Credential = &sourcespb.GitHub_Token{
@@ -34,18 +46,41 @@ func (e *Engine) ScanGitHub(ctx context.Context, endpoint string, repos, orgs []
 		logrus.WithError(err).Error("failed to marshal github connection")
 		return err
 	}
-	err = source.Init(ctx, "trufflehog - github", 0, 0, false, &conn, concurrency)
+	err = source.Init(ctx, sourceName(ctx, "trufflehog - github"), 0, 0, false, &conn, concurrency)
 	if err != nil {
 		logrus.WithError(err).Error("failed to initialize github source")
 		return err
 	}
+	if opts, ok := ctx.Value(githubSnapshotKey{}).(github.SnapshotOptions); ok {
+		if err := source.UseSnapshot(opts); err != nil {
+			logrus.WithError(err).Error("failed to load github snapshot")
+			return err
+		}
+	}
+	source.SetRepoLimits(repoLimits(ctx))
+	e.trackProgress(source.GetProgress())
 
 	go func() {
 		err := source.Chunks(ctx, e.ChunksChan())
 		if err != nil {
-			logrus.WithError(err).Fatal("could not scan github")
+			logging.FromContext(ctx).WithError(err).Error("could not scan github")
+		}
+		e.sourceDone()
+	}()
+	return nil
+}
+
+// ScanGitHubAuditLog scans the events of the audit logs as they're recorded, until the context is done.
+func (e *Engine) ScanGitHubAuditLog(ctx context.Context, auditLog *github.AuditLog) error {
+	ctx = logging.WithModule(ctx, "sources.github")
+	auditLog.SourceName = sourceName(ctx, "trufflehog - github audit log")
+
+	go func() {
+		err := auditLog.Chunks(ctx, e.ChunksChan())
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("could not scan github audit log")
 		}
-		close(e.ChunksChan())
+		e.sourceDone()
 	}()
 	return nil
 }
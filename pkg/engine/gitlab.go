@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/go-errors/errors"
 	"github.com/sirupsen/logrus"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/gitlab"
 	"golang.org/x/net/context"
@@ -12,8 +13,12 @@ import (
 	"runtime"
 )
 
-func (e *Engine) ScanGitLab(ctx context.Context, endpoint, token string, repositories []string) error {
-	connection := &sourcespb.GitLab{}
+func (e *Engine) ScanGitLab(ctx context.Context, endpoint, token string, repositories, includeRepos, excludeRepos []string) error {
+	ctx = logging.WithModule(ctx, "sources.gitlab")
+	connection := &sourcespb.GitLab{
+		IncludeRepos: includeRepos,
+		ExcludeRepos: excludeRepos,
+	}
 
 	switch {
 	case len(token) > 0:
@@ -40,16 +45,18 @@ func (e *Engine) ScanGitLab(ctx context.Context, endpoint, token string, reposit
 	}
 
 	gitlabSource := gitlab.Source{}
-	err = gitlabSource.Init(ctx, "trufflehog - gitlab", 0, int64(sourcespb.SourceType_SOURCE_TYPE_GITLAB), true, &conn, runtime.NumCPU())
+	err = gitlabSource.Init(ctx, sourceName(ctx, "trufflehog - gitlab"), 0, int64(sourcespb.SourceType_SOURCE_TYPE_GITLAB), true, &conn, runtime.NumCPU())
 	if err != nil {
 		return errors.WrapPrefix(err, "could not init GitLab source", 0)
 	}
+	gitlabSource.SetRepoLimits(repoLimits(ctx))
+	e.trackProgress(gitlabSource.GetProgress())
 	go func() {
 		err := gitlabSource.Chunks(ctx, e.ChunksChan())
 		if err != nil {
-			logrus.WithError(err).Error("error scanning GitLab")
+			logging.FromContext(ctx).WithError(err).Error("error scanning GitLab")
 		}
-		close(e.ChunksChan())
+		e.sourceDone()
 	}()
 	return nil
 }
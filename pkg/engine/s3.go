@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/go-errors/errors"
 	"github.com/sirupsen/logrus"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/credentialspb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/s3"
@@ -14,6 +15,7 @@ import (
 )
 
 func (e *Engine) ScanS3(ctx context.Context, key, secret string, cloudCred bool, buckets []string) error {
+	ctx = logging.WithModule(ctx, "sources.s3")
 	connection := &sourcespb.S3{
 		Credential: &sourcespb.S3_Unauthenticated{},
 	}
@@ -42,16 +44,17 @@ func (e *Engine) ScanS3(ctx context.Context, key, secret string, cloudCred bool,
 	}
 
 	s3Source := s3.Source{}
-	err = s3Source.Init(ctx, "trufflehog - s3", 0, int64(sourcespb.SourceType_SOURCE_TYPE_S3), true, &conn, runtime.NumCPU())
+	err = s3Source.Init(ctx, sourceName(ctx, "trufflehog - s3"), 0, int64(sourcespb.SourceType_SOURCE_TYPE_S3), true, &conn, runtime.NumCPU())
 	if err != nil {
 		return errors.WrapPrefix(err, "failed to init S3 source", 0)
 	}
+	e.trackProgress(s3Source.GetProgress())
 	go func() {
 		err := s3Source.Chunks(ctx, e.ChunksChan())
 		if err != nil {
-			logrus.WithError(err).Error("error scanning s3")
+			logging.FromContext(ctx).WithError(err).Error("error scanning s3")
 		}
-		close(e.ChunksChan())
+		e.sourceDone()
 	}()
 	return nil
 }
@@ -0,0 +1,23 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/sshremote"
+)
+
+// ScanSSH scans files on remote hosts over SSH.
+func (e *Engine) ScanSSH(ctx context.Context, src *sshremote.Source) error {
+	ctx = logging.WithModule(ctx, "sources.sshremote")
+	src.SourceName = sourceName(ctx, "trufflehog - ssh")
+
+	go func() {
+		err := src.Chunks(ctx, e.ChunksChan())
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("could not scan ssh hosts")
+		}
+		e.sourceDone()
+	}()
+	return nil
+}
@@ -8,11 +8,13 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 	"os"
 
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/syslog"
 )
 
 func (e *Engine) ScanSyslog(ctx context.Context, address, protocol, certPath, keyPath, format string, concurrency int) error {
+	ctx = logging.WithModule(ctx, "sources.syslog")
 	connection := &sourcespb.Syslog{
 		Protocol:      protocol,
 		ListenAddress: address,
@@ -39,7 +41,7 @@ func (e *Engine) ScanSyslog(ctx context.Context, address, protocol, certPath, ke
 		return errors.WrapPrefix(err, "error unmarshalling connection", 0)
 	}
 	source := syslog.Source{}
-	err = source.Init(ctx, "trufflehog - syslog", 0, 0, false, &conn, concurrency)
+	err = source.Init(ctx, sourceName(ctx, "trufflehog - syslog"), 0, 0, false, &conn, concurrency)
 	source.InjectConnection(connection)
 	if err != nil {
 		logrus.WithError(err).Error("failed to initialize syslog source")
@@ -49,9 +51,9 @@ func (e *Engine) ScanSyslog(ctx context.Context, address, protocol, certPath, ke
 	go func() {
 		err := source.Chunks(ctx, e.ChunksChan())
 		if err != nil {
-			logrus.WithError(err).Fatal("could not scan syslog")
+			logging.FromContext(ctx).WithError(err).Error("could not scan syslog")
 		}
-		close(e.ChunksChan())
+		e.sourceDone()
 	}()
 	return nil
 }
@@ -0,0 +1,146 @@
+// Package findings reads back the results trufflehog writes with --json, for the commands and integrations that work
+// with the results of earlier scans.
+package findings
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/allowlist"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+// Finding is a result read back from trufflehog's --json output.
+type Finding struct {
+	DetectorType string
+	Verified     bool
+	Raw          string
+	Redacted     string
+	Severity     detectors.Severity
+	ExtraData    map[string]string
+	SourceName   string
+	// SourceKind is the kind of source metadata, such as "Git" or "Filesystem".
+	SourceKind string
+	// Metadata holds the source metadata fields, such as the commit and file.
+	Metadata map[string]interface{}
+}
+
+// jsonResult mirrors the JSON encoding of detectors.ResultWithMetadata. The source metadata is a protobuf oneof, which
+// encoding/json can't decode back into its interface field, so it is decoded generically.
+type jsonResult struct {
+	SourceName     string
+	SourceMetadata struct {
+		Data map[string]map[string]interface{}
+	}
+	DetectorType detectorspb.DetectorType
+	Verified     bool
+	Raw          []byte
+	Redacted     string
+	Severity     detectors.Severity
+	ExtraData    map[string]string
+
+	// Manifest and ScanEnd are only set on the envelope lines written with --manifest, which aren't results.
+	Manifest json.RawMessage
+	ScanEnd  json.RawMessage
+}
+
+// LoadFindings reads findings written by trufflehog with --json, one per line. Scan manifest lines are skipped.
+func LoadFindings(r io.Reader) ([]Finding, error) {
+	var findings []Finding
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		finding, ok, err := decodeFinding([]byte(text))
+		if err != nil {
+			return nil, fmt.Errorf("line %d is not a trufflehog JSON result: %w", line, err)
+		}
+		if !ok {
+			continue
+		}
+		findings = append(findings, finding)
+	}
+	return findings, scanner.Err()
+}
+
+// FindingFromResult converts a result of a scan in progress to the Finding it would be read back as from --json output.
+func FindingFromResult(r *detectors.ResultWithMetadata) (Finding, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return Finding{}, err
+	}
+	finding, _, err := decodeFinding(data)
+	return finding, err
+}
+
+// decodeFinding decodes a line of --json output. It returns false for envelope lines, which aren't results.
+func decodeFinding(data []byte) (Finding, bool, error) {
+	var result jsonResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return Finding{}, false, err
+	}
+	if result.Manifest != nil || result.ScanEnd != nil {
+		return Finding{}, false, nil
+	}
+	finding := Finding{
+		DetectorType: result.DetectorType.String(),
+		Verified:     result.Verified,
+		Raw:          string(result.Raw),
+		Redacted:     result.Redacted,
+		Severity:     result.Severity,
+		ExtraData:    result.ExtraData,
+		SourceName:   result.SourceName,
+	}
+	for kind, metadata := range result.SourceMetadata.Data {
+		finding.SourceKind = kind
+		finding.Metadata = metadata
+	}
+	return finding, true, nil
+}
+
+// Hash returns the hash that identifies the secret in the baseline file without storing the secret itself.
+func (f *Finding) Hash() string {
+	return allowlist.Hash([]byte(f.Raw))
+}
+
+// Fingerprint identifies the secret independently of where it was found, so that a secret that moved between files
+// or commits is still recognized as the same finding.
+func (f *Finding) Fingerprint() string {
+	return allowlist.Hash([]byte(f.DetectorType + "\x00" + f.Raw))
+}
+
+// Location returns a short human readable description of where the finding is.
+func (f *Finding) Location() string {
+	file, _ := f.Metadata["file"].(string)
+	if file == "" {
+		file, _ = f.Metadata["link"].(string)
+	}
+	if line, ok := f.Metadata["line"].(float64); ok && line > 0 {
+		file = fmt.Sprintf("%s:%d", file, int64(line))
+	}
+	if commit, ok := f.Metadata["commit"].(string); ok && commit != "" {
+		if len(commit) > 8 {
+			commit = commit[:8]
+		}
+		return fmt.Sprintf("%s@%s", file, commit)
+	}
+	return file
+}
+
+// MetadataKeys returns the metadata keys in a stable order.
+func (f *Finding) MetadataKeys() []string {
+	keys := make([]string, 0, len(f.Metadata))
+	for k := range f.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"context"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/h2non/filetype"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+)
+
+const (
+	// DefaultMaxEntrySize is the most decompressed bytes read from any one archive entry. The rest of an entry larger
+	// than this, such as a decompression bomb, is not scanned.
+	DefaultMaxEntrySize = 256 * 1024 * 1024 // 256MB
+	// DefaultMaxDepth is how many archives deep, such as a tarball inside a gzip inside a tarball, entries are read.
+	DefaultMaxDepth = 5
+
+	// These sizes match the filesystem source's, which are driven by our largest credential size, GCP @ ~2.25KB.
+	chunkSize = 10 * 1024 // 10KB
+	peekSize  = 3 * 1024  // 3KB
+
+	// headerSize is the number of bytes filetype needs to match an archive.
+	headerSize = 262
+)
+
+// ChunkFunc is called with the provenance of an archive entry and a chunk of its decompressed data. The provenance is
+// the path of the entry in each archive it's nested in, outermost first, such as ["layer.tar.gz", "etc/app.conf"].
+// Joined with slashes, it's the path of the entry relative to the archive it was given.
+type ChunkFunc func(provenance []string, data []byte)
+
+// Archive streams the entries of tar, zip, gzip, and bzip2 archives through the chunker, without extracting them to
+// disk.
+type Archive struct {
+	// MaxEntrySize caps the decompressed bytes read from each entry.
+	MaxEntrySize int64
+	// MaxDepth caps how many nested archives are read.
+	MaxDepth int
+}
+
+// NewArchive returns an Archive with the default limits.
+func NewArchive() *Archive {
+	return &Archive{
+		MaxEntrySize: DefaultMaxEntrySize,
+		MaxDepth:     DefaultMaxDepth,
+	}
+}
+
+// IsArchive reports whether header, the start of a file, is an archive that can be streamed.
+func IsArchive(header []byte) bool {
+	return archiveKind(header) != ""
+}
+
+func archiveKind(header []byte) string {
+	if len(header) > headerSize {
+		header = header[:headerSize]
+	}
+	kind, err := filetype.Match(header)
+	if err != nil {
+		return ""
+	}
+	switch kind.Extension {
+	case "tar", "zip", "gz", "bz2":
+		return kind.Extension
+	}
+	return ""
+}
+
+// Chunks streams the entries of the archive read from r to fn. It returns false if r isn't an archive, in which case the
+// start of r may have been read. Zip archives are only read if r is also an io.ReaderAt with a Size, such as an io.SectionReader or a
+// bytes.Reader, since their directory is at the end.
+func (a *Archive) Chunks(ctx context.Context, r io.Reader, fn ChunkFunc) (bool, error) {
+	if zr, ok := r.(sizedReaderAt); ok {
+		header := make([]byte, headerSize)
+		n, _ := zr.ReadAt(header, 0)
+		if archiveKind(header[:n]) == "zip" {
+			return true, a.zipEntries(ctx, zr, nil, 0, fn)
+		}
+	}
+
+	br := bufio.NewReaderSize(r, chunkSize)
+	header, _ := br.Peek(headerSize)
+	kind := archiveKind(header)
+	if kind == "" || kind == "zip" {
+		return false, nil
+	}
+	return true, a.archive(ctx, kind, br, nil, 0, fn)
+}
+
+type sizedReaderAt interface {
+	io.ReaderAt
+	Size() int64
+}
+
+// archive reads the archive of the given kind at depth from r, whose entries are nested in the provenance.
+func (a *Archive) archive(ctx context.Context, kind string, r io.Reader, provenance []string, depth int, fn ChunkFunc) error {
+	switch kind {
+	case "tar":
+		return a.tarEntries(ctx, r, provenance, depth, fn)
+	case "gz":
+		gzr, err := gzip.NewReader(r)
+		if err != nil {
+			return errors.WrapPrefix(err, "could not read gzip", 0)
+		}
+		defer gzr.Close()
+		// The stream is named after the file it was compressed from, if gzip recorded it.
+		if gzr.Name != "" {
+			provenance = with(provenance, strings.TrimSuffix(path.Base(gzr.Name), ".gz"))
+		}
+		return a.entry(ctx, gzr, provenance, depth, fn)
+	case "bz2":
+		return a.entry(ctx, bzip2.NewReader(r), provenance, depth, fn)
+	case "zip":
+		// A zip nested in a stream is buffered, since its directory is at the end, but only up to the entry cap.
+		data, err := io.ReadAll(io.LimitReader(r, a.MaxEntrySize+1))
+		if err != nil {
+			return err
+		}
+		if int64(len(data)) > a.MaxEntrySize {
+			log.Debugf("skipping nested zip %s, which is larger than %d bytes", path.Join(provenance...), a.MaxEntrySize)
+			return nil
+		}
+		return a.zipEntries(ctx, bytes.NewReader(data), provenance, depth, fn)
+	}
+	return nil
+}
+
+func (a *Archive) tarEntries(ctx context.Context, r io.Reader, provenance []string, depth int, fn ChunkFunc) error {
+	tr := tar.NewReader(r)
+	for {
+		if common.IsDone(ctx) {
+			return nil
+		}
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return errors.WrapPrefix(err, "could not read tar entry in "+path.Join(provenance...), 0)
+		}
+		if !hdr.FileInfo().Mode().IsRegular() {
+			continue
+		}
+		if err := a.entry(ctx, tr, with(provenance, hdr.Name), depth+1, fn); err != nil {
+			return err
+		}
+	}
+}
+
+func (a *Archive) zipEntries(ctx context.Context, r sizedReaderAt, provenance []string, depth int, fn ChunkFunc) error {
+	zr, err := zip.NewReader(r, r.Size())
+	if err != nil {
+		return errors.WrapPrefix(err, "could not read zip "+path.Join(provenance...), 0)
+	}
+	for _, f := range zr.File {
+		if common.IsDone(ctx) {
+			return nil
+		}
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			log.WithError(err).Debugf("could not open zip entry %s", path.Join(with(provenance, f.Name)...))
+			continue
+		}
+		err = a.entry(ctx, rc, with(provenance, f.Name), depth+1, fn)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// entry streams one decompressed entry to fn, or reads it as an archive if it is one and the depth allows. At most
+// MaxEntrySize bytes of it are chunked. An archive entry isn't capped as a whole, since each of its own entries is.
+func (a *Archive) entry(ctx context.Context, r io.Reader, provenance []string, depth int, fn ChunkFunc) error {
+	name := path.Join(provenance...)
+	br := bufio.NewReaderSize(r, chunkSize)
+
+	header, _ := br.Peek(headerSize)
+	if kind := archiveKind(header); kind != "" {
+		if depth >= a.MaxDepth {
+			log.Debugf("skipping %s, which is nested more than %d archives deep", name, a.MaxDepth)
+			return nil
+		}
+		if err := a.archive(ctx, kind, br, provenance, depth, fn); err != nil {
+			// A corrupt nested archive shouldn't stop the rest of its parent from being scanned.
+			log.WithError(err).Debugf("could not read nested archive %s", name)
+		}
+		return nil
+	}
+	if len(header) > 0 && common.SkipFile(name, header) {
+		return nil
+	}
+
+	limited := bufio.NewReaderSize(io.LimitReader(br, a.MaxEntrySize), chunkSize)
+	for read := int64(0); ; {
+		if common.IsDone(ctx) {
+			return nil
+		}
+		buf := make([]byte, chunkSize)
+		n, err := io.ReadFull(limited, buf)
+		read += int64(n)
+		if n > 0 {
+			// We are peeking in case a secret exists in our chunk boundaries,
+			// but we never care if we've run into a peek error.
+			peekData, _ := limited.Peek(peekSize)
+			fn(provenance, append(buf[:n], peekData...))
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			if read == a.MaxEntrySize {
+				if _, err := br.Peek(1); err == nil {
+					log.Debugf("stopped reading %s after %d decompressed bytes", name, a.MaxEntrySize)
+				}
+			}
+			return nil
+		}
+		if err != nil {
+			return errors.WrapPrefix(err, "could not read "+name, 0)
+		}
+	}
+}
+
+// with returns a copy of the provenance with name appended, so that siblings never share a backing array.
+func with(provenance []string, name string) []string {
+	return append(provenance[:len(provenance):len(provenance)], name)
+}
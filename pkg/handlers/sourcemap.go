@@ -0,0 +1,349 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-errors/errors"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+)
+
+// MaxSourceMapSize is the largest JavaScript file or source map that is read as one. Larger files are scanned as is.
+const MaxSourceMapSize = 64 * 1024 * 1024 // 64MB
+
+// SourceMap is a version 3 source map, which maps the positions of generated JavaScript, such as a minified bundle, to
+// the original sources it was built from. See https://sourcemaps.info/spec.html.
+type SourceMap struct {
+	Version        int        `json:"version"`
+	File           string     `json:"file"`
+	SourceRoot     string     `json:"sourceRoot"`
+	Sources        []string   `json:"sources"`
+	SourcesContent []*string  `json:"sourcesContent"`
+	Mappings       string     `json:"mappings"`
+	Sections       []struct{} `json:"sections"`
+
+	// lines holds the decoded mappings of each generated line.
+	lines [][]mapping
+}
+
+// mapping maps a generated column to a line of a source. Mappings without a source are dropped.
+type mapping struct {
+	column, source, line int
+}
+
+// ParseSourceMap parses a source map and decodes its mappings. Index maps, which are made of sections, aren't
+// supported.
+func ParseSourceMap(data []byte) (*SourceMap, error) {
+	var m SourceMap
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, errors.WrapPrefix(err, "could not parse source map", 0)
+	}
+	if m.Version != 3 {
+		return nil, errors.Errorf("unsupported source map version %d", m.Version)
+	}
+	if len(m.Sections) > 0 {
+		return nil, errors.New("index source maps aren't supported")
+	}
+	lines, err := decodeMappings(m.Mappings, len(m.Sources))
+	if err != nil {
+		return nil, err
+	}
+	m.lines = lines
+	return &m, nil
+}
+
+// decodeMappings decodes the base64 VLQ mappings of a source map. Lines are separated by ";" and segments by ",".
+// Each segment has a generated column relative to the previous segment of the line, and optionally a source, line,
+// column, and name, each relative to the previous segment with one.
+func decodeMappings(mappings string, sources int) ([][]mapping, error) {
+	var lines [][]mapping
+	source, line := 0, 0
+	for _, generated := range strings.Split(mappings, ";") {
+		var segments []mapping
+		column := 0
+		for _, segment := range strings.Split(generated, ",") {
+			if segment == "" {
+				continue
+			}
+			fields, err := decodeVLQ(segment)
+			if err != nil {
+				return nil, err
+			}
+			column += fields[0]
+			if len(fields) < 4 {
+				continue
+			}
+			source += fields[1]
+			line += fields[2]
+			if source < 0 || source >= sources || line < 0 {
+				return nil, errors.Errorf("invalid source map segment %q", segment)
+			}
+			segments = append(segments, mapping{column: column, source: source, line: line})
+		}
+		lines = append(lines, segments)
+	}
+	return lines, nil
+}
+
+const vlqAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+// decodeVLQ decodes the signed base64 VLQ values of a segment. Each digit holds 5 bits of the value, least significant
+// first, and a continuation bit. The lowest bit of the value is its sign.
+func decodeVLQ(segment string) ([]int, error) {
+	var values []int
+	value, shift := 0, 0
+	for i := 0; i < len(segment); i++ {
+		digit := strings.IndexByte(vlqAlphabet, segment[i])
+		if digit < 0 || shift > 30 {
+			return nil, errors.Errorf("invalid source map segment %q", segment)
+		}
+		value |= (digit & 31) << shift
+		if digit&32 != 0 {
+			shift += 5
+			continue
+		}
+		if value&1 != 0 {
+			values = append(values, -(value >> 1))
+		} else {
+			values = append(values, value>>1)
+		}
+		value, shift = 0, 0
+	}
+	if shift != 0 || len(values) == 0 {
+		return nil, errors.Errorf("invalid source map segment %q", segment)
+	}
+	return values, nil
+}
+
+// SourceName returns the name of the i-th source, under the map's source root if it has one.
+func (m *SourceMap) SourceName(i int) string {
+	name := m.Sources[i]
+	if m.SourceRoot == "" || strings.Contains(name, "://") || strings.HasPrefix(name, "/") {
+		return name
+	}
+	return strings.TrimSuffix(m.SourceRoot, "/") + "/" + name
+}
+
+// HasContent reports whether the map embeds the content of any of its sources.
+func (m *SourceMap) HasContent() bool {
+	for _, content := range m.SourcesContent {
+		if content != nil && *content != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Original returns the source and one-based line that a zero-based line and column of the generated file were built
+// from.
+func (m *SourceMap) Original(line, column int) (string, int, bool) {
+	if line < 0 || line >= len(m.lines) {
+		return "", 0, false
+	}
+	var found *mapping
+	for i, segment := range m.lines[line] {
+		if segment.column > column {
+			break
+		}
+		found = &m.lines[line][i]
+	}
+	if found == nil {
+		return "", 0, false
+	}
+	return m.SourceName(found.source), found.line + 1, true
+}
+
+// Chunks chunks the embedded content of each source. Results in the content of a source are in that source, so each
+// chunk comes with the SourcePosition of its first line.
+func (m *SourceMap) Chunks(ctx context.Context, fn func(source string, data []byte, original *SourcePosition)) {
+	for i, content := range m.SourcesContent {
+		if i >= len(m.Sources) || content == nil || *content == "" {
+			continue
+		}
+		source := m.SourceName(i)
+		chunkLines(ctx, []byte(*content), func(data []byte, line, _ int) {
+			fn(source, data, &SourcePosition{File: source, Line: line})
+		})
+	}
+}
+
+// GeneratedPosition maps the positions of a chunk of generated JavaScript to its original sources.
+type GeneratedPosition struct {
+	m *SourceMap
+	// Line and Column are where the chunk starts in the generated file, zero-based.
+	Line, Column int
+}
+
+// OriginalPosition returns the source and one-based line of a zero-based line and byte column of the chunk. Source
+// map columns count UTF-16 code units, so non-ASCII text earlier on the line can shift the column slightly.
+func (p *GeneratedPosition) OriginalPosition(line, column int) (string, int, bool) {
+	if line == 0 {
+		column += p.Column
+	}
+	return p.m.Original(p.Line+line, column)
+}
+
+// SourcePosition maps the positions of a chunk of an original source's content to its lines.
+type SourcePosition struct {
+	File string
+	// Line is the zero-based line of the source the chunk starts on.
+	Line int
+}
+
+// OriginalPosition returns the source and one-based line of a zero-based line of the chunk.
+func (p *SourcePosition) OriginalPosition(line, _ int) (string, int, bool) {
+	return p.File, p.Line + line + 1, true
+}
+
+// Bundle is a JavaScript file generated with a source map, such as the minified output of a bundler.
+type Bundle struct {
+	Map *SourceMap
+	// MapPath is the path of the source map, or empty if it's inlined in the file as a data URL.
+	MapPath string
+	// Minified is set if the file looks minified.
+	Minified bool
+
+	data []byte
+}
+
+// IsJavaScriptPath reports whether name is a JavaScript file, which may have a source map.
+func IsJavaScriptPath(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".js", ".mjs", ".cjs":
+		return true
+	}
+	return false
+}
+
+// IsSourceMapPath reports whether name looks like a source map, such as app.js.map.
+func IsSourceMapPath(name string) bool {
+	return strings.EqualFold(path.Ext(name), ".map")
+}
+
+// IsMinified reports whether JavaScript looks minified: it's mostly made of lines too long to have been written by
+// hand.
+func IsMinified(data []byte) bool {
+	if len(data) < 1024 {
+		return false
+	}
+	lines := bytes.Count(data, []byte("\n")) + 1
+	longest, start := 0, 0
+	for start < len(data) {
+		end := bytes.IndexByte(data[start:], '\n')
+		if end < 0 {
+			end = len(data) - start
+		}
+		if end > longest {
+			longest = end
+		}
+		start += end + 1
+	}
+	return longest >= 1000 && len(data)/lines >= 200
+}
+
+// SourceMappingURL returns the URL of a JavaScript file's source map, from its last sourceMappingURL comment.
+func SourceMappingURL(data []byte) string {
+	for _, marker := range []string{"//# sourceMappingURL=", "//@ sourceMappingURL="} {
+		i := bytes.LastIndex(data, []byte(marker))
+		if i < 0 {
+			continue
+		}
+		rest := data[i+len(marker):]
+		if end := bytes.IndexAny(rest, " \t\r\n"); end >= 0 {
+			rest = rest[:end]
+		}
+		return string(rest)
+	}
+	return ""
+}
+
+// ReadBundle reads the source map of the JavaScript file at path, whose content is data. The map is either inlined as
+// a data URL or a file relative to the JavaScript; remote maps aren't fetched. It returns nil if the file doesn't
+// reference a source map that can be read.
+func ReadBundle(jsPath string, data []byte) (*Bundle, error) {
+	ref := SourceMappingURL(data)
+	if ref == "" {
+		return nil, nil
+	}
+	bundle := &Bundle{Minified: IsMinified(data), data: data}
+
+	var mapData []byte
+	if strings.HasPrefix(ref, "data:") {
+		comma := strings.IndexByte(ref, ',')
+		if comma < 0 {
+			return nil, errors.New("invalid source map data URL")
+		}
+		header, payload := ref[len("data:"):comma], ref[comma+1:]
+		var err error
+		if strings.HasSuffix(header, ";base64") {
+			mapData, err = base64.StdEncoding.DecodeString(payload)
+		} else {
+			var unescaped string
+			unescaped, err = url.PathUnescape(payload)
+			mapData = []byte(unescaped)
+		}
+		if err != nil {
+			return nil, errors.WrapPrefix(err, "could not decode inline source map", 0)
+		}
+	} else {
+		u, err := url.Parse(ref)
+		if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" {
+			return nil, nil
+		}
+		bundle.MapPath = filepath.Join(filepath.Dir(jsPath), filepath.FromSlash(u.Path))
+		info, err := os.Stat(bundle.MapPath)
+		if err != nil || !info.Mode().IsRegular() || info.Size() > MaxSourceMapSize {
+			return nil, nil
+		}
+		if mapData, err = os.ReadFile(bundle.MapPath); err != nil {
+			return nil, err
+		}
+	}
+
+	m, err := ParseSourceMap(mapData)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Map = m
+	return bundle, nil
+}
+
+// Chunks chunks the generated JavaScript, with the GeneratedPosition of each chunk.
+func (b *Bundle) Chunks(ctx context.Context, fn func(data []byte, original *GeneratedPosition)) {
+	chunkLines(ctx, b.data, func(data []byte, line, column int) {
+		fn(data, &GeneratedPosition{m: b.Map, Line: line, Column: column})
+	})
+}
+
+// chunkLines chunks data, with a peek of the next chunk like the archive chunker, and the zero-based line and byte
+// column each chunk starts at.
+func chunkLines(ctx context.Context, data []byte, fn func(data []byte, line, column int)) {
+	line, lineStart := 0, 0
+	for start := 0; start < len(data); start += chunkSize {
+		if common.IsDone(ctx) {
+			return
+		}
+		end := start + chunkSize + peekSize
+		if end > len(data) {
+			end = len(data)
+		}
+		fn(data[start:end], line, start-lineStart)
+
+		next := start + chunkSize
+		if next > len(data) {
+			next = len(data)
+		}
+		if n := bytes.Count(data[start:next], []byte("\n")); n > 0 {
+			line += n
+			lineStart = start + bytes.LastIndexByte(data[start:next], '\n') + 1
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testMap maps `const a=1;const key='secret';` back to the two lines of src/config.js.
+const testMap = `{
+	"version": 3,
+	"file": "app.min.js",
+	"sourceRoot": "webpack://app",
+	"sources": ["src/config.js"],
+	"sourcesContent": ["const a = 1;\nconst key = 'secret';\n"],
+	"mappings": "AAAA,UACA"
+}`
+
+func TestDecodeVLQ(t *testing.T) {
+	tests := map[string][]int{
+		"A":     {0},
+		"C":     {1},
+		"D":     {-1},
+		"AAgBC": {0, 0, 16, 1},
+		"2H":    {123},
+		"hB":    {-16},
+	}
+	for segment, expected := range tests {
+		values, err := decodeVLQ(segment)
+		if err != nil {
+			t.Errorf("%s: %v", segment, err)
+			continue
+		}
+		if !reflect.DeepEqual(values, expected) {
+			t.Errorf("%s: expected %v, got %v", segment, expected, values)
+		}
+	}
+	for _, segment := range []string{"g", "A!", ""} {
+		if _, err := decodeVLQ(segment); err == nil {
+			t.Errorf("%q: expected an error", segment)
+		}
+	}
+}
+
+func TestSourceMapOriginal(t *testing.T) {
+	m, err := ParseSourceMap([]byte(testMap))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		line, column int
+		source       string
+		originalLine int
+		ok           bool
+	}{
+		{0, 3, "webpack://app/src/config.js", 1, true},
+		{0, 21, "webpack://app/src/config.js", 2, true},
+		{1, 0, "", 0, false},
+	}
+	for _, test := range tests {
+		source, line, ok := m.Original(test.line, test.column)
+		if source != test.source || line != test.originalLine || ok != test.ok {
+			t.Errorf("%d:%d: expected %s:%d, got %s:%d", test.line, test.column, test.source, test.originalLine, source, line)
+		}
+	}
+
+	for _, invalid := range []string{`{"version": 2}`, `{"version": 3, "sources": [], "mappings": "AAAA"}`, `{"version": 3, "sections": [{}]}`} {
+		if _, err := ParseSourceMap([]byte(invalid)); err == nil {
+			t.Errorf("expected %s to be rejected", invalid)
+		}
+	}
+}
+
+func TestSourceMapChunks(t *testing.T) {
+	m, err := ParseSourceMap([]byte(testMap))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var chunks int
+	m.Chunks(context.Background(), func(source string, data []byte, original *SourcePosition) {
+		chunks++
+		i := strings.Index(string(data), "secret")
+		line := strings.Count(string(data[:i]), "\n")
+		if file, l, _ := original.OriginalPosition(line, 0); file != "webpack://app/src/config.js" || l != 2 {
+			t.Errorf("expected the secret on line 2 of the source, got %s:%d", file, l)
+		}
+	})
+	if chunks != 1 {
+		t.Errorf("expected 1 chunk, got %d", chunks)
+	}
+}
+
+func TestReadBundle(t *testing.T) {
+	dir := t.TempDir()
+	js := "const a=1;const key='secret';\n"
+	if err := os.WriteFile(filepath.Join(dir, "app.min.js.map"), []byte(testMap), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, ref := range map[string]string{
+		"external": "app.min.js.map",
+		"inline":   "data:application/json;charset=utf-8;base64," + base64.StdEncoding.EncodeToString([]byte(testMap)),
+	} {
+		data := []byte(js + "//# sourceMappingURL=" + ref + "\n")
+		bundle, err := ReadBundle(filepath.Join(dir, "app.min.js"), data)
+		if err != nil || bundle == nil {
+			t.Fatalf("%s: expected a bundle, got %v", name, err)
+		}
+		if (bundle.MapPath == "") != (name == "inline") {
+			t.Errorf("%s: unexpected map path %q", name, bundle.MapPath)
+		}
+		bundle.Chunks(context.Background(), func(data []byte, original *GeneratedPosition) {
+			column := strings.Index(string(data), "secret")
+			if _, line, ok := original.OriginalPosition(0, column); !ok || line != 2 {
+				t.Errorf("%s: expected the secret on line 2 of the source, got %d", name, line)
+			}
+		})
+	}
+
+	for _, data := range []string{js, js + "//# sourceMappingURL=https://cdn.example.com/app.js.map", js + "//# sourceMappingURL=missing.map"} {
+		if bundle, err := ReadBundle(filepath.Join(dir, "app.min.js"), []byte(data)); bundle != nil || err != nil {
+			t.Errorf("expected no bundle for %q, got %v", data, err)
+		}
+	}
+}
+
+func TestChunkLines(t *testing.T) {
+	line := strings.Repeat("x", 99) + "\n"
+	data := []byte(strings.Repeat(line, chunkSize/len(line)+10))
+	var starts [][2]int
+	chunkLines(context.Background(), data, func(_ []byte, line, column int) {
+		starts = append(starts, [2]int{line, column})
+	})
+	expected := [][2]int{{0, 0}, {chunkSize / 100, chunkSize % 100}}
+	if !reflect.DeepEqual(starts, expected) {
+		t.Errorf("expected chunks to start at %v, got %v", expected, starts)
+	}
+}
+
+func TestIsMinified(t *testing.T) {
+	if IsMinified([]byte(strings.Repeat("var a = 1;\n", 200))) {
+		t.Error("expected short lines not to be minified")
+	}
+	if !IsMinified([]byte(strings.Repeat("var a=1;", 500))) {
+		t.Error("expected one long line to be minified")
+	}
+}
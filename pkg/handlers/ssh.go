@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/pem"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// defaultIdentities are the key files ssh tries for hosts without an IdentityFile.
+var defaultIdentities = map[string]bool{
+	"id_rsa":        true,
+	"id_ecdsa":      true,
+	"id_ecdsa_sk":   true,
+	"id_ed25519":    true,
+	"id_ed25519_sk": true,
+	"id_xmss":       true,
+	"id_dsa":        true,
+}
+
+// SSHDir is what a .ssh directory says about the keys in it: the hosts they're configured for and the hosts that have
+// been connected to.
+type SSHDir struct {
+	// identities maps the base name of an IdentityFile to the Host patterns it's configured for.
+	identities map[string][]string
+	// defaultHosts are the Host patterns without an IdentityFile, for which ssh tries the default keys.
+	defaultHosts []string
+	// knownHosts are the hosts in known_hosts whose names aren't hashed.
+	knownHosts []string
+	// hashedKnownHosts counts the hosts in known_hosts whose names are hashed.
+	hashedKnownHosts int
+}
+
+// IsSSHDir reports whether the directory is an ssh configuration directory, such as ~/.ssh.
+func IsSSHDir(dir string) bool {
+	return filepath.Base(dir) == ".ssh"
+}
+
+// ReadSSHDir reads the config and known_hosts files of a .ssh directory. Missing files are skipped.
+func ReadSSHDir(dir string) (*SSHDir, error) {
+	d := &SSHDir{identities: map[string][]string{}}
+	if err := readIfExists(filepath.Join(dir, "config"), d.parseConfig); err != nil {
+		return nil, err
+	}
+	if err := readIfExists(filepath.Join(dir, "known_hosts"), d.parseKnownHosts); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func readIfExists(name string, parse func(io.Reader)) error {
+	f, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	parse(f)
+	return nil
+}
+
+// parseConfig records the IdentityFiles of each Host block. Match blocks and Include directives aren't followed.
+func (d *SSHDir) parseConfig(r io.Reader) {
+	var hosts, identities []string
+	hostname := ""
+	endBlock := func() {
+		if hostname != "" {
+			for i, host := range hosts {
+				if !strings.ContainsAny(host, "*?!") && host != hostname {
+					hosts[i] = host + " (" + hostname + ")"
+				}
+			}
+		}
+		if len(identities) == 0 {
+			d.defaultHosts = append(d.defaultHosts, hosts...)
+		}
+		for _, name := range identities {
+			d.identities[name] = append(d.identities[name], hosts...)
+		}
+		hosts, identities, hostname = nil, nil, ""
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		keyword, args := splitConfigLine(scanner.Text())
+		switch keyword {
+		case "host":
+			endBlock()
+			hosts = args
+		case "match":
+			endBlock()
+		case "hostname":
+			if len(args) == 1 {
+				hostname = args[0]
+			}
+		case "identityfile":
+			if len(args) == 1 {
+				identities = append(identities, path.Base(filepath.ToSlash(args[0])))
+			}
+		}
+	}
+	endBlock()
+}
+
+// splitConfigLine returns the lowercased keyword and the arguments of an ssh_config line, which may separate them
+// with an equals sign. Quoted arguments aren't unquoted beyond trimming the quotes.
+func splitConfigLine(line string) (string, []string) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", nil
+	}
+	fields := strings.Fields(strings.Replace(line, "=", " ", 1))
+	args := fields[1:]
+	for i, arg := range args {
+		args[i] = strings.Trim(arg, `"`)
+	}
+	return strings.ToLower(fields[0]), args
+}
+
+func (d *SSHDir) parseKnownHosts(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if strings.HasPrefix(fields[0], "@") {
+			// @cert-authority and @revoked markers precede the hosts.
+			fields = fields[1:]
+			if len(fields) == 0 {
+				continue
+			}
+		}
+		if strings.HasPrefix(fields[0], "|") {
+			d.hashedKnownHosts++
+			continue
+		}
+		d.knownHosts = append(d.knownHosts, strings.Split(fields[0], ",")...)
+	}
+}
+
+// KeyExtraData returns what the directory says about the key file with the given base name, to add to the extra data
+// of results found in it: "ssh_hosts", the Host patterns it's configured for; "ssh_known_hosts", the hosts that have
+// been connected to from this directory; and "ssh_key_encrypted", whether the key is protected by a passphrase. It
+// returns nil if data isn't a private key.
+func (d *SSHDir) KeyExtraData(name string, data []byte) map[string]string {
+	encrypted, ok := privateKeyEncrypted(data)
+	if !ok {
+		return nil
+	}
+	extra := map[string]string{"ssh_key_encrypted": strconv.FormatBool(encrypted)}
+
+	hosts := d.identities[name]
+	if defaultIdentities[name] {
+		hosts = append(hosts[:len(hosts):len(hosts)], d.defaultHosts...)
+	}
+	if len(hosts) > 0 {
+		extra["ssh_hosts"] = strings.Join(dedupe(hosts), ", ")
+	}
+
+	known := dedupe(d.knownHosts)
+	if d.hashedKnownHosts > 0 {
+		known = append(known, strconv.Itoa(d.hashedKnownHosts)+" hashed")
+	}
+	if len(known) > 0 {
+		extra["ssh_known_hosts"] = strings.Join(known, ", ")
+	}
+	return extra
+}
+
+func dedupe(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var out []string
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// privateKeyEncrypted reports whether the PEM private key in data is encrypted, and false for ok if there's no PEM
+// private key in data.
+func privateKeyEncrypted(data []byte) (encrypted, ok bool) {
+	block, _ := pem.Decode(data)
+	if block == nil || !strings.HasSuffix(block.Type, "PRIVATE KEY") {
+		return false, false
+	}
+	switch block.Type {
+	case "ENCRYPTED PRIVATE KEY":
+		// PKCS #8 keys are encrypted as a whole.
+		return true, true
+	case "OPENSSH PRIVATE KEY":
+		// The key is in the format of PROTOCOL.key in OpenSSH: a magic string and then the cipher's name as a
+		// length-prefixed string, which is "none" for unencrypted keys.
+		const magic = "openssh-key-v1\x00"
+		body := block.Bytes
+		if !bytes.HasPrefix(body, []byte(magic)) || len(body) < len(magic)+4 {
+			return false, false
+		}
+		body = body[len(magic):]
+		n := binary.BigEndian.Uint32(body)
+		if uint64(len(body)-4) < uint64(n) {
+			return false, false
+		}
+		return string(body[4:4+n]) != "none", true
+	default:
+		// Traditional PEM keys are encrypted with a Proc-Type header.
+		return strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED"), true
+	}
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/binary"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+// openSSHKey returns a PEM OpenSSH private key whose body holds only the magic string and the cipher's name.
+func openSSHKey(cipher string) []byte {
+	body := []byte("openssh-key-v1\x00\x00\x00\x00\x00" + cipher)
+	binary.BigEndian.PutUint32(body[15:], uint32(len(cipher)))
+	return pem.EncodeToMemory(&pem.Block{Type: "OPENSSH PRIVATE KEY", Bytes: body})
+}
+
+func TestSSHDir_KeyExtraData(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ".ssh")
+	if err := os.Mkdir(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	config := `
+Host bastion
+  HostName bastion.example.com
+  IdentityFile ~/.ssh/deploy_key
+
+Host = github.com gitlab.com
+  IdentityFile "~/.ssh/deploy_key"
+
+Host *.internal
+`
+	knownHosts := `
+github.com,140.82.112.3 ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl
+@cert-authority *.internal ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl
+|1|JfKTdBh7rNbXkVAQCRp4OQoPfmI=|USECr3SWf1JUPsms5AqfD5QfxkM= ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQ
+`
+	for name, content := range map[string]string{"config": config, "known_hosts": knownHosts} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !IsSSHDir(dir) {
+		t.Fatalf("%s isn't an ssh directory", dir)
+	}
+	sshDir, err := ReadSSHDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	knownHostsWant := "*.internal, 140.82.112.3, github.com, 1 hashed"
+	encryptedPEM := pem.EncodeToMemory(&pem.Block{
+		Type:    "RSA PRIVATE KEY",
+		Headers: map[string]string{"Proc-Type": "4,ENCRYPTED", "DEK-Info": "AES-128-CBC,00000000000000000000000000000000"},
+		Bytes:   []byte("key"),
+	})
+	tests := map[string]struct {
+		name string
+		data []byte
+		want map[string]string
+	}{
+		"configured key": {
+			name: "deploy_key",
+			data: openSSHKey("none"),
+			want: map[string]string{
+				"ssh_key_encrypted": "false",
+				"ssh_hosts":         "bastion (bastion.example.com), github.com, gitlab.com",
+				"ssh_known_hosts":   knownHostsWant,
+			},
+		},
+		"default key": {
+			name: "id_ed25519",
+			data: openSSHKey("aes256-ctr"),
+			want: map[string]string{
+				"ssh_key_encrypted": "true",
+				"ssh_hosts":         "*.internal",
+				"ssh_known_hosts":   knownHostsWant,
+			},
+		},
+		"encrypted PEM key": {
+			name: "legacy_rsa",
+			data: encryptedPEM,
+			want: map[string]string{
+				"ssh_key_encrypted": "true",
+				"ssh_known_hosts":   knownHostsWant,
+			},
+		},
+		"public key": {
+			name: "deploy_key.pub",
+			data: []byte("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl"),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := sshDir.KeyExtraData(tt.name, tt.data)
+			if diff := pretty.Compare(got, tt.want); diff != "" {
+				t.Errorf("(-got +want)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Checker tracks whether a long running process is ready to accept work. The zero value is alive but not ready.
+type Checker struct {
+	ready int32
+}
+
+// SetReady marks the process as ready or not ready.
+func (c *Checker) SetReady(ready bool) {
+	var v int32
+	if ready {
+		v = 1
+	}
+	atomic.StoreInt32(&c.ready, v)
+}
+
+// Ready returns true if the process is ready to accept work.
+func (c *Checker) Ready() bool {
+	return atomic.LoadInt32(&c.ready) == 1
+}
+
+// Handler serves /healthz, which succeeds as long as the process can respond, and /readyz, which only succeeds
+// while the process is ready.
+func (c *Checker) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !c.Ready() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("not ready"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+	return mux
+}
+
+// Serve serves the health endpoints on addr until the context is cancelled.
+func Serve(ctx context.Context, addr string, checker *Checker) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           checker.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.WithError(err).Debug("could not shut down health server cleanly")
+		}
+	}()
+
+	log.Infof("serving health checks on %s /healthz and /readyz", addr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package health
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestChecker(t *testing.T) {
+	checker := &Checker{}
+	server := httptest.NewServer(checker.Handler())
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		ready  bool
+		path   string
+		status int
+	}{
+		{name: "alive while starting", ready: false, path: "/healthz", status: http.StatusOK},
+		{name: "not ready while starting", ready: false, path: "/readyz", status: http.StatusServiceUnavailable},
+		{name: "alive when ready", ready: true, path: "/healthz", status: http.StatusOK},
+		{name: "ready", ready: true, path: "/readyz", status: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker.SetReady(tt.ready)
+			res, err := http.Get(server.URL + tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res.Body.Close()
+			if res.StatusCode != tt.status {
+				t.Errorf("unexpected status for %s. Got: %d, Expected: %d", tt.path, res.StatusCode, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,323 @@
+// Package heatmap aggregates the findings recorded in a results database into a heatmap for program-level reporting:
+// the number of findings per repository, team, and detector in each period, the file types that leak the most
+// secrets, and the trend of the share of findings that are verified.
+//
+// A finding is counted once in each period it was seen in, for each repository, team, and detector it was seen with,
+// so a secret that stays in a repository is counted in every period until it's removed. Findings triaged as false
+// positives aren't counted.
+package heatmap
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/store"
+)
+
+// Period is the length of the time buckets of a Report.
+type Period string
+
+const (
+	Day   Period = "day"
+	Week  Period = "week"
+	Month Period = "month"
+)
+
+// start returns the start of the period containing t, in UTC. Weeks start on Monday.
+func (p Period) start(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch p {
+	case Week:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case Month:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
+
+func (p Period) next(t time.Time) time.Time {
+	switch p {
+	case Week:
+		return t.AddDate(0, 0, 7)
+	case Month:
+		return t.AddDate(0, 1, 0)
+	}
+	return t.AddDate(0, 0, 1)
+}
+
+const (
+	// Unowned is the team of findings in files without code owners, or from sources without files.
+	Unowned = "(unowned)"
+	// NoExtension is the file type of files without an extension.
+	NoExtension = "(none)"
+)
+
+// Options configure a Report.
+type Options struct {
+	Period Period
+	// Since and Until bound the periods of the report. Periods without findings are included, so that the report
+	// covers the whole time range. Zero values default to the first and last sightings.
+	Since time.Time
+	Until time.Time
+	// Top is the number of repositories, teams, detectors, and file types to report, with the most findings. Zero
+	// reports all of them.
+	Top int
+}
+
+// Report is a heatmap of findings over time.
+type Report struct {
+	Generated time.Time `json:"generated"`
+	Period    Period    `json:"period"`
+	// Periods are the start dates of the periods, in the form 2006-01-02. Every Counts slice of the report has a count
+	// for each of them.
+	Periods []string `json:"periods"`
+	// Totals are all findings in each period, with the share of them that's verified.
+	Totals []Total `json:"totals"`
+	// Findings is the number of findings seen in any period.
+	Findings     int        `json:"findings"`
+	Repositories []Row      `json:"repositories"`
+	Teams        []Row      `json:"teams"`
+	Detectors    []Row      `json:"detectors"`
+	FileTypes    []FileType `json:"file_types"`
+}
+
+// Total counts the findings of a period.
+type Total struct {
+	Findings int `json:"findings"`
+	Verified int `json:"verified"`
+	// VerifiedRatio is Verified divided by Findings, or 0 if there are no findings.
+	VerifiedRatio float64 `json:"verified_ratio"`
+}
+
+// Row counts the findings of a repository, team, or detector in each period.
+type Row struct {
+	Name string `json:"name"`
+	// Findings is the number of findings seen in any period.
+	Findings int   `json:"findings"`
+	Verified int   `json:"verified"`
+	Counts   []int `json:"counts"`
+}
+
+// FileType counts the findings in files with an extension, such as ".env".
+type FileType struct {
+	Extension string `json:"extension"`
+	Findings  int    `json:"findings"`
+	Verified  int    `json:"verified"`
+}
+
+// counter counts the distinct findings of each name, such as each repository, in total and in each period.
+type counter struct {
+	periods int
+	names   map[string]*counts
+}
+
+// counts holds whether each finding was verified, over all periods and in each period.
+type counts struct {
+	findings map[string]bool
+	periods  []map[string]bool
+}
+
+func newCounter(periods int) *counter {
+	return &counter{periods: periods, names: map[string]*counts{}}
+}
+
+func (c *counter) add(name string, period int, fingerprint string, verified bool) {
+	n := c.names[name]
+	if n == nil {
+		n = &counts{findings: map[string]bool{}, periods: make([]map[string]bool, c.periods)}
+		c.names[name] = n
+	}
+	n.findings[fingerprint] = n.findings[fingerprint] || verified
+	if n.periods[period] == nil {
+		n.periods[period] = map[string]bool{}
+	}
+	n.periods[period][fingerprint] = n.periods[period][fingerprint] || verified
+}
+
+// rows returns the top rows, with the most findings first, then by name.
+func (c *counter) rows(top int) []Row {
+	rows := make([]Row, 0, len(c.names))
+	for name, n := range c.names {
+		row := Row{Name: name, Findings: len(n.findings), Verified: countVerified(n.findings), Counts: make([]int, c.periods)}
+		for i, findings := range n.periods {
+			row.Counts[i] = len(findings)
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Findings != rows[j].Findings {
+			return rows[i].Findings > rows[j].Findings
+		}
+		return rows[i].Name < rows[j].Name
+	})
+	if top > 0 && len(rows) > top {
+		rows = rows[:top]
+	}
+	return rows
+}
+
+func countVerified(findings map[string]bool) int {
+	verified := 0
+	for _, v := range findings {
+		if v {
+			verified++
+		}
+	}
+	return verified
+}
+
+// Build aggregates the sightings of findings, from store.Sightings, into a report. Sightings outside Since and Until
+// are left out.
+func Build(sightings []store.Sighting, opts Options, now time.Time) *Report {
+	if opts.Period == "" {
+		opts.Period = Week
+	}
+	var kept []store.Sighting
+	first, last := opts.Since, opts.Until
+	for _, s := range sightings {
+		if s.State == store.StateFalsePositive ||
+			(!opts.Since.IsZero() && s.Seen.Before(opts.Since)) || (!opts.Until.IsZero() && s.Seen.After(opts.Until)) {
+			continue
+		}
+		kept = append(kept, s)
+		if opts.Since.IsZero() && (first.IsZero() || s.Seen.Before(first)) {
+			first = s.Seen
+		}
+		if opts.Until.IsZero() && s.Seen.After(last) {
+			last = s.Seen
+		}
+	}
+
+	report := &Report{Generated: now.UTC(), Period: opts.Period, Periods: []string{}, Totals: []Total{}}
+	var starts []time.Time
+	if !first.IsZero() && !last.IsZero() {
+		for t := opts.Period.start(first); !t.After(last); t = opts.Period.next(t) {
+			starts = append(starts, t)
+			report.Periods = append(report.Periods, t.Format("2006-01-02"))
+		}
+	}
+
+	// Each name of a counter is a row of the report, except for totals, which has one.
+	totals, repositories, teams, detectors := newCounter(len(starts)), newCounter(len(starts)), newCounter(len(starts)), newCounter(len(starts))
+	fileTypes := newCounter(1)
+	for _, s := range kept {
+		period := sort.Search(len(starts), func(i int) bool { return starts[i].After(s.Seen) }) - 1
+		totals.add("", period, s.Fingerprint, s.Verified)
+		repository := s.Repository
+		if repository == "" {
+			repository = s.SourceName
+		}
+		repositories.add(repository, period, s.Fingerprint, s.Verified)
+		owners := s.Owners
+		if len(owners) == 0 {
+			owners = []string{Unowned}
+		}
+		for _, owner := range owners {
+			teams.add(owner, period, s.Fingerprint, s.Verified)
+		}
+		detectors.add(s.DetectorType, period, s.Fingerprint, s.Verified)
+		if s.File != "" {
+			fileTypes.add(fileType(s.File), 0, s.Fingerprint, s.Verified)
+		}
+	}
+
+	for i := range starts {
+		var total Total
+		if all := totals.names[""]; all != nil {
+			total.Findings, total.Verified = len(all.periods[i]), countVerified(all.periods[i])
+		}
+		if total.Findings > 0 {
+			total.VerifiedRatio = float64(total.Verified) / float64(total.Findings)
+		}
+		report.Totals = append(report.Totals, total)
+	}
+	if all := totals.names[""]; all != nil {
+		report.Findings = len(all.findings)
+	}
+	report.Repositories = repositories.rows(opts.Top)
+	report.Teams = teams.rows(opts.Top)
+	report.Detectors = detectors.rows(opts.Top)
+	report.FileTypes = []FileType{}
+	for _, row := range fileTypes.rows(opts.Top) {
+		report.FileTypes = append(report.FileTypes, FileType{Extension: row.Name, Findings: row.Findings, Verified: row.Verified})
+	}
+	return report
+}
+
+// fileType returns the lowercase extension of a file, such as ".env", or NoExtension.
+func fileType(file string) string {
+	ext := strings.ToLower(path.Ext(strings.ReplaceAll(file, "\\", "/")))
+	if ext == "" {
+		return NoExtension
+	}
+	return ext
+}
+
+//go:embed heatmap.tmpl
+var htmlTemplate string
+
+var htmlReport = template.Must(template.New("heatmap").Funcs(template.FuncMap{
+	"heat":     heat,
+	"barWidth": func(count, max int) string { return fmt.Sprintf("%.1f", 20*float64(count)/float64(max)) },
+	"maxCount": maxCount,
+	"percent":  func(ratio float64) string { return fmt.Sprintf("%.0f%%", ratio*100) },
+}).Parse(htmlTemplate))
+
+// heat returns the opacity of a heatmap cell with count findings, relative to the most findings of any cell in the
+// table.
+func heat(count, max int) string {
+	if max == 0 || count == 0 {
+		return "0"
+	}
+	// Every cell with findings is visible, however few.
+	return fmt.Sprintf("%.2f", 0.1+0.9*float64(count)/float64(max))
+}
+
+// WriteJSON writes the report as JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// htmlData is a Report with its heatmap tables, for the HTML template.
+type htmlData struct {
+	*Report
+	Sections []htmlSection
+}
+
+type htmlSection struct {
+	Title  string
+	Column string
+	Rows   []Row
+}
+
+// WriteHTML writes the report as a self-contained HTML page.
+func (r *Report) WriteHTML(w io.Writer) error {
+	return htmlReport.Execute(w, htmlData{Report: r, Sections: []htmlSection{
+		{"Repositories", "Repository", r.Repositories},
+		{"Teams", "Team", r.Teams},
+		{"Detectors", "Detector", r.Detectors},
+	}})
+}
+
+// maxCount returns the most findings of any period of the rows, for scaling their heatmap.
+func maxCount(rows []Row) int {
+	max := 0
+	for _, row := range rows {
+		for _, c := range row.Counts {
+			if c > max {
+				max = c
+			}
+		}
+	}
+	return max
+}
@@ -0,0 +1,144 @@
+package heatmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/store"
+)
+
+func sighting(fingerprint, detector, repository, file string, seen time.Time, verified bool, owners ...string) store.Sighting {
+	return store.Sighting{
+		Fingerprint:  fingerprint,
+		DetectorType: detector,
+		State:        store.StateOpen,
+		Seen:         seen,
+		Occurrence: store.Occurrence{
+			SourceName: "trufflehog - github",
+			Repository: repository,
+			File:       file,
+			Owners:     owners,
+			Verified:   verified,
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	// A Tuesday, so the first week starts the day before.
+	start := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+	sightings := []store.Sighting{
+		sighting("one", "AWS", "org/api", "deploy/prod.env", start, false, "@org/platform"),
+		// The same finding twice in a week is counted once, and is verified if either sighting is.
+		sighting("one", "AWS", "org/api", "deploy/prod.env", start.Add(time.Hour), true, "@org/platform"),
+		sighting("two", "Stripe", "org/web", "src/Config.TS", start.Add(time.Hour), false),
+		sighting("one", "AWS", "org/api", "deploy/prod.env", start.Add(2*week), true, "@org/platform"),
+		sighting("three", "AWS", "org/api", "scripts/deploy", start.Add(2*week), false, "@org/platform", "@alice"),
+		{Fingerprint: "four", DetectorType: "Slack", State: store.StateResolved, Seen: start.Add(2 * week),
+			Occurrence: store.Occurrence{SourceName: "trufflehog - slack"}},
+		{Fingerprint: "fp", DetectorType: "AWS", State: store.StateFalsePositive, Seen: start,
+			Occurrence: store.Occurrence{Repository: "org/api", File: "test.env"}},
+	}
+	now := start.Add(3 * week)
+	report := Build(sightings, Options{Period: Week, Until: now}, now)
+
+	if want := []string{"2024-01-01", "2024-01-08", "2024-01-15", "2024-01-22"}; !reflect.DeepEqual(report.Periods, want) {
+		t.Errorf("expected periods %v up to now, got %v", want, report.Periods)
+	}
+	if report.Findings != 4 {
+		t.Errorf("expected 4 findings without the false positive, got %d", report.Findings)
+	}
+	wantTotals := []Total{{2, 1, 0.5}, {0, 0, 0}, {3, 1, 1.0 / 3}, {0, 0, 0}}
+	if !reflect.DeepEqual(report.Totals, wantTotals) {
+		t.Errorf("expected totals %+v, got %+v", wantTotals, report.Totals)
+	}
+
+	wantRepos := []Row{
+		{Name: "org/api", Findings: 2, Verified: 1, Counts: []int{1, 0, 2, 0}},
+		{Name: "org/web", Findings: 1, Counts: []int{1, 0, 0, 0}},
+		// Findings from sources without repositories are reported by source.
+		{Name: "trufflehog - slack", Findings: 1, Counts: []int{0, 0, 1, 0}},
+	}
+	if !reflect.DeepEqual(report.Repositories, wantRepos) {
+		t.Errorf("expected repositories %+v, got %+v", wantRepos, report.Repositories)
+	}
+	var teams []string
+	for _, row := range report.Teams {
+		teams = append(teams, row.Name)
+	}
+	if want := []string{"(unowned)", "@org/platform", "@alice"}; !reflect.DeepEqual(teams, want) {
+		t.Errorf("expected teams %v, got %v", want, teams)
+	}
+	if report.Detectors[0].Name != "AWS" || report.Detectors[0].Findings != 2 {
+		t.Errorf("unexpected detectors %+v", report.Detectors)
+	}
+	wantTypes := []FileType{{"(none)", 1, 0}, {".env", 1, 1}, {".ts", 1, 0}}
+	if !reflect.DeepEqual(report.FileTypes, wantTypes) {
+		t.Errorf("expected file types %+v, got %+v", wantTypes, report.FileTypes)
+	}
+
+	top := Build(sightings, Options{Period: Month, Top: 1}, now)
+	if len(top.Periods) != 1 || len(top.Repositories) != 1 || len(top.Teams) != 1 || len(top.FileTypes) != 1 {
+		t.Errorf("expected one month and the top row of each table, got %+v", top)
+	}
+}
+
+func TestBuild_Empty(t *testing.T) {
+	now := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	report := Build(nil, Options{Period: Day, Since: now.AddDate(0, 0, -2), Until: now}, now)
+	if len(report.Periods) != 3 || len(report.Totals) != 3 || report.Findings != 0 {
+		t.Errorf("expected empty periods over the time range, got %+v", report)
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"repositories", "teams", "detectors", "file_types"} {
+		if rows, ok := decoded[key].([]interface{}); !ok || len(rows) != 0 {
+			t.Errorf("expected %s to be an empty list, got %v", key, decoded[key])
+		}
+	}
+	buf.Reset()
+	if err := report.WriteHTML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "No findings were recorded in this time range.") {
+		t.Errorf("unexpected report:\n%s", buf.String())
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	start := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	report := Build([]store.Sighting{
+		sighting("one", "AWS", "<org>/api", "deploy/prod.env", start, true),
+		sighting("two", "AWS", "<org>/api", "deploy/prod.env", start, false),
+		sighting("three", "Stripe", "org/web", "main.go", start.AddDate(0, 0, 1), false),
+	}, Options{Period: Day}, start)
+
+	var buf bytes.Buffer
+	if err := report.WriteHTML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	page := buf.String()
+	for _, want := range []string{
+		"<th>2024-01-02</th><th>2024-01-03</th>",
+		"<td>50%</td><td>0%</td>",
+		`<td class="name" title="&lt;org&gt;/api">&lt;org&gt;/api</td>`,
+		`<td class="cell" style="background-color: rgba(207, 34, 46, 1.00)">2</td>`,
+		`<td class="cell" style="background-color: rgba(207, 34, 46, 0)"></td>`,
+		`<code>.env</code>`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("expected the page to contain %s", want)
+		}
+	}
+}
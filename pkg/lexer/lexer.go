@@ -0,0 +1,217 @@
+// Package lexer finds the string literals and comments of source code files. Secrets in code are almost always in
+// one of the two, so matches elsewhere, such as in identifiers and hashes, are likely false positives.
+//
+// The lexers are deliberately lightweight: they know each language's quotes and comment markers, not its grammar.
+// They tolerate the fragments of files in git diffs, where a string or comment may start before the fragment.
+package lexer
+
+import (
+	"bytes"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Kind is the kind of text a secret was found in.
+type Kind int
+
+const (
+	// Code is text outside string literals and comments.
+	Code Kind = iota
+	String
+	Comment
+)
+
+func (k Kind) String() string {
+	switch k {
+	case String:
+		return "string"
+	case Comment:
+		return "comment"
+	default:
+		return "code"
+	}
+}
+
+// Span is a string literal or comment, including its quotes or comment markers.
+type Span struct {
+	Start, End int
+	Kind       Kind
+}
+
+// quote is a string delimiter.
+type quote struct {
+	delim string
+	// raw strings don't have escapes.
+	raw bool
+	// multiLine strings can span lines. Other strings end at the end of the line even if they're unterminated.
+	multiLine bool
+}
+
+// Language is the lexical syntax of a family of languages.
+type Language struct {
+	Name          string
+	lineComments  []string
+	blockComments [][2]string
+	// quotes are tried in order, so longer delimiters, like Python's """, come first.
+	quotes []quote
+}
+
+var (
+	cLike = &Language{
+		Name:          "c",
+		lineComments:  []string{"//"},
+		blockComments: [][2]string{{"/*", "*/"}},
+		quotes:        []quote{{delim: `"`}, {delim: `'`}},
+	}
+	golang = &Language{
+		Name:          "go",
+		lineComments:  []string{"//"},
+		blockComments: [][2]string{{"/*", "*/"}},
+		quotes:        []quote{{delim: `"`}, {delim: `'`}, {delim: "`", raw: true, multiLine: true}},
+	}
+	javascript = &Language{
+		Name:          "javascript",
+		lineComments:  []string{"//"},
+		blockComments: [][2]string{{"/*", "*/"}},
+		quotes:        []quote{{delim: `"`}, {delim: `'`}, {delim: "`", multiLine: true}},
+	}
+	python = &Language{
+		Name:         "python",
+		lineComments: []string{"#"},
+		quotes: []quote{
+			{delim: `"""`, multiLine: true}, {delim: `'''`, multiLine: true}, {delim: `"`}, {delim: `'`},
+		},
+	}
+	ruby = &Language{
+		Name:          "ruby",
+		lineComments:  []string{"#"},
+		blockComments: [][2]string{{"\n=begin", "\n=end"}},
+		quotes:        []quote{{delim: `"`, multiLine: true}, {delim: `'`, multiLine: true}},
+	}
+	shell = &Language{
+		Name:         "shell",
+		lineComments: []string{"#"},
+		quotes:       []quote{{delim: `"`, multiLine: true}, {delim: `'`, raw: true, multiLine: true}},
+	}
+	php = &Language{
+		Name:          "php",
+		lineComments:  []string{"//", "#"},
+		blockComments: [][2]string{{"/*", "*/"}},
+		quotes:        []quote{{delim: `"`, multiLine: true}, {delim: `'`, multiLine: true}},
+	}
+	sql = &Language{
+		Name:          "sql",
+		lineComments:  []string{"--"},
+		blockComments: [][2]string{{"/*", "*/"}},
+		quotes:        []quote{{delim: `'`, multiLine: true}, {delim: `"`}},
+	}
+)
+
+// languages maps file extensions to their language.
+var languages = map[string]*Language{
+	".c": cLike, ".h": cLike, ".cc": cLike, ".cpp": cLike, ".cxx": cLike, ".hpp": cLike, ".cs": cLike, ".java": cLike,
+	".kt": cLike, ".kts": cLike, ".scala": cLike, ".swift": cLike, ".rs": cLike, ".dart": cLike, ".groovy": cLike,
+	".gradle": cLike, ".m": cLike,
+	".go": golang,
+	".js": javascript, ".jsx": javascript, ".mjs": javascript, ".cjs": javascript, ".ts": javascript, ".tsx": javascript,
+	".py": python,
+	".rb": ruby,
+	".sh": shell, ".bash": shell, ".zsh": shell,
+	".php": php,
+	".sql": sql,
+}
+
+// ForPath returns the language of a source code file, or nil if its extension isn't known.
+func ForPath(path string) *Language {
+	return languages[strings.ToLower(filepath.Ext(path))]
+}
+
+// Spans returns the string literals and comments of data, in order.
+func (l *Language) Spans(data []byte) []Span {
+	var spans []Span
+	for i := 0; i < len(data); {
+		span, ok := l.spanAt(data, i)
+		if !ok {
+			i++
+			continue
+		}
+		spans = append(spans, span)
+		i = span.End
+	}
+	return spans
+}
+
+// spanAt returns the string or comment that starts at i, if any.
+func (l *Language) spanAt(data []byte, i int) (Span, bool) {
+	rest := data[i:]
+	for _, marker := range l.lineComments {
+		if bytes.HasPrefix(rest, []byte(marker)) {
+			return Span{Start: i, End: lineEnd(data, i), Kind: Comment}, true
+		}
+	}
+	for _, markers := range l.blockComments {
+		if bytes.HasPrefix(rest, []byte(markers[0])) {
+			end := len(data)
+			if j := bytes.Index(rest[len(markers[0]):], []byte(markers[1])); j >= 0 {
+				end = i + len(markers[0]) + j + len(markers[1])
+			}
+			return Span{Start: i, End: end, Kind: Comment}, true
+		}
+	}
+	for _, q := range l.quotes {
+		if bytes.HasPrefix(rest, []byte(q.delim)) {
+			return Span{Start: i, End: stringEnd(data, i+len(q.delim), q), Kind: String}, true
+		}
+	}
+	return Span{}, false
+}
+
+// stringEnd returns the end of a string whose contents start at i.
+func stringEnd(data []byte, i int, q quote) int {
+	for i < len(data) {
+		switch {
+		case !q.raw && data[i] == '\\':
+			i += 2
+		case bytes.HasPrefix(data[i:], []byte(q.delim)):
+			return i + len(q.delim)
+		case data[i] == '\n' && !q.multiLine:
+			return i
+		default:
+			i++
+		}
+	}
+	return len(data)
+}
+
+func lineEnd(data []byte, i int) int {
+	if j := bytes.IndexByte(data[i:], '\n'); j >= 0 {
+		return i + j
+	}
+	return len(data)
+}
+
+// Locate returns the kind of text secret was found in. If it occurs more than once, a string or comment is preferred
+// to code. It returns false if secret doesn't occur in data, such as when it was decoded.
+func Locate(spans []Span, data, secret []byte) (Kind, bool) {
+	if len(secret) == 0 {
+		return Code, false
+	}
+	found := false
+	for offset := 0; offset < len(data); {
+		j := bytes.Index(data[offset:], secret)
+		if j < 0 {
+			break
+		}
+		start := offset + j
+		end := start + len(secret)
+		found = true
+		// Spans don't overlap, so only the last one to start before the secret can hold it.
+		k := sort.Search(len(spans), func(k int) bool { return spans[k].Start > start }) - 1
+		if k >= 0 && end <= spans[k].End {
+			return spans[k].Kind, true
+		}
+		offset = start + 1
+	}
+	return Code, found
+}
@@ -0,0 +1,45 @@
+package lexer
+
+import (
+	"testing"
+)
+
+func TestLocate(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		data   string
+		secret string
+		kind   Kind
+		found  bool
+	}{
+		{name: "go string", path: "main.go", data: `key := "AKIAEXAMPLE"`, secret: "AKIAEXAMPLE", kind: String, found: true},
+		{name: "go raw string", path: "main.go", data: "key := `a\\\nAKIAEXAMPLE`", secret: "AKIAEXAMPLE", kind: String, found: true},
+		{name: "go identifier", path: "main.go", data: `var AKIAEXAMPLE = 1`, secret: "AKIAEXAMPLE", kind: Code, found: true},
+		{name: "go comment", path: "main.go", data: "x := 1 // AKIAEXAMPLE\n", secret: "AKIAEXAMPLE", kind: Comment, found: true},
+		{name: "escaped quote", path: "App.java", data: `s = "a\"AKIAEXAMPLE";`, secret: "AKIAEXAMPLE", kind: String, found: true},
+		{name: "string ends at line", path: "App.java", data: "s = \"a\nAKIAEXAMPLE", secret: "AKIAEXAMPLE", kind: Code, found: true},
+		{name: "block comment", path: "app.ts", data: "/* a\nAKIAEXAMPLE\n*/ x", secret: "AKIAEXAMPLE", kind: Comment, found: true},
+		{name: "template literal", path: "app.js", data: "x = `a\n${b}AKIAEXAMPLE`", secret: "AKIAEXAMPLE", kind: String, found: true},
+		{name: "python docstring", path: "app.py", data: "\"\"\"\nAKIAEXAMPLE\n\"\"\"", secret: "AKIAEXAMPLE", kind: String, found: true},
+		{name: "python hash in string", path: "app.py", data: `x = "#" + AKIAEXAMPLE`, secret: "AKIAEXAMPLE", kind: Code, found: true},
+		{name: "shell comment", path: "deploy.sh", data: "# AKIAEXAMPLE\n", secret: "AKIAEXAMPLE", kind: Comment, found: true},
+		{name: "prefers string", path: "main.go", data: `AKIAEXAMPLE("AKIAEXAMPLE")`, secret: "AKIAEXAMPLE", kind: String, found: true},
+		{name: "not found", path: "main.go", data: `key := "QUtJQUVYQU1QTEU="`, secret: "AKIAEXAMPLE", kind: Code, found: false},
+	}
+	for _, test := range tests {
+		lang := ForPath(test.path)
+		if lang == nil {
+			t.Fatalf("%s: no language for %s", test.name, test.path)
+		}
+		data := []byte(test.data)
+		kind, found := Locate(lang.Spans(data), data, []byte(test.secret))
+		if kind != test.kind || found != test.found {
+			t.Errorf("%s: expected %s and %t, got %s and %t", test.name, test.kind, test.found, kind, found)
+		}
+	}
+
+	if ForPath("config.yaml") != nil {
+		t.Error("expected no language for YAML")
+	}
+}
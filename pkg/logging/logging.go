@@ -0,0 +1,238 @@
+// Package logging configures trufflehog's structured logger and threads it through contexts, so that fields such as
+// the source or repository being scanned are attached to every log line without being passed around by hand.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Config describes how log output is formatted and filtered.
+type Config struct {
+	// Format is either "text" or "json".
+	Format string
+	// Level is the default level for all modules.
+	Level logrus.Level
+	// ModuleLevels overrides the level for a module and its submodules, e.g. "sources" covers "sources.github".
+	ModuleLevels map[string]logrus.Level
+	// Output defaults to stderr.
+	Output io.Writer
+}
+
+type contextKey int
+
+const (
+	fieldsKey contextKey = iota
+	moduleKey
+	scanIDKey
+	unitIDKey
+)
+
+var (
+	mu      sync.RWMutex
+	config  = Config{Format: "text", Level: logrus.InfoLevel, Output: os.Stderr}
+	modules = map[string]*logrus.Logger{}
+)
+
+// Configure applies the config to the standard logrus logger and to every module logger.
+func Configure(c Config) error {
+	if c.Output == nil {
+		c.Output = os.Stderr
+	}
+	if c.Format == "" {
+		c.Format = "text"
+	}
+	if _, err := formatter(c.Format); err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	config = c
+	apply(logrus.StandardLogger(), "")
+	for name, logger := range modules {
+		apply(logger, name)
+	}
+	return nil
+}
+
+// ParseLevel parses a level name such as "debug". It exists so callers don't need to import logrus.
+func ParseLevel(level string) (logrus.Level, error) {
+	return logrus.ParseLevel(level)
+}
+
+// ParseModuleLevels parses "module=level" pairs, such as "engine=debug" or "sources.github=trace".
+func ParseModuleLevels(specs []string) (map[string]logrus.Level, error) {
+	levels := make(map[string]logrus.Level, len(specs))
+	for _, spec := range specs {
+		for _, pair := range strings.Split(spec, ",") {
+			if strings.TrimSpace(pair) == "" {
+				continue
+			}
+			parts := strings.SplitN(pair, "=", 2)
+			if len(parts) != 2 || parts[0] == "" {
+				return nil, fmt.Errorf("invalid module log level %q, expected module=level", pair)
+			}
+			level, err := logrus.ParseLevel(parts[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid log level for module %s: %w", parts[0], err)
+			}
+			levels[strings.TrimSpace(parts[0])] = level
+		}
+	}
+	return levels, nil
+}
+
+// Module returns the logger for a module, honoring any module specific level.
+func Module(name string) *logrus.Logger {
+	mu.RLock()
+	logger, ok := modules[name]
+	mu.RUnlock()
+	if ok {
+		return logger
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if logger, ok := modules[name]; ok {
+		return logger
+	}
+	logger = logrus.New()
+	apply(logger, name)
+	modules[name] = logger
+	return logger
+}
+
+// WithModule returns a context whose logger belongs to the named module.
+func WithModule(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, moduleKey, name)
+}
+
+// WithFields returns a context that attaches the fields to everything logged with it, in addition to any fields
+// already attached to the parent.
+func WithFields(ctx context.Context, fields logrus.Fields) context.Context {
+	merged := logrus.Fields{}
+	for k, v := range contextFields(ctx) {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, fieldsKey, merged)
+}
+
+// WithField is a convenience wrapper around WithFields for a single field.
+func WithField(ctx context.Context, key string, value interface{}) context.Context {
+	return WithFields(ctx, logrus.Fields{key: value})
+}
+
+// FromContext returns a log entry for the context's module with the context's fields attached.
+func FromContext(ctx context.Context) *logrus.Entry {
+	var logger *logrus.Logger
+	if name, ok := ctx.Value(moduleKey).(string); ok {
+		logger = Module(name)
+	} else {
+		logger = logrus.StandardLogger()
+	}
+	return logger.WithContext(ctx).WithFields(contextFields(ctx))
+}
+
+// newID returns a random correlation ID.
+func newID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
+
+// WithScanID returns a context whose logs are tagged with the ID of the scan, as "scan_id".
+func WithScanID(ctx context.Context, id string) context.Context {
+	return context.WithValue(WithField(ctx, "scan_id", id), scanIDKey, id)
+}
+
+// ScanID returns the scan ID set with WithScanID, or an empty string.
+func ScanID(ctx context.Context) string {
+	id, _ := ctx.Value(scanIDKey).(string)
+	return id
+}
+
+// WithUnit returns a context for scanning a single unit of a source, such as a repository or an object. Its logs are
+// tagged with the unit, as the field named kind, and with a new correlation ID, as "unit_id". Sources copy the ID onto
+// their chunks, so that the results of a unit can be traced back to its logs, even in a scan of thousands of units.
+func WithUnit(ctx context.Context, kind, name string) context.Context {
+	id := newID()
+	return context.WithValue(WithFields(ctx, logrus.Fields{kind: name, "unit_id": id}), unitIDKey, id)
+}
+
+// UnitID returns the correlation ID of the unit set with WithUnit, or an empty string.
+func UnitID(ctx context.Context) string {
+	id, _ := ctx.Value(unitIDKey).(string)
+	return id
+}
+
+func contextFields(ctx context.Context) logrus.Fields {
+	fields, _ := ctx.Value(fieldsKey).(logrus.Fields)
+	return fields
+}
+
+// apply must be called with mu held.
+func apply(logger *logrus.Logger, module string) {
+	f, _ := formatter(config.Format)
+	logger.SetFormatter(f)
+	logger.SetOutput(config.Output)
+	logger.SetLevel(moduleLevel(module))
+	if module != "" {
+		hooks := logrus.LevelHooks{}
+		hooks.Add(moduleHook(module))
+		logger.ReplaceHooks(hooks)
+	}
+}
+
+// moduleLevel returns the level of the most specific configured module matching name.
+func moduleLevel(name string) logrus.Level {
+	for name != "" {
+		if level, ok := config.ModuleLevels[name]; ok {
+			return level
+		}
+		i := strings.LastIndex(name, ".")
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return config.Level
+}
+
+func formatter(format string) (logrus.Formatter, error) {
+	switch format {
+	case "text":
+		return &logrus.TextFormatter{}, nil
+	case "json":
+		return &logrus.JSONFormatter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q, expected text or json", format)
+	}
+}
+
+// moduleHook adds the module name to every entry of a module logger.
+type moduleHook string
+
+func (h moduleHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h moduleHook) Fire(entry *logrus.Entry) error {
+	if _, ok := entry.Data["module"]; !ok {
+		entry.Data["module"] = string(h)
+	}
+	return nil
+}
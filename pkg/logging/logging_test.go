@@ -0,0 +1,133 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestParseModuleLevels(t *testing.T) {
+	tests := map[string]struct {
+		specs   []string
+		want    map[string]logrus.Level
+		wantErr bool
+	}{
+		"empty": {
+			specs: nil,
+			want:  map[string]logrus.Level{},
+		},
+		"repeated flags": {
+			specs: []string{"engine=debug", "sources.github=trace"},
+			want:  map[string]logrus.Level{"engine": logrus.DebugLevel, "sources.github": logrus.TraceLevel},
+		},
+		"comma separated": {
+			specs: []string{"engine=warn,sources=error"},
+			want:  map[string]logrus.Level{"engine": logrus.WarnLevel, "sources": logrus.ErrorLevel},
+		},
+		"missing level": {
+			specs:   []string{"engine"},
+			wantErr: true,
+		},
+		"unknown level": {
+			specs:   []string{"engine=loud"},
+			wantErr: true,
+		},
+	}
+	for name, test := range tests {
+		got, err := ParseModuleLevels(test.specs)
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: unexpected error. Got: %v, Expected error: %t", name, err, test.wantErr)
+			continue
+		}
+		if test.wantErr {
+			continue
+		}
+		if len(got) != len(test.want) {
+			t.Errorf("%s: unexpected levels. Got: %v, Expected: %v", name, got, test.want)
+			continue
+		}
+		for module, level := range test.want {
+			if got[module] != level {
+				t.Errorf("%s: unexpected level for %s. Got: %v, Expected: %v", name, module, got[module], level)
+			}
+		}
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	err := Configure(Config{
+		Format:       "json",
+		Level:        logrus.InfoLevel,
+		ModuleLevels: map[string]logrus.Level{"sources": logrus.DebugLevel},
+		Output:       &buf,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer Configure(Config{})
+
+	ctx := WithFields(WithModule(context.Background(), "sources.github"), logrus.Fields{"source_type": "github"})
+	ctx = WithField(ctx, "repo", "https://github.com/trufflesecurity/test_keys.git")
+	FromContext(ctx).Debug("scanning repo")
+
+	var line map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("could not decode log line %q: %s", buf.String(), err)
+	}
+	want := map[string]string{
+		"msg":         "scanning repo",
+		"level":       "debug",
+		"module":      "sources.github",
+		"source_type": "github",
+		"repo":        "https://github.com/trufflesecurity/test_keys.git",
+	}
+	for k, v := range want {
+		if line[k] != v {
+			t.Errorf("unexpected %s. Got: %v, Expected: %v", k, line[k], v)
+		}
+	}
+
+	buf.Reset()
+	FromContext(WithModule(context.Background(), "engine")).Debug("filtered")
+	if buf.Len() != 0 {
+		t.Errorf("expected debug log of an info level module to be filtered, got %q", buf.String())
+	}
+}
+
+func TestCorrelationIDs(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Configure(Config{Format: "json", Level: logrus.InfoLevel, Output: &buf}); err != nil {
+		t.Fatal(err)
+	}
+	defer Configure(Config{})
+
+	ctx := WithScanID(context.Background(), "scan-1")
+	first := WithUnit(ctx, "repo", "https://github.com/trufflesecurity/test_keys.git")
+	second := WithUnit(ctx, "repo", "https://github.com/trufflesecurity/trufflehog.git")
+	if UnitID(first) == "" || UnitID(first) == UnitID(second) {
+		t.Errorf("expected distinct unit IDs, got %q and %q", UnitID(first), UnitID(second))
+	}
+	if ScanID(first) != "scan-1" || UnitID(ctx) != "" {
+		t.Errorf("unexpected IDs. Got scan ID %q and unit ID %q outside a unit", ScanID(first), UnitID(ctx))
+	}
+
+	FromContext(first).Info("could not clone repo")
+	var line map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("could not decode log line %q: %s", buf.String(), err)
+	}
+	want := map[string]string{
+		"scan_id": "scan-1",
+		"unit_id": UnitID(first),
+		"repo":    "https://github.com/trufflesecurity/test_keys.git",
+	}
+	for k, v := range want {
+		if line[k] != v {
+			t.Errorf("unexpected %s. Got: %v, Expected: %v", k, line[k], v)
+		}
+	}
+}
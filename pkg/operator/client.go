@@ -0,0 +1,100 @@
+package operator
+
+import (
+	"context"
+
+	"github.com/go-errors/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// Client wraps the Kubernetes clients used by the operator. SecretScans are read and updated through the dynamic
+// client, so no generated clientset is needed for the custom resource.
+type Client struct {
+	kube    kubernetes.Interface
+	dynamic dynamic.Interface
+}
+
+// NewClient returns a Client using the provided clients.
+func NewClient(kube kubernetes.Interface, dynamicClient dynamic.Interface) *Client {
+	return &Client{
+		kube:    kube,
+		dynamic: dynamicClient,
+	}
+}
+
+// InClusterClient returns a Client configured from the pod's service account.
+func InClusterClient() (*Client, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "could not load in-cluster config", 0)
+	}
+	kube, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(kube, dynamicClient), nil
+}
+
+// ListSecretScans lists SecretScans in a namespace, or in all namespaces if namespace is empty.
+func (c *Client) ListSecretScans(ctx context.Context, namespace string) ([]SecretScan, error) {
+	list, err := c.dynamic.Resource(secretScanResource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	scans := make([]SecretScan, 0, len(list.Items))
+	for _, item := range list.Items {
+		var scan SecretScan
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &scan); err != nil {
+			return nil, errors.WrapPrefix(err, "could not decode SecretScan "+item.GetNamespace()+"/"+item.GetName(), 0)
+		}
+		scans = append(scans, scan)
+	}
+	return scans, nil
+}
+
+// UpdateSecretScanStatus writes the status subresource of a SecretScan. The scan's resource version is updated, so
+// it can be updated again.
+func (c *Client) UpdateSecretScanStatus(ctx context.Context, scan *SecretScan) error {
+	scan.APIVersion = Group + "/" + Version
+	scan.Kind = Kind
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(scan)
+	if err != nil {
+		return err
+	}
+	updated, err := c.dynamic.Resource(secretScanResource).Namespace(scan.Namespace).UpdateStatus(ctx, &unstructured.Unstructured{Object: obj}, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	scan.ResourceVersion = updated.GetResourceVersion()
+	return nil
+}
+
+// ApplyConfigMap creates the ConfigMap, or replaces its data and annotations if it already exists.
+func (c *Client) ApplyConfigMap(ctx context.Context, cm *corev1.ConfigMap) error {
+	configMaps := c.kube.CoreV1().ConfigMaps(cm.Namespace)
+	existing, err := configMaps.Get(ctx, cm.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		_, err = configMaps.Create(ctx, cm, metav1.CreateOptions{})
+		return err
+	case err != nil:
+		return err
+	}
+	existing.Labels = cm.Labels
+	existing.Annotations = cm.Annotations
+	existing.OwnerReferences = cm.OwnerReferences
+	existing.Data = cm.Data
+	_, err = configMaps.Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
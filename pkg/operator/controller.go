@@ -0,0 +1,366 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
+)
+
+const (
+	// jobTTL is how long finished Jobs are kept. Their outcome is also recorded on the findings ConfigMap, so the
+	// status of a scan can still be reconciled once its Job is cleaned up.
+	jobTTL = 24 * time.Hour
+	// jobGracePeriod is added to a scan's timeout for its Job's deadline, giving the scan time to record its partial
+	// findings before the Job is stopped.
+	jobGracePeriod = 5 * time.Minute
+	// maxJobNamePrefix leaves room for the start time in Job names, which are limited to 63 characters.
+	maxJobNamePrefix = 52
+
+	// envSecretScan and envJob pass the scan and the name of its Job to the Job's pod.
+	envSecretScan = "TRUFFLEHOG_SECRETSCAN"
+	envJob        = "TRUFFLEHOG_JOB"
+	// The credentials of a scan are passed to its Job's pod as these variables, read from the referenced Secrets.
+	envGitHubToken        = "GITHUB_TOKEN"
+	envGitLabToken        = "GITLAB_TOKEN"
+	envAWSAccessKeyID     = "AWS_ACCESS_KEY_ID"
+	envAWSSecretAccessKey = "AWS_SECRET_ACCESS_KEY"
+
+	labelManagedBy  = "app.kubernetes.io/managed-by"
+	labelSecretScan = Group + "/secretscan"
+	// The outcome of a scan is recorded on its findings ConfigMap with these annotations.
+	annotationJob              = Group + "/job"
+	annotationFindings         = Group + "/findings"
+	annotationVerifiedFindings = Group + "/verified-findings"
+	annotationDuration         = Group + "/duration"
+	annotationError            = Group + "/error"
+)
+
+// Controller periodically reconciles SecretScan resources, starting a Job for each scan that is due and recording
+// the outcome of the Jobs that finished.
+type Controller struct {
+	client             *Client
+	namespace          string
+	resync             time.Duration
+	maxConcurrentScans int
+	image              string
+	serviceAccount     string
+	log                *log.Entry
+}
+
+// NewController returns a Controller watching namespace, or all namespaces if namespace is empty. Scans are run in
+// Jobs using image, with the serviceAccount that must exist in the namespace of each SecretScan.
+func NewController(client *Client, namespace string, resync time.Duration, maxConcurrentScans int, image, serviceAccount string) *Controller {
+	if maxConcurrentScans < 1 {
+		maxConcurrentScans = 1
+	}
+	return &Controller{
+		client:             client,
+		namespace:          namespace,
+		resync:             resync,
+		maxConcurrentScans: maxConcurrentScans,
+		image:              image,
+		serviceAccount:     serviceAccount,
+		log:                log.NewEntry(logging.Module("operator")),
+	}
+}
+
+// Run reconciles SecretScans every resync interval until the context is cancelled. Scans run in Jobs, so they carry
+// on while the operator is stopped, and their outcome is recorded once it's started again.
+func (c *Controller) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.resync)
+	defer ticker.Stop()
+	for {
+		c.reconcile(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Controller) reconcile(ctx context.Context, now time.Time) {
+	scans, err := c.client.ListSecretScans(ctx, c.namespace)
+	if err != nil {
+		c.log.WithError(err).Error("could not list SecretScans")
+		return
+	}
+
+	running := 0
+	for i := range scans {
+		if scans[i].Status.Phase != PhaseRunning {
+			continue
+		}
+		c.syncJob(ctx, &scans[i])
+		if scans[i].Status.Phase == PhaseRunning {
+			running++
+		}
+	}
+
+	for i := range scans {
+		if !due(&scans[i], now) {
+			continue
+		}
+		if running >= c.maxConcurrentScans {
+			// All job slots are taken, pick the remaining scans up on a later resync.
+			return
+		}
+		if c.startJob(ctx, &scans[i], now) {
+			running++
+		}
+	}
+}
+
+// due returns true if the scan should be started at the provided time.
+func due(scan *SecretScan, now time.Time) bool {
+	if scan.Spec.Suspend || scan.Status.Phase == PhaseRunning {
+		return false
+	}
+	// A changed spec always triggers a new scan.
+	if scan.Status.ObservedGeneration != scan.Generation || scan.Status.LastScanTime == "" {
+		return true
+	}
+	if scan.Spec.Interval == "" {
+		return false
+	}
+	interval, err := time.ParseDuration(scan.Spec.Interval)
+	if err != nil || interval <= 0 {
+		return false
+	}
+	last, err := time.Parse(time.RFC3339, scan.Status.LastScanTime)
+	if err != nil {
+		return true
+	}
+	return !now.Before(last.Add(interval))
+}
+
+// startJob marks the scan running and starts a Job for it, returning whether the Job was started. The status is
+// updated first, so a Job is never started for a scan that isn't marked running. If the operator stops before the
+// Job is created, the scan is marked failed on the next reconcile.
+func (c *Controller) startJob(ctx context.Context, scan *SecretScan, now time.Time) bool {
+	logger := c.log.WithField("scan", scan.Key())
+
+	job, err := c.newJob(scan, jobName(scan, now))
+	if err != nil {
+		logger.WithError(err).Error("could not build scan Job")
+		return false
+	}
+
+	scan.Status.Phase = PhaseRunning
+	scan.Status.Message = ""
+	scan.Status.ObservedGeneration = scan.Generation
+	scan.Status.LastScanTime = now.UTC().Format(time.RFC3339)
+	scan.Status.Job = job.Name
+	if err := c.client.UpdateSecretScanStatus(ctx, scan); err != nil {
+		logger.WithError(err).Error("could not update SecretScan status, skipping scan")
+		return false
+	}
+
+	if _, err := c.client.kube.BatchV1().Jobs(scan.Namespace).Create(ctx, job, metav1.CreateOptions{}); err != nil {
+		logger.WithError(err).Error("could not create scan Job")
+		scan.Status.Phase = PhaseFailed
+		scan.Status.Message = fmt.Sprintf("could not create Job %s: %s", job.Name, err)
+		if err := c.client.UpdateSecretScanStatus(ctx, scan); err != nil {
+			logger.WithError(err).Error("could not update SecretScan status")
+		}
+		return false
+	}
+	logger.WithField("job", job.Name).Info("started scan")
+	return true
+}
+
+// syncJob records the outcome of a running scan once its Job has finished. Scans whose Job is missing and that
+// haven't recorded an outcome, such as scans that were running when the operator stopped before the Job was created,
+// are marked failed rather than being left running.
+func (c *Controller) syncJob(ctx context.Context, scan *SecretScan) {
+	logger := c.log.WithField("scan", scan.Key())
+
+	var job *batchv1.Job
+	if scan.Status.Job != "" {
+		var err error
+		job, err = c.client.kube.BatchV1().Jobs(scan.Namespace).Get(ctx, scan.Status.Job, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			job = nil
+		case err != nil:
+			logger.WithError(err).Error("could not get scan Job")
+			return
+		case !jobFinished(job):
+			return
+		}
+	}
+
+	cm, err := c.client.kube.CoreV1().ConfigMaps(scan.Namespace).Get(ctx, scan.resultsConfigMap(), metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		logger.WithError(err).Error("could not get findings ConfigMap")
+		return
+	}
+
+	switch {
+	case err == nil && scan.Status.Job != "" && cm.Annotations[annotationJob] == scan.Status.Job:
+		scan.Status.Findings, _ = strconv.Atoi(cm.Annotations[annotationFindings])
+		scan.Status.VerifiedFindings, _ = strconv.Atoi(cm.Annotations[annotationVerifiedFindings])
+		scan.Status.LastScanDuration = cm.Annotations[annotationDuration]
+		if msg := cm.Annotations[annotationError]; msg != "" {
+			scan.Status.Phase = PhaseFailed
+			scan.Status.Message = msg
+		} else {
+			scan.Status.Phase = PhaseSucceeded
+			scan.Status.Message = ""
+			scan.Status.ResultsConfigMap = cm.Name
+		}
+	case scan.Status.Job == "":
+		scan.Status.Phase = PhaseFailed
+		scan.Status.Message = "scan was interrupted before its Job was started"
+	case job == nil:
+		scan.Status.Phase = PhaseFailed
+		scan.Status.Message = fmt.Sprintf("Job %s was deleted before the scan recorded its findings", scan.Status.Job)
+	default:
+		scan.Status.Phase = PhaseFailed
+		scan.Status.Message = fmt.Sprintf("Job %s failed before the scan recorded its findings: %s", job.Name, jobFailure(job))
+	}
+	logger.WithField("phase", scan.Status.Phase).Info("scan finished")
+
+	if err := c.client.UpdateSecretScanStatus(ctx, scan); err != nil {
+		logger.WithError(err).Error("could not update SecretScan status")
+	}
+}
+
+// jobFinished returns true if the Job completed or failed.
+func jobFinished(job *batchv1.Job) bool {
+	for _, cond := range job.Status.Conditions {
+		if (cond.Type == batchv1.JobComplete || cond.Type == batchv1.JobFailed) && cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// jobFailure returns why the Job failed, such as its deadline being exceeded.
+func jobFailure(job *batchv1.Job) string {
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobFailed && cond.Status == corev1.ConditionTrue {
+			return strings.TrimSpace(cond.Reason + " " + cond.Message)
+		}
+	}
+	return "Job completed without recording findings"
+}
+
+// jobName returns the name of the Job for a scan started at the provided time.
+func jobName(scan *SecretScan, start time.Time) string {
+	prefix := scan.Name
+	if len(prefix) > maxJobNamePrefix {
+		prefix = strings.TrimRight(prefix[:maxJobNamePrefix], "-.")
+	}
+	return fmt.Sprintf("%s-%d", prefix, start.Unix())
+}
+
+// newJob returns the Job running the scan. The Job is owned by the scan, so it is deleted with it, and the scan's
+// credentials are read by the Job's pod from the referenced Secrets.
+func (c *Controller) newJob(scan *SecretScan, name string) (*batchv1.Job, error) {
+	// Only the spec is passed, so the scan is run as configured when the Job was started.
+	encoded, err := json.Marshal(SecretScan{
+		ObjectMeta: metav1.ObjectMeta{Name: scan.Name, Namespace: scan.Namespace, UID: scan.UID},
+		Spec:       scan.Spec,
+	})
+	if err != nil {
+		return nil, err
+	}
+	env := []corev1.EnvVar{
+		{Name: envSecretScan, Value: string(encoded)},
+		{Name: envJob, Value: name},
+	}
+	src := scan.Spec.Source
+	if src.GitHub != nil {
+		env = append(env, secretEnv(envGitHubToken, src.GitHub.TokenSecretRef)...)
+	}
+	if src.GitLab != nil {
+		env = append(env, secretEnv(envGitLabToken, src.GitLab.TokenSecretRef)...)
+	}
+	if src.S3 != nil {
+		env = append(env, secretEnv(envAWSAccessKeyID, src.S3.KeySecretRef)...)
+		env = append(env, secretEnv(envAWSSecretAccessKey, src.S3.SecretSecretRef)...)
+	}
+
+	backoffLimit := int32(0)
+	ttl := int32(jobTTL / time.Second)
+	labels := scanLabels(scan)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       scan.Namespace,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{scanOwner(scan)},
+		},
+		Spec: batchv1.JobSpec{
+			// A failed scan is reported rather than retried, and runs again on its next interval.
+			BackoffLimit:            &backoffLimit,
+			TTLSecondsAfterFinished: &ttl,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: c.serviceAccount,
+					Containers: []corev1.Container{{
+						Name:    "scan",
+						Image:   c.image,
+						Command: []string{"trufflehog"},
+						Args:    []string{"--no-update", "operator-scan"},
+						Env:     env,
+					}},
+				},
+			},
+		},
+	}
+	if timeout, err := time.ParseDuration(scan.Spec.Timeout); err == nil && timeout > 0 {
+		deadline := int64((timeout + jobGracePeriod) / time.Second)
+		job.Spec.ActiveDeadlineSeconds = &deadline
+	}
+	return job, nil
+}
+
+func secretEnv(name string, ref *SecretKeyRef) []corev1.EnvVar {
+	if ref == nil {
+		return nil
+	}
+	return []corev1.EnvVar{{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: ref.Name},
+				Key:                  ref.Key,
+			},
+		},
+	}}
+}
+
+func scanLabels(scan *SecretScan) map[string]string {
+	return map[string]string{
+		labelManagedBy:  "trufflehog",
+		labelSecretScan: scan.Name,
+	}
+}
+
+// scanOwner returns the owner reference tying the Jobs and ConfigMaps of a scan to it, so they are garbage
+// collected with it.
+func scanOwner(scan *SecretScan) metav1.OwnerReference {
+	controller := true
+	return metav1.OwnerReference{
+		APIVersion: Group + "/" + Version,
+		Kind:       Kind,
+		Name:       scan.Name,
+		UID:        scan.UID,
+		Controller: &controller,
+	}
+}
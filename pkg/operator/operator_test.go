@@ -0,0 +1,197 @@
+package operator
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+func TestDue(t *testing.T) {
+	now := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		scan SecretScan
+		due  bool
+	}{
+		"never scanned": {
+			scan: SecretScan{},
+			due:  true,
+		},
+		"suspended": {
+			scan: SecretScan{Spec: SecretScanSpec{Suspend: true}},
+			due:  false,
+		},
+		"running": {
+			scan: SecretScan{Status: SecretScanStatus{Phase: PhaseRunning}},
+			due:  false,
+		},
+		"one-off already scanned": {
+			scan: SecretScan{Status: SecretScanStatus{LastScanTime: now.Add(-time.Hour).Format(time.RFC3339)}},
+			due:  false,
+		},
+		"spec changed": {
+			scan: SecretScan{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status:     SecretScanStatus{ObservedGeneration: 1, LastScanTime: now.Format(time.RFC3339)},
+			},
+			due: true,
+		},
+		"interval not elapsed": {
+			scan: SecretScan{
+				Spec:   SecretScanSpec{Interval: "2h"},
+				Status: SecretScanStatus{LastScanTime: now.Add(-time.Hour).Format(time.RFC3339)},
+			},
+			due: false,
+		},
+		"interval elapsed": {
+			scan: SecretScan{
+				Spec:   SecretScanSpec{Interval: "1h"},
+				Status: SecretScanStatus{LastScanTime: now.Add(-time.Hour).Format(time.RFC3339)},
+			},
+			due: true,
+		},
+	}
+	for name, test := range tests {
+		if got := due(&test.scan, now); got != test.due {
+			t.Errorf("%s: unexpected due result. Got: %t, Expected: %t", name, got, test.due)
+		}
+	}
+}
+
+func newTestScan(t *testing.T, name string, status SecretScanStatus) *unstructured.Unstructured {
+	t.Helper()
+	scan := SecretScan{
+		TypeMeta:   metav1.TypeMeta{APIVersion: Group + "/" + Version, Kind: Kind},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "security", UID: types.UID("uid-" + name), Generation: 1},
+		Spec: SecretScanSpec{
+			Interval: "24h",
+			Timeout:  "1h",
+			Source: SourceSpec{GitHub: &GitHubSpec{
+				Orgs:           []string{"trufflesecurity"},
+				TokenSecretRef: &SecretKeyRef{Name: "github", Key: "token"},
+			}},
+		},
+		Status: status,
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&scan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &unstructured.Unstructured{Object: obj}
+}
+
+func getScan(t *testing.T, client *Client, name string) SecretScan {
+	t.Helper()
+	scans, err := client.ListSecretScans(context.Background(), "security")
+	if err != nil {
+		t.Fatalf("could not list scans: %s", err)
+	}
+	for _, scan := range scans {
+		if scan.Name == name {
+			return scan
+		}
+	}
+	t.Fatalf("scan %s not found", name)
+	return SecretScan{}
+}
+
+func TestControllerReconcile(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	kube := kubefake.NewSimpleClientset()
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{secretScanResource: "SecretScanList"},
+		newTestScan(t, "nightly", SecretScanStatus{}),
+		// A scan left running by an operator that stopped before creating its Job.
+		newTestScan(t, "stale", SecretScanStatus{Phase: PhaseRunning, Job: "stale-1", LastScanTime: now.Format(time.RFC3339), ObservedGeneration: 1}),
+	)
+	client := NewClient(kube, dynamicClient)
+	controller := NewController(client, "security", time.Minute, 1, "trufflesecurity/trufflehog:latest", "trufflehog-scanner")
+
+	controller.reconcile(ctx, now)
+
+	stale := getScan(t, client, "stale")
+	if stale.Status.Phase != PhaseFailed || !strings.Contains(stale.Status.Message, "stale-1 was deleted") {
+		t.Errorf("expected the stale scan to fail, got phase %q: %q", stale.Status.Phase, stale.Status.Message)
+	}
+
+	nightly := getScan(t, client, "nightly")
+	if nightly.Status.Phase != PhaseRunning || nightly.Status.Job != jobName(&nightly, now) {
+		t.Fatalf("expected the nightly scan to be running in a Job, got phase %q and Job %q", nightly.Status.Phase, nightly.Status.Job)
+	}
+	job, err := kube.BatchV1().Jobs("security").Get(ctx, nightly.Status.Job, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("could not get Job: %s", err)
+	}
+	if len(job.OwnerReferences) != 1 || job.OwnerReferences[0].UID != "uid-nightly" {
+		t.Errorf("expected the Job to be owned by the scan, got %+v", job.OwnerReferences)
+	}
+	if job.Spec.ActiveDeadlineSeconds == nil || *job.Spec.ActiveDeadlineSeconds != int64((time.Hour+jobGracePeriod)/time.Second) {
+		t.Errorf("unexpected Job deadline: %v", job.Spec.ActiveDeadlineSeconds)
+	}
+	var tokenRef *corev1.SecretKeySelector
+	for _, env := range job.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == envGitHubToken && env.ValueFrom != nil {
+			tokenRef = env.ValueFrom.SecretKeyRef
+		}
+	}
+	if tokenRef == nil || tokenRef.Name != "github" || tokenRef.Key != "token" {
+		t.Errorf("expected the token to be read from the github secret, got %+v", tokenRef)
+	}
+
+	// The Job is still running, so nothing changes and no other Job is started.
+	controller.reconcile(ctx, now.Add(time.Minute))
+	if jobs, _ := kube.BatchV1().Jobs("security").List(ctx, metav1.ListOptions{}); len(jobs.Items) != 1 {
+		t.Fatalf("expected 1 Job, got %d", len(jobs.Items))
+	}
+	if phase := getScan(t, client, "nightly").Status.Phase; phase != PhaseRunning {
+		t.Fatalf("expected the scan to still be running, got %q", phase)
+	}
+
+	// The scan records its findings and its Job completes.
+	results := []detectors.ResultWithMetadata{
+		{Result: detectors.Result{Verified: true, Redacted: "AKIA..."}},
+		{Result: detectors.Result{Redacted: "ghp_..."}},
+	}
+	if err := writeFindings(ctx, client, &nightly, nightly.Status.Job, 2*time.Second, results, nil); err != nil {
+		t.Fatalf("could not write findings: %s", err)
+	}
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	if _, err := kube.BatchV1().Jobs("security").UpdateStatus(ctx, job, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	controller.reconcile(ctx, now.Add(2*time.Minute))
+
+	nightly = getScan(t, client, "nightly")
+	if nightly.Status.Phase != PhaseSucceeded {
+		t.Fatalf("expected the scan to succeed, got phase %q: %q", nightly.Status.Phase, nightly.Status.Message)
+	}
+	if nightly.Status.Findings != 2 || nightly.Status.VerifiedFindings != 1 || nightly.Status.ResultsConfigMap != "nightly-findings" {
+		t.Errorf("unexpected status: %+v", nightly.Status)
+	}
+}
+
+func TestJobName(t *testing.T) {
+	start := time.Unix(1651406400, 0)
+	scan := &SecretScan{ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a", 51) + "-b-c"}}
+	name := jobName(scan, start)
+	if len(name) > 63 {
+		t.Errorf("Job name %q is longer than 63 characters", name)
+	}
+	if want := strings.Repeat("a", 51) + "-1651406400"; name != want {
+		t.Errorf("unexpected Job name. Got: %q, Expected: %q", name, want)
+	}
+}
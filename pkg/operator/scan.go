@@ -0,0 +1,194 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/go-errors/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/decoders"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/git"
+)
+
+// maxStoredFindings caps the findings written to a ConfigMap, which are limited to 1MiB in total.
+const maxStoredFindings = 1000
+
+// RunScan runs the scan passed to the Job it's called from by the operator. The findings and the outcome of the scan
+// are written to the scan's findings ConfigMap, where the operator reads them once the Job finishes.
+func RunScan(ctx context.Context, client *Client) error {
+	var scan SecretScan
+	if err := json.Unmarshal([]byte(os.Getenv(envSecretScan)), &scan); err != nil {
+		return errors.WrapPrefix(err, "could not decode "+envSecretScan, 0)
+	}
+	logger := log.NewEntry(logging.Module("operator")).WithField("scan", scan.Key())
+	start := time.Now()
+
+	scanCtx := ctx
+	if timeout, parseErr := time.ParseDuration(scan.Spec.Timeout); parseErr == nil && timeout > 0 {
+		var cancelScan context.CancelFunc
+		scanCtx, cancelScan = context.WithTimeout(ctx, timeout)
+		defer cancelScan()
+	}
+
+	logger.Info("starting scan")
+	results, err := scanSource(scanCtx, &scan)
+	if err == nil && scanCtx.Err() != nil {
+		err = errors.New("scan interrupted by Job shutdown")
+		if ctx.Err() == nil {
+			err = fmt.Errorf("scan timed out after %s", scan.Spec.Timeout)
+		}
+	}
+	if err != nil {
+		logger.WithError(err).Error("scan failed")
+	} else {
+		logger.WithField("findings", len(results)).Info("scan finished")
+	}
+
+	// The scan context is cancelled when the Job is stopped or the scan times out, but whatever was found so far and
+	// the outcome should still be recorded.
+	writeCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if writeErr := writeFindings(writeCtx, client, &scan, os.Getenv(envJob), time.Since(start), results, err); writeErr != nil {
+		return errors.WrapPrefix(writeErr, "could not write findings", 0)
+	}
+	return err
+}
+
+func scanSource(ctx context.Context, scan *SecretScan) ([]detectors.ResultWithMetadata, error) {
+	spec := scan.Spec
+	concurrency := spec.Concurrency
+	if concurrency == 0 {
+		concurrency = runtime.NumCPU()
+	}
+	e := engine.Start(ctx,
+		engine.WithConcurrency(concurrency),
+		engine.WithDecoders(decoders.DefaultDecoders()...),
+		engine.WithDetectors(!spec.NoVerification, engine.DefaultDetectors()...),
+		engine.WithOnlyVerified(spec.OnlyVerified),
+	)
+
+	repoPath, err := startScan(ctx, e, scan, concurrency)
+	if repoPath != "" {
+		defer os.RemoveAll(repoPath)
+	}
+	if err != nil {
+		// Nothing was started, so the engine workers need to be released here.
+		close(e.ChunksChan())
+		return nil, err
+	}
+
+	var results []detectors.ResultWithMetadata
+	for r := range e.ResultsChan() {
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// startScan starts scanning the configured source in the engine. If a remote repository was cloned, its path is
+// returned so it can be cleaned up once the scan is done. Credentials are read from the variables the operator set
+// from the referenced Secrets.
+func startScan(ctx context.Context, e *engine.Engine, scan *SecretScan, concurrency int) (string, error) {
+	src := scan.Spec.Source
+	switch {
+	case src.Git != nil:
+		repoPath, remote, err := git.PrepareRepo(src.Git.URI)
+		if err != nil {
+			return "", err
+		}
+		err = e.ScanGit(ctx, repoPath, src.Git.Branch, src.Git.SinceCommit, src.Git.MaxDepth, common.FilterEmpty())
+		if !remote {
+			return "", err
+		}
+		return repoPath, err
+	case src.GitHub != nil:
+		endpoint := src.GitHub.Endpoint
+		if endpoint == "" {
+			endpoint = "https://api.github.com"
+		}
+		return "", e.ScanGitHub(ctx, endpoint, src.GitHub.Repos, src.GitHub.Orgs, os.Getenv(envGitHubToken), src.GitHub.IncludeForks, common.FilterEmpty(), concurrency, src.GitHub.IncludeMembers, src.GitHub.IncludeRepos, src.GitHub.ExcludeRepos)
+	case src.GitLab != nil:
+		endpoint := src.GitLab.Endpoint
+		if endpoint == "" {
+			endpoint = "https://gitlab.com"
+		}
+		return "", e.ScanGitLab(ctx, endpoint, os.Getenv(envGitLabToken), src.GitLab.Repos, src.GitLab.IncludeRepos, src.GitLab.ExcludeRepos)
+	case src.Filesystem != nil:
+		return "", e.ScanFileSystem(ctx, src.Filesystem.Directories)
+	case src.S3 != nil:
+		return "", e.ScanS3(ctx, os.Getenv(envAWSAccessKeyID), os.Getenv(envAWSSecretAccessKey), src.S3.CloudEnvironment, src.S3.Buckets)
+	default:
+		return "", errors.New("spec.source must configure a source")
+	}
+}
+
+// finding is the representation of a result stored in the findings ConfigMap. ConfigMaps are not meant to hold
+// sensitive data, so the raw secret is never included.
+type finding struct {
+	DetectorType   string                      `json:"detectorType"`
+	Verified       bool                        `json:"verified"`
+	Redacted       string                      `json:"redacted,omitempty"`
+	SourceName     string                      `json:"sourceName"`
+	SourceMetadata *source_metadatapb.MetaData `json:"sourceMetadata"`
+}
+
+// writeFindings writes the findings to the scan's ConfigMap, and records the outcome of the scan and the Job that ran
+// it in its annotations.
+func writeFindings(ctx context.Context, client *Client, scan *SecretScan, job string, duration time.Duration, results []detectors.ResultWithMetadata, scanErr error) error {
+	findings := make([]finding, 0, len(results))
+	verified := 0
+	for _, r := range results {
+		if r.Verified {
+			verified++
+		}
+		if len(findings) == maxStoredFindings {
+			continue
+		}
+		findings = append(findings, finding{
+			DetectorType:   r.DetectorType.String(),
+			Verified:       r.Verified,
+			Redacted:       r.Redacted,
+			SourceName:     r.SourceName,
+			SourceMetadata: r.SourceMetadata,
+		})
+	}
+	data, err := json.Marshal(findings)
+	if err != nil {
+		return err
+	}
+
+	annotations := map[string]string{
+		annotationJob:              job,
+		annotationFindings:         strconv.Itoa(len(results)),
+		annotationVerifiedFindings: strconv.Itoa(verified),
+		annotationDuration:         duration.Round(time.Second).String(),
+	}
+	if scanErr != nil {
+		annotations[annotationError] = scanErr.Error()
+	}
+	return client.ApplyConfigMap(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            scan.resultsConfigMap(),
+			Namespace:       scan.Namespace,
+			Labels:          scanLabels(scan),
+			Annotations:     annotations,
+			OwnerReferences: []metav1.OwnerReference{scanOwner(scan)},
+		},
+		Data: map[string]string{
+			"findings.json": string(data),
+			"truncated":     fmt.Sprintf("%t", len(results) > maxStoredFindings),
+		},
+	})
+}
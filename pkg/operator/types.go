@@ -0,0 +1,134 @@
+package operator
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// Group is the API group of the SecretScan custom resource.
+	Group = "trufflesecurity.com"
+	// Version is the API version of the SecretScan custom resource.
+	Version = "v1alpha1"
+	// Plural is the resource name used in API paths.
+	Plural = "secretscans"
+	// Kind is the kind of the SecretScan custom resource.
+	Kind = "SecretScan"
+
+	PhasePending   = "Pending"
+	PhaseRunning   = "Running"
+	PhaseSucceeded = "Succeeded"
+	PhaseFailed    = "Failed"
+)
+
+// SecretScan describes a scan that the operator should run, and optionally how often to run it.
+type SecretScan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecretScanSpec   `json:"spec"`
+	Status SecretScanStatus `json:"status,omitempty"`
+}
+
+type SecretScanSpec struct {
+	// Interval is a Go duration (e.g. "24h") between scans. An empty interval runs the scan once.
+	Interval string `json:"interval,omitempty"`
+	// Timeout is a Go duration after which the scan is cancelled. Findings up to that point are still recorded.
+	Timeout string `json:"timeout,omitempty"`
+	// Suspend stops any new scans from being scheduled.
+	Suspend        bool        `json:"suspend,omitempty"`
+	OnlyVerified   bool        `json:"onlyVerified,omitempty"`
+	NoVerification bool        `json:"noVerification,omitempty"`
+	Concurrency    int         `json:"concurrency,omitempty"`
+	Source         SourceSpec  `json:"source"`
+	Results        ResultsSpec `json:"results,omitempty"`
+}
+
+// SourceSpec holds the source to scan. Exactly one field should be set.
+type SourceSpec struct {
+	Git        *GitSpec        `json:"git,omitempty"`
+	GitHub     *GitHubSpec     `json:"github,omitempty"`
+	GitLab     *GitLabSpec     `json:"gitlab,omitempty"`
+	Filesystem *FilesystemSpec `json:"filesystem,omitempty"`
+	S3         *S3Spec         `json:"s3,omitempty"`
+}
+
+// SecretKeyRef points to a key of a Secret in the same namespace as the SecretScan.
+type SecretKeyRef struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+type GitSpec struct {
+	URI         string `json:"uri"`
+	Branch      string `json:"branch,omitempty"`
+	SinceCommit string `json:"sinceCommit,omitempty"`
+	MaxDepth    int    `json:"maxDepth,omitempty"`
+}
+
+type GitHubSpec struct {
+	Endpoint       string        `json:"endpoint,omitempty"`
+	Repos          []string      `json:"repos,omitempty"`
+	Orgs           []string      `json:"orgs,omitempty"`
+	TokenSecretRef *SecretKeyRef `json:"tokenSecretRef,omitempty"`
+	IncludeForks   bool          `json:"includeForks,omitempty"`
+	IncludeMembers bool          `json:"includeMembers,omitempty"`
+	// IncludeRepos and ExcludeRepos are repository names or globs that filter the repos found in Orgs.
+	IncludeRepos []string `json:"includeRepos,omitempty"`
+	ExcludeRepos []string `json:"excludeRepos,omitempty"`
+}
+
+type GitLabSpec struct {
+	Endpoint       string        `json:"endpoint,omitempty"`
+	Repos          []string      `json:"repos,omitempty"`
+	TokenSecretRef *SecretKeyRef `json:"tokenSecretRef,omitempty"`
+	// IncludeRepos and ExcludeRepos are project paths or globs that filter the projects found when Repos is empty.
+	IncludeRepos []string `json:"includeRepos,omitempty"`
+	ExcludeRepos []string `json:"excludeRepos,omitempty"`
+}
+
+type FilesystemSpec struct {
+	Directories []string `json:"directories"`
+}
+
+type S3Spec struct {
+	Buckets          []string      `json:"buckets,omitempty"`
+	KeySecretRef     *SecretKeyRef `json:"keySecretRef,omitempty"`
+	SecretSecretRef  *SecretKeyRef `json:"secretSecretRef,omitempty"`
+	CloudEnvironment bool          `json:"cloudEnvironment,omitempty"`
+}
+
+// ResultsSpec configures where findings are written.
+type ResultsSpec struct {
+	// ConfigMap is the name of the ConfigMap findings are written to. Defaults to "<scan name>-findings".
+	ConfigMap string `json:"configMap,omitempty"`
+}
+
+type SecretScanStatus struct {
+	Phase              string `json:"phase,omitempty"`
+	Message            string `json:"message,omitempty"`
+	ObservedGeneration int64  `json:"observedGeneration,omitempty"`
+	// LastScanTime is the RFC 3339 start time of the most recent scan.
+	LastScanTime     string `json:"lastScanTime,omitempty"`
+	LastScanDuration string `json:"lastScanDuration,omitempty"`
+	Findings         int    `json:"findings"`
+	VerifiedFindings int    `json:"verifiedFindings"`
+	ResultsConfigMap string `json:"resultsConfigMap,omitempty"`
+	// Job is the name of the Job running the most recent scan.
+	Job string `json:"job,omitempty"`
+}
+
+// secretScanResource is the resource SecretScans are served as.
+var secretScanResource = schema.GroupVersionResource{Group: Group, Version: Version, Resource: Plural}
+
+// Key returns the namespace/name key of the SecretScan.
+func (s *SecretScan) Key() string {
+	return s.Namespace + "/" + s.Name
+}
+
+func (s *SecretScan) resultsConfigMap() string {
+	if s.Spec.Results.ConfigMap != "" {
+		return s.Spec.Results.ConfigMap
+	}
+	return s.Name + "-findings"
+}
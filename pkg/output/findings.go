@@ -0,0 +1,86 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+)
+
+// FindingsSink is a Sink for integrations that send findings, as read back from --json output, to ticketing systems
+// and SIEMs. Results are converted to findings as they're written, and sent in batches of BatchSize, with the rest
+// sent when the sink is flushed.
+type FindingsSink struct {
+	// Send sends a batch of findings.
+	Send func(ctx context.Context, batch []findings.Finding) error
+	// BatchSize is the number of findings sent at once. Zero sends them all when the sink is flushed.
+	BatchSize int
+
+	batch []findings.Finding
+}
+
+func (s *FindingsSink) Start(context.Context) error { return nil }
+
+// Write adds the result to the batch, and sends the batch once it's full.
+func (s *FindingsSink) Write(ctx context.Context, r *detectors.ResultWithMetadata) error {
+	finding, err := findings.FindingFromResult(r)
+	if err != nil {
+		return err
+	}
+	s.batch = append(s.batch, finding)
+	if s.BatchSize > 0 && len(s.batch) >= s.BatchSize {
+		return s.Flush(ctx)
+	}
+	return nil
+}
+
+// Flush sends the batch.
+func (s *FindingsSink) Flush(ctx context.Context) error {
+	if len(s.batch) == 0 {
+		return nil
+	}
+	if err := s.Send(ctx, s.batch); err != nil {
+		return err
+	}
+	s.batch = s.batch[:0]
+	return nil
+}
+
+func (s *FindingsSink) Close() error { return nil }
+
+// CheckSinkOptions returns an error if options has a key that isn't known, for sink factories.
+func CheckSinkOptions(options map[string]string, known ...string) error {
+	var unknown []string
+	for key := range options {
+		found := false
+		for _, k := range known {
+			if key == k {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown option %s, expected %s", strings.Join(unknown, ", "), strings.Join(known, ", "))
+	}
+	return nil
+}
+
+// BoolSinkOption parses an optional boolean sink option.
+func BoolSinkOption(options map[string]string, key string) (bool, error) {
+	switch strings.ToLower(options[key]) {
+	case "", "false", "0", "no":
+		return false, nil
+	case "true", "1", "yes":
+		return true, nil
+	default:
+		return false, fmt.Errorf("option %s must be true or false", key)
+	}
+}
@@ -0,0 +1,47 @@
+package output
+
+import (
+	"context"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+func TestFindingsSink(t *testing.T) {
+	var batches [][]findings.Finding
+	sink := &FindingsSink{
+		BatchSize: 2,
+		Send: func(_ context.Context, batch []findings.Finding) error {
+			batches = append(batches, append([]findings.Finding(nil), batch...))
+			return nil
+		},
+	}
+	ctx := context.Background()
+	for _, raw := range []string{"one", "two", "three"} {
+		r := &detectors.ResultWithMetadata{
+			SourceName: "trufflehog - git",
+			Result:     detectors.Result{DetectorType: detectorspb.DetectorType_AWS, Raw: []byte(raw), Verified: true},
+		}
+		if err := sink.Write(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(batches) != 1 || len(batches[0]) != 2 {
+		t.Fatalf("expected a full batch to be sent while writing, got %+v", batches)
+	}
+	if err := sink.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(batches) != 2 || len(batches[1]) != 1 {
+		t.Fatalf("expected the rest to be sent on flush, got %+v", batches)
+	}
+	got := batches[1][0]
+	if got.Raw != "three" || got.DetectorType != "AWS" || !got.Verified || got.SourceName != "trufflehog - git" {
+		t.Errorf("unexpected finding: %+v", got)
+	}
+	if err := sink.Flush(ctx); err != nil || len(batches) != 2 {
+		t.Errorf("expected an empty flush to send nothing, got %d batches and %v", len(batches), err)
+	}
+}
@@ -0,0 +1,133 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+)
+
+// GroupedResult is every occurrence of one secret. Rotating the secret resolves all of them at once.
+type GroupedResult struct {
+	DetectorType string
+	// Verified is true if any occurrence was verified.
+	Verified bool
+	Raw      []byte
+	Redacted string
+	Entropy  float64
+	// Severity is the highest severity of any occurrence.
+	Severity    detectors.Severity
+	Remediation *detectors.Remediation `json:",omitempty"`
+	Locations   []Location
+}
+
+// Location is where one occurrence of a secret was found.
+type Location struct {
+	SourceName     string
+	SourceType     sourcespb.SourceType
+	SourceMetadata *source_metadatapb.MetaData
+	PresentAtHead  *bool          `json:",omitempty"`
+	Age            *detectors.Age `json:",omitempty"`
+	Context        string         `json:",omitempty"`
+	Provenance     []string       `json:",omitempty"`
+}
+
+// Grouper collects results and groups them by detector type and secret.
+type Grouper struct {
+	groups map[string]*GroupedResult
+	order  []string
+}
+
+// NewGrouper returns an empty Grouper.
+func NewGrouper() *Grouper {
+	return &Grouper{groups: map[string]*GroupedResult{}}
+}
+
+// Add adds an occurrence of a result.
+func (g *Grouper) Add(r *detectors.ResultWithMetadata) {
+	key := r.DetectorType.String() + "\x00" + string(r.Raw)
+	group, ok := g.groups[key]
+	if !ok {
+		group = &GroupedResult{
+			DetectorType: r.DetectorType.String(),
+			Raw:          r.Raw,
+			Redacted:     r.Redacted,
+			Entropy:      r.Entropy,
+			Remediation:  r.Remediation,
+		}
+		g.groups[key] = group
+		g.order = append(g.order, key)
+	}
+	group.Verified = group.Verified || r.Verified
+	if r.Severity > group.Severity {
+		group.Severity = r.Severity
+	}
+	group.Locations = append(group.Locations, Location{
+		SourceName:     r.SourceName,
+		SourceType:     r.SourceType,
+		SourceMetadata: r.SourceMetadata,
+		PresentAtHead:  r.PresentAtHead,
+		Age:            r.Age,
+		Context:        r.Context,
+		Provenance:     r.Provenance,
+	})
+}
+
+// Results returns the grouped results, most severe first and then in the order they were first found.
+func (g *Grouper) Results() []GroupedResult {
+	results := make([]GroupedResult, 0, len(g.order))
+	for _, key := range g.order {
+		results = append(results, *g.groups[key])
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Severity > results[j].Severity
+	})
+	return results
+}
+
+// PrintGroupedPlainOutput prints a human readable, colorized version of the grouped result to stdout.
+func PrintGroupedPlainOutput(r *GroupedResult) error {
+	printer := greenPrinter
+	if r.Verified {
+		yellowPrinter.Printf("Found verified result in %d locations 🐷🔑\n", len(r.Locations))
+	} else {
+		printer = whitePrinter
+		whitePrinter.Printf("Found unverified result in %d locations 🐷🔑❓\n", len(r.Locations))
+	}
+	printer.Printf("Detector Type: %s\n", r.DetectorType)
+	printer.Printf("Raw result: %s\n", whitePrinter.Sprint(strings.TrimSpace(string(r.Raw))))
+	printer.Printf("Entropy: %.2f\n", r.Entropy)
+	printRemediation(printer, r.Remediation)
+	for i, location := range r.Locations {
+		meta, err := structToMap(location.SourceMetadata.GetData())
+		if err != nil {
+			return fmt.Errorf("could not marshal result: %w", err)
+		}
+		printer.Printf("Location %d:\n", i+1)
+		for _, data := range meta {
+			for k, v := range data {
+				printer.Printf("  %s: %v\n", strings.Title(k), v)
+			}
+		}
+		if len(location.Provenance) > 0 {
+			printer.Printf("  Provenance: %s\n", strings.Join(location.Provenance, " → "))
+		}
+		if location.PresentAtHead != nil {
+			printer.Printf("  Present at HEAD: %t\n", *location.PresentAtHead)
+		}
+		if location.Age != nil {
+			printer.Printf("  Age: %s\n", formatAge(location.Age))
+		}
+		if location.Context != "" {
+			printer.Println("  Context:")
+			for _, line := range strings.Split(location.Context, "\n") {
+				whitePrinter.Printf("      %s\n", line)
+			}
+		}
+	}
+	fmt.Println("")
+	return nil
+}
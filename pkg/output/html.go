@@ -0,0 +1,217 @@
+package output
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/allowlist"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/version"
+)
+
+//go:embed html_report.tmpl
+var htmlReportTemplate string
+
+var htmlReport = template.Must(template.New("report").Parse(htmlReportTemplate))
+
+// HTMLSink writes the results as a single static HTML file, for sharing them with people who don't use the CLI. The
+// report can be filtered by detector, repository, and verification, and sorted by any column, with scripts embedded
+// in the file, so it works offline and as an email attachment. Results include the lines around the secret when the
+// engine was configured with context lines, with the secret masked. Secrets are otherwise only included redacted, and
+// identified by their hash. Like SARIF, the report is a single document, so results are buffered and written when the
+// scan finishes.
+type HTMLSink struct {
+	path    string
+	version string
+	w       io.Writer
+	file    io.Closer
+
+	findings []htmlFinding
+}
+
+// NewHTMLSink returns an HTMLSink that writes to the file at path once the scan finishes, naming version as the
+// version of trufflehog that found the results.
+func NewHTMLSink(path, version string) *HTMLSink {
+	return &HTMLSink{path: path, version: version}
+}
+
+func init() {
+	RegisterSink("html", newHTMLFileSink)
+}
+
+// newHTMLFileSink writes an HTML report of the results to the file option.
+func newHTMLFileSink(options map[string]string) (Sink, error) {
+	for key := range options {
+		if key != "file" {
+			return nil, fmt.Errorf("unknown option %s, expected file", key)
+		}
+	}
+	path := options["file"]
+	if path == "" {
+		return nil, fmt.Errorf("the file option is required")
+	}
+	return NewHTMLSink(path, version.BuildVersion), nil
+}
+
+type htmlFinding struct {
+	Detector     string
+	Verified     bool
+	Severity     string
+	SeverityRank int
+	Source       string
+	Repository   string
+	File         string
+	Line         int64
+	Commit       string
+	Link         string
+	Secret       string
+	Hash         string
+	Snippet      []htmlSnippetLine
+}
+
+type htmlSnippetLine struct {
+	// Number is the line's number in its file, or zero if it isn't known.
+	Number int64
+	Text   string
+	// Secret is set on the line the secret was found on.
+	Secret bool
+}
+
+type htmlReportData struct {
+	Version   string
+	Generated string
+	Findings  []htmlFinding
+	Verified  int
+	// Detectors and Repositories are the distinct values to filter by, sorted.
+	Detectors    []string
+	Repositories []string
+}
+
+// Start creates the report's file, so that a path that can't be written fails the scan before it starts.
+func (s *HTMLSink) Start(context.Context) error {
+	file, err := CreateOutputFile(s.path, 0)
+	if err != nil {
+		return err
+	}
+	s.w, s.file = file, file
+	return nil
+}
+
+func (s *HTMLSink) Write(_ context.Context, r *detectors.ResultWithMetadata) error {
+	finding := htmlFinding{
+		Detector:     r.DetectorType.String(),
+		Verified:     r.Verified,
+		Severity:     r.Severity.String(),
+		SeverityRank: int(r.Severity),
+		Source:       r.SourceName,
+		Repository:   r.SourceName,
+		File:         filepath.ToSlash(detectors.FilePath(r.SourceMetadata)),
+		Secret:       r.Redacted,
+		Hash:         allowlist.Hash(r.Raw),
+	}
+	if finding.Secret == "" {
+		finding.Secret = maskSecret(string(r.Raw))
+	}
+	for _, field := range []string{"repository", "bucket"} {
+		if v := metadataField(r.SourceMetadata, field); v.IsValid() && v.String() != "" {
+			finding.Repository = v.String()
+			break
+		}
+	}
+	if v := metadataField(r.SourceMetadata, "commit"); v.IsValid() {
+		finding.Commit = v.String()
+	}
+	if v := metadataField(r.SourceMetadata, "link"); v.IsValid() {
+		finding.Link = v.String()
+	}
+	if v := metadataField(r.SourceMetadata, "line"); v.IsValid() {
+		finding.Line = v.Int()
+	}
+	finding.Snippet = snippetLines(r.Context, string(r.Raw), finding.Line)
+	s.findings = append(s.findings, finding)
+	return nil
+}
+
+// Flush writes the report of every result, verified and most severe first.
+func (s *HTMLSink) Flush(context.Context) error {
+	sort.SliceStable(s.findings, func(i, j int) bool {
+		a, b := s.findings[i], s.findings[j]
+		if a.Verified != b.Verified {
+			return a.Verified
+		}
+		return a.SeverityRank > b.SeverityRank
+	})
+	data := htmlReportData{
+		Version:   s.version,
+		Generated: time.Now().UTC().Format(time.RFC1123),
+		Findings:  s.findings,
+	}
+	detectorSet, repoSet := map[string]bool{}, map[string]bool{}
+	for _, f := range s.findings {
+		if f.Verified {
+			data.Verified++
+		}
+		detectorSet[f.Detector] = true
+		repoSet[f.Repository] = true
+	}
+	data.Detectors, data.Repositories = sortedKeys(detectorSet), sortedKeys(repoSet)
+	return htmlReport.Execute(s.w, data)
+}
+
+func (s *HTMLSink) Close() error {
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Close()
+}
+
+// maskSecret hides all but the first few characters of a secret, enough to tell secrets apart in a report.
+func maskSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	shown := len(secret) / 4
+	if shown > 4 {
+		shown = 4
+	}
+	return secret[:shown] + strings.Repeat("*", 8)
+}
+
+// snippetLines splits the context lines around a secret, masking the secret and numbering the lines if the line of
+// the secret is known. Other values on the lines are left as they are.
+func snippetLines(snippet, secret string, line int64) []htmlSnippetLine {
+	if snippet == "" {
+		return nil
+	}
+	secretLine := -1
+	// The secret is masked before splitting, since it can span lines, like a private key.
+	if i := strings.Index(snippet, secret); secret != "" && i >= 0 {
+		secretLine = strings.Count(snippet[:i], "\n")
+		snippet = strings.ReplaceAll(snippet, secret, maskSecret(secret))
+	}
+	texts := strings.Split(snippet, "\n")
+	lines := make([]htmlSnippetLine, len(texts))
+	for i, text := range texts {
+		lines[i] = htmlSnippetLine{Text: text, Secret: i == secretLine}
+		if line > 0 && secretLine >= 0 {
+			lines[i].Number = line - int64(secretLine-i)
+		}
+	}
+	return lines
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,221 @@
+package output
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/allowlist"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+// JUnitSink writes results as a JUnit XML report, so CI systems such as Jenkins, GitLab, and Azure DevOps show them as
+// failed tests. Each detector type is a test suite, with a failed test case for each file it found secrets in. The
+// failure lists the file's secrets, redacted, with their location and the lines around them when the engine was
+// configured with context lines, with the secret masked. A scan without results is a single passed test case, since
+// some CI systems treat a report without tests as an error. Like SARIF, results are buffered and written when the scan
+// finishes.
+type JUnitSink struct {
+	w       io.Writer
+	version string
+
+	suites     []*junitSuite
+	suiteIndex map[string]*junitSuite
+}
+
+// NewJUnitSink returns a JUnitSink that writes to w, naming version as the version of trufflehog that found the results.
+func NewJUnitSink(w io.Writer, version string) *JUnitSink {
+	return &JUnitSink{w: w, version: version, suiteIndex: map[string]*junitSuite{}}
+}
+
+type junitReport struct {
+	XMLName  xml.Name      `xml:"testsuites"`
+	Name     string        `xml:"name,attr"`
+	Tests    int           `xml:"tests,attr"`
+	Failures int           `xml:"failures,attr"`
+	Suites   []*junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Cases    []*junitCase `xml:"testcase"`
+
+	caseIndex map[string]*junitCase
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+
+	detector string
+	secrets  int
+	verified int
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+func (s *JUnitSink) Start(context.Context) error { return nil }
+
+func (s *JUnitSink) Write(_ context.Context, r *detectors.ResultWithMetadata) error {
+	detector := r.DetectorType.String()
+	suite, ok := s.suiteIndex[detector]
+	if !ok {
+		suite = &junitSuite{Name: detector, caseIndex: map[string]*junitCase{}}
+		s.suites = append(s.suites, suite)
+		s.suiteIndex[detector] = suite
+	}
+
+	file := filepath.ToSlash(detectors.FilePath(r.SourceMetadata))
+	repository := ""
+	if v := metadataField(r.SourceMetadata, "repository"); v.IsValid() {
+		repository = v.String()
+	}
+	name := junitCaseName(r.SourceName, repository, file)
+	c, ok := suite.caseIndex[name]
+	if !ok {
+		c = &junitCase{
+			Name:      name,
+			ClassName: "trufflehog." + detector,
+			File:      file,
+			Failure:   &junitFailure{Type: "unverified"},
+			detector:  detector,
+		}
+		suite.Cases = append(suite.Cases, c)
+		suite.caseIndex[name] = c
+		suite.Tests++
+		suite.Failures++
+	}
+	c.secrets++
+	if r.Verified {
+		c.verified++
+		c.Failure.Type = "verified"
+	}
+	c.Failure.Message = c.message()
+	if c.Failure.Text != "" {
+		c.Failure.Text += "\n"
+	}
+	c.Failure.Text += junitDetails(r)
+	return nil
+}
+
+// Flush writes the report of every result.
+func (s *JUnitSink) Flush(context.Context) error {
+	report := junitReport{Name: "TruffleHog", Suites: s.suites}
+	if s.version != "" {
+		report.Name += " " + s.version
+	}
+	for _, suite := range s.suites {
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+	}
+	if len(report.Suites) == 0 {
+		report.Tests = 1
+		report.Suites = []*junitSuite{{
+			Name:  "TruffleHog",
+			Tests: 1,
+			Cases: []*junitCase{{Name: "no secrets found", ClassName: "trufflehog"}},
+		}}
+	}
+	if _, err := io.WriteString(s.w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(s.w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(s.w, "\n"); err != nil {
+		return err
+	}
+	if f, ok := s.w.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+func (s *JUnitSink) Close() error { return nil }
+
+// message summarizes the secrets found in a test case's file, such as "2 AWS secrets found, 1 verified".
+func (c *junitCase) message() string {
+	if c.secrets == 1 {
+		if c.verified == 1 {
+			return "Verified " + c.detector + " secret found"
+		}
+		return "Unverified " + c.detector + " secret found"
+	}
+	return fmt.Sprintf("%d %s secrets found, %d verified", c.secrets, c.detector, c.verified)
+}
+
+// junitCaseName names the test case of a file: the file, with its repository if it has one, or the repository or
+// source for sources without files.
+func junitCaseName(source, repository, file string) string {
+	switch {
+	case file != "" && repository != "":
+		return file + " (" + repository + ")"
+	case file != "":
+		return file
+	case repository != "":
+		return repository
+	}
+	return source
+}
+
+// junitDetails describes a result in its test case's failure. The secret is only included redacted, or masked if the
+// detector doesn't redact it, and identified by its hash.
+func junitDetails(r *detectors.ResultWithMetadata) string {
+	var b strings.Builder
+	status := "Unverified"
+	if r.Verified {
+		status = "Verified"
+	}
+	secret := r.Redacted
+	if secret == "" {
+		secret = maskSecret(string(r.Raw))
+	}
+	fmt.Fprintf(&b, "%s %s secret %s (%s severity)\n", status, r.DetectorType, secret, r.Severity)
+
+	var line int64
+	if v := metadataField(r.SourceMetadata, "line"); v.IsValid() {
+		line = v.Int()
+	}
+	if file := detectors.FilePath(r.SourceMetadata); file != "" {
+		if line > 0 {
+			file = fmt.Sprintf("%s:%d", file, line)
+		}
+		fmt.Fprintf(&b, "File: %s\n", filepath.ToSlash(file))
+	}
+	for _, field := range []struct{ name, label string }{
+		{"commit", "Commit"},
+		{"repository", "Repository"},
+		{"link", "Link"},
+	} {
+		if v := metadataField(r.SourceMetadata, field.name); v.IsValid() && v.String() != "" {
+			fmt.Fprintf(&b, "%s: %s\n", field.label, v.String())
+		}
+	}
+	fmt.Fprintf(&b, "Source: %s\n", r.SourceName)
+	fmt.Fprintf(&b, "Secret hash: %s\n", allowlist.Hash(r.Raw))
+	for _, l := range snippetLines(r.Context, string(r.Raw), line) {
+		marker := " "
+		if l.Secret {
+			marker = ">"
+		}
+		number := ""
+		if l.Number > 0 {
+			number = fmt.Sprint(l.Number)
+		}
+		fmt.Fprintf(&b, "%s %6s | %s\n", marker, number, l.Text)
+	}
+	return b.String()
+}
@@ -2,9 +2,10 @@ package output
 
 import (
 	"fmt"
-	"log"
 	"net/url"
+	"sort"
 	"strings"
+	"sync"
 
 	gogit "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
@@ -15,7 +16,8 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
 )
 
-func ConvertToLegacyJSON(r *detectors.ResultWithMetadata, repoPath string) *LegacyJSONOutput {
+// ConvertToLegacyJSON converts a result from a git based source to the pre-v3.0 JSON format.
+func ConvertToLegacyJSON(r *detectors.ResultWithMetadata, repoPath string) (*LegacyJSONOutput, error) {
 	var source LegacyJSONCompatibleSource
 	switch r.SourceType {
 	case sourcespb.SourceType_SOURCE_TYPE_GIT:
@@ -25,21 +27,21 @@ func ConvertToLegacyJSON(r *detectors.ResultWithMetadata, repoPath string) *Lega
 	case sourcespb.SourceType_SOURCE_TYPE_GITLAB:
 		source = r.SourceMetadata.GetGitlab()
 	default:
-		log.Fatalf("legacy JSON output can not be used with this source: %s", r.SourceName)
+		return nil, fmt.Errorf("legacy JSON output can not be used with this source: %s", r.SourceName)
 	}
 
 	// The repo will be needed to gather info needed for the legacy output that isn't included in the new
 	// output format.
 	repo, err := gogit.PlainOpenWithOptions(repoPath, &gogit.PlainOpenOptions{DetectDotGit: true})
 	if err != nil {
-		logrus.WithError(err).Fatalf("could not open repo: %s", repoPath)
+		return nil, fmt.Errorf("could not open repo %s: %w", repoPath, err)
 	}
 
 	fileName := source.GetFile()
 	commitHash := plumbing.NewHash(source.GetCommit())
 	commit, err := repo.CommitObject(commitHash)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("could not find commit %s: %w", commitHash, err)
 	}
 
 	diff := GenerateDiff(commit, fileName)
@@ -50,8 +52,14 @@ func ConvertToLegacyJSON(r *detectors.ResultWithMetadata, repoPath string) *Lega
 	printableDiff := strings.ReplaceAll(diff, foundString, fmt.Sprintf("\u001b[93m%s\u001b[0m", foundString))
 
 	// Load up the struct to match the old JSON format
+	branches := FindBranches(commit, repo, repoPath)
+	var branch string
+	if len(branches) > 0 {
+		branch = branches[0]
+	}
 	output := &LegacyJSONOutput{
-		Branch:       FindBranch(commit, repo),
+		Branch:       branch,
+		Branches:     branches,
 		Commit:       commit.Message,
 		CommitHash:   commitHash.String(),
 		Date:         commit.Committer.When.Format("2006-01-02 15:04:05"),
@@ -61,54 +69,98 @@ func ConvertToLegacyJSON(r *detectors.ResultWithMetadata, repoPath string) *Lega
 		Reason:       r.Result.DetectorType.String(),
 		StringsFound: []string{foundString},
 	}
-	return output
+	return output, nil
+}
+
+// BranchIndex maps commits to the branches that contain them. Building it walks the history of each branch once, which
+// is much cheaper than checking ancestry against every branch head for every result.
+type BranchIndex struct {
+	names    []string
+	branches map[plumbing.Hash][]int
 }
 
-// BranchHeads creates a map of branch names to their head commit. This can be used to find if a commit is an ancestor
-// of a branch head.
-func BranchHeads(repo *gogit.Repository) (map[string]*object.Commit, error) {
-	branches := map[string]*object.Commit{}
-	branchIter, err := repo.Branches()
+// NewBranchIndex indexes the local and remote branches of the repository.
+func NewBranchIndex(repo *gogit.Repository) (*BranchIndex, error) {
+	refs, err := repo.References()
 	if err != nil {
-		return branches, err
+		return nil, err
 	}
-
-	err = branchIter.ForEach(func(branchRef *plumbing.Reference) error {
-		branchName := branchRef.Name().String()
-		headHash, err := repo.ResolveRevision(plumbing.Revision(branchName))
-		if err != nil {
-			logrus.WithError(err).Errorf("unable to resolve head of branch: %s", branchRef.Name().String())
+	heads := map[string]plumbing.Hash{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference || !(ref.Name().IsBranch() || ref.Name().IsRemote()) {
 			return nil
 		}
-		headCommit, err := repo.CommitObject(*headHash)
-		if err != nil {
-			logrus.WithError(err).Errorf("unable to get commit: %s", headCommit.String())
-			return nil
-		}
-		branches[branchName] = headCommit
+		heads[ref.Name().Short()] = ref.Hash()
 		return nil
 	})
-	return branches, err
-}
-
-// FindBranch returns the first branch a commit is a part of. Not the most accurate, but it should work similar to pre v3.0.
-func FindBranch(commit *object.Commit, repo *gogit.Repository) string {
-	branches, err := BranchHeads(repo)
 	if err != nil {
-		logrus.WithError(err).Fatal("could not list branches")
+		return nil, err
 	}
 
-	for name, head := range branches {
-		isAncestor, err := commit.IsAncestor(head)
-		if err != nil {
-			logrus.WithError(err).Errorf("could not determine if %s is an ancestor of %s", commit.Hash.String(), head.Hash.String())
+	index := &BranchIndex{branches: map[plumbing.Hash][]int{}}
+	for name := range heads {
+		index.names = append(index.names, name)
+	}
+	// Walking branches in sorted order keeps each commit's branch list sorted.
+	sort.Strings(index.names)
+	for i, name := range index.names {
+		if err := index.walk(repo, heads[name], i); err != nil {
+			logrus.WithError(err).Errorf("unable to walk history of branch: %s", name)
+		}
+	}
+	return index, nil
+}
+
+// walk records that the branch contains the head and all of its ancestors.
+func (b *BranchIndex) walk(repo *gogit.Repository, head plumbing.Hash, branch int) error {
+	stack := []plumbing.Hash{head}
+	for len(stack) > 0 {
+		hash := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if contains := b.branches[hash]; len(contains) > 0 && contains[len(contains)-1] == branch {
 			continue
 		}
-		if isAncestor {
-			return name
+		commit, err := repo.CommitObject(hash)
+		if err != nil {
+			return err
+		}
+		b.branches[hash] = append(b.branches[hash], branch)
+		stack = append(stack, commit.ParentHashes...)
+	}
+	return nil
+}
+
+// Branches returns the sorted names of the branches that contain the commit.
+func (b *BranchIndex) Branches(hash plumbing.Hash) []string {
+	indexes := b.branches[hash]
+	names := make([]string, len(indexes))
+	for i, index := range indexes {
+		names[i] = b.names[index]
+	}
+	return names
+}
+
+var branchIndexes = struct {
+	sync.Mutex
+	byPath map[string]*BranchIndex
+}{byPath: map[string]*BranchIndex{}}
+
+// FindBranches returns the sorted names of all branches a commit is a part of. The branches of each repository path
+// are indexed the first time it is used.
+func FindBranches(commit *object.Commit, repo *gogit.Repository, repoPath string) []string {
+	branchIndexes.Lock()
+	defer branchIndexes.Unlock()
+	index, ok := branchIndexes.byPath[repoPath]
+	if !ok {
+		var err error
+		index, err = NewBranchIndex(repo)
+		if err != nil {
+			logrus.WithError(err).Error("could not list branches")
+			return nil
 		}
+		branchIndexes.byPath[repoPath] = index
 	}
-	return ""
+	return index.Branches(commit.Hash)
 }
 
 // GenerateDiff will take a commit and create a string diff between the commit and its first parent.
@@ -172,7 +224,9 @@ func GenerateDiff(commit *object.Commit, fileName string) string {
 }
 
 type LegacyJSONOutput struct {
+	// Branch is the first of Branches, for compatibility with the pre-v3.0 format.
 	Branch       string   `json:"branch"`
+	Branches     []string `json:"branches"`
 	Commit       string   `json:"commit"`
 	CommitHash   string   `json:"commitHash"`
 	Date         string   `json:"date"`
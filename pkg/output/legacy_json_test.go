@@ -0,0 +1,79 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestBranchIndex(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := gogit.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit := func(msg string) plumbing.Hash {
+		if err := os.WriteFile(filepath.Join(dir, "file"), []byte(msg), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := worktree.Add("file"); err != nil {
+			t.Fatal(err)
+		}
+		hash, err := worktree.Commit(msg, &gogit.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hash
+	}
+	branch := func(name string, hash plumbing.Hash) {
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName(name), hash)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	root := commit("root")
+	shared := commit("shared")
+	branch("release", shared)
+	branch("feature", shared)
+	mainOnly := commit("main only")
+
+	index, err := NewBranchIndex(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mainBranch := head.Name().Short()
+	all := []string{"feature", mainBranch, "release"}
+	sort.Strings(all)
+
+	tests := map[string]struct {
+		hash plumbing.Hash
+		want []string
+	}{
+		"root":      {hash: root, want: all},
+		"shared":    {hash: shared, want: all},
+		"main only": {hash: mainOnly, want: []string{mainBranch}},
+		"unknown":   {hash: plumbing.NewHash("0000000000000000000000000000000000000001"), want: []string{}},
+	}
+	for name, test := range tests {
+		if got := index.Branches(test.hash); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: unexpected branches. Got: %v, Expected: %v", name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package output
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+// Manifest describes how a scan was run, so that saved --json output can be understood and reproduced later. It's
+// written as {"Manifest": ...} before the results, and the scan's end as {"ScanEnd": ...} after them.
+type Manifest struct {
+	ScanID    string
+	StartTime time.Time
+	Version   string
+	// Command is the source command, such as "github".
+	Command string
+	// Options are the flags and arguments the scan was run with, by name. Credentials in them are redacted.
+	Options map[string]string
+	// Detectors are the names of the detectors that ran.
+	Detectors []string
+}
+
+// ScanEnd records the end of a scan described by a Manifest.
+type ScanEnd struct {
+	ScanID  string
+	EndTime time.Time
+	Results uint64
+	// Cancelled is set if the scan was stopped, by a signal or a timeout, before its sources finished.
+	Cancelled bool `json:",omitempty"`
+	// Suppressed counts the results dropped by allowlists, false positive filters, and triage, by reason.
+	Suppressed map[string]uint64 `json:",omitempty"`
+}
+
+// ManifestLine and ScanEndLine are the envelope lines of --json output.
+type ManifestLine struct {
+	Manifest *Manifest
+}
+
+type ScanEndLine struct {
+	ScanEnd *ScanEnd
+}
+
+// redactedValue replaces credentials in the manifest.
+const redactedValue = "REDACTED"
+
+// sensitiveOption matches names of options that hold credentials.
+var sensitiveOption = regexp.MustCompile(`(?i)(token|secret|password|key)$`)
+
+// NewScanID returns a random ID for a scan.
+func NewScanID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
+
+// RedactOptions returns a copy of the options with credentials replaced. Options named like "token" or "secret" are
+// replaced entirely, and passwords in URLs, such as a git remote with a token in it, are replaced in place.
+func RedactOptions(options map[string]string) map[string]string {
+	redacted := make(map[string]string, len(options))
+	for name, value := range options {
+		switch {
+		case value == "":
+		case sensitiveOption.MatchString(name):
+			value = redactedValue
+		default:
+			value = redactURL(value)
+		}
+		redacted[name] = value
+	}
+	return redacted
+}
+
+func redactURL(value string) string {
+	u, err := url.Parse(value)
+	if err != nil || u.User == nil {
+		return value
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redactedValue)
+	} else if u.Scheme == "http" || u.Scheme == "https" {
+		// A lone user in an https URL, such as https://ghp_xxx@github.com, is usually a token.
+		u.User = url.User(redactedValue)
+	}
+	return u.String()
+}
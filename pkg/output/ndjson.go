@@ -0,0 +1,153 @@
+package output
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"sync"
+)
+
+// partialSuffix is added to the name of an output file while it's being written.
+const partialSuffix = ".partial"
+
+// LineWriter writes newline delimited JSON. Each value is written with a single Write call, so lines from concurrent
+// callers never interleave, and is flushed before WriteJSON returns.
+type LineWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewLineWriter returns a LineWriter that writes to w.
+func NewLineWriter(w io.Writer) *LineWriter {
+	return &LineWriter{w: w}
+}
+
+// WriteJSON writes v as one line. encoding/json escapes newlines in strings, so a value is never split across lines.
+func (l *LineWriter) WriteJSON(v interface{}) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.w.Write(line); err != nil {
+		return err
+	}
+	if f, ok := l.w.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// OutputFile is an output file that only appears under its name once it's complete. It's written as "<path>.partial"
+// and renamed when closed, so a scan that crashes never leaves a truncated file at the path, or replaces a complete
+// one from an earlier scan. The partial file holds the results found before the crash.
+//
+// With a maximum size, the output is split into numbered files, "<path>.1", "<path>.2", and so on. Each is renamed
+// into place as soon as it's full, so the results of a long scan are available while it runs.
+//
+// If a signing key is set, each file gets a detached signature, "<path>.sig", written just before the file is renamed
+// into place.
+type OutputFile struct {
+	path    string
+	maxSize int64
+	signer  crypto.Signer
+
+	file    *os.File
+	size    int64
+	segment int
+	digest  hash.Hash
+}
+
+// CreateOutputFile creates an output file. A maxSize of zero writes a single file. Writes are never split, so files
+// can exceed maxSize by up to one write.
+func CreateOutputFile(path string, maxSize int64) (*OutputFile, error) {
+	f := &OutputFile{path: path, maxSize: maxSize}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// SignWith signs each file of the output with key.
+func (f *OutputFile) SignWith(key crypto.Signer) {
+	f.signer = key
+}
+
+func (f *OutputFile) open() error {
+	f.segment++
+	file, err := os.OpenFile(f.segmentPath()+partialSuffix, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("could not create output file: %w", err)
+	}
+	f.file = file
+	f.size = 0
+	f.digest = sha256.New()
+	return nil
+}
+
+func (f *OutputFile) segmentPath() string {
+	if f.maxSize <= 0 {
+		return f.path
+	}
+	return fmt.Sprintf("%s.%d", f.path, f.segment)
+}
+
+// Write writes p to the current file, then starts the next file if the current one is full.
+func (f *OutputFile) Write(p []byte) (int, error) {
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	f.digest.Write(p[:n])
+	if err != nil {
+		return n, err
+	}
+	if f.maxSize > 0 && f.size >= f.maxSize {
+		if err := f.finish(); err != nil {
+			return n, err
+		}
+		if err := f.open(); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// finish syncs the current file to disk and renames it into place.
+func (f *OutputFile) finish() error {
+	if err := f.file.Sync(); err != nil {
+		f.file.Close()
+		return fmt.Errorf("could not sync output file: %w", err)
+	}
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("could not close output file: %w", err)
+	}
+	if f.signer != nil {
+		sig, err := SignDigest(f.signer, f.digest.Sum(nil))
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(f.segmentPath()+SignatureSuffix, append(sig, '\n'), 0o644); err != nil {
+			return fmt.Errorf("could not write output signature: %w", err)
+		}
+	}
+	if err := os.Rename(f.file.Name(), f.segmentPath()); err != nil {
+		return fmt.Errorf("could not rename output file: %w", err)
+	}
+	return nil
+}
+
+// Close completes the output. With a maximum size, an empty last file is removed rather than renamed, unless it's the
+// only one.
+func (f *OutputFile) Close() error {
+	if f.maxSize > 0 && f.size == 0 && f.segment > 1 {
+		f.file.Close()
+		return os.Remove(f.file.Name())
+	}
+	return f.finish()
+}
@@ -0,0 +1,99 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestLineWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewLineWriter(&buf)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := w.WriteJSON(map[string]string{"Raw": "line one\nline two"}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 50 {
+		t.Fatalf("got %d lines, want 50", len(lines))
+	}
+	for _, line := range lines {
+		if line != `{"Raw":"line one\nline two"}` {
+			t.Fatalf("unexpected line: %s", line)
+		}
+	}
+}
+
+func TestOutputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	if err := os.WriteFile(path, []byte("earlier scan\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := CreateOutputFile(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("{}\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Until the output is closed, the earlier scan's file is untouched and results are in the partial file.
+	assertFile(t, path, "earlier scan\n")
+	assertFile(t, path+partialSuffix, "{}\n")
+
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	assertFile(t, path, "{}\n")
+	if _, err := os.Stat(path + partialSuffix); !os.IsNotExist(err) {
+		t.Errorf("partial file was not renamed: %v", err)
+	}
+}
+
+func TestOutputFileRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	f, err := CreateOutputFile(path, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"{\"a\":1}\n", "{\"b\":2}\n", "{\"c\":3}\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The first file is full, so it's in place before the output is closed.
+	assertFile(t, path+".1", "{\"a\":1}\n{\"b\":2}\n")
+
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	assertFile(t, path+".2", "{\"c\":3}\n")
+	matches, _ := filepath.Glob(path + "*")
+	if len(matches) != 2 {
+		t.Errorf("unexpected output files: %v", matches)
+	}
+}
+
+func assertFile(t *testing.T, path, want string) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("%s: got %q, want %q", filepath.Base(path), got, want)
+	}
+}
@@ -0,0 +1,212 @@
+package output
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+// PartitionIndex is the name of the file in an output directory that lists its partitions.
+const PartitionIndex = "index.json"
+
+// Partition is a file of an output directory, holding the results of one unit of the scan.
+type Partition struct {
+	// Unit is the repository, bucket, or other unit the results were found in, or the source name for results that
+	// have none.
+	Unit string
+	// File is the path of the partition, relative to the output directory.
+	File    string
+	Results int
+
+	digest hash.Hash
+}
+
+// PartitionedSink writes the results of each repository, bucket, or other unit of a scan to its own file in a
+// directory, so that org-scale scans can be processed and retried per repository. Files are named after their unit,
+// see PartitionPath.
+//
+// Like OutputFile, each file is written as "<file>.partial", and renamed into place on Flush, once the scan has
+// completed. Flush then writes an index of the files, index.json, with a JSON line per partition.
+type PartitionedSink struct {
+	dir      string
+	protobuf bool
+	signer   crypto.Signer
+
+	partitions map[string]*Partition
+	// units maps the file of each partition to its unit, so units whose names collide get distinct files.
+	units map[string]string
+}
+
+// NewPartitionedSink returns a PartitionedSink that writes JSON lines, or length-delimited protobuf messages, to dir.
+func NewPartitionedSink(dir string, protobuf bool) *PartitionedSink {
+	return &PartitionedSink{
+		dir:        dir,
+		protobuf:   protobuf,
+		partitions: map[string]*Partition{},
+		// The index's name is reserved, so a unit named "index" doesn't replace it.
+		units: map[string]string{PartitionIndex: ""},
+	}
+}
+
+// SignWith signs each file of the output with key.
+func (s *PartitionedSink) SignWith(key crypto.Signer) {
+	s.signer = key
+}
+
+// Start creates the directory.
+func (s *PartitionedSink) Start(context.Context) error {
+	return os.MkdirAll(s.dir, 0o755)
+}
+
+// Write appends the result to the file of its unit. Results are rare enough that the file is opened for each one,
+// so scans of thousands of repositories don't hold thousands of files open.
+func (s *PartitionedSink) Write(_ context.Context, r *detectors.ResultWithMetadata) error {
+	unit := partitionUnit(r)
+	p, ok := s.partitions[unit]
+	flags := os.O_WRONLY | os.O_APPEND
+	if !ok {
+		p = &Partition{Unit: unit, File: s.partitionFile(unit), digest: sha256.New()}
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(s.dir, p.File)), 0o755); err != nil {
+			return err
+		}
+		s.partitions[unit] = p
+		s.units[p.File] = unit
+		// A partial file left by an earlier scan is replaced.
+		flags |= os.O_CREATE | os.O_TRUNC
+	}
+
+	file, err := os.OpenFile(filepath.Join(s.dir, p.File)+partialSuffix, flags, 0o644)
+	if err != nil {
+		return fmt.Errorf("could not open partition %s: %w", p.File, err)
+	}
+	w := io.MultiWriter(file, p.digest)
+	if s.protobuf {
+		err = NewProtoWriter(w).WriteResult(r)
+	} else {
+		err = NewLineWriter(w).WriteJSON(r)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("could not write to partition %s: %w", p.File, err)
+	}
+	p.Results++
+	return nil
+}
+
+// partitionFile returns the file of a new partition. A unit whose path is already taken by another unit gets a
+// suffix from the hash of its name.
+func (s *PartitionedSink) partitionFile(unit string) string {
+	ext := ".json"
+	if s.protobuf {
+		ext = ".pb"
+	}
+	path := PartitionPath(unit)
+	if _, taken := s.units[path+ext]; taken {
+		sum := sha256.Sum256([]byte(unit))
+		path += "-" + hex.EncodeToString(sum[:4])
+	}
+	return path + ext
+}
+
+// Flush renames each partition into place, then writes the index.
+func (s *PartitionedSink) Flush(context.Context) error {
+	partitions := make([]*Partition, 0, len(s.partitions))
+	for _, p := range s.partitions {
+		partitions = append(partitions, p)
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i].File < partitions[j].File })
+
+	for _, p := range partitions {
+		path := filepath.Join(s.dir, p.File)
+		if s.signer != nil {
+			sig, err := SignDigest(s.signer, p.digest.Sum(nil))
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(path+SignatureSuffix, append(sig, '\n'), 0o644); err != nil {
+				return fmt.Errorf("could not write output signature: %w", err)
+			}
+		}
+		if err := os.Rename(path+partialSuffix, path); err != nil {
+			return fmt.Errorf("could not rename partition %s: %w", p.File, err)
+		}
+	}
+
+	index, err := CreateOutputFile(filepath.Join(s.dir, PartitionIndex), 0)
+	if err != nil {
+		return err
+	}
+	if s.signer != nil {
+		index.SignWith(s.signer)
+	}
+	w := NewLineWriter(index)
+	for _, p := range partitions {
+		if err := w.WriteJSON(p); err != nil {
+			index.Close()
+			return err
+		}
+	}
+	return index.Close()
+}
+
+func (s *PartitionedSink) Close() error { return nil }
+
+// partitionUnit returns the unit a result is partitioned by: its repository, bucket, container, image, or project,
+// or else its source name.
+func partitionUnit(r *detectors.ResultWithMetadata) string {
+	if unit := sortKeyOf(r).repository; unit != "" {
+		return unit
+	}
+	return r.SourceName
+}
+
+// PartitionPath returns the path of a unit's partition, relative to the output directory and without an extension.
+// URLs become their host and path, without credentials or a ".git" suffix, so the results of
+// https://github.com/org/repo.git are in github.com/org/repo.json. Characters other than letters, digits, and
+// ".-_@+=," are replaced by "_" in each part of the path.
+func PartitionPath(unit string) string {
+	name := unit
+	if u, err := url.Parse(unit); err == nil && u.Host != "" {
+		name = u.Host + "/" + u.Path
+	} else if at, colon := strings.Index(unit, "@"), strings.Index(unit, ":"); at >= 0 && colon > at {
+		// An scp-like git address, such as git@github.com:org/repo.git.
+		name = unit[at+1:colon] + "/" + unit[colon+1:]
+	}
+	name = strings.TrimSuffix(strings.TrimRight(name, "/"), ".git")
+
+	var parts []string
+	for _, part := range strings.Split(name, "/") {
+		part = strings.Map(func(r rune) rune {
+			switch {
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', strings.ContainsRune(".-_@+=,", r):
+				return r
+			default:
+				return '_'
+			}
+		}, part)
+		switch part {
+		case "":
+			continue
+		case ".", "..":
+			part = "_"
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		return "_"
+	}
+	return strings.Join(parts, "/")
+}
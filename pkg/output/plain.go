@@ -6,7 +6,6 @@ import (
 	"strings"
 
 	"github.com/fatih/color"
-	"github.com/sirupsen/logrus"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 )
@@ -17,7 +16,8 @@ var (
 	whitePrinter  = color.New(color.FgWhite)
 )
 
-func PrintPlainOutput(r *detectors.ResultWithMetadata) {
+// PrintPlainOutput prints a human readable, colorized version of the result to stdout.
+func PrintPlainOutput(r *detectors.ResultWithMetadata) error {
 	out := outputFormat{
 		DetectorType: r.Result.DetectorType.String(),
 		Verified:     r.Result.Verified,
@@ -27,7 +27,7 @@ func PrintPlainOutput(r *detectors.ResultWithMetadata) {
 
 	meta, err := structToMap(out.MetaData.Data)
 	if err != nil {
-		logrus.WithError(err).Fatal("could not marshal result")
+		return fmt.Errorf("could not marshal result: %w", err)
 	}
 
 	printer := greenPrinter
@@ -40,12 +40,58 @@ func PrintPlainOutput(r *detectors.ResultWithMetadata) {
 	}
 	printer.Printf("Detector Type: %s\n", out.DetectorType)
 	printer.Printf("Raw result: %s\n", whitePrinter.Sprint(out.Raw))
+	printer.Printf("Entropy: %.2f\n", r.Entropy)
+	if len(r.Provenance) > 0 {
+		printer.Printf("Provenance: %s\n", strings.Join(r.Provenance, " → "))
+	}
+	if file := r.ExtraData["original_file"]; file != "" {
+		printer.Printf("Original source: %s:%s\n", file, r.ExtraData["original_line"])
+	}
+	if r.PresentAtHead != nil {
+		printer.Printf("Present at HEAD: %t\n", *r.PresentAtHead)
+	}
+	if r.Age != nil {
+		printer.Printf("Age: %s\n", formatAge(r.Age))
+	}
 	for _, data := range meta {
 		for k, v := range data {
 			printer.Printf("%s: %v\n", strings.Title(k), v)
 		}
 	}
+	if r.Context != "" {
+		printer.Println("Context:")
+		for _, line := range strings.Split(r.Context, "\n") {
+			whitePrinter.Printf("    %s\n", line)
+		}
+	}
+	printRemediation(printer, r.Remediation)
 	fmt.Println("")
+	return nil
+}
+
+// formatAge describes how long a secret has been in history, such as "412 days (since 2021-03-02, removed 2022-04-18)".
+func formatAge(age *detectors.Age) string {
+	days := fmt.Sprintf("%d days", age.Days)
+	if age.Days == 1 {
+		days = "1 day"
+	}
+	if age.Removed == nil {
+		return fmt.Sprintf("%s (since %s, not removed)", days, age.Introduced.Format("2006-01-02"))
+	}
+	return fmt.Sprintf("%s (since %s, removed %s)", days, age.Introduced.Format("2006-01-02"), age.Removed.Format("2006-01-02"))
+}
+
+// printRemediation prints how to revoke the credential, if it's known.
+func printRemediation(printer *color.Color, remediation *detectors.Remediation) {
+	if remediation == nil {
+		return
+	}
+	if remediation.Guidance != "" {
+		printer.Printf("Remediation: %s\n", remediation.Guidance)
+	}
+	if remediation.URL != "" {
+		printer.Printf("Rotation guide: %s\n", remediation.URL)
+	}
 }
 
 func structToMap(obj interface{}) (m map[string]map[string]interface{}, err error) {
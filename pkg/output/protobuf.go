@@ -0,0 +1,79 @@
+package output
+
+import (
+	"io"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/allowlist"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/resultspb"
+)
+
+// ProtoSchemaVersion is the schema_version of the Finding messages written by ProtoWriter, defined in
+// proto/results.proto.
+const ProtoSchemaVersion = 1
+
+// ProtoWriter writes results as a stream of length-delimited Finding messages, for consumers that don't want to parse
+// JSON. Each message is written with a single Write call, so messages from concurrent callers never interleave, and
+// is flushed before WriteResult returns.
+type ProtoWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewProtoWriter returns a ProtoWriter that writes to w.
+func NewProtoWriter(w io.Writer) *ProtoWriter {
+	return &ProtoWriter{w: w}
+}
+
+// WriteResult writes r as a Finding preceded by its length as a varint.
+func (p *ProtoWriter) WriteResult(r *detectors.ResultWithMetadata) error {
+	msg, err := MarshalFinding(r)
+	if err != nil {
+		return err
+	}
+	b := protowire.AppendVarint(make([]byte, 0, protowire.SizeVarint(uint64(len(msg)))+len(msg)), uint64(len(msg)))
+	b = append(b, msg...)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := p.w.Write(b); err != nil {
+		return err
+	}
+	if f, ok := p.w.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// MarshalFinding encodes r as a Finding message, without a length prefix.
+func MarshalFinding(r *detectors.ResultWithMetadata) ([]byte, error) {
+	finding := &resultspb.Finding{
+		SchemaVersion: ProtoSchemaVersion,
+		DetectorType:  r.DetectorType,
+		Result: &detectorspb.Result{
+			SourceId:       r.SourceID,
+			Redacted:       r.Redacted,
+			Verified:       r.Verified,
+			Hash:           allowlist.Hash(r.Raw),
+			ExtraData:      r.ExtraData,
+			StructuredData: r.StructuredData,
+		},
+		SourceType:     r.SourceType,
+		SourceName:     r.SourceName,
+		SourceMetadata: r.SourceMetadata,
+		Raw:            r.Raw,
+		Entropy:        r.Entropy,
+		Provenance:     r.Provenance,
+		ScanId:         r.ScanID,
+		UnitId:         r.UnitID,
+	}
+	if r.Severity != detectors.SeverityUnknown {
+		finding.Severity = r.Severity.String()
+	}
+	return proto.MarshalOptions{Deterministic: true}.Marshal(finding)
+}
@@ -0,0 +1,246 @@
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strconv"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/allowlist"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+// SARIFSchema is the JSON schema of the SARIF 2.1.0 documents written by SARIFSink.
+const SARIFSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// securitySeverities are the CVSS-like scores GitHub code scanning ranks rules by, for each severity.
+var securitySeverities = map[detectors.Severity]string{
+	detectors.SeverityLow:      "3.0",
+	detectors.SeverityMedium:   "5.5",
+	detectors.SeverityHigh:     "8.0",
+	detectors.SeverityCritical: "9.5",
+}
+
+// SARIFSink writes results as a SARIF 2.1.0 log, for GitHub code scanning and other SARIF consumers. Each detector
+// type is a rule, and each result is located at its file, line, and commit when the source has them. Verified results
+// are errors, and others are warnings or notes by their severity. SARIF is a single document, so results are buffered
+// and written when the scan finishes. The secret itself is never written, only its redacted form and its hash.
+type SARIFSink struct {
+	w       io.Writer
+	version string
+
+	rules     []sarifRule
+	ruleIndex map[string]int
+	results   []sarifResult
+}
+
+// NewSARIFSink returns a SARIFSink that writes to w, naming version as the tool's version.
+func NewSARIFSink(w io.Writer, version string) *SARIFSink {
+	return &SARIFSink{w: w, version: version, ruleIndex: map[string]int{}}
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	ShortDescription sarifMessage      `json:"shortDescription"`
+	Help             *sarifMessage     `json:"help,omitempty"`
+	HelpURI          string            `json:"helpUri,omitempty"`
+	Properties       sarifRuleProperty `json:"properties"`
+	severity         detectors.Severity
+}
+
+type sarifRuleProperty struct {
+	Tags             []string `json:"tags"`
+	SecuritySeverity string   `json:"security-severity,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Properties          map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int64 `json:"startLine"`
+}
+
+func (s *SARIFSink) Start(context.Context) error { return nil }
+
+func (s *SARIFSink) Write(_ context.Context, r *detectors.ResultWithMetadata) error {
+	id := r.DetectorType.String()
+	index, ok := s.ruleIndex[id]
+	if !ok {
+		rule := sarifRule{
+			ID:               id,
+			Name:             id,
+			ShortDescription: sarifMessage{Text: id + " secret"},
+			Properties:       sarifRuleProperty{Tags: []string{"security", "secret"}},
+		}
+		if r.Remediation != nil {
+			rule.HelpURI = r.Remediation.URL
+			if r.Remediation.Guidance != "" {
+				rule.Help = &sarifMessage{Text: r.Remediation.Guidance}
+			}
+		}
+		index = len(s.rules)
+		s.rules = append(s.rules, rule)
+		s.ruleIndex[id] = index
+	}
+	// GitHub ranks alerts by their rule's severity, so a rule has the highest severity of its results.
+	if rule := &s.rules[index]; r.Severity > rule.severity {
+		rule.severity = r.Severity
+		rule.Properties.SecuritySeverity = securitySeverities[r.Severity]
+	}
+
+	message := "Unverified " + id + " secret found"
+	if r.Verified {
+		message = "Verified " + id + " secret found"
+	}
+	if r.Redacted != "" {
+		message += ": " + r.Redacted
+	}
+	result := sarifResult{
+		RuleID:    id,
+		RuleIndex: index,
+		Level:     sarifLevel(r),
+		Message:   sarifMessage{Text: message},
+		// The hash identifies the secret across scans without including it.
+		PartialFingerprints: map[string]string{"secretHash/v1": allowlist.Hash(r.Raw)},
+		Properties: map[string]string{
+			"verified": strconv.FormatBool(r.Verified),
+			"severity": r.Severity.String(),
+			"source":   r.SourceName,
+		},
+	}
+	for _, field := range []string{"commit", "repository", "link", "email", "timestamp"} {
+		if v := metadataField(r.SourceMetadata, field); v.IsValid() && v.String() != "" {
+			result.Properties[field] = v.String()
+		}
+	}
+	if file := detectors.FilePath(r.SourceMetadata); file != "" {
+		location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(file)},
+		}}
+		if line := metadataField(r.SourceMetadata, "line"); line.IsValid() && line.Int() > 0 {
+			location.PhysicalLocation.Region = &sarifRegion{StartLine: line.Int()}
+		}
+		result.Locations = []sarifLocation{location}
+	}
+	s.results = append(s.results, result)
+	return nil
+}
+
+// Flush writes the SARIF log of every result.
+func (s *SARIFSink) Flush(context.Context) error {
+	log := sarifLog{
+		Schema:  SARIFSchema,
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "TruffleHog",
+				InformationURI: "https://github.com/trufflesecurity/trufflehog",
+				Version:        s.version,
+				Rules:          s.rules,
+			}},
+			Results: s.results,
+		}},
+	}
+	if log.Runs[0].Tool.Driver.Rules == nil {
+		log.Runs[0].Tool.Driver.Rules = []sarifRule{}
+	}
+	if log.Runs[0].Results == nil {
+		log.Runs[0].Results = []sarifResult{}
+	}
+	enc := json.NewEncoder(s.w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(log); err != nil {
+		return err
+	}
+	if f, ok := s.w.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+func (s *SARIFSink) Close() error { return nil }
+
+// sarifLevel returns the SARIF level of a result: error for verified results, and otherwise by severity.
+func sarifLevel(r *detectors.ResultWithMetadata) string {
+	switch {
+	case r.Verified || r.Severity >= detectors.SeverityHigh:
+		return "error"
+	case r.Severity == detectors.SeverityMedium:
+		return "warning"
+	}
+	return "note"
+}
+
+// metadataField returns the field of the source metadata with the name, such as "line", or an invalid value if the
+// source has no such field.
+func metadataField(metadata *source_metadatapb.MetaData, name string) protoreflect.Value {
+	if metadata == nil {
+		return protoreflect.Value{}
+	}
+	m := metadata.ProtoReflect()
+	oneof := m.WhichOneof(m.Descriptor().Oneofs().ByName("data"))
+	if oneof == nil || oneof.Kind() != protoreflect.MessageKind {
+		return protoreflect.Value{}
+	}
+	source := m.Get(oneof).Message()
+	field := source.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if field == nil {
+		return protoreflect.Value{}
+	}
+	switch field.Kind() {
+	case protoreflect.StringKind, protoreflect.Int64Kind:
+		return source.Get(field)
+	}
+	return protoreflect.Value{}
+}
@@ -0,0 +1,172 @@
+package output
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// SignatureSuffix is added to the name of an output file for its detached signature.
+const SignatureSuffix = ".sig"
+
+// LoadSigningKey reads an unencrypted PEM private key, either ECDSA or RSA, in PKCS #8, SEC 1, or PKCS #1 form.
+func LoadSigningKey(path string) (crypto.Signer, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	var key interface{}
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%s holds a %s, expected an unencrypted private key", path, strings.ToLower(block.Type))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse signing key %s: %w", path, err)
+	}
+	switch key := key.(type) {
+	case *ecdsa.PrivateKey:
+		return key, nil
+	case *rsa.PrivateKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("signing key %s is a %T, expected an ECDSA or RSA key", path, key)
+	}
+}
+
+// LoadVerifyingKey reads a PEM public key, in PKIX form, or the public key of a PEM certificate.
+func LoadVerifyingKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse public key %s: %w", path, err)
+		}
+		return key, nil
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse certificate %s: %w", path, err)
+		}
+		return cert.PublicKey, nil
+	default:
+		return nil, fmt.Errorf("%s holds a %s, expected a public key or certificate", path, strings.ToLower(block.Type))
+	}
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s is not PEM encoded", path)
+	}
+	return block, nil
+}
+
+// SignDigest returns the base64 encoded signature of the SHA-256 digest of some output, like those of cosign sign-blob,
+// so it can be checked with trufflehog verify-signature, cosign verify-blob --key, or openssl dgst -sha256 -verify.
+// ECDSA signatures are ASN.1 encoded, and RSA signatures are PKCS #1 v1.5.
+func SignDigest(key crypto.Signer, digest []byte) ([]byte, error) {
+	sig, err := key.Sign(rand.Reader, digest, crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("could not sign output: %w", err)
+	}
+	encoded := make([]byte, base64.StdEncoding.EncodedLen(len(sig)))
+	base64.StdEncoding.Encode(encoded, sig)
+	return encoded, nil
+}
+
+// ErrBadSignature is returned by Verify when the signature doesn't match the data or key.
+var ErrBadSignature = errors.New("signature doesn't match")
+
+// Verify checks the base64 encoded signature of the data read from r.
+func Verify(key crypto.PublicKey, r io.Reader, signature []byte) error {
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return fmt.Errorf("could not decode signature: %w", err)
+	}
+	digest := sha256.New()
+	if _, err := io.Copy(digest, r); err != nil {
+		return err
+	}
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest.Sum(nil), sig) {
+			return ErrBadSignature
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest.Sum(nil), sig); err != nil {
+			return ErrBadSignature
+		}
+	default:
+		return fmt.Errorf("verifying key is a %T, expected an ECDSA or RSA key", key)
+	}
+	return nil
+}
+
+// VerifyFile checks the detached signature of a file, read from sigPath.
+func VerifyFile(key crypto.PublicKey, path, sigPath string) error {
+	signature, err := os.ReadFile(sigPath)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return Verify(key, file, signature)
+}
+
+// SignedWriter passes writes through to a writer, such as stdout, and signs everything written once the output is
+// complete.
+type SignedWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	digest hash.Hash
+}
+
+// NewSignedWriter returns a SignedWriter that writes to w.
+func NewSignedWriter(w io.Writer) *SignedWriter {
+	return &SignedWriter{w: w, digest: sha256.New()}
+}
+
+// Write writes p to the underlying writer, and adds what was written to the signed output.
+func (s *SignedWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, err := s.w.Write(p)
+	s.digest.Write(p[:n])
+	return n, err
+}
+
+// Sign returns the base64 encoded signature of everything written so far.
+func (s *SignedWriter) Sign(key crypto.Signer) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return SignDigest(key, s.digest.Sum(nil))
+}
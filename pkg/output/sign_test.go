@@ -0,0 +1,110 @@
+package output
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestKeys(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	private, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	public, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	privatePath, publicPath := filepath.Join(dir, "key.pem"), filepath.Join(dir, "key.pub")
+	if err := os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: private}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public}), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return privatePath, publicPath
+}
+
+func TestSignedOutputFile(t *testing.T) {
+	privatePath, publicPath := writeTestKeys(t)
+	signer, err := LoadSigningKey(privatePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := LoadVerifyingKey(publicPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "results.json")
+	f, err := CreateOutputFile(path, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.SignWith(signer)
+	for _, line := range []string{"{\"a\":1}\n", "{\"b\":2}\n", "{\"c\":3}\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, segment := range []string{path + ".1", path + ".2"} {
+		if err := VerifyFile(verifier, segment, segment+SignatureSuffix); err != nil {
+			t.Errorf("%s: %v", filepath.Base(segment), err)
+		}
+	}
+	if err := os.WriteFile(path+".2", []byte("{\"c\":4}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyFile(verifier, path+".2", path+".2"+SignatureSuffix); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("expected a changed file to fail verification, got %v", err)
+	}
+}
+
+func TestSignedWriter(t *testing.T) {
+	privatePath, publicPath := writeTestKeys(t)
+	signer, err := LoadSigningKey(privatePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := LoadVerifyingKey(publicPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	w := NewSignedWriter(&buf)
+	if err := NewLineWriter(w).WriteJSON(map[string]string{"Raw": "secret"}); err != nil {
+		t.Fatal(err)
+	}
+	sig, err := w.Sign(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify(verifier, bytes.NewReader(buf.Bytes()), sig); err != nil {
+		t.Error(err)
+	}
+	if err := Verify(verifier, bytes.NewReader(append(buf.Bytes(), '\n')), sig); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("expected changed output to fail verification, got %v", err)
+	}
+
+	if _, err := LoadSigningKey(publicPath); err == nil {
+		t.Error("expected a public key to be rejected as a signing key")
+	}
+}
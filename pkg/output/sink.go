@@ -0,0 +1,343 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/policy"
+)
+
+// Sink receives the results of a scan. The built in outputs are sinks, and other packages can register their own
+// with RegisterSink to send results to webhooks, queues, or databases without changes to this package.
+//
+// Start is called once before the first result, and Write once for each result, from a single goroutine. Flush is
+// called once the scan has finished, and must deliver any results the sink buffered. Close is always called last,
+// even if the scan failed, and releases the sink's resources.
+type Sink interface {
+	Start(ctx context.Context) error
+	Write(ctx context.Context, r *detectors.ResultWithMetadata) error
+	Flush(ctx context.Context) error
+	Close() error
+}
+
+// SinkFactory creates a sink from its options.
+type SinkFactory func(options map[string]string) (Sink, error)
+
+var (
+	sinksMu sync.RWMutex
+	sinks   = map[string]SinkFactory{}
+)
+
+// RegisterSink makes a sink available to NewSink, and to --sink on the command line, under name. It's meant to be
+// called from init, and panics if the name is already registered.
+func RegisterSink(name string, factory SinkFactory) {
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+	if factory == nil {
+		panic("output: RegisterSink factory is nil")
+	}
+	if _, ok := sinks[name]; ok {
+		panic("output: RegisterSink called twice for sink " + name)
+	}
+	sinks[name] = factory
+}
+
+// SinkNames returns the names of the registered sinks, sorted.
+func SinkNames() []string {
+	sinksMu.RLock()
+	defer sinksMu.RUnlock()
+	names := make([]string, 0, len(sinks))
+	for name := range sinks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewSink creates a registered sink from a spec of the form "name:key=value,key=value". A part without "=" continues
+// the previous value, so lists can be given as "brokers=a:9092,b:9092".
+//
+// Every sink also accepts the actions option, which limits it to results a policy rule tagged with one of the
+// actions, such as "actions=notify,ticket".
+func NewSink(spec string) (Sink, error) {
+	name, options, err := ParseSinkSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	var actions map[policy.Action]bool
+	if list, ok := options["actions"]; ok {
+		actions = map[policy.Action]bool{}
+		for _, name := range strings.Split(list, ",") {
+			action, err := policy.ParseAction(strings.TrimSpace(name))
+			if err != nil {
+				return nil, err
+			}
+			actions[action] = true
+		}
+		delete(options, "actions")
+	}
+	sinksMu.RLock()
+	factory, ok := sinks[name]
+	sinksMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown sink %q, expected one of: %s", name, strings.Join(SinkNames(), ", "))
+	}
+	sink, err := factory(options)
+	if err != nil {
+		return nil, fmt.Errorf("could not create %s sink: %w", name, err)
+	}
+	if actions != nil {
+		sink = &actionSink{Sink: sink, actions: actions}
+	}
+	return sink, nil
+}
+
+// actionSink passes a sink only the results tagged with one of its policy actions.
+type actionSink struct {
+	Sink
+	actions map[policy.Action]bool
+}
+
+func (s *actionSink) Write(ctx context.Context, r *detectors.ResultWithMetadata) error {
+	if !s.actions[policy.ActionOf(r)] {
+		return nil
+	}
+	return s.Sink.Write(ctx, r)
+}
+
+// ParseSinkSpec splits a sink spec into the sink's name and options.
+func ParseSinkSpec(spec string) (string, map[string]string, error) {
+	name, rest := spec, ""
+	if i := strings.IndexByte(spec, ':'); i >= 0 {
+		name, rest = spec[:i], spec[i+1:]
+	}
+	if name == "" {
+		return "", nil, fmt.Errorf("sink %q has no name", spec)
+	}
+	options := map[string]string{}
+	if rest == "" {
+		return name, options, nil
+	}
+	var last string
+	for _, part := range strings.Split(rest, ",") {
+		i := strings.IndexByte(part, '=')
+		if i < 0 {
+			if last == "" {
+				return "", nil, fmt.Errorf("sink %s option %q is not of the form key=value", name, part)
+			}
+			options[last] += "," + part
+			continue
+		}
+		key := strings.TrimSpace(part[:i])
+		if key == "" {
+			return "", nil, fmt.Errorf("sink %s option %q has no key", name, part)
+		}
+		if _, ok := options[key]; ok {
+			return "", nil, fmt.Errorf("sink %s option %s is given more than once", name, key)
+		}
+		options[key] = part[i+1:]
+		last = key
+	}
+	return name, options, nil
+}
+
+func init() {
+	RegisterSink("json", newJSONFileSink)
+}
+
+// newJSONFileSink writes results as JSON lines to the file option, in addition to the scan's main output.
+func newJSONFileSink(options map[string]string) (Sink, error) {
+	for key := range options {
+		if key != "file" {
+			return nil, fmt.Errorf("unknown option %s, expected file", key)
+		}
+	}
+	path := options["file"]
+	if path == "" {
+		return nil, fmt.Errorf("the file option is required")
+	}
+	return &JSONSink{path: path}, nil
+}
+
+// PlainSink prints results to stdout in the human readable format of PrintPlainOutput.
+type PlainSink struct{}
+
+// NewPlainSink returns a PlainSink.
+func NewPlainSink() *PlainSink {
+	return &PlainSink{}
+}
+
+func (s *PlainSink) Start(context.Context) error { return nil }
+
+func (s *PlainSink) Write(_ context.Context, r *detectors.ResultWithMetadata) error {
+	return PrintPlainOutput(r)
+}
+
+func (s *PlainSink) Flush(context.Context) error { return nil }
+
+func (s *PlainSink) Close() error { return nil }
+
+// JSONSink writes results as JSON lines.
+type JSONSink struct {
+	w *LineWriter
+	// path is the file the sink creates on Start, if it wasn't given a writer.
+	path string
+	file io.Closer
+}
+
+// NewJSONSink returns a JSONSink that writes to w.
+func NewJSONSink(w *LineWriter) *JSONSink {
+	return &JSONSink{w: w}
+}
+
+// Start creates the sink's file, if it was created by NewSink.
+func (s *JSONSink) Start(context.Context) error {
+	if s.path == "" {
+		return nil
+	}
+	file, err := CreateOutputFile(s.path, 0)
+	if err != nil {
+		return err
+	}
+	s.w, s.file = NewLineWriter(file), file
+	return nil
+}
+
+func (s *JSONSink) Write(_ context.Context, r *detectors.ResultWithMetadata) error {
+	return s.w.WriteJSON(r)
+}
+
+func (s *JSONSink) Flush(context.Context) error { return nil }
+
+func (s *JSONSink) Close() error {
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Close()
+}
+
+// ProtoSink writes results as length-delimited protobuf messages.
+type ProtoSink struct {
+	w *ProtoWriter
+}
+
+// NewProtoSink returns a ProtoSink that writes to w.
+func NewProtoSink(w *ProtoWriter) *ProtoSink {
+	return &ProtoSink{w: w}
+}
+
+func (s *ProtoSink) Start(context.Context) error { return nil }
+
+func (s *ProtoSink) Write(_ context.Context, r *detectors.ResultWithMetadata) error {
+	return s.w.WriteResult(r)
+}
+
+func (s *ProtoSink) Flush(context.Context) error { return nil }
+
+func (s *ProtoSink) Close() error { return nil }
+
+// GroupedSink groups every occurrence of the same secret, and outputs the groups on Flush, as JSON lines if it has a
+// writer and in the human readable format of PrintGroupedPlainOutput otherwise.
+type GroupedSink struct {
+	w       *LineWriter
+	grouper *Grouper
+	groups  int
+}
+
+// NewGroupedSink returns a GroupedSink that writes JSON lines to w, or prints to stdout if w is nil.
+func NewGroupedSink(w *LineWriter) *GroupedSink {
+	return &GroupedSink{w: w, grouper: NewGrouper()}
+}
+
+func (s *GroupedSink) Start(context.Context) error { return nil }
+
+func (s *GroupedSink) Write(_ context.Context, r *detectors.ResultWithMetadata) error {
+	s.grouper.Add(r)
+	return nil
+}
+
+// Flush outputs the groups, most severe first.
+func (s *GroupedSink) Flush(context.Context) error {
+	for _, group := range s.grouper.Results() {
+		s.groups++
+		if s.w != nil {
+			if err := s.w.WriteJSON(group); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := PrintGroupedPlainOutput(&group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *GroupedSink) Close() error { return nil }
+
+// Groups returns the number of groups output by Flush.
+func (s *GroupedSink) Groups() int {
+	return s.groups
+}
+
+// PrepareRepoFunc returns a local path of a repository, and whether it's a clone that should be removed once it's no
+// longer needed.
+type PrepareRepoFunc func(repository string) (path string, remote bool, err error)
+
+// LegacyJSONSink writes results as JSON lines in the pre-v3.0 format. Each repository is prepared once, since the
+// legacy output indexes its branches, and clones are removed on Close.
+type LegacyJSONSink struct {
+	w       *LineWriter
+	prepare PrepareRepoFunc
+	paths   map[string]string
+	clones  []string
+}
+
+// NewLegacyJSONSink returns a LegacyJSONSink that writes to w, and prepares repositories with prepare.
+func NewLegacyJSONSink(w *LineWriter, prepare PrepareRepoFunc) *LegacyJSONSink {
+	return &LegacyJSONSink{w: w, prepare: prepare, paths: map[string]string{}}
+}
+
+func (s *LegacyJSONSink) Start(context.Context) error { return nil }
+
+func (s *LegacyJSONSink) Write(_ context.Context, r *detectors.ResultWithMetadata) error {
+	repository := r.SourceMetadata.GetGithub().Repository
+	path, ok := s.paths[repository]
+	if !ok {
+		var remote bool
+		var err error
+		path, remote, err = s.prepare(repository)
+		if err != nil {
+			return fmt.Errorf("could not prepare git repo %s: %w", repository, err)
+		}
+		if path == "" {
+			return fmt.Errorf("could not prepare git repo %s", repository)
+		}
+		if remote {
+			s.clones = append(s.clones, path)
+		}
+		s.paths[repository] = path
+	}
+	legacy, err := ConvertToLegacyJSON(r, path)
+	if err != nil {
+		return err
+	}
+	return s.w.WriteJSON(legacy)
+}
+
+func (s *LegacyJSONSink) Flush(context.Context) error { return nil }
+
+// Close removes the repositories the sink cloned.
+func (s *LegacyJSONSink) Close() error {
+	for _, clone := range s.clones {
+		os.RemoveAll(clone)
+	}
+	s.clones = nil
+	return nil
+}
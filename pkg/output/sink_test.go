@@ -0,0 +1,142 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/policy"
+)
+
+func TestParseSinkSpec(t *testing.T) {
+	tests := []struct {
+		spec    string
+		name    string
+		options map[string]string
+		err     string
+	}{
+		{spec: "webhook", name: "webhook", options: map[string]string{}},
+		{spec: "json:file=out.json", name: "json", options: map[string]string{"file": "out.json"}},
+		{
+			spec:    "kafka:brokers=a:9092,b:9092,topic=secrets",
+			name:    "kafka",
+			options: map[string]string{"brokers": "a:9092,b:9092", "topic": "secrets"},
+		},
+		{spec: "url:token=a=b", name: "url", options: map[string]string{"token": "a=b"}},
+		{spec: ":file=out.json", err: "no name"},
+		{spec: "json:out.json", err: "not of the form key=value"},
+		{spec: "json:=out.json", err: "no key"},
+		{spec: "json:file=a,file=b", err: "more than once"},
+	}
+	for _, test := range tests {
+		name, options, err := ParseSinkSpec(test.spec)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%s: expected an error containing %q, got %v", test.spec, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.spec, err)
+			continue
+		}
+		if name != test.name || !reflect.DeepEqual(options, test.options) {
+			t.Errorf("%s: expected %s %v, got %s %v", test.spec, test.name, test.options, name, options)
+		}
+	}
+}
+
+func TestNewSink(t *testing.T) {
+	if _, err := NewSink("nonexistent"); err == nil || !strings.Contains(err.Error(), "json") {
+		t.Errorf("expected an unknown sink to list the registered sinks, got %v", err)
+	}
+	if _, err := NewSink("json:path=out.json"); err == nil {
+		t.Error("expected an unknown option to be rejected")
+	}
+
+	path := filepath.Join(t.TempDir(), "results.json")
+	sink, err := NewSink("json:file=" + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := sink.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Write(ctx, &detectors.ResultWithMetadata{SourceName: "test"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte(`"SourceName":"test"`)) {
+		t.Errorf("expected the result in the sink's file, got %s", data)
+	}
+}
+
+func TestNewSink_Actions(t *testing.T) {
+	if _, err := NewSink("json:file=out.json,actions=page"); err == nil || !strings.Contains(err.Error(), `unknown policy action "page"`) {
+		t.Errorf("expected an unknown action to be rejected, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "tickets.json")
+	sink, err := NewSink("json:file=" + path + ",actions=ticket,fail")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := sink.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, action := range []policy.Action{policy.ActionNotify, policy.ActionTicket, ""} {
+		r := &detectors.ResultWithMetadata{
+			SourceName: "action " + string(action),
+			Result:     detectors.Result{ExtraData: map[string]string{policy.ActionKey: string(action)}},
+		}
+		if err := sink.Write(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Count(data, []byte("\n")) != 1 || !bytes.Contains(data, []byte(`"SourceName":"action ticket"`)) {
+		t.Errorf("expected only the ticket result in the sink's file, got %s", data)
+	}
+}
+
+func TestGroupedSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewGroupedSink(NewLineWriter(&buf))
+	ctx := context.Background()
+	for _, raw := range []string{"a", "b", "a"} {
+		if err := sink.Write(ctx, &detectors.ResultWithMetadata{Result: detectors.Result{Raw: []byte(raw)}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if buf.Len() != 0 {
+		t.Error("expected groups to be written on Flush")
+	}
+	if err := sink.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if sink.Groups() != 2 || bytes.Count(buf.Bytes(), []byte("\n")) != 2 {
+		t.Errorf("expected 2 groups, got %d and %q", sink.Groups(), buf.String())
+	}
+}
@@ -0,0 +1,97 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+// resultSortKey is what results are ordered by. The fields are compared in order.
+type resultSortKey struct {
+	sourceName string
+	repository string
+	file       string
+	commit     string
+	line       int64
+	detector   int32
+	raw        string
+}
+
+// SortResults orders results by source, repository, file, commit, line, and detector. Results are otherwise output in
+// the order detectors finish, which changes from run to run, so sorting makes the output of repeated scans diffable.
+func SortResults(results []detectors.ResultWithMetadata) {
+	keys := make([]resultSortKey, len(results))
+	for i := range results {
+		keys[i] = sortKeyOf(&results[i])
+	}
+	sort.Stable(resultSorter{results: results, keys: keys})
+}
+
+type resultSorter struct {
+	results []detectors.ResultWithMetadata
+	keys    []resultSortKey
+}
+
+func (s resultSorter) Len() int { return len(s.results) }
+
+func (s resultSorter) Swap(i, j int) {
+	s.results[i], s.results[j] = s.results[j], s.results[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
+
+func (s resultSorter) Less(i, j int) bool {
+	a, b := s.keys[i], s.keys[j]
+	switch {
+	case a.sourceName != b.sourceName:
+		return a.sourceName < b.sourceName
+	case a.repository != b.repository:
+		return a.repository < b.repository
+	case a.file != b.file:
+		return a.file < b.file
+	case a.commit != b.commit:
+		return a.commit < b.commit
+	case a.line != b.line:
+		return a.line < b.line
+	case a.detector != b.detector:
+		return a.detector < b.detector
+	default:
+		return a.raw < b.raw
+	}
+}
+
+func sortKeyOf(r *detectors.ResultWithMetadata) resultSortKey {
+	key := resultSortKey{
+		sourceName: r.SourceName,
+		detector:   int32(r.DetectorType),
+		raw:        string(r.Raw),
+	}
+	if r.SourceMetadata == nil {
+		return key
+	}
+	// Each source has its own metadata message, so the common fields are read from its JSON encoding.
+	data, err := structToMap(r.SourceMetadata.Data)
+	if err != nil {
+		return key
+	}
+	for _, metadata := range data {
+		key.repository = firstString(metadata, "repository", "bucket", "container", "image", "project")
+		key.file = firstString(metadata, "file", "link")
+		key.commit = firstString(metadata, "commit")
+		if line, ok := metadata["line"].(float64); ok {
+			key.line = int64(line)
+		}
+	}
+	return key
+}
+
+func firstString(metadata map[string]interface{}, names ...string) string {
+	for _, name := range names {
+		if value, ok := metadata[name]; ok && value != nil {
+			if s := fmt.Sprint(value); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+func TestSortResults(t *testing.T) {
+	git := func(file, commit string, line int64, detectorType detectorspb.DetectorType, raw string) detectors.ResultWithMetadata {
+		return detectors.ResultWithMetadata{
+			SourceName: "trufflehog - git",
+			SourceMetadata: &source_metadatapb.MetaData{
+				Data: &source_metadatapb.MetaData_Git{Git: &source_metadatapb.Git{File: file, Commit: commit, Line: line}},
+			},
+			Result: detectors.Result{DetectorType: detectorType, Raw: []byte(raw)},
+		}
+	}
+	filesystem := detectors.ResultWithMetadata{
+		SourceName: "trufflehog - filesystem",
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_Filesystem{Filesystem: &source_metadatapb.Filesystem{File: "z.env"}},
+		},
+		Result: detectors.Result{DetectorType: detectorspb.DetectorType_AWS, Raw: []byte("AKIA")},
+	}
+
+	results := []detectors.ResultWithMetadata{
+		git("b.env", "aaaa", 1, detectorspb.DetectorType_AWS, "AKIA"),
+		git("a.env", "bbbb", 9, detectorspb.DetectorType_Github, "ghp_2"),
+		git("a.env", "bbbb", 2, detectorspb.DetectorType_Github, "ghp_1"),
+		git("a.env", "aaaa", 5, detectorspb.DetectorType_Github, "ghp_1"),
+		git("a.env", "aaaa", 5, detectorspb.DetectorType_AWS, "AKIA"),
+		filesystem,
+	}
+	SortResults(results)
+
+	order := make([]string, 0, len(results))
+	for _, r := range results {
+		key := sortKeyOf(&r)
+		order = append(order, r.SourceName+" "+key.file+" "+key.commit+" "+r.DetectorType.String()+" "+string(r.Raw))
+	}
+	want := []string{
+		"trufflehog - filesystem z.env  AWS AKIA",
+		"trufflehog - git a.env aaaa AWS AKIA",
+		"trufflehog - git a.env aaaa Github ghp_1",
+		"trufflehog - git a.env bbbb Github ghp_1",
+		"trufflehog - git a.env bbbb Github ghp_2",
+		"trufflehog - git b.env aaaa AWS AKIA",
+	}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("got:\n%q\nwant:\n%q", order, want)
+	}
+}
@@ -0,0 +1,130 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/allowlist"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+// TemplateFuncs are the functions available to output templates, in addition to the text/template builtins. Those
+// taking a result are called with the template's dot, as in {{file .}}.
+var TemplateFuncs = template.FuncMap{
+	// json encodes a value as JSON, for templates that output JSON of their own shape.
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	// raw returns the secret, which is otherwise a byte slice.
+	"raw": func(r *detectors.ResultWithMetadata) string { return string(r.Raw) },
+	// hash returns the SHA-256 hash of the secret, as used by --allowlist.
+	"hash": func(r *detectors.ResultWithMetadata) string { return allowlist.Hash(r.Raw) },
+	// file returns the path of the file the secret was found in, if the source has files.
+	"file": func(r *detectors.ResultWithMetadata) string { return detectors.FilePath(r.SourceMetadata) },
+	// metadata returns a field of the source metadata by its name in the JSON output, such as "commit" or "line", or
+	// an empty string if the source has no such field.
+	"metadata": func(r *detectors.ResultWithMetadata, name string) string {
+		if v := metadataField(r.SourceMetadata, name); v.IsValid() {
+			return v.String()
+		}
+		return ""
+	},
+}
+
+// ParseOutputTemplate parses an output template, with the TemplateFuncs.
+func ParseOutputTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(TemplateFuncs).Parse(text)
+}
+
+// LoadOutputTemplate parses the output template in the file at path.
+func LoadOutputTemplate(path string) (*template.Template, error) {
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOutputTemplate(filepath.Base(path), string(text))
+}
+
+// TemplateSink renders each result through a text/template, for downstream tools that expect a format of their own.
+// The template's dot is the *detectors.ResultWithMetadata, so its fields are available as in {{.DetectorType}}. Each
+// result is written with a single Write call, ending with a newline, which is added if the template doesn't end with
+// one.
+type TemplateSink struct {
+	tmpl *template.Template
+	w    io.Writer
+	// path is the file the sink creates on Start, if it wasn't given a writer.
+	path string
+	file io.Closer
+}
+
+// NewTemplateSink returns a TemplateSink that renders results through tmpl to w.
+func NewTemplateSink(w io.Writer, tmpl *template.Template) *TemplateSink {
+	return &TemplateSink{tmpl: tmpl, w: w}
+}
+
+func init() {
+	RegisterSink("template", newTemplateFileSink)
+}
+
+// newTemplateFileSink renders results through the output template in the template option to the file option.
+func newTemplateFileSink(options map[string]string) (Sink, error) {
+	for key := range options {
+		if key != "file" && key != "template" {
+			return nil, fmt.Errorf("unknown option %s, expected file or template", key)
+		}
+	}
+	if options["file"] == "" || options["template"] == "" {
+		return nil, fmt.Errorf("the file and template options are required")
+	}
+	tmpl, err := LoadOutputTemplate(options["template"])
+	if err != nil {
+		return nil, fmt.Errorf("could not load output template: %w", err)
+	}
+	return &TemplateSink{tmpl: tmpl, path: options["file"]}, nil
+}
+
+// Start creates the sink's file, if it was created by NewSink.
+func (s *TemplateSink) Start(context.Context) error {
+	if s.path == "" {
+		return nil
+	}
+	file, err := CreateOutputFile(s.path, 0)
+	if err != nil {
+		return err
+	}
+	s.w, s.file = file, file
+	return nil
+}
+
+func (s *TemplateSink) Write(_ context.Context, r *detectors.ResultWithMetadata) error {
+	var buf bytes.Buffer
+	if err := s.tmpl.Execute(&buf, r); err != nil {
+		return fmt.Errorf("could not render output template: %w", err)
+	}
+	if buf.Len() == 0 || buf.Bytes()[buf.Len()-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+	if _, err := s.w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	if f, ok := s.w.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+func (s *TemplateSink) Flush(context.Context) error { return nil }
+
+func (s *TemplateSink) Close() error {
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Close()
+}
@@ -23,874 +23,896 @@ const (
 type DetectorType int32
 
 const (
-	DetectorType_Alibaba                       DetectorType = 0
-	DetectorType_AMQP                          DetectorType = 1
-	DetectorType_AWS                           DetectorType = 2
-	DetectorType_Azure                         DetectorType = 3
-	DetectorType_Circle                        DetectorType = 4
-	DetectorType_Coinbase                      DetectorType = 5
-	DetectorType_GCP                           DetectorType = 6
-	DetectorType_Generic                       DetectorType = 7
-	DetectorType_Github                        DetectorType = 8
-	DetectorType_Gitlab                        DetectorType = 9
-	DetectorType_JDBC                          DetectorType = 10
-	DetectorType_RazorPay                      DetectorType = 11
-	DetectorType_SendGrid                      DetectorType = 12
-	DetectorType_Slack                         DetectorType = 13
-	DetectorType_Square                        DetectorType = 14
-	DetectorType_PrivateKey                    DetectorType = 15
-	DetectorType_Stripe                        DetectorType = 16
-	DetectorType_URI                           DetectorType = 17
-	DetectorType_Dropbox                       DetectorType = 18
-	DetectorType_Heroku                        DetectorType = 19
-	DetectorType_Mailchimp                     DetectorType = 20
-	DetectorType_Okta                          DetectorType = 21
-	DetectorType_OneLogin                      DetectorType = 22
-	DetectorType_PivotalTracker                DetectorType = 23
-	DetectorType_SquareApp                     DetectorType = 25
-	DetectorType_Twilio                        DetectorType = 26
-	DetectorType_Test                          DetectorType = 27
-	DetectorType_TravisCI                      DetectorType = 29
-	DetectorType_SlackWebhook                  DetectorType = 30
-	DetectorType_PaypalOauth                   DetectorType = 31
-	DetectorType_PagerDutyApiKey               DetectorType = 32
-	DetectorType_Firebase                      DetectorType = 33
-	DetectorType_Mailgun                       DetectorType = 34
-	DetectorType_HubSpot                       DetectorType = 35
-	DetectorType_GitHubApp                     DetectorType = 36
-	DetectorType_CircleCI                      DetectorType = 37
-	DetectorType_WpEngine                      DetectorType = 38
-	DetectorType_DatadogToken                  DetectorType = 39
-	DetectorType_FacebookOAuth                 DetectorType = 40
-	DetectorType_AsanaPersonalAccessToken      DetectorType = 41
-	DetectorType_AmplitudeApiKey               DetectorType = 42
-	DetectorType_BitLyAccessToken              DetectorType = 43
-	DetectorType_CalendlyApiKey                DetectorType = 44
-	DetectorType_ZapierWebhook                 DetectorType = 45
-	DetectorType_YoutubeApiKey                 DetectorType = 46
-	DetectorType_SalesforceOauth2              DetectorType = 47
-	DetectorType_TwitterApiSecret              DetectorType = 48
-	DetectorType_NpmToken                      DetectorType = 49
-	DetectorType_NewRelicPersonalApiKey        DetectorType = 50
-	DetectorType_AirtableApiKey                DetectorType = 51
-	DetectorType_AkamaiToken                   DetectorType = 52
-	DetectorType_AmazonMWS                     DetectorType = 53
-	DetectorType_KubeConfig                    DetectorType = 54
-	DetectorType_Auth0oauth                    DetectorType = 55
-	DetectorType_Bitfinex                      DetectorType = 56
-	DetectorType_Clarifai                      DetectorType = 57
-	DetectorType_CloudflareGlobalApiKey        DetectorType = 58
-	DetectorType_CloudflareCaKey               DetectorType = 59
-	DetectorType_Confluent                     DetectorType = 60
-	DetectorType_ContentfulDelivery            DetectorType = 61 // Didn't do
-	DetectorType_DatabricksToken               DetectorType = 62
-	DetectorType_DigitalOceanSpaces            DetectorType = 63 // Didn't do
-	DetectorType_DigitalOceanToken             DetectorType = 64
-	DetectorType_DiscordBotToken               DetectorType = 65
-	DetectorType_DiscordWebhook                DetectorType = 66
-	DetectorType_EtsyApiKey                    DetectorType = 67
-	DetectorType_FastlyPersonalToken           DetectorType = 68
-	DetectorType_GoogleOauth2                  DetectorType = 69
-	DetectorType_ReCAPTCHA                     DetectorType = 70 // Didn't do
-	DetectorType_GoogleApiKey                  DetectorType = 71 // Didn't do
-	DetectorType_Hunter                        DetectorType = 72
-	DetectorType_IbmCloudUserKey               DetectorType = 73
-	DetectorType_Netlify                       DetectorType = 74
-	DetectorType_Vonage                        DetectorType = 75
-	DetectorType_EquinixOauth                  DetectorType = 76
-	DetectorType_Paystack                      DetectorType = 77
-	DetectorType_PlaidToken                    DetectorType = 78
-	DetectorType_PlaidKey                      DetectorType = 79
-	DetectorType_Plivo                         DetectorType = 80
-	DetectorType_Postmark                      DetectorType = 81
-	DetectorType_PubNubPublishKey              DetectorType = 82
-	DetectorType_PubNubSubscriptionKey         DetectorType = 83
-	DetectorType_PusherChannelKey              DetectorType = 84
-	DetectorType_ScalewayKey                   DetectorType = 85
-	DetectorType_SendinBlueV2                  DetectorType = 86
-	DetectorType_SentryToken                   DetectorType = 87
-	DetectorType_ShodanKey                     DetectorType = 88
-	DetectorType_SnykKey                       DetectorType = 89
-	DetectorType_SpotifyKey                    DetectorType = 90
-	DetectorType_TelegramBotToken              DetectorType = 91
-	DetectorType_TencentCloudKey               DetectorType = 92
-	DetectorType_TerraformCloudPersonalToken   DetectorType = 93
-	DetectorType_TrelloApiKey                  DetectorType = 94
-	DetectorType_ZendeskApi                    DetectorType = 95
-	DetectorType_MaxMindLicense                DetectorType = 96
-	DetectorType_AirtableMetadataApiKey        DetectorType = 97
-	DetectorType_AsanaOauth                    DetectorType = 98
-	DetectorType_RapidApi                      DetectorType = 99
-	DetectorType_CloudflareApiToken            DetectorType = 100
-	DetectorType_Webex                         DetectorType = 101
-	DetectorType_FirebaseCloudMessaging        DetectorType = 102
-	DetectorType_ContentfulPersonalAccessToken DetectorType = 103
-	DetectorType_MapBox                        DetectorType = 104
-	DetectorType_MailJetBasicAuth              DetectorType = 105
-	DetectorType_MailJetSMS                    DetectorType = 106
-	DetectorType_HubSpotApiKey                 DetectorType = 107
-	DetectorType_HubSpotOauth                  DetectorType = 108
-	DetectorType_SslMate                       DetectorType = 109
-	DetectorType_Auth0ManagementApiToken       DetectorType = 110
-	DetectorType_MessageBird                   DetectorType = 111
-	DetectorType_ElasticEmail                  DetectorType = 112
-	DetectorType_FigmaPersonalAccessToken      DetectorType = 113
-	DetectorType_MicrosoftTeamsWebhook         DetectorType = 114
-	DetectorType_GitHubOld                     DetectorType = 115
-	DetectorType_VultrApiKey                   DetectorType = 116
-	DetectorType_Pepipost                      DetectorType = 117
-	DetectorType_Postman                       DetectorType = 118
-	DetectorType_CloudsightKey                 DetectorType = 119
-	DetectorType_JiraToken                     DetectorType = 120
-	DetectorType_NexmoApiKey                   DetectorType = 121
-	DetectorType_SegmentApiKey                 DetectorType = 122
-	DetectorType_SumoLogicKey                  DetectorType = 123
-	DetectorType_PushBulletApiKey              DetectorType = 124
-	DetectorType_AirbrakeProjectKey            DetectorType = 125
-	DetectorType_AirbrakeUserKey               DetectorType = 126
-	DetectorType_PendoIntegrationKey           DetectorType = 127
-	DetectorType_SplunkOberservabilityToken    DetectorType = 128
-	DetectorType_LokaliseToken                 DetectorType = 129
-	DetectorType_Calendarific                  DetectorType = 130
-	DetectorType_Jumpcloud                     DetectorType = 131
-	DetectorType_IpStack                       DetectorType = 133
-	DetectorType_Notion                        DetectorType = 134
-	DetectorType_DroneCI                       DetectorType = 135
-	DetectorType_AdobeIO                       DetectorType = 136
-	DetectorType_TwelveData                    DetectorType = 137
-	DetectorType_D7Network                     DetectorType = 138
-	DetectorType_ScrapingBee                   DetectorType = 139
-	DetectorType_KeenIO                        DetectorType = 140
-	DetectorType_Wakatime                      DetectorType = 141
-	DetectorType_Buildkite                     DetectorType = 142
-	DetectorType_Verimail                      DetectorType = 143
-	DetectorType_Zerobounce                    DetectorType = 144
-	DetectorType_Mailboxlayer                  DetectorType = 145
-	DetectorType_Fastspring                    DetectorType = 146
-	DetectorType_Paddle                        DetectorType = 147
-	DetectorType_Sellfy                        DetectorType = 148
-	DetectorType_FixerIO                       DetectorType = 149
-	DetectorType_ButterCMS                     DetectorType = 150
-	DetectorType_Taxjar                        DetectorType = 151
-	DetectorType_Avalara                       DetectorType = 152
-	DetectorType_Helpscout                     DetectorType = 153
-	DetectorType_ElasticPath                   DetectorType = 154
-	DetectorType_Zeplin                        DetectorType = 155
-	DetectorType_Intercom                      DetectorType = 156
-	DetectorType_Mailmodo                      DetectorType = 157
-	DetectorType_CannyIo                       DetectorType = 158
-	DetectorType_Pipedrive                     DetectorType = 159
-	DetectorType_Vercel                        DetectorType = 160
-	DetectorType_PosthogApp                    DetectorType = 161
-	DetectorType_SinchMessage                  DetectorType = 162
-	DetectorType_Ayrshare                      DetectorType = 163
-	DetectorType_HelpCrunch                    DetectorType = 164
-	DetectorType_LiveAgent                     DetectorType = 165
-	DetectorType_Beamer                        DetectorType = 166
-	DetectorType_WeChatAppKey                  DetectorType = 167
-	DetectorType_LineMessaging                 DetectorType = 168
-	DetectorType_UberServerToken               DetectorType = 169
-	DetectorType_AlgoliaAdminKey               DetectorType = 170
-	DetectorType_FullContact                   DetectorType = 171
-	DetectorType_Mandrill                      DetectorType = 172
-	DetectorType_Flutterwave                   DetectorType = 173
-	DetectorType_MattermostPersonalToken       DetectorType = 174
-	DetectorType_Cloudant                      DetectorType = 175
-	DetectorType_LineNotify                    DetectorType = 176
-	DetectorType_LinearAPI                     DetectorType = 177
-	DetectorType_Ubidots                       DetectorType = 178
-	DetectorType_Anypoint                      DetectorType = 179
-	DetectorType_Dwolla                        DetectorType = 180
-	DetectorType_ArtifactoryAccessToken        DetectorType = 181
-	DetectorType_Surge                         DetectorType = 182
-	DetectorType_Sparkpost                     DetectorType = 183
-	DetectorType_GoCardless                    DetectorType = 184
-	DetectorType_Codacy                        DetectorType = 185
-	DetectorType_Kraken                        DetectorType = 186
-	DetectorType_Checkout                      DetectorType = 187
-	DetectorType_Kairos                        DetectorType = 188
-	DetectorType_ClockworkSMS                  DetectorType = 189
-	DetectorType_Atlassian                     DetectorType = 190
-	DetectorType_LaunchDarkly                  DetectorType = 191
-	DetectorType_Coveralls                     DetectorType = 192
-	DetectorType_Linode                        DetectorType = 193
-	DetectorType_WePay                         DetectorType = 194
-	DetectorType_PlanetScale                   DetectorType = 195
-	DetectorType_Doppler                       DetectorType = 196
-	DetectorType_Agora                         DetectorType = 197
-	DetectorType_Samsara                       DetectorType = 198
-	DetectorType_FrameIO                       DetectorType = 199
-	DetectorType_RubyGems                      DetectorType = 200
-	DetectorType_OpenAI                        DetectorType = 201
-	DetectorType_SurveySparrow                 DetectorType = 202
-	DetectorType_Simvoly                       DetectorType = 203
-	DetectorType_Survicate                     DetectorType = 204
-	DetectorType_Omnisend                      DetectorType = 205
-	DetectorType_Groovehq                      DetectorType = 206
-	DetectorType_Newsapi                       DetectorType = 207
-	DetectorType_Chatbot                       DetectorType = 208
-	DetectorType_ClickSendsms                  DetectorType = 209
-	DetectorType_Getgist                       DetectorType = 210
-	DetectorType_CustomerIO                    DetectorType = 211
-	DetectorType_ApiDeck                       DetectorType = 212
-	DetectorType_Nftport                       DetectorType = 213
-	DetectorType_Copper                        DetectorType = 214
-	DetectorType_Close                         DetectorType = 215
-	DetectorType_Myfreshworks                  DetectorType = 216
-	DetectorType_Salesflare                    DetectorType = 217
-	DetectorType_Webflow                       DetectorType = 218
-	DetectorType_Duda                          DetectorType = 219
-	DetectorType_Yext                          DetectorType = 220
-	DetectorType_ContentStack                  DetectorType = 221
-	DetectorType_Storyblok                     DetectorType = 222
-	DetectorType_GraphCMS                      DetectorType = 223
-	DetectorType_Checkmarket                   DetectorType = 224
-	DetectorType_Convertkit                    DetectorType = 225
-	DetectorType_CustomerGuru                  DetectorType = 226
-	DetectorType_Kaleyra                       DetectorType = 227
-	DetectorType_Mailerlite                    DetectorType = 228
-	DetectorType_Qualaroo                      DetectorType = 229
-	DetectorType_SatismeterProjectkey          DetectorType = 230
-	DetectorType_SatismeterWritekey            DetectorType = 231
-	DetectorType_Simplesat                     DetectorType = 232
-	DetectorType_SurveyAnyplace                DetectorType = 233
-	DetectorType_SurveyBot                     DetectorType = 234
-	DetectorType_Webengage                     DetectorType = 235
-	DetectorType_ZonkaFeedback                 DetectorType = 236
-	DetectorType_Delighted                     DetectorType = 237
-	DetectorType_Feedier                       DetectorType = 238
-	DetectorType_Abbysale                      DetectorType = 239
-	DetectorType_Magnetic                      DetectorType = 240
-	DetectorType_Nytimes                       DetectorType = 241
-	DetectorType_Polygon                       DetectorType = 242
-	DetectorType_Powrbot                       DetectorType = 243
-	DetectorType_ProspectIO                    DetectorType = 244
-	DetectorType_Skrappio                      DetectorType = 245
-	DetectorType_Monday                        DetectorType = 246
-	DetectorType_Smartsheets                   DetectorType = 247
-	DetectorType_Wrike                         DetectorType = 248
-	DetectorType_Float                         DetectorType = 249
-	DetectorType_Imagekit                      DetectorType = 250
-	DetectorType_Integromat                    DetectorType = 251
-	DetectorType_Salesblink                    DetectorType = 252
-	DetectorType_Bored                         DetectorType = 253
-	DetectorType_Campayn                       DetectorType = 254
-	DetectorType_Clinchpad                     DetectorType = 255
-	DetectorType_CompanyHub                    DetectorType = 256
-	DetectorType_Debounce                      DetectorType = 257
-	DetectorType_Dyspatch                      DetectorType = 258
-	DetectorType_Guardianapi                   DetectorType = 259
-	DetectorType_Harvest                       DetectorType = 260
-	DetectorType_Moosend                       DetectorType = 261
-	DetectorType_OpenWeather                   DetectorType = 262
-	DetectorType_Siteleaf                      DetectorType = 263
-	DetectorType_Squarespace                   DetectorType = 264
-	DetectorType_FlowFlu                       DetectorType = 265
-	DetectorType_Nimble                        DetectorType = 266
-	DetectorType_LessAnnoyingCRM               DetectorType = 267
-	DetectorType_Nethunt                       DetectorType = 268
-	DetectorType_Apptivo                       DetectorType = 269
-	DetectorType_CapsuleCRM                    DetectorType = 270
-	DetectorType_Insightly                     DetectorType = 271
-	DetectorType_Kylas                         DetectorType = 272
-	DetectorType_OnepageCRM                    DetectorType = 273
-	DetectorType_User                          DetectorType = 274
-	DetectorType_ProspectCRM                   DetectorType = 275
-	DetectorType_ReallySimpleSystems           DetectorType = 276
-	DetectorType_Airship                       DetectorType = 277
-	DetectorType_Artsy                         DetectorType = 278
-	DetectorType_Yandex                        DetectorType = 279
-	DetectorType_Clockify                      DetectorType = 280
-	DetectorType_Dnscheck                      DetectorType = 281
-	DetectorType_EasyInsight                   DetectorType = 282
-	DetectorType_Ethplorer                     DetectorType = 283
-	DetectorType_Everhour                      DetectorType = 284
-	DetectorType_Fulcrum                       DetectorType = 285
-	DetectorType_GeoIpifi                      DetectorType = 286
-	DetectorType_Jotform                       DetectorType = 287
-	DetectorType_Refiner                       DetectorType = 288
-	DetectorType_Timezoneapi                   DetectorType = 289
-	DetectorType_TogglTrack                    DetectorType = 290
-	DetectorType_Vpnapi                        DetectorType = 291
-	DetectorType_Workstack                     DetectorType = 292
-	DetectorType_Apollo                        DetectorType = 293
-	DetectorType_Eversign                      DetectorType = 294
-	DetectorType_Juro                          DetectorType = 295
-	DetectorType_KarmaCRM                      DetectorType = 296
-	DetectorType_Metrilo                       DetectorType = 297
-	DetectorType_Pandadoc                      DetectorType = 298
-	DetectorType_RevampCRM                     DetectorType = 299
-	DetectorType_Salescookie                   DetectorType = 300
-	DetectorType_Alconost                      DetectorType = 301
-	DetectorType_Blogger                       DetectorType = 302
-	DetectorType_Accuweather                   DetectorType = 303
-	DetectorType_Opengraphr                    DetectorType = 304
-	DetectorType_Rawg                          DetectorType = 305
-	DetectorType_Riotgames                     DetectorType = 306
-	DetectorType_RoninApp                      DetectorType = 307
-	DetectorType_Stormglass                    DetectorType = 308
-	DetectorType_Tomtom                        DetectorType = 309
-	DetectorType_Twitch                        DetectorType = 310
-	DetectorType_Documo                        DetectorType = 311
-	DetectorType_Cloudways                     DetectorType = 312
-	DetectorType_Veevavault                    DetectorType = 313
-	DetectorType_KiteConnect                   DetectorType = 314
-	DetectorType_ShopeeOpenPlatform            DetectorType = 315
-	DetectorType_TeamViewer                    DetectorType = 316
-	DetectorType_Bulbul                        DetectorType = 317
-	DetectorType_CentralStationCRM             DetectorType = 318
-	DetectorType_Teamgate                      DetectorType = 319
-	DetectorType_Axonaut                       DetectorType = 320
-	DetectorType_Tyntec                        DetectorType = 321
-	DetectorType_Appcues                       DetectorType = 322
-	DetectorType_Autoklose                     DetectorType = 323
-	DetectorType_Cloudplan                     DetectorType = 324
-	DetectorType_Dotmailer                     DetectorType = 325
-	DetectorType_GetEmail                      DetectorType = 326
-	DetectorType_GetEmails                     DetectorType = 327
-	DetectorType_Kontent                       DetectorType = 328
-	DetectorType_Leadfeeder                    DetectorType = 329
-	DetectorType_Raven                         DetectorType = 330
-	DetectorType_RocketReach                   DetectorType = 331
-	DetectorType_Uplead                        DetectorType = 332
-	DetectorType_Brandfetch                    DetectorType = 333
-	DetectorType_Clearbit                      DetectorType = 334
-	DetectorType_Crowdin                       DetectorType = 335
-	DetectorType_Mapquest                      DetectorType = 336
-	DetectorType_Noticeable                    DetectorType = 337
-	DetectorType_Onbuka                        DetectorType = 338
-	DetectorType_Todoist                       DetectorType = 339
-	DetectorType_Storychief                    DetectorType = 340
-	DetectorType_LinkedIn                      DetectorType = 341
-	DetectorType_YouSign                       DetectorType = 342
-	DetectorType_Docker                        DetectorType = 343
-	DetectorType_Telesign                      DetectorType = 344
-	DetectorType_Spoonacular                   DetectorType = 345
-	DetectorType_Aerisweather                  DetectorType = 346
-	DetectorType_Alphavantage                  DetectorType = 347
-	DetectorType_Imgur                         DetectorType = 348
-	DetectorType_Imagga                        DetectorType = 349
-	DetectorType_SMSApi                        DetectorType = 350
-	DetectorType_Distribusion                  DetectorType = 351
-	DetectorType_Blablabus                     DetectorType = 352
-	DetectorType_WordsApi                      DetectorType = 353
-	DetectorType_Currencylayer                 DetectorType = 354
-	DetectorType_Html2Pdf                      DetectorType = 355
-	DetectorType_IPGeolocation                 DetectorType = 356
-	DetectorType_Owlbot                        DetectorType = 357
-	DetectorType_Cloudmersive                  DetectorType = 358
-	DetectorType_Dynalist                      DetectorType = 359
-	DetectorType_ExchangeRateAPI               DetectorType = 360
-	DetectorType_HolidayAPI                    DetectorType = 361
-	DetectorType_Ipapi                         DetectorType = 362
-	DetectorType_Marketstack                   DetectorType = 363
-	DetectorType_Nutritionix                   DetectorType = 364
-	DetectorType_Swell                         DetectorType = 365
-	DetectorType_ClickupPersonalToken          DetectorType = 366
-	DetectorType_Nitro                         DetectorType = 367
-	DetectorType_Rev                           DetectorType = 368
-	DetectorType_RunRunIt                      DetectorType = 369
-	DetectorType_Typeform                      DetectorType = 370
-	DetectorType_Mixpanel                      DetectorType = 371
-	DetectorType_Tradier                       DetectorType = 372
-	DetectorType_Verifier                      DetectorType = 373
-	DetectorType_Vouchery                      DetectorType = 374
-	DetectorType_Alegra                        DetectorType = 375
-	DetectorType_Audd                          DetectorType = 376
-	DetectorType_Baremetrics                   DetectorType = 377
-	DetectorType_Coinlib                       DetectorType = 378
-	DetectorType_ExchangeRatesAPI              DetectorType = 379
-	DetectorType_CurrencyScoop                 DetectorType = 380
-	DetectorType_FXMarket                      DetectorType = 381
-	DetectorType_CurrencyCloud                 DetectorType = 382
-	DetectorType_GetGeoAPI                     DetectorType = 383
-	DetectorType_Abstract                      DetectorType = 384
-	DetectorType_Billomat                      DetectorType = 385
-	DetectorType_Dovico                        DetectorType = 386
-	DetectorType_Bitbar                        DetectorType = 387
-	DetectorType_Bugsnag                       DetectorType = 388
-	DetectorType_AssemblyAI                    DetectorType = 389
-	DetectorType_AdafruitIO                    DetectorType = 390
-	DetectorType_Apify                         DetectorType = 391
-	DetectorType_CoinGecko                     DetectorType = 392
-	DetectorType_CryptoCompare                 DetectorType = 393
-	DetectorType_Fullstory                     DetectorType = 394
-	DetectorType_HelloSign                     DetectorType = 395
-	DetectorType_Loyverse                      DetectorType = 396
-	DetectorType_NetCore                       DetectorType = 397
-	DetectorType_SauceLabs                     DetectorType = 398
-	DetectorType_AlienVault                    DetectorType = 399
-	DetectorType_Apiflash                      DetectorType = 401
-	DetectorType_Coinlayer                     DetectorType = 402
-	DetectorType_CurrentsAPI                   DetectorType = 403
-	DetectorType_DataGov                       DetectorType = 404
-	DetectorType_Enigma                        DetectorType = 405
-	DetectorType_FinancialModelingPrep         DetectorType = 406
-	DetectorType_Geocodio                      DetectorType = 407
-	DetectorType_HereAPI                       DetectorType = 408
-	DetectorType_Macaddress                    DetectorType = 409
-	DetectorType_OOPSpam                       DetectorType = 410
-	DetectorType_ProtocolsIO                   DetectorType = 411
-	DetectorType_ScraperAPI                    DetectorType = 412
-	DetectorType_SecurityTrails                DetectorType = 413
-	DetectorType_TomorrowIO                    DetectorType = 414
-	DetectorType_WorldCoinIndex                DetectorType = 415
-	DetectorType_FacePlusPlus                  DetectorType = 416
-	DetectorType_Voicegain                     DetectorType = 417
-	DetectorType_Deepgram                      DetectorType = 418
-	DetectorType_VisualCrossing                DetectorType = 419
-	DetectorType_Finnhub                       DetectorType = 420
-	DetectorType_Tiingo                        DetectorType = 421
-	DetectorType_RingCentral                   DetectorType = 422
-	DetectorType_Finage                        DetectorType = 423
-	DetectorType_Edamam                        DetectorType = 424
-	DetectorType_HypeAuditor                   DetectorType = 425
-	DetectorType_Gengo                         DetectorType = 426
-	DetectorType_Front                         DetectorType = 427
-	DetectorType_Fleetbase                     DetectorType = 428
-	DetectorType_Bubble                        DetectorType = 429
-	DetectorType_Bannerbear                    DetectorType = 430
-	DetectorType_Adzuna                        DetectorType = 431
-	DetectorType_BitcoinAverage                DetectorType = 432
-	DetectorType_CommerceJS                    DetectorType = 433
-	DetectorType_DetectLanguage                DetectorType = 434
-	DetectorType_FakeJSON                      DetectorType = 435
-	DetectorType_Graphhopper                   DetectorType = 436
-	DetectorType_Lexigram                      DetectorType = 437
-	DetectorType_LinkPreview                   DetectorType = 438
-	DetectorType_Numverify                     DetectorType = 439
-	DetectorType_ProxyCrawl                    DetectorType = 440
-	DetectorType_ZipCodeAPI                    DetectorType = 441
-	DetectorType_Cometchat                     DetectorType = 442
-	DetectorType_Keygen                        DetectorType = 443
-	DetectorType_Mixcloud                      DetectorType = 444
-	DetectorType_TatumIO                       DetectorType = 445
-	DetectorType_Tmetric                       DetectorType = 446
-	DetectorType_Lastfm                        DetectorType = 447
-	DetectorType_Browshot                      DetectorType = 448
-	DetectorType_JSONbin                       DetectorType = 449
-	DetectorType_LocationIQ                    DetectorType = 450
-	DetectorType_ScreenshotAPI                 DetectorType = 451
-	DetectorType_WeatherStack                  DetectorType = 452
-	DetectorType_Amadeus                       DetectorType = 453
-	DetectorType_FourSquare                    DetectorType = 454
-	DetectorType_Flickr                        DetectorType = 455
-	DetectorType_ClickHelp                     DetectorType = 456
-	DetectorType_Ambee                         DetectorType = 457
-	DetectorType_Api2Cart                      DetectorType = 458
-	DetectorType_Hypertrack                    DetectorType = 459
-	DetectorType_KakaoTalk                     DetectorType = 460
-	DetectorType_RiteKit                       DetectorType = 461
-	DetectorType_Shutterstock                  DetectorType = 462
-	DetectorType_Text2Data                     DetectorType = 463
-	DetectorType_YouNeedABudget                DetectorType = 464
-	DetectorType_Cricket                       DetectorType = 465
-	DetectorType_Filestack                     DetectorType = 466
-	DetectorType_Gyazo                         DetectorType = 467
-	DetectorType_Mavenlink                     DetectorType = 468
-	DetectorType_Sheety                        DetectorType = 469
-	DetectorType_Sportsmonk                    DetectorType = 470
-	DetectorType_Stockdata                     DetectorType = 471
-	DetectorType_Unsplash                      DetectorType = 472
-	DetectorType_Allsports                     DetectorType = 473
-	DetectorType_CalorieNinja                  DetectorType = 474
-	DetectorType_WalkScore                     DetectorType = 475
-	DetectorType_Strava                        DetectorType = 476
-	DetectorType_Cicero                        DetectorType = 477
-	DetectorType_IPQuality                     DetectorType = 478
-	DetectorType_ParallelDots                  DetectorType = 479
-	DetectorType_Roaring                       DetectorType = 480
-	DetectorType_Mailsac                       DetectorType = 481
-	DetectorType_Whoxy                         DetectorType = 482
-	DetectorType_WorldWeather                  DetectorType = 483
-	DetectorType_ApiFonica                     DetectorType = 484
-	DetectorType_Aylien                        DetectorType = 485
-	DetectorType_Geocode                       DetectorType = 486
-	DetectorType_IconFinder                    DetectorType = 487
-	DetectorType_Ipify                         DetectorType = 488
-	DetectorType_LanguageLayer                 DetectorType = 489
-	DetectorType_Lob                           DetectorType = 490
-	DetectorType_OnWaterIO                     DetectorType = 491
-	DetectorType_Pastebin                      DetectorType = 492
-	DetectorType_PdfLayer                      DetectorType = 493
-	DetectorType_Pixabay                       DetectorType = 494
-	DetectorType_ReadMe                        DetectorType = 495
-	DetectorType_VatLayer                      DetectorType = 496
-	DetectorType_VirusTotal                    DetectorType = 497
-	DetectorType_AirVisual                     DetectorType = 498
-	DetectorType_Currencyfreaks                DetectorType = 499
-	DetectorType_Duffel                        DetectorType = 500
-	DetectorType_FlatIO                        DetectorType = 501
-	DetectorType_M3o                           DetectorType = 502
-	DetectorType_Mesibo                        DetectorType = 503
-	DetectorType_Openuv                        DetectorType = 504
-	DetectorType_Snipcart                      DetectorType = 505
-	DetectorType_Besttime                      DetectorType = 506
-	DetectorType_Happyscribe                   DetectorType = 507
-	DetectorType_Humanity                      DetectorType = 508
-	DetectorType_Impala                        DetectorType = 509
-	DetectorType_Loginradius                   DetectorType = 510
-	DetectorType_AutoPilot                     DetectorType = 511
-	DetectorType_Bitmex                        DetectorType = 512
-	DetectorType_ClustDoc                      DetectorType = 513
-	DetectorType_Messari                       DetectorType = 514
-	DetectorType_PdfShift                      DetectorType = 515
-	DetectorType_Poloniex                      DetectorType = 516
-	DetectorType_RestpackHtmlToPdfAPI          DetectorType = 517
-	DetectorType_RestpackScreenshotAPI         DetectorType = 518
-	DetectorType_ShutterstockOAuth             DetectorType = 519
-	DetectorType_SkyBiometry                   DetectorType = 520
-	DetectorType_AbuseIPDB                     DetectorType = 521
-	DetectorType_AletheiaApi                   DetectorType = 522
-	DetectorType_BlitApp                       DetectorType = 523
-	DetectorType_Censys                        DetectorType = 524
-	DetectorType_Cloverly                      DetectorType = 525
-	DetectorType_CountryLayer                  DetectorType = 526
-	DetectorType_FileIO                        DetectorType = 527
-	DetectorType_FlightApi                     DetectorType = 528
-	DetectorType_Geoapify                      DetectorType = 529
-	DetectorType_IPinfoDB                      DetectorType = 530
-	DetectorType_MediaStack                    DetectorType = 531
-	DetectorType_NasdaqDataLink                DetectorType = 532
-	DetectorType_OpenCageData                  DetectorType = 533
-	DetectorType_Paymongo                      DetectorType = 534
-	DetectorType_PositionStack                 DetectorType = 535
-	DetectorType_Rebrandly                     DetectorType = 536
-	DetectorType_ScreenshotLayer               DetectorType = 537
-	DetectorType_Stytch                        DetectorType = 538
-	DetectorType_Unplugg                       DetectorType = 539
-	DetectorType_UPCDatabase                   DetectorType = 540
-	DetectorType_UserStack                     DetectorType = 541
-	DetectorType_Geocodify                     DetectorType = 542
-	DetectorType_Newscatcher                   DetectorType = 543
-	DetectorType_Nicereply                     DetectorType = 544
-	DetectorType_Partnerstack                  DetectorType = 545
-	DetectorType_Route4me                      DetectorType = 546
-	DetectorType_Scrapeowl                     DetectorType = 547
-	DetectorType_ScrapingDog                   DetectorType = 548
-	DetectorType_Streak                        DetectorType = 549
-	DetectorType_Veriphone                     DetectorType = 550
-	DetectorType_Webscraping                   DetectorType = 551
-	DetectorType_Zenscrape                     DetectorType = 552
-	DetectorType_Zenserp                       DetectorType = 553
-	DetectorType_CoinApi                       DetectorType = 554
-	DetectorType_Gitter                        DetectorType = 555
-	DetectorType_Host                          DetectorType = 556
-	DetectorType_Iexcloud                      DetectorType = 557
-	DetectorType_Restpack                      DetectorType = 558
-	DetectorType_ScraperBox                    DetectorType = 559
-	DetectorType_ScrapingAnt                   DetectorType = 560
-	DetectorType_SerpStack                     DetectorType = 561
-	DetectorType_SmartyStreets                 DetectorType = 562
-	DetectorType_TicketMaster                  DetectorType = 563
-	DetectorType_AviationStack                 DetectorType = 564
-	DetectorType_BombBomb                      DetectorType = 565
-	DetectorType_Commodities                   DetectorType = 566
-	DetectorType_Dfuse                         DetectorType = 567
-	DetectorType_EdenAI                        DetectorType = 568
-	DetectorType_Glassnode                     DetectorType = 569
-	DetectorType_Guru                          DetectorType = 570
-	DetectorType_Hive                          DetectorType = 571
-	DetectorType_Hiveage                       DetectorType = 572
-	DetectorType_Kickbox                       DetectorType = 573
-	DetectorType_Passbase                      DetectorType = 574
-	DetectorType_PostageApp                    DetectorType = 575
-	DetectorType_PureStake                     DetectorType = 576
-	DetectorType_Qubole                        DetectorType = 577
-	DetectorType_CarbonInterface               DetectorType = 578
-	DetectorType_Intrinio                      DetectorType = 579
-	DetectorType_QuickMetrics                  DetectorType = 580
-	DetectorType_ScrapeStack                   DetectorType = 581
-	DetectorType_TechnicalAnalysisApi          DetectorType = 582
-	DetectorType_Urlscan                       DetectorType = 583
-	DetectorType_BaseApiIO                     DetectorType = 584
-	DetectorType_DailyCO                       DetectorType = 585
-	DetectorType_TLy                           DetectorType = 586
-	DetectorType_Shortcut                      DetectorType = 587
-	DetectorType_Appfollow                     DetectorType = 588
-	DetectorType_Thinkific                     DetectorType = 589
-	DetectorType_Feedly                        DetectorType = 590
-	DetectorType_Stitchdata                    DetectorType = 591
-	DetectorType_Fetchrss                      DetectorType = 592
-	DetectorType_Signupgenius                  DetectorType = 593
-	DetectorType_Signaturit                    DetectorType = 594
-	DetectorType_Optimizely                    DetectorType = 595
-	DetectorType_OcrSpace                      DetectorType = 596
-	DetectorType_WeatherBit                    DetectorType = 597
-	DetectorType_BuddyNS                       DetectorType = 598
-	DetectorType_ZipAPI                        DetectorType = 599
-	DetectorType_ZipBooks                      DetectorType = 600
-	DetectorType_Onedesk                       DetectorType = 601
-	DetectorType_Bugherd                       DetectorType = 602
-	DetectorType_Blazemeter                    DetectorType = 603
-	DetectorType_Autodesk                      DetectorType = 604
-	DetectorType_Tru                           DetectorType = 605
-	DetectorType_UnifyID                       DetectorType = 606
-	DetectorType_Trimble                       DetectorType = 607
-	DetectorType_Smooch                        DetectorType = 608
-	DetectorType_Semaphore                     DetectorType = 609
-	DetectorType_Telnyx                        DetectorType = 610
-	DetectorType_Signalwire                    DetectorType = 611
-	DetectorType_Textmagic                     DetectorType = 612
-	DetectorType_Serphouse                     DetectorType = 613
-	DetectorType_Planyo                        DetectorType = 614
-	DetectorType_Simplybook                    DetectorType = 615
-	DetectorType_Vyte                          DetectorType = 616
-	DetectorType_Nylas                         DetectorType = 617
-	DetectorType_Squareup                      DetectorType = 618
-	DetectorType_Dandelion                     DetectorType = 619
-	DetectorType_DataFire                      DetectorType = 620
-	DetectorType_DeepAI                        DetectorType = 621
-	DetectorType_MeaningCloud                  DetectorType = 622
-	DetectorType_NeutrinoApi                   DetectorType = 623
-	DetectorType_Storecove                     DetectorType = 624
-	DetectorType_Shipday                       DetectorType = 625
-	DetectorType_Sentiment                     DetectorType = 626
-	DetectorType_StreamChatMessaging           DetectorType = 627
-	DetectorType_TeamworkCRM                   DetectorType = 628
-	DetectorType_TeamworkDesk                  DetectorType = 629
-	DetectorType_TeamworkSpaces                DetectorType = 630
-	DetectorType_TheOddsApi                    DetectorType = 631
-	DetectorType_Apacta                        DetectorType = 632
-	DetectorType_GetSandbox                    DetectorType = 633
-	DetectorType_Happi                         DetectorType = 634
-	DetectorType_Oanda                         DetectorType = 635
-	DetectorType_FastForex                     DetectorType = 636
-	DetectorType_APIMatic                      DetectorType = 637
-	DetectorType_VersionEye                    DetectorType = 638
-	DetectorType_EagleEyeNetworks              DetectorType = 639
-	DetectorType_ThousandEyes                  DetectorType = 640
-	DetectorType_SelectPDF                     DetectorType = 641
-	DetectorType_Flightstats                   DetectorType = 642
-	DetectorType_ChecIO                        DetectorType = 643
-	DetectorType_Manifest                      DetectorType = 644
-	DetectorType_ApiScience                    DetectorType = 645
-	DetectorType_AppSynergy                    DetectorType = 646
-	DetectorType_Caflou                        DetectorType = 647
-	DetectorType_Caspio                        DetectorType = 648
-	DetectorType_ChecklyHQ                     DetectorType = 649
-	DetectorType_CloudElements                 DetectorType = 650
-	DetectorType_DronaHQ                       DetectorType = 651
-	DetectorType_Enablex                       DetectorType = 652
-	DetectorType_Fmfw                          DetectorType = 653
-	DetectorType_GoodDay                       DetectorType = 654
-	DetectorType_Luno                          DetectorType = 655
-	DetectorType_Meistertask                   DetectorType = 656
-	DetectorType_Mindmeister                   DetectorType = 657
-	DetectorType_PeopleDataLabs                DetectorType = 658
-	DetectorType_ScraperSite                   DetectorType = 659
-	DetectorType_Scrapfly                      DetectorType = 660
-	DetectorType_SimplyNoted                   DetectorType = 661
-	DetectorType_TravelPayouts                 DetectorType = 662
-	DetectorType_WebScraper                    DetectorType = 663
-	DetectorType_Convier                       DetectorType = 664
-	DetectorType_Courier                       DetectorType = 665
-	DetectorType_Ditto                         DetectorType = 666
-	DetectorType_Findl                         DetectorType = 667
-	DetectorType_Lendflow                      DetectorType = 668
-	DetectorType_Moderation                    DetectorType = 669
-	DetectorType_Opendatasoft                  DetectorType = 670
-	DetectorType_Podio                         DetectorType = 671
-	DetectorType_Rockset                       DetectorType = 672
-	DetectorType_Rownd                         DetectorType = 673
-	DetectorType_Shotstack                     DetectorType = 674
-	DetectorType_Swiftype                      DetectorType = 675
-	DetectorType_Twitter                       DetectorType = 676
-	DetectorType_Honey                         DetectorType = 677
-	DetectorType_Freshdesk                     DetectorType = 678
-	DetectorType_Upwave                        DetectorType = 679
-	DetectorType_Fountain                      DetectorType = 680
-	DetectorType_Freshbooks                    DetectorType = 681
-	DetectorType_Mite                          DetectorType = 682
-	DetectorType_Deputy                        DetectorType = 683
-	DetectorType_Beebole                       DetectorType = 684
-	DetectorType_Cashboard                     DetectorType = 685
-	DetectorType_Kanban                        DetectorType = 686
-	DetectorType_Worksnaps                     DetectorType = 687
-	DetectorType_MyIntervals                   DetectorType = 688
-	DetectorType_InvoiceOcean                  DetectorType = 689
-	DetectorType_Sherpadesk                    DetectorType = 690
-	DetectorType_Mrticktock                    DetectorType = 691
-	DetectorType_Chatfule                      DetectorType = 692
-	DetectorType_Aeroworkflow                  DetectorType = 693
-	DetectorType_Emailoctopus                  DetectorType = 694
-	DetectorType_Fusebill                      DetectorType = 695
-	DetectorType_Geckoboard                    DetectorType = 696
-	DetectorType_Gosquared                     DetectorType = 697
-	DetectorType_Moonclerk                     DetectorType = 698
-	DetectorType_Paymoapp                      DetectorType = 699
-	DetectorType_Mixmax                        DetectorType = 700
-	DetectorType_Processst                     DetectorType = 701
-	DetectorType_Repairshopr                   DetectorType = 702
-	DetectorType_Goshippo                      DetectorType = 703
-	DetectorType_Sigopt                        DetectorType = 704
-	DetectorType_Sugester                      DetectorType = 705
-	DetectorType_Viewneo                       DetectorType = 706
-	DetectorType_BoostNote                     DetectorType = 707
-	DetectorType_CaptainData                   DetectorType = 708
-	DetectorType_Checkvist                     DetectorType = 709
-	DetectorType_Cliengo                       DetectorType = 710
-	DetectorType_Cloze                         DetectorType = 711
-	DetectorType_FormIO                        DetectorType = 712
-	DetectorType_FormBucket                    DetectorType = 713
-	DetectorType_GoCanvas                      DetectorType = 714
-	DetectorType_MadKudu                       DetectorType = 715
-	DetectorType_NozbeTeams                    DetectorType = 716
-	DetectorType_Papyrs                        DetectorType = 717
-	DetectorType_SuperNotesAPI                 DetectorType = 718
-	DetectorType_Tallyfy                       DetectorType = 719
-	DetectorType_ZenkitAPI                     DetectorType = 720
-	DetectorType_CloudImage                    DetectorType = 721
-	DetectorType_UploadCare                    DetectorType = 722
-	DetectorType_Borgbase                      DetectorType = 723
-	DetectorType_Pipedream                     DetectorType = 724
-	DetectorType_Sirv                          DetectorType = 725
-	DetectorType_Diffbot                       DetectorType = 726
-	DetectorType_EightxEight                   DetectorType = 727
-	DetectorType_Sendoso                       DetectorType = 728
-	DetectorType_Printfection                  DetectorType = 729
-	DetectorType_Authorize                     DetectorType = 730
-	DetectorType_PandaScore                    DetectorType = 731
-	DetectorType_Paymo                         DetectorType = 732
-	DetectorType_AvazaPersonalAccessToken      DetectorType = 733
-	DetectorType_PlanviewLeanKit               DetectorType = 734
-	DetectorType_Livestorm                     DetectorType = 735
-	DetectorType_KuCoin                        DetectorType = 736
-	DetectorType_MetaAPI                       DetectorType = 737
-	DetectorType_NiceHash                      DetectorType = 738
-	DetectorType_CexIO                         DetectorType = 739
-	DetectorType_Klipfolio                     DetectorType = 740
-	DetectorType_Dynatrace                     DetectorType = 741
-	DetectorType_MollieAPIKey                  DetectorType = 742
-	DetectorType_MollieAccessToken             DetectorType = 743
-	DetectorType_BasisTheory                   DetectorType = 744
-	DetectorType_Nordigen                      DetectorType = 745
-	DetectorType_FlagsmithEnvironmentKey       DetectorType = 746
-	DetectorType_FlagsmithToken                DetectorType = 747
-	DetectorType_Mux                           DetectorType = 748
-	DetectorType_Column                        DetectorType = 749
-	DetectorType_Sendbird                      DetectorType = 750
-	DetectorType_SendbirdOrganizationAPI       DetectorType = 751
-	DetectorType_Midise                        DetectorType = 752
-	DetectorType_Mockaroo                      DetectorType = 753
-	DetectorType_Image4                        DetectorType = 754
-	DetectorType_Pinata                        DetectorType = 755
-	DetectorType_BrowserStack                  DetectorType = 756
-	DetectorType_CrossBrowserTesting           DetectorType = 757
-	DetectorType_Loadmill                      DetectorType = 758
-	DetectorType_TestingBot                    DetectorType = 759
-	DetectorType_KnapsackPro                   DetectorType = 760
-	DetectorType_Qase                          DetectorType = 761
-	DetectorType_Dareboost                     DetectorType = 762
-	DetectorType_GTMetrix                      DetectorType = 763
-	DetectorType_Holistic                      DetectorType = 764
-	DetectorType_Parsers                       DetectorType = 765
-	DetectorType_ScrutinizerCi                 DetectorType = 766
-	DetectorType_SonarCloud                    DetectorType = 767
-	DetectorType_APITemplate                   DetectorType = 768
-	DetectorType_ConversionTools               DetectorType = 769
-	DetectorType_CraftMyPDF                    DetectorType = 770
-	DetectorType_ExportSDK                     DetectorType = 771
-	DetectorType_GlitterlyAPI                  DetectorType = 772
-	DetectorType_Hybiscus                      DetectorType = 773
-	DetectorType_Miro                          DetectorType = 774
-	DetectorType_Statuspage                    DetectorType = 775
-	DetectorType_Statuspal                     DetectorType = 776
-	DetectorType_Teletype                      DetectorType = 777
-	DetectorType_TimeCamp                      DetectorType = 778
-	DetectorType_Userflow                      DetectorType = 779
-	DetectorType_Wistia                        DetectorType = 780
-	DetectorType_SportRadar                    DetectorType = 781
-	DetectorType_UptimeRobot                   DetectorType = 782
-	DetectorType_Codequiry                     DetectorType = 783
-	DetectorType_ExtractorAPI                  DetectorType = 784
-	DetectorType_Signable                      DetectorType = 785
-	DetectorType_MagicBell                     DetectorType = 786
-	DetectorType_Stormboard                    DetectorType = 787
-	DetectorType_Apilayer                      DetectorType = 788
-	DetectorType_Disqus                        DetectorType = 789
-	DetectorType_Woopra                        DetectorType = 790
-	DetectorType_Paperform                     DetectorType = 791
-	DetectorType_Gumroad                       DetectorType = 792
-	DetectorType_Paydirtapp                    DetectorType = 793
-	DetectorType_Detectify                     DetectorType = 794
-	DetectorType_Statuscake                    DetectorType = 795
-	DetectorType_Jumpseller                    DetectorType = 796
-	DetectorType_LunchMoney                    DetectorType = 797
-	DetectorType_Rosette                       DetectorType = 798
-	DetectorType_Yelp                          DetectorType = 799
-	DetectorType_Atera                         DetectorType = 800
-	DetectorType_EcoStruxureIT                 DetectorType = 801
-	DetectorType_Aha                           DetectorType = 802
-	DetectorType_Parsehub                      DetectorType = 803
-	DetectorType_PackageCloud                  DetectorType = 804
-	DetectorType_Cloudsmith                    DetectorType = 805
-	DetectorType_Flowdash                      DetectorType = 806
-	DetectorType_Flowdock                      DetectorType = 807
-	DetectorType_Fibery                        DetectorType = 808
-	DetectorType_Typetalk                      DetectorType = 809
-	DetectorType_VoodooSMS                     DetectorType = 810
-	DetectorType_ZulipChat                     DetectorType = 811
-	DetectorType_Formcraft                     DetectorType = 812
-	DetectorType_Iexapis                       DetectorType = 813
-	DetectorType_Reachmail                     DetectorType = 814
-	DetectorType_Chartmogul                    DetectorType = 815
-	DetectorType_Appointed                     DetectorType = 816
-	DetectorType_Wit                           DetectorType = 817
-	DetectorType_RechargePayments              DetectorType = 818
-	DetectorType_Diggernaut                    DetectorType = 819
-	DetectorType_MonkeyLearn                   DetectorType = 820
-	DetectorType_Duply                         DetectorType = 821
-	DetectorType_Postbacks                     DetectorType = 822
-	DetectorType_Collect2                      DetectorType = 823
-	DetectorType_ZenRows                       DetectorType = 824
-	DetectorType_Zipcodebase                   DetectorType = 825
-	DetectorType_Tefter                        DetectorType = 826
-	DetectorType_Twist                         DetectorType = 827
-	DetectorType_BraintreePayments             DetectorType = 828
-	DetectorType_CloudConvert                  DetectorType = 829
-	DetectorType_Grafana                       DetectorType = 830
-	DetectorType_ConvertApi                    DetectorType = 831
-	DetectorType_Transferwise                  DetectorType = 832
-	DetectorType_Bulksms                       DetectorType = 833
-	DetectorType_Databox                       DetectorType = 834
-	DetectorType_Onesignal                     DetectorType = 835
-	DetectorType_Rentman                       DetectorType = 836
-	DetectorType_Parseur                       DetectorType = 837
-	DetectorType_Docparser                     DetectorType = 838
-	DetectorType_Formsite                      DetectorType = 839
-	DetectorType_Tickettailor                  DetectorType = 840
-	DetectorType_Lemlist                       DetectorType = 841
-	DetectorType_Prodpad                       DetectorType = 842
-	DetectorType_Formstack                     DetectorType = 843
-	DetectorType_Codeclimate                   DetectorType = 844
-	DetectorType_Codemagic                     DetectorType = 845
-	DetectorType_Vbout                         DetectorType = 846
-	DetectorType_Nightfall                     DetectorType = 847
-	DetectorType_FlightLabs                    DetectorType = 848
-	DetectorType_SpeechTextAI                  DetectorType = 849
-	DetectorType_PollsAPI                      DetectorType = 850
-	DetectorType_SimFin                        DetectorType = 851
-	DetectorType_Scalr                         DetectorType = 852
-	DetectorType_Kanbantool                    DetectorType = 853
-	DetectorType_Brightlocal                   DetectorType = 854
-	DetectorType_Hotwire                       DetectorType = 855
-	DetectorType_Instabot                      DetectorType = 856
-	DetectorType_Timekit                       DetectorType = 857
-	DetectorType_Interseller                   DetectorType = 858
-	DetectorType_Mojohelpdesk                  DetectorType = 859
-	DetectorType_Createsend                    DetectorType = 860
-	DetectorType_Getresponse                   DetectorType = 861
-	DetectorType_Dynadot                       DetectorType = 862
-	DetectorType_Demio                         DetectorType = 863
-	DetectorType_Tokeet                        DetectorType = 864
-	DetectorType_Myexperiment                  DetectorType = 865
-	DetectorType_Copyscape                     DetectorType = 866
-	DetectorType_Besnappy                      DetectorType = 867
-	DetectorType_Salesmate                     DetectorType = 868
-	DetectorType_Heatmapapi                    DetectorType = 869
-	DetectorType_Websitepulse                  DetectorType = 870
-	DetectorType_Uclassify                     DetectorType = 871
+	DetectorType_Alibaba                          DetectorType = 0
+	DetectorType_AMQP                             DetectorType = 1
+	DetectorType_AWS                              DetectorType = 2
+	DetectorType_Azure                            DetectorType = 3
+	DetectorType_Circle                           DetectorType = 4
+	DetectorType_Coinbase                         DetectorType = 5
+	DetectorType_GCP                              DetectorType = 6
+	DetectorType_Generic                          DetectorType = 7
+	DetectorType_Github                           DetectorType = 8
+	DetectorType_Gitlab                           DetectorType = 9
+	DetectorType_JDBC                             DetectorType = 10
+	DetectorType_RazorPay                         DetectorType = 11
+	DetectorType_SendGrid                         DetectorType = 12
+	DetectorType_Slack                            DetectorType = 13
+	DetectorType_Square                           DetectorType = 14
+	DetectorType_PrivateKey                       DetectorType = 15
+	DetectorType_Stripe                           DetectorType = 16
+	DetectorType_URI                              DetectorType = 17
+	DetectorType_Dropbox                          DetectorType = 18
+	DetectorType_Heroku                           DetectorType = 19
+	DetectorType_Mailchimp                        DetectorType = 20
+	DetectorType_Okta                             DetectorType = 21
+	DetectorType_OneLogin                         DetectorType = 22
+	DetectorType_PivotalTracker                   DetectorType = 23
+	DetectorType_SquareApp                        DetectorType = 25
+	DetectorType_Twilio                           DetectorType = 26
+	DetectorType_Test                             DetectorType = 27
+	DetectorType_TravisCI                         DetectorType = 29
+	DetectorType_SlackWebhook                     DetectorType = 30
+	DetectorType_PaypalOauth                      DetectorType = 31
+	DetectorType_PagerDutyApiKey                  DetectorType = 32
+	DetectorType_Firebase                         DetectorType = 33
+	DetectorType_Mailgun                          DetectorType = 34
+	DetectorType_HubSpot                          DetectorType = 35
+	DetectorType_GitHubApp                        DetectorType = 36
+	DetectorType_CircleCI                         DetectorType = 37
+	DetectorType_WpEngine                         DetectorType = 38
+	DetectorType_DatadogToken                     DetectorType = 39
+	DetectorType_FacebookOAuth                    DetectorType = 40
+	DetectorType_AsanaPersonalAccessToken         DetectorType = 41
+	DetectorType_AmplitudeApiKey                  DetectorType = 42
+	DetectorType_BitLyAccessToken                 DetectorType = 43
+	DetectorType_CalendlyApiKey                   DetectorType = 44
+	DetectorType_ZapierWebhook                    DetectorType = 45
+	DetectorType_YoutubeApiKey                    DetectorType = 46
+	DetectorType_SalesforceOauth2                 DetectorType = 47
+	DetectorType_TwitterApiSecret                 DetectorType = 48
+	DetectorType_NpmToken                         DetectorType = 49
+	DetectorType_NewRelicPersonalApiKey           DetectorType = 50
+	DetectorType_AirtableApiKey                   DetectorType = 51
+	DetectorType_AkamaiToken                      DetectorType = 52
+	DetectorType_AmazonMWS                        DetectorType = 53
+	DetectorType_KubeConfig                       DetectorType = 54
+	DetectorType_Auth0oauth                       DetectorType = 55
+	DetectorType_Bitfinex                         DetectorType = 56
+	DetectorType_Clarifai                         DetectorType = 57
+	DetectorType_CloudflareGlobalApiKey           DetectorType = 58
+	DetectorType_CloudflareCaKey                  DetectorType = 59
+	DetectorType_Confluent                        DetectorType = 60
+	DetectorType_ContentfulDelivery               DetectorType = 61 // Didn't do
+	DetectorType_DatabricksToken                  DetectorType = 62
+	DetectorType_DigitalOceanSpaces               DetectorType = 63 // Didn't do
+	DetectorType_DigitalOceanToken                DetectorType = 64
+	DetectorType_DiscordBotToken                  DetectorType = 65
+	DetectorType_DiscordWebhook                   DetectorType = 66
+	DetectorType_EtsyApiKey                       DetectorType = 67
+	DetectorType_FastlyPersonalToken              DetectorType = 68
+	DetectorType_GoogleOauth2                     DetectorType = 69
+	DetectorType_ReCAPTCHA                        DetectorType = 70 // Didn't do
+	DetectorType_GoogleApiKey                     DetectorType = 71 // Didn't do
+	DetectorType_Hunter                           DetectorType = 72
+	DetectorType_IbmCloudUserKey                  DetectorType = 73
+	DetectorType_Netlify                          DetectorType = 74
+	DetectorType_Vonage                           DetectorType = 75
+	DetectorType_EquinixOauth                     DetectorType = 76
+	DetectorType_Paystack                         DetectorType = 77
+	DetectorType_PlaidToken                       DetectorType = 78
+	DetectorType_PlaidKey                         DetectorType = 79
+	DetectorType_Plivo                            DetectorType = 80
+	DetectorType_Postmark                         DetectorType = 81
+	DetectorType_PubNubPublishKey                 DetectorType = 82
+	DetectorType_PubNubSubscriptionKey            DetectorType = 83
+	DetectorType_PusherChannelKey                 DetectorType = 84
+	DetectorType_ScalewayKey                      DetectorType = 85
+	DetectorType_SendinBlueV2                     DetectorType = 86
+	DetectorType_SentryToken                      DetectorType = 87
+	DetectorType_ShodanKey                        DetectorType = 88
+	DetectorType_SnykKey                          DetectorType = 89
+	DetectorType_SpotifyKey                       DetectorType = 90
+	DetectorType_TelegramBotToken                 DetectorType = 91
+	DetectorType_TencentCloudKey                  DetectorType = 92
+	DetectorType_TerraformCloudPersonalToken      DetectorType = 93
+	DetectorType_TrelloApiKey                     DetectorType = 94
+	DetectorType_ZendeskApi                       DetectorType = 95
+	DetectorType_MaxMindLicense                   DetectorType = 96
+	DetectorType_AirtableMetadataApiKey           DetectorType = 97
+	DetectorType_AsanaOauth                       DetectorType = 98
+	DetectorType_RapidApi                         DetectorType = 99
+	DetectorType_CloudflareApiToken               DetectorType = 100
+	DetectorType_Webex                            DetectorType = 101
+	DetectorType_FirebaseCloudMessaging           DetectorType = 102
+	DetectorType_ContentfulPersonalAccessToken    DetectorType = 103
+	DetectorType_MapBox                           DetectorType = 104
+	DetectorType_MailJetBasicAuth                 DetectorType = 105
+	DetectorType_MailJetSMS                       DetectorType = 106
+	DetectorType_HubSpotApiKey                    DetectorType = 107
+	DetectorType_HubSpotOauth                     DetectorType = 108
+	DetectorType_SslMate                          DetectorType = 109
+	DetectorType_Auth0ManagementApiToken          DetectorType = 110
+	DetectorType_MessageBird                      DetectorType = 111
+	DetectorType_ElasticEmail                     DetectorType = 112
+	DetectorType_FigmaPersonalAccessToken         DetectorType = 113
+	DetectorType_MicrosoftTeamsWebhook            DetectorType = 114
+	DetectorType_GitHubOld                        DetectorType = 115
+	DetectorType_VultrApiKey                      DetectorType = 116
+	DetectorType_Pepipost                         DetectorType = 117
+	DetectorType_Postman                          DetectorType = 118
+	DetectorType_CloudsightKey                    DetectorType = 119
+	DetectorType_JiraToken                        DetectorType = 120
+	DetectorType_NexmoApiKey                      DetectorType = 121
+	DetectorType_SegmentApiKey                    DetectorType = 122
+	DetectorType_SumoLogicKey                     DetectorType = 123
+	DetectorType_PushBulletApiKey                 DetectorType = 124
+	DetectorType_AirbrakeProjectKey               DetectorType = 125
+	DetectorType_AirbrakeUserKey                  DetectorType = 126
+	DetectorType_PendoIntegrationKey              DetectorType = 127
+	DetectorType_SplunkOberservabilityToken       DetectorType = 128
+	DetectorType_LokaliseToken                    DetectorType = 129
+	DetectorType_Calendarific                     DetectorType = 130
+	DetectorType_Jumpcloud                        DetectorType = 131
+	DetectorType_IpStack                          DetectorType = 133
+	DetectorType_Notion                           DetectorType = 134
+	DetectorType_DroneCI                          DetectorType = 135
+	DetectorType_AdobeIO                          DetectorType = 136
+	DetectorType_TwelveData                       DetectorType = 137
+	DetectorType_D7Network                        DetectorType = 138
+	DetectorType_ScrapingBee                      DetectorType = 139
+	DetectorType_KeenIO                           DetectorType = 140
+	DetectorType_Wakatime                         DetectorType = 141
+	DetectorType_Buildkite                        DetectorType = 142
+	DetectorType_Verimail                         DetectorType = 143
+	DetectorType_Zerobounce                       DetectorType = 144
+	DetectorType_Mailboxlayer                     DetectorType = 145
+	DetectorType_Fastspring                       DetectorType = 146
+	DetectorType_Paddle                           DetectorType = 147
+	DetectorType_Sellfy                           DetectorType = 148
+	DetectorType_FixerIO                          DetectorType = 149
+	DetectorType_ButterCMS                        DetectorType = 150
+	DetectorType_Taxjar                           DetectorType = 151
+	DetectorType_Avalara                          DetectorType = 152
+	DetectorType_Helpscout                        DetectorType = 153
+	DetectorType_ElasticPath                      DetectorType = 154
+	DetectorType_Zeplin                           DetectorType = 155
+	DetectorType_Intercom                         DetectorType = 156
+	DetectorType_Mailmodo                         DetectorType = 157
+	DetectorType_CannyIo                          DetectorType = 158
+	DetectorType_Pipedrive                        DetectorType = 159
+	DetectorType_Vercel                           DetectorType = 160
+	DetectorType_PosthogApp                       DetectorType = 161
+	DetectorType_SinchMessage                     DetectorType = 162
+	DetectorType_Ayrshare                         DetectorType = 163
+	DetectorType_HelpCrunch                       DetectorType = 164
+	DetectorType_LiveAgent                        DetectorType = 165
+	DetectorType_Beamer                           DetectorType = 166
+	DetectorType_WeChatAppKey                     DetectorType = 167
+	DetectorType_LineMessaging                    DetectorType = 168
+	DetectorType_UberServerToken                  DetectorType = 169
+	DetectorType_AlgoliaAdminKey                  DetectorType = 170
+	DetectorType_FullContact                      DetectorType = 171
+	DetectorType_Mandrill                         DetectorType = 172
+	DetectorType_Flutterwave                      DetectorType = 173
+	DetectorType_MattermostPersonalToken          DetectorType = 174
+	DetectorType_Cloudant                         DetectorType = 175
+	DetectorType_LineNotify                       DetectorType = 176
+	DetectorType_LinearAPI                        DetectorType = 177
+	DetectorType_Ubidots                          DetectorType = 178
+	DetectorType_Anypoint                         DetectorType = 179
+	DetectorType_Dwolla                           DetectorType = 180
+	DetectorType_ArtifactoryAccessToken           DetectorType = 181
+	DetectorType_Surge                            DetectorType = 182
+	DetectorType_Sparkpost                        DetectorType = 183
+	DetectorType_GoCardless                       DetectorType = 184
+	DetectorType_Codacy                           DetectorType = 185
+	DetectorType_Kraken                           DetectorType = 186
+	DetectorType_Checkout                         DetectorType = 187
+	DetectorType_Kairos                           DetectorType = 188
+	DetectorType_ClockworkSMS                     DetectorType = 189
+	DetectorType_Atlassian                        DetectorType = 190
+	DetectorType_LaunchDarkly                     DetectorType = 191
+	DetectorType_Coveralls                        DetectorType = 192
+	DetectorType_Linode                           DetectorType = 193
+	DetectorType_WePay                            DetectorType = 194
+	DetectorType_PlanetScale                      DetectorType = 195
+	DetectorType_Doppler                          DetectorType = 196
+	DetectorType_Agora                            DetectorType = 197
+	DetectorType_Samsara                          DetectorType = 198
+	DetectorType_FrameIO                          DetectorType = 199
+	DetectorType_RubyGems                         DetectorType = 200
+	DetectorType_OpenAI                           DetectorType = 201
+	DetectorType_SurveySparrow                    DetectorType = 202
+	DetectorType_Simvoly                          DetectorType = 203
+	DetectorType_Survicate                        DetectorType = 204
+	DetectorType_Omnisend                         DetectorType = 205
+	DetectorType_Groovehq                         DetectorType = 206
+	DetectorType_Newsapi                          DetectorType = 207
+	DetectorType_Chatbot                          DetectorType = 208
+	DetectorType_ClickSendsms                     DetectorType = 209
+	DetectorType_Getgist                          DetectorType = 210
+	DetectorType_CustomerIO                       DetectorType = 211
+	DetectorType_ApiDeck                          DetectorType = 212
+	DetectorType_Nftport                          DetectorType = 213
+	DetectorType_Copper                           DetectorType = 214
+	DetectorType_Close                            DetectorType = 215
+	DetectorType_Myfreshworks                     DetectorType = 216
+	DetectorType_Salesflare                       DetectorType = 217
+	DetectorType_Webflow                          DetectorType = 218
+	DetectorType_Duda                             DetectorType = 219
+	DetectorType_Yext                             DetectorType = 220
+	DetectorType_ContentStack                     DetectorType = 221
+	DetectorType_Storyblok                        DetectorType = 222
+	DetectorType_GraphCMS                         DetectorType = 223
+	DetectorType_Checkmarket                      DetectorType = 224
+	DetectorType_Convertkit                       DetectorType = 225
+	DetectorType_CustomerGuru                     DetectorType = 226
+	DetectorType_Kaleyra                          DetectorType = 227
+	DetectorType_Mailerlite                       DetectorType = 228
+	DetectorType_Qualaroo                         DetectorType = 229
+	DetectorType_SatismeterProjectkey             DetectorType = 230
+	DetectorType_SatismeterWritekey               DetectorType = 231
+	DetectorType_Simplesat                        DetectorType = 232
+	DetectorType_SurveyAnyplace                   DetectorType = 233
+	DetectorType_SurveyBot                        DetectorType = 234
+	DetectorType_Webengage                        DetectorType = 235
+	DetectorType_ZonkaFeedback                    DetectorType = 236
+	DetectorType_Delighted                        DetectorType = 237
+	DetectorType_Feedier                          DetectorType = 238
+	DetectorType_Abbysale                         DetectorType = 239
+	DetectorType_Magnetic                         DetectorType = 240
+	DetectorType_Nytimes                          DetectorType = 241
+	DetectorType_Polygon                          DetectorType = 242
+	DetectorType_Powrbot                          DetectorType = 243
+	DetectorType_ProspectIO                       DetectorType = 244
+	DetectorType_Skrappio                         DetectorType = 245
+	DetectorType_Monday                           DetectorType = 246
+	DetectorType_Smartsheets                      DetectorType = 247
+	DetectorType_Wrike                            DetectorType = 248
+	DetectorType_Float                            DetectorType = 249
+	DetectorType_Imagekit                         DetectorType = 250
+	DetectorType_Integromat                       DetectorType = 251
+	DetectorType_Salesblink                       DetectorType = 252
+	DetectorType_Bored                            DetectorType = 253
+	DetectorType_Campayn                          DetectorType = 254
+	DetectorType_Clinchpad                        DetectorType = 255
+	DetectorType_CompanyHub                       DetectorType = 256
+	DetectorType_Debounce                         DetectorType = 257
+	DetectorType_Dyspatch                         DetectorType = 258
+	DetectorType_Guardianapi                      DetectorType = 259
+	DetectorType_Harvest                          DetectorType = 260
+	DetectorType_Moosend                          DetectorType = 261
+	DetectorType_OpenWeather                      DetectorType = 262
+	DetectorType_Siteleaf                         DetectorType = 263
+	DetectorType_Squarespace                      DetectorType = 264
+	DetectorType_FlowFlu                          DetectorType = 265
+	DetectorType_Nimble                           DetectorType = 266
+	DetectorType_LessAnnoyingCRM                  DetectorType = 267
+	DetectorType_Nethunt                          DetectorType = 268
+	DetectorType_Apptivo                          DetectorType = 269
+	DetectorType_CapsuleCRM                       DetectorType = 270
+	DetectorType_Insightly                        DetectorType = 271
+	DetectorType_Kylas                            DetectorType = 272
+	DetectorType_OnepageCRM                       DetectorType = 273
+	DetectorType_User                             DetectorType = 274
+	DetectorType_ProspectCRM                      DetectorType = 275
+	DetectorType_ReallySimpleSystems              DetectorType = 276
+	DetectorType_Airship                          DetectorType = 277
+	DetectorType_Artsy                            DetectorType = 278
+	DetectorType_Yandex                           DetectorType = 279
+	DetectorType_Clockify                         DetectorType = 280
+	DetectorType_Dnscheck                         DetectorType = 281
+	DetectorType_EasyInsight                      DetectorType = 282
+	DetectorType_Ethplorer                        DetectorType = 283
+	DetectorType_Everhour                         DetectorType = 284
+	DetectorType_Fulcrum                          DetectorType = 285
+	DetectorType_GeoIpifi                         DetectorType = 286
+	DetectorType_Jotform                          DetectorType = 287
+	DetectorType_Refiner                          DetectorType = 288
+	DetectorType_Timezoneapi                      DetectorType = 289
+	DetectorType_TogglTrack                       DetectorType = 290
+	DetectorType_Vpnapi                           DetectorType = 291
+	DetectorType_Workstack                        DetectorType = 292
+	DetectorType_Apollo                           DetectorType = 293
+	DetectorType_Eversign                         DetectorType = 294
+	DetectorType_Juro                             DetectorType = 295
+	DetectorType_KarmaCRM                         DetectorType = 296
+	DetectorType_Metrilo                          DetectorType = 297
+	DetectorType_Pandadoc                         DetectorType = 298
+	DetectorType_RevampCRM                        DetectorType = 299
+	DetectorType_Salescookie                      DetectorType = 300
+	DetectorType_Alconost                         DetectorType = 301
+	DetectorType_Blogger                          DetectorType = 302
+	DetectorType_Accuweather                      DetectorType = 303
+	DetectorType_Opengraphr                       DetectorType = 304
+	DetectorType_Rawg                             DetectorType = 305
+	DetectorType_Riotgames                        DetectorType = 306
+	DetectorType_RoninApp                         DetectorType = 307
+	DetectorType_Stormglass                       DetectorType = 308
+	DetectorType_Tomtom                           DetectorType = 309
+	DetectorType_Twitch                           DetectorType = 310
+	DetectorType_Documo                           DetectorType = 311
+	DetectorType_Cloudways                        DetectorType = 312
+	DetectorType_Veevavault                       DetectorType = 313
+	DetectorType_KiteConnect                      DetectorType = 314
+	DetectorType_ShopeeOpenPlatform               DetectorType = 315
+	DetectorType_TeamViewer                       DetectorType = 316
+	DetectorType_Bulbul                           DetectorType = 317
+	DetectorType_CentralStationCRM                DetectorType = 318
+	DetectorType_Teamgate                         DetectorType = 319
+	DetectorType_Axonaut                          DetectorType = 320
+	DetectorType_Tyntec                           DetectorType = 321
+	DetectorType_Appcues                          DetectorType = 322
+	DetectorType_Autoklose                        DetectorType = 323
+	DetectorType_Cloudplan                        DetectorType = 324
+	DetectorType_Dotmailer                        DetectorType = 325
+	DetectorType_GetEmail                         DetectorType = 326
+	DetectorType_GetEmails                        DetectorType = 327
+	DetectorType_Kontent                          DetectorType = 328
+	DetectorType_Leadfeeder                       DetectorType = 329
+	DetectorType_Raven                            DetectorType = 330
+	DetectorType_RocketReach                      DetectorType = 331
+	DetectorType_Uplead                           DetectorType = 332
+	DetectorType_Brandfetch                       DetectorType = 333
+	DetectorType_Clearbit                         DetectorType = 334
+	DetectorType_Crowdin                          DetectorType = 335
+	DetectorType_Mapquest                         DetectorType = 336
+	DetectorType_Noticeable                       DetectorType = 337
+	DetectorType_Onbuka                           DetectorType = 338
+	DetectorType_Todoist                          DetectorType = 339
+	DetectorType_Storychief                       DetectorType = 340
+	DetectorType_LinkedIn                         DetectorType = 341
+	DetectorType_YouSign                          DetectorType = 342
+	DetectorType_Docker                           DetectorType = 343
+	DetectorType_Telesign                         DetectorType = 344
+	DetectorType_Spoonacular                      DetectorType = 345
+	DetectorType_Aerisweather                     DetectorType = 346
+	DetectorType_Alphavantage                     DetectorType = 347
+	DetectorType_Imgur                            DetectorType = 348
+	DetectorType_Imagga                           DetectorType = 349
+	DetectorType_SMSApi                           DetectorType = 350
+	DetectorType_Distribusion                     DetectorType = 351
+	DetectorType_Blablabus                        DetectorType = 352
+	DetectorType_WordsApi                         DetectorType = 353
+	DetectorType_Currencylayer                    DetectorType = 354
+	DetectorType_Html2Pdf                         DetectorType = 355
+	DetectorType_IPGeolocation                    DetectorType = 356
+	DetectorType_Owlbot                           DetectorType = 357
+	DetectorType_Cloudmersive                     DetectorType = 358
+	DetectorType_Dynalist                         DetectorType = 359
+	DetectorType_ExchangeRateAPI                  DetectorType = 360
+	DetectorType_HolidayAPI                       DetectorType = 361
+	DetectorType_Ipapi                            DetectorType = 362
+	DetectorType_Marketstack                      DetectorType = 363
+	DetectorType_Nutritionix                      DetectorType = 364
+	DetectorType_Swell                            DetectorType = 365
+	DetectorType_ClickupPersonalToken             DetectorType = 366
+	DetectorType_Nitro                            DetectorType = 367
+	DetectorType_Rev                              DetectorType = 368
+	DetectorType_RunRunIt                         DetectorType = 369
+	DetectorType_Typeform                         DetectorType = 370
+	DetectorType_Mixpanel                         DetectorType = 371
+	DetectorType_Tradier                          DetectorType = 372
+	DetectorType_Verifier                         DetectorType = 373
+	DetectorType_Vouchery                         DetectorType = 374
+	DetectorType_Alegra                           DetectorType = 375
+	DetectorType_Audd                             DetectorType = 376
+	DetectorType_Baremetrics                      DetectorType = 377
+	DetectorType_Coinlib                          DetectorType = 378
+	DetectorType_ExchangeRatesAPI                 DetectorType = 379
+	DetectorType_CurrencyScoop                    DetectorType = 380
+	DetectorType_FXMarket                         DetectorType = 381
+	DetectorType_CurrencyCloud                    DetectorType = 382
+	DetectorType_GetGeoAPI                        DetectorType = 383
+	DetectorType_Abstract                         DetectorType = 384
+	DetectorType_Billomat                         DetectorType = 385
+	DetectorType_Dovico                           DetectorType = 386
+	DetectorType_Bitbar                           DetectorType = 387
+	DetectorType_Bugsnag                          DetectorType = 388
+	DetectorType_AssemblyAI                       DetectorType = 389
+	DetectorType_AdafruitIO                       DetectorType = 390
+	DetectorType_Apify                            DetectorType = 391
+	DetectorType_CoinGecko                        DetectorType = 392
+	DetectorType_CryptoCompare                    DetectorType = 393
+	DetectorType_Fullstory                        DetectorType = 394
+	DetectorType_HelloSign                        DetectorType = 395
+	DetectorType_Loyverse                         DetectorType = 396
+	DetectorType_NetCore                          DetectorType = 397
+	DetectorType_SauceLabs                        DetectorType = 398
+	DetectorType_AlienVault                       DetectorType = 399
+	DetectorType_Apiflash                         DetectorType = 401
+	DetectorType_Coinlayer                        DetectorType = 402
+	DetectorType_CurrentsAPI                      DetectorType = 403
+	DetectorType_DataGov                          DetectorType = 404
+	DetectorType_Enigma                           DetectorType = 405
+	DetectorType_FinancialModelingPrep            DetectorType = 406
+	DetectorType_Geocodio                         DetectorType = 407
+	DetectorType_HereAPI                          DetectorType = 408
+	DetectorType_Macaddress                       DetectorType = 409
+	DetectorType_OOPSpam                          DetectorType = 410
+	DetectorType_ProtocolsIO                      DetectorType = 411
+	DetectorType_ScraperAPI                       DetectorType = 412
+	DetectorType_SecurityTrails                   DetectorType = 413
+	DetectorType_TomorrowIO                       DetectorType = 414
+	DetectorType_WorldCoinIndex                   DetectorType = 415
+	DetectorType_FacePlusPlus                     DetectorType = 416
+	DetectorType_Voicegain                        DetectorType = 417
+	DetectorType_Deepgram                         DetectorType = 418
+	DetectorType_VisualCrossing                   DetectorType = 419
+	DetectorType_Finnhub                          DetectorType = 420
+	DetectorType_Tiingo                           DetectorType = 421
+	DetectorType_RingCentral                      DetectorType = 422
+	DetectorType_Finage                           DetectorType = 423
+	DetectorType_Edamam                           DetectorType = 424
+	DetectorType_HypeAuditor                      DetectorType = 425
+	DetectorType_Gengo                            DetectorType = 426
+	DetectorType_Front                            DetectorType = 427
+	DetectorType_Fleetbase                        DetectorType = 428
+	DetectorType_Bubble                           DetectorType = 429
+	DetectorType_Bannerbear                       DetectorType = 430
+	DetectorType_Adzuna                           DetectorType = 431
+	DetectorType_BitcoinAverage                   DetectorType = 432
+	DetectorType_CommerceJS                       DetectorType = 433
+	DetectorType_DetectLanguage                   DetectorType = 434
+	DetectorType_FakeJSON                         DetectorType = 435
+	DetectorType_Graphhopper                      DetectorType = 436
+	DetectorType_Lexigram                         DetectorType = 437
+	DetectorType_LinkPreview                      DetectorType = 438
+	DetectorType_Numverify                        DetectorType = 439
+	DetectorType_ProxyCrawl                       DetectorType = 440
+	DetectorType_ZipCodeAPI                       DetectorType = 441
+	DetectorType_Cometchat                        DetectorType = 442
+	DetectorType_Keygen                           DetectorType = 443
+	DetectorType_Mixcloud                         DetectorType = 444
+	DetectorType_TatumIO                          DetectorType = 445
+	DetectorType_Tmetric                          DetectorType = 446
+	DetectorType_Lastfm                           DetectorType = 447
+	DetectorType_Browshot                         DetectorType = 448
+	DetectorType_JSONbin                          DetectorType = 449
+	DetectorType_LocationIQ                       DetectorType = 450
+	DetectorType_ScreenshotAPI                    DetectorType = 451
+	DetectorType_WeatherStack                     DetectorType = 452
+	DetectorType_Amadeus                          DetectorType = 453
+	DetectorType_FourSquare                       DetectorType = 454
+	DetectorType_Flickr                           DetectorType = 455
+	DetectorType_ClickHelp                        DetectorType = 456
+	DetectorType_Ambee                            DetectorType = 457
+	DetectorType_Api2Cart                         DetectorType = 458
+	DetectorType_Hypertrack                       DetectorType = 459
+	DetectorType_KakaoTalk                        DetectorType = 460
+	DetectorType_RiteKit                          DetectorType = 461
+	DetectorType_Shutterstock                     DetectorType = 462
+	DetectorType_Text2Data                        DetectorType = 463
+	DetectorType_YouNeedABudget                   DetectorType = 464
+	DetectorType_Cricket                          DetectorType = 465
+	DetectorType_Filestack                        DetectorType = 466
+	DetectorType_Gyazo                            DetectorType = 467
+	DetectorType_Mavenlink                        DetectorType = 468
+	DetectorType_Sheety                           DetectorType = 469
+	DetectorType_Sportsmonk                       DetectorType = 470
+	DetectorType_Stockdata                        DetectorType = 471
+	DetectorType_Unsplash                         DetectorType = 472
+	DetectorType_Allsports                        DetectorType = 473
+	DetectorType_CalorieNinja                     DetectorType = 474
+	DetectorType_WalkScore                        DetectorType = 475
+	DetectorType_Strava                           DetectorType = 476
+	DetectorType_Cicero                           DetectorType = 477
+	DetectorType_IPQuality                        DetectorType = 478
+	DetectorType_ParallelDots                     DetectorType = 479
+	DetectorType_Roaring                          DetectorType = 480
+	DetectorType_Mailsac                          DetectorType = 481
+	DetectorType_Whoxy                            DetectorType = 482
+	DetectorType_WorldWeather                     DetectorType = 483
+	DetectorType_ApiFonica                        DetectorType = 484
+	DetectorType_Aylien                           DetectorType = 485
+	DetectorType_Geocode                          DetectorType = 486
+	DetectorType_IconFinder                       DetectorType = 487
+	DetectorType_Ipify                            DetectorType = 488
+	DetectorType_LanguageLayer                    DetectorType = 489
+	DetectorType_Lob                              DetectorType = 490
+	DetectorType_OnWaterIO                        DetectorType = 491
+	DetectorType_Pastebin                         DetectorType = 492
+	DetectorType_PdfLayer                         DetectorType = 493
+	DetectorType_Pixabay                          DetectorType = 494
+	DetectorType_ReadMe                           DetectorType = 495
+	DetectorType_VatLayer                         DetectorType = 496
+	DetectorType_VirusTotal                       DetectorType = 497
+	DetectorType_AirVisual                        DetectorType = 498
+	DetectorType_Currencyfreaks                   DetectorType = 499
+	DetectorType_Duffel                           DetectorType = 500
+	DetectorType_FlatIO                           DetectorType = 501
+	DetectorType_M3o                              DetectorType = 502
+	DetectorType_Mesibo                           DetectorType = 503
+	DetectorType_Openuv                           DetectorType = 504
+	DetectorType_Snipcart                         DetectorType = 505
+	DetectorType_Besttime                         DetectorType = 506
+	DetectorType_Happyscribe                      DetectorType = 507
+	DetectorType_Humanity                         DetectorType = 508
+	DetectorType_Impala                           DetectorType = 509
+	DetectorType_Loginradius                      DetectorType = 510
+	DetectorType_AutoPilot                        DetectorType = 511
+	DetectorType_Bitmex                           DetectorType = 512
+	DetectorType_ClustDoc                         DetectorType = 513
+	DetectorType_Messari                          DetectorType = 514
+	DetectorType_PdfShift                         DetectorType = 515
+	DetectorType_Poloniex                         DetectorType = 516
+	DetectorType_RestpackHtmlToPdfAPI             DetectorType = 517
+	DetectorType_RestpackScreenshotAPI            DetectorType = 518
+	DetectorType_ShutterstockOAuth                DetectorType = 519
+	DetectorType_SkyBiometry                      DetectorType = 520
+	DetectorType_AbuseIPDB                        DetectorType = 521
+	DetectorType_AletheiaApi                      DetectorType = 522
+	DetectorType_BlitApp                          DetectorType = 523
+	DetectorType_Censys                           DetectorType = 524
+	DetectorType_Cloverly                         DetectorType = 525
+	DetectorType_CountryLayer                     DetectorType = 526
+	DetectorType_FileIO                           DetectorType = 527
+	DetectorType_FlightApi                        DetectorType = 528
+	DetectorType_Geoapify                         DetectorType = 529
+	DetectorType_IPinfoDB                         DetectorType = 530
+	DetectorType_MediaStack                       DetectorType = 531
+	DetectorType_NasdaqDataLink                   DetectorType = 532
+	DetectorType_OpenCageData                     DetectorType = 533
+	DetectorType_Paymongo                         DetectorType = 534
+	DetectorType_PositionStack                    DetectorType = 535
+	DetectorType_Rebrandly                        DetectorType = 536
+	DetectorType_ScreenshotLayer                  DetectorType = 537
+	DetectorType_Stytch                           DetectorType = 538
+	DetectorType_Unplugg                          DetectorType = 539
+	DetectorType_UPCDatabase                      DetectorType = 540
+	DetectorType_UserStack                        DetectorType = 541
+	DetectorType_Geocodify                        DetectorType = 542
+	DetectorType_Newscatcher                      DetectorType = 543
+	DetectorType_Nicereply                        DetectorType = 544
+	DetectorType_Partnerstack                     DetectorType = 545
+	DetectorType_Route4me                         DetectorType = 546
+	DetectorType_Scrapeowl                        DetectorType = 547
+	DetectorType_ScrapingDog                      DetectorType = 548
+	DetectorType_Streak                           DetectorType = 549
+	DetectorType_Veriphone                        DetectorType = 550
+	DetectorType_Webscraping                      DetectorType = 551
+	DetectorType_Zenscrape                        DetectorType = 552
+	DetectorType_Zenserp                          DetectorType = 553
+	DetectorType_CoinApi                          DetectorType = 554
+	DetectorType_Gitter                           DetectorType = 555
+	DetectorType_Host                             DetectorType = 556
+	DetectorType_Iexcloud                         DetectorType = 557
+	DetectorType_Restpack                         DetectorType = 558
+	DetectorType_ScraperBox                       DetectorType = 559
+	DetectorType_ScrapingAnt                      DetectorType = 560
+	DetectorType_SerpStack                        DetectorType = 561
+	DetectorType_SmartyStreets                    DetectorType = 562
+	DetectorType_TicketMaster                     DetectorType = 563
+	DetectorType_AviationStack                    DetectorType = 564
+	DetectorType_BombBomb                         DetectorType = 565
+	DetectorType_Commodities                      DetectorType = 566
+	DetectorType_Dfuse                            DetectorType = 567
+	DetectorType_EdenAI                           DetectorType = 568
+	DetectorType_Glassnode                        DetectorType = 569
+	DetectorType_Guru                             DetectorType = 570
+	DetectorType_Hive                             DetectorType = 571
+	DetectorType_Hiveage                          DetectorType = 572
+	DetectorType_Kickbox                          DetectorType = 573
+	DetectorType_Passbase                         DetectorType = 574
+	DetectorType_PostageApp                       DetectorType = 575
+	DetectorType_PureStake                        DetectorType = 576
+	DetectorType_Qubole                           DetectorType = 577
+	DetectorType_CarbonInterface                  DetectorType = 578
+	DetectorType_Intrinio                         DetectorType = 579
+	DetectorType_QuickMetrics                     DetectorType = 580
+	DetectorType_ScrapeStack                      DetectorType = 581
+	DetectorType_TechnicalAnalysisApi             DetectorType = 582
+	DetectorType_Urlscan                          DetectorType = 583
+	DetectorType_BaseApiIO                        DetectorType = 584
+	DetectorType_DailyCO                          DetectorType = 585
+	DetectorType_TLy                              DetectorType = 586
+	DetectorType_Shortcut                         DetectorType = 587
+	DetectorType_Appfollow                        DetectorType = 588
+	DetectorType_Thinkific                        DetectorType = 589
+	DetectorType_Feedly                           DetectorType = 590
+	DetectorType_Stitchdata                       DetectorType = 591
+	DetectorType_Fetchrss                         DetectorType = 592
+	DetectorType_Signupgenius                     DetectorType = 593
+	DetectorType_Signaturit                       DetectorType = 594
+	DetectorType_Optimizely                       DetectorType = 595
+	DetectorType_OcrSpace                         DetectorType = 596
+	DetectorType_WeatherBit                       DetectorType = 597
+	DetectorType_BuddyNS                          DetectorType = 598
+	DetectorType_ZipAPI                           DetectorType = 599
+	DetectorType_ZipBooks                         DetectorType = 600
+	DetectorType_Onedesk                          DetectorType = 601
+	DetectorType_Bugherd                          DetectorType = 602
+	DetectorType_Blazemeter                       DetectorType = 603
+	DetectorType_Autodesk                         DetectorType = 604
+	DetectorType_Tru                              DetectorType = 605
+	DetectorType_UnifyID                          DetectorType = 606
+	DetectorType_Trimble                          DetectorType = 607
+	DetectorType_Smooch                           DetectorType = 608
+	DetectorType_Semaphore                        DetectorType = 609
+	DetectorType_Telnyx                           DetectorType = 610
+	DetectorType_Signalwire                       DetectorType = 611
+	DetectorType_Textmagic                        DetectorType = 612
+	DetectorType_Serphouse                        DetectorType = 613
+	DetectorType_Planyo                           DetectorType = 614
+	DetectorType_Simplybook                       DetectorType = 615
+	DetectorType_Vyte                             DetectorType = 616
+	DetectorType_Nylas                            DetectorType = 617
+	DetectorType_Squareup                         DetectorType = 618
+	DetectorType_Dandelion                        DetectorType = 619
+	DetectorType_DataFire                         DetectorType = 620
+	DetectorType_DeepAI                           DetectorType = 621
+	DetectorType_MeaningCloud                     DetectorType = 622
+	DetectorType_NeutrinoApi                      DetectorType = 623
+	DetectorType_Storecove                        DetectorType = 624
+	DetectorType_Shipday                          DetectorType = 625
+	DetectorType_Sentiment                        DetectorType = 626
+	DetectorType_StreamChatMessaging              DetectorType = 627
+	DetectorType_TeamworkCRM                      DetectorType = 628
+	DetectorType_TeamworkDesk                     DetectorType = 629
+	DetectorType_TeamworkSpaces                   DetectorType = 630
+	DetectorType_TheOddsApi                       DetectorType = 631
+	DetectorType_Apacta                           DetectorType = 632
+	DetectorType_GetSandbox                       DetectorType = 633
+	DetectorType_Happi                            DetectorType = 634
+	DetectorType_Oanda                            DetectorType = 635
+	DetectorType_FastForex                        DetectorType = 636
+	DetectorType_APIMatic                         DetectorType = 637
+	DetectorType_VersionEye                       DetectorType = 638
+	DetectorType_EagleEyeNetworks                 DetectorType = 639
+	DetectorType_ThousandEyes                     DetectorType = 640
+	DetectorType_SelectPDF                        DetectorType = 641
+	DetectorType_Flightstats                      DetectorType = 642
+	DetectorType_ChecIO                           DetectorType = 643
+	DetectorType_Manifest                         DetectorType = 644
+	DetectorType_ApiScience                       DetectorType = 645
+	DetectorType_AppSynergy                       DetectorType = 646
+	DetectorType_Caflou                           DetectorType = 647
+	DetectorType_Caspio                           DetectorType = 648
+	DetectorType_ChecklyHQ                        DetectorType = 649
+	DetectorType_CloudElements                    DetectorType = 650
+	DetectorType_DronaHQ                          DetectorType = 651
+	DetectorType_Enablex                          DetectorType = 652
+	DetectorType_Fmfw                             DetectorType = 653
+	DetectorType_GoodDay                          DetectorType = 654
+	DetectorType_Luno                             DetectorType = 655
+	DetectorType_Meistertask                      DetectorType = 656
+	DetectorType_Mindmeister                      DetectorType = 657
+	DetectorType_PeopleDataLabs                   DetectorType = 658
+	DetectorType_ScraperSite                      DetectorType = 659
+	DetectorType_Scrapfly                         DetectorType = 660
+	DetectorType_SimplyNoted                      DetectorType = 661
+	DetectorType_TravelPayouts                    DetectorType = 662
+	DetectorType_WebScraper                       DetectorType = 663
+	DetectorType_Convier                          DetectorType = 664
+	DetectorType_Courier                          DetectorType = 665
+	DetectorType_Ditto                            DetectorType = 666
+	DetectorType_Findl                            DetectorType = 667
+	DetectorType_Lendflow                         DetectorType = 668
+	DetectorType_Moderation                       DetectorType = 669
+	DetectorType_Opendatasoft                     DetectorType = 670
+	DetectorType_Podio                            DetectorType = 671
+	DetectorType_Rockset                          DetectorType = 672
+	DetectorType_Rownd                            DetectorType = 673
+	DetectorType_Shotstack                        DetectorType = 674
+	DetectorType_Swiftype                         DetectorType = 675
+	DetectorType_Twitter                          DetectorType = 676
+	DetectorType_Honey                            DetectorType = 677
+	DetectorType_Freshdesk                        DetectorType = 678
+	DetectorType_Upwave                           DetectorType = 679
+	DetectorType_Fountain                         DetectorType = 680
+	DetectorType_Freshbooks                       DetectorType = 681
+	DetectorType_Mite                             DetectorType = 682
+	DetectorType_Deputy                           DetectorType = 683
+	DetectorType_Beebole                          DetectorType = 684
+	DetectorType_Cashboard                        DetectorType = 685
+	DetectorType_Kanban                           DetectorType = 686
+	DetectorType_Worksnaps                        DetectorType = 687
+	DetectorType_MyIntervals                      DetectorType = 688
+	DetectorType_InvoiceOcean                     DetectorType = 689
+	DetectorType_Sherpadesk                       DetectorType = 690
+	DetectorType_Mrticktock                       DetectorType = 691
+	DetectorType_Chatfule                         DetectorType = 692
+	DetectorType_Aeroworkflow                     DetectorType = 693
+	DetectorType_Emailoctopus                     DetectorType = 694
+	DetectorType_Fusebill                         DetectorType = 695
+	DetectorType_Geckoboard                       DetectorType = 696
+	DetectorType_Gosquared                        DetectorType = 697
+	DetectorType_Moonclerk                        DetectorType = 698
+	DetectorType_Paymoapp                         DetectorType = 699
+	DetectorType_Mixmax                           DetectorType = 700
+	DetectorType_Processst                        DetectorType = 701
+	DetectorType_Repairshopr                      DetectorType = 702
+	DetectorType_Goshippo                         DetectorType = 703
+	DetectorType_Sigopt                           DetectorType = 704
+	DetectorType_Sugester                         DetectorType = 705
+	DetectorType_Viewneo                          DetectorType = 706
+	DetectorType_BoostNote                        DetectorType = 707
+	DetectorType_CaptainData                      DetectorType = 708
+	DetectorType_Checkvist                        DetectorType = 709
+	DetectorType_Cliengo                          DetectorType = 710
+	DetectorType_Cloze                            DetectorType = 711
+	DetectorType_FormIO                           DetectorType = 712
+	DetectorType_FormBucket                       DetectorType = 713
+	DetectorType_GoCanvas                         DetectorType = 714
+	DetectorType_MadKudu                          DetectorType = 715
+	DetectorType_NozbeTeams                       DetectorType = 716
+	DetectorType_Papyrs                           DetectorType = 717
+	DetectorType_SuperNotesAPI                    DetectorType = 718
+	DetectorType_Tallyfy                          DetectorType = 719
+	DetectorType_ZenkitAPI                        DetectorType = 720
+	DetectorType_CloudImage                       DetectorType = 721
+	DetectorType_UploadCare                       DetectorType = 722
+	DetectorType_Borgbase                         DetectorType = 723
+	DetectorType_Pipedream                        DetectorType = 724
+	DetectorType_Sirv                             DetectorType = 725
+	DetectorType_Diffbot                          DetectorType = 726
+	DetectorType_EightxEight                      DetectorType = 727
+	DetectorType_Sendoso                          DetectorType = 728
+	DetectorType_Printfection                     DetectorType = 729
+	DetectorType_Authorize                        DetectorType = 730
+	DetectorType_PandaScore                       DetectorType = 731
+	DetectorType_Paymo                            DetectorType = 732
+	DetectorType_AvazaPersonalAccessToken         DetectorType = 733
+	DetectorType_PlanviewLeanKit                  DetectorType = 734
+	DetectorType_Livestorm                        DetectorType = 735
+	DetectorType_KuCoin                           DetectorType = 736
+	DetectorType_MetaAPI                          DetectorType = 737
+	DetectorType_NiceHash                         DetectorType = 738
+	DetectorType_CexIO                            DetectorType = 739
+	DetectorType_Klipfolio                        DetectorType = 740
+	DetectorType_Dynatrace                        DetectorType = 741
+	DetectorType_MollieAPIKey                     DetectorType = 742
+	DetectorType_MollieAccessToken                DetectorType = 743
+	DetectorType_BasisTheory                      DetectorType = 744
+	DetectorType_Nordigen                         DetectorType = 745
+	DetectorType_FlagsmithEnvironmentKey          DetectorType = 746
+	DetectorType_FlagsmithToken                   DetectorType = 747
+	DetectorType_Mux                              DetectorType = 748
+	DetectorType_Column                           DetectorType = 749
+	DetectorType_Sendbird                         DetectorType = 750
+	DetectorType_SendbirdOrganizationAPI          DetectorType = 751
+	DetectorType_Midise                           DetectorType = 752
+	DetectorType_Mockaroo                         DetectorType = 753
+	DetectorType_Image4                           DetectorType = 754
+	DetectorType_Pinata                           DetectorType = 755
+	DetectorType_BrowserStack                     DetectorType = 756
+	DetectorType_CrossBrowserTesting              DetectorType = 757
+	DetectorType_Loadmill                         DetectorType = 758
+	DetectorType_TestingBot                       DetectorType = 759
+	DetectorType_KnapsackPro                      DetectorType = 760
+	DetectorType_Qase                             DetectorType = 761
+	DetectorType_Dareboost                        DetectorType = 762
+	DetectorType_GTMetrix                         DetectorType = 763
+	DetectorType_Holistic                         DetectorType = 764
+	DetectorType_Parsers                          DetectorType = 765
+	DetectorType_ScrutinizerCi                    DetectorType = 766
+	DetectorType_SonarCloud                       DetectorType = 767
+	DetectorType_APITemplate                      DetectorType = 768
+	DetectorType_ConversionTools                  DetectorType = 769
+	DetectorType_CraftMyPDF                       DetectorType = 770
+	DetectorType_ExportSDK                        DetectorType = 771
+	DetectorType_GlitterlyAPI                     DetectorType = 772
+	DetectorType_Hybiscus                         DetectorType = 773
+	DetectorType_Miro                             DetectorType = 774
+	DetectorType_Statuspage                       DetectorType = 775
+	DetectorType_Statuspal                        DetectorType = 776
+	DetectorType_Teletype                         DetectorType = 777
+	DetectorType_TimeCamp                         DetectorType = 778
+	DetectorType_Userflow                         DetectorType = 779
+	DetectorType_Wistia                           DetectorType = 780
+	DetectorType_SportRadar                       DetectorType = 781
+	DetectorType_UptimeRobot                      DetectorType = 782
+	DetectorType_Codequiry                        DetectorType = 783
+	DetectorType_ExtractorAPI                     DetectorType = 784
+	DetectorType_Signable                         DetectorType = 785
+	DetectorType_MagicBell                        DetectorType = 786
+	DetectorType_Stormboard                       DetectorType = 787
+	DetectorType_Apilayer                         DetectorType = 788
+	DetectorType_Disqus                           DetectorType = 789
+	DetectorType_Woopra                           DetectorType = 790
+	DetectorType_Paperform                        DetectorType = 791
+	DetectorType_Gumroad                          DetectorType = 792
+	DetectorType_Paydirtapp                       DetectorType = 793
+	DetectorType_Detectify                        DetectorType = 794
+	DetectorType_Statuscake                       DetectorType = 795
+	DetectorType_Jumpseller                       DetectorType = 796
+	DetectorType_LunchMoney                       DetectorType = 797
+	DetectorType_Rosette                          DetectorType = 798
+	DetectorType_Yelp                             DetectorType = 799
+	DetectorType_Atera                            DetectorType = 800
+	DetectorType_EcoStruxureIT                    DetectorType = 801
+	DetectorType_Aha                              DetectorType = 802
+	DetectorType_Parsehub                         DetectorType = 803
+	DetectorType_PackageCloud                     DetectorType = 804
+	DetectorType_Cloudsmith                       DetectorType = 805
+	DetectorType_Flowdash                         DetectorType = 806
+	DetectorType_Flowdock                         DetectorType = 807
+	DetectorType_Fibery                           DetectorType = 808
+	DetectorType_Typetalk                         DetectorType = 809
+	DetectorType_VoodooSMS                        DetectorType = 810
+	DetectorType_ZulipChat                        DetectorType = 811
+	DetectorType_Formcraft                        DetectorType = 812
+	DetectorType_Iexapis                          DetectorType = 813
+	DetectorType_Reachmail                        DetectorType = 814
+	DetectorType_Chartmogul                       DetectorType = 815
+	DetectorType_Appointed                        DetectorType = 816
+	DetectorType_Wit                              DetectorType = 817
+	DetectorType_RechargePayments                 DetectorType = 818
+	DetectorType_Diggernaut                       DetectorType = 819
+	DetectorType_MonkeyLearn                      DetectorType = 820
+	DetectorType_Duply                            DetectorType = 821
+	DetectorType_Postbacks                        DetectorType = 822
+	DetectorType_Collect2                         DetectorType = 823
+	DetectorType_ZenRows                          DetectorType = 824
+	DetectorType_Zipcodebase                      DetectorType = 825
+	DetectorType_Tefter                           DetectorType = 826
+	DetectorType_Twist                            DetectorType = 827
+	DetectorType_BraintreePayments                DetectorType = 828
+	DetectorType_CloudConvert                     DetectorType = 829
+	DetectorType_Grafana                          DetectorType = 830
+	DetectorType_ConvertApi                       DetectorType = 831
+	DetectorType_Transferwise                     DetectorType = 832
+	DetectorType_Bulksms                          DetectorType = 833
+	DetectorType_Databox                          DetectorType = 834
+	DetectorType_Onesignal                        DetectorType = 835
+	DetectorType_Rentman                          DetectorType = 836
+	DetectorType_Parseur                          DetectorType = 837
+	DetectorType_Docparser                        DetectorType = 838
+	DetectorType_Formsite                         DetectorType = 839
+	DetectorType_Tickettailor                     DetectorType = 840
+	DetectorType_Lemlist                          DetectorType = 841
+	DetectorType_Prodpad                          DetectorType = 842
+	DetectorType_Formstack                        DetectorType = 843
+	DetectorType_Codeclimate                      DetectorType = 844
+	DetectorType_Codemagic                        DetectorType = 845
+	DetectorType_Vbout                            DetectorType = 846
+	DetectorType_Nightfall                        DetectorType = 847
+	DetectorType_FlightLabs                       DetectorType = 848
+	DetectorType_SpeechTextAI                     DetectorType = 849
+	DetectorType_PollsAPI                         DetectorType = 850
+	DetectorType_SimFin                           DetectorType = 851
+	DetectorType_Scalr                            DetectorType = 852
+	DetectorType_Kanbantool                       DetectorType = 853
+	DetectorType_Brightlocal                      DetectorType = 854
+	DetectorType_Hotwire                          DetectorType = 855
+	DetectorType_Instabot                         DetectorType = 856
+	DetectorType_Timekit                          DetectorType = 857
+	DetectorType_Interseller                      DetectorType = 858
+	DetectorType_Mojohelpdesk                     DetectorType = 859
+	DetectorType_Createsend                       DetectorType = 860
+	DetectorType_Getresponse                      DetectorType = 861
+	DetectorType_Dynadot                          DetectorType = 862
+	DetectorType_Demio                            DetectorType = 863
+	DetectorType_Tokeet                           DetectorType = 864
+	DetectorType_Myexperiment                     DetectorType = 865
+	DetectorType_Copyscape                        DetectorType = 866
+	DetectorType_Besnappy                         DetectorType = 867
+	DetectorType_Salesmate                        DetectorType = 868
+	DetectorType_Heatmapapi                       DetectorType = 869
+	DetectorType_Websitepulse                     DetectorType = 870
+	DetectorType_Uclassify                        DetectorType = 871
+	DetectorType_AWSSessionKey                    DetectorType = 872
+	DetectorType_AzureStorageKey                  DetectorType = 873
+	DetectorType_AzureSASToken                    DetectorType = 874
+	DetectorType_Postgres                         DetectorType = 875
+	DetectorType_MySQL                            DetectorType = 876
+	DetectorType_MongoDB                          DetectorType = 877
+	DetectorType_Redis                            DetectorType = 878
+	DetectorType_SQLServer                        DetectorType = 879
+	DetectorType_GCPApplicationDefaultCredentials DetectorType = 881
+	DetectorType_PyPI                             DetectorType = 882
+	DetectorType_Anthropic                        DetectorType = 883
+	DetectorType_Cohere                           DetectorType = 884
+	DetectorType_HuggingFace                      DetectorType = 885
+	DetectorType_Vault                            DetectorType = 886
+	DetectorType_Consul                           DetectorType = 887
+	DetectorType_Shopify                          DetectorType = 888
+	DetectorType_GitCredentials                   DetectorType = 889
+	DetectorType_Netrc                            DetectorType = 890
+	DetectorType_CIConfig                         DetectorType = 891
+	DetectorType_IaC                              DetectorType = 892
+	DetectorType_Combolist                        DetectorType = 893
+	DetectorType_CustomRegex                      DetectorType = 894
 )
 
 // Enum value maps for DetectorType.
@@ -1764,876 +1786,920 @@ var (
 		869: "Heatmapapi",
 		870: "Websitepulse",
 		871: "Uclassify",
+		872: "AWSSessionKey",
+		873: "AzureStorageKey",
+		874: "AzureSASToken",
+		875: "Postgres",
+		876: "MySQL",
+		877: "MongoDB",
+		878: "Redis",
+		879: "SQLServer",
+		881: "GCPApplicationDefaultCredentials",
+		882: "PyPI",
+		883: "Anthropic",
+		884: "Cohere",
+		885: "HuggingFace",
+		886: "Vault",
+		887: "Consul",
+		888: "Shopify",
+		889: "GitCredentials",
+		890: "Netrc",
+		891: "CIConfig",
+		892: "IaC",
+		893: "Combolist",
+		894: "CustomRegex",
 	}
 	DetectorType_value = map[string]int32{
-		"Alibaba":                       0,
-		"AMQP":                          1,
-		"AWS":                           2,
-		"Azure":                         3,
-		"Circle":                        4,
-		"Coinbase":                      5,
-		"GCP":                           6,
-		"Generic":                       7,
-		"Github":                        8,
-		"Gitlab":                        9,
-		"JDBC":                          10,
-		"RazorPay":                      11,
-		"SendGrid":                      12,
-		"Slack":                         13,
-		"Square":                        14,
-		"PrivateKey":                    15,
-		"Stripe":                        16,
-		"URI":                           17,
-		"Dropbox":                       18,
-		"Heroku":                        19,
-		"Mailchimp":                     20,
-		"Okta":                          21,
-		"OneLogin":                      22,
-		"PivotalTracker":                23,
-		"SquareApp":                     25,
-		"Twilio":                        26,
-		"Test":                          27,
-		"TravisCI":                      29,
-		"SlackWebhook":                  30,
-		"PaypalOauth":                   31,
-		"PagerDutyApiKey":               32,
-		"Firebase":                      33,
-		"Mailgun":                       34,
-		"HubSpot":                       35,
-		"GitHubApp":                     36,
-		"CircleCI":                      37,
-		"WpEngine":                      38,
-		"DatadogToken":                  39,
-		"FacebookOAuth":                 40,
-		"AsanaPersonalAccessToken":      41,
-		"AmplitudeApiKey":               42,
-		"BitLyAccessToken":              43,
-		"CalendlyApiKey":                44,
-		"ZapierWebhook":                 45,
-		"YoutubeApiKey":                 46,
-		"SalesforceOauth2":              47,
-		"TwitterApiSecret":              48,
-		"NpmToken":                      49,
-		"NewRelicPersonalApiKey":        50,
-		"AirtableApiKey":                51,
-		"AkamaiToken":                   52,
-		"AmazonMWS":                     53,
-		"KubeConfig":                    54,
-		"Auth0oauth":                    55,
-		"Bitfinex":                      56,
-		"Clarifai":                      57,
-		"CloudflareGlobalApiKey":        58,
-		"CloudflareCaKey":               59,
-		"Confluent":                     60,
-		"ContentfulDelivery":            61,
-		"DatabricksToken":               62,
-		"DigitalOceanSpaces":            63,
-		"DigitalOceanToken":             64,
-		"DiscordBotToken":               65,
-		"DiscordWebhook":                66,
-		"EtsyApiKey":                    67,
-		"FastlyPersonalToken":           68,
-		"GoogleOauth2":                  69,
-		"ReCAPTCHA":                     70,
-		"GoogleApiKey":                  71,
-		"Hunter":                        72,
-		"IbmCloudUserKey":               73,
-		"Netlify":                       74,
-		"Vonage":                        75,
-		"EquinixOauth":                  76,
-		"Paystack":                      77,
-		"PlaidToken":                    78,
-		"PlaidKey":                      79,
-		"Plivo":                         80,
-		"Postmark":                      81,
-		"PubNubPublishKey":              82,
-		"PubNubSubscriptionKey":         83,
-		"PusherChannelKey":              84,
-		"ScalewayKey":                   85,
-		"SendinBlueV2":                  86,
-		"SentryToken":                   87,
-		"ShodanKey":                     88,
-		"SnykKey":                       89,
-		"SpotifyKey":                    90,
-		"TelegramBotToken":              91,
-		"TencentCloudKey":               92,
-		"TerraformCloudPersonalToken":   93,
-		"TrelloApiKey":                  94,
-		"ZendeskApi":                    95,
-		"MaxMindLicense":                96,
-		"AirtableMetadataApiKey":        97,
-		"AsanaOauth":                    98,
-		"RapidApi":                      99,
-		"CloudflareApiToken":            100,
-		"Webex":                         101,
-		"FirebaseCloudMessaging":        102,
-		"ContentfulPersonalAccessToken": 103,
-		"MapBox":                        104,
-		"MailJetBasicAuth":              105,
-		"MailJetSMS":                    106,
-		"HubSpotApiKey":                 107,
-		"HubSpotOauth":                  108,
-		"SslMate":                       109,
-		"Auth0ManagementApiToken":       110,
-		"MessageBird":                   111,
-		"ElasticEmail":                  112,
-		"FigmaPersonalAccessToken":      113,
-		"MicrosoftTeamsWebhook":         114,
-		"GitHubOld":                     115,
-		"VultrApiKey":                   116,
-		"Pepipost":                      117,
-		"Postman":                       118,
-		"CloudsightKey":                 119,
-		"JiraToken":                     120,
-		"NexmoApiKey":                   121,
-		"SegmentApiKey":                 122,
-		"SumoLogicKey":                  123,
-		"PushBulletApiKey":              124,
-		"AirbrakeProjectKey":            125,
-		"AirbrakeUserKey":               126,
-		"PendoIntegrationKey":           127,
-		"SplunkOberservabilityToken":    128,
-		"LokaliseToken":                 129,
-		"Calendarific":                  130,
-		"Jumpcloud":                     131,
-		"IpStack":                       133,
-		"Notion":                        134,
-		"DroneCI":                       135,
-		"AdobeIO":                       136,
-		"TwelveData":                    137,
-		"D7Network":                     138,
-		"ScrapingBee":                   139,
-		"KeenIO":                        140,
-		"Wakatime":                      141,
-		"Buildkite":                     142,
-		"Verimail":                      143,
-		"Zerobounce":                    144,
-		"Mailboxlayer":                  145,
-		"Fastspring":                    146,
-		"Paddle":                        147,
-		"Sellfy":                        148,
-		"FixerIO":                       149,
-		"ButterCMS":                     150,
-		"Taxjar":                        151,
-		"Avalara":                       152,
-		"Helpscout":                     153,
-		"ElasticPath":                   154,
-		"Zeplin":                        155,
-		"Intercom":                      156,
-		"Mailmodo":                      157,
-		"CannyIo":                       158,
-		"Pipedrive":                     159,
-		"Vercel":                        160,
-		"PosthogApp":                    161,
-		"SinchMessage":                  162,
-		"Ayrshare":                      163,
-		"HelpCrunch":                    164,
-		"LiveAgent":                     165,
-		"Beamer":                        166,
-		"WeChatAppKey":                  167,
-		"LineMessaging":                 168,
-		"UberServerToken":               169,
-		"AlgoliaAdminKey":               170,
-		"FullContact":                   171,
-		"Mandrill":                      172,
-		"Flutterwave":                   173,
-		"MattermostPersonalToken":       174,
-		"Cloudant":                      175,
-		"LineNotify":                    176,
-		"LinearAPI":                     177,
-		"Ubidots":                       178,
-		"Anypoint":                      179,
-		"Dwolla":                        180,
-		"ArtifactoryAccessToken":        181,
-		"Surge":                         182,
-		"Sparkpost":                     183,
-		"GoCardless":                    184,
-		"Codacy":                        185,
-		"Kraken":                        186,
-		"Checkout":                      187,
-		"Kairos":                        188,
-		"ClockworkSMS":                  189,
-		"Atlassian":                     190,
-		"LaunchDarkly":                  191,
-		"Coveralls":                     192,
-		"Linode":                        193,
-		"WePay":                         194,
-		"PlanetScale":                   195,
-		"Doppler":                       196,
-		"Agora":                         197,
-		"Samsara":                       198,
-		"FrameIO":                       199,
-		"RubyGems":                      200,
-		"OpenAI":                        201,
-		"SurveySparrow":                 202,
-		"Simvoly":                       203,
-		"Survicate":                     204,
-		"Omnisend":                      205,
-		"Groovehq":                      206,
-		"Newsapi":                       207,
-		"Chatbot":                       208,
-		"ClickSendsms":                  209,
-		"Getgist":                       210,
-		"CustomerIO":                    211,
-		"ApiDeck":                       212,
-		"Nftport":                       213,
-		"Copper":                        214,
-		"Close":                         215,
-		"Myfreshworks":                  216,
-		"Salesflare":                    217,
-		"Webflow":                       218,
-		"Duda":                          219,
-		"Yext":                          220,
-		"ContentStack":                  221,
-		"Storyblok":                     222,
-		"GraphCMS":                      223,
-		"Checkmarket":                   224,
-		"Convertkit":                    225,
-		"CustomerGuru":                  226,
-		"Kaleyra":                       227,
-		"Mailerlite":                    228,
-		"Qualaroo":                      229,
-		"SatismeterProjectkey":          230,
-		"SatismeterWritekey":            231,
-		"Simplesat":                     232,
-		"SurveyAnyplace":                233,
-		"SurveyBot":                     234,
-		"Webengage":                     235,
-		"ZonkaFeedback":                 236,
-		"Delighted":                     237,
-		"Feedier":                       238,
-		"Abbysale":                      239,
-		"Magnetic":                      240,
-		"Nytimes":                       241,
-		"Polygon":                       242,
-		"Powrbot":                       243,
-		"ProspectIO":                    244,
-		"Skrappio":                      245,
-		"Monday":                        246,
-		"Smartsheets":                   247,
-		"Wrike":                         248,
-		"Float":                         249,
-		"Imagekit":                      250,
-		"Integromat":                    251,
-		"Salesblink":                    252,
-		"Bored":                         253,
-		"Campayn":                       254,
-		"Clinchpad":                     255,
-		"CompanyHub":                    256,
-		"Debounce":                      257,
-		"Dyspatch":                      258,
-		"Guardianapi":                   259,
-		"Harvest":                       260,
-		"Moosend":                       261,
-		"OpenWeather":                   262,
-		"Siteleaf":                      263,
-		"Squarespace":                   264,
-		"FlowFlu":                       265,
-		"Nimble":                        266,
-		"LessAnnoyingCRM":               267,
-		"Nethunt":                       268,
-		"Apptivo":                       269,
-		"CapsuleCRM":                    270,
-		"Insightly":                     271,
-		"Kylas":                         272,
-		"OnepageCRM":                    273,
-		"User":                          274,
-		"ProspectCRM":                   275,
-		"ReallySimpleSystems":           276,
-		"Airship":                       277,
-		"Artsy":                         278,
-		"Yandex":                        279,
-		"Clockify":                      280,
-		"Dnscheck":                      281,
-		"EasyInsight":                   282,
-		"Ethplorer":                     283,
-		"Everhour":                      284,
-		"Fulcrum":                       285,
-		"GeoIpifi":                      286,
-		"Jotform":                       287,
-		"Refiner":                       288,
-		"Timezoneapi":                   289,
-		"TogglTrack":                    290,
-		"Vpnapi":                        291,
-		"Workstack":                     292,
-		"Apollo":                        293,
-		"Eversign":                      294,
-		"Juro":                          295,
-		"KarmaCRM":                      296,
-		"Metrilo":                       297,
-		"Pandadoc":                      298,
-		"RevampCRM":                     299,
-		"Salescookie":                   300,
-		"Alconost":                      301,
-		"Blogger":                       302,
-		"Accuweather":                   303,
-		"Opengraphr":                    304,
-		"Rawg":                          305,
-		"Riotgames":                     306,
-		"RoninApp":                      307,
-		"Stormglass":                    308,
-		"Tomtom":                        309,
-		"Twitch":                        310,
-		"Documo":                        311,
-		"Cloudways":                     312,
-		"Veevavault":                    313,
-		"KiteConnect":                   314,
-		"ShopeeOpenPlatform":            315,
-		"TeamViewer":                    316,
-		"Bulbul":                        317,
-		"CentralStationCRM":             318,
-		"Teamgate":                      319,
-		"Axonaut":                       320,
-		"Tyntec":                        321,
-		"Appcues":                       322,
-		"Autoklose":                     323,
-		"Cloudplan":                     324,
-		"Dotmailer":                     325,
-		"GetEmail":                      326,
-		"GetEmails":                     327,
-		"Kontent":                       328,
-		"Leadfeeder":                    329,
-		"Raven":                         330,
-		"RocketReach":                   331,
-		"Uplead":                        332,
-		"Brandfetch":                    333,
-		"Clearbit":                      334,
-		"Crowdin":                       335,
-		"Mapquest":                      336,
-		"Noticeable":                    337,
-		"Onbuka":                        338,
-		"Todoist":                       339,
-		"Storychief":                    340,
-		"LinkedIn":                      341,
-		"YouSign":                       342,
-		"Docker":                        343,
-		"Telesign":                      344,
-		"Spoonacular":                   345,
-		"Aerisweather":                  346,
-		"Alphavantage":                  347,
-		"Imgur":                         348,
-		"Imagga":                        349,
-		"SMSApi":                        350,
-		"Distribusion":                  351,
-		"Blablabus":                     352,
-		"WordsApi":                      353,
-		"Currencylayer":                 354,
-		"Html2Pdf":                      355,
-		"IPGeolocation":                 356,
-		"Owlbot":                        357,
-		"Cloudmersive":                  358,
-		"Dynalist":                      359,
-		"ExchangeRateAPI":               360,
-		"HolidayAPI":                    361,
-		"Ipapi":                         362,
-		"Marketstack":                   363,
-		"Nutritionix":                   364,
-		"Swell":                         365,
-		"ClickupPersonalToken":          366,
-		"Nitro":                         367,
-		"Rev":                           368,
-		"RunRunIt":                      369,
-		"Typeform":                      370,
-		"Mixpanel":                      371,
-		"Tradier":                       372,
-		"Verifier":                      373,
-		"Vouchery":                      374,
-		"Alegra":                        375,
-		"Audd":                          376,
-		"Baremetrics":                   377,
-		"Coinlib":                       378,
-		"ExchangeRatesAPI":              379,
-		"CurrencyScoop":                 380,
-		"FXMarket":                      381,
-		"CurrencyCloud":                 382,
-		"GetGeoAPI":                     383,
-		"Abstract":                      384,
-		"Billomat":                      385,
-		"Dovico":                        386,
-		"Bitbar":                        387,
-		"Bugsnag":                       388,
-		"AssemblyAI":                    389,
-		"AdafruitIO":                    390,
-		"Apify":                         391,
-		"CoinGecko":                     392,
-		"CryptoCompare":                 393,
-		"Fullstory":                     394,
-		"HelloSign":                     395,
-		"Loyverse":                      396,
-		"NetCore":                       397,
-		"SauceLabs":                     398,
-		"AlienVault":                    399,
-		"Apiflash":                      401,
-		"Coinlayer":                     402,
-		"CurrentsAPI":                   403,
-		"DataGov":                       404,
-		"Enigma":                        405,
-		"FinancialModelingPrep":         406,
-		"Geocodio":                      407,
-		"HereAPI":                       408,
-		"Macaddress":                    409,
-		"OOPSpam":                       410,
-		"ProtocolsIO":                   411,
-		"ScraperAPI":                    412,
-		"SecurityTrails":                413,
-		"TomorrowIO":                    414,
-		"WorldCoinIndex":                415,
-		"FacePlusPlus":                  416,
-		"Voicegain":                     417,
-		"Deepgram":                      418,
-		"VisualCrossing":                419,
-		"Finnhub":                       420,
-		"Tiingo":                        421,
-		"RingCentral":                   422,
-		"Finage":                        423,
-		"Edamam":                        424,
-		"HypeAuditor":                   425,
-		"Gengo":                         426,
-		"Front":                         427,
-		"Fleetbase":                     428,
-		"Bubble":                        429,
-		"Bannerbear":                    430,
-		"Adzuna":                        431,
-		"BitcoinAverage":                432,
-		"CommerceJS":                    433,
-		"DetectLanguage":                434,
-		"FakeJSON":                      435,
-		"Graphhopper":                   436,
-		"Lexigram":                      437,
-		"LinkPreview":                   438,
-		"Numverify":                     439,
-		"ProxyCrawl":                    440,
-		"ZipCodeAPI":                    441,
-		"Cometchat":                     442,
-		"Keygen":                        443,
-		"Mixcloud":                      444,
-		"TatumIO":                       445,
-		"Tmetric":                       446,
-		"Lastfm":                        447,
-		"Browshot":                      448,
-		"JSONbin":                       449,
-		"LocationIQ":                    450,
-		"ScreenshotAPI":                 451,
-		"WeatherStack":                  452,
-		"Amadeus":                       453,
-		"FourSquare":                    454,
-		"Flickr":                        455,
-		"ClickHelp":                     456,
-		"Ambee":                         457,
-		"Api2Cart":                      458,
-		"Hypertrack":                    459,
-		"KakaoTalk":                     460,
-		"RiteKit":                       461,
-		"Shutterstock":                  462,
-		"Text2Data":                     463,
-		"YouNeedABudget":                464,
-		"Cricket":                       465,
-		"Filestack":                     466,
-		"Gyazo":                         467,
-		"Mavenlink":                     468,
-		"Sheety":                        469,
-		"Sportsmonk":                    470,
-		"Stockdata":                     471,
-		"Unsplash":                      472,
-		"Allsports":                     473,
-		"CalorieNinja":                  474,
-		"WalkScore":                     475,
-		"Strava":                        476,
-		"Cicero":                        477,
-		"IPQuality":                     478,
-		"ParallelDots":                  479,
-		"Roaring":                       480,
-		"Mailsac":                       481,
-		"Whoxy":                         482,
-		"WorldWeather":                  483,
-		"ApiFonica":                     484,
-		"Aylien":                        485,
-		"Geocode":                       486,
-		"IconFinder":                    487,
-		"Ipify":                         488,
-		"LanguageLayer":                 489,
-		"Lob":                           490,
-		"OnWaterIO":                     491,
-		"Pastebin":                      492,
-		"PdfLayer":                      493,
-		"Pixabay":                       494,
-		"ReadMe":                        495,
-		"VatLayer":                      496,
-		"VirusTotal":                    497,
-		"AirVisual":                     498,
-		"Currencyfreaks":                499,
-		"Duffel":                        500,
-		"FlatIO":                        501,
-		"M3o":                           502,
-		"Mesibo":                        503,
-		"Openuv":                        504,
-		"Snipcart":                      505,
-		"Besttime":                      506,
-		"Happyscribe":                   507,
-		"Humanity":                      508,
-		"Impala":                        509,
-		"Loginradius":                   510,
-		"AutoPilot":                     511,
-		"Bitmex":                        512,
-		"ClustDoc":                      513,
-		"Messari":                       514,
-		"PdfShift":                      515,
-		"Poloniex":                      516,
-		"RestpackHtmlToPdfAPI":          517,
-		"RestpackScreenshotAPI":         518,
-		"ShutterstockOAuth":             519,
-		"SkyBiometry":                   520,
-		"AbuseIPDB":                     521,
-		"AletheiaApi":                   522,
-		"BlitApp":                       523,
-		"Censys":                        524,
-		"Cloverly":                      525,
-		"CountryLayer":                  526,
-		"FileIO":                        527,
-		"FlightApi":                     528,
-		"Geoapify":                      529,
-		"IPinfoDB":                      530,
-		"MediaStack":                    531,
-		"NasdaqDataLink":                532,
-		"OpenCageData":                  533,
-		"Paymongo":                      534,
-		"PositionStack":                 535,
-		"Rebrandly":                     536,
-		"ScreenshotLayer":               537,
-		"Stytch":                        538,
-		"Unplugg":                       539,
-		"UPCDatabase":                   540,
-		"UserStack":                     541,
-		"Geocodify":                     542,
-		"Newscatcher":                   543,
-		"Nicereply":                     544,
-		"Partnerstack":                  545,
-		"Route4me":                      546,
-		"Scrapeowl":                     547,
-		"ScrapingDog":                   548,
-		"Streak":                        549,
-		"Veriphone":                     550,
-		"Webscraping":                   551,
-		"Zenscrape":                     552,
-		"Zenserp":                       553,
-		"CoinApi":                       554,
-		"Gitter":                        555,
-		"Host":                          556,
-		"Iexcloud":                      557,
-		"Restpack":                      558,
-		"ScraperBox":                    559,
-		"ScrapingAnt":                   560,
-		"SerpStack":                     561,
-		"SmartyStreets":                 562,
-		"TicketMaster":                  563,
-		"AviationStack":                 564,
-		"BombBomb":                      565,
-		"Commodities":                   566,
-		"Dfuse":                         567,
-		"EdenAI":                        568,
-		"Glassnode":                     569,
-		"Guru":                          570,
-		"Hive":                          571,
-		"Hiveage":                       572,
-		"Kickbox":                       573,
-		"Passbase":                      574,
-		"PostageApp":                    575,
-		"PureStake":                     576,
-		"Qubole":                        577,
-		"CarbonInterface":               578,
-		"Intrinio":                      579,
-		"QuickMetrics":                  580,
-		"ScrapeStack":                   581,
-		"TechnicalAnalysisApi":          582,
-		"Urlscan":                       583,
-		"BaseApiIO":                     584,
-		"DailyCO":                       585,
-		"TLy":                           586,
-		"Shortcut":                      587,
-		"Appfollow":                     588,
-		"Thinkific":                     589,
-		"Feedly":                        590,
-		"Stitchdata":                    591,
-		"Fetchrss":                      592,
-		"Signupgenius":                  593,
-		"Signaturit":                    594,
-		"Optimizely":                    595,
-		"OcrSpace":                      596,
-		"WeatherBit":                    597,
-		"BuddyNS":                       598,
-		"ZipAPI":                        599,
-		"ZipBooks":                      600,
-		"Onedesk":                       601,
-		"Bugherd":                       602,
-		"Blazemeter":                    603,
-		"Autodesk":                      604,
-		"Tru":                           605,
-		"UnifyID":                       606,
-		"Trimble":                       607,
-		"Smooch":                        608,
-		"Semaphore":                     609,
-		"Telnyx":                        610,
-		"Signalwire":                    611,
-		"Textmagic":                     612,
-		"Serphouse":                     613,
-		"Planyo":                        614,
-		"Simplybook":                    615,
-		"Vyte":                          616,
-		"Nylas":                         617,
-		"Squareup":                      618,
-		"Dandelion":                     619,
-		"DataFire":                      620,
-		"DeepAI":                        621,
-		"MeaningCloud":                  622,
-		"NeutrinoApi":                   623,
-		"Storecove":                     624,
-		"Shipday":                       625,
-		"Sentiment":                     626,
-		"StreamChatMessaging":           627,
-		"TeamworkCRM":                   628,
-		"TeamworkDesk":                  629,
-		"TeamworkSpaces":                630,
-		"TheOddsApi":                    631,
-		"Apacta":                        632,
-		"GetSandbox":                    633,
-		"Happi":                         634,
-		"Oanda":                         635,
-		"FastForex":                     636,
-		"APIMatic":                      637,
-		"VersionEye":                    638,
-		"EagleEyeNetworks":              639,
-		"ThousandEyes":                  640,
-		"SelectPDF":                     641,
-		"Flightstats":                   642,
-		"ChecIO":                        643,
-		"Manifest":                      644,
-		"ApiScience":                    645,
-		"AppSynergy":                    646,
-		"Caflou":                        647,
-		"Caspio":                        648,
-		"ChecklyHQ":                     649,
-		"CloudElements":                 650,
-		"DronaHQ":                       651,
-		"Enablex":                       652,
-		"Fmfw":                          653,
-		"GoodDay":                       654,
-		"Luno":                          655,
-		"Meistertask":                   656,
-		"Mindmeister":                   657,
-		"PeopleDataLabs":                658,
-		"ScraperSite":                   659,
-		"Scrapfly":                      660,
-		"SimplyNoted":                   661,
-		"TravelPayouts":                 662,
-		"WebScraper":                    663,
-		"Convier":                       664,
-		"Courier":                       665,
-		"Ditto":                         666,
-		"Findl":                         667,
-		"Lendflow":                      668,
-		"Moderation":                    669,
-		"Opendatasoft":                  670,
-		"Podio":                         671,
-		"Rockset":                       672,
-		"Rownd":                         673,
-		"Shotstack":                     674,
-		"Swiftype":                      675,
-		"Twitter":                       676,
-		"Honey":                         677,
-		"Freshdesk":                     678,
-		"Upwave":                        679,
-		"Fountain":                      680,
-		"Freshbooks":                    681,
-		"Mite":                          682,
-		"Deputy":                        683,
-		"Beebole":                       684,
-		"Cashboard":                     685,
-		"Kanban":                        686,
-		"Worksnaps":                     687,
-		"MyIntervals":                   688,
-		"InvoiceOcean":                  689,
-		"Sherpadesk":                    690,
-		"Mrticktock":                    691,
-		"Chatfule":                      692,
-		"Aeroworkflow":                  693,
-		"Emailoctopus":                  694,
-		"Fusebill":                      695,
-		"Geckoboard":                    696,
-		"Gosquared":                     697,
-		"Moonclerk":                     698,
-		"Paymoapp":                      699,
-		"Mixmax":                        700,
-		"Processst":                     701,
-		"Repairshopr":                   702,
-		"Goshippo":                      703,
-		"Sigopt":                        704,
-		"Sugester":                      705,
-		"Viewneo":                       706,
-		"BoostNote":                     707,
-		"CaptainData":                   708,
-		"Checkvist":                     709,
-		"Cliengo":                       710,
-		"Cloze":                         711,
-		"FormIO":                        712,
-		"FormBucket":                    713,
-		"GoCanvas":                      714,
-		"MadKudu":                       715,
-		"NozbeTeams":                    716,
-		"Papyrs":                        717,
-		"SuperNotesAPI":                 718,
-		"Tallyfy":                       719,
-		"ZenkitAPI":                     720,
-		"CloudImage":                    721,
-		"UploadCare":                    722,
-		"Borgbase":                      723,
-		"Pipedream":                     724,
-		"Sirv":                          725,
-		"Diffbot":                       726,
-		"EightxEight":                   727,
-		"Sendoso":                       728,
-		"Printfection":                  729,
-		"Authorize":                     730,
-		"PandaScore":                    731,
-		"Paymo":                         732,
-		"AvazaPersonalAccessToken":      733,
-		"PlanviewLeanKit":               734,
-		"Livestorm":                     735,
-		"KuCoin":                        736,
-		"MetaAPI":                       737,
-		"NiceHash":                      738,
-		"CexIO":                         739,
-		"Klipfolio":                     740,
-		"Dynatrace":                     741,
-		"MollieAPIKey":                  742,
-		"MollieAccessToken":             743,
-		"BasisTheory":                   744,
-		"Nordigen":                      745,
-		"FlagsmithEnvironmentKey":       746,
-		"FlagsmithToken":                747,
-		"Mux":                           748,
-		"Column":                        749,
-		"Sendbird":                      750,
-		"SendbirdOrganizationAPI":       751,
-		"Midise":                        752,
-		"Mockaroo":                      753,
-		"Image4":                        754,
-		"Pinata":                        755,
-		"BrowserStack":                  756,
-		"CrossBrowserTesting":           757,
-		"Loadmill":                      758,
-		"TestingBot":                    759,
-		"KnapsackPro":                   760,
-		"Qase":                          761,
-		"Dareboost":                     762,
-		"GTMetrix":                      763,
-		"Holistic":                      764,
-		"Parsers":                       765,
-		"ScrutinizerCi":                 766,
-		"SonarCloud":                    767,
-		"APITemplate":                   768,
-		"ConversionTools":               769,
-		"CraftMyPDF":                    770,
-		"ExportSDK":                     771,
-		"GlitterlyAPI":                  772,
-		"Hybiscus":                      773,
-		"Miro":                          774,
-		"Statuspage":                    775,
-		"Statuspal":                     776,
-		"Teletype":                      777,
-		"TimeCamp":                      778,
-		"Userflow":                      779,
-		"Wistia":                        780,
-		"SportRadar":                    781,
-		"UptimeRobot":                   782,
-		"Codequiry":                     783,
-		"ExtractorAPI":                  784,
-		"Signable":                      785,
-		"MagicBell":                     786,
-		"Stormboard":                    787,
-		"Apilayer":                      788,
-		"Disqus":                        789,
-		"Woopra":                        790,
-		"Paperform":                     791,
-		"Gumroad":                       792,
-		"Paydirtapp":                    793,
-		"Detectify":                     794,
-		"Statuscake":                    795,
-		"Jumpseller":                    796,
-		"LunchMoney":                    797,
-		"Rosette":                       798,
-		"Yelp":                          799,
-		"Atera":                         800,
-		"EcoStruxureIT":                 801,
-		"Aha":                           802,
-		"Parsehub":                      803,
-		"PackageCloud":                  804,
-		"Cloudsmith":                    805,
-		"Flowdash":                      806,
-		"Flowdock":                      807,
-		"Fibery":                        808,
-		"Typetalk":                      809,
-		"VoodooSMS":                     810,
-		"ZulipChat":                     811,
-		"Formcraft":                     812,
-		"Iexapis":                       813,
-		"Reachmail":                     814,
-		"Chartmogul":                    815,
-		"Appointed":                     816,
-		"Wit":                           817,
-		"RechargePayments":              818,
-		"Diggernaut":                    819,
-		"MonkeyLearn":                   820,
-		"Duply":                         821,
-		"Postbacks":                     822,
-		"Collect2":                      823,
-		"ZenRows":                       824,
-		"Zipcodebase":                   825,
-		"Tefter":                        826,
-		"Twist":                         827,
-		"BraintreePayments":             828,
-		"CloudConvert":                  829,
-		"Grafana":                       830,
-		"ConvertApi":                    831,
-		"Transferwise":                  832,
-		"Bulksms":                       833,
-		"Databox":                       834,
-		"Onesignal":                     835,
-		"Rentman":                       836,
-		"Parseur":                       837,
-		"Docparser":                     838,
-		"Formsite":                      839,
-		"Tickettailor":                  840,
-		"Lemlist":                       841,
-		"Prodpad":                       842,
-		"Formstack":                     843,
-		"Codeclimate":                   844,
-		"Codemagic":                     845,
-		"Vbout":                         846,
-		"Nightfall":                     847,
-		"FlightLabs":                    848,
-		"SpeechTextAI":                  849,
-		"PollsAPI":                      850,
-		"SimFin":                        851,
-		"Scalr":                         852,
-		"Kanbantool":                    853,
-		"Brightlocal":                   854,
-		"Hotwire":                       855,
-		"Instabot":                      856,
-		"Timekit":                       857,
-		"Interseller":                   858,
-		"Mojohelpdesk":                  859,
-		"Createsend":                    860,
-		"Getresponse":                   861,
-		"Dynadot":                       862,
-		"Demio":                         863,
-		"Tokeet":                        864,
-		"Myexperiment":                  865,
-		"Copyscape":                     866,
-		"Besnappy":                      867,
-		"Salesmate":                     868,
-		"Heatmapapi":                    869,
-		"Websitepulse":                  870,
-		"Uclassify":                     871,
+		"Alibaba":                          0,
+		"AMQP":                             1,
+		"AWS":                              2,
+		"Azure":                            3,
+		"Circle":                           4,
+		"Coinbase":                         5,
+		"GCP":                              6,
+		"Generic":                          7,
+		"Github":                           8,
+		"Gitlab":                           9,
+		"JDBC":                             10,
+		"RazorPay":                         11,
+		"SendGrid":                         12,
+		"Slack":                            13,
+		"Square":                           14,
+		"PrivateKey":                       15,
+		"Stripe":                           16,
+		"URI":                              17,
+		"Dropbox":                          18,
+		"Heroku":                           19,
+		"Mailchimp":                        20,
+		"Okta":                             21,
+		"OneLogin":                         22,
+		"PivotalTracker":                   23,
+		"SquareApp":                        25,
+		"Twilio":                           26,
+		"Test":                             27,
+		"TravisCI":                         29,
+		"SlackWebhook":                     30,
+		"PaypalOauth":                      31,
+		"PagerDutyApiKey":                  32,
+		"Firebase":                         33,
+		"Mailgun":                          34,
+		"HubSpot":                          35,
+		"GitHubApp":                        36,
+		"CircleCI":                         37,
+		"WpEngine":                         38,
+		"DatadogToken":                     39,
+		"FacebookOAuth":                    40,
+		"AsanaPersonalAccessToken":         41,
+		"AmplitudeApiKey":                  42,
+		"BitLyAccessToken":                 43,
+		"CalendlyApiKey":                   44,
+		"ZapierWebhook":                    45,
+		"YoutubeApiKey":                    46,
+		"SalesforceOauth2":                 47,
+		"TwitterApiSecret":                 48,
+		"NpmToken":                         49,
+		"NewRelicPersonalApiKey":           50,
+		"AirtableApiKey":                   51,
+		"AkamaiToken":                      52,
+		"AmazonMWS":                        53,
+		"KubeConfig":                       54,
+		"Auth0oauth":                       55,
+		"Bitfinex":                         56,
+		"Clarifai":                         57,
+		"CloudflareGlobalApiKey":           58,
+		"CloudflareCaKey":                  59,
+		"Confluent":                        60,
+		"ContentfulDelivery":               61,
+		"DatabricksToken":                  62,
+		"DigitalOceanSpaces":               63,
+		"DigitalOceanToken":                64,
+		"DiscordBotToken":                  65,
+		"DiscordWebhook":                   66,
+		"EtsyApiKey":                       67,
+		"FastlyPersonalToken":              68,
+		"GoogleOauth2":                     69,
+		"ReCAPTCHA":                        70,
+		"GoogleApiKey":                     71,
+		"Hunter":                           72,
+		"IbmCloudUserKey":                  73,
+		"Netlify":                          74,
+		"Vonage":                           75,
+		"EquinixOauth":                     76,
+		"Paystack":                         77,
+		"PlaidToken":                       78,
+		"PlaidKey":                         79,
+		"Plivo":                            80,
+		"Postmark":                         81,
+		"PubNubPublishKey":                 82,
+		"PubNubSubscriptionKey":            83,
+		"PusherChannelKey":                 84,
+		"ScalewayKey":                      85,
+		"SendinBlueV2":                     86,
+		"SentryToken":                      87,
+		"ShodanKey":                        88,
+		"SnykKey":                          89,
+		"SpotifyKey":                       90,
+		"TelegramBotToken":                 91,
+		"TencentCloudKey":                  92,
+		"TerraformCloudPersonalToken":      93,
+		"TrelloApiKey":                     94,
+		"ZendeskApi":                       95,
+		"MaxMindLicense":                   96,
+		"AirtableMetadataApiKey":           97,
+		"AsanaOauth":                       98,
+		"RapidApi":                         99,
+		"CloudflareApiToken":               100,
+		"Webex":                            101,
+		"FirebaseCloudMessaging":           102,
+		"ContentfulPersonalAccessToken":    103,
+		"MapBox":                           104,
+		"MailJetBasicAuth":                 105,
+		"MailJetSMS":                       106,
+		"HubSpotApiKey":                    107,
+		"HubSpotOauth":                     108,
+		"SslMate":                          109,
+		"Auth0ManagementApiToken":          110,
+		"MessageBird":                      111,
+		"ElasticEmail":                     112,
+		"FigmaPersonalAccessToken":         113,
+		"MicrosoftTeamsWebhook":            114,
+		"GitHubOld":                        115,
+		"VultrApiKey":                      116,
+		"Pepipost":                         117,
+		"Postman":                          118,
+		"CloudsightKey":                    119,
+		"JiraToken":                        120,
+		"NexmoApiKey":                      121,
+		"SegmentApiKey":                    122,
+		"SumoLogicKey":                     123,
+		"PushBulletApiKey":                 124,
+		"AirbrakeProjectKey":               125,
+		"AirbrakeUserKey":                  126,
+		"PendoIntegrationKey":              127,
+		"SplunkOberservabilityToken":       128,
+		"LokaliseToken":                    129,
+		"Calendarific":                     130,
+		"Jumpcloud":                        131,
+		"IpStack":                          133,
+		"Notion":                           134,
+		"DroneCI":                          135,
+		"AdobeIO":                          136,
+		"TwelveData":                       137,
+		"D7Network":                        138,
+		"ScrapingBee":                      139,
+		"KeenIO":                           140,
+		"Wakatime":                         141,
+		"Buildkite":                        142,
+		"Verimail":                         143,
+		"Zerobounce":                       144,
+		"Mailboxlayer":                     145,
+		"Fastspring":                       146,
+		"Paddle":                           147,
+		"Sellfy":                           148,
+		"FixerIO":                          149,
+		"ButterCMS":                        150,
+		"Taxjar":                           151,
+		"Avalara":                          152,
+		"Helpscout":                        153,
+		"ElasticPath":                      154,
+		"Zeplin":                           155,
+		"Intercom":                         156,
+		"Mailmodo":                         157,
+		"CannyIo":                          158,
+		"Pipedrive":                        159,
+		"Vercel":                           160,
+		"PosthogApp":                       161,
+		"SinchMessage":                     162,
+		"Ayrshare":                         163,
+		"HelpCrunch":                       164,
+		"LiveAgent":                        165,
+		"Beamer":                           166,
+		"WeChatAppKey":                     167,
+		"LineMessaging":                    168,
+		"UberServerToken":                  169,
+		"AlgoliaAdminKey":                  170,
+		"FullContact":                      171,
+		"Mandrill":                         172,
+		"Flutterwave":                      173,
+		"MattermostPersonalToken":          174,
+		"Cloudant":                         175,
+		"LineNotify":                       176,
+		"LinearAPI":                        177,
+		"Ubidots":                          178,
+		"Anypoint":                         179,
+		"Dwolla":                           180,
+		"ArtifactoryAccessToken":           181,
+		"Surge":                            182,
+		"Sparkpost":                        183,
+		"GoCardless":                       184,
+		"Codacy":                           185,
+		"Kraken":                           186,
+		"Checkout":                         187,
+		"Kairos":                           188,
+		"ClockworkSMS":                     189,
+		"Atlassian":                        190,
+		"LaunchDarkly":                     191,
+		"Coveralls":                        192,
+		"Linode":                           193,
+		"WePay":                            194,
+		"PlanetScale":                      195,
+		"Doppler":                          196,
+		"Agora":                            197,
+		"Samsara":                          198,
+		"FrameIO":                          199,
+		"RubyGems":                         200,
+		"OpenAI":                           201,
+		"SurveySparrow":                    202,
+		"Simvoly":                          203,
+		"Survicate":                        204,
+		"Omnisend":                         205,
+		"Groovehq":                         206,
+		"Newsapi":                          207,
+		"Chatbot":                          208,
+		"ClickSendsms":                     209,
+		"Getgist":                          210,
+		"CustomerIO":                       211,
+		"ApiDeck":                          212,
+		"Nftport":                          213,
+		"Copper":                           214,
+		"Close":                            215,
+		"Myfreshworks":                     216,
+		"Salesflare":                       217,
+		"Webflow":                          218,
+		"Duda":                             219,
+		"Yext":                             220,
+		"ContentStack":                     221,
+		"Storyblok":                        222,
+		"GraphCMS":                         223,
+		"Checkmarket":                      224,
+		"Convertkit":                       225,
+		"CustomerGuru":                     226,
+		"Kaleyra":                          227,
+		"Mailerlite":                       228,
+		"Qualaroo":                         229,
+		"SatismeterProjectkey":             230,
+		"SatismeterWritekey":               231,
+		"Simplesat":                        232,
+		"SurveyAnyplace":                   233,
+		"SurveyBot":                        234,
+		"Webengage":                        235,
+		"ZonkaFeedback":                    236,
+		"Delighted":                        237,
+		"Feedier":                          238,
+		"Abbysale":                         239,
+		"Magnetic":                         240,
+		"Nytimes":                          241,
+		"Polygon":                          242,
+		"Powrbot":                          243,
+		"ProspectIO":                       244,
+		"Skrappio":                         245,
+		"Monday":                           246,
+		"Smartsheets":                      247,
+		"Wrike":                            248,
+		"Float":                            249,
+		"Imagekit":                         250,
+		"Integromat":                       251,
+		"Salesblink":                       252,
+		"Bored":                            253,
+		"Campayn":                          254,
+		"Clinchpad":                        255,
+		"CompanyHub":                       256,
+		"Debounce":                         257,
+		"Dyspatch":                         258,
+		"Guardianapi":                      259,
+		"Harvest":                          260,
+		"Moosend":                          261,
+		"OpenWeather":                      262,
+		"Siteleaf":                         263,
+		"Squarespace":                      264,
+		"FlowFlu":                          265,
+		"Nimble":                           266,
+		"LessAnnoyingCRM":                  267,
+		"Nethunt":                          268,
+		"Apptivo":                          269,
+		"CapsuleCRM":                       270,
+		"Insightly":                        271,
+		"Kylas":                            272,
+		"OnepageCRM":                       273,
+		"User":                             274,
+		"ProspectCRM":                      275,
+		"ReallySimpleSystems":              276,
+		"Airship":                          277,
+		"Artsy":                            278,
+		"Yandex":                           279,
+		"Clockify":                         280,
+		"Dnscheck":                         281,
+		"EasyInsight":                      282,
+		"Ethplorer":                        283,
+		"Everhour":                         284,
+		"Fulcrum":                          285,
+		"GeoIpifi":                         286,
+		"Jotform":                          287,
+		"Refiner":                          288,
+		"Timezoneapi":                      289,
+		"TogglTrack":                       290,
+		"Vpnapi":                           291,
+		"Workstack":                        292,
+		"Apollo":                           293,
+		"Eversign":                         294,
+		"Juro":                             295,
+		"KarmaCRM":                         296,
+		"Metrilo":                          297,
+		"Pandadoc":                         298,
+		"RevampCRM":                        299,
+		"Salescookie":                      300,
+		"Alconost":                         301,
+		"Blogger":                          302,
+		"Accuweather":                      303,
+		"Opengraphr":                       304,
+		"Rawg":                             305,
+		"Riotgames":                        306,
+		"RoninApp":                         307,
+		"Stormglass":                       308,
+		"Tomtom":                           309,
+		"Twitch":                           310,
+		"Documo":                           311,
+		"Cloudways":                        312,
+		"Veevavault":                       313,
+		"KiteConnect":                      314,
+		"ShopeeOpenPlatform":               315,
+		"TeamViewer":                       316,
+		"Bulbul":                           317,
+		"CentralStationCRM":                318,
+		"Teamgate":                         319,
+		"Axonaut":                          320,
+		"Tyntec":                           321,
+		"Appcues":                          322,
+		"Autoklose":                        323,
+		"Cloudplan":                        324,
+		"Dotmailer":                        325,
+		"GetEmail":                         326,
+		"GetEmails":                        327,
+		"Kontent":                          328,
+		"Leadfeeder":                       329,
+		"Raven":                            330,
+		"RocketReach":                      331,
+		"Uplead":                           332,
+		"Brandfetch":                       333,
+		"Clearbit":                         334,
+		"Crowdin":                          335,
+		"Mapquest":                         336,
+		"Noticeable":                       337,
+		"Onbuka":                           338,
+		"Todoist":                          339,
+		"Storychief":                       340,
+		"LinkedIn":                         341,
+		"YouSign":                          342,
+		"Docker":                           343,
+		"Telesign":                         344,
+		"Spoonacular":                      345,
+		"Aerisweather":                     346,
+		"Alphavantage":                     347,
+		"Imgur":                            348,
+		"Imagga":                           349,
+		"SMSApi":                           350,
+		"Distribusion":                     351,
+		"Blablabus":                        352,
+		"WordsApi":                         353,
+		"Currencylayer":                    354,
+		"Html2Pdf":                         355,
+		"IPGeolocation":                    356,
+		"Owlbot":                           357,
+		"Cloudmersive":                     358,
+		"Dynalist":                         359,
+		"ExchangeRateAPI":                  360,
+		"HolidayAPI":                       361,
+		"Ipapi":                            362,
+		"Marketstack":                      363,
+		"Nutritionix":                      364,
+		"Swell":                            365,
+		"ClickupPersonalToken":             366,
+		"Nitro":                            367,
+		"Rev":                              368,
+		"RunRunIt":                         369,
+		"Typeform":                         370,
+		"Mixpanel":                         371,
+		"Tradier":                          372,
+		"Verifier":                         373,
+		"Vouchery":                         374,
+		"Alegra":                           375,
+		"Audd":                             376,
+		"Baremetrics":                      377,
+		"Coinlib":                          378,
+		"ExchangeRatesAPI":                 379,
+		"CurrencyScoop":                    380,
+		"FXMarket":                         381,
+		"CurrencyCloud":                    382,
+		"GetGeoAPI":                        383,
+		"Abstract":                         384,
+		"Billomat":                         385,
+		"Dovico":                           386,
+		"Bitbar":                           387,
+		"Bugsnag":                          388,
+		"AssemblyAI":                       389,
+		"AdafruitIO":                       390,
+		"Apify":                            391,
+		"CoinGecko":                        392,
+		"CryptoCompare":                    393,
+		"Fullstory":                        394,
+		"HelloSign":                        395,
+		"Loyverse":                         396,
+		"NetCore":                          397,
+		"SauceLabs":                        398,
+		"AlienVault":                       399,
+		"Apiflash":                         401,
+		"Coinlayer":                        402,
+		"CurrentsAPI":                      403,
+		"DataGov":                          404,
+		"Enigma":                           405,
+		"FinancialModelingPrep":            406,
+		"Geocodio":                         407,
+		"HereAPI":                          408,
+		"Macaddress":                       409,
+		"OOPSpam":                          410,
+		"ProtocolsIO":                      411,
+		"ScraperAPI":                       412,
+		"SecurityTrails":                   413,
+		"TomorrowIO":                       414,
+		"WorldCoinIndex":                   415,
+		"FacePlusPlus":                     416,
+		"Voicegain":                        417,
+		"Deepgram":                         418,
+		"VisualCrossing":                   419,
+		"Finnhub":                          420,
+		"Tiingo":                           421,
+		"RingCentral":                      422,
+		"Finage":                           423,
+		"Edamam":                           424,
+		"HypeAuditor":                      425,
+		"Gengo":                            426,
+		"Front":                            427,
+		"Fleetbase":                        428,
+		"Bubble":                           429,
+		"Bannerbear":                       430,
+		"Adzuna":                           431,
+		"BitcoinAverage":                   432,
+		"CommerceJS":                       433,
+		"DetectLanguage":                   434,
+		"FakeJSON":                         435,
+		"Graphhopper":                      436,
+		"Lexigram":                         437,
+		"LinkPreview":                      438,
+		"Numverify":                        439,
+		"ProxyCrawl":                       440,
+		"ZipCodeAPI":                       441,
+		"Cometchat":                        442,
+		"Keygen":                           443,
+		"Mixcloud":                         444,
+		"TatumIO":                          445,
+		"Tmetric":                          446,
+		"Lastfm":                           447,
+		"Browshot":                         448,
+		"JSONbin":                          449,
+		"LocationIQ":                       450,
+		"ScreenshotAPI":                    451,
+		"WeatherStack":                     452,
+		"Amadeus":                          453,
+		"FourSquare":                       454,
+		"Flickr":                           455,
+		"ClickHelp":                        456,
+		"Ambee":                            457,
+		"Api2Cart":                         458,
+		"Hypertrack":                       459,
+		"KakaoTalk":                        460,
+		"RiteKit":                          461,
+		"Shutterstock":                     462,
+		"Text2Data":                        463,
+		"YouNeedABudget":                   464,
+		"Cricket":                          465,
+		"Filestack":                        466,
+		"Gyazo":                            467,
+		"Mavenlink":                        468,
+		"Sheety":                           469,
+		"Sportsmonk":                       470,
+		"Stockdata":                        471,
+		"Unsplash":                         472,
+		"Allsports":                        473,
+		"CalorieNinja":                     474,
+		"WalkScore":                        475,
+		"Strava":                           476,
+		"Cicero":                           477,
+		"IPQuality":                        478,
+		"ParallelDots":                     479,
+		"Roaring":                          480,
+		"Mailsac":                          481,
+		"Whoxy":                            482,
+		"WorldWeather":                     483,
+		"ApiFonica":                        484,
+		"Aylien":                           485,
+		"Geocode":                          486,
+		"IconFinder":                       487,
+		"Ipify":                            488,
+		"LanguageLayer":                    489,
+		"Lob":                              490,
+		"OnWaterIO":                        491,
+		"Pastebin":                         492,
+		"PdfLayer":                         493,
+		"Pixabay":                          494,
+		"ReadMe":                           495,
+		"VatLayer":                         496,
+		"VirusTotal":                       497,
+		"AirVisual":                        498,
+		"Currencyfreaks":                   499,
+		"Duffel":                           500,
+		"FlatIO":                           501,
+		"M3o":                              502,
+		"Mesibo":                           503,
+		"Openuv":                           504,
+		"Snipcart":                         505,
+		"Besttime":                         506,
+		"Happyscribe":                      507,
+		"Humanity":                         508,
+		"Impala":                           509,
+		"Loginradius":                      510,
+		"AutoPilot":                        511,
+		"Bitmex":                           512,
+		"ClustDoc":                         513,
+		"Messari":                          514,
+		"PdfShift":                         515,
+		"Poloniex":                         516,
+		"RestpackHtmlToPdfAPI":             517,
+		"RestpackScreenshotAPI":            518,
+		"ShutterstockOAuth":                519,
+		"SkyBiometry":                      520,
+		"AbuseIPDB":                        521,
+		"AletheiaApi":                      522,
+		"BlitApp":                          523,
+		"Censys":                           524,
+		"Cloverly":                         525,
+		"CountryLayer":                     526,
+		"FileIO":                           527,
+		"FlightApi":                        528,
+		"Geoapify":                         529,
+		"IPinfoDB":                         530,
+		"MediaStack":                       531,
+		"NasdaqDataLink":                   532,
+		"OpenCageData":                     533,
+		"Paymongo":                         534,
+		"PositionStack":                    535,
+		"Rebrandly":                        536,
+		"ScreenshotLayer":                  537,
+		"Stytch":                           538,
+		"Unplugg":                          539,
+		"UPCDatabase":                      540,
+		"UserStack":                        541,
+		"Geocodify":                        542,
+		"Newscatcher":                      543,
+		"Nicereply":                        544,
+		"Partnerstack":                     545,
+		"Route4me":                         546,
+		"Scrapeowl":                        547,
+		"ScrapingDog":                      548,
+		"Streak":                           549,
+		"Veriphone":                        550,
+		"Webscraping":                      551,
+		"Zenscrape":                        552,
+		"Zenserp":                          553,
+		"CoinApi":                          554,
+		"Gitter":                           555,
+		"Host":                             556,
+		"Iexcloud":                         557,
+		"Restpack":                         558,
+		"ScraperBox":                       559,
+		"ScrapingAnt":                      560,
+		"SerpStack":                        561,
+		"SmartyStreets":                    562,
+		"TicketMaster":                     563,
+		"AviationStack":                    564,
+		"BombBomb":                         565,
+		"Commodities":                      566,
+		"Dfuse":                            567,
+		"EdenAI":                           568,
+		"Glassnode":                        569,
+		"Guru":                             570,
+		"Hive":                             571,
+		"Hiveage":                          572,
+		"Kickbox":                          573,
+		"Passbase":                         574,
+		"PostageApp":                       575,
+		"PureStake":                        576,
+		"Qubole":                           577,
+		"CarbonInterface":                  578,
+		"Intrinio":                         579,
+		"QuickMetrics":                     580,
+		"ScrapeStack":                      581,
+		"TechnicalAnalysisApi":             582,
+		"Urlscan":                          583,
+		"BaseApiIO":                        584,
+		"DailyCO":                          585,
+		"TLy":                              586,
+		"Shortcut":                         587,
+		"Appfollow":                        588,
+		"Thinkific":                        589,
+		"Feedly":                           590,
+		"Stitchdata":                       591,
+		"Fetchrss":                         592,
+		"Signupgenius":                     593,
+		"Signaturit":                       594,
+		"Optimizely":                       595,
+		"OcrSpace":                         596,
+		"WeatherBit":                       597,
+		"BuddyNS":                          598,
+		"ZipAPI":                           599,
+		"ZipBooks":                         600,
+		"Onedesk":                          601,
+		"Bugherd":                          602,
+		"Blazemeter":                       603,
+		"Autodesk":                         604,
+		"Tru":                              605,
+		"UnifyID":                          606,
+		"Trimble":                          607,
+		"Smooch":                           608,
+		"Semaphore":                        609,
+		"Telnyx":                           610,
+		"Signalwire":                       611,
+		"Textmagic":                        612,
+		"Serphouse":                        613,
+		"Planyo":                           614,
+		"Simplybook":                       615,
+		"Vyte":                             616,
+		"Nylas":                            617,
+		"Squareup":                         618,
+		"Dandelion":                        619,
+		"DataFire":                         620,
+		"DeepAI":                           621,
+		"MeaningCloud":                     622,
+		"NeutrinoApi":                      623,
+		"Storecove":                        624,
+		"Shipday":                          625,
+		"Sentiment":                        626,
+		"StreamChatMessaging":              627,
+		"TeamworkCRM":                      628,
+		"TeamworkDesk":                     629,
+		"TeamworkSpaces":                   630,
+		"TheOddsApi":                       631,
+		"Apacta":                           632,
+		"GetSandbox":                       633,
+		"Happi":                            634,
+		"Oanda":                            635,
+		"FastForex":                        636,
+		"APIMatic":                         637,
+		"VersionEye":                       638,
+		"EagleEyeNetworks":                 639,
+		"ThousandEyes":                     640,
+		"SelectPDF":                        641,
+		"Flightstats":                      642,
+		"ChecIO":                           643,
+		"Manifest":                         644,
+		"ApiScience":                       645,
+		"AppSynergy":                       646,
+		"Caflou":                           647,
+		"Caspio":                           648,
+		"ChecklyHQ":                        649,
+		"CloudElements":                    650,
+		"DronaHQ":                          651,
+		"Enablex":                          652,
+		"Fmfw":                             653,
+		"GoodDay":                          654,
+		"Luno":                             655,
+		"Meistertask":                      656,
+		"Mindmeister":                      657,
+		"PeopleDataLabs":                   658,
+		"ScraperSite":                      659,
+		"Scrapfly":                         660,
+		"SimplyNoted":                      661,
+		"TravelPayouts":                    662,
+		"WebScraper":                       663,
+		"Convier":                          664,
+		"Courier":                          665,
+		"Ditto":                            666,
+		"Findl":                            667,
+		"Lendflow":                         668,
+		"Moderation":                       669,
+		"Opendatasoft":                     670,
+		"Podio":                            671,
+		"Rockset":                          672,
+		"Rownd":                            673,
+		"Shotstack":                        674,
+		"Swiftype":                         675,
+		"Twitter":                          676,
+		"Honey":                            677,
+		"Freshdesk":                        678,
+		"Upwave":                           679,
+		"Fountain":                         680,
+		"Freshbooks":                       681,
+		"Mite":                             682,
+		"Deputy":                           683,
+		"Beebole":                          684,
+		"Cashboard":                        685,
+		"Kanban":                           686,
+		"Worksnaps":                        687,
+		"MyIntervals":                      688,
+		"InvoiceOcean":                     689,
+		"Sherpadesk":                       690,
+		"Mrticktock":                       691,
+		"Chatfule":                         692,
+		"Aeroworkflow":                     693,
+		"Emailoctopus":                     694,
+		"Fusebill":                         695,
+		"Geckoboard":                       696,
+		"Gosquared":                        697,
+		"Moonclerk":                        698,
+		"Paymoapp":                         699,
+		"Mixmax":                           700,
+		"Processst":                        701,
+		"Repairshopr":                      702,
+		"Goshippo":                         703,
+		"Sigopt":                           704,
+		"Sugester":                         705,
+		"Viewneo":                          706,
+		"BoostNote":                        707,
+		"CaptainData":                      708,
+		"Checkvist":                        709,
+		"Cliengo":                          710,
+		"Cloze":                            711,
+		"FormIO":                           712,
+		"FormBucket":                       713,
+		"GoCanvas":                         714,
+		"MadKudu":                          715,
+		"NozbeTeams":                       716,
+		"Papyrs":                           717,
+		"SuperNotesAPI":                    718,
+		"Tallyfy":                          719,
+		"ZenkitAPI":                        720,
+		"CloudImage":                       721,
+		"UploadCare":                       722,
+		"Borgbase":                         723,
+		"Pipedream":                        724,
+		"Sirv":                             725,
+		"Diffbot":                          726,
+		"EightxEight":                      727,
+		"Sendoso":                          728,
+		"Printfection":                     729,
+		"Authorize":                        730,
+		"PandaScore":                       731,
+		"Paymo":                            732,
+		"AvazaPersonalAccessToken":         733,
+		"PlanviewLeanKit":                  734,
+		"Livestorm":                        735,
+		"KuCoin":                           736,
+		"MetaAPI":                          737,
+		"NiceHash":                         738,
+		"CexIO":                            739,
+		"Klipfolio":                        740,
+		"Dynatrace":                        741,
+		"MollieAPIKey":                     742,
+		"MollieAccessToken":                743,
+		"BasisTheory":                      744,
+		"Nordigen":                         745,
+		"FlagsmithEnvironmentKey":          746,
+		"FlagsmithToken":                   747,
+		"Mux":                              748,
+		"Column":                           749,
+		"Sendbird":                         750,
+		"SendbirdOrganizationAPI":          751,
+		"Midise":                           752,
+		"Mockaroo":                         753,
+		"Image4":                           754,
+		"Pinata":                           755,
+		"BrowserStack":                     756,
+		"CrossBrowserTesting":              757,
+		"Loadmill":                         758,
+		"TestingBot":                       759,
+		"KnapsackPro":                      760,
+		"Qase":                             761,
+		"Dareboost":                        762,
+		"GTMetrix":                         763,
+		"Holistic":                         764,
+		"Parsers":                          765,
+		"ScrutinizerCi":                    766,
+		"SonarCloud":                       767,
+		"APITemplate":                      768,
+		"ConversionTools":                  769,
+		"CraftMyPDF":                       770,
+		"ExportSDK":                        771,
+		"GlitterlyAPI":                     772,
+		"Hybiscus":                         773,
+		"Miro":                             774,
+		"Statuspage":                       775,
+		"Statuspal":                        776,
+		"Teletype":                         777,
+		"TimeCamp":                         778,
+		"Userflow":                         779,
+		"Wistia":                           780,
+		"SportRadar":                       781,
+		"UptimeRobot":                      782,
+		"Codequiry":                        783,
+		"ExtractorAPI":                     784,
+		"Signable":                         785,
+		"MagicBell":                        786,
+		"Stormboard":                       787,
+		"Apilayer":                         788,
+		"Disqus":                           789,
+		"Woopra":                           790,
+		"Paperform":                        791,
+		"Gumroad":                          792,
+		"Paydirtapp":                       793,
+		"Detectify":                        794,
+		"Statuscake":                       795,
+		"Jumpseller":                       796,
+		"LunchMoney":                       797,
+		"Rosette":                          798,
+		"Yelp":                             799,
+		"Atera":                            800,
+		"EcoStruxureIT":                    801,
+		"Aha":                              802,
+		"Parsehub":                         803,
+		"PackageCloud":                     804,
+		"Cloudsmith":                       805,
+		"Flowdash":                         806,
+		"Flowdock":                         807,
+		"Fibery":                           808,
+		"Typetalk":                         809,
+		"VoodooSMS":                        810,
+		"ZulipChat":                        811,
+		"Formcraft":                        812,
+		"Iexapis":                          813,
+		"Reachmail":                        814,
+		"Chartmogul":                       815,
+		"Appointed":                        816,
+		"Wit":                              817,
+		"RechargePayments":                 818,
+		"Diggernaut":                       819,
+		"MonkeyLearn":                      820,
+		"Duply":                            821,
+		"Postbacks":                        822,
+		"Collect2":                         823,
+		"ZenRows":                          824,
+		"Zipcodebase":                      825,
+		"Tefter":                           826,
+		"Twist":                            827,
+		"BraintreePayments":                828,
+		"CloudConvert":                     829,
+		"Grafana":                          830,
+		"ConvertApi":                       831,
+		"Transferwise":                     832,
+		"Bulksms":                          833,
+		"Databox":                          834,
+		"Onesignal":                        835,
+		"Rentman":                          836,
+		"Parseur":                          837,
+		"Docparser":                        838,
+		"Formsite":                         839,
+		"Tickettailor":                     840,
+		"Lemlist":                          841,
+		"Prodpad":                          842,
+		"Formstack":                        843,
+		"Codeclimate":                      844,
+		"Codemagic":                        845,
+		"Vbout":                            846,
+		"Nightfall":                        847,
+		"FlightLabs":                       848,
+		"SpeechTextAI":                     849,
+		"PollsAPI":                         850,
+		"SimFin":                           851,
+		"Scalr":                            852,
+		"Kanbantool":                       853,
+		"Brightlocal":                      854,
+		"Hotwire":                          855,
+		"Instabot":                         856,
+		"Timekit":                          857,
+		"Interseller":                      858,
+		"Mojohelpdesk":                     859,
+		"Createsend":                       860,
+		"Getresponse":                      861,
+		"Dynadot":                          862,
+		"Demio":                            863,
+		"Tokeet":                           864,
+		"Myexperiment":                     865,
+		"Copyscape":                        866,
+		"Besnappy":                         867,
+		"Salesmate":                        868,
+		"Heatmapapi":                       869,
+		"Websitepulse":                     870,
+		"Uclassify":                        871,
+		"AWSSessionKey":                    872,
+		"AzureStorageKey":                  873,
+		"AzureSASToken":                    874,
+		"Postgres":                         875,
+		"MySQL":                            876,
+		"MongoDB":                          877,
+		"Redis":                            878,
+		"SQLServer":                        879,
+		"GCPApplicationDefaultCredentials": 881,
+		"PyPI":                             882,
+		"Anthropic":                        883,
+		"Cohere":                           884,
+		"HuggingFace":                      885,
+		"Vault":                            886,
+		"Consul":                           887,
+		"Shopify":                          888,
+		"GitCredentials":                   889,
+		"Netrc":                            890,
+		"CIConfig":                         891,
+		"IaC":                              892,
+		"Combolist":                        893,
+		"CustomRegex":                      894,
 	}
 )
 
@@ -2973,7 +3039,7 @@ var file_detectors_proto_rawDesc = []byte{
 	0x75, 0x73, 0x65, 0x72, 0x12, 0x34, 0x0a, 0x16, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b,
 	0x65, 0x79, 0x5f, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x02,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x14, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x46,
-	0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x2a, 0xa2, 0x6d, 0x0a, 0x0c, 0x44,
+	0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x2a, 0x89, 0x70, 0x0a, 0x0c, 0x44,
 	0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x41,
 	0x6c, 0x69, 0x62, 0x61, 0x62, 0x61, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x41, 0x4d, 0x51, 0x50,
 	0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x57, 0x53, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x41,
@@ -3847,12 +3913,34 @@ var file_detectors_proto_rawDesc = []byte{
 	0x61, 0x6c, 0x65, 0x73, 0x6d, 0x61, 0x74, 0x65, 0x10, 0xe4, 0x06, 0x12, 0x0f, 0x0a, 0x0a, 0x48,
 	0x65, 0x61, 0x74, 0x6d, 0x61, 0x70, 0x61, 0x70, 0x69, 0x10, 0xe5, 0x06, 0x12, 0x11, 0x0a, 0x0c,
 	0x57, 0x65, 0x62, 0x73, 0x69, 0x74, 0x65, 0x70, 0x75, 0x6c, 0x73, 0x65, 0x10, 0xe6, 0x06, 0x12,
-	0x0e, 0x0a, 0x09, 0x55, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x79, 0x10, 0xe7, 0x06, 0x42,
-	0x3d, 0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x72,
-	0x75, 0x66, 0x66, 0x6c, 0x65, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x2f, 0x74, 0x72,
-	0x75, 0x66, 0x66, 0x6c, 0x65, 0x68, 0x6f, 0x67, 0x2f, 0x76, 0x33, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
-	0x70, 0x62, 0x2f, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x70, 0x62, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x0e, 0x0a, 0x09, 0x55, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x79, 0x10, 0xe7, 0x06, 0x12,
+	0x12, 0x0a, 0x0d, 0x41, 0x57, 0x53, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79,
+	0x10, 0xe8, 0x06, 0x12, 0x14, 0x0a, 0x0f, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x53, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x4b, 0x65, 0x79, 0x10, 0xe9, 0x06, 0x12, 0x12, 0x0a, 0x0d, 0x41, 0x7a, 0x75,
+	0x72, 0x65, 0x53, 0x41, 0x53, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x10, 0xea, 0x06, 0x12, 0x0d, 0x0a,
+	0x08, 0x50, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x10, 0xeb, 0x06, 0x12, 0x0a, 0x0a, 0x05,
+	0x4d, 0x79, 0x53, 0x51, 0x4c, 0x10, 0xec, 0x06, 0x12, 0x0c, 0x0a, 0x07, 0x4d, 0x6f, 0x6e, 0x67,
+	0x6f, 0x44, 0x42, 0x10, 0xed, 0x06, 0x12, 0x0a, 0x0a, 0x05, 0x52, 0x65, 0x64, 0x69, 0x73, 0x10,
+	0xee, 0x06, 0x12, 0x0e, 0x0a, 0x09, 0x53, 0x51, 0x4c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x10,
+	0xef, 0x06, 0x12, 0x25, 0x0a, 0x20, 0x47, 0x43, 0x50, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x43, 0x72, 0x65, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x10, 0xf1, 0x06, 0x12, 0x09, 0x0a, 0x04, 0x50, 0x79, 0x50,
+	0x49, 0x10, 0xf2, 0x06, 0x12, 0x0e, 0x0a, 0x09, 0x41, 0x6e, 0x74, 0x68, 0x72, 0x6f, 0x70, 0x69,
+	0x63, 0x10, 0xf3, 0x06, 0x12, 0x0b, 0x0a, 0x06, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x10, 0xf4,
+	0x06, 0x12, 0x10, 0x0a, 0x0b, 0x48, 0x75, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x46, 0x61, 0x63, 0x65,
+	0x10, 0xf5, 0x06, 0x12, 0x0a, 0x0a, 0x05, 0x56, 0x61, 0x75, 0x6c, 0x74, 0x10, 0xf6, 0x06, 0x12,
+	0x0b, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6c, 0x10, 0xf7, 0x06, 0x12, 0x0c, 0x0a, 0x07,
+	0x53, 0x68, 0x6f, 0x70, 0x69, 0x66, 0x79, 0x10, 0xf8, 0x06, 0x12, 0x13, 0x0a, 0x0e, 0x47, 0x69,
+	0x74, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x10, 0xf9, 0x06, 0x12,
+	0x0a, 0x0a, 0x05, 0x4e, 0x65, 0x74, 0x72, 0x63, 0x10, 0xfa, 0x06, 0x12, 0x0d, 0x0a, 0x08, 0x43,
+	0x49, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x10, 0xfb, 0x06, 0x12, 0x08, 0x0a, 0x03, 0x49, 0x61,
+	0x43, 0x10, 0xfc, 0x06, 0x12, 0x0e, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x73,
+	0x74, 0x10, 0xfd, 0x06, 0x12, 0x10, 0x0a, 0x0b, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x52, 0x65,
+	0x67, 0x65, 0x78, 0x10, 0xfe, 0x06, 0x42, 0x3d, 0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x72, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x73, 0x65, 0x63, 0x75,
+	0x72, 0x69, 0x74, 0x79, 0x2f, 0x74, 0x72, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x68, 0x6f, 0x67, 0x2f,
+	0x76, 0x33, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0x2f, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x73, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
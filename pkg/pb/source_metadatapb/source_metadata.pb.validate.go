@@ -958,6 +958,14 @@ func (m *Git) validate(all bool) error {
 
 	// no validation rules for Line
 
+	// no validation rules for AuthorName
+
+	// no validation rules for CommitterName
+
+	// no validation rules for CommitterEmail
+
+	// no validation rules for CommitTimestamp
+
 	if len(errors) > 0 {
 		return GitMultiError(errors)
 	}
@@ -1072,6 +1080,14 @@ func (m *Github) validate(all bool) error {
 
 	// no validation rules for Line
 
+	// no validation rules for AuthorName
+
+	// no validation rules for CommitterName
+
+	// no validation rules for CommitterEmail
+
+	// no validation rules for CommitTimestamp
+
 	if len(errors) > 0 {
 		return GithubMultiError(errors)
 	}
@@ -1184,6 +1200,14 @@ func (m *Gitlab) validate(all bool) error {
 
 	// no validation rules for Line
 
+	// no validation rules for AuthorName
+
+	// no validation rules for CommitterName
+
+	// no validation rules for CommitterEmail
+
+	// no validation rules for CommitTimestamp
+
 	if len(errors) > 0 {
 		return GitlabMultiError(errors)
 	}
@@ -2562,6 +2586,428 @@ var _ interface {
 	ErrorName() string
 } = SyslogValidationError{}
 
+// Validate checks the field values on AWS with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AWS) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AWS with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in AWSMultiError, or nil if none found.
+func (m *AWS) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AWS) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Arn
+
+	// no validation rules for Service
+
+	// no validation rules for Region
+
+	// no validation rules for Account
+
+	if len(errors) > 0 {
+		return AWSMultiError(errors)
+	}
+
+	return nil
+}
+
+// AWSMultiError is an error wrapping multiple validation errors returned by
+// AWS.ValidateAll() if the designated constraints aren't met.
+type AWSMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AWSMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AWSMultiError) AllErrors() []error { return m }
+
+// AWSValidationError is the validation error returned by AWS.Validate if
+// the designated constraints aren't met.
+type AWSValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AWSValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AWSValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AWSValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AWSValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AWSValidationError) ErrorName() string { return "AWSValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AWSValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAWS.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AWSValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AWSValidationError{}
+
+// Validate checks the field values on GCP with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *GCP) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GCP with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in GCPMultiError, or nil if none found.
+func (m *GCP) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GCP) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Resource
+
+	// no validation rules for Service
+
+	// no validation rules for Project
+
+	// no validation rules for Location
+
+	if len(errors) > 0 {
+		return GCPMultiError(errors)
+	}
+
+	return nil
+}
+
+// GCPMultiError is an error wrapping multiple validation errors returned by
+// GCP.ValidateAll() if the designated constraints aren't met.
+type GCPMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GCPMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GCPMultiError) AllErrors() []error { return m }
+
+// GCPValidationError is the validation error returned by GCP.Validate if
+// the designated constraints aren't met.
+type GCPValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GCPValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GCPValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GCPValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GCPValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GCPValidationError) ErrorName() string { return "GCPValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GCPValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGCP.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GCPValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GCPValidationError{}
+
+// Validate checks the field values on AzureResource with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AzureResource) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AzureResource with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in AzureResourceMultiError, or nil if none found.
+func (m *AzureResource) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AzureResource) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ResourceId
+
+	// no validation rules for Service
+
+	// no validation rules for Subscription
+
+	// no validation rules for Location
+
+	if len(errors) > 0 {
+		return AzureResourceMultiError(errors)
+	}
+
+	return nil
+}
+
+// AzureResourceMultiError is an error wrapping multiple validation errors returned by
+// AzureResource.ValidateAll() if the designated constraints aren't met.
+type AzureResourceMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AzureResourceMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AzureResourceMultiError) AllErrors() []error { return m }
+
+// AzureResourceValidationError is the validation error returned by AzureResource.Validate if
+// the designated constraints aren't met.
+type AzureResourceValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AzureResourceValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AzureResourceValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AzureResourceValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AzureResourceValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AzureResourceValidationError) ErrorName() string { return "AzureResourceValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AzureResourceValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAzureResource.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AzureResourceValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AzureResourceValidationError{}
+
+// Validate checks the field values on SSH with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SSH) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SSH with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in SSHMultiError, or nil if none found.
+func (m *SSH) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SSH) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Host
+
+	// no validation rules for User
+
+	// no validation rules for File
+
+	if len(errors) > 0 {
+		return SSHMultiError(errors)
+	}
+
+	return nil
+}
+
+// SSHMultiError is an error wrapping multiple validation errors returned by
+// SSH.ValidateAll() if the designated constraints aren't met.
+type SSHMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SSHMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SSHMultiError) AllErrors() []error { return m }
+
+// SSHValidationError is the validation error returned by SSH.Validate if
+// the designated constraints aren't met.
+type SSHValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SSHValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SSHValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SSHValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SSHValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SSHValidationError) ErrorName() string { return "SSHValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SSHValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSSH.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SSHValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SSHValidationError{}
+
 // Validate checks the field values on MetaData with the rules defined in the
 // proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
@@ -3299,6 +3745,130 @@ func (m *MetaData) validate(all bool) error {
 			}
 		}
 
+	case *MetaData_Aws:
+
+		if all {
+			switch v := interface{}(m.GetAws()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MetaDataValidationError{
+						field:  "Aws",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MetaDataValidationError{
+						field:  "Aws",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetAws()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MetaDataValidationError{
+					field:  "Aws",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *MetaData_Gcp:
+
+		if all {
+			switch v := interface{}(m.GetGcp()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MetaDataValidationError{
+						field:  "Gcp",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MetaDataValidationError{
+						field:  "Gcp",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetGcp()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MetaDataValidationError{
+					field:  "Gcp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *MetaData_AzureResource:
+
+		if all {
+			switch v := interface{}(m.GetAzureResource()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MetaDataValidationError{
+						field:  "AzureResource",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MetaDataValidationError{
+						field:  "AzureResource",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetAzureResource()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MetaDataValidationError{
+					field:  "AzureResource",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *MetaData_Ssh:
+
+		if all {
+			switch v := interface{}(m.GetSsh()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MetaDataValidationError{
+						field:  "Ssh",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MetaDataValidationError{
+						field:  "Ssh",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetSsh()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MetaDataValidationError{
+					field:  "Ssh",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
 	}
 
 	if len(errors) > 0 {
@@ -0,0 +1,174 @@
+package policy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+// field is a field of a finding that expressions can refer to.
+type field struct {
+	typ *cel.Type
+	get func(r *detectors.ResultWithMetadata) interface{}
+}
+
+var fields = map[string]field{
+	"detector": {cel.StringType, func(r *detectors.ResultWithMetadata) interface{} { return r.DetectorType.String() }},
+	"verified": {cel.BoolType, func(r *detectors.ResultWithMetadata) interface{} { return r.Verified }},
+	"severity": {cel.IntType, func(r *detectors.ResultWithMetadata) interface{} { return int64(r.Severity) }},
+	"redacted": {cel.StringType, func(r *detectors.ResultWithMetadata) interface{} { return r.Redacted }},
+	"entropy":  {cel.DoubleType, func(r *detectors.ResultWithMetadata) interface{} { return r.Entropy }},
+	"source":   {cel.StringType, func(r *detectors.ResultWithMetadata) interface{} { return r.SourceName }},
+	"source_type": {cel.StringType, func(r *detectors.ResultWithMetadata) interface{} {
+		return strings.TrimPrefix(r.SourceType.String(), "SOURCE_TYPE_")
+	}},
+	"file":       {cel.StringType, func(r *detectors.ResultWithMetadata) interface{} { return detectors.FilePath(r.SourceMetadata) }},
+	"line":       {cel.IntType, metadataGetter("line", true)},
+	"commit":     {cel.StringType, metadataGetter("commit", false)},
+	"repository": {cel.StringType, metadataGetter("repository", false)},
+	"email":      {cel.StringType, metadataGetter("email", false)},
+	"link":       {cel.StringType, metadataGetter("link", false)},
+	"extra": {cel.MapType(cel.StringType, cel.StringType), func(r *detectors.ResultWithMetadata) interface{} {
+		if r.ExtraData == nil {
+			return map[string]string{}
+		}
+		return r.ExtraData
+	}},
+}
+
+// severities are the constants severity compares with, such as HIGH.
+var severities = map[string]interface{}{
+	"LOW":      int64(detectors.SeverityLow),
+	"MEDIUM":   int64(detectors.SeverityMedium),
+	"HIGH":     int64(detectors.SeverityHigh),
+	"CRITICAL": int64(detectors.SeverityCritical),
+}
+
+// env declares the fields and severities to expressions.
+var env = func() *cel.Env {
+	opts := []cel.EnvOption{cel.HomogeneousAggregateLiterals()}
+	for name, f := range fields {
+		opts = append(opts, cel.Variable(name, f.typ))
+	}
+	for name := range severities {
+		opts = append(opts, cel.Variable(name, cel.IntType))
+	}
+	env, err := cel.NewEnv(opts...)
+	if err != nil {
+		panic(err)
+	}
+	return env
+}()
+
+// FieldNames returns the names of the fields expressions can refer to, sorted.
+func FieldNames() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// metadataGetter returns a getter for the field of the source metadata with the name, which is 0 or "" for sources
+// without it.
+func metadataGetter(name string, isInt bool) func(r *detectors.ResultWithMetadata) interface{} {
+	return func(r *detectors.ResultWithMetadata) interface{} {
+		v := metadataField(r.SourceMetadata, name)
+		if isInt {
+			if v.IsValid() {
+				if i, ok := v.Interface().(int64); ok {
+					return i
+				}
+			}
+			return int64(0)
+		}
+		if v.IsValid() {
+			if s, ok := v.Interface().(string); ok {
+				return s
+			}
+		}
+		return ""
+	}
+}
+
+// metadataField returns the field of the source metadata with the name, or an invalid value if the source has no
+// such field.
+func metadataField(metadata *source_metadatapb.MetaData, name string) protoreflect.Value {
+	if metadata == nil {
+		return protoreflect.Value{}
+	}
+	m := metadata.ProtoReflect()
+	oneof := m.WhichOneof(m.Descriptor().Oneofs().ByName("data"))
+	if oneof == nil || oneof.Kind() != protoreflect.MessageKind {
+		return protoreflect.Value{}
+	}
+	source := m.Get(oneof).Message()
+	f := source.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if f == nil || f.Cardinality() == protoreflect.Repeated {
+		return protoreflect.Value{}
+	}
+	return source.Get(f)
+}
+
+// Expr is a compiled expression that selects findings.
+type Expr struct {
+	src string
+	prg cel.Program
+}
+
+// Compile parses and type checks a CEL expression over the fields of a finding, which must be a bool, such as
+//
+//	verified && severity >= HIGH && !file.startsWith("test/")
+//
+// The fields are listed by FieldNames. severity is compared with the constants LOW, MEDIUM, HIGH, and CRITICAL, and
+// extra is the finding's extra data. Looking up a key of extra that a finding doesn't have is an error, which doesn't
+// match, so expressions should check for it first with has(extra.key) or "key" in extra.
+func Compile(src string) (*Expr, error) {
+	ast, issues := env.Compile(src)
+	if issues != nil && issues.Err() != nil {
+		var msgs []string
+		for _, err := range issues.Errors() {
+			msgs = append(msgs, fmt.Sprintf("column %d: %s", err.Location.Column()+1, err.Message))
+		}
+		return nil, fmt.Errorf("invalid expression: %s", strings.Join(msgs, "; "))
+	}
+	if !cel.BoolType.IsAssignableType(ast.OutputType()) {
+		return nil, fmt.Errorf("expression is a %s, expected a bool", ast.OutputType())
+	}
+	// Optimizing the program compiles regular expressions now, so invalid ones are reported here.
+	prg, err := env.Program(ast, cel.EvalOptions(cel.OptOptimize))
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression: %w", err)
+	}
+	return &Expr{src: src, prg: prg}, nil
+}
+
+// String returns the source of the expression.
+func (e *Expr) String() string {
+	return e.src
+}
+
+// Match returns true if the expression is true for the result. Expressions that fail to evaluate don't match.
+func (e *Expr) Match(r *detectors.ResultWithMetadata) bool {
+	vars := make(map[string]interface{}, len(fields)+len(severities))
+	for name, f := range fields {
+		get := f.get
+		vars[name] = func() interface{} { return get(r) }
+	}
+	for name, v := range severities {
+		vars[name] = v
+	}
+	out, _, err := e.prg.Eval(vars)
+	if err != nil {
+		return false
+	}
+	match, ok := out.Value().(bool)
+	return ok && match
+}
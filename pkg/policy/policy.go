@@ -0,0 +1,108 @@
+// Package policy classifies findings into the actions an organization takes on them, such as failing the build or
+// opening a ticket. A policy is a list of rules, each an expression over the fields of a finding and the action to
+// take on the findings it matches. The engine evaluates the policy, so a policy written once applies the same way to
+// every scan and pipeline that loads it.
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+// Action is what to do with a finding.
+type Action string
+
+const (
+	// ActionFail fails the scan, so that the build or pipeline running it fails.
+	ActionFail Action = "fail"
+	// ActionNotify findings are sent to the sinks that notify people, such as a chat webhook.
+	ActionNotify Action = "notify"
+	// ActionTicket findings are sent to the sinks that open tickets, such as an issue tracker.
+	ActionTicket Action = "ticket"
+	// ActionIgnore findings are suppressed.
+	ActionIgnore Action = "ignore"
+)
+
+// Actions are the actions rules can take, in order of severity.
+var Actions = []Action{ActionFail, ActionTicket, ActionNotify, ActionIgnore}
+
+// The keys of the extra data the engine tags findings with.
+const (
+	// ActionKey is the action of the rule that matched the finding.
+	ActionKey = "policy_action"
+	// RuleKey is the name of the rule that matched the finding.
+	RuleKey = "policy_rule"
+)
+
+// ParseAction parses the name of an action, such as "fail".
+func ParseAction(name string) (Action, error) {
+	for _, action := range Actions {
+		if strings.EqualFold(string(action), name) {
+			return action, nil
+		}
+	}
+	names := make([]string, len(Actions))
+	for i, action := range Actions {
+		names[i] = string(action)
+	}
+	return "", fmt.Errorf("unknown policy action %q, expected one of %s", name, strings.Join(names, ", "))
+}
+
+// Rule takes an action on the findings its expression matches.
+type Rule struct {
+	// Name identifies the rule in the extra data of the findings it matches. It defaults to the rule's position,
+	// such as "rule 1".
+	Name string
+	// When is the expression, such as `verified && severity >= HIGH`. See Compile for the syntax.
+	When   string
+	Action Action
+}
+
+// Policy applies the first of its rules that matches a finding.
+type Policy struct {
+	rules []compiledRule
+}
+
+type compiledRule struct {
+	Rule
+	when *Expr
+}
+
+// New returns a policy of the rules, which apply in the order given.
+func New(rules []Rule) (*Policy, error) {
+	p := &Policy{}
+	for i, rule := range rules {
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule %d", i+1)
+		}
+		if _, err := ParseAction(string(rule.Action)); err != nil {
+			return nil, fmt.Errorf("policy rule %s: %w", rule.Name, err)
+		}
+		when, err := Compile(rule.When)
+		if err != nil {
+			return nil, fmt.Errorf("policy rule %s: %w", rule.Name, err)
+		}
+		p.rules = append(p.rules, compiledRule{Rule: rule, when: when})
+	}
+	return p, nil
+}
+
+// Evaluate returns the first rule that matches the result, or false if none does.
+func (p *Policy) Evaluate(r *detectors.ResultWithMetadata) (Rule, bool) {
+	if p == nil {
+		return Rule{}, false
+	}
+	for _, rule := range p.rules {
+		if rule.when.Match(r) {
+			return rule.Rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// ActionOf returns the action the engine tagged the result with, or "" if no rule matched it.
+func ActionOf(r *detectors.ResultWithMetadata) Action {
+	return Action(r.ExtraData[ActionKey])
+}
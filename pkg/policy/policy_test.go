@@ -0,0 +1,125 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+)
+
+func TestCompile(t *testing.T) {
+	r := &detectors.ResultWithMetadata{
+		SourceType: sourcespb.SourceType_SOURCE_TYPE_GITHUB,
+		SourceName: "trufflehog - github",
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_Github{
+				Github: &source_metadatapb.Github{File: "deploy/prod.env", Line: 12, Repository: "https://github.com/acme/api.git"},
+			},
+		},
+		Entropy: 3.5,
+		Result: detectors.Result{
+			DetectorType: detectorspb.DetectorType_AWS,
+			Verified:     true,
+			Severity:     detectors.SeverityHigh,
+			ExtraData:    map[string]string{"managed_secret": "true"},
+		},
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`verified`, true},
+		{`!verified`, false},
+		{`verified && severity >= HIGH`, true},
+		{`severity > HIGH`, false},
+		{`severity in [CRITICAL, HIGH]`, true},
+		{`detector == "AWS" && source_type == "GITHUB"`, true},
+		{`detector in ["GCP", "Azure"] || file.startsWith("deploy/")`, true},
+		{`file.endsWith(".env") && repository.contains("acme")`, true},
+		{`file.matches(r'^deploy/\w+\.env$')`, true},
+		{`line > 10 && line <= 12 && entropy > 3.0`, true},
+		{`entropy < -1.5`, false},
+		{`extra.managed_secret == "true" && !has(extra.secret_store)`, true},
+		{`"managed_secret" in extra && !("allowlisted" in extra)`, true},
+		{`extra.secret_store == ""`, false},
+		{`commit == "" && (email == "" || false)`, true},
+	}
+	for _, test := range tests {
+		expr, err := Compile(test.expr)
+		if err != nil {
+			t.Errorf("%s: %v", test.expr, err)
+			continue
+		}
+		if got := expr.Match(r); got != test.want {
+			t.Errorf("%s: expected %t, got %t", test.expr, test.want, got)
+		}
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	tests := []struct {
+		expr string
+		err  string
+	}{
+		{`detector`, "expression is a string, expected a bool"},
+		{`verified &&`, "column 12: Syntax error"},
+		{`verfied`, "column 1: undeclared reference to 'verfied'"},
+		{`severity >= "high"`, "no matching overload for '_>=_'"},
+		{`line == "12"`, "no matching overload for '_==_'"},
+		{`detector in ["AWS", 1]`, "expected type 'string' but found 'int'"},
+		{`file.startsWith(1)`, "no matching overload for 'startsWith'"},
+		{`file.matches("[")`, "invalid expression"},
+		{`file.length() > 1`, "undeclared reference to 'length'"},
+		{`detector == "AWS`, "Syntax error"},
+		{`verified.x`, "does not support field selection"},
+	}
+	for _, test := range tests {
+		_, err := Compile(test.expr)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: expected error containing %q, got %v", test.expr, test.err, err)
+		}
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	p, err := New([]Rule{
+		{Name: "live", When: `verified`, Action: ActionFail},
+		{When: `file.startsWith("test/")`, Action: ActionIgnore},
+		{Name: "everything else", When: `true`, Action: ActionNotify},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixture := &detectors.ResultWithMetadata{
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_Filesystem{Filesystem: &source_metadatapb.Filesystem{File: "test/fixture.json"}},
+		},
+	}
+	tests := []struct {
+		r      *detectors.ResultWithMetadata
+		name   string
+		action Action
+	}{
+		{&detectors.ResultWithMetadata{Result: detectors.Result{Verified: true}}, "live", ActionFail},
+		{fixture, "rule 2", ActionIgnore},
+		{&detectors.ResultWithMetadata{}, "everything else", ActionNotify},
+	}
+	for _, test := range tests {
+		rule, ok := p.Evaluate(test.r)
+		if !ok || rule.Name != test.name || rule.Action != test.action {
+			t.Errorf("expected %s to %s, got %+v", test.name, test.action, rule)
+		}
+	}
+
+	var none *Policy
+	if _, ok := none.Evaluate(&detectors.ResultWithMetadata{}); ok {
+		t.Error("expected a nil policy to match nothing")
+	}
+	if _, err := New([]Rule{{When: `verified`, Action: "page"}}); err == nil || !strings.Contains(err.Error(), `rule 1: unknown policy action "page"`) {
+		t.Errorf("unexpected error for an unknown action: %v", err)
+	}
+}
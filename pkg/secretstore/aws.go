@@ -0,0 +1,69 @@
+package secretstore
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+)
+
+// AWSSecretsManager is the secrets of one AWS Secrets Manager region. Only the current version of each secret is read.
+type AWSSecretsManager struct {
+	client secretsmanageriface.SecretsManagerAPI
+}
+
+// NewAWSSecretsManager returns the store for the region, authenticating with the SDK's default credential chain. An
+// empty region uses the SDK's default, such as $AWS_REGION.
+func NewAWSSecretsManager(region string) (*AWSSecretsManager, error) {
+	cfg := aws.NewConfig()
+	if region != "" {
+		cfg.Region = aws.String(region)
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+		Config:            *cfg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &AWSSecretsManager{client: secretsmanager.New(sess)}, nil
+}
+
+// Name implements Store.
+func (s *AWSSecretsManager) Name() string {
+	return "aws-secrets-manager"
+}
+
+// Walk implements Store. Secrets are named after the last part of their name, so "prod/stripe_api_key" is named
+// "stripe_api_key", and the fields of secrets that are JSON objects are named after the field.
+func (s *AWSSecretsManager) Walk(ctx context.Context, fn func(name string, value []byte)) error {
+	var secrets []*secretsmanager.SecretListEntry
+	err := s.client.ListSecretsPagesWithContext(ctx, &secretsmanager.ListSecretsInput{},
+		func(page *secretsmanager.ListSecretsOutput, _ bool) bool {
+			secrets = append(secrets, page.SecretList...)
+			return true
+		})
+	if err != nil {
+		return err
+	}
+
+	for _, secret := range secrets {
+		out, err := s.client.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{SecretId: secret.ARN})
+		if err != nil {
+			return err
+		}
+		name := path.Base(aws.StringValue(secret.Name))
+		if out.SecretString != nil {
+			fn(name, []byte(*out.SecretString))
+			walkValues(json.RawMessage(*out.SecretString), fn)
+		}
+		if len(out.SecretBinary) > 0 {
+			fn(name, out.SecretBinary)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package secretstore
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+)
+
+type fakeSecretsManager struct {
+	secretsmanageriface.SecretsManagerAPI
+	values map[string]*secretsmanager.GetSecretValueOutput
+}
+
+func (f *fakeSecretsManager) ListSecretsPagesWithContext(_ aws.Context, _ *secretsmanager.ListSecretsInput, fn func(*secretsmanager.ListSecretsOutput, bool) bool, _ ...request.Option) error {
+	page := &secretsmanager.ListSecretsOutput{}
+	for arn := range f.values {
+		name := "prod/" + arn[strings.LastIndex(arn, ":")+1:]
+		page.SecretList = append(page.SecretList, &secretsmanager.SecretListEntry{ARN: aws.String(arn), Name: aws.String(name)})
+	}
+	fn(page, true)
+	return nil
+}
+
+func (f *fakeSecretsManager) GetSecretValueWithContext(_ aws.Context, in *secretsmanager.GetSecretValueInput, _ ...request.Option) (*secretsmanager.GetSecretValueOutput, error) {
+	return f.values[*in.SecretId], nil
+}
+
+func TestAWSSecretsManager(t *testing.T) {
+	store := &AWSSecretsManager{client: &fakeSecretsManager{values: map[string]*secretsmanager.GetSecretValueOutput{
+		"arn:aws:secretsmanager:us-east-1:123456789012:secret:db":  {SecretString: aws.String(`{"password":"db-password","DB_READONLY_PASSWORD":"reader-password"}`)},
+		"arn:aws:secretsmanager:us-east-1:123456789012:secret:key": {SecretBinary: []byte("binary-key")},
+	}}}
+
+	inv, err := Collect(context.Background(), store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"db-password", "reader-password", "binary-key"} {
+		if name, ok := inv.Lookup([]byte(secret)); !ok || name != "aws-secrets-manager" {
+			t.Errorf("%s: got: %q %t, want it in aws-secrets-manager", secret, name, ok)
+		}
+	}
+
+	if _, scope, ok := inv.Names().Near([]byte("DB_READONLY_PASSWORD=abc"), []byte("abc")); !ok || scope != "aws-secrets-manager" {
+		t.Errorf("got: %q %t, want the field name in aws-secrets-manager", scope, ok)
+	}
+	if _, _, ok := inv.Names().Near([]byte("password=abc"), []byte("abc")); ok {
+		t.Error("generic field names should not be added")
+	}
+}
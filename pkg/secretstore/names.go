@@ -0,0 +1,83 @@
+package secretstore
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Names is a set of the names, not values, of secrets configured in CI systems such as GitHub Actions. A finding
+// assigned to a variable of the same name is likely the production value of that secret.
+type Names struct {
+	// scopes maps a normalized secret name to where it's configured.
+	scopes map[string]string
+}
+
+// NewNames returns an empty set of names.
+func NewNames() *Names {
+	return &Names{scopes: map[string]string{}}
+}
+
+// minNameLen is the shortest normalized name that's matched. Shorter names, such as "PW", match too many variables.
+const minNameLen = 4
+
+// Add adds the name of a secret configured in the scope, such as "actions:trufflesecurity".
+func (n *Names) Add(scope, name string) {
+	if key := normalizeName(name); len(key) >= minNameLen {
+		n.scopes[key] = scope
+	}
+}
+
+// Merge adds the names of other to the set.
+func (n *Names) Merge(other *Names) {
+	if other == nil {
+		return
+	}
+	for key, scope := range other.scopes {
+		n.scopes[key] = scope
+	}
+}
+
+// Len returns the number of names in the set.
+func (n *Names) Len() int {
+	if n == nil {
+		return 0
+	}
+	return len(n.scopes)
+}
+
+var identifierPat = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_\-]*`)
+
+// Near returns the name of a configured secret that raw is assigned to in data, and where it's configured. The
+// identifiers on the line of raw's first occurrence, before it, are checked nearest first, so "NPM_TOKEN=npm_..." and
+// `"npmToken": "npm_..."` both match the secret NPM_TOKEN.
+func (n *Names) Near(data, raw []byte) (name, scope string, ok bool) {
+	if n.Len() == 0 || len(raw) == 0 {
+		return "", "", false
+	}
+	i := bytes.Index(data, raw)
+	if i < 0 {
+		return "", "", false
+	}
+	line := data[bytes.LastIndexByte(data[:i], '\n')+1 : i]
+
+	identifiers := identifierPat.FindAll(line, -1)
+	for j := len(identifiers) - 1; j >= 0; j-- {
+		if scope, ok := n.scopes[normalizeName(string(identifiers[j]))]; ok {
+			return string(identifiers[j]), scope, true
+		}
+	}
+	return "", "", false
+}
+
+// normalizeName uppercases the name and drops everything but letters and digits, so that names match across the
+// casing and separator conventions of languages and file formats.
+func normalizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, name)
+}
@@ -0,0 +1,115 @@
+// Package secretstore builds inventories of the secrets held in secret managers, such as HashiCorp Vault and AWS
+// Secrets Manager, so that findings can be recognized as managed secrets that leaked out of their store. A managed
+// secret is in use by something, unlike an unknown token that may be a test value, so a leak is more severe.
+//
+// Inventories only hold SHA-256 hashes of the secret values, as computed by allowlist.Hash.
+package secretstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/allowlist"
+)
+
+// Store is a secret manager whose secrets can be listed.
+type Store interface {
+	// Name identifies the store in results, such as "vault".
+	Name() string
+	// Walk calls fn with the name and value of every secret in the store. The name is what an application would
+	// likely call the secret, such as a field of a Vault secret, rather than its full path.
+	Walk(ctx context.Context, fn func(name string, value []byte)) error
+}
+
+// Inventory is the set of secret hashes held in one or more stores.
+type Inventory struct {
+	// stores maps a secret hash to the name of the store holding it.
+	stores map[string]string
+	// names holds the names of the secrets, scoped to their store.
+	names *Names
+}
+
+// NewInventory returns an empty Inventory.
+func NewInventory() *Inventory {
+	return &Inventory{stores: map[string]string{}, names: NewNames()}
+}
+
+// Collect returns the inventory of the stores. Any store failing fails the whole collection, since a partial inventory
+// would silently downgrade the findings of the missing store.
+func Collect(ctx context.Context, stores ...Store) (*Inventory, error) {
+	inv := NewInventory()
+	for _, store := range stores {
+		err := store.Walk(ctx, func(name string, value []byte) {
+			inv.Add(store.Name(), value)
+			inv.AddName(store.Name(), name)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not list secrets in %s: %w", store.Name(), err)
+		}
+	}
+	return inv, nil
+}
+
+// Add adds a secret value held in the named store. Values that are JSON objects, as secrets with several fields often
+// are, also add each of their string fields, since a finding is usually a single field.
+func (i *Inventory) Add(store string, value []byte) {
+	if len(value) == 0 {
+		return
+	}
+	i.stores[allowlist.Hash(value)] = store
+
+	var fields map[string]interface{}
+	if json.Unmarshal(value, &fields) != nil {
+		return
+	}
+	for _, field := range fields {
+		if s, ok := field.(string); ok && s != "" {
+			i.stores[allowlist.Hash([]byte(s))] = store
+		}
+	}
+}
+
+// genericNames are secret names that say nothing about which secret a variable holds, such as the "password" field of
+// a Vault secret. They're not added to the names of an inventory, since most findings are assigned to one of them.
+var genericNames = map[string]bool{
+	"PASSWORD": true,
+	"PASSWD":   true,
+	"SECRET":   true,
+	"TOKEN":    true,
+	"APIKEY":   true,
+	"VALUE":    true,
+	"DATA":     true,
+	"USERNAME": true,
+	"USER":     true,
+	"PASS":     true,
+}
+
+// AddName adds the name of a secret held in the named store, unless it's generic.
+func (i *Inventory) AddName(store, name string) {
+	if !genericNames[normalizeName(name)] {
+		i.names.Add(store, name)
+	}
+}
+
+// Names returns the names of the secrets in the inventory, scoped to the store holding them.
+func (i *Inventory) Names() *Names {
+	if i == nil {
+		return nil
+	}
+	return i.names
+}
+
+// Lookup returns the name of the store holding the raw secret, and false if it isn't a managed secret.
+func (i *Inventory) Lookup(raw []byte) (string, bool) {
+	if i == nil {
+		return "", false
+	}
+	store, ok := i.stores[allowlist.Hash(raw)]
+	return store, ok
+}
+
+// Len returns the number of hashes in the inventory.
+func (i *Inventory) Len() int {
+	return len(i.stores)
+}
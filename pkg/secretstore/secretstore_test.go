@@ -0,0 +1,96 @@
+package secretstore
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestInventory(t *testing.T) {
+	inv := NewInventory()
+	inv.Add("aws-secrets-manager", []byte(`{"username":"app","password":"hunter2-but-longer"}`))
+	inv.Add("vault", []byte("ghp_exampleexampleexample"))
+
+	tests := map[string]struct {
+		raw       string
+		wantStore string
+	}{
+		"whole value":       {raw: "ghp_exampleexampleexample", wantStore: "vault"},
+		"JSON field":        {raw: "hunter2-but-longer", wantStore: "aws-secrets-manager"},
+		"whole JSON secret": {raw: `{"username":"app","password":"hunter2-but-longer"}`, wantStore: "aws-secrets-manager"},
+		"unmanaged":         {raw: "ghp_somethingelse"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			store, ok := inv.Lookup([]byte(tt.raw))
+			if ok != (tt.wantStore != "") || store != tt.wantStore {
+				t.Errorf("got: %q %t, want: %q", store, ok, tt.wantStore)
+			}
+		})
+	}
+
+	var nilInv *Inventory
+	if _, ok := nilInv.Lookup([]byte("anything")); ok {
+		t.Error("a nil inventory should hold nothing")
+	}
+}
+
+// fakeVault serves a KV version 2 engine at "secret" and a version 1 engine at "kv".
+func fakeVault(t *testing.T) *httptest.Server {
+	responses := map[string]interface{}{
+		"GET /v1/sys/internal/ui/mounts/secret": map[string]interface{}{"data": map[string]interface{}{"options": map[string]string{"version": "2"}}},
+		"GET /v1/sys/internal/ui/mounts/kv":     map[string]interface{}{"data": map[string]interface{}{"options": nil}},
+		"LIST /v1/secret/metadata/":             map[string]interface{}{"data": map[string]interface{}{"keys": []string{"app/", "root-token"}}},
+		"LIST /v1/secret/metadata/app/":         map[string]interface{}{"data": map[string]interface{}{"keys": []string{"db"}}},
+		"GET /v1/secret/data/app/db":            map[string]interface{}{"data": map[string]interface{}{"data": map[string]string{"password": "db-password", "REPLICA_PASSWORD": "replica-password"}}},
+		"GET /v1/secret/data/root-token":        map[string]interface{}{"data": map[string]interface{}{"data": map[string]string{"token": "hvs.roottoken"}}},
+		"LIST /v1/kv/":                          map[string]interface{}{"data": map[string]interface{}{"keys": []string{"legacy"}}},
+		"GET /v1/kv/legacy":                     map[string]interface{}{"data": map[string]string{"api_key": "legacy-key"}},
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		res, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+}
+
+func TestVault(t *testing.T) {
+	server := fakeVault(t)
+	defer server.Close()
+
+	vault := &Vault{Address: server.URL, Token: "test-token", Mounts: []string{"secret", "/kv/"}, client: server.Client()}
+	inv, err := Collect(context.Background(), vault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"db-password", "replica-password", "hvs.roottoken", "legacy-key"} {
+		if store, ok := inv.Lookup([]byte(secret)); !ok || store != "vault" {
+			t.Errorf("%s: got: %q %t, want it in vault", secret, store, ok)
+		}
+	}
+	if inv.Len() != 4 {
+		t.Errorf("got %d hashes, want 4", inv.Len())
+	}
+	// Generic field names, such as password and api_key, aren't added.
+	if inv.Names().Len() != 1 {
+		t.Errorf("got %d names, want 1", inv.Names().Len())
+	}
+	if name, scope, ok := inv.Names().Near([]byte(`replicaPassword: "abc"`), []byte("abc")); !ok || name != "replicaPassword" || scope != "vault" {
+		t.Errorf("got: %q %q %t, want the REPLICA_PASSWORD field of a vault secret", name, scope, ok)
+	}
+
+	vault = &Vault{Address: server.URL, Token: "wrong", Mounts: []string{"secret"}, client: server.Client()}
+	if _, err := Collect(context.Background(), vault); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("expected a permission error, got: %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package secretstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+)
+
+// Vault is the KV secrets engines of a HashiCorp Vault server. Both versions of the KV engine are supported. Only the
+// current version of each KV version 2 secret is read.
+type Vault struct {
+	// Address is the server's address, such as https://vault.internal:8200.
+	Address string
+	Token   string
+	// Mounts are the paths the KV engines are mounted at, such as "secret".
+	Mounts []string
+
+	client *http.Client
+}
+
+// Name implements Store.
+func (v *Vault) Name() string {
+	return "vault"
+}
+
+// Walk implements Store.
+func (v *Vault) Walk(ctx context.Context, fn func(name string, value []byte)) error {
+	if v.client == nil {
+		v.client = common.SaneHttpClient()
+	}
+	for _, mount := range v.Mounts {
+		mount = strings.Trim(mount, "/")
+		version, err := v.kvVersion(ctx, mount)
+		if err != nil {
+			return err
+		}
+		if err := v.walk(ctx, mount, version, "", fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// kvVersion returns the version of the KV engine mounted at mount.
+func (v *Vault) kvVersion(ctx context.Context, mount string) (string, error) {
+	var res struct {
+		Data struct {
+			Options map[string]string `json:"options"`
+		} `json:"data"`
+	}
+	if _, err := v.get(ctx, "GET", "sys/internal/ui/mounts/"+mount, &res); err != nil {
+		return "", err
+	}
+	if res.Data.Options["version"] == "2" {
+		return "2", nil
+	}
+	return "1", nil
+}
+
+func (v *Vault) walk(ctx context.Context, mount, version, path string, fn func(name string, value []byte)) error {
+	listPath, readPath := mount+"/"+path, mount+"/"+path
+	if version == "2" {
+		listPath, readPath = mount+"/metadata/"+path, mount+"/data/"+path
+	}
+
+	var list struct {
+		Data struct {
+			Keys []string `json:"keys"`
+		} `json:"data"`
+	}
+	found, err := v.get(ctx, "LIST", listPath, &list)
+	if err != nil || !found {
+		return err
+	}
+	for _, key := range list.Data.Keys {
+		if strings.HasSuffix(key, "/") {
+			if err := v.walk(ctx, mount, version, path+key, fn); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var secret struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if found, err := v.get(ctx, "GET", readPath+key, &secret); err != nil {
+			return err
+		} else if !found {
+			continue
+		}
+		data := secret.Data
+		if version == "2" {
+			var versioned struct {
+				Data json.RawMessage `json:"data"`
+			}
+			if err := json.Unmarshal(data, &versioned); err != nil {
+				return err
+			}
+			data = versioned.Data
+		}
+		walkValues(data, fn)
+	}
+	return nil
+}
+
+// get makes a request to the Vault API and decodes the response into out. It returns false if the path isn't found,
+// which Vault also returns for a LIST of an empty directory, or a deleted secret.
+func (v *Vault) get(ctx context.Context, method, path string, out interface{}) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(v.Address, "/")+"/v1/"+path, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("X-Vault-Token", v.Token)
+	res, err := v.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return false, nil
+	case res.StatusCode != http.StatusOK:
+		return false, fmt.Errorf("%s %s: unexpected status %d", method, path, res.StatusCode)
+	}
+	return true, json.NewDecoder(res.Body).Decode(out)
+}
+
+// walkValues calls fn with the name and string value of every one of a secret's fields.
+func walkValues(data json.RawMessage, fn func(name string, value []byte)) {
+	var fields map[string]interface{}
+	if json.Unmarshal(data, &fields) != nil {
+		return
+	}
+	for name, field := range fields {
+		if s, ok := field.(string); ok {
+			fn(name, []byte(s))
+		}
+	}
+}
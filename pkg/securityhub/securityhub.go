@@ -0,0 +1,200 @@
+// Package securityhub publishes the findings of a scan to AWS Security Hub in the AWS Security Finding Format (ASFF).
+// Importing it registers the "securityhub" output sink.
+//
+// A finding's ID is derived from its secret and location, so publishing the results of a later scan updates the
+// findings already in Security Hub instead of duplicating them.
+package securityhub
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/securityhub"
+	"github.com/aws/aws-sdk-go/service/securityhub/securityhubiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/allowlist"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+)
+
+const (
+	schemaVersion = "2018-10-08"
+	// findingType is the ASFF finding type of leaked credentials.
+	findingType = "Sensitive Data Identifications/Passwords"
+	// maxBatchSize is the most findings BatchImportFindings accepts at once.
+	maxBatchSize = 100
+	// maxDescription is the longest description ASFF allows.
+	maxDescription = 1024
+)
+
+// Publisher imports findings into one account and region's Security Hub.
+type Publisher struct {
+	client  securityhubiface.SecurityHubAPI
+	account string
+	region  string
+	// BatchSize is the number of findings imported per request, at most 100.
+	BatchSize int
+}
+
+// New returns a Publisher for the region, authenticating with the SDK's default credential chain. An empty region uses
+// the SDK's default, such as $AWS_REGION, and an empty account is that of the credentials.
+func New(ctx context.Context, region, account string) (*Publisher, error) {
+	cfg := aws.NewConfig()
+	if region != "" {
+		cfg.Region = aws.String(region)
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+		Config:            *cfg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if account == "" {
+		identity, err := sts.New(sess).GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{})
+		if err != nil {
+			return nil, fmt.Errorf("could not look up the AWS account: %w", err)
+		}
+		account = aws.StringValue(identity.Account)
+	}
+	return NewPublisher(securityhub.New(sess), account, aws.StringValue(sess.Config.Region)), nil
+}
+
+// NewPublisher returns a Publisher. Findings are imported with the account's default product, which Security Hub
+// accepts without integrating a third party product.
+func NewPublisher(client securityhubiface.SecurityHubAPI, account, region string) *Publisher {
+	return &Publisher{client: client, account: account, region: region, BatchSize: maxBatchSize}
+}
+
+// Publish imports the findings in batches. It returns the number imported, and an error if any were rejected.
+func (p *Publisher) Publish(ctx context.Context, results []findings.Finding) (int, error) {
+	now := time.Now().UTC()
+	batchSize := p.BatchSize
+	if batchSize <= 0 || batchSize > maxBatchSize {
+		batchSize = maxBatchSize
+	}
+
+	imported := 0
+	var failures []string
+	for start := 0; start < len(results); start += batchSize {
+		end := start + batchSize
+		if end > len(results) {
+			end = len(results)
+		}
+		batch := make([]*securityhub.AwsSecurityFinding, 0, end-start)
+		for i := start; i < end; i++ {
+			batch = append(batch, p.Finding(&results[i], now))
+		}
+
+		out, err := p.client.BatchImportFindingsWithContext(ctx, &securityhub.BatchImportFindingsInput{Findings: batch})
+		if err != nil {
+			return imported, err
+		}
+		imported += int(aws.Int64Value(out.SuccessCount))
+		for _, failed := range out.FailedFindings {
+			failures = append(failures, fmt.Sprintf("%s: %s", aws.StringValue(failed.ErrorCode), aws.StringValue(failed.ErrorMessage)))
+		}
+	}
+	if len(failures) > 0 {
+		return imported, fmt.Errorf("%d findings were rejected, first: %s", len(failures), failures[0])
+	}
+	return imported, nil
+}
+
+// Finding converts a finding to ASFF. The raw secret is never included.
+func (p *Publisher) Finding(f *findings.Finding, now time.Time) *securityhub.AwsSecurityFinding {
+	timestamp := aws.String(now.Format(time.RFC3339))
+	location := f.Location()
+	resource := location
+	if link, ok := f.Metadata["link"].(string); ok && link != "" {
+		resource = link
+	}
+	if resource == "" {
+		resource = f.SourceName
+	}
+
+	finding := &securityhub.AwsSecurityFinding{
+		SchemaVersion: aws.String(schemaVersion),
+		Id:            aws.String(allowlist.Hash([]byte(f.DetectorType + "\x00" + f.Raw + "\x00" + location))),
+		ProductArn:    aws.String(fmt.Sprintf("arn:aws:securityhub:%s:%s:product/%s/default", p.region, p.account, p.account)),
+		GeneratorId:   aws.String("trufflehog/" + strings.ToLower(f.DetectorType)),
+		AwsAccountId:  aws.String(p.account),
+		Types:         aws.StringSlice([]string{findingType}),
+		CreatedAt:     timestamp,
+		UpdatedAt:     timestamp,
+		Severity:      &securityhub.Severity{Label: aws.String(severityLabel(f.Severity))},
+		Title:         aws.String(fmt.Sprintf("Leaked %s secret", f.DetectorType)),
+		Description:   aws.String(truncate(description(f, location), maxDescription)),
+		ProductName:   aws.String("TruffleHog"),
+		CompanyName:   aws.String("Truffle Security"),
+		Resources: []*securityhub.Resource{{
+			Type: aws.String("Other"),
+			Id:   aws.String(truncate(resource, 512)),
+		}},
+		ProductFields: map[string]*string{
+			"trufflehog/DetectorType": aws.String(f.DetectorType),
+			"trufflehog/Verified":     aws.String(fmt.Sprint(f.Verified)),
+			"trufflehog/SourceName":   aws.String(f.SourceName),
+			"trufflehog/Fingerprint":  aws.String(f.Fingerprint()),
+		},
+	}
+	if f.Verified {
+		finding.Confidence = aws.Int64(100)
+	}
+	if dt, err := detectors.ParseDetectorType(f.DetectorType); err == nil {
+		if remediation := detectors.RemediationFor(dt); remediation != nil {
+			finding.Remediation = &securityhub.Remediation{Recommendation: &securityhub.Recommendation{
+				Text: aws.String(remediation.Guidance),
+			}}
+			if remediation.URL != "" {
+				finding.Remediation.Recommendation.Url = aws.String(remediation.URL)
+			}
+		}
+	}
+	return finding
+}
+
+func description(f *findings.Finding, location string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "TruffleHog found a %s secret", f.DetectorType)
+	if f.Verified {
+		b.WriteString(" and verified that it's live")
+	}
+	fmt.Fprintf(&b, " in %s", f.SourceName)
+	if location != "" {
+		fmt.Fprintf(&b, " at %s", location)
+	}
+	b.WriteString(".")
+	if f.Redacted != "" {
+		fmt.Fprintf(&b, " Secret: %s.", f.Redacted)
+	}
+	return b.String()
+}
+
+// severityLabel returns the ASFF label of a severity.
+func severityLabel(s detectors.Severity) string {
+	switch s {
+	case detectors.SeverityCritical:
+		return securityhub.SeverityLabelCritical
+	case detectors.SeverityHigh:
+		return securityhub.SeverityLabelHigh
+	case detectors.SeverityMedium:
+		return securityhub.SeverityLabelMedium
+	case detectors.SeverityLow:
+		return securityhub.SeverityLabelLow
+	default:
+		return securityhub.SeverityLabelInformational
+	}
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
@@ -0,0 +1,109 @@
+package securityhub
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/securityhub"
+	"github.com/aws/aws-sdk-go/service/securityhub/securityhubiface"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+)
+
+type fakeSecurityHub struct {
+	securityhubiface.SecurityHubAPI
+	batches [][]*securityhub.AwsSecurityFinding
+	// reject is the ID of a finding to reject.
+	reject string
+}
+
+func (f *fakeSecurityHub) BatchImportFindingsWithContext(_ aws.Context, in *securityhub.BatchImportFindingsInput, _ ...request.Option) (*securityhub.BatchImportFindingsOutput, error) {
+	f.batches = append(f.batches, in.Findings)
+	out := &securityhub.BatchImportFindingsOutput{SuccessCount: aws.Int64(0), FailedCount: aws.Int64(0)}
+	for _, finding := range in.Findings {
+		if aws.StringValue(finding.Id) == f.reject {
+			*out.FailedCount++
+			out.FailedFindings = append(out.FailedFindings, &securityhub.ImportFindingsError{
+				Id: finding.Id, ErrorCode: aws.String("InvalidInput"), ErrorMessage: aws.String("rejected"),
+			})
+			continue
+		}
+		*out.SuccessCount++
+	}
+	return out, nil
+}
+
+func testFindings(n int) []findings.Finding {
+	results := make([]findings.Finding, n)
+	for i := range results {
+		results[i] = findings.Finding{
+			DetectorType: "AWS",
+			Verified:     i == 0,
+			Raw:          fmt.Sprintf("AKIAEXAMPLE%d", i),
+			Redacted:     fmt.Sprintf("AKIAEXAMPLE%d", i),
+			Severity:     detectors.SeverityHigh,
+			SourceName:   "trufflehog - github",
+			Metadata: map[string]interface{}{
+				"file": "deploy.sh",
+				"line": float64(i + 1),
+				"link": fmt.Sprintf("https://github.com/org/repo/blob/abc/deploy.sh#L%d", i+1),
+			},
+		}
+	}
+	return results
+}
+
+func TestFinding(t *testing.T) {
+	p := NewPublisher(nil, "123456789012", "us-east-1")
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	findings := testFindings(1)
+	got := p.Finding(&findings[0], now)
+
+	if err := got.Validate(); err != nil {
+		t.Errorf("invalid ASFF: %v", err)
+	}
+	if aws.StringValue(got.ProductArn) != "arn:aws:securityhub:us-east-1:123456789012:product/123456789012/default" {
+		t.Errorf("unexpected product ARN: %s", aws.StringValue(got.ProductArn))
+	}
+	if aws.StringValue(got.Severity.Label) != "HIGH" || aws.StringValue(got.CreatedAt) != "2024-01-02T03:04:05Z" {
+		t.Errorf("unexpected finding: %s", got)
+	}
+	if id := aws.StringValue(got.Resources[0].Id); id != "https://github.com/org/repo/blob/abc/deploy.sh#L1" {
+		t.Errorf("unexpected resource: %s", id)
+	}
+	if got.Remediation == nil || aws.StringValue(got.Remediation.Recommendation.Url) == "" {
+		t.Errorf("expected remediation for AWS: %s", got)
+	}
+
+	// The finding ID is stable across scans.
+	if again := p.Finding(&findings[0], now.Add(time.Hour)); aws.StringValue(again.Id) != aws.StringValue(got.Id) {
+		t.Error("the ID of the same finding changed")
+	}
+}
+
+func TestPublish(t *testing.T) {
+	fake := &fakeSecurityHub{}
+	p := NewPublisher(fake, "123456789012", "us-east-1")
+	p.BatchSize = 2
+
+	findings := testFindings(5)
+	imported, err := p.Publish(context.Background(), findings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported != 5 || len(fake.batches) != 3 || len(fake.batches[2]) != 1 {
+		t.Errorf("got %d imported in %d batches, want 5 in 3", imported, len(fake.batches))
+	}
+
+	fake = &fakeSecurityHub{reject: aws.StringValue(p.Finding(&findings[1], time.Now()).Id)}
+	p = NewPublisher(fake, "123456789012", "us-east-1")
+	imported, err = p.Publish(context.Background(), findings)
+	if err == nil || imported != 4 {
+		t.Errorf("expected 4 imported and an error, got %d and %v", imported, err)
+	}
+}
@@ -0,0 +1,38 @@
+package securityhub
+
+import (
+	"context"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/output"
+)
+
+func init() {
+	output.RegisterSink("securityhub", newSink)
+}
+
+// newSink publishes findings to the Security Hub of the region and account options, which default to the SDK's region
+// and the account of its credentials.
+func newSink(options map[string]string) (output.Sink, error) {
+	if err := output.CheckSinkOptions(options, "region", "account"); err != nil {
+		return nil, err
+	}
+	publisher, err := New(context.Background(), options["region"], options["account"])
+	if err != nil {
+		return nil, err
+	}
+	return NewSink(publisher), nil
+}
+
+// NewSink returns a sink that publishes the findings of a scan with publisher, a batch at a time.
+func NewSink(publisher *Publisher) *output.FindingsSink {
+	return &output.FindingsSink{
+		BatchSize: publisher.BatchSize,
+		Send: func(ctx context.Context, batch []findings.Finding) error {
+			imported, err := publisher.Publish(ctx, batch)
+			logging.FromContext(ctx).WithField("findings", imported).Debug("published findings to Security Hub")
+			return err
+		},
+	}
+}
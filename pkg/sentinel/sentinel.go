@@ -0,0 +1,173 @@
+// Package sentinel sends the findings of a scan to Microsoft Sentinel through the Azure Monitor Logs Ingestion API,
+// which writes them to a Log Analytics table by way of a data collection rule (DCR). Importing it registers the
+// "sentinel" output sink.
+package sentinel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/allowlist"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+)
+
+const (
+	apiVersion = "2023-01-01"
+	// resource is the Azure AD resource that ingestion tokens are issued for.
+	resource = "https://monitor.azure.com"
+	// maxBatchBytes keeps each request under the API's 1MB limit.
+	maxBatchBytes = 900 * 1024
+)
+
+// DefaultStream is the DCR stream findings are sent to unless another is configured.
+const DefaultStream = "Custom-TruffleHog_CL"
+
+// Client sends findings to a DCR stream.
+type Client struct {
+	// Endpoint is the logs ingestion endpoint of the data collection endpoint or rule, such as
+	// https://my-dce-abcd.eastus-1.ingest.monitor.azure.com.
+	Endpoint string
+	// RuleID is the immutable ID of the DCR, such as dcr-0123456789abcdef0123456789abcdef.
+	RuleID string
+	// Stream is the DCR stream declared for the records, see DefaultStream.
+	Stream string
+
+	authorizer autorest.Authorizer
+	client     *http.Client
+}
+
+// New returns a Client that authenticates with the environment's Azure credentials: a service principal from
+// $AZURE_TENANT_ID, $AZURE_CLIENT_ID, and $AZURE_CLIENT_SECRET, or otherwise a managed identity.
+func New(endpoint, ruleID, stream string) (*Client, error) {
+	authorizer, err := auth.NewAuthorizerFromEnvironmentWithResource(resource)
+	if err != nil {
+		return nil, fmt.Errorf("could not load Azure credentials: %w", err)
+	}
+	return NewClient(endpoint, ruleID, stream, authorizer), nil
+}
+
+// NewClient returns a Client that authorizes requests with authorizer.
+func NewClient(endpoint, ruleID, stream string, authorizer autorest.Authorizer) *Client {
+	if stream == "" {
+		stream = DefaultStream
+	}
+	return &Client{Endpoint: endpoint, RuleID: ruleID, Stream: stream, authorizer: authorizer, client: common.SaneHttpClient()}
+}
+
+// Record is the row written for a finding. The DCR's stream declaration must have these columns.
+type Record struct {
+	TimeGenerated string
+	DetectorType  string
+	Verified      bool
+	Severity      string
+	SourceName    string
+	Location      string `json:",omitempty"`
+	Link          string `json:",omitempty"`
+	Redacted      string `json:",omitempty"`
+	Fingerprint   string
+	// FindingID identifies the secret at its location, so that repeated scans can be deduplicated with a query.
+	FindingID   string
+	Remediation string `json:",omitempty"`
+}
+
+// NewRecord converts a finding to a record. The raw secret is never included.
+func NewRecord(f *findings.Finding, now time.Time) Record {
+	location := f.Location()
+	record := Record{
+		TimeGenerated: now.Format(time.RFC3339),
+		DetectorType:  f.DetectorType,
+		Verified:      f.Verified,
+		Severity:      f.Severity.String(),
+		SourceName:    f.SourceName,
+		Location:      location,
+		Redacted:      f.Redacted,
+		Fingerprint:   f.Fingerprint(),
+		FindingID:     allowlist.Hash([]byte(f.DetectorType + "\x00" + f.Raw + "\x00" + location)),
+	}
+	if link, ok := f.Metadata["link"].(string); ok {
+		record.Link = link
+	}
+	if dt, err := detectors.ParseDetectorType(f.DetectorType); err == nil {
+		if remediation := detectors.RemediationFor(dt); remediation != nil {
+			record.Remediation = remediation.Guidance
+		}
+	}
+	return record
+}
+
+// Send sends a record for each finding, in as many requests as the API's size limit requires. It returns the number
+// of records sent.
+func (c *Client) Send(ctx context.Context, results []findings.Finding) (int, error) {
+	now := time.Now().UTC()
+	sent := 0
+	var batch []json.RawMessage
+	size := 0
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := c.upload(ctx, batch); err != nil {
+			return err
+		}
+		sent += len(batch)
+		batch, size = batch[:0], 0
+		return nil
+	}
+
+	for i := range results {
+		data, err := json.Marshal(NewRecord(&results[i], now))
+		if err != nil {
+			return sent, err
+		}
+		if size+len(data)+1 > maxBatchBytes {
+			if err := flush(); err != nil {
+				return sent, err
+			}
+		}
+		batch = append(batch, data)
+		size += len(data) + 1
+	}
+	return sent, flush()
+}
+
+func (c *Client) upload(ctx context.Context, records []json.RawMessage) error {
+	body, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("%s/dataCollectionRules/%s/streams/%s?api-version=%s",
+		strings.TrimSuffix(c.Endpoint, "/"), url.PathEscape(c.RuleID), url.PathEscape(c.Stream), apiVersion)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req, err = autorest.Prepare(req, c.authorizer.WithAuthorization())
+	if err != nil {
+		return fmt.Errorf("could not authorize request: %w", err)
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("unexpected status %d: %s", res.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	return nil
+}
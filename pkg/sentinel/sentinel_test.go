@@ -0,0 +1,91 @@
+package sentinel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+)
+
+func testFindings(n int) []findings.Finding {
+	results := make([]findings.Finding, n)
+	for i := range results {
+		results[i] = findings.Finding{
+			DetectorType: "AWS",
+			Verified:     i == 0,
+			Raw:          fmt.Sprintf("AKIAEXAMPLE%d", i),
+			Redacted:     "AKIAEXAMPLE",
+			Severity:     detectors.SeverityHigh,
+			SourceName:   "trufflehog - github",
+			Metadata: map[string]interface{}{
+				"file": "deploy.sh",
+				"line": float64(i + 1),
+				"link": fmt.Sprintf("https://github.com/org/repo/blob/abc/deploy.sh#L%d", i+1),
+			},
+		}
+	}
+	return results
+}
+
+func TestNewRecord(t *testing.T) {
+	findings := testFindings(1)
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	record := NewRecord(&findings[0], now)
+
+	if record.TimeGenerated != "2024-01-02T03:04:05Z" || record.Severity != "high" || !record.Verified {
+		t.Errorf("unexpected record: %+v", record)
+	}
+	if record.Location != "deploy.sh:1" || record.Link == "" || record.Remediation == "" {
+		t.Errorf("unexpected location or remediation: %+v", record)
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), findings[0].Raw) {
+		t.Error("the record includes the raw secret")
+	}
+}
+
+func TestSend(t *testing.T) {
+	var records []Record
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dataCollectionRules/dcr-abc/streams/Custom-TruffleHog_CL" || r.URL.Query().Get("api-version") != apiVersion {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body []Record
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requests++
+		records = append(records, body...)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "dcr-abc", "", autorest.NullAuthorizer{})
+	sent, err := client.Send(context.Background(), testFindings(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent != 3 || requests != 1 || len(records) != 3 {
+		t.Errorf("got %d sent in %d requests, want 3 in 1", sent, requests)
+	}
+
+	client = NewClient(server.URL, "dcr-other", "", autorest.NullAuthorizer{})
+	if _, err := client.Send(context.Background(), testFindings(1)); err == nil {
+		t.Error("expected an error for a rejected request")
+	}
+}
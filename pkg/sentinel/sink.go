@@ -0,0 +1,46 @@
+package sentinel
+
+import (
+	"context"
+	"errors"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/output"
+)
+
+// sinkBatchSize is the number of findings the sink sends at once. Send splits them further to stay under the API's
+// size limit.
+const sinkBatchSize = 500
+
+func init() {
+	output.RegisterSink("sentinel", newSink)
+}
+
+// newSink sends records to the stream option of the data collection rule in the rule-id option, at the endpoint
+// option, authenticating with the environment's Azure credentials.
+func newSink(options map[string]string) (output.Sink, error) {
+	if err := output.CheckSinkOptions(options, "endpoint", "rule-id", "stream"); err != nil {
+		return nil, err
+	}
+	if options["endpoint"] == "" || options["rule-id"] == "" {
+		return nil, errors.New("the endpoint and rule-id options are required")
+	}
+	client, err := New(options["endpoint"], options["rule-id"], options["stream"])
+	if err != nil {
+		return nil, err
+	}
+	return NewSink(client), nil
+}
+
+// NewSink returns a sink that sends the findings of a scan with client, a batch at a time.
+func NewSink(client *Client) *output.FindingsSink {
+	return &output.FindingsSink{
+		BatchSize: sinkBatchSize,
+		Send: func(ctx context.Context, batch []findings.Finding) error {
+			sent, err := client.Send(ctx, batch)
+			logging.FromContext(ctx).WithField("findings", sent).Debug("sent findings to Sentinel")
+			return err
+		},
+	}
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Role is a permission granted to an API token.
+type Role string
+
+const (
+	// RoleSubmit allows registering sources and starting scans of them.
+	RoleSubmit Role = "submit"
+	// RoleRead allows listing sources and scans, and reading the results of scans.
+	RoleRead Role = "read"
+)
+
+// TokenConfig configures an API token. Only the SHA-256 hash of the token is stored, so the tokens file doesn't hold
+// credentials. Generate one with: printf %s "$TOKEN" | sha256sum
+type TokenConfig struct {
+	// Name identifies the token in logs, such as the team or pipeline it was issued to.
+	Name string `yaml:"name"`
+	// Tenant is the tenant the token acts for. Tenants only see their own sources and scans.
+	Tenant string `yaml:"tenant"`
+	SHA256 string `yaml:"sha256"`
+	Roles  []Role `yaml:"roles"`
+}
+
+// Principal is the tenant and roles of an authenticated request.
+type Principal struct {
+	Name   string
+	Tenant string
+	Roles  []Role
+}
+
+// Can returns true if the principal has the role.
+func (p *Principal) Can(role Role) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Tokens authenticates API tokens.
+type Tokens struct {
+	// byHash maps the hex SHA-256 hash of each token to its principal. Requests are looked up by the hash of their
+	// token, so the lookup doesn't leak the tokens through timing.
+	byHash map[string]*Principal
+}
+
+// LoadTokens reads a YAML file of tokens, in the form:
+//
+//	tokens:
+//	  - name: payments-ci
+//	    tenant: payments
+//	    sha256: 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+//	    roles: [submit, read]
+func LoadTokens(path string) (*Tokens, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file struct {
+		Tokens []TokenConfig `yaml:"tokens"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("could not parse tokens file %s: %w", path, err)
+	}
+	return NewTokens(file.Tokens)
+}
+
+// NewTokens returns Tokens that authenticate the configured tokens.
+func NewTokens(configs []TokenConfig) (*Tokens, error) {
+	t := &Tokens{byHash: map[string]*Principal{}}
+	for i, c := range configs {
+		if c.Tenant == "" {
+			return nil, fmt.Errorf("token %d (%s) has no tenant", i+1, c.Name)
+		}
+		hash := strings.ToLower(strings.TrimSpace(c.SHA256))
+		if b, err := hex.DecodeString(hash); err != nil || len(b) != sha256.Size {
+			return nil, fmt.Errorf("token %d (%s) has an invalid sha256, expected 64 hex characters", i+1, c.Name)
+		}
+		if len(c.Roles) == 0 {
+			return nil, fmt.Errorf("token %d (%s) has no roles", i+1, c.Name)
+		}
+		for _, role := range c.Roles {
+			if role != RoleSubmit && role != RoleRead {
+				return nil, fmt.Errorf("token %d (%s) has unknown role %q, expected submit or read", i+1, c.Name, role)
+			}
+		}
+		if _, ok := t.byHash[hash]; ok {
+			return nil, fmt.Errorf("token %d (%s) is configured more than once", i+1, c.Name)
+		}
+		t.byHash[hash] = &Principal{Name: c.Name, Tenant: c.Tenant, Roles: c.Roles}
+	}
+	return t, nil
+}
+
+// Authenticate returns the principal of the request's bearer token, or nil if it has no valid token.
+func (t *Tokens) Authenticate(r *http.Request) *Principal {
+	header := r.Header.Get("Authorization")
+	if len(header) < len("Bearer ") || !strings.EqualFold(header[:len("Bearer ")], "Bearer ") {
+		return nil
+	}
+	token := strings.TrimSpace(header[len("Bearer "):])
+	if token == "" {
+		return nil
+	}
+	sum := sha256.Sum256([]byte(token))
+	return t.byHash[hex.EncodeToString(sum[:])]
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/config"
+)
+
+// DefaultAllowedHosts are the hosts sources can be scanned from if no others are configured: GitHub and GitLab's
+// hosted services.
+var DefaultAllowedHosts = []string{"github.com", "api.github.com", "gitlab.com"}
+
+// HostPolicy limits the hosts that tenants' sources can make the server connect to, so that the API can't be used to
+// reach services on the server's own network.
+type HostPolicy struct {
+	allowed              []string
+	allowPrivateNetworks bool
+	lookupIP             func(ctx context.Context, host string) ([]net.IP, error)
+}
+
+// NewHostPolicy returns a HostPolicy that only allows the hosts in allowed. An entry of the form "*.example.com"
+// allows every subdomain of example.com. Hosts that resolve to loopback, link-local, multicast, or unspecified
+// addresses are always rejected, and hosts that resolve to private addresses are rejected unless allowPrivateNetworks
+// is set, for self-hosted GitHub Enterprise or GitLab instances.
+func NewHostPolicy(allowed []string, allowPrivateNetworks bool) *HostPolicy {
+	hosts := make([]string, 0, len(allowed))
+	for _, host := range allowed {
+		hosts = append(hosts, normalizeHost(host))
+	}
+	return &HostPolicy{
+		allowed:              hosts,
+		allowPrivateNetworks: allowPrivateNetworks,
+		lookupIP: func(ctx context.Context, host string) ([]net.IP, error) {
+			return net.DefaultResolver.LookupIP(ctx, "ip", host)
+		},
+	}
+}
+
+// CheckSource returns an error if the source would connect to a host the policy doesn't allow.
+func (p *HostPolicy) CheckSource(ctx context.Context, src *config.Source) error {
+	var urls []string
+	switch src.Type {
+	case "git":
+		urls = append(urls, src.URI)
+	case "github", "gitlab":
+		endpoint := src.Endpoint
+		if endpoint == "" {
+			endpoint = "https://api.github.com"
+			if src.Type == "gitlab" {
+				endpoint = "https://gitlab.com"
+			}
+		}
+		urls = append(urls, endpoint)
+		// Repositories can be given as URLs, which are cloned from directly.
+		for _, repo := range src.Repos {
+			if strings.Contains(repo, "://") {
+				urls = append(urls, repo)
+			}
+		}
+	}
+	for _, raw := range urls {
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+			// The URL isn't included, as it may hold credentials.
+			return fmt.Errorf("%s sources can only be scanned from http or https URLs", src.Type)
+		}
+		if err := p.CheckHost(ctx, u.Hostname()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckHost returns an error if the host isn't allowed, or if it resolves to an address that isn't.
+func (p *HostPolicy) CheckHost(ctx context.Context, host string) error {
+	host = normalizeHost(host)
+	if !p.allows(host) {
+		return fmt.Errorf("host %s is not allowed by the server", host)
+	}
+	ips := []net.IP{net.ParseIP(host)}
+	if ips[0] == nil {
+		var err error
+		if ips, err = p.lookupIP(ctx, host); err != nil {
+			return fmt.Errorf("could not resolve host %s: %w", host, err)
+		}
+	}
+	for _, ip := range ips {
+		if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsMulticast() || ip.IsUnspecified() ||
+			(ip.IsPrivate() && !p.allowPrivateNetworks) {
+			return fmt.Errorf("host %s resolves to %s, which the server can't connect to", host, ip)
+		}
+	}
+	return nil
+}
+
+func (p *HostPolicy) allows(host string) bool {
+	for _, allowed := range p.allowed {
+		if host == allowed {
+			return true
+		}
+		if strings.HasPrefix(allowed, "*.") && strings.HasSuffix(host, allowed[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+}
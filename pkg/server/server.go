@@ -0,0 +1,561 @@
+// Package server serves an HTTP API for running scans on behalf of several teams from one deployment. Each API token
+// acts for a tenant and is granted roles: tenants only see the sources and scans they created, and a token can only
+// start scans or read results if it has the role to. Sources, scans, and results are persisted in the results
+// database, scoped to their tenant.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/semaphore"
+	"gopkg.in/yaml.v3"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/config"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/output"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/store"
+)
+
+const (
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// maxScansPerTenant caps the finished scans kept for each tenant. The oldest are purged once a tenant has more.
+const maxScansPerTenant = 100
+
+// purgeInterval is how often scans are checked against the retention policy.
+const purgeInterval = time.Minute
+
+// maxBodySize caps the size of request bodies.
+const maxBodySize = 1 << 20
+
+// ScanFunc scans a source and returns its results. ctx carries the scan ID, for logging.ScanID. ScanFunc should return
+// once ctx is done, with the results found so far.
+type ScanFunc func(ctx context.Context, src config.Source) ([]detectors.ResultWithMetadata, error)
+
+// Retention configures how long the server keeps scans, and what it keeps of their results.
+type Retention struct {
+	// MaxAge is how long finished scans and their results are kept. If it's zero, they're kept until the tenant has
+	// more than maxScansPerTenant scans.
+	MaxAge time.Duration
+	// Export is called with each scan before it's purged. If it returns an error, the scan is kept and its export is
+	// retried later.
+	Export func(tenant string, scan Scan, results []json.RawMessage) error
+}
+
+// Scan is the status of a scan.
+type Scan struct {
+	ID       string
+	Source   string
+	Status   string
+	Started  time.Time
+	Finished *time.Time `json:",omitempty"`
+	Results  int
+	Verified int
+	Error    string `json:",omitempty"`
+}
+
+func newScan(scan *store.TenantScan) Scan {
+	s := Scan{
+		ID:       scan.ID,
+		Source:   scan.Source,
+		Status:   scan.Status,
+		Started:  scan.Start,
+		Results:  scan.Results,
+		Verified: scan.Verified,
+		Error:    scan.Error,
+	}
+	if !scan.End.IsZero() {
+		end := scan.End
+		s.Finished = &end
+	}
+	return s
+}
+
+// Server serves the scan API.
+type Server struct {
+	ctx       context.Context
+	tokens    *Tokens
+	db        *store.Store
+	scan      ScanFunc
+	retention Retention
+	hosts     *HostPolicy
+	sem       *semaphore.Weighted
+	wg        sync.WaitGroup
+	log       *log.Entry
+}
+
+// New returns a Server that persists its tenants' sources and scans in db, runs scans with scan, up to
+// maxConcurrentScans at a time, and purges them according to retention. Sources can only be scanned if the hosts
+// policy allows them. Scans that were running when the server last stopped are marked failed. Scans are cancelled,
+// and purging stops, when ctx is done.
+func New(ctx context.Context, tokens *Tokens, db *store.Store, scan ScanFunc, maxConcurrentScans int, retention Retention, hosts *HostPolicy) (*Server, error) {
+	if maxConcurrentScans < 1 {
+		maxConcurrentScans = 1
+	}
+	s := &Server{
+		ctx:       ctx,
+		tokens:    tokens,
+		db:        db,
+		scan:      scan,
+		retention: retention,
+		hosts:     hosts,
+		sem:       semaphore.NewWeighted(int64(maxConcurrentScans)),
+		log:       log.NewEntry(logging.Module("server")),
+	}
+	interrupted, err := db.EndRunningTenantScans(ctx, StatusFailed, "scan interrupted by server shutdown", time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("could not end interrupted scans: %w", err)
+	}
+	if interrupted > 0 {
+		s.log.WithField("scans", interrupted).Warn("marked scans that were running when the server stopped as failed")
+	}
+	go s.purgeLoop()
+	return s, nil
+}
+
+// Wait waits for running scans to return.
+func (s *Server) Wait() {
+	s.wg.Wait()
+}
+
+// Handler returns the handler of the API's routes, which are all under /v1/.
+//
+//	GET    /v1/sources                list the tenant's sources (read)
+//	PUT    /v1/sources/{name}         create or replace a source (submit)
+//	GET    /v1/sources/{name}         show a source, with its credentials redacted (read)
+//	DELETE /v1/sources/{name}         delete a source (submit)
+//	GET    /v1/scans                  list the tenant's scans (read)
+//	POST   /v1/scans                  start a scan of {"source": name} (submit)
+//	GET    /v1/scans/{id}             show a scan (read)
+//	GET    /v1/scans/{id}/results     list a scan's results (read)
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/v1/sources", s.authenticated(s.handleSources))
+	mux.Handle("/v1/sources/", s.authenticated(s.handleSource))
+	mux.Handle("/v1/scans", s.authenticated(s.handleScans))
+	mux.Handle("/v1/scans/", s.authenticated(s.handleScan))
+	return mux
+}
+
+type handlerFunc func(w http.ResponseWriter, r *http.Request, p *Principal)
+
+// authenticated rejects requests without a valid token, and passes the principal of the others to h.
+func (s *Server) authenticated(h handlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := s.tokens.Authenticate(r)
+		if p == nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="trufflehog"`)
+			writeError(w, http.StatusUnauthorized, "a valid API token is required")
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+		h(w, r, p)
+	})
+}
+
+// authorized returns true if the principal has the role, and otherwise responds with an error.
+func (s *Server) authorized(w http.ResponseWriter, r *http.Request, p *Principal, role Role) bool {
+	if p.Can(role) {
+		return true
+	}
+	s.log.WithFields(log.Fields{"tenant": p.Tenant, "token": p.Name, "role": role, "path": r.URL.Path}).Warn("denied request without the required role")
+	writeError(w, http.StatusForbidden, fmt.Sprintf("this token doesn't have the %s role", role))
+	return false
+}
+
+func (s *Server) handleSources(w http.ResponseWriter, r *http.Request, p *Principal) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+	if !s.authorized(w, r, p, RoleRead) {
+		return
+	}
+	saved, err := s.db.TenantSources(r.Context(), p.Tenant)
+	if err != nil {
+		s.internalError(w, err)
+		return
+	}
+	sources := make([]config.Source, 0, len(saved))
+	for _, raw := range saved {
+		var src config.Source
+		if err := json.Unmarshal(raw, &src); err != nil {
+			s.internalError(w, err)
+			return
+		}
+		sources = append(sources, redactSource(src))
+	}
+	writeJSON(w, http.StatusOK, sources)
+}
+
+func (s *Server) handleSource(w http.ResponseWriter, r *http.Request, p *Principal) {
+	name, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), "/v1/sources/"))
+	if err != nil || name == "" || strings.Contains(name, "/") {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if !s.authorized(w, r, p, RoleRead) {
+			return
+		}
+		src, err := s.source(r.Context(), p.Tenant, name)
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "no source named "+name)
+			return
+		}
+		if err != nil {
+			s.internalError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, redactSource(*src))
+	case http.MethodPut:
+		if !s.authorized(w, r, p, RoleSubmit) {
+			return
+		}
+		// Sources are written like those of the config file's sources list. JSON is also YAML, so either can be sent.
+		var src config.Source
+		decoder := yaml.NewDecoder(r.Body)
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&src); err != nil {
+			writeError(w, http.StatusBadRequest, "could not parse source: "+err.Error())
+			return
+		}
+		src.Name = name
+		if err := s.validateSource(r.Context(), &src); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err := s.saveSource(r.Context(), p.Tenant, &src); err != nil {
+			s.internalError(w, err)
+			return
+		}
+		s.log.WithFields(log.Fields{"tenant": p.Tenant, "token": p.Name, "source": name}).Info("saved source")
+		writeJSON(w, http.StatusOK, redactSource(src))
+	case http.MethodDelete:
+		if !s.authorized(w, r, p, RoleSubmit) {
+			return
+		}
+		err := s.db.DeleteTenantSource(r.Context(), p.Tenant, name)
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "no source named "+name)
+			return
+		}
+		if err != nil {
+			s.internalError(w, err)
+			return
+		}
+		s.log.WithFields(log.Fields{"tenant": p.Tenant, "token": p.Name, "source": name}).Info("deleted source")
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w, http.MethodGet, http.MethodPut, http.MethodDelete)
+	}
+}
+
+func (s *Server) handleScans(w http.ResponseWriter, r *http.Request, p *Principal) {
+	switch r.Method {
+	case http.MethodGet:
+		if !s.authorized(w, r, p, RoleRead) {
+			return
+		}
+		saved, err := s.db.TenantScans(r.Context(), p.Tenant)
+		if err != nil {
+			s.internalError(w, err)
+			return
+		}
+		scans := make([]Scan, 0, len(saved))
+		for i := range saved {
+			scans = append(scans, newScan(&saved[i]))
+		}
+		writeJSON(w, http.StatusOK, scans)
+	case http.MethodPost:
+		if !s.authorized(w, r, p, RoleSubmit) {
+			return
+		}
+		var req struct {
+			Source string `json:"source"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "could not parse request: "+err.Error())
+			return
+		}
+		scan, status, err := s.startScan(r.Context(), p, req.Source)
+		if err != nil {
+			writeError(w, status, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusAccepted, scan)
+	default:
+		methodNotAllowed(w, http.MethodGet, http.MethodPost)
+	}
+}
+
+func (s *Server) handleScan(w http.ResponseWriter, r *http.Request, p *Principal) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+	if !s.authorized(w, r, p, RoleRead) {
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Path, "/v1/scans/")
+	results := strings.HasSuffix(id, "/results")
+	id = strings.TrimSuffix(id, "/results")
+	scan, err := s.db.TenantScan(r.Context(), p.Tenant, id)
+	// Another tenant's scan is reported as missing, so that its ID doesn't reveal that it exists.
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "no scan with ID "+id)
+		return
+	}
+	if err != nil {
+		s.internalError(w, err)
+		return
+	}
+	if !results {
+		writeJSON(w, http.StatusOK, newScan(scan))
+		return
+	}
+	found, err := s.db.TenantScanResults(r.Context(), p.Tenant, id)
+	if err != nil {
+		s.internalError(w, err)
+		return
+	}
+	if found == nil {
+		found = []json.RawMessage{}
+	}
+	writeJSON(w, http.StatusOK, found)
+}
+
+// startScan starts a scan of the tenant's source. If it can't be started, the HTTP status to respond with is returned
+// with the error.
+func (s *Server) startScan(ctx context.Context, p *Principal, name string) (Scan, int, error) {
+	src, err := s.source(ctx, p.Tenant, name)
+	if errors.Is(err, store.ErrNotFound) {
+		return Scan{}, http.StatusNotFound, fmt.Errorf("no source named %q", name)
+	}
+	if err != nil {
+		return Scan{}, http.StatusInternalServerError, err
+	}
+	// The source's hosts are checked again, as the policy, or the addresses they resolve to, may have changed since it
+	// was saved.
+	if err := s.hosts.CheckSource(ctx, src); err != nil {
+		return Scan{}, http.StatusBadRequest, err
+	}
+	if !s.sem.TryAcquire(1) {
+		return Scan{}, http.StatusTooManyRequests, fmt.Errorf("the maximum number of scans are running, try again later")
+	}
+
+	record := &store.TenantScan{
+		Tenant: p.Tenant,
+		ID:     output.NewScanID(),
+		Source: name,
+		Status: StatusRunning,
+		Start:  time.Now().UTC(),
+	}
+	if err := s.db.StartTenantScan(ctx, record); err != nil {
+		s.sem.Release(1)
+		return Scan{}, http.StatusInternalServerError, err
+	}
+
+	logger := s.log.WithFields(log.Fields{"tenant": p.Tenant, "token": p.Name, "source": name, "scan_id": record.ID})
+	logger.Info("starting scan")
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer s.sem.Release(1)
+		ctx := logging.WithScanID(s.ctx, record.ID)
+		results, err := s.scan(ctx, *src)
+		if err == nil && ctx.Err() != nil {
+			err = fmt.Errorf("scan interrupted by server shutdown")
+		}
+
+		record.End = time.Now().UTC()
+		record.Results = len(results)
+		for _, r := range results {
+			if r.Verified {
+				record.Verified++
+			}
+		}
+		if err != nil {
+			record.Status = StatusFailed
+			record.Error = err.Error()
+			logger.WithError(err).Error("scan failed")
+		} else {
+			record.Status = StatusSucceeded
+			logger.WithField("results", len(results)).Info("scan finished")
+		}
+
+		// Results are stored with their raw secrets replaced by their fingerprints, like the other results in the
+		// database. They're recorded even if the server is shutting down, so what was found isn't lost.
+		redactRaw(results)
+		encoded := make([]json.RawMessage, 0, len(results))
+		for _, r := range results {
+			b, err := json.Marshal(r)
+			if err != nil {
+				logger.WithError(err).Error("could not encode result")
+				continue
+			}
+			encoded = append(encoded, b)
+		}
+		if err := s.db.EndTenantScan(context.Background(), record, encoded); err != nil {
+			logger.WithError(err).Error("could not record scan results")
+		}
+	}()
+	return newScan(record), http.StatusAccepted, nil
+}
+
+// source returns a tenant's source by name.
+func (s *Server) source(ctx context.Context, tenant, name string) (*config.Source, error) {
+	raw, err := s.db.TenantSource(ctx, tenant, name)
+	if err != nil {
+		return nil, err
+	}
+	var src config.Source
+	if err := json.Unmarshal(raw, &src); err != nil {
+		return nil, err
+	}
+	return &src, nil
+}
+
+// saveSource creates or replaces a tenant's source.
+func (s *Server) saveSource(ctx context.Context, tenant string, src *config.Source) error {
+	raw, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return s.db.PutTenantSource(ctx, tenant, src.Name, raw)
+}
+
+func (s *Server) purgeLoop() {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.purge(time.Now())
+		}
+	}
+}
+
+// purge removes the finished scans that are older than the retention's MaxAge, or beyond the newest
+// maxScansPerTenant of their tenant, exporting each first.
+func (s *Server) purge(now time.Time) {
+	var before time.Time
+	if s.retention.MaxAge > 0 {
+		before = now.Add(-s.retention.MaxAge)
+	}
+	expired, err := s.db.ExpiredTenantScans(s.ctx, before, maxScansPerTenant)
+	if err != nil {
+		s.log.WithError(err).Error("could not list expired scans")
+		return
+	}
+
+	for i := range expired {
+		scan := &expired[i]
+		logger := s.log.WithFields(log.Fields{"tenant": scan.Tenant, "scan_id": scan.ID})
+		if s.retention.Export != nil {
+			results, err := s.db.TenantScanResults(s.ctx, scan.Tenant, scan.ID)
+			if err == nil {
+				err = s.retention.Export(scan.Tenant, newScan(scan), results)
+			}
+			if err != nil {
+				logger.WithError(err).Error("could not export scan, keeping it until it can be")
+				continue
+			}
+		}
+		if err := s.db.DeleteTenantScan(s.ctx, scan.Tenant, scan.ID); err != nil {
+			logger.WithError(err).Error("could not purge scan")
+			continue
+		}
+		logger.Debug("purged scan")
+	}
+}
+
+// redactRaw removes the raw secrets from results, and the context lines around them, keeping their fingerprints.
+func redactRaw(results []detectors.ResultWithMetadata) {
+	for i := range results {
+		r := &results[i]
+		finding := findings.Finding{DetectorType: r.DetectorType.String(), Raw: string(r.Raw)}
+		extra := make(map[string]string, len(r.ExtraData)+1)
+		for k, v := range r.ExtraData {
+			extra[k] = v
+		}
+		extra["fingerprint"] = finding.Fingerprint()
+		r.ExtraData = extra
+		r.Raw = nil
+		r.Context = ""
+	}
+}
+
+// validateSource checks a source like the config file's sources are checked, and also rejects sources that would
+// scan with the server's own access rather than the tenant's: local paths, credentials from the environment, snapshot
+// files, which the server would read and overwrite, and hosts that the host policy doesn't allow, such as services on
+// the server's network.
+func (s *Server) validateSource(ctx context.Context, src *config.Source) error {
+	if errs := (&config.Config{Sources: []config.Source{*src}}).Validate(); len(errs) > 0 {
+		msgs := make([]string, len(errs))
+		for i, err := range errs {
+			msgs[i] = err.Msg
+		}
+		return fmt.Errorf("%s", strings.Join(msgs, "; "))
+	}
+	switch src.Type {
+	case "filesystem":
+		return fmt.Errorf("filesystem sources can't be scanned by the server")
+	case "s3":
+		if src.CloudEnvironment || src.Key == "" || src.Secret == "" {
+			return fmt.Errorf("s3 sources require a key and secret")
+		}
+	}
+	if src.Snapshot != "" {
+		return fmt.Errorf("snapshots can't be used by the server")
+	}
+	return s.hosts.CheckSource(ctx, src)
+}
+
+// redactSource returns a copy of the source with its credentials replaced, for responses.
+func redactSource(src config.Source) config.Source {
+	redacted := output.RedactOptions(map[string]string{"uri": src.URI, "token": src.Token, "secret": src.Secret})
+	src.URI, src.Token, src.Secret = redacted["uri"], redacted["token"], redacted["secret"]
+	return src
+}
+
+// internalError logs an error that isn't the client's, and responds without its details.
+func (s *Server) internalError(w http.ResponseWriter, err error) {
+	s.log.WithError(err).Error("could not handle request")
+	writeError(w, http.StatusInternalServerError, "internal error")
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.WithError(err).Debug("could not write response")
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+func methodNotAllowed(w http.ResponseWriter, methods ...string) {
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+}
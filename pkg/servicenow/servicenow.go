@@ -0,0 +1,221 @@
+// Package servicenow opens ServiceNow incidents for the findings of a scan. Importing it registers the "servicenow"
+// output sink.
+//
+// Each incident's correlation ID is the finding's fingerprint, so a secret that's already being handled in an active
+// incident doesn't get another one, however many times or places it's found.
+package servicenow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+)
+
+// correlationDisplay marks the incidents opened by trufflehog.
+const correlationDisplay = "TruffleHog"
+
+// Priority is the impact and urgency of an incident, from 1 (high) to 3 (low). ServiceNow derives the incident's
+// priority from the two.
+type Priority struct {
+	Impact  int
+	Urgency int
+}
+
+// DefaultSeverityMap is the priority of incidents for each severity.
+var DefaultSeverityMap = map[detectors.Severity]Priority{
+	detectors.SeverityCritical: {Impact: 1, Urgency: 1},
+	detectors.SeverityHigh:     {Impact: 1, Urgency: 2},
+	detectors.SeverityMedium:   {Impact: 2, Urgency: 2},
+	detectors.SeverityLow:      {Impact: 3, Urgency: 3},
+	detectors.SeverityUnknown:  {Impact: 3, Urgency: 3},
+}
+
+// ParseSeverityMap returns DefaultSeverityMap overridden by mappings like "high=1/1", which is a severity, then the
+// impact and urgency.
+func ParseSeverityMap(mappings []string) (map[detectors.Severity]Priority, error) {
+	m := make(map[detectors.Severity]Priority, len(DefaultSeverityMap))
+	for severity, priority := range DefaultSeverityMap {
+		m[severity] = priority
+	}
+	for _, mapping := range mappings {
+		split := strings.SplitN(mapping, "=", 2)
+		if len(split) != 2 {
+			return nil, fmt.Errorf("invalid severity mapping %q, expected SEVERITY=IMPACT/URGENCY", mapping)
+		}
+		severity, err := detectors.ParseSeverity(split[0])
+		if err != nil {
+			return nil, err
+		}
+		values := strings.SplitN(split[1], "/", 2)
+		if len(values) != 2 {
+			return nil, fmt.Errorf("invalid severity mapping %q, expected SEVERITY=IMPACT/URGENCY", mapping)
+		}
+		impact, err1 := strconv.Atoi(values[0])
+		urgency, err2 := strconv.Atoi(values[1])
+		if err1 != nil || err2 != nil || impact < 1 || impact > 3 || urgency < 1 || urgency > 3 {
+			return nil, fmt.Errorf("invalid severity mapping %q, impact and urgency are 1 to 3", mapping)
+		}
+		m[severity] = Priority{Impact: impact, Urgency: urgency}
+	}
+	return m, nil
+}
+
+// Client opens incidents on a ServiceNow instance with the Table API.
+type Client struct {
+	// URL is the instance's address, such as https://example.service-now.com.
+	URL string
+	// Username and Password authenticate with basic auth. Token, if set, is used as an OAuth bearer token instead.
+	Username string
+	Password string
+	Token    string
+
+	// AssignmentGroup is the name or sys_id of the group incidents are assigned to.
+	AssignmentGroup string
+	// Category is the incident category, if set.
+	Category    string
+	SeverityMap map[detectors.Severity]Priority
+
+	client *http.Client
+}
+
+// Incident is an incident opened for a finding.
+type Incident struct {
+	Number string `json:"number"`
+	SysID  string `json:"sys_id"`
+	// Existing is true if an active incident was already open for the finding's secret.
+	Existing bool `json:"-"`
+}
+
+// Open opens an incident for the finding, unless an active incident for the same secret already exists, in which
+// case that incident is returned.
+func (c *Client) Open(ctx context.Context, f *findings.Finding) (*Incident, error) {
+	fingerprint := f.Fingerprint()
+
+	query := url.Values{
+		"sysparm_query":  {"correlation_id=" + fingerprint + "^active=true"},
+		"sysparm_fields": {"number,sys_id"},
+		"sysparm_limit":  {"1"},
+	}
+	var existing struct {
+		Result []Incident `json:"result"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/api/now/table/incident?"+query.Encode(), nil, &existing); err != nil {
+		return nil, fmt.Errorf("could not look up existing incidents: %w", err)
+	}
+	if len(existing.Result) > 0 {
+		incident := existing.Result[0]
+		incident.Existing = true
+		return &incident, nil
+	}
+
+	severityMap := c.SeverityMap
+	if severityMap == nil {
+		severityMap = DefaultSeverityMap
+	}
+	priority, ok := severityMap[f.Severity]
+	if !ok {
+		priority = DefaultSeverityMap[detectors.SeverityUnknown]
+	}
+	incident := map[string]string{
+		"short_description":   fmt.Sprintf("Leaked %s secret found in %s", f.DetectorType, f.SourceName),
+		"description":         description(f),
+		"impact":              strconv.Itoa(priority.Impact),
+		"urgency":             strconv.Itoa(priority.Urgency),
+		"correlation_id":      fingerprint,
+		"correlation_display": correlationDisplay,
+	}
+	if c.AssignmentGroup != "" {
+		incident["assignment_group"] = c.AssignmentGroup
+	}
+	if c.Category != "" {
+		incident["category"] = c.Category
+	}
+
+	var created struct {
+		Result Incident `json:"result"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/api/now/table/incident", incident, &created); err != nil {
+		return nil, fmt.Errorf("could not open incident: %w", err)
+	}
+	return &created.Result, nil
+}
+
+// description describes the finding without including the raw secret.
+func description(f *findings.Finding) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "TruffleHog found a %s secret", f.DetectorType)
+	if f.Verified {
+		b.WriteString(" and verified that it's live")
+	}
+	b.WriteString(". Rotate it, then remove it from the source.\n\n")
+	if f.Redacted != "" {
+		fmt.Fprintf(&b, "Secret: %s\n", f.Redacted)
+	}
+	fmt.Fprintf(&b, "Severity: %s\n", f.Severity)
+	fmt.Fprintf(&b, "Source: %s\n", f.SourceName)
+	if location := f.Location(); location != "" {
+		fmt.Fprintf(&b, "Location: %s\n", location)
+	}
+	if link, ok := f.Metadata["link"].(string); ok && link != "" {
+		fmt.Fprintf(&b, "Link: %s\n", link)
+	}
+	if dt, err := detectors.ParseDetectorType(f.DetectorType); err == nil {
+		if remediation := detectors.RemediationFor(dt); remediation != nil {
+			if remediation.Guidance != "" {
+				fmt.Fprintf(&b, "\nRemediation: %s\n", remediation.Guidance)
+			}
+			if remediation.URL != "" {
+				fmt.Fprintf(&b, "Rotation guide: %s\n", remediation.URL)
+			}
+		}
+	}
+	return b.String()
+}
+
+func (c *Client) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.URL, "/")+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	} else {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	if c.client == nil {
+		c.client = common.SaneHttpClient()
+	}
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("unexpected status %d: %s", res.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
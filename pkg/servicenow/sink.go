@@ -0,0 +1,110 @@
+package servicenow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/credentials"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/output"
+)
+
+func init() {
+	output.RegisterSink("servicenow", newSink)
+}
+
+// newSink opens incidents on the instance at the url option. Without a password or token option, the credential is
+// looked up from $SERVICENOW_USERNAME and $SERVICENOW_PASSWORD, ~/.netrc, or the OS keychain. Options named
+// priority-SEVERITY, such as priority-high=1/1, override the impact and urgency of incidents for a severity.
+func newSink(options map[string]string) (output.Sink, error) {
+	known := []string{"url", "username", "password", "token", "assignment-group", "category", "include-unverified"}
+	var mappings []string
+	for _, severity := range []detectors.Severity{detectors.SeverityCritical, detectors.SeverityHigh, detectors.SeverityMedium, detectors.SeverityLow} {
+		key := "priority-" + severity.String()
+		known = append(known, key)
+		if priority, ok := options[key]; ok {
+			mappings = append(mappings, severity.String()+"="+priority)
+		}
+	}
+	if err := output.CheckSinkOptions(options, known...); err != nil {
+		return nil, err
+	}
+	if options["url"] == "" {
+		return nil, errors.New("the url option is required")
+	}
+	severityMap, err := ParseSeverityMap(mappings)
+	if err != nil {
+		return nil, err
+	}
+	includeUnverified, err := output.BoolSinkOption(options, "include-unverified")
+	if err != nil {
+		return nil, err
+	}
+
+	client := &Client{
+		URL:             options["url"],
+		Username:        options["username"],
+		Password:        options["password"],
+		Token:           options["token"],
+		AssignmentGroup: options["assignment-group"],
+		Category:        options["category"],
+		SeverityMap:     severityMap,
+	}
+	if client.Password == "" && client.Token == "" {
+		host := ""
+		if u, err := url.Parse(client.URL); err == nil {
+			host = u.Hostname()
+		}
+		cred, _ := credentials.New().Resolve(context.Background(), credentials.Request{
+			Source:      "servicenow",
+			Host:        host,
+			UsernameEnv: []string{"SERVICENOW_USERNAME"},
+			PasswordEnv: []string{"SERVICENOW_PASSWORD"},
+		})
+		if cred.Password == "" {
+			return nil, errors.New("no ServiceNow credential found, set the password or token option, or $SERVICENOW_PASSWORD")
+		}
+		switch {
+		case client.Username != "":
+			client.Password = cred.Password
+		case cred.Username != "":
+			client.Username, client.Password = cred.Username, cred.Password
+		default:
+			client.Token = cred.Password
+		}
+	}
+	return NewSink(client, includeUnverified), nil
+}
+
+// NewSink returns a sink that opens an incident with client for each verified finding of a scan as it's found, and
+// for unverified findings too if includeUnverified is set.
+func NewSink(client *Client, includeUnverified bool) *output.FindingsSink {
+	return &output.FindingsSink{
+		BatchSize: 1,
+		Send: func(ctx context.Context, batch []findings.Finding) error {
+			for i := range batch {
+				finding := &batch[i]
+				if !finding.Verified && !includeUnverified {
+					continue
+				}
+				incident, err := client.Open(ctx, finding)
+				if err != nil {
+					return fmt.Errorf("could not open ServiceNow incident: %w", err)
+				}
+				logger := logging.FromContext(ctx).WithFields(logrus.Fields{"incident": incident.Number, "detector": finding.DetectorType})
+				if incident.Existing {
+					logger.Info("secret already has an active incident")
+				} else {
+					logger.Info("opened incident")
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,493 @@
+// Package sourcecheck tests that a source from the config file can be scanned: that its credentials are accepted,
+// that they have the scopes the scan needs, and that the organizations, repositories, buckets, and directories it
+// names exist and can be read. Problems are reported with a hint on how to fix them, before a long scan fails.
+package sourcecheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/config"
+)
+
+// Result is the outcome of one check.
+type Result struct {
+	// Check is what was checked, such as "authentication" or "bucket logs".
+	Check string
+	// Detail describes a passed check, such as the user that was authenticated.
+	Detail string
+	// Err is why the check failed, or nil if it passed.
+	Err error
+	// Hint suggests how to fix a failed check.
+	Hint string
+	// Warning is set for problems that don't stop the scan, but limit it.
+	Warning bool
+}
+
+// Failed reports whether the check found a problem that will stop the scan.
+func (r *Result) Failed() bool {
+	return r.Err != nil && !r.Warning
+}
+
+// Checker checks sources.
+type Checker struct {
+	client *http.Client
+	// newS3 returns an S3 client for the source in a region.
+	newS3 func(src *config.Source, region string) (s3iface.S3API, error)
+}
+
+// New returns a Checker that makes requests with the default HTTP client.
+func New() *Checker {
+	return &Checker{client: common.SaneHttpClient(), newS3: newS3}
+}
+
+// Check runs the checks for the source's type. The source's credentials must already be resolved.
+func (c *Checker) Check(ctx context.Context, src *config.Source) []Result {
+	switch src.Type {
+	case "git":
+		return []Result{checkGit(ctx, src.URI)}
+	case "github":
+		return c.checkGitHub(ctx, src)
+	case "gitlab":
+		return c.checkGitLab(ctx, src)
+	case "filesystem":
+		results := make([]Result, 0, len(src.Directories))
+		for _, dir := range src.Directories {
+			results = append(results, checkDirectory(dir))
+		}
+		return results
+	case "s3":
+		return c.checkS3(ctx, src)
+	default:
+		return []Result{{Check: "type", Err: fmt.Errorf("unknown source type %q", src.Type)}}
+	}
+}
+
+func checkGit(ctx context.Context, uri string) Result {
+	result := Result{Check: "repository " + uri}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	// The URI comes after "--" so that git never reads it as an option, such as --upload-pack.
+	cmd := exec.CommandContext(ctx, "git", "ls-remote", "--heads", "--", uri)
+	// Fail instead of waiting for a password that will never be typed.
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		msg := strings.TrimSpace(string(out))
+		if i := strings.IndexByte(msg, '\n'); i > 0 {
+			msg = msg[:i]
+		}
+		result.Err = fmt.Errorf("git ls-remote failed: %s", msg)
+		if out := string(out); strings.Contains(out, "Authentication failed") || strings.Contains(out, "could not read Username") {
+			result.Hint = "Put credentials for the repository in a git credential helper, or in the URL."
+		} else {
+			result.Hint = "Check the URL, and that the repository exists."
+		}
+		return result
+	}
+	if branches := strings.Count(string(out), "\n"); branches == 1 {
+		result.Detail = "1 branch"
+	} else {
+		result.Detail = fmt.Sprintf("%d branches", branches)
+	}
+	return result
+}
+
+func checkDirectory(dir string) Result {
+	result := Result{Check: "directory " + dir}
+	info, err := os.Stat(dir)
+	if err != nil {
+		result.Err = err
+		result.Hint = "Check the path, which is relative to the directory trufflehog is run in."
+		return result
+	}
+	if !info.IsDir() {
+		result.Err = fmt.Errorf("%s is not a directory", dir)
+		result.Hint = "List the directory that contains the file."
+		return result
+	}
+	f, err := os.Open(dir)
+	if err == nil {
+		_, err = f.Readdirnames(1)
+		f.Close()
+		if err == io.EOF {
+			result.Detail = "empty"
+			err = nil
+		}
+	}
+	if err != nil {
+		result.Err = err
+		result.Hint = "Run trufflehog as a user that can read the directory."
+	}
+	return result
+}
+
+// apiError is an unexpected response from an API.
+type apiError struct {
+	status int
+	msg    string
+}
+
+func (e *apiError) Error() string {
+	if e.msg == "" {
+		return fmt.Sprintf("unexpected status %d", e.status)
+	}
+	return fmt.Sprintf("unexpected status %d: %s", e.status, e.msg)
+}
+
+// get requests endpoint+path and decodes a successful JSON response into v, which may be nil.
+func (c *Checker) get(ctx context.Context, endpoint, path string, header http.Header, v interface{}) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(endpoint, "/")+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, values := range header {
+		req.Header[k] = values
+	}
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		var msg struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &msg) != nil || msg.Message == "" {
+			msg.Message = strings.TrimSpace(string(body))
+		}
+		return res, &apiError{status: res.StatusCode, msg: msg.Message}
+	}
+	if v == nil {
+		_, _ = io.Copy(io.Discard, res.Body)
+		return res, nil
+	}
+	return res, json.NewDecoder(res.Body).Decode(v)
+}
+
+// status returns the status code of an apiError, or 0 for other errors.
+func status(err error) int {
+	if e, ok := err.(*apiError); ok {
+		return e.status
+	}
+	return 0
+}
+
+func (c *Checker) checkGitHub(ctx context.Context, src *config.Source) []Result {
+	endpoint := src.Endpoint
+	if endpoint == "" {
+		endpoint = "https://api.github.com"
+	}
+	header := http.Header{"Accept": {"application/vnd.github+json"}}
+
+	auth := Result{Check: "authentication"}
+	if src.Token == "" {
+		auth.Err = fmt.Errorf("no token, so only public repositories can be scanned, at 60 requests an hour")
+		auth.Hint = "Set token, $GITHUB_TOKEN, or a credential helper."
+		auth.Warning = true
+	} else {
+		header.Set("Authorization", "token "+src.Token)
+		var user struct {
+			Login string `json:"login"`
+		}
+		res, err := c.get(ctx, endpoint, "/user", header, &user)
+		switch {
+		case status(err) == http.StatusUnauthorized:
+			auth.Err = fmt.Errorf("the token was rejected")
+			auth.Hint = "Check that the token hasn't expired or been revoked."
+			return []Result{auth}
+		case err != nil:
+			auth.Err = err
+			auth.Hint = fmt.Sprintf("Check that %s is a GitHub API endpoint, such as https://github.example.com/api/v3.", endpoint)
+			return []Result{auth}
+		}
+		auth.Detail = "as " + user.Login
+		if remaining := res.Header.Get("X-RateLimit-Remaining"); remaining == "0" {
+			auth.Err = fmt.Errorf("the rate limit is used up")
+			auth.Hint = "Wait until " + rateLimitReset(res) + ", or use another token."
+			return []Result{auth}
+		}
+		// Classic tokens list their scopes. Fine-grained tokens and app tokens don't, and can't be checked here.
+		if scopes, ok := res.Header["X-Oauth-Scopes"]; ok {
+			granted := splitScopes(strings.Join(scopes, ","))
+			auth.Detail += " with scopes " + strings.Join(granted, ", ")
+			if !hasScope(granted, "repo") {
+				auth.Err = fmt.Errorf("the token doesn't have the repo scope, so private repositories will be skipped")
+				auth.Hint = "Add the repo scope to the token."
+				auth.Warning = true
+			} else if src.IncludeMembers && !hasScope(granted, "read:org", "admin:org") {
+				auth.Err = fmt.Errorf("the token doesn't have the read:org scope, so private members will be skipped")
+				auth.Hint = "Add the read:org scope to the token."
+				auth.Warning = true
+			}
+		}
+	}
+	results := []Result{auth}
+
+	for _, org := range src.Orgs {
+		result := Result{Check: "organization " + org}
+		var account struct {
+			PublicRepos       int `json:"public_repos"`
+			TotalPrivateRepos int `json:"total_private_repos"`
+		}
+		// Like the source, fall back to a user of the same name.
+		_, err := c.get(ctx, endpoint, "/orgs/"+url.PathEscape(org), header, &account)
+		if status(err) == http.StatusNotFound {
+			_, err = c.get(ctx, endpoint, "/users/"+url.PathEscape(org), header, &account)
+		}
+		switch {
+		case status(err) == http.StatusNotFound:
+			result.Err = fmt.Errorf("no organization or user named %s", org)
+			result.Hint = "Check the name, which is the one in the organization's URL."
+		case err != nil:
+			result.Err = err
+		default:
+			result.Detail = fmt.Sprintf("%d repositories", account.PublicRepos+account.TotalPrivateRepos)
+		}
+		results = append(results, result)
+	}
+
+	for _, repo := range src.Repos {
+		result := Result{Check: "repository " + repo}
+		fullName, err := githubRepoName(repo)
+		if err == nil {
+			var info struct {
+				Private bool `json:"private"`
+			}
+			_, err = c.get(ctx, endpoint, "/repos/"+fullName, header, &info)
+			if status(err) == http.StatusNotFound {
+				err = fmt.Errorf("not found")
+				result.Hint = "Check the URL. Private repositories are reported as not found when the token can't read them."
+			} else if err == nil && info.Private {
+				result.Detail = "private"
+			}
+		}
+		result.Err = err
+		results = append(results, result)
+	}
+	return results
+}
+
+// githubRepoName returns the owner/name of a repository URL.
+func githubRepoName(repo string) (string, error) {
+	u, err := url.Parse(repo)
+	if err != nil {
+		return "", err
+	}
+	parts := strings.Split(strings.Trim(strings.TrimSuffix(u.Path, ".git"), "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("expected a URL like https://github.com/owner/repo")
+	}
+	return url.PathEscape(parts[0]) + "/" + url.PathEscape(parts[1]), nil
+}
+
+func rateLimitReset(res *http.Response) string {
+	reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return "the rate limit resets"
+	}
+	return time.Unix(reset, 0).UTC().Format(time.RFC3339)
+}
+
+func splitScopes(s string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(s, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// hasScope reports whether any of the wanted scopes was granted.
+func hasScope(granted []string, wanted ...string) bool {
+	for _, g := range granted {
+		for _, w := range wanted {
+			if g == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *Checker) checkGitLab(ctx context.Context, src *config.Source) []Result {
+	endpoint := src.Endpoint
+	if endpoint == "" {
+		endpoint = "https://gitlab.com"
+	}
+	api := strings.TrimSuffix(endpoint, "/") + "/api/v4"
+
+	auth := Result{Check: "authentication"}
+	if src.Token == "" {
+		auth.Err = fmt.Errorf("no token")
+		auth.Hint = "Set token, $GITLAB_TOKEN, or a credential helper."
+		return []Result{auth}
+	}
+	header := http.Header{"Private-Token": {src.Token}}
+	var user struct {
+		Username string `json:"username"`
+	}
+	_, err := c.get(ctx, api, "/user", header, &user)
+	switch {
+	case status(err) == http.StatusUnauthorized:
+		auth.Err = fmt.Errorf("the token was rejected")
+		auth.Hint = "Check that the token hasn't expired or been revoked."
+		return []Result{auth}
+	case err != nil:
+		auth.Err = err
+		auth.Hint = fmt.Sprintf("Check that %s is a GitLab instance.", endpoint)
+		return []Result{auth}
+	}
+	auth.Detail = "as " + user.Username
+
+	// Personal, project, and group access tokens can describe themselves. Older instances can't, so a failure here
+	// is ignored.
+	var token struct {
+		Scopes []string `json:"scopes"`
+	}
+	if _, err := c.get(ctx, api, "/personal_access_tokens/self", header, &token); err == nil {
+		auth.Detail += " with scopes " + strings.Join(token.Scopes, ", ")
+		switch {
+		case !hasScope(token.Scopes, "api", "read_api"):
+			auth.Err = fmt.Errorf("the token can't list projects")
+			auth.Hint = "Add the read_api scope to the token."
+		case !hasScope(token.Scopes, "api", "read_repository"):
+			auth.Err = fmt.Errorf("the token can't clone repositories")
+			auth.Hint = "Add the read_repository scope to the token."
+		}
+	}
+	results := []Result{auth}
+
+	for _, repo := range src.Repos {
+		result := Result{Check: "project " + repo}
+		u, err := url.Parse(repo)
+		if err == nil {
+			path := strings.Trim(strings.TrimSuffix(u.Path, ".git"), "/")
+			_, err = c.get(ctx, api, "/projects/"+url.PathEscape(path), header, nil)
+			if status(err) == http.StatusNotFound {
+				err = fmt.Errorf("not found")
+				result.Hint = "Check the URL. Private projects are reported as not found when the token can't read them."
+			}
+		}
+		result.Err = err
+		results = append(results, result)
+	}
+	return results
+}
+
+func newS3(src *config.Source, region string) (s3iface.S3API, error) {
+	cfg := aws.NewConfig()
+	cfg.CredentialsChainVerboseErrors = aws.Bool(true)
+	cfg.Region = aws.String(region)
+	switch {
+	case src.Key != "" && src.Secret != "":
+		cfg.Credentials = credentials.NewStaticCredentials(src.Key, src.Secret, "")
+	case !src.CloudEnvironment:
+		cfg.Credentials = credentials.AnonymousCredentials
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+		Config:            *cfg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s3.New(sess), nil
+}
+
+func (c *Checker) checkS3(ctx context.Context, src *config.Source) []Result {
+	client, err := c.newS3(src, "us-east-1")
+	if err != nil {
+		return []Result{{Check: "credentials", Err: err}}
+	}
+
+	buckets := src.Buckets
+	if len(buckets) == 0 {
+		result := Result{Check: "list buckets"}
+		out, err := client.ListBucketsWithContext(ctx, &s3.ListBucketsInput{})
+		if err != nil {
+			result.Err = err
+			result.Hint = s3Hint(err, "s3:ListAllMyBuckets")
+			return []Result{result}
+		}
+		result.Detail = fmt.Sprintf("%d buckets", len(out.Buckets))
+		return []Result{result}
+	}
+
+	var results []Result
+	for _, bucket := range buckets {
+		result := Result{Check: "bucket " + bucket}
+		region, err := bucketRegion(ctx, client, bucket)
+		if err == nil {
+			regional := client
+			if region != "us-east-1" {
+				regional, err = c.newS3(src, region)
+			}
+			if err == nil {
+				_, err = regional.ListObjectsV2WithContext(ctx, &s3.ListObjectsV2Input{Bucket: aws.String(bucket), MaxKeys: aws.Int64(1)})
+			}
+			if err == nil {
+				result.Detail = "in " + region
+			}
+		}
+		if err != nil {
+			result.Err = err
+			result.Hint = s3Hint(err, "s3:ListBucket and s3:GetObject")
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// bucketRegion returns the region of a bucket.
+func bucketRegion(ctx context.Context, client s3iface.S3API, bucket string) (string, error) {
+	out, err := client.GetBucketLocationWithContext(ctx, &s3.GetBucketLocationInput{Bucket: aws.String(bucket)})
+	if err != nil {
+		return "", err
+	}
+	// Buckets in us-east-1 have no location constraint.
+	if region := aws.StringValue(out.LocationConstraint); region != "" {
+		return region, nil
+	}
+	return "us-east-1", nil
+}
+
+// s3Hint suggests a fix for an S3 error. permissions are the IAM actions the request needs.
+func s3Hint(err error, permissions string) string {
+	aerr, ok := err.(awserr.Error)
+	if !ok {
+		return ""
+	}
+	switch aerr.Code() {
+	case "AccessDenied", "AllAccessDisabled":
+		return fmt.Sprintf("Grant the credentials %s on the bucket.", permissions)
+	case "NoSuchBucket":
+		return "Check the bucket name."
+	case "InvalidAccessKeyId", "SignatureDoesNotMatch":
+		return "Check the key and secret."
+	case "NoCredentialProviders":
+		return "Set key and secret, $AWS_ACCESS_KEY_ID and $AWS_SECRET_ACCESS_KEY, or run where IAM credentials are available."
+	case "ExpiredToken":
+		return "Refresh the session credentials."
+	}
+	return ""
+}
@@ -0,0 +1,197 @@
+package sourcecheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/config"
+)
+
+func checkNames(results []Result) map[string]Result {
+	byCheck := map[string]Result{}
+	for _, r := range results {
+		byCheck[r.Check] = r
+	}
+	return byCheck
+}
+
+func TestCheckGitHub(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message": "Bad credentials"}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/user":
+			w.Header().Set("X-OAuth-Scopes", "public_repo, read:org")
+			_, _ = w.Write([]byte(`{"login": "octocat"}`))
+		case "/orgs/acme":
+			_, _ = w.Write([]byte(`{"public_repos": 2, "total_private_repos": 3}`))
+		case "/users/octocat":
+			_, _ = w.Write([]byte(`{"public_repos": 1}`))
+		case "/repos/acme/api":
+			_, _ = w.Write([]byte(`{"private": true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	checker := &Checker{client: server.Client()}
+	src := &config.Source{
+		Type:     "github",
+		Endpoint: server.URL,
+		Token:    "good",
+		Orgs:     []string{"acme", "octocat", "missing"},
+		Repos:    []string{"https://github.com/acme/api.git", "https://github.com/acme/gone"},
+	}
+	results := checkNames(checker.Check(context.Background(), src))
+
+	auth := results["authentication"]
+	if !auth.Warning || auth.Failed() || !strings.Contains(auth.Detail, "octocat") || !strings.Contains(auth.Hint, "repo scope") {
+		t.Errorf("expected a warning about the repo scope: %+v", auth)
+	}
+	if r := results["organization acme"]; r.Err != nil || r.Detail != "5 repositories" {
+		t.Errorf("unexpected organization result: %+v", r)
+	}
+	if r := results["organization octocat"]; r.Err != nil {
+		t.Errorf("expected a user to be accepted as an organization: %+v", r)
+	}
+	if r := results["organization missing"]; !r.Failed() || r.Hint == "" {
+		t.Errorf("expected a missing organization to fail: %+v", r)
+	}
+	if r := results["repository https://github.com/acme/api.git"]; r.Err != nil || r.Detail != "private" {
+		t.Errorf("unexpected repository result: %+v", r)
+	}
+	if r := results["repository https://github.com/acme/gone"]; !r.Failed() {
+		t.Errorf("expected a missing repository to fail: %+v", r)
+	}
+
+	src.Token = "bad"
+	results = checkNames(checker.Check(context.Background(), src))
+	if r := results["authentication"]; !r.Failed() || len(results) != 1 {
+		t.Errorf("expected only a failed authentication check: %+v", results)
+	}
+}
+
+func TestCheckGitLab(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Private-Token") != "good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.EscapedPath() {
+		case "/api/v4/user":
+			_, _ = w.Write([]byte(`{"username": "tanuki"}`))
+		case "/api/v4/personal_access_tokens/self":
+			_, _ = w.Write([]byte(`{"scopes": ["read_api"]}`))
+		case "/api/v4/projects/group%2Fproject":
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	checker := &Checker{client: server.Client()}
+	src := &config.Source{
+		Type:     "gitlab",
+		Endpoint: server.URL,
+		Token:    "good",
+		Repos:    []string{"https://gitlab.com/group/project.git", "https://gitlab.com/group/other"},
+	}
+	results := checkNames(checker.Check(context.Background(), src))
+	if r := results["authentication"]; !r.Failed() || !strings.Contains(r.Hint, "read_repository") {
+		t.Errorf("expected the missing read_repository scope to fail: %+v", r)
+	}
+	if r := results["project https://gitlab.com/group/project.git"]; r.Err != nil {
+		t.Errorf("unexpected project result: %+v", r)
+	}
+	if r := results["project https://gitlab.com/group/other"]; !r.Failed() {
+		t.Errorf("expected a missing project to fail: %+v", r)
+	}
+
+	src.Token = ""
+	if results := checker.Check(context.Background(), src); len(results) != 1 || !results[0].Failed() {
+		t.Errorf("expected a missing token to fail: %+v", results)
+	}
+}
+
+func TestCheckDirectory(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, []byte("x"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if r := checkDirectory(dir); r.Err != nil {
+		t.Errorf("unexpected error: %v", r.Err)
+	}
+	if r := checkDirectory(file); !r.Failed() {
+		t.Error("expected a file to fail")
+	}
+	if r := checkDirectory(filepath.Join(dir, "missing")); !r.Failed() || r.Hint == "" {
+		t.Error("expected a missing directory to fail with a hint")
+	}
+}
+
+func TestCheckGitOption(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	marker := filepath.Join(t.TempDir(), "marker")
+	if r := checkGit(context.Background(), "--upload-pack=touch "+marker); !r.Failed() {
+		t.Error("expected an option to fail as a repository")
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("the repository URI was run as an option")
+	}
+}
+
+// fakeS3 answers for the bucket "logs", in eu-west-1, and denies everything else.
+type fakeS3 struct {
+	s3iface.S3API
+}
+
+func (fakeS3) GetBucketLocationWithContext(_ aws.Context, in *s3.GetBucketLocationInput, _ ...request.Option) (*s3.GetBucketLocationOutput, error) {
+	if aws.StringValue(in.Bucket) != "logs" {
+		return nil, awserr.New("AccessDenied", "Access Denied", nil)
+	}
+	return &s3.GetBucketLocationOutput{LocationConstraint: aws.String("eu-west-1")}, nil
+}
+
+func (fakeS3) ListObjectsV2WithContext(aws.Context, *s3.ListObjectsV2Input, ...request.Option) (*s3.ListObjectsV2Output, error) {
+	return &s3.ListObjectsV2Output{}, nil
+}
+
+func TestCheckS3(t *testing.T) {
+	var regions []string
+	checker := &Checker{newS3: func(_ *config.Source, region string) (s3iface.S3API, error) {
+		regions = append(regions, region)
+		return fakeS3{}, nil
+	}}
+	src := &config.Source{Type: "s3", Buckets: []string{"logs", "secret"}}
+	results := checkNames(checker.Check(context.Background(), src))
+
+	if r := results["bucket logs"]; r.Err != nil || r.Detail != "in eu-west-1" {
+		t.Errorf("unexpected bucket result: %+v", r)
+	}
+	if r := results["bucket secret"]; !r.Failed() || !strings.Contains(r.Hint, "s3:ListBucket") {
+		t.Errorf("expected a denied bucket to fail with a hint: %+v", r)
+	}
+	if len(regions) != 2 || regions[1] != "eu-west-1" {
+		t.Errorf("expected a client for the bucket's region, got %v", regions)
+	}
+}
@@ -0,0 +1,373 @@
+// Package awsaccount sweeps an AWS account for credentials in the places they're commonly left outside a secret
+// store: Lambda environment variables, EC2 user data, ECS task definitions, CloudFormation templates, and SSM
+// documents. Each chunk is attributed to the ARN of the resource it was read from.
+package awsaccount
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/go-errors/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// Source sweeps the regions of an account.
+type Source struct {
+	// Key and Secret authenticate the sweep. If they're empty, the SDK's default credential chain is used.
+	Key    string
+	Secret string
+	// Regions to sweep. If none are given, every region enabled for the account is swept.
+	Regions []string
+
+	SourceName string
+	Verify     bool
+
+	// newClients returns the service clients for a region. It's replaced in tests.
+	newClients func(region string) (*clients, error)
+}
+
+type clients struct {
+	lambda         lambdaiface.LambdaAPI
+	ec2            ec2iface.EC2API
+	ecs            ecsiface.ECSAPI
+	cloudformation cloudformationiface.CloudFormationAPI
+	ssm            ssmiface.SSMAPI
+}
+
+// sweeps are the services swept in each region, in order.
+var sweeps = []struct {
+	service string
+	sweep   func(s *Source, ctx context.Context, c *clients, region string, emit func(arn string, data []byte) bool) error
+}{
+	{"lambda", (*Source).sweepLambda},
+	{"ec2", (*Source).sweepEC2},
+	{"ecs", (*Source).sweepECS},
+	{"cloudformation", (*Source).sweepCloudFormation},
+	{"ssm", (*Source).sweepSSM},
+}
+
+// Chunks sweeps each region, sending a chunk for each resource. A service that can't be read, for example because
+// the credentials lack permission, is logged and skipped so the rest of the account is still swept.
+func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk) error {
+	if s.newClients == nil {
+		s.newClients = s.sessionClients
+	}
+	regions := s.Regions
+	if len(regions) == 0 {
+		var err error
+		if regions, err = s.enabledRegions(ctx); err != nil {
+			return err
+		}
+	}
+
+	for _, region := range regions {
+		c, err := s.newClients(region)
+		if err != nil {
+			return errors.WrapPrefix(err, "could not create AWS clients", 0)
+		}
+		for _, sw := range sweeps {
+			if common.IsDone(ctx) {
+				return nil
+			}
+			service := sw.service
+			emit := func(arn string, data []byte) bool {
+				if len(bytes.TrimSpace(data)) == 0 {
+					return true
+				}
+				select {
+				case chunksChan <- s.chunk(service, region, arn, data):
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			}
+			if err := sw.sweep(s, ctx, c, region, emit); err != nil {
+				if common.IsDone(ctx) {
+					return nil
+				}
+				log.WithError(err).WithField("region", region).Warnf("could not sweep %s", service)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Source) chunk(service, region, arn string, data []byte) *sources.Chunk {
+	return &sources.Chunk{
+		SourceName: s.SourceName,
+		SourceType: sourcespb.SourceType_SOURCE_TYPE_AWS,
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_Aws{
+				Aws: &source_metadatapb.AWS{
+					Arn:     arn,
+					Service: service,
+					Region:  region,
+					Account: accountFromARN(arn),
+				},
+			},
+		},
+		Data:   data,
+		Verify: s.Verify,
+	}
+}
+
+func (s *Source) session(region string) (*session.Session, error) {
+	cfg := aws.NewConfig()
+	cfg.CredentialsChainVerboseErrors = aws.Bool(true)
+	if region != "" {
+		cfg.Region = aws.String(region)
+	}
+	if s.Key != "" || s.Secret != "" {
+		cfg.Credentials = credentials.NewStaticCredentials(s.Key, s.Secret, "")
+	}
+	return session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+		Config:            *cfg,
+	})
+}
+
+func (s *Source) sessionClients(region string) (*clients, error) {
+	sess, err := s.session(region)
+	if err != nil {
+		return nil, err
+	}
+	return &clients{
+		lambda:         lambda.New(sess),
+		ec2:            ec2.New(sess),
+		ecs:            ecs.New(sess),
+		cloudformation: cloudformation.New(sess),
+		ssm:            ssm.New(sess),
+	}, nil
+}
+
+// enabledRegions lists the regions enabled for the account, asking the SDK's default region or us-east-1.
+func (s *Source) enabledRegions(ctx context.Context) ([]string, error) {
+	sess, err := s.session("")
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "could not create AWS session", 0)
+	}
+	region := aws.StringValue(sess.Config.Region)
+	if region == "" {
+		region = "us-east-1"
+	}
+	c, err := s.newClients(region)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "could not create AWS clients", 0)
+	}
+	out, err := c.ec2.DescribeRegionsWithContext(ctx, &ec2.DescribeRegionsInput{})
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "could not list regions", 0)
+	}
+	var regions []string
+	for _, r := range out.Regions {
+		regions = append(regions, aws.StringValue(r.RegionName))
+	}
+	sort.Strings(regions)
+	return regions, nil
+}
+
+// sweepLambda scans the environment variables of functions, one per line as NAME=value.
+func (s *Source) sweepLambda(ctx context.Context, c *clients, region string, emit func(arn string, data []byte) bool) error {
+	return c.lambda.ListFunctionsPagesWithContext(ctx, &lambda.ListFunctionsInput{}, func(page *lambda.ListFunctionsOutput, _ bool) bool {
+		for _, fn := range page.Functions {
+			if fn.Environment == nil {
+				continue
+			}
+			if !emit(aws.StringValue(fn.FunctionArn), envLines(fn.Environment.Variables)) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func envLines(vars map[string]*string) []byte {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b bytes.Buffer
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s=%s\n", name, aws.StringValue(vars[name]))
+	}
+	return b.Bytes()
+}
+
+// sweepEC2 scans the user data of instances.
+func (s *Source) sweepEC2(ctx context.Context, c *clients, region string, emit func(arn string, data []byte) bool) error {
+	type instance struct{ id, arn string }
+	var instances []instance
+	err := c.ec2.DescribeInstancesPagesWithContext(ctx, &ec2.DescribeInstancesInput{}, func(page *ec2.DescribeInstancesOutput, _ bool) bool {
+		for _, reservation := range page.Reservations {
+			for _, i := range reservation.Instances {
+				id := aws.StringValue(i.InstanceId)
+				arn := fmt.Sprintf("arn:aws:ec2:%s:%s:instance/%s", region, aws.StringValue(reservation.OwnerId), id)
+				instances = append(instances, instance{id: id, arn: arn})
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, i := range instances {
+		out, err := c.ec2.DescribeInstanceAttributeWithContext(ctx, &ec2.DescribeInstanceAttributeInput{
+			Attribute:  aws.String(ec2.InstanceAttributeNameUserData),
+			InstanceId: aws.String(i.id),
+		})
+		if err != nil {
+			return err
+		}
+		if out.UserData == nil {
+			continue
+		}
+		data, err := userData(aws.StringValue(out.UserData.Value))
+		if err != nil {
+			log.WithError(err).WithField("arn", i.arn).Debug("could not decode user data")
+			continue
+		}
+		if !emit(i.arn, data) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// maxUserDataSize limits decompressed user data. EC2 limits it to 16KB before compression.
+const maxUserDataSize = 1 << 20
+
+// userData decodes base64 user data, which cloud-init also accepts gzipped.
+func userData(encoded string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		return data, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(io.LimitReader(r, maxUserDataSize))
+}
+
+// sweepECS scans the container definitions of active task definitions, which include their environment, commands,
+// and entry points.
+func (s *Source) sweepECS(ctx context.Context, c *clients, region string, emit func(arn string, data []byte) bool) error {
+	var arns []string
+	input := &ecs.ListTaskDefinitionsInput{Status: aws.String(ecs.TaskDefinitionStatusActive)}
+	err := c.ecs.ListTaskDefinitionsPagesWithContext(ctx, input, func(page *ecs.ListTaskDefinitionsOutput, _ bool) bool {
+		arns = append(arns, aws.StringValueSlice(page.TaskDefinitionArns)...)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, arn := range arns {
+		out, err := c.ecs.DescribeTaskDefinitionWithContext(ctx, &ecs.DescribeTaskDefinitionInput{TaskDefinition: aws.String(arn)})
+		if err != nil {
+			return err
+		}
+		if out.TaskDefinition == nil {
+			continue
+		}
+		data, err := json.MarshalIndent(out.TaskDefinition.ContainerDefinitions, "", "  ")
+		if err != nil {
+			return errors.New(err)
+		}
+		if !emit(arn, data) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// sweepCloudFormation scans the templates of stacks.
+func (s *Source) sweepCloudFormation(ctx context.Context, c *clients, region string, emit func(arn string, data []byte) bool) error {
+	var stacks []*cloudformation.Stack
+	err := c.cloudformation.DescribeStacksPagesWithContext(ctx, &cloudformation.DescribeStacksInput{}, func(page *cloudformation.DescribeStacksOutput, _ bool) bool {
+		stacks = append(stacks, page.Stacks...)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, stack := range stacks {
+		out, err := c.cloudformation.GetTemplateWithContext(ctx, &cloudformation.GetTemplateInput{StackName: stack.StackId})
+		if err != nil {
+			return err
+		}
+		if !emit(aws.StringValue(stack.StackId), []byte(aws.StringValue(out.TemplateBody))) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// sweepSSM scans the content of the account's own documents. Documents shared by AWS and other accounts are skipped.
+func (s *Source) sweepSSM(ctx context.Context, c *clients, region string, emit func(arn string, data []byte) bool) error {
+	var documents []*ssm.DocumentIdentifier
+	input := &ssm.ListDocumentsInput{
+		Filters: []*ssm.DocumentKeyValuesFilter{{Key: aws.String("Owner"), Values: aws.StringSlice([]string{"Self"})}},
+	}
+	err := c.ssm.ListDocumentsPagesWithContext(ctx, input, func(page *ssm.ListDocumentsOutput, _ bool) bool {
+		documents = append(documents, page.DocumentIdentifiers...)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, document := range documents {
+		out, err := c.ssm.GetDocumentWithContext(ctx, &ssm.GetDocumentInput{Name: document.Name})
+		if err != nil {
+			return err
+		}
+		arn := fmt.Sprintf("arn:aws:ssm:%s:%s:document/%s", region, aws.StringValue(document.Owner), aws.StringValue(document.Name))
+		if !emit(arn, []byte(aws.StringValue(out.Content))) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// accountFromARN returns the account ID field of an ARN, such as 123456789012 in
+// arn:aws:lambda:us-east-1:123456789012:function:api.
+func accountFromARN(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 {
+		return ""
+	}
+	return parts[4]
+}
@@ -0,0 +1,168 @@
+package awsaccount
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+type fakeLambda struct{ lambdaiface.LambdaAPI }
+
+func (fakeLambda) ListFunctionsPagesWithContext(_ aws.Context, _ *lambda.ListFunctionsInput, fn func(*lambda.ListFunctionsOutput, bool) bool, _ ...request.Option) error {
+	fn(&lambda.ListFunctionsOutput{Functions: []*lambda.FunctionConfiguration{
+		{FunctionArn: aws.String("arn:aws:lambda:us-east-1:123456789012:function:api"), Environment: &lambda.EnvironmentResponse{
+			Variables: aws.StringMap(map[string]string{"DB_PASSWORD": "lambda-secret", "STAGE": "prod"}),
+		}},
+		{FunctionArn: aws.String("arn:aws:lambda:us-east-1:123456789012:function:noenv")},
+	}}, true)
+	return nil
+}
+
+type fakeEC2 struct{ ec2iface.EC2API }
+
+func (fakeEC2) DescribeInstancesPagesWithContext(_ aws.Context, _ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, _ ...request.Option) error {
+	fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{
+		OwnerId:   aws.String("123456789012"),
+		Instances: []*ec2.Instance{{InstanceId: aws.String("i-0abc")}},
+	}}}, true)
+	return nil
+}
+
+func (fakeEC2) DescribeInstanceAttributeWithContext(_ aws.Context, in *ec2.DescribeInstanceAttributeInput, _ ...request.Option) (*ec2.DescribeInstanceAttributeOutput, error) {
+	script := base64.StdEncoding.EncodeToString([]byte("#!/bin/sh\nexport API_TOKEN=ec2-secret\n"))
+	return &ec2.DescribeInstanceAttributeOutput{UserData: &ec2.AttributeValue{Value: aws.String(script)}}, nil
+}
+
+type fakeECS struct{ ecsiface.ECSAPI }
+
+func (fakeECS) ListTaskDefinitionsPagesWithContext(_ aws.Context, _ *ecs.ListTaskDefinitionsInput, _ func(*ecs.ListTaskDefinitionsOutput, bool) bool, _ ...request.Option) error {
+	return errors.New("AccessDeniedException")
+}
+
+type fakeCloudFormation struct {
+	cloudformationiface.CloudFormationAPI
+}
+
+func (fakeCloudFormation) DescribeStacksPagesWithContext(_ aws.Context, _ *cloudformation.DescribeStacksInput, fn func(*cloudformation.DescribeStacksOutput, bool) bool, _ ...request.Option) error {
+	fn(&cloudformation.DescribeStacksOutput{Stacks: []*cloudformation.Stack{
+		{StackId: aws.String("arn:aws:cloudformation:us-east-1:123456789012:stack/web/1")},
+	}}, true)
+	return nil
+}
+
+func (fakeCloudFormation) GetTemplateWithContext(_ aws.Context, _ *cloudformation.GetTemplateInput, _ ...request.Option) (*cloudformation.GetTemplateOutput, error) {
+	return &cloudformation.GetTemplateOutput{TemplateBody: aws.String("Resources:\n  DB:\n    Properties:\n      MasterUserPassword: cfn-secret\n")}, nil
+}
+
+type fakeSSM struct{ ssmiface.SSMAPI }
+
+func (fakeSSM) ListDocumentsPagesWithContext(_ aws.Context, _ *ssm.ListDocumentsInput, fn func(*ssm.ListDocumentsOutput, bool) bool, _ ...request.Option) error {
+	fn(&ssm.ListDocumentsOutput{DocumentIdentifiers: []*ssm.DocumentIdentifier{
+		{Name: aws.String("deploy"), Owner: aws.String("123456789012")},
+	}}, true)
+	return nil
+}
+
+func (fakeSSM) GetDocumentWithContext(_ aws.Context, _ *ssm.GetDocumentInput, _ ...request.Option) (*ssm.GetDocumentOutput, error) {
+	return &ssm.GetDocumentOutput{Content: aws.String(`{"mainSteps":[{"inputs":{"runCommand":["curl -u admin:ssm-secret"]}}]}`)}, nil
+}
+
+func TestSource_Chunks(t *testing.T) {
+	s := &Source{
+		Regions: []string{"us-east-1"},
+		newClients: func(region string) (*clients, error) {
+			return &clients{
+				lambda:         fakeLambda{},
+				ec2:            fakeEC2{},
+				ecs:            fakeECS{},
+				cloudformation: fakeCloudFormation{},
+				ssm:            fakeSSM{},
+			}, nil
+		},
+	}
+	chunksChan := make(chan *sources.Chunk, 10)
+	if err := s.Chunks(context.Background(), chunksChan); err != nil {
+		t.Fatal(err)
+	}
+	close(chunksChan)
+
+	want := map[string]string{
+		"arn:aws:lambda:us-east-1:123456789012:function:api":        "DB_PASSWORD=lambda-secret\nSTAGE=prod\n",
+		"arn:aws:ec2:us-east-1:123456789012:instance/i-0abc":        "ec2-secret",
+		"arn:aws:cloudformation:us-east-1:123456789012:stack/web/1": "cfn-secret",
+		"arn:aws:ssm:us-east-1:123456789012:document/deploy":        "ssm-secret",
+	}
+	got := 0
+	for chunk := range chunksChan {
+		got++
+		metadata := chunk.SourceMetadata.GetAws()
+		secret, ok := want[metadata.GetArn()]
+		if !ok {
+			t.Errorf("unexpected chunk for %s", metadata.GetArn())
+			continue
+		}
+		if !strings.Contains(string(chunk.Data), secret) {
+			t.Errorf("%s: expected %q in chunk, got: %q", metadata.GetArn(), secret, chunk.Data)
+		}
+		if metadata.GetAccount() != "123456789012" || metadata.GetRegion() != "us-east-1" {
+			t.Errorf("%s: unexpected account and region: %s %s", metadata.GetArn(), metadata.GetAccount(), metadata.GetRegion())
+		}
+	}
+	if got != len(want) {
+		t.Errorf("unexpected number of chunks. Got: %d, Expected: %d", got, len(want))
+	}
+}
+
+func TestUserData(t *testing.T) {
+	script := "#!/bin/sh\necho hello\n"
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, _ = w.Write([]byte(script))
+	_ = w.Close()
+
+	for name, encoded := range map[string]string{
+		"plain":   base64.StdEncoding.EncodeToString([]byte(script)),
+		"gzipped": base64.StdEncoding.EncodeToString(buf.Bytes()),
+	} {
+		got, err := userData(encoded)
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+			continue
+		}
+		if string(got) != script {
+			t.Errorf("%s: got %q, expected %q", name, got, script)
+		}
+	}
+}
+
+func TestAccountFromARN(t *testing.T) {
+	tests := map[string]string{
+		"arn:aws:lambda:us-east-1:123456789012:function:api": "123456789012",
+		"arn:aws:s3:::bucket":                                "",
+		"not-an-arn":                                         "",
+	}
+	for arn, want := range tests {
+		if got := accountFromARN(arn); got != want {
+			t.Errorf("accountFromARN(%q) = %q, expected %q", arn, got, want)
+		}
+	}
+}
@@ -0,0 +1,381 @@
+// Package azuresubscription sweeps Azure subscriptions for credentials in the places they're commonly left outside
+// Key Vault: App Service and Function app settings and connection strings, virtual machine custom script extensions,
+// and the templates and parameters of ARM deployments. Each chunk is attributed to the ID of the resource it was read
+// from.
+package azuresubscription
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/go-errors/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+const (
+	endpoint = "https://management.azure.com"
+	// maxScriptSize limits decoded custom scripts, which Azure limits to 256KB before compression.
+	maxScriptSize = 1 << 20
+)
+
+// Services are the services that can be swept, in the order they're swept.
+var Services = []string{"appservice", "functions", "vm", "deployments"}
+
+// apiVersions are the Resource Manager API versions used for each resource provider.
+var apiVersions = map[string]string{
+	"subscriptions": "2020-01-01",
+	"web":           "2022-03-01",
+	"compute":       "2022-08-01",
+	"resources":     "2021-04-01",
+}
+
+// Source sweeps subscriptions.
+type Source struct {
+	// Subscriptions are the IDs of the subscriptions to sweep. If none are given, every enabled subscription the
+	// credentials can read is swept.
+	Subscriptions []string
+	// Services to sweep. If none are given, all of Services are swept.
+	Services []string
+
+	SourceName string
+	Verify     bool
+
+	authorizer autorest.Authorizer
+	client     *http.Client
+	endpoint   string
+}
+
+type emitFunc func(resourceID, location string, data []byte) bool
+
+// sweeps are the sweep of each service. App Service and Function apps are both sites, and are told apart by the
+// site's kind.
+var sweeps = map[string]func(s *Source, ctx context.Context, subscription string, emit emitFunc) error{
+	"appservice": func(s *Source, ctx context.Context, subscription string, emit emitFunc) error {
+		return s.sweepSites(ctx, subscription, false, emit)
+	},
+	"functions": func(s *Source, ctx context.Context, subscription string, emit emitFunc) error {
+		return s.sweepSites(ctx, subscription, true, emit)
+	},
+	"vm":          (*Source).sweepVMExtensions,
+	"deployments": (*Source).sweepDeployments,
+}
+
+// Chunks sweeps each subscription, sending a chunk for each resource. A service that can't be read, for example
+// because the credentials lack permission, is logged and skipped so the rest of the subscription is still swept.
+func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk) error {
+	services := s.Services
+	if len(services) == 0 {
+		services = Services
+	}
+	for _, service := range services {
+		if _, ok := sweeps[service]; !ok {
+			return fmt.Errorf("unknown Azure service %q, expected one of %s", service, strings.Join(Services, ", "))
+		}
+	}
+	if s.authorizer == nil {
+		authorizer, err := auth.NewAuthorizerFromEnvironment()
+		if err != nil {
+			return fmt.Errorf("could not load Azure credentials: %w", err)
+		}
+		s.authorizer = authorizer
+	}
+	if s.client == nil {
+		s.client = common.SaneHttpClient()
+	}
+	if s.endpoint == "" {
+		s.endpoint = endpoint
+	}
+
+	subscriptions := s.Subscriptions
+	if len(subscriptions) == 0 {
+		var err error
+		if subscriptions, err = s.enabledSubscriptions(ctx); err != nil {
+			return errors.WrapPrefix(err, "could not list subscriptions", 0)
+		}
+	}
+
+	for _, subscription := range subscriptions {
+		for _, service := range services {
+			if common.IsDone(ctx) {
+				return nil
+			}
+			service := service
+			emit := func(resourceID, location string, data []byte) bool {
+				if len(bytes.TrimSpace(data)) == 0 {
+					return true
+				}
+				select {
+				case chunksChan <- s.chunk(service, subscription, location, resourceID, data):
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			}
+			if err := sweeps[service](s, ctx, subscription, emit); err != nil {
+				if common.IsDone(ctx) {
+					return nil
+				}
+				log.WithError(err).WithField("subscription", subscription).Warnf("could not sweep %s", service)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Source) chunk(service, subscription, location, resourceID string, data []byte) *sources.Chunk {
+	return &sources.Chunk{
+		SourceName: s.SourceName,
+		SourceType: sourcespb.SourceType_SOURCE_TYPE_AZURE_SUBSCRIPTION,
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_AzureResource{
+				AzureResource: &source_metadatapb.AzureResource{
+					ResourceId:   resourceID,
+					Service:      service,
+					Subscription: subscription,
+					Location:     location,
+				},
+			},
+		},
+		Data:   data,
+		Verify: s.Verify,
+	}
+}
+
+// do decodes the JSON response to a request for a resource ID, or for a nextLink URL.
+func (s *Source) do(ctx context.Context, method, path, provider string, v interface{}) error {
+	u := path
+	if !strings.HasPrefix(path, "https://") && !strings.HasPrefix(path, "http://") {
+		u = strings.TrimSuffix(s.endpoint, "/") + path + "?api-version=" + apiVersions[provider]
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return err
+	}
+	req, err = autorest.Prepare(req, s.authorizer.WithAuthorization())
+	if err != nil {
+		return fmt.Errorf("could not authorize request: %w", err)
+	}
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("%s %s: unexpected status %d: %s", method, path, res.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// resource is the part of a resource listed by Resource Manager that's used by the sweeps.
+type resource struct {
+	ID         string          `json:"id"`
+	Name       string          `json:"name"`
+	Kind       string          `json:"kind"`
+	Location   string          `json:"location"`
+	Properties json.RawMessage `json:"properties"`
+}
+
+// list returns the resources of a collection, following its next links.
+func (s *Source) list(ctx context.Context, path, provider string) ([]resource, error) {
+	var resources []resource
+	for path != "" {
+		var page struct {
+			Value    []resource `json:"value"`
+			NextLink string     `json:"nextLink"`
+		}
+		if err := s.do(ctx, http.MethodGet, path, provider, &page); err != nil {
+			return nil, err
+		}
+		resources = append(resources, page.Value...)
+		path = page.NextLink
+	}
+	return resources, nil
+}
+
+// enabledSubscriptions lists the enabled subscriptions the credentials can read.
+func (s *Source) enabledSubscriptions(ctx context.Context) ([]string, error) {
+	var subscriptions []string
+	for path := "/subscriptions"; path != ""; {
+		var page struct {
+			Value []struct {
+				SubscriptionID string `json:"subscriptionId"`
+				State          string `json:"state"`
+			} `json:"value"`
+			NextLink string `json:"nextLink"`
+		}
+		if err := s.do(ctx, http.MethodGet, path, "subscriptions", &page); err != nil {
+			return nil, err
+		}
+		for _, sub := range page.Value {
+			if sub.State == "Enabled" {
+				subscriptions = append(subscriptions, sub.SubscriptionID)
+			}
+		}
+		path = page.NextLink
+	}
+	return subscriptions, nil
+}
+
+// sweepSites scans the app settings and connection strings of web apps, or of Function apps, one per line as
+// NAME=value. Settings that reference Key Vault are scanned too, but only hold the reference.
+func (s *Source) sweepSites(ctx context.Context, subscription string, functions bool, emit emitFunc) error {
+	sites, err := s.list(ctx, "/subscriptions/"+subscription+"/providers/Microsoft.Web/sites", "web")
+	if err != nil {
+		return err
+	}
+	for _, site := range sites {
+		if strings.Contains(strings.ToLower(site.Kind), "functionapp") != functions {
+			continue
+		}
+		var settings struct {
+			Properties map[string]string `json:"properties"`
+		}
+		if err := s.do(ctx, http.MethodPost, site.ID+"/config/appsettings/list", "web", &settings); err != nil {
+			return err
+		}
+		var connectionStrings struct {
+			Properties map[string]struct {
+				Value string `json:"value"`
+			} `json:"properties"`
+		}
+		if err := s.do(ctx, http.MethodPost, site.ID+"/config/connectionstrings/list", "web", &connectionStrings); err != nil {
+			return err
+		}
+		values := settings.Properties
+		if values == nil {
+			values = map[string]string{}
+		}
+		for name, cs := range connectionStrings.Properties {
+			values[name] = cs.Value
+		}
+		if !emit(site.ID, site.Location, settingLines(values)) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// sweepVMExtensions scans the public settings of virtual machines' custom script extensions, which hold the command
+// run and, on Linux, the script itself. Protected settings are never returned by the API.
+func (s *Source) sweepVMExtensions(ctx context.Context, subscription string, emit emitFunc) error {
+	vms, err := s.list(ctx, "/subscriptions/"+subscription+"/providers/Microsoft.Compute/virtualMachines", "compute")
+	if err != nil {
+		return err
+	}
+	for _, vm := range vms {
+		extensions, err := s.list(ctx, vm.ID+"/extensions", "compute")
+		if err != nil {
+			return err
+		}
+		for _, ext := range extensions {
+			var props struct {
+				Type     string                 `json:"type"`
+				Settings map[string]interface{} `json:"settings"`
+			}
+			if json.Unmarshal(ext.Properties, &props) != nil || !strings.HasPrefix(props.Type, "CustomScript") {
+				continue
+			}
+			data, _ := json.MarshalIndent(props.Settings, "", "  ")
+			if encoded, ok := props.Settings["script"].(string); ok {
+				if script, err := decodeScript(encoded); err == nil {
+					data = append(append(data, '\n'), script...)
+				}
+			}
+			if !emit(ext.ID, vm.Location, data) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// decodeScript decodes the script of a Linux custom script extension, which is base64 and may be gzipped.
+func decodeScript(encoded string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		return data, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(io.LimitReader(r, maxScriptSize))
+}
+
+// sweepDeployments scans the parameters and exported templates of the subscription's deployments and those of each
+// resource group. Parameters of type securestring are never returned by the API, so this finds secrets that were
+// passed as plain strings or written into templates.
+func (s *Source) sweepDeployments(ctx context.Context, subscription string, emit emitFunc) error {
+	groups, err := s.list(ctx, "/subscriptions/"+subscription+"/resourcegroups", "resources")
+	if err != nil {
+		return err
+	}
+	scopes := append([]resource{{ID: "/subscriptions/" + subscription}}, groups...)
+
+	for _, scope := range scopes {
+		deployments, err := s.list(ctx, scope.ID+"/providers/Microsoft.Resources/deployments", "resources")
+		if err != nil {
+			return err
+		}
+		for _, deployment := range deployments {
+			var props struct {
+				Parameters json.RawMessage `json:"parameters"`
+			}
+			_ = json.Unmarshal(deployment.Properties, &props)
+			var exported struct {
+				Template json.RawMessage `json:"template"`
+			}
+			if err := s.do(ctx, http.MethodPost, deployment.ID+"/exportTemplate", "resources", &exported); err != nil {
+				return err
+			}
+			var data bytes.Buffer
+			for _, part := range []json.RawMessage{props.Parameters, exported.Template} {
+				if len(part) > 0 && json.Indent(&data, part, "", "  ") == nil {
+					data.WriteByte('\n')
+				}
+			}
+			location := deployment.Location
+			if location == "" {
+				location = scope.Location
+			}
+			if !emit(deployment.ID, location, data.Bytes()) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// settingLines returns settings one per line as NAME=value, sorted by name.
+func settingLines(settings map[string]string) []byte {
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var data bytes.Buffer
+	for _, name := range names {
+		fmt.Fprintf(&data, "%s=%s\n", name, settings[name])
+	}
+	return data.Bytes()
+}
@@ -0,0 +1,84 @@
+package azuresubscription
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+func TestSource_Chunks(t *testing.T) {
+	const sub = "/subscriptions/0000"
+	script := base64.StdEncoding.EncodeToString([]byte("#!/bin/sh\nexport TOKEN=script-secret\n"))
+	var server *httptest.Server
+	responses := map[string]string{
+		"GET /subscriptions": `{"value":[{"subscriptionId":"0000","state":"Enabled"},{"subscriptionId":"1111","state":"Disabled"}]}`,
+
+		"GET " + sub + "/providers/Microsoft.Web/sites": `{"value":[{"id":"` + sub + `/resourceGroups/web/providers/Microsoft.Web/sites/shop","kind":"app","location":"westeurope"}],"nextLink":"{server}/next-sites"}`,
+		"GET /next-sites": `{"value":[{"id":"` + sub + `/resourceGroups/web/providers/Microsoft.Web/sites/jobs","kind":"functionapp,linux","location":"eastus"}]}`,
+		"POST " + sub + "/resourceGroups/web/providers/Microsoft.Web/sites/shop/config/appsettings/list":       `{"properties":{"STRIPE_KEY":"appservice-secret"}}`,
+		"POST " + sub + "/resourceGroups/web/providers/Microsoft.Web/sites/shop/config/connectionstrings/list": `{"properties":{"db":{"value":"Server=db;Password=connection-secret","type":"SQLAzure"}}}`,
+		"POST " + sub + "/resourceGroups/web/providers/Microsoft.Web/sites/jobs/config/appsettings/list":       `{"properties":{"QUEUE_KEY":"functions-secret"}}`,
+		"POST " + sub + "/resourceGroups/web/providers/Microsoft.Web/sites/jobs/config/connectionstrings/list": `{"properties":{}}`,
+
+		"GET " + sub + "/providers/Microsoft.Compute/virtualMachines":                                   `{"value":[{"id":"` + sub + `/resourceGroups/vms/providers/Microsoft.Compute/virtualMachines/vm1","location":"eastus"}]}`,
+		"GET " + sub + "/resourceGroups/vms/providers/Microsoft.Compute/virtualMachines/vm1/extensions": `{"value":[{"id":"` + sub + `/resourceGroups/vms/providers/Microsoft.Compute/virtualMachines/vm1/extensions/setup","properties":{"type":"CustomScript","settings":{"script":"` + script + `"}}},{"id":"` + sub + `/resourceGroups/vms/providers/Microsoft.Compute/virtualMachines/vm1/extensions/agent","properties":{"type":"AzureMonitorLinuxAgent","settings":{"key":"not-scanned"}}}]}`,
+
+		"GET " + sub + "/resourcegroups":                                                                    `{"value":[{"id":"` + sub + `/resourceGroups/infra","location":"northeurope"}]}`,
+		"GET " + sub + "/providers/Microsoft.Resources/deployments":                                         `{"value":[]}`,
+		"GET " + sub + "/resourceGroups/infra/providers/Microsoft.Resources/deployments":                    `{"value":[{"id":"` + sub + `/resourceGroups/infra/providers/Microsoft.Resources/deployments/db","properties":{"parameters":{"adminPassword":{"type":"String","value":"parameter-secret"}}}}]}`,
+		"POST " + sub + "/resourceGroups/infra/providers/Microsoft.Resources/deployments/db/exportTemplate": `{"template":{"resources":[]}}`,
+	}
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			http.Error(w, `{"error":{"code":"AuthorizationFailed"}}`, http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(strings.ReplaceAll(body, "{server}", server.URL)))
+	}))
+	defer server.Close()
+
+	s := &Source{
+		authorizer: autorest.NullAuthorizer{},
+		client:     server.Client(),
+		endpoint:   server.URL,
+	}
+	chunksChan := make(chan *sources.Chunk, 10)
+	if err := s.Chunks(context.Background(), chunksChan); err != nil {
+		t.Fatal(err)
+	}
+	close(chunksChan)
+
+	want := map[string]struct{ service, location, secret string }{
+		sub + "/resourceGroups/web/providers/Microsoft.Web/sites/shop":                               {"appservice", "westeurope", "STRIPE_KEY=appservice-secret\ndb=Server=db;Password=connection-secret"},
+		sub + "/resourceGroups/web/providers/Microsoft.Web/sites/jobs":                               {"functions", "eastus", "QUEUE_KEY=functions-secret"},
+		sub + "/resourceGroups/vms/providers/Microsoft.Compute/virtualMachines/vm1/extensions/setup": {"vm", "eastus", "export TOKEN=script-secret"},
+		sub + "/resourceGroups/infra/providers/Microsoft.Resources/deployments/db":                   {"deployments", "northeurope", "parameter-secret"},
+	}
+	got := 0
+	for chunk := range chunksChan {
+		got++
+		metadata := chunk.SourceMetadata.GetAzureResource()
+		expected, ok := want[metadata.GetResourceId()]
+		if !ok {
+			t.Errorf("unexpected chunk for %s", metadata.GetResourceId())
+			continue
+		}
+		if metadata.GetService() != expected.service || metadata.GetLocation() != expected.location || metadata.GetSubscription() != "0000" {
+			t.Errorf("%s: unexpected metadata: %v", metadata.GetResourceId(), metadata)
+		}
+		if !strings.Contains(string(chunk.Data), expected.secret) {
+			t.Errorf("%s: expected %q in chunk, got: %q", metadata.GetResourceId(), expected.secret, chunk.Data)
+		}
+	}
+	if got != len(want) {
+		t.Errorf("unexpected number of chunks. Got: %d, Expected: %d", got, len(want))
+	}
+}
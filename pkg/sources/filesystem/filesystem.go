@@ -8,6 +8,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/go-errors/errors"
 	log "github.com/sirupsen/logrus"
@@ -15,6 +16,7 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/handlers"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sanitizer"
@@ -26,6 +28,13 @@ const (
 	// Having a peek size larger than that ensures that we have complete credential coverage in our chunks.
 	BufferSize = 10 * 1024 // 10KB
 	PeekSize   = 3 * 1024  // 3KB
+
+	// MmapThreshold is the size from which files are memory mapped rather than read, such as database dumps and logs
+	// found in forensic disk scans.
+	MmapThreshold = 64 * 1024 * 1024 // 64MB
+	// mapWindow is the size of the region of a large file that is mapped at once. It's a multiple of BufferSize, so
+	// chunks never span windows, and of every platform's mapping alignment, 64KB on Windows.
+	mapWindow = 640 * BufferSize * 16 // 100MB
 )
 
 type Source struct {
@@ -36,6 +45,9 @@ type Source struct {
 	paths    []string
 	aCtx     context.Context
 	log      *log.Entry
+	archive  *handlers.Archive
+	// sshDirs holds the .ssh directories found while walking, by path, to describe the keys in them.
+	sshDirs map[string]*handlers.SSHDir
 	sources.Progress
 }
 
@@ -73,10 +85,176 @@ func (s *Source) Init(aCtx context.Context, name string, jobId, sourceId int64,
 	}
 
 	s.paths = conn.Directories
+	s.archive = handlers.NewArchive()
+	s.sshDirs = map[string]*handlers.SSHDir{}
 
 	return nil
 }
 
+// chunkReader sends the chunks of a file read through a buffer.
+func (s *Source) chunkReader(inputFile *os.File, path string, extra map[string]string, chunksChan chan *sources.Chunk, done *bool) error {
+	reader := bufio.NewReaderSize(bufio.NewReader(inputFile), BufferSize)
+	firstChunk := true
+	for {
+		if *done {
+			return nil
+		}
+
+		end := BufferSize
+		buf := make([]byte, BufferSize)
+		n, err := reader.Read(buf)
+
+		if n < BufferSize {
+			end = n
+		}
+
+		if end > 0 {
+			data := buf[0:end]
+
+			if firstChunk {
+				firstChunk = false
+				if common.SkipFile(path, data) {
+					s.RecordSkippedFile(path, sources.SkipReasonIgnoredType)
+					return nil
+				}
+			}
+
+			// We are peeking in case a secret exists in our chunk boundaries,
+			// but we never care if we've run into a peek error.
+			peekData, _ := reader.Peek(PeekSize)
+			chunksChan <- s.chunk(path, append(data, peekData...), extra)
+		}
+
+		// io.EOF can be emmitted when 0<n<buffer size
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			} else {
+				return err
+			}
+		}
+	}
+}
+
+// chunkMapped sends the chunks of a large file by mapping it into memory a window at a time, rather than reading it
+// through buffers. Each chunk is copied out of the window, so the window can be unmapped as soon as it's chunked and
+// only one is mapped at a time. It returns false if the file couldn't be mapped and should be read instead.
+func (s *Source) chunkMapped(inputFile *os.File, path string, size int64, extra map[string]string, chunksChan chan *sources.Chunk, done *bool) (bool, error) {
+	for windowStart := int64(0); windowStart < size; windowStart += mapWindow {
+		// Each window is mapped with the peek of its last chunk, which overlaps the next window.
+		length := int64(mapWindow + PeekSize)
+		if windowStart+length > size {
+			length = size - windowStart
+		}
+		window, unmap, err := mapRegion(inputFile, windowStart, int(length))
+		if err != nil {
+			if windowStart == 0 {
+				return false, nil
+			}
+			return true, errors.WrapPrefix(err, "could not map "+path, 0)
+		}
+
+		chunkEnd := int64(mapWindow)
+		if chunkEnd > length {
+			chunkEnd = length
+		}
+		for offset := int64(0); offset < chunkEnd; offset += BufferSize {
+			if *done {
+				return true, unmap()
+			}
+			end := offset + BufferSize + PeekSize
+			if end > length {
+				end = length
+			}
+			data := make([]byte, end-offset)
+			copy(data, window[offset:end])
+
+			if windowStart == 0 && offset == 0 && common.SkipFile(path, data) {
+				s.RecordSkippedFile(path, sources.SkipReasonIgnoredType)
+				return true, unmap()
+			}
+			chunksChan <- s.chunk(path, data, extra)
+		}
+		if err := unmap(); err != nil {
+			return true, errors.WrapPrefix(err, "could not unmap "+path, 0)
+		}
+	}
+	return true, nil
+}
+
+// chunkSourceMap sends the chunks of the sources embedded in a source map, or of a JavaScript bundle with the position
+// of each chunk in its original sources. It returns false if the file is neither, or its source map can't be read, in
+// which case it should be scanned as is.
+func (s *Source) chunkSourceMap(ctx context.Context, inputFile *os.File, path, fullPath string, size int64, chunksChan chan *sources.Chunk) bool {
+	isMap := handlers.IsSourceMapPath(path)
+	if (!isMap && !handlers.IsJavaScriptPath(path)) || size > handlers.MaxSourceMapSize {
+		return false
+	}
+	data, err := io.ReadAll(io.NewSectionReader(inputFile, 0, size))
+	if err != nil {
+		log.WithError(err).Warnf("unable to read file: %s", path)
+		return false
+	}
+
+	// The sources of a map are reported like the entries of an archive, as <map>/<source>.
+	chunkSources := func(m *handlers.SourceMap, mapPath string) {
+		m.Chunks(ctx, func(source string, data []byte, original *handlers.SourcePosition) {
+			chunk := s.chunk(mapPath+"/"+source, data, nil)
+			chunk.Provenance = []string{mapPath, source}
+			chunk.Original = original
+			chunksChan <- chunk
+		})
+	}
+	if isMap {
+		m, err := handlers.ParseSourceMap(data)
+		if err != nil || !m.HasContent() {
+			return false
+		}
+		chunkSources(m, path)
+		return true
+	}
+
+	bundle, err := handlers.ReadBundle(fullPath, data)
+	if err != nil {
+		log.WithError(err).Debugf("unable to read source map of %s", path)
+	}
+	if bundle == nil {
+		return false
+	}
+	var extra map[string]string
+	if bundle.Minified {
+		extra = map[string]string{"minified": "true"}
+	}
+	bundle.Chunks(ctx, func(data []byte, original *handlers.GeneratedPosition) {
+		chunk := s.chunk(path, data, extra)
+		chunk.Original = original
+		chunksChan <- chunk
+	})
+	// A map inlined in the bundle isn't walked on its own, so its sources are scanned with the bundle.
+	if bundle.MapPath == "" {
+		chunkSources(bundle.Map, path)
+	}
+	return true
+}
+
+func (s *Source) chunk(path string, data []byte, extra map[string]string) *sources.Chunk {
+	return &sources.Chunk{
+		SourceType: s.Type(),
+		SourceName: s.name,
+		SourceID:   s.SourceID(),
+		Data:       data,
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_Filesystem{
+				Filesystem: &source_metadatapb.Filesystem{
+					File: sanitizer.UTF8(path),
+				},
+			},
+		},
+		Verify:    s.verify,
+		ExtraData: extra,
+	}
+}
+
 // Chunks emits chunks of bytes over a channel.
 func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk) error {
 	for i, path := range s.paths {
@@ -89,14 +267,32 @@ func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk) err
 			done = true
 		}()
 
-		err := fs.WalkDir(os.DirFS(cleanPath), ".", func(relativePath string, d fs.DirEntry, err error) error {
+		// Files are opened by their long path, so that paths beyond Windows' 260 character limit can be read, but are
+		// reported relative to the directory as it was given.
+		root := common.LongPath(cleanPath)
+		err := filepath.WalkDir(root, func(fullPath string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+
+			relativePath, err := filepath.Rel(root, fullPath)
 			if err != nil {
 				return nil
 			}
+			path := common.DisplayPath(filepath.Join(cleanPath, relativePath))
 
-			path := filepath.Join(cleanPath, relativePath)
+			// A .ssh directory is walked before the keys in it, so its config and known_hosts are read first.
+			if d.IsDir() && handlers.IsSSHDir(fullPath) {
+				sshDir, err := handlers.ReadSSHDir(fullPath)
+				if err != nil {
+					log.WithError(err).Warnf("unable to read ssh directory: %s", path)
+					return nil
+				}
+				s.sshDirs[fullPath] = sshDir
+				return nil
+			}
 
-			fileStat, err := os.Stat(path)
+			fileStat, err := os.Stat(fullPath)
 			if err != nil {
 				log.WithError(err).Warnf("unable to stat file: %s", path)
 				return nil
@@ -105,66 +301,47 @@ func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk) err
 				return nil
 			}
 
-			inputFile, err := os.Open(path)
+			inputFile, err := os.Open(fullPath)
 			if err != nil {
 				log.Warn(err)
 				return nil
 			}
 			defer inputFile.Close()
 
-			reader := bufio.NewReaderSize(bufio.NewReader(inputFile), BufferSize)
-			firstChunk := true
-			for {
-				if done {
-					return nil
+			// Archives are streamed through the chunker an entry at a time, rather than extracted to disk.
+			isArchive, err := s.archive.Chunks(ctx, io.NewSectionReader(inputFile, 0, fileStat.Size()), func(provenance []string, data []byte) {
+				chunk := s.chunk(path+"/"+strings.Join(provenance, "/"), data, nil)
+				chunk.Provenance = append([]string{path}, provenance...)
+				chunksChan <- chunk
+			})
+			if isArchive {
+				if err != nil {
+					log.WithError(err).Warnf("unable to read archive: %s", path)
 				}
+				return nil
+			}
 
-				end := BufferSize
-				buf := make([]byte, BufferSize)
-				n, err := reader.Read(buf)
-
-				if n < BufferSize {
-					end = n
-				}
+			// Source maps are scanned through the sources they embed, and JavaScript bundles with a source map are
+			// mapped back to their original sources.
+			if s.chunkSourceMap(ctx, inputFile, path, fullPath, fileStat.Size(), chunksChan) {
+				return nil
+			}
 
-				if end > 0 {
-					data := buf[0:end]
-
-					if firstChunk {
-						firstChunk = false
-						if common.SkipFile(path, data) {
-							return nil
-						}
-					}
-
-					// We are peeking in case a secret exists in our chunk boundaries,
-					// but we never care if we've run into a peek error.
-					peekData, _ := reader.Peek(PeekSize)
-					chunksChan <- &sources.Chunk{
-						SourceType: s.Type(),
-						SourceName: s.name,
-						SourceID:   s.SourceID(),
-						Data:       append(data, peekData...),
-						SourceMetadata: &source_metadatapb.MetaData{
-							Data: &source_metadatapb.MetaData_Filesystem{
-								Filesystem: &source_metadatapb.Filesystem{
-									File: sanitizer.UTF8(path),
-								},
-							},
-						},
-						Verify: s.verify,
-					}
-				}
+			var extra map[string]string
+			if sshDir, ok := s.sshDirs[filepath.Dir(fullPath)]; ok {
+				head := make([]byte, BufferSize+PeekSize)
+				n, _ := inputFile.ReadAt(head, 0)
+				extra = sshDir.KeyExtraData(filepath.Base(fullPath), head[:n])
+			}
 
-				// io.EOF can be emmitted when 0<n<buffer size
-				if err != nil {
-					if errors.Is(err, io.EOF) {
-						return nil
-					} else {
-						return err
-					}
+			if fileStat.Size() >= MmapThreshold {
+				mapped, err := s.chunkMapped(inputFile, path, fileStat.Size(), extra, chunksChan, &done)
+				if mapped || err != nil {
+					return err
 				}
+				log.Debugf("could not map %s, reading it instead", path)
 			}
+			return s.chunkReader(inputFile, path, extra, chunksChan, &done)
 		})
 
 		if err != nil && err != io.EOF {
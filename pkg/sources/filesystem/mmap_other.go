@@ -0,0 +1,14 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !windows
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!windows
+
+package filesystem
+
+import (
+	"errors"
+	"os"
+)
+
+// mapRegion isn't supported on this platform, so large files are read like any other.
+func mapRegion(*os.File, int64, int) ([]byte, func() error, error) {
+	return nil, nil, errors.New("memory mapping is not supported on this platform")
+}
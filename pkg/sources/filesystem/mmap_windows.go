@@ -0,0 +1,38 @@
+//go:build windows
+// +build windows
+
+package filesystem
+
+import (
+	"os"
+	"reflect"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// mapRegion maps length bytes of f at offset, which must be a multiple of the platform's mapping alignment, read-only.
+func mapRegion(f *os.File, offset int64, length int) ([]byte, func() error, error) {
+	end := uint64(offset) + uint64(length)
+	mapping, err := windows.CreateFileMapping(windows.Handle(f.Fd()), nil, windows.PAGE_READONLY, uint32(end>>32), uint32(end), nil)
+	if err != nil {
+		return nil, nil, os.NewSyscallError("CreateFileMapping", err)
+	}
+	addr, err := windows.MapViewOfFile(mapping, windows.FILE_MAP_READ, uint32(uint64(offset)>>32), uint32(offset), uintptr(length))
+	if err != nil {
+		windows.CloseHandle(mapping)
+		return nil, nil, os.NewSyscallError("MapViewOfFile", err)
+	}
+	// The view isn't Go memory, so the slice is pointed at it through its header.
+	var data []byte
+	header := (*reflect.SliceHeader)(unsafe.Pointer(&data))
+	header.Data, header.Len, header.Cap = addr, length, length
+	unmap := func() error {
+		err := windows.UnmapViewOfFile(addr)
+		if closeErr := windows.CloseHandle(mapping); err == nil {
+			err = closeErr
+		}
+		return err
+	}
+	return data, unmap, nil
+}
@@ -0,0 +1,453 @@
+// Package gcpproject sweeps GCP projects for credentials in the places they're commonly left outside Secret Manager:
+// Cloud Functions and Cloud Run environment variables, Compute Engine instance and project metadata such as startup
+// scripts, and Deployment Manager configurations. Each chunk is attributed to the full resource name of the resource
+// it was read from.
+package gcpproject
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// scope is read-only access to the APIs swept.
+const scope = "https://www.googleapis.com/auth/cloud-platform.read-only"
+
+// Services are the services that can be swept, in the order they're swept.
+var Services = []string{"functions", "run", "compute", "deploymentmanager"}
+
+// defaultEndpoints are the API endpoints, by API name.
+var defaultEndpoints = map[string]string{
+	"cloudfunctions":       "https://cloudfunctions.googleapis.com",
+	"run":                  "https://run.googleapis.com",
+	"compute":              "https://compute.googleapis.com",
+	"deploymentmanager":    "https://deploymentmanager.googleapis.com",
+	"cloudresourcemanager": "https://cloudresourcemanager.googleapis.com",
+}
+
+// Source sweeps projects, given directly or as every active project under an organization.
+type Source struct {
+	Projects []string
+	// Organization is the numeric ID of an organization whose projects are swept, including those in folders.
+	Organization string
+	// CredentialsFile is a service account key to authenticate with. If it's empty, the application default
+	// credentials are used.
+	CredentialsFile string
+	// Services to sweep. If none are given, all of Services are swept.
+	Services []string
+
+	SourceName string
+	Verify     bool
+
+	client    *http.Client
+	endpoints map[string]string
+}
+
+type emitFunc func(resource, location string, data []byte) bool
+
+// sweeps are the sweep of each service.
+var sweeps = map[string]func(s *Source, ctx context.Context, project string, emit emitFunc) error{
+	"functions":         (*Source).sweepFunctions,
+	"run":               (*Source).sweepRun,
+	"compute":           (*Source).sweepCompute,
+	"deploymentmanager": (*Source).sweepDeploymentManager,
+}
+
+// Chunks sweeps each project, sending a chunk for each resource. A service that can't be read, for example because
+// its API isn't enabled in the project, is logged and skipped so the rest of the project is still swept.
+func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk) error {
+	services := s.Services
+	if len(services) == 0 {
+		services = Services
+	}
+	for _, service := range services {
+		if _, ok := sweeps[service]; !ok {
+			return fmt.Errorf("unknown GCP service %q, expected one of %s", service, strings.Join(Services, ", "))
+		}
+	}
+	if s.client == nil {
+		client, err := s.authenticate(ctx)
+		if err != nil {
+			return err
+		}
+		s.client = client
+	}
+	if s.endpoints == nil {
+		s.endpoints = defaultEndpoints
+	}
+
+	projects := s.Projects
+	if s.Organization != "" {
+		orgProjects, err := s.organizationProjects(ctx, "organizations/"+s.Organization)
+		if err != nil {
+			return errors.WrapPrefix(err, "could not list the organization's projects", 0)
+		}
+		projects = append(append([]string{}, projects...), orgProjects...)
+	}
+
+	for _, project := range projects {
+		for _, service := range services {
+			if common.IsDone(ctx) {
+				return nil
+			}
+			service := service
+			emit := func(resource, location string, data []byte) bool {
+				if len(bytes.TrimSpace(data)) == 0 {
+					return true
+				}
+				select {
+				case chunksChan <- s.chunk(service, project, location, resource, data):
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			}
+			if err := sweeps[service](s, ctx, project, emit); err != nil {
+				if common.IsDone(ctx) {
+					return nil
+				}
+				log.WithError(err).WithField("project", project).Warnf("could not sweep %s", service)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Source) chunk(service, project, location, resource string, data []byte) *sources.Chunk {
+	return &sources.Chunk{
+		SourceName: s.SourceName,
+		SourceType: sourcespb.SourceType_SOURCE_TYPE_GCP,
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_Gcp{
+				Gcp: &source_metadatapb.GCP{
+					Resource: resource,
+					Service:  service,
+					Project:  project,
+					Location: location,
+				},
+			},
+		},
+		Data:   data,
+		Verify: s.Verify,
+	}
+}
+
+func (s *Source) authenticate(ctx context.Context) (*http.Client, error) {
+	var creds *google.Credentials
+	var err error
+	if s.CredentialsFile != "" {
+		var data []byte
+		data, err = os.ReadFile(s.CredentialsFile)
+		if err != nil {
+			return nil, err
+		}
+		creds, err = google.CredentialsFromJSON(ctx, data, scope)
+	} else {
+		creds, err = google.FindDefaultCredentials(ctx, scope)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not load Google credentials: %w", err)
+	}
+	return oauth2.NewClient(ctx, creds.TokenSource), nil
+}
+
+// get decodes the JSON response to a GET of a path of an API.
+func (s *Source) get(ctx context.Context, api, p string, query url.Values, v interface{}) error {
+	u := strings.TrimSuffix(s.endpoints[api], "/") + p
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("GET %s: %s: %s", p, res.Status, bytes.TrimSpace(body))
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// pages calls fetch with each page token until a page has no next page token.
+func pages(fetch func(query url.Values) (next string, err error)) error {
+	query := url.Values{}
+	for {
+		next, err := fetch(query)
+		if err != nil || next == "" {
+			return err
+		}
+		query.Set("pageToken", next)
+	}
+}
+
+// organizationProjects lists the active projects under a parent, recursing into its folders.
+func (s *Source) organizationProjects(ctx context.Context, parent string) ([]string, error) {
+	var projects []string
+	err := pages(func(query url.Values) (string, error) {
+		query.Set("parent", parent)
+		var page struct {
+			Projects []struct {
+				ProjectID string `json:"projectId"`
+				State     string `json:"state"`
+			} `json:"projects"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		if err := s.get(ctx, "cloudresourcemanager", "/v3/projects", query, &page); err != nil {
+			return "", err
+		}
+		for _, p := range page.Projects {
+			if p.State == "ACTIVE" {
+				projects = append(projects, p.ProjectID)
+			}
+		}
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var folders []string
+	err = pages(func(query url.Values) (string, error) {
+		query.Set("parent", parent)
+		var page struct {
+			Folders []struct {
+				Name  string `json:"name"`
+				State string `json:"state"`
+			} `json:"folders"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		if err := s.get(ctx, "cloudresourcemanager", "/v3/folders", query, &page); err != nil {
+			return "", err
+		}
+		for _, f := range page.Folders {
+			if f.State == "ACTIVE" {
+				folders = append(folders, f.Name)
+			}
+		}
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, folder := range folders {
+		folderProjects, err := s.organizationProjects(ctx, folder)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, folderProjects...)
+	}
+	return projects, nil
+}
+
+// sweepFunctions scans the runtime and build environment variables of functions, one per line as NAME=value.
+func (s *Source) sweepFunctions(ctx context.Context, project string, emit emitFunc) error {
+	return pages(func(query url.Values) (string, error) {
+		var page struct {
+			Functions []struct {
+				Name                      string            `json:"name"`
+				EnvironmentVariables      map[string]string `json:"environmentVariables"`
+				BuildEnvironmentVariables map[string]string `json:"buildEnvironmentVariables"`
+			} `json:"functions"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		if err := s.get(ctx, "cloudfunctions", "/v1/projects/"+project+"/locations/-/functions", query, &page); err != nil {
+			return "", err
+		}
+		for _, fn := range page.Functions {
+			data := append(envLines(fn.EnvironmentVariables), envLines(fn.BuildEnvironmentVariables)...)
+			if !emit("//cloudfunctions.googleapis.com/"+fn.Name, nameLocation(fn.Name), data) {
+				return "", nil
+			}
+		}
+		return page.NextPageToken, nil
+	})
+}
+
+// sweepRun scans the environment variables set on the containers of Cloud Run services. Variables that reference
+// Secret Manager have no value and are skipped.
+func (s *Source) sweepRun(ctx context.Context, project string, emit emitFunc) error {
+	return pages(func(query url.Values) (string, error) {
+		var page struct {
+			Services []struct {
+				Name     string `json:"name"`
+				Template struct {
+					Containers []struct {
+						Env []struct {
+							Name  string `json:"name"`
+							Value string `json:"value"`
+						} `json:"env"`
+					} `json:"containers"`
+				} `json:"template"`
+			} `json:"services"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		if err := s.get(ctx, "run", "/v2/projects/"+project+"/locations/-/services", query, &page); err != nil {
+			return "", err
+		}
+		for _, svc := range page.Services {
+			var data bytes.Buffer
+			for _, c := range svc.Template.Containers {
+				for _, env := range c.Env {
+					if env.Value != "" {
+						fmt.Fprintf(&data, "%s=%s\n", env.Name, env.Value)
+					}
+				}
+			}
+			if !emit("//run.googleapis.com/"+svc.Name, nameLocation(svc.Name), data.Bytes()) {
+				return "", nil
+			}
+		}
+		return page.NextPageToken, nil
+	})
+}
+
+type computeMetadata struct {
+	Items []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	} `json:"items"`
+}
+
+// bytes returns the metadata items one after another as key=value, where values such as startup scripts may span
+// several lines.
+func (m computeMetadata) bytes() []byte {
+	var data bytes.Buffer
+	for _, item := range m.Items {
+		fmt.Fprintf(&data, "%s=%s\n", item.Key, item.Value)
+	}
+	return data.Bytes()
+}
+
+// sweepCompute scans the project's common instance metadata and the metadata of each instance, which hold startup
+// scripts and other values passed to instances.
+func (s *Source) sweepCompute(ctx context.Context, project string, emit emitFunc) error {
+	var p struct {
+		CommonInstanceMetadata computeMetadata `json:"commonInstanceMetadata"`
+	}
+	if err := s.get(ctx, "compute", "/compute/v1/projects/"+project, nil, &p); err != nil {
+		return err
+	}
+	if !emit("//compute.googleapis.com/projects/"+project, "global", p.CommonInstanceMetadata.bytes()) {
+		return nil
+	}
+
+	return pages(func(query url.Values) (string, error) {
+		var page struct {
+			Items map[string]struct {
+				Instances []struct {
+					Name     string          `json:"name"`
+					Metadata computeMetadata `json:"metadata"`
+				} `json:"instances"`
+			} `json:"items"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		if err := s.get(ctx, "compute", "/compute/v1/projects/"+project+"/aggregated/instances", query, &page); err != nil {
+			return "", err
+		}
+		scopes := make([]string, 0, len(page.Items))
+		for scope := range page.Items {
+			scopes = append(scopes, scope)
+		}
+		sort.Strings(scopes)
+		for _, scope := range scopes {
+			zone := path.Base(scope)
+			for _, instance := range page.Items[scope].Instances {
+				resource := fmt.Sprintf("//compute.googleapis.com/projects/%s/zones/%s/instances/%s", project, zone, instance.Name)
+				if !emit(resource, zone, instance.Metadata.bytes()) {
+					return "", nil
+				}
+			}
+		}
+		return page.NextPageToken, nil
+	})
+}
+
+// sweepDeploymentManager scans the configuration and imported templates of each deployment's latest manifest.
+func (s *Source) sweepDeploymentManager(ctx context.Context, project string, emit emitFunc) error {
+	base := "/deploymentmanager/v2/projects/" + project + "/global/deployments"
+	return pages(func(query url.Values) (string, error) {
+		var page struct {
+			Deployments []struct {
+				Name     string `json:"name"`
+				Manifest string `json:"manifest"`
+			} `json:"deployments"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		if err := s.get(ctx, "deploymentmanager", base, query, &page); err != nil {
+			return "", err
+		}
+		for _, d := range page.Deployments {
+			if d.Manifest == "" {
+				continue
+			}
+			var manifest struct {
+				Config struct {
+					Content string `json:"content"`
+				} `json:"config"`
+				Imports []struct {
+					Name    string `json:"name"`
+					Content string `json:"content"`
+				} `json:"imports"`
+			}
+			// The manifest is given as a URL, but only its name is used so that the endpoint can be overridden.
+			if err := s.get(ctx, "deploymentmanager", base+"/"+d.Name+"/manifests/"+path.Base(d.Manifest), nil, &manifest); err != nil {
+				return "", err
+			}
+			data := []byte(manifest.Config.Content + "\n")
+			for _, imp := range manifest.Imports {
+				data = append(data, imp.Content+"\n"...)
+			}
+			resource := "//deploymentmanager.googleapis.com/projects/" + project + "/global/deployments/" + d.Name
+			if !emit(resource, "global", data) {
+				return "", nil
+			}
+		}
+		return page.NextPageToken, nil
+	})
+}
+
+// envLines returns environment variables one per line as NAME=value, sorted by name.
+func envLines(vars map[string]string) []byte {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var data bytes.Buffer
+	for _, name := range names {
+		fmt.Fprintf(&data, "%s=%s\n", name, vars[name])
+	}
+	return data.Bytes()
+}
+
+// nameLocation returns the location of a resource name such as projects/p/locations/us-central1/functions/f.
+func nameLocation(name string) string {
+	parts := strings.Split(name, "/")
+	if len(parts) >= 4 && parts[2] == "locations" {
+		return parts[3]
+	}
+	return ""
+}
@@ -0,0 +1,86 @@
+package gcpproject
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+func TestSource_Chunks(t *testing.T) {
+	responses := map[string]string{
+		"/v3/projects?parent=organizations%2F42":                                        `{"projects":[{"projectId":"web","state":"ACTIVE"},{"projectId":"old","state":"DELETE_REQUESTED"}]}`,
+		"/v3/folders?parent=organizations%2F42":                                         `{"folders":[{"name":"folders/7","state":"ACTIVE"}]}`,
+		"/v3/projects?parent=folders%2F7":                                               `{"projects":[{"projectId":"batch","state":"ACTIVE"}]}`,
+		"/v3/folders?parent=folders%2F7":                                                `{}`,
+		"/v1/projects/web/locations/-/functions":                                        `{"functions":[{"name":"projects/web/locations/us-central1/functions/api","environmentVariables":{"DB_PASSWORD":"functions-secret"}}],"nextPageToken":"2"}`,
+		"/v1/projects/web/locations/-/functions?pageToken=2":                            `{"functions":[{"name":"projects/web/locations/us-central1/functions/noenv"}]}`,
+		"/v2/projects/web/locations/-/services":                                         `{"services":[{"name":"projects/web/locations/europe-west1/services/frontend","template":{"containers":[{"env":[{"name":"API_KEY","value":"run-secret"},{"name":"DB","valueSource":{}}]}]}}]}`,
+		"/compute/v1/projects/web":                                                      `{"commonInstanceMetadata":{"items":[{"key":"startup-script","value":"#!/bin/sh\nexport TOKEN=project-secret\n"}]}}`,
+		"/compute/v1/projects/web/aggregated/instances":                                 `{"items":{"zones/us-east1-b":{"instances":[{"name":"vm-1","metadata":{"items":[{"key":"startup-script","value":"curl -u admin:instance-secret"}]}}]},"zones/us-west1-a":{"warning":{"code":"NO_RESULTS_ON_PAGE"}}}}`,
+		"/deploymentmanager/v2/projects/web/global/deployments":                         `{"deployments":[{"name":"db","manifest":"https://www.googleapis.com/deploymentmanager/v2/projects/web/global/deployments/db/manifests/manifest-1"}]}`,
+		"/deploymentmanager/v2/projects/web/global/deployments/db/manifests/manifest-1": `{"config":{"content":"imports:\n- path: db.jinja\n"},"imports":[{"name":"db.jinja","content":"rootPassword: dm-secret\n"}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.RequestURI()]
+		if !ok {
+			// The batch project has none of the APIs enabled.
+			http.Error(w, `{"error":{"code":403,"status":"PERMISSION_DENIED"}}`, http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	endpoints := map[string]string{}
+	for api := range defaultEndpoints {
+		endpoints[api] = server.URL
+	}
+	s := &Source{
+		Organization: "42",
+		client:       server.Client(),
+		endpoints:    endpoints,
+	}
+	chunksChan := make(chan *sources.Chunk, 10)
+	if err := s.Chunks(context.Background(), chunksChan); err != nil {
+		t.Fatal(err)
+	}
+	close(chunksChan)
+
+	want := map[string]struct{ service, location, secret string }{
+		"//cloudfunctions.googleapis.com/projects/web/locations/us-central1/functions/api": {"functions", "us-central1", "DB_PASSWORD=functions-secret"},
+		"//run.googleapis.com/projects/web/locations/europe-west1/services/frontend":       {"run", "europe-west1", "API_KEY=run-secret"},
+		"//compute.googleapis.com/projects/web":                                            {"compute", "global", "project-secret"},
+		"//compute.googleapis.com/projects/web/zones/us-east1-b/instances/vm-1":            {"compute", "us-east1-b", "instance-secret"},
+		"//deploymentmanager.googleapis.com/projects/web/global/deployments/db":            {"deploymentmanager", "global", "dm-secret"},
+	}
+	got := 0
+	for chunk := range chunksChan {
+		got++
+		metadata := chunk.SourceMetadata.GetGcp()
+		expected, ok := want[metadata.GetResource()]
+		if !ok {
+			t.Errorf("unexpected chunk for %s", metadata.GetResource())
+			continue
+		}
+		if metadata.GetService() != expected.service || metadata.GetLocation() != expected.location || metadata.GetProject() != "web" {
+			t.Errorf("%s: unexpected metadata: %v", metadata.GetResource(), metadata)
+		}
+		if !strings.Contains(string(chunk.Data), expected.secret) {
+			t.Errorf("%s: expected %q in chunk, got: %q", metadata.GetResource(), expected.secret, chunk.Data)
+		}
+	}
+	if got != len(want) {
+		t.Errorf("unexpected number of chunks. Got: %d, Expected: %d", got, len(want))
+	}
+}
+
+func TestSource_ChunksUnknownService(t *testing.T) {
+	s := &Source{Projects: []string{"web"}, Services: []string{"gke"}}
+	if err := s.Chunks(context.Background(), make(chan *sources.Chunk)); err == nil {
+		t.Error("expected an error for an unknown service")
+	}
+}
@@ -0,0 +1,110 @@
+package git
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+// AgeEstimator estimates how long secrets found in history have existed, from the history of the file each was found
+// in. A secret that has been exposed for years needs a broader response than one committed yesterday.
+type AgeEstimator struct {
+	repo *git.Repository
+	now  func() time.Time
+
+	mu sync.Mutex
+	// versions caches the versions of each file on any branch, newest first.
+	versions map[string][]fileVersion
+}
+
+// fileVersion is a file as of a commit that changed it. content is nil if the commit deleted the file.
+type fileVersion struct {
+	when    time.Time
+	content []byte
+}
+
+// NewAgeEstimator returns an AgeEstimator for the repository.
+func NewAgeEstimator(repo *git.Repository) *AgeEstimator {
+	return &AgeEstimator{repo: repo, now: time.Now, versions: map[string][]fileVersion{}}
+}
+
+// Age returns the age of the secret in the file at path. It was introduced by the oldest commit whose version of the
+// file contains it, and removed by the commit after the newest such version, if there is one. Commits are ordered by
+// committer date across all branches, so the estimate can be off for secrets removed on one branch but not another.
+// Age returns nil if no committed version of the file contains the secret, such as for unstaged changes.
+func (a *AgeEstimator) Age(path string, secret []byte) (*detectors.Age, error) {
+	versions, err := a.fileVersions(path)
+	if err != nil {
+		return nil, err
+	}
+	newest, oldest := -1, -1
+	for i, version := range versions {
+		if version.content != nil && bytes.Contains(version.content, secret) {
+			if newest < 0 {
+				newest = i
+			}
+			oldest = i
+		}
+	}
+	if newest < 0 {
+		return nil, nil
+	}
+
+	age := &detectors.Age{Introduced: versions[oldest].when}
+	end := a.now()
+	if newest > 0 {
+		removed := versions[newest-1].when
+		age.Removed = &removed
+		end = removed
+	}
+	age.Days = int(end.Sub(age.Introduced).Hours() / 24)
+	return age, nil
+}
+
+func (a *AgeEstimator) fileVersions(path string) ([]fileVersion, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if versions, ok := a.versions[path]; ok {
+		return versions, nil
+	}
+
+	commits, err := a.repo.Log(&git.LogOptions{All: true, FileName: &path, Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "could not read the history of "+path, 0)
+	}
+	defer commits.Close()
+	var versions []fileVersion
+	err = commits.ForEach(func(commit *object.Commit) error {
+		version := fileVersion{when: commit.Committer.When}
+		file, err := commit.File(path)
+		switch {
+		case err == object.ErrFileNotFound:
+		case err != nil:
+			return errors.WrapPrefix(err, "could not read "+path+" at "+commit.Hash.String(), 0)
+		default:
+			content, err := file.Contents()
+			if err != nil {
+				return errors.WrapPrefix(err, "could not read "+path+" at "+commit.Hash.String(), 0)
+			}
+			version.content = []byte(content)
+		}
+		versions = append(versions, version)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// The log of all branches walks each branch in turn, so it's only ordered within a branch.
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].when.After(versions[j].when)
+	})
+	a.versions[path] = versions
+	return versions, nil
+}
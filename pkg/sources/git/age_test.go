@@ -0,0 +1,87 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestAgeEstimator(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := func(n int) time.Time {
+		return time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, n)
+	}
+	commit := func(when time.Time, file, content string) {
+		path := filepath.Join(dir, file)
+		if content == "" {
+			if _, err := worktree.Remove(file); err != nil {
+				t.Fatal(err)
+			}
+		} else {
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := worktree.Add(file); err != nil {
+				t.Fatal(err)
+			}
+		}
+		signature := &object.Signature{Name: "test", Email: "test@example.com", When: when}
+		_, err := worktree.Commit("update "+file, &git.CommitOptions{Author: signature, Committer: signature})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	commit(day(0), "keys.env", "OLD_KEY=rotated\n")
+	commit(day(10), "keys.env", "OLD_KEY=rotated\nLIVE_KEY=still-here\n")
+	commit(day(30), "keys.env", "LIVE_KEY=still-here\n")
+	commit(day(40), "other.env", "GONE_KEY=file-deleted\n")
+	commit(day(45), "other.env", "")
+
+	estimator := NewAgeEstimator(repo)
+	estimator.now = func() time.Time { return day(100) }
+	tests := []struct {
+		file       string
+		secret     string
+		introduced time.Time
+		removed    time.Time
+		days       int
+	}{
+		{file: "keys.env", secret: "rotated", introduced: day(0), removed: day(30), days: 30},
+		{file: "keys.env", secret: "still-here", introduced: day(10), days: 90},
+		{file: "other.env", secret: "file-deleted", introduced: day(40), removed: day(45), days: 5},
+	}
+	for _, test := range tests {
+		age, err := estimator.Age(test.file, []byte(test.secret))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.secret, err)
+			continue
+		}
+		if age == nil {
+			t.Errorf("%s: expected an age", test.secret)
+			continue
+		}
+		if !age.Introduced.Equal(test.introduced) || age.Days != test.days {
+			t.Errorf("%s: got introduced %s and %d days, expected %s and %d days", test.secret, age.Introduced, age.Days, test.introduced, test.days)
+		}
+		if test.removed.IsZero() != (age.Removed == nil) || (age.Removed != nil && !age.Removed.Equal(test.removed)) {
+			t.Errorf("%s: got removed %v, expected %s", test.secret, age.Removed, test.removed)
+		}
+	}
+
+	if age, err := estimator.Age("keys.env", []byte("never-committed")); err != nil || age != nil {
+		t.Errorf("expected no age for a secret that was never committed, got %v, %v", age, err)
+	}
+}
@@ -25,6 +25,9 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 
+	"github.com/trufflesecurity/trufflehog/v3/pkg/codeowners"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sanitizer"
@@ -47,13 +50,13 @@ type Git struct {
 	sourceName         string
 	sourceID           int64
 	jobID              int64
-	sourceMetadataFunc func(file, email, commit, timestamp, repository string, line int64) *source_metadatapb.MetaData
+	sourceMetadataFunc func(file, email, commit, timestamp, repository string, line int64, authorName, committerName, committerEmail, commitTimestamp string) *source_metadatapb.MetaData
 	verify             bool
 	concurrency        *semaphore.Weighted
 }
 
 func NewGit(sourceType sourcespb.SourceType, jobID, sourceID int64, sourceName string, verify bool, concurrency int,
-	sourceMetadataFunc func(file, email, commit, timestamp, repository string, line int64) *source_metadatapb.MetaData,
+	sourceMetadataFunc func(file, email, commit, timestamp, repository string, line int64, authorName, committerName, committerEmail, commitTimestamp string) *source_metadatapb.MetaData,
 ) *Git {
 	return &Git{
 		sourceType:         sourceType,
@@ -105,16 +108,20 @@ func (s *Source) Init(aCtx context.Context, name string, jobId, sourceId int64,
 	}
 
 	s.git = NewGit(s.Type(), s.jobId, s.sourceId, s.name, s.verify, concurrency,
-		func(file, email, commit, repository, timestamp string, line int64) *source_metadatapb.MetaData {
+		func(file, email, commit, timestamp, repository string, line int64, authorName, committerName, committerEmail, commitTimestamp string) *source_metadatapb.MetaData {
 			return &source_metadatapb.MetaData{
 				Data: &source_metadatapb.MetaData_Git{
 					Git: &source_metadatapb.Git{
-						Commit:     sanitizer.UTF8(commit),
-						File:       sanitizer.UTF8(file),
-						Email:      sanitizer.UTF8(email),
-						Repository: sanitizer.UTF8(repository),
-						Timestamp:  sanitizer.UTF8(timestamp),
-						Line:       line,
+						Commit:          sanitizer.UTF8(commit),
+						File:            sanitizer.UTF8(file),
+						Email:           sanitizer.UTF8(email),
+						Repository:      sanitizer.UTF8(repository),
+						Timestamp:       sanitizer.UTF8(timestamp),
+						Line:            line,
+						AuthorName:      sanitizer.UTF8(authorName),
+						CommitterName:   sanitizer.UTF8(committerName),
+						CommitterEmail:  sanitizer.UTF8(committerEmail),
+						CommitTimestamp: sanitizer.UTF8(commitTimestamp),
 					},
 				},
 			}
@@ -134,7 +141,7 @@ func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk) err
 			if len(repoURI) == 0 {
 				continue
 			}
-			path, repo, err := CloneRepoUsingToken(token, repoURI, user)
+			path, repo, err := CloneRepoUsingToken(ctx, token, repoURI, user)
 			defer os.RemoveAll(path)
 			if err != nil {
 				return err
@@ -150,7 +157,7 @@ func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk) err
 			if len(repoURI) == 0 {
 				continue
 			}
-			path, repo, err := CloneRepoUsingUnauthenticated(repoURI)
+			path, repo, err := CloneRepoUsingUnauthenticated(ctx, repoURI)
 			defer os.RemoveAll(path)
 			if err != nil {
 				return err
@@ -201,7 +208,8 @@ func CleanOnError(err *error, path string) {
 	}
 }
 
-func CloneRepo(userInfo *url.Userinfo, gitUrl string) (clonePath string, repo *git.Repository, err error) {
+// CloneRepo clones a repo to a temporary directory. The clone is stopped if the context is done.
+func CloneRepo(ctx context.Context, userInfo *url.Userinfo, gitUrl string) (clonePath string, repo *git.Repository, err error) {
 	if err = GitCmdCheck(); err != nil {
 		return
 	}
@@ -218,7 +226,7 @@ func CloneRepo(userInfo *url.Userinfo, gitUrl string) (clonePath string, repo *g
 	}
 
 	cloneURL.User = userInfo
-	cloneCmd := exec.Command("git", "clone", cloneURL.String(), clonePath)
+	cloneCmd := exec.CommandContext(ctx, "git", "clone", cloneURL.String(), clonePath)
 
 	output, err := cloneCmd.CombinedOutput()
 	if err != nil {
@@ -228,6 +236,9 @@ func CloneRepo(userInfo *url.Userinfo, gitUrl string) (clonePath string, repo *g
 	if cloneCmd.ProcessState == nil {
 		return "", nil, errors.New("clone command exited with no output")
 	}
+	if ctx.Err() != nil {
+		return "", nil, fmt.Errorf("clone stopped: %w", ctx.Err())
+	}
 	if cloneCmd.ProcessState != nil && cloneCmd.ProcessState.ExitCode() != 0 {
 		safeUrl, err := stripPassword(gitUrl)
 		if err != nil {
@@ -245,14 +256,14 @@ func CloneRepo(userInfo *url.Userinfo, gitUrl string) (clonePath string, repo *g
 }
 
 // CloneRepoUsingToken clones a repo using a provided token.
-func CloneRepoUsingToken(token, gitUrl, user string) (string, *git.Repository, error) {
+func CloneRepoUsingToken(ctx context.Context, token, gitUrl, user string) (string, *git.Repository, error) {
 	userInfo := url.UserPassword(user, token)
-	return CloneRepo(userInfo, gitUrl)
+	return CloneRepo(ctx, userInfo, gitUrl)
 }
 
 // CloneRepoUsingUnauthenticated clones a repo with no authentication required.
-func CloneRepoUsingUnauthenticated(url string) (string, *git.Repository, error) {
-	return CloneRepo(nil, url)
+func CloneRepoUsingUnauthenticated(ctx context.Context, url string) (string, *git.Repository, error) {
+	return CloneRepo(ctx, nil, url)
 }
 
 func GitCmdCheck() error {
@@ -262,7 +273,7 @@ func GitCmdCheck() error {
 	return nil
 }
 
-func (s *Git) ScanCommits(repo *git.Repository, path string, scanOptions *ScanOptions, chunksChan chan *sources.Chunk) error {
+func (s *Git) ScanCommits(ctx context.Context, repo *git.Repository, path string, scanOptions *ScanOptions, chunksChan chan *sources.Chunk) error {
 	if err := GitCmdCheck(); err != nil {
 		return err
 	}
@@ -291,10 +302,20 @@ func (s *Git) ScanCommits(repo *git.Repository, path string, scanOptions *ScanOp
 
 	// get the URL metadata for reporting (may be empty)
 	urlMetadata := getSafeRemoteURL(repo, "origin")
+	owners := readCodeOwners(repo)
 
 	var depth int64
 	var reachedBase = false
+	var committer *committerInfo
 	for file := range fileChan {
+		if common.IsDone(ctx) {
+			// Let git log run to completion in the background rather than blocking on a channel nobody reads.
+			go func() {
+				for range fileChan {
+				}
+			}()
+			return ctx.Err()
+		}
 		if file == nil || file.PatchHeader == nil {
 			log.Debugf("file missing patch header, skipping")
 			continue
@@ -322,15 +343,21 @@ func (s *Git) ScanCommits(repo *git.Repository, path string, scanOptions *ScanOp
 		if fileName == "" {
 			continue
 		}
-		var email, hash, when string
+		var email, authorName, hash, when string
 		if file.PatchHeader != nil {
 			if file.PatchHeader.Author != nil {
 				email = file.PatchHeader.Author.Email
+				authorName = file.PatchHeader.Author.Name
 			}
 			hash = file.PatchHeader.SHA
 			when = file.PatchHeader.AuthorDate.String()
 		}
 
+		// git log's default format doesn't include the committer, so look it up once per commit.
+		if committer == nil || committer.hash != hash {
+			committer = commitCommitter(repo, hash)
+		}
+
 		for _, frag := range file.TextFragments {
 			newLines := bytes.Buffer{}
 			newLineNumber := frag.NewPosition
@@ -340,12 +367,15 @@ func (s *Git) ScanCommits(repo *git.Repository, path string, scanOptions *ScanOp
 				}
 			}
 			log.WithField("fragment", newLines.String()).Trace("detecting fragment")
-			metadata := s.sourceMetadataFunc(fileName, email, hash, when, urlMetadata, newLineNumber)
+			metadata := s.sourceMetadataFunc(fileName, email, hash, when, urlMetadata, newLineNumber,
+				authorName, committer.name, committer.email, committer.when)
 			chunksChan <- &sources.Chunk{
 				SourceName:     s.sourceName,
 				SourceID:       s.sourceID,
 				SourceType:     s.sourceType,
 				SourceMetadata: metadata,
+				ExtraData:      ownersExtraData(owners, fileName),
+				UnitID:         logging.UnitID(ctx),
 				Data:           newLines.Bytes(),
 				Verify:         s.verify,
 			}
@@ -354,9 +384,67 @@ func (s *Git) ScanCommits(repo *git.Repository, path string, scanOptions *ScanOp
 	return nil
 }
 
-func (s *Git) ScanUnstaged(repo *git.Repository, scanOptions *ScanOptions, chunksChan chan *sources.Chunk) error {
+// readCodeOwners returns the CODEOWNERS file at the repository's HEAD, or nil if it has none.
+func readCodeOwners(repo *git.Repository) *codeowners.File {
+	head, err := repo.Head()
+	if err != nil {
+		return nil
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		log.WithError(err).Debug("could not read HEAD to find CODEOWNERS")
+		return nil
+	}
+	for _, path := range codeowners.Paths {
+		file, err := commit.File(path)
+		if err != nil {
+			continue
+		}
+		content, err := file.Contents()
+		if err != nil {
+			log.WithError(err).WithField("file", path).Debug("could not read CODEOWNERS")
+			return nil
+		}
+		return codeowners.Parse([]byte(content))
+	}
+	return nil
+}
+
+// ownersExtraData tags the chunks of a file with its code owners, so that results can be routed to them.
+func ownersExtraData(owners *codeowners.File, path string) map[string]string {
+	names := owners.Owners(path)
+	if len(names) == 0 {
+		return nil
+	}
+	return map[string]string{"codeowners": strings.Join(names, " ")}
+}
+
+// committerInfo is the committer of a commit.
+type committerInfo struct {
+	hash, name, email, when string
+}
+
+// commitCommitter returns the committer of the commit with the hash, or an empty committer if it can't be read.
+func commitCommitter(repo *git.Repository, hash string) *committerInfo {
+	info := &committerInfo{hash: hash}
+	if repo == nil || hash == "" {
+		return info
+	}
+	commit, err := repo.CommitObject(plumbing.NewHash(hash))
+	if err != nil {
+		log.WithError(err).WithField("commit", hash).Debug("could not read committer")
+		return info
+	}
+	info.name = commit.Committer.Name
+	info.email = commit.Committer.Email
+	info.when = commit.Committer.When.String()
+	return info
+}
+
+func (s *Git) ScanUnstaged(ctx context.Context, repo *git.Repository, scanOptions *ScanOptions, chunksChan chan *sources.Chunk) error {
 	// get the URL metadata for reporting (may be empty)
 	urlMetadata := getSafeRemoteURL(repo, "origin")
+	owners := readCodeOwners(repo)
 
 	// Also scan any unstaged changes in the working tree of the repo
 	_, err := repo.Head()
@@ -377,7 +465,7 @@ func (s *Git) ScanUnstaged(repo *git.Repository, scanOptions *ScanOptions, chunk
 				continue
 			}
 			metadata := s.sourceMetadataFunc(
-				fh, "unstaged", "unstaged", time.Now().String(), urlMetadata, 0,
+				fh, "unstaged", "unstaged", time.Now().String(), urlMetadata, 0, "", "", "", "",
 			)
 
 			fileBuf := bytes.NewBuffer(nil)
@@ -396,6 +484,8 @@ func (s *Git) ScanUnstaged(repo *git.Repository, scanOptions *ScanOptions, chunk
 				SourceID:       s.sourceID,
 				Data:           fileBuf.Bytes(),
 				SourceMetadata: metadata,
+				ExtraData:      ownersExtraData(owners, fh),
+				UnitID:         logging.UnitID(ctx),
 				Verify:         s.verify,
 			}
 		}
@@ -403,22 +493,29 @@ func (s *Git) ScanUnstaged(repo *git.Repository, scanOptions *ScanOptions, chunk
 	return nil
 }
 
-func (s *Git) ScanRepo(_ context.Context, repo *git.Repository, repoPath string, scanOptions *ScanOptions, chunksChan chan *sources.Chunk) error {
+func (s *Git) ScanRepo(ctx context.Context, repo *git.Repository, repoPath string, scanOptions *ScanOptions, chunksChan chan *sources.Chunk) error {
+	if logging.UnitID(ctx) == "" {
+		name := getSafeRemoteURL(repo, "origin")
+		if name == "" {
+			name = repoPath
+		}
+		ctx = logging.WithUnit(ctx, "repo", name)
+	}
 	start := time.Now().UnixNano()
-	if err := s.ScanCommits(repo, repoPath, scanOptions, chunksChan); err != nil {
+	if err := s.ScanCommits(ctx, repo, repoPath, scanOptions, chunksChan); err != nil {
 		return err
 	}
-	if err := s.ScanUnstaged(repo, scanOptions, chunksChan); err != nil {
+	if err := s.ScanUnstaged(ctx, repo, scanOptions, chunksChan); err != nil {
 		// https://github.com/src-d/go-git/issues/879
 		if strings.Contains(err.Error(), "object not found") {
-			log.WithError(err).Error("known issue: probably caused by a dangling reference in the repo")
+			logging.FromContext(ctx).WithError(err).Error("known issue: probably caused by a dangling reference in the repo")
 		} else {
 			return errors.New(err)
 		}
 		return err
 	}
 	scanTime := time.Now().UnixNano() - start
-	log.Debugf("Scanning complete. Scan time: %f", time.Duration(scanTime).Seconds())
+	logging.FromContext(ctx).Debugf("Scanning complete. Scan time: %f", time.Duration(scanTime).Seconds())
 	return nil
 }
 
@@ -483,7 +580,7 @@ func PrepareRepo(uriString string) (string, bool, error) {
 	remote := false
 	switch uri.Scheme {
 	case "file":
-		path = fmt.Sprintf("%s%s", uri.Host, uri.Path)
+		path = common.FileURIPath(uri.Host, uri.Path)
 	case "http", "https":
 		remotePath := fmt.Sprintf("%s://%s%s", uri.Scheme, uri.Host, uri.Path)
 		remote = true
@@ -494,13 +591,13 @@ func PrepareRepo(uriString string) (string, bool, error) {
 			if !ok {
 				return "", remote, fmt.Errorf("password must be included in Git repo URL when username is provided")
 			}
-			path, _, err = CloneRepoUsingToken(password, remotePath, uri.User.Username())
+			path, _, err = CloneRepoUsingToken(context.Background(), password, remotePath, uri.User.Username())
 			if err != nil {
 				return path, remote, fmt.Errorf("failed to clone authenticated Git repo (%s): %s", remotePath, err)
 			}
 		default:
 			log.Debugf("Cloning remote Git repo without authentication")
-			path, _, err = CloneRepoUsingUnauthenticated(remotePath)
+			path, _, err = CloneRepoUsingUnauthenticated(context.Background(), remotePath)
 			if err != nil {
 				return path, remote, fmt.Errorf("failed to clone unauthenticated Git repo (%s): %s", remotePath, err)
 			}
@@ -0,0 +1,98 @@
+package git
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/go-errors/errors"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// HeadChecker reports whether secrets found in history are still present at the tip of any local or remote branch.
+// A secret that is only in history needs rotating, but one that is still in a branch will keep being deployed and
+// copied until it is also removed from the code.
+type HeadChecker struct {
+	heads []*object.Tree
+
+	mu sync.Mutex
+	// files caches file contents at each head by path. A nil entry means the file doesn't exist at that head.
+	files map[string][][]byte
+}
+
+// NewHeadChecker returns a HeadChecker for the branches of the repository.
+func NewHeadChecker(repo *git.Repository) (*HeadChecker, error) {
+	refs, err := repo.References()
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "could not list references", 0)
+	}
+	defer refs.Close()
+
+	checker := &HeadChecker{files: map[string][][]byte{}}
+	seen := map[plumbing.Hash]struct{}{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference || !(ref.Name().IsBranch() || ref.Name().IsRemote()) {
+			return nil
+		}
+		if _, ok := seen[ref.Hash()]; ok {
+			return nil
+		}
+		seen[ref.Hash()] = struct{}{}
+		commit, err := repo.CommitObject(ref.Hash())
+		if err != nil {
+			return errors.WrapPrefix(err, "could not read commit of "+ref.Name().String(), 0)
+		}
+		tree, err := commit.Tree()
+		if err != nil {
+			return errors.WrapPrefix(err, "could not read tree of "+ref.Name().String(), 0)
+		}
+		checker.heads = append(checker.heads, tree)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return checker, nil
+}
+
+// PresentAtHead returns true if the file at path contains the secret at the tip of any branch. Only the file the
+// secret was found in is checked, so a secret that was moved to another file is reported as not present.
+func (c *HeadChecker) PresentAtHead(path string, secret []byte) (bool, error) {
+	contents, err := c.contents(path)
+	if err != nil {
+		return false, err
+	}
+	for _, content := range contents {
+		if content != nil && bytes.Contains(content, secret) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c *HeadChecker) contents(path string) ([][]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if contents, ok := c.files[path]; ok {
+		return contents, nil
+	}
+
+	contents := make([][]byte, len(c.heads))
+	for i, tree := range c.heads {
+		file, err := tree.File(path)
+		if err == object.ErrFileNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, errors.WrapPrefix(err, "could not read "+path, 0)
+		}
+		content, err := file.Contents()
+		if err != nil {
+			return nil, errors.WrapPrefix(err, "could not read "+path, 0)
+		}
+		contents[i] = []byte(content)
+	}
+	c.files[path] = contents
+	return contents, nil
+}
@@ -0,0 +1,83 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestHeadChecker(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit := func(file, content string) {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := worktree.Add(file); err != nil {
+			t.Fatal(err)
+		}
+		_, err := worktree.Commit("update "+file, &git.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	commit("keys.env", "GONE_KEY=deleted-everywhere\n")
+	commit("keys.env", "OLD_KEY=removed-from-main\nLIVE_KEY=still-here\n")
+	// The removed key is still on a branch created before it was removed.
+	commit("branch.env", "BRANCH_KEY=only-on-feature\n")
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference("refs/heads/feature", head.Hash())); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, "branch.env")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := worktree.Remove("branch.env"); err != nil {
+		t.Fatal(err)
+	}
+	commit("keys.env", "LIVE_KEY=still-here\n")
+
+	checker, err := NewHeadChecker(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		file   string
+		secret string
+		want   bool
+	}{
+		{file: "keys.env", secret: "still-here", want: true},
+		{file: "keys.env", secret: "removed-from-main", want: true},
+		{file: "branch.env", secret: "only-on-feature", want: true},
+		{file: "keys.env", secret: "deleted-everywhere", want: false},
+		{file: "deleted.env", secret: "still-here", want: false},
+	}
+	for _, test := range tests {
+		got, err := checker.PresentAtHead(test.file, []byte(test.secret))
+		if err != nil {
+			t.Errorf("%s in %s: unexpected error: %v", test.secret, test.file, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%s in %s: got %t, expected %t", test.secret, test.file, got, test.want)
+		}
+	}
+}
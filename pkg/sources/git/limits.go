@@ -0,0 +1,125 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// RepoLimits bound the repositories scanned by sources that enumerate them, such as GitHub organizations, so that
+// one huge repository can't take up a whole scheduled scan. Repositories over a limit are skipped and recorded in
+// the source's progress. The zero value has no limits.
+type RepoLimits struct {
+	// MaxSize is the size in bytes of the largest repository to clone, as reported by the provider's API.
+	MaxSize int64
+	// Timeout is how long cloning and scanning a repository can take.
+	Timeout time.Duration
+	// MaxObjects is the most git objects a cloned repository can have to be scanned.
+	MaxObjects int64
+}
+
+// LimitError is why a repository is over one of the limits.
+type LimitError struct {
+	// Reason is one of the sources.SkipReasonRepo reasons, such as sources.SkipReasonRepoSize.
+	Reason string
+	Detail string
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Reason, e.Detail)
+}
+
+// CheckSize returns a LimitError if a repository of size bytes is over MaxSize. Sources that don't know the size of
+// a repository before cloning it pass 0.
+func (l RepoLimits) CheckSize(size int64) *LimitError {
+	if l.MaxSize <= 0 || size <= l.MaxSize {
+		return nil
+	}
+	return &LimitError{
+		Reason: sources.SkipReasonRepoSize,
+		Detail: fmt.Sprintf("%d MB is over %d MB", size>>20, l.MaxSize>>20),
+	}
+}
+
+// WithTimeout returns the context to clone and scan a repository with, which is done once Timeout has passed.
+func (l RepoLimits) WithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if l.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, l.Timeout)
+}
+
+// CheckTimeout returns a LimitError if the repository's context, from WithTimeout, is done because Timeout passed
+// rather than because its parent is done.
+func (l RepoLimits) CheckTimeout(parent, ctx context.Context) *LimitError {
+	if l.Timeout <= 0 || parent.Err() != nil || ctx.Err() != context.DeadlineExceeded {
+		return nil
+	}
+	return &LimitError{
+		Reason: sources.SkipReasonRepoTimeout,
+		Detail: fmt.Sprintf("not done after %s", l.Timeout),
+	}
+}
+
+// CheckObjects returns a LimitError if the repository cloned to path has more than MaxObjects objects. Repositories
+// whose objects can't be counted pass.
+func (l RepoLimits) CheckObjects(ctx context.Context, path string) *LimitError {
+	if l.MaxObjects <= 0 {
+		return nil
+	}
+	count, err := CountObjects(ctx, path)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Warn("could not count the repo's objects, scanning it anyway")
+		return nil
+	}
+	if count <= l.MaxObjects {
+		return nil
+	}
+	return &LimitError{
+		Reason: sources.SkipReasonRepoObjects,
+		Detail: fmt.Sprintf("%d objects is over %d", count, l.MaxObjects),
+	}
+}
+
+// RecordSkipped logs a repository that's over a limit and records it in the source's progress.
+func RecordSkipped(ctx context.Context, progress *sources.Progress, repo string, err *LimitError) {
+	logging.FromContext(ctx).WithError(err).Warn("skipping repo over the limits")
+	progress.RecordSkippedRepo(repo, err.Reason)
+}
+
+// CountObjects returns the number of objects, loose and packed, in the repository at path.
+func CountObjects(ctx context.Context, path string) (int64, error) {
+	out, err := exec.CommandContext(ctx, "git", "-C", path, "count-objects", "-v").Output()
+	if err != nil {
+		return 0, fmt.Errorf("error running 'git count-objects': %w", err)
+	}
+	return parseCountObjects(string(out))
+}
+
+// parseCountObjects sums the loose and packed objects in the output of git count-objects -v.
+func parseCountObjects(out string) (int64, error) {
+	var total int64
+	var found bool
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 || (parts[0] != "count" && parts[0] != "in-pack") {
+			continue
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("unexpected 'git count-objects' output %q", line)
+		}
+		total += n
+		found = true
+	}
+	if !found {
+		return 0, fmt.Errorf("no object counts in 'git count-objects' output")
+	}
+	return total, nil
+}
@@ -0,0 +1,77 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+func TestRepoLimits(t *testing.T) {
+	limits := RepoLimits{MaxSize: 10 << 20, Timeout: time.Millisecond, MaxObjects: 2}
+
+	if err := limits.CheckSize(10 << 20); err != nil {
+		t.Errorf("expected a repo at the max size to pass, got %v", err)
+	}
+	if err := limits.CheckSize(80 << 30); err == nil || err.Reason != sources.SkipReasonRepoSize {
+		t.Errorf("expected a size limit error, got %v", err)
+	}
+	if err := (RepoLimits{}).CheckSize(80 << 30); err != nil {
+		t.Errorf("expected no size limit by default, got %v", err)
+	}
+
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel := limits.WithTimeout(parent)
+	defer cancel()
+	<-ctx.Done()
+	if err := limits.CheckTimeout(parent, ctx); err == nil || err.Reason != sources.SkipReasonRepoTimeout {
+		t.Errorf("expected a timeout limit error, got %v", err)
+	}
+	cancelParent()
+	if err := limits.CheckTimeout(parent, ctx); err != nil {
+		t.Errorf("expected no timeout limit error once the scan is cancelled, got %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "README"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	// A blob, a tree, and a commit.
+	if count, err := CountObjects(context.Background(), dir); err != nil || count != 3 {
+		t.Errorf("expected 3 objects, got %d, %v", count, err)
+	}
+	if err := limits.CheckObjects(context.Background(), dir); err == nil || err.Reason != sources.SkipReasonRepoObjects {
+		t.Errorf("expected an objects limit error, got %v", err)
+	}
+	if err := limits.CheckObjects(context.Background(), t.TempDir()); err != nil {
+		t.Errorf("expected a repo whose objects can't be counted to pass, got %v", err)
+	}
+}
+
+func TestParseCountObjects(t *testing.T) {
+	out := "count: 12\nsize: 48\nin-pack: 1500\npacks: 1\nsize-pack: 900\nprune-packable: 0\ngarbage: 0\nsize-garbage: 0\n"
+	if count, err := parseCountObjects(out); err != nil || count != 1512 {
+		t.Errorf("expected 1512 objects, got %d, %v", count, err)
+	}
+	if _, err := parseCountObjects("fatal: not a git repository"); err == nil {
+		t.Error("expected an error for output without counts")
+	}
+	if _, err := parseCountObjects("count: many"); err == nil {
+		t.Error("expected an error for an invalid count")
+	}
+}
@@ -0,0 +1,188 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/google/go-github/v42/github"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// AuditLog tails the audit logs of organizations and enterprises, scanning each event as it's recorded. Events such as
+// webhook configuration changes, secret scanning alerts, and repository settings changes carry the values that were
+// set, so tokens pasted into the platform itself are found as well as those pushed to repositories.
+type AuditLog struct {
+	Endpoint    string
+	Token       string
+	Orgs        []string
+	Enterprises []string
+	// Phrase is an audit log search phrase that limits the events scanned, such as "action:hook".
+	Phrase string
+	// Since is how far back to start. Later events are scanned as they're recorded.
+	Since time.Duration
+	// Interval is the time between polls.
+	Interval time.Duration
+
+	SourceName string
+	Verify     bool
+}
+
+// auditLogPoller follows one organization's or enterprise's audit log.
+type auditLogPoller struct {
+	path string
+	// since is the time of the latest event scanned. The audit log can only be searched by the second, so the
+	// document IDs of events in that second are kept to skip them in the next poll.
+	since time.Time
+	seen  map[string]struct{}
+}
+
+// auditEvent holds the audit log fields used in chunk metadata. The whole event is scanned.
+type auditEvent struct {
+	DocumentID string `json:"_document_id"`
+	Timestamp  int64  `json:"@timestamp"`
+	Action     string `json:"action"`
+	Actor      string `json:"actor"`
+	Repo       string `json:"repo"`
+}
+
+var linkNextPat = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// Chunks polls the audit logs until the context is done, sending a chunk for each new event.
+func (a *AuditLog) Chunks(ctx context.Context, chunksChan chan *sources.Chunk) error {
+	if len(a.Orgs) == 0 && len(a.Enterprises) == 0 {
+		return errors.New("no organizations or enterprises to follow")
+	}
+	apiClient, err := tokenClient(a.Endpoint, a.Token)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now().Add(-a.Since).UTC().Truncate(time.Second)
+	var pollers []*auditLogPoller
+	for _, org := range a.Orgs {
+		pollers = append(pollers, &auditLogPoller{path: "orgs/" + org + "/audit-log", since: start, seen: map[string]struct{}{}})
+	}
+	for _, enterprise := range a.Enterprises {
+		pollers = append(pollers, &auditLogPoller{path: "enterprises/" + enterprise + "/audit-log", since: start, seen: map[string]struct{}{}})
+	}
+
+	interval := a.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	for {
+		for _, p := range pollers {
+			if err := a.poll(ctx, apiClient, p, chunksChan); err != nil {
+				if common.IsDone(ctx) {
+					return nil
+				}
+				// The log is polled again on the next interval, from where it left off.
+				log.WithError(err).Errorf("could not read audit log %s", p.path)
+			}
+		}
+		if !common.Sleep(ctx, interval) {
+			return nil
+		}
+	}
+}
+
+// poll scans the events recorded since the poller's last event, oldest first.
+func (a *AuditLog) poll(ctx context.Context, apiClient *github.Client, p *auditLogPoller, chunksChan chan *sources.Chunk) error {
+	phrase := strings.TrimSpace(a.Phrase + " created:>=" + p.since.Format(time.RFC3339))
+	query := url.Values{"phrase": {phrase}, "include": {"all"}, "order": {"asc"}, "per_page": {"100"}}
+	next := p.path + "?" + query.Encode()
+	for next != "" {
+		req, err := apiClient.NewRequest(http.MethodGet, next, nil)
+		if err != nil {
+			return errors.New(err)
+		}
+		var events []json.RawMessage
+		res, err := apiClient.Do(ctx, req, &events)
+		if handleRateLimit(ctx, err, res) {
+			continue
+		}
+		if err != nil {
+			return errors.WrapPrefix(err, "could not list audit log events", 0)
+		}
+		for _, data := range events {
+			var event auditEvent
+			if err := json.Unmarshal(data, &event); err != nil {
+				log.WithError(err).Debug("could not parse audit log event")
+				continue
+			}
+			if !p.advance(event) {
+				continue
+			}
+			chunk := a.chunk(event, data)
+			select {
+			case chunksChan <- chunk:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		next = nextLink(res.Header.Get("Link"))
+	}
+	return nil
+}
+
+// advance records the event as scanned, and returns false if it already was.
+func (p *auditLogPoller) advance(event auditEvent) bool {
+	if _, ok := p.seen[event.DocumentID]; ok {
+		return false
+	}
+	created := time.Unix(0, event.Timestamp*int64(time.Millisecond)).UTC().Truncate(time.Second)
+	if created.After(p.since) {
+		p.since = created
+		p.seen = map[string]struct{}{}
+	}
+	if event.DocumentID != "" {
+		p.seen[event.DocumentID] = struct{}{}
+	}
+	return true
+}
+
+func (a *AuditLog) chunk(event auditEvent, data []byte) *sources.Chunk {
+	timestamp := time.Unix(0, event.Timestamp*int64(time.Millisecond)).UTC()
+	chunk := &sources.Chunk{
+		SourceName: a.SourceName,
+		SourceType: sourcespb.SourceType_SOURCE_TYPE_GITHUB,
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_Github{
+				Github: &source_metadatapb.Github{
+					Username:   event.Actor,
+					Repository: event.Repo,
+					Timestamp:  timestamp.Format(time.RFC3339),
+				},
+			},
+		},
+		Data:   data,
+		Verify: a.Verify,
+		ExtraData: map[string]string{
+			"audit_action": event.Action,
+		},
+	}
+	if event.DocumentID != "" {
+		chunk.ExtraData["audit_document_id"] = event.DocumentID
+	}
+	return chunk
+}
+
+// nextLink returns the URL of the next page from a Link header. The audit log is paginated with cursors, which
+// go-github doesn't parse into the response.
+func nextLink(header string) string {
+	if match := linkNextPat.FindStringSubmatch(header); match != nil {
+		return match[1]
+	}
+	return ""
+}
@@ -30,6 +30,7 @@ import (
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/giturl"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sanitizer"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/git"
@@ -51,6 +52,15 @@ type Source struct {
 	token  string
 	conn   *sourcespb.GitHub
 	jobSem *semaphore.Weighted
+	// repoFilter skips enumerated repositories. Repositories given explicitly are always scanned.
+	repoFilter *common.RepoFilter
+	// snapshot, if set, is where organizations' repositories are listed from.
+	snapshot     *Snapshot
+	snapshotOpts SnapshotOptions
+	// limits skip repositories that are too big to scan. repoSizes are the sizes in bytes of the enumerated
+	// repositories, by clone URL.
+	limits    git.RepoLimits
+	repoSizes map[string]int64
 }
 
 // Ensure the Source satisfies the interface at compile time
@@ -104,7 +114,7 @@ func (s *Source) Init(aCtx context.Context, name string, jobId, sourceId int64,
 	s.verify = verify
 	s.jobSem = semaphore.NewWeighted(int64(concurrency))
 
-	s.httpClient = common.SaneHttpClient()
+	s.httpClient = common.APIHttpClient()
 
 	var conn sourcespb.GitHub
 	err := anypb.UnmarshalTo(connection, &conn, proto.UnmarshalOptions{})
@@ -115,6 +125,10 @@ func (s *Source) Init(aCtx context.Context, name string, jobId, sourceId int64,
 
 	s.repos = s.conn.Repositories
 	s.orgs = s.conn.Organizations
+	s.repoFilter, err = common.NewRepoFilter(s.conn.IncludeRepos, s.conn.ExcludeRepos)
+	if err != nil {
+		return errors.WrapPrefix(err, "invalid repository filter", 0)
+	}
 
 	// Head or base should only be used with incoming webhooks
 	if (len(s.conn.Head) > 0 || len(s.conn.Base) > 0) && len(s.repos) != 1 {
@@ -122,17 +136,21 @@ func (s *Source) Init(aCtx context.Context, name string, jobId, sourceId int64,
 	}
 
 	s.git = git.NewGit(s.Type(), s.JobID(), s.SourceID(), s.name, s.verify, runtime.NumCPU(),
-		func(file, email, commit, timestamp, repository string, line int64) *source_metadatapb.MetaData {
+		func(file, email, commit, timestamp, repository string, line int64, authorName, committerName, committerEmail, commitTimestamp string) *source_metadatapb.MetaData {
 			return &source_metadatapb.MetaData{
 				Data: &source_metadatapb.MetaData_Github{
 					Github: &source_metadatapb.Github{
-						Commit:     sanitizer.UTF8(commit),
-						File:       sanitizer.UTF8(file),
-						Email:      sanitizer.UTF8(email),
-						Repository: sanitizer.UTF8(repository),
-						Link:       git.GenerateLink(repository, commit, file),
-						Timestamp:  sanitizer.UTF8(timestamp),
-						Line:       line,
+						Commit:          sanitizer.UTF8(commit),
+						File:            sanitizer.UTF8(file),
+						Email:           sanitizer.UTF8(email),
+						Repository:      sanitizer.UTF8(repository),
+						Link:            git.GenerateLink(repository, commit, file),
+						Timestamp:       sanitizer.UTF8(timestamp),
+						Line:            line,
+						AuthorName:      sanitizer.UTF8(authorName),
+						CommitterName:   sanitizer.UTF8(committerName),
+						CommitterEmail:  sanitizer.UTF8(committerEmail),
+						CommitTimestamp: sanitizer.UTF8(commitTimestamp),
 					},
 				},
 			}
@@ -148,11 +166,10 @@ func (s *Source) enumerateUnauthenticated(ctx context.Context) *github.Client {
 	}
 
 	for _, org := range s.orgs {
-		errOrg := s.addReposByOrg(ctx, apiClient, org)
-		errUser := s.addReposByUser(ctx, apiClient, org)
-		if errOrg != nil && errUser != nil {
-			log.WithError(errOrg).Error("error fetching repos for org or user: ", org)
+		if common.IsDone(ctx) {
+			break
 		}
+		s.addReposByOwner(ctx, apiClient, org)
 	}
 	return apiClient
 }
@@ -162,22 +179,9 @@ func (s *Source) enumerateWithToken(ctx context.Context, apiEndpoint, token stri
 	s.token = token
 
 	// needed to list repos
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
-	tc := oauth2.NewClient(context.TODO(), ts)
-
-	var err error
-	// If we're using public github, make a regular client.
-	// Otherwise make an enterprise client
-	var apiClient *github.Client
-	if apiEndpoint == "https://api.github.com" {
-		apiClient = github.NewClient(tc)
-	} else {
-		apiClient, err = github.NewEnterpriseClient(apiEndpoint, apiEndpoint, tc)
-		if err != nil {
-			return nil, errors.New(err)
-		}
+	apiClient, err := tokenClient(apiEndpoint, token)
+	if err != nil {
+		return nil, err
 	}
 
 	// TODO: this should support scanning users too
@@ -191,15 +195,14 @@ func (s *Source) enumerateWithToken(ctx context.Context, apiEndpoint, token stri
 	if len(s.orgs) > 0 {
 		specificScope = true
 		for _, org := range s.orgs {
-			errOrg := s.addReposByOrg(ctx, apiClient, org)
-			errUser := s.addReposByUser(ctx, apiClient, org)
-			if errOrg != nil && errUser != nil {
-				log.WithError(errOrg).Error("error fetching repos for org or user: ", org)
+			if common.IsDone(ctx) {
+				break
 			}
+			s.addReposByOwner(ctx, apiClient, org)
 		}
 	}
 
-	user, _, err := apiClient.Users.Get(context.TODO(), "")
+	user, _, err := apiClient.Users.Get(ctx, "")
 	if err != nil {
 		return nil, errors.New(err)
 	}
@@ -227,6 +230,25 @@ func (s *Source) enumerateWithToken(ctx context.Context, apiEndpoint, token stri
 	return apiClient, nil
 }
 
+// tokenClient returns an API client authenticated with the token.
+func tokenClient(apiEndpoint, token string) (*github.Client, error) {
+	ts := oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: token},
+	)
+	tc := oauth2.NewClient(context.WithValue(context.TODO(), oauth2.HTTPClient, common.APIHttpClient()), ts)
+
+	// If we're using public github, make a regular client.
+	// Otherwise make an enterprise client
+	if apiEndpoint == "https://api.github.com" {
+		return github.NewClient(tc), nil
+	}
+	apiClient, err := github.NewEnterpriseClient(apiEndpoint, apiEndpoint, tc)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+	return apiClient, nil
+}
+
 func (s *Source) enumerateWithApp(ctx context.Context, apiEndpoint string, app *credentialspb.GitHubApp) (apiClient, installationClient *github.Client, err error) {
 	installationID, err := strconv.ParseInt(app.InstallationId, 10, 64)
 	if err != nil {
@@ -240,7 +262,7 @@ func (s *Source) enumerateWithApp(ctx context.Context, apiEndpoint string, app *
 
 	// This client is used for most APIs
 	itr, err := ghinstallation.New(
-		common.SaneHttpClient().Transport,
+		common.APIHttpClient().Transport,
 		appID,
 		installationID,
 		[]byte(app.PrivateKey))
@@ -256,7 +278,7 @@ func (s *Source) enumerateWithApp(ctx context.Context, apiEndpoint string, app *
 	// This client is required to create installation tokens for cloning.. Otherwise the required JWT is not in the
 	// request for the token :/
 	appItr, err := ghinstallation.NewAppsTransport(
-		common.SaneHttpClient().Transport,
+		common.APIHttpClient().Transport,
 		appID,
 		[]byte(app.PrivateKey))
 	if err != nil {
@@ -300,6 +322,11 @@ func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk) err
 	if len(apiEndpoint) == 0 || endsWithGithub.MatchString(apiEndpoint) {
 		apiEndpoint = "https://api.github.com"
 	}
+	if s.snapshot != nil {
+		if err := s.snapshot.useEndpoint(apiEndpoint); err != nil {
+			return err
+		}
+	}
 
 	var apiClient, installationClient *github.Client
 
@@ -322,6 +349,13 @@ func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk) err
 	}
 
 	s.normalizeRepos(ctx, apiClient)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if s.snapshotOpts.EnumerateOnly {
+		log.WithField("snapshot", s.snapshotOpts.Path).Infof("Enumerated %d repos, skipping the scan", len(s.repos))
+		return nil
+	}
 
 	if _, ok := os.LookupEnv("DO_NOT_RANDOMIZE"); !ok {
 		// Randomize channel scan order on each scan
@@ -341,13 +375,15 @@ func (s *Source) scan(ctx context.Context, installationClient *github.Client, ch
 	var errsMut sync.Mutex
 	for i, repoURL := range s.repos {
 		if err := s.jobSem.Acquire(ctx, 1); err != nil {
+			// Acquire only fails if the context is done, so there's no point trying the remaining repos.
 			log.WithError(err).Debug("could not acquire semaphore")
-			continue
+			break
 		}
 		wg.Add(1)
 		go func(ctx context.Context, repoURL string, i int) {
 			defer s.jobSem.Release(1)
 			defer wg.Done()
+			ctx = logging.WithUnit(ctx, "repo", repoURL)
 
 			s.SetProgressComplete(i, len(s.repos), fmt.Sprintf("Repo: %s", repoURL), "")
 
@@ -355,6 +391,14 @@ func (s *Source) scan(ctx context.Context, installationClient *github.Client, ch
 				return
 			}
 
+			if err := s.limits.CheckSize(s.repoSizes[repoURL]); err != nil {
+				git.RecordSkipped(ctx, &s.Progress, repoURL, err)
+				return
+			}
+			parentCtx := ctx
+			ctx, cancel := s.limits.WithTimeout(ctx)
+			defer cancel()
+
 			s.log.WithField("repo", repoURL).Debugf("attempting to clone repo %d/%d", i+1, len(s.repos))
 			var path string
 			var repo *gogit.Repository
@@ -362,7 +406,7 @@ func (s *Source) scan(ctx context.Context, installationClient *github.Client, ch
 
 			switch s.conn.GetCredential().(type) {
 			case *sourcespb.GitHub_Unauthenticated:
-				path, repo, err = git.CloneRepoUsingUnauthenticated(repoURL)
+				path, repo, err = git.CloneRepoUsingUnauthenticated(ctx, repoURL)
 			default:
 				var token string
 				token, err = s.Token(ctx, installationClient)
@@ -373,12 +417,20 @@ func (s *Source) scan(ctx context.Context, installationClient *github.Client, ch
 					errsMut.Unlock()
 					return
 				}
-				path, repo, err = git.CloneRepoUsingToken(token, repoURL, "clone")
+				path, repo, err = git.CloneRepoUsingToken(ctx, token, repoURL, "clone")
 			}
 
 			defer os.RemoveAll(path)
+			if err := s.limits.CheckTimeout(parentCtx, ctx); err != nil {
+				git.RecordSkipped(ctx, &s.Progress, repoURL, err)
+				return
+			}
 			if err != nil {
-				log.WithError(err).Errorf("unable to clone repo (%s), continuing", repoURL)
+				logging.FromContext(ctx).WithError(err).Error("unable to clone repo, continuing")
+				return
+			}
+			if err := s.limits.CheckObjects(ctx, path); err != nil {
+				git.RecordSkipped(ctx, &s.Progress, repoURL, err)
 				return
 			}
 			// Base and head will only exist from incoming webhooks.
@@ -388,8 +440,10 @@ func (s *Source) scan(ctx context.Context, installationClient *github.Client, ch
 			)
 
 			err = s.git.ScanRepo(ctx, repo, path, scanOptions, chunksChan)
-			if err != nil {
-				log.WithError(err).Errorf("unable to scan repo, continuing")
+			if limitErr := s.limits.CheckTimeout(parentCtx, ctx); limitErr != nil {
+				git.RecordSkipped(ctx, &s.Progress, repoURL, limitErr)
+			} else if err != nil {
+				logging.FromContext(ctx).WithError(err).Error("unable to scan repo, continuing")
 			}
 			// TODO: use atomic library
 			scanned++
@@ -407,10 +461,10 @@ func (s *Source) scan(ctx context.Context, installationClient *github.Client, ch
 	return nil
 }
 
-// handleRateLimit returns true if a rate limit was handled
+// handleRateLimit returns true if a rate limit was handled, or false if the context was done while waiting for it.
 // Unauthenticated access to most github endpoints has a rate limit of 60 requests per hour.
 // This will likely only be exhausted if many users/orgs are scanned without auth
-func handleRateLimit(errIn error, res *github.Response) bool {
+func handleRateLimit(ctx context.Context, errIn error, res *github.Response) bool {
 	limit, ok := errIn.(*github.RateLimitError)
 	if !ok {
 		return false
@@ -432,18 +486,32 @@ func handleRateLimit(errIn error, res *github.Response) bool {
 			if waitTime > 0 {
 				duration := time.Duration(waitTime+1) * time.Second
 				log.WithField("resumeTime", time.Now().Add(duration).String()).Debugf("rate limited")
-				time.Sleep(duration)
-				return true
+				return common.Sleep(ctx, duration)
 			}
 		}
 	}
 
 	log.WithField("retry-after", limit.Message).Debug("handling rate limit (5 minutes retry)")
-	time.Sleep(time.Minute * 5)
-	return true
+	return common.Sleep(ctx, time.Minute*5)
+}
+
+// addReposByOwner adds the repositories of an organization, or of a user if the owner isn't an organization.
+func (s *Source) addReposByOwner(ctx context.Context, apiClient *github.Client, owner string) {
+	errOrg := s.addReposByOrg(ctx, apiClient, owner)
+	if errOrg == nil && s.snapshot != nil {
+		// Listing the organization's repositories again as a user's would undo the point of the snapshot.
+		return
+	}
+	errUser := s.addReposByUser(ctx, apiClient, owner)
+	if errOrg != nil && errUser != nil {
+		log.WithError(errOrg).Error("error fetching repos for org or user: ", owner)
+	}
 }
 
 func (s *Source) addReposByOrg(ctx context.Context, apiClient *github.Client, org string) error {
+	if s.snapshot != nil {
+		return s.addReposBySnapshot(ctx, apiClient, org)
+	}
 	opts := &github.RepositoryListByOrgOptions{
 		ListOptions: github.ListOptions{
 			PerPage: 100,
@@ -455,7 +523,7 @@ func (s *Source) addReposByOrg(ctx context.Context, apiClient *github.Client, or
 		if err == nil {
 			defer res.Body.Close()
 		}
-		if handled := handleRateLimit(err, res); handled {
+		if handled := handleRateLimit(ctx, err, res); handled {
 			continue
 		}
 		if err != nil {
@@ -472,7 +540,7 @@ func (s *Source) addReposByOrg(ctx context.Context, apiClient *github.Client, or
 					continue
 				}
 			}
-			common.AddStringSliceItem(r.GetCloneURL(), &s.repos)
+			s.addRepo(r)
 		}
 		if res.NextPage == 0 {
 			break
@@ -483,6 +551,98 @@ func (s *Source) addReposByOrg(ctx context.Context, apiClient *github.Client, or
 	return nil
 }
 
+// UseSnapshot lists organizations' repositories through the snapshot file at opts.Path, so that scans reuse the
+// repositories listed by earlier ones and resume enumerations that were interrupted.
+func (s *Source) UseSnapshot(opts SnapshotOptions) error {
+	snapshot, err := LoadSnapshot(opts.Path)
+	if err != nil {
+		return err
+	}
+	s.snapshot, s.snapshotOpts = snapshot, opts
+	return nil
+}
+
+// addReposBySnapshot adds an organization's repositories from the snapshot, listing them first if they're missing
+// or out of date.
+func (s *Source) addReposBySnapshot(ctx context.Context, apiClient *github.Client, org string) error {
+	list := func(ctx context.Context, page int, sort, direction string) ([]SnapshotRepo, int, error) {
+		opts := &github.RepositoryListByOrgOptions{
+			Sort:        sort,
+			Direction:   direction,
+			ListOptions: github.ListOptions{PerPage: 100, Page: page},
+		}
+		for {
+			someRepos, res, err := apiClient.Repositories.ListByOrg(ctx, org, opts)
+			if err == nil {
+				res.Body.Close()
+			}
+			if handled := handleRateLimit(ctx, err, res); handled {
+				continue
+			}
+			if err != nil {
+				return nil, 0, err
+			}
+			repos := make([]SnapshotRepo, 0, len(someRepos))
+			for _, r := range someRepos {
+				repos = append(repos, SnapshotRepo{
+					FullName:      r.GetFullName(),
+					CloneURL:      r.GetCloneURL(),
+					DefaultBranch: r.GetDefaultBranch(),
+					Size:          r.GetSize(),
+					Fork:          r.GetFork(),
+					Archived:      r.GetArchived(),
+					UpdatedAt:     r.GetUpdatedAt().Time,
+				})
+			}
+			return repos, res.NextPage, nil
+		}
+	}
+
+	entry, err := s.snapshot.update(ctx, org, s.snapshotOpts, time.Now(), list)
+	if entry == nil {
+		return fmt.Errorf("could not list repos for org %s: %w", org, err)
+	}
+	if err != nil {
+		log.WithError(err).WithField("org", org).Warn("could not update the snapshot, using the repos listed so far")
+	}
+	var numForks int
+	for _, r := range entry.Repos {
+		if r.Fork {
+			numForks++
+			if !s.conn.IncludeForks {
+				continue
+			}
+		}
+		s.addRepoURL(r.FullName, r.CloneURL, r.Size)
+	}
+	log.WithField("org", org).Debugf("Found %d repos (%d forks) in the snapshot", len(entry.Repos), numForks)
+	return nil
+}
+
+// SetRepoLimits skips the repositories that are over the limits, recording them in the source's progress.
+func (s *Source) SetRepoLimits(limits git.RepoLimits) {
+	s.limits = limits
+}
+
+// addRepo adds an enumerated repository to the scan, unless it's filtered out.
+func (s *Source) addRepo(r *github.Repository) {
+	s.addRepoURL(r.GetFullName(), r.GetCloneURL(), r.GetSize())
+}
+
+// addRepoURL adds an enumerated repository by its full name, clone URL, and size in kilobytes, unless it's filtered
+// out.
+func (s *Source) addRepoURL(fullName, cloneURL string, size int) {
+	if !s.repoFilter.Pass(fullName) {
+		s.log.WithField("repo", fullName).Debug("skipping filtered repository")
+		return
+	}
+	common.AddStringSliceItem(cloneURL, &s.repos)
+	if s.repoSizes == nil {
+		s.repoSizes = map[string]int64{}
+	}
+	s.repoSizes[cloneURL] = int64(size) << 10
+}
+
 func (s *Source) addReposByUser(ctx context.Context, apiClient *github.Client, user string) error {
 	opts := &github.RepositoryListOptions{
 		ListOptions: github.ListOptions{
@@ -494,7 +654,7 @@ func (s *Source) addReposByUser(ctx context.Context, apiClient *github.Client, u
 		if err == nil {
 			defer res.Body.Close()
 		}
-		if handled := handleRateLimit(err, res); handled {
+		if handled := handleRateLimit(ctx, err, res); handled {
 			continue
 		}
 		if err != nil {
@@ -504,7 +664,7 @@ func (s *Source) addReposByUser(ctx context.Context, apiClient *github.Client, u
 			if r.GetFork() && !s.conn.IncludeForks {
 				continue
 			}
-			common.AddStringSliceItem(r.GetCloneURL(), &s.repos)
+			s.addRepo(r)
 		}
 		if res.NextPage == 0 {
 			break
@@ -521,7 +681,7 @@ func (s *Source) addGistsByUser(ctx context.Context, apiClient *github.Client, u
 		if err == nil {
 			defer resp.Body.Close()
 		}
-		if handled := handleRateLimit(err, resp); handled {
+		if handled := handleRateLimit(ctx, err, resp); handled {
 			continue
 		}
 		if err != nil {
@@ -559,7 +719,7 @@ func (s *Source) addMembersByApp(ctx context.Context, installationClient *github
 			if err == nil {
 				defer res.Body.Close()
 			}
-			if handled := handleRateLimit(err, res); handled {
+			if handled := handleRateLimit(ctx, err, res); handled {
 				continue
 			}
 			if err != nil || len(members) == 0 {
@@ -594,7 +754,7 @@ func (s *Source) addReposByApp(ctx context.Context, apiClient *github.Client) er
 		if err == nil {
 			defer res.Body.Close()
 		}
-		if handled := handleRateLimit(err, res); handled {
+		if handled := handleRateLimit(ctx, err, res); handled {
 			continue
 		}
 		if err != nil {
@@ -604,7 +764,7 @@ func (s *Source) addReposByApp(ctx context.Context, apiClient *github.Client) er
 			if r.GetFork() && !s.conn.IncludeForks {
 				continue
 			}
-			common.AddStringSliceItem(r.GetCloneURL(), &s.repos)
+			s.addRepo(r)
 		}
 		if res.NextPage == 0 {
 			break
@@ -623,7 +783,7 @@ func (s *Source) addOrgsByUser(ctx context.Context, apiClient *github.Client, us
 		if err == nil {
 			defer resp.Body.Close()
 		}
-		if handled := handleRateLimit(err, resp); handled {
+		if handled := handleRateLimit(ctx, err, resp); handled {
 			continue
 		}
 		if err != nil {
@@ -582,3 +582,20 @@ func basicCheckFunc(minOrg, minRepo int, wantChunk *sources.Chunk, s *Source) co
 // 		})
 // 	}
 // }
+
+func TestSource_addRepo(t *testing.T) {
+	filter, err := common.NewRepoFilter([]string{"acme/*"}, []string{"*-archive"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Source{repoFilter: filter, log: log.WithField("source", "test")}
+	for _, name := range []string{"acme/api", "acme/logs-archive", "other/api"} {
+		s.addRepo(&github.Repository{
+			FullName: github.String(name),
+			CloneURL: github.String("https://github.com/" + name + ".git"),
+		})
+	}
+	if diff := pretty.Compare(s.repos, []string{"https://github.com/acme/api.git"}); diff != "" {
+		t.Errorf("unexpected repos: (-got +want)\n%s", diff)
+	}
+}
@@ -0,0 +1,62 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-errors/errors"
+	"github.com/google/go-github/v42/github"
+	log "github.com/sirupsen/logrus"
+)
+
+// secretKinds are the features whose organization secrets are listed by ListSecretNames.
+var secretKinds = []string{"actions", "dependabot"}
+
+// ListSecretNames calls fn with the name of every Actions and Dependabot secret of the organizations, and a scope
+// naming where it's configured, such as "actions:trufflesecurity". The API never returns secret values, so the token
+// only needs to be able to read organization secrets.
+func ListSecretNames(ctx context.Context, apiEndpoint, token string, orgs []string, fn func(scope, name string)) error {
+	apiClient, err := tokenClient(apiEndpoint, token)
+	if err != nil {
+		return err
+	}
+	for _, org := range orgs {
+		for _, kind := range secretKinds {
+			if err := listOrgSecretNames(ctx, apiClient, org, kind, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func listOrgSecretNames(ctx context.Context, apiClient *github.Client, org, kind string, fn func(scope, name string)) error {
+	page := 1
+	for {
+		req, err := apiClient.NewRequest(http.MethodGet, fmt.Sprintf("orgs/%s/%s/secrets?per_page=100&page=%d", org, kind, page), nil)
+		if err != nil {
+			return errors.New(err)
+		}
+		var secrets github.Secrets
+		res, err := apiClient.Do(ctx, req, &secrets)
+		if handleRateLimit(ctx, err, res) {
+			continue
+		}
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			// Older GitHub Enterprise Server versions don't have Dependabot secrets.
+			log.Debugf("no %s secrets endpoint for %s", kind, org)
+			return nil
+		}
+		if err != nil {
+			return errors.WrapPrefix(err, fmt.Sprintf("could not list %s secrets of %s", kind, org), 0)
+		}
+		for _, secret := range secrets.Secrets {
+			fn(kind+":"+org, secret.Name)
+		}
+		if res.NextPage == 0 {
+			return nil
+		}
+		page = res.NextPage
+	}
+}
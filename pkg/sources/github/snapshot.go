@@ -0,0 +1,222 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultSnapshotMaxAge is how old an organization's enumeration can be before it's refreshed, unless
+// SnapshotOptions.MaxAge is set.
+const DefaultSnapshotMaxAge = 24 * time.Hour
+
+// snapshotClockSkew is subtracted from the time of the last enumeration when refreshing, so that repositories
+// updated around then aren't missed if GitHub's clock differs from ours.
+const snapshotClockSkew = 5 * time.Minute
+
+// SnapshotOptions configure a GitHub source to enumerate organizations' repositories through a snapshot file.
+type SnapshotOptions struct {
+	// Path is the snapshot file. It's created if it doesn't exist.
+	Path string
+	// MaxAge is how old an organization's enumeration can be before it's refreshed by listing the repositories
+	// updated since. Zero uses DefaultSnapshotMaxAge.
+	MaxAge time.Duration
+	// Rebuild enumerates the organizations from scratch, replacing their repositories in the snapshot.
+	Rebuild bool
+	// EnumerateOnly updates the snapshot without scanning the repositories, so that enumeration can be scheduled
+	// separately from scans.
+	EnumerateOnly bool
+}
+
+// Snapshot is the repositories enumerated from organizations, saved so that later scans can reuse them rather than
+// listing every repository of a large organization again. It's saved after every page of repositories is listed, so
+// an enumeration that's interrupted, by a rate limit or otherwise, resumes where it stopped. A Snapshot isn't safe
+// for concurrent use.
+type Snapshot struct {
+	// Endpoint is the GitHub API the repositories were listed from.
+	Endpoint string `json:"endpoint"`
+	// Orgs is keyed by organization login.
+	Orgs map[string]*OrgSnapshot `json:"orgs"`
+
+	path string
+}
+
+// OrgSnapshot is the repositories of an organization.
+type OrgSnapshot struct {
+	// EnumeratedAt is when the last complete enumeration or refresh started. Refreshes list the repositories
+	// updated since.
+	EnumeratedAt time.Time `json:"enumerated_at"`
+	// Complete is false while an enumeration is in progress, and NextPage is the page it resumes from.
+	Complete bool           `json:"complete"`
+	NextPage int            `json:"next_page,omitempty"`
+	Repos    []SnapshotRepo `json:"repos"`
+
+	// index maps the full names of Repos to their index.
+	index map[string]int
+}
+
+// SnapshotRepo is a repository as it was listed.
+type SnapshotRepo struct {
+	FullName      string `json:"full_name"`
+	CloneURL      string `json:"clone_url"`
+	DefaultBranch string `json:"default_branch,omitempty"`
+	// Size is in kilobytes, as reported by GitHub.
+	Size      int       `json:"size"`
+	Fork      bool      `json:"fork,omitempty"`
+	Archived  bool      `json:"archived,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// LoadSnapshot reads the snapshot at path, or returns an empty one if the file doesn't exist yet.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	s := &Snapshot{Orgs: map[string]*OrgSnapshot{}, path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("could not parse snapshot %s: %w", path, err)
+	}
+	if s.Orgs == nil {
+		s.Orgs = map[string]*OrgSnapshot{}
+	}
+	return s, nil
+}
+
+// Save writes the snapshot to its file, replacing it only once it's completely written.
+func (s *Snapshot) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// useEndpoint checks that the snapshot was taken of the endpoint, or records it if the snapshot is new.
+func (s *Snapshot) useEndpoint(endpoint string) error {
+	if s.Endpoint == "" {
+		s.Endpoint = endpoint
+		return nil
+	}
+	if s.Endpoint != endpoint {
+		return fmt.Errorf("snapshot %s lists repositories of %s, not %s", s.path, s.Endpoint, endpoint)
+	}
+	return nil
+}
+
+// listPage lists a page of an organization's repositories sorted by the field, in the direction. Page zero is the
+// first page, and a next page of zero means there are no more.
+type listPage func(ctx context.Context, page int, sort, direction string) (repos []SnapshotRepo, next int, err error)
+
+// update brings the organization's repositories up to date and returns them. A complete enumeration younger than
+// the maximum age is used as is, an older one is refreshed, and an incomplete one is resumed. If listing fails, the
+// repositories listed so far are returned with the error, or nil if the organization has never been listed, such as
+// when it's a user.
+func (s *Snapshot) update(ctx context.Context, org string, opts SnapshotOptions, now time.Time, list listPage) (*OrgSnapshot, error) {
+	maxAge := opts.MaxAge
+	if maxAge == 0 {
+		maxAge = DefaultSnapshotMaxAge
+	}
+	entry, ok := s.Orgs[org]
+	if !ok {
+		entry = &OrgSnapshot{}
+	}
+	if opts.Rebuild {
+		entry.Complete, entry.NextPage = false, 0
+	}
+	switch {
+	case entry.Complete && now.Sub(entry.EnumeratedAt) < maxAge:
+		return entry, nil
+	case entry.Complete:
+		return entry, s.refresh(ctx, entry, now, list)
+	}
+
+	if entry.NextPage == 0 {
+		*entry = OrgSnapshot{EnumeratedAt: now}
+	}
+	for page := entry.NextPage; ; {
+		repos, next, err := list(ctx, page, "full_name", "asc")
+		if err != nil {
+			if _, ok := s.Orgs[org]; !ok {
+				return nil, err
+			}
+			return entry, err
+		}
+		// The organization is only added once it's been listed, so that users aren't.
+		s.Orgs[org] = entry
+		entry.add(repos)
+		entry.NextPage, entry.Complete = next, next == 0
+		if err := s.Save(); err != nil {
+			return entry, fmt.Errorf("could not save snapshot: %w", err)
+		}
+		if entry.Complete {
+			return entry, nil
+		}
+		page = next
+	}
+}
+
+// refresh lists the repositories updated since the last enumeration, most recently updated first, and adds them.
+// Repositories that were deleted remain until the organization is rebuilt.
+func (s *Snapshot) refresh(ctx context.Context, entry *OrgSnapshot, now time.Time, list listPage) error {
+	since := entry.EnumeratedAt.Add(-snapshotClockSkew)
+	for page := 0; ; {
+		repos, next, err := list(ctx, page, "updated", "desc")
+		if err != nil {
+			return err
+		}
+		for i, r := range repos {
+			if r.UpdatedAt.Before(since) {
+				repos, next = repos[:i], 0
+				break
+			}
+		}
+		entry.add(repos)
+		if next == 0 {
+			break
+		}
+		page = next
+	}
+	entry.EnumeratedAt = now
+	if err := s.Save(); err != nil {
+		return fmt.Errorf("could not save snapshot: %w", err)
+	}
+	return nil
+}
+
+// add adds repositories, replacing those of the same name.
+func (o *OrgSnapshot) add(repos []SnapshotRepo) {
+	if o.index == nil {
+		o.index = make(map[string]int, len(o.Repos))
+		for i, r := range o.Repos {
+			o.index[r.FullName] = i
+		}
+	}
+	for _, r := range repos {
+		if i, ok := o.index[r.FullName]; ok {
+			o.Repos[i] = r
+			continue
+		}
+		o.index[r.FullName] = len(o.Repos)
+		o.Repos = append(o.Repos, r)
+	}
+}
@@ -0,0 +1,153 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+// fakeOrg serves an organization's repositories two to a page, like the API sorted by the field asked for.
+type fakeOrg struct {
+	repos  []SnapshotRepo
+	failAt int
+	calls  []string
+}
+
+func (f *fakeOrg) list(_ context.Context, page int, sortBy, direction string) ([]SnapshotRepo, int, error) {
+	if page == 0 {
+		page = 1
+	}
+	f.calls = append(f.calls, fmt.Sprintf("%s %s %d", sortBy, direction, page))
+	if page == f.failAt {
+		return nil, 0, errors.New("rate limited")
+	}
+	repos := append([]SnapshotRepo(nil), f.repos...)
+	if sortBy == "updated" {
+		sort.SliceStable(repos, func(i, j int) bool { return repos[i].UpdatedAt.After(repos[j].UpdatedAt) })
+	}
+	start, end, next := (page-1)*2, page*2, page+1
+	if end >= len(repos) {
+		end, next = len(repos), 0
+	}
+	return repos[start:end], next, nil
+}
+
+func names(repos []SnapshotRepo) []string {
+	var names []string
+	for _, r := range repos {
+		names = append(names, r.FullName)
+	}
+	return names
+}
+
+func TestSnapshot(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	start := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	repo := func(name string, updated time.Time) SnapshotRepo {
+		return SnapshotRepo{FullName: "acme/" + name, CloneURL: "https://github.com/acme/" + name + ".git", UpdatedAt: updated}
+	}
+	org := &fakeOrg{
+		repos: []SnapshotRepo{
+			repo("a", start.Add(-time.Hour)),
+			repo("b", start.Add(-time.Hour)),
+			repo("c", start.Add(-time.Hour)),
+			repo("d", start.Add(-time.Hour)),
+			repo("e", start.Add(-time.Hour)),
+		},
+		failAt: 2,
+	}
+
+	// The enumeration is interrupted after the first page, which is saved.
+	s, err := LoadSnapshot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, err := s.update(ctx, "acme", SnapshotOptions{}, start, org.list)
+	if err == nil || entry.Complete || !reflect.DeepEqual(names(entry.Repos), []string{"acme/a", "acme/b"}) {
+		t.Fatalf("expected the first page and an error, got %+v, %v", entry, err)
+	}
+
+	// The next run resumes from the second page.
+	org.failAt, org.calls = 0, nil
+	if s, err = LoadSnapshot(path); err != nil {
+		t.Fatal(err)
+	}
+	entry, err = s.update(ctx, "acme", SnapshotOptions{}, start.Add(time.Minute), org.list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"full_name asc 2", "full_name asc 3"}; !reflect.DeepEqual(org.calls, want) {
+		t.Errorf("expected calls %v, got %v", want, org.calls)
+	}
+	if !entry.Complete || !entry.EnumeratedAt.Equal(start) || len(entry.Repos) != 5 {
+		t.Errorf("expected a complete enumeration started at %s, got %+v", start, entry)
+	}
+
+	// A recent enumeration is reused without listing anything.
+	org.calls = nil
+	if s, err = LoadSnapshot(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.update(ctx, "acme", SnapshotOptions{}, start.Add(time.Hour), org.list); err != nil || len(org.calls) != 0 {
+		t.Errorf("expected the snapshot to be reused, got calls %v and error %v", org.calls, err)
+	}
+
+	// An old one is refreshed with the repositories updated since.
+	org.calls = nil
+	org.repos[2].DefaultBranch = "trunk"
+	org.repos[2].UpdatedAt = start.Add(2 * time.Hour)
+	org.repos = append(org.repos, repo("f", start.Add(3*time.Hour)))
+	refreshed := start.Add(48 * time.Hour)
+	entry, err = s.update(ctx, "acme", SnapshotOptions{}, refreshed, org.list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"updated desc 1", "updated desc 2"}; !reflect.DeepEqual(org.calls, want) {
+		t.Errorf("expected calls %v, got %v", want, org.calls)
+	}
+	if want := []string{"acme/a", "acme/b", "acme/c", "acme/d", "acme/e", "acme/f"}; !reflect.DeepEqual(names(entry.Repos), want) {
+		t.Errorf("expected repos %v, got %v", want, names(entry.Repos))
+	}
+	if entry.Repos[2].DefaultBranch != "trunk" || !entry.EnumeratedAt.Equal(refreshed) {
+		t.Errorf("expected the updated repo and refresh time, got %+v", entry)
+	}
+
+	// A rebuild lists everything again, dropping deleted repositories.
+	org.calls = nil
+	org.repos = org.repos[1:]
+	entry, err = s.update(ctx, "acme", SnapshotOptions{Rebuild: true}, refreshed, org.list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(org.calls) != 3 || len(entry.Repos) != 5 || entry.Repos[0].FullName != "acme/b" {
+		t.Errorf("expected a rebuild without acme/a, got calls %v and repos %v", org.calls, names(entry.Repos))
+	}
+}
+
+func TestSnapshot_NotAnOrg(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	s, err := LoadSnapshot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	org := &fakeOrg{failAt: 1}
+	if entry, err := s.update(context.Background(), "octocat", SnapshotOptions{}, time.Now(), org.list); entry != nil || err == nil {
+		t.Errorf("expected no entry and an error, got %+v, %v", entry, err)
+	}
+	if len(s.Orgs) != 0 {
+		t.Errorf("expected the user not to be added, got %v", s.Orgs)
+	}
+
+	if err := s.useEndpoint("https://api.github.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.useEndpoint("https://github.example.com/api/v3"); err == nil {
+		t.Error("expected an error using the snapshot with another endpoint")
+	}
+}
@@ -14,6 +14,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/giturl"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sanitizer"
@@ -36,10 +37,14 @@ type Source struct {
 	token      string
 	url        string
 	repos      []string
+	repoFilter *common.RepoFilter
 	git        *git.Git
 	aCtx       context.Context
 	sources.Progress
 	jobSem *semaphore.Weighted
+	// limits skip repositories that are too big to scan. GitLab doesn't report sizes without extra permissions, so
+	// only the timeout and object limits apply.
+	limits git.RepoLimits
 }
 
 // Ensure the Source satisfies the interface at compile time.
@@ -76,6 +81,10 @@ func (s *Source) Init(aCtx context.Context, name string, jobId, sourceId int64,
 	}
 
 	s.repos = conn.Repositories
+	s.repoFilter, err = common.NewRepoFilter(conn.IncludeRepos, conn.ExcludeRepos)
+	if err != nil {
+		return errors.WrapPrefix(err, "invalid repository filter", 0)
+	}
 	s.url = conn.Endpoint
 	if conn.Endpoint != "" && !strings.HasSuffix(s.url, "/") {
 		s.url = s.url + "/"
@@ -104,17 +113,21 @@ func (s *Source) Init(aCtx context.Context, name string, jobId, sourceId int64,
 	}
 
 	s.git = git.NewGit(s.Type(), s.JobID(), s.SourceID(), s.name, s.verify, runtime.NumCPU(),
-		func(file, email, commit, timestamp, repository string, line int64) *source_metadatapb.MetaData {
+		func(file, email, commit, timestamp, repository string, line int64, authorName, committerName, committerEmail, commitTimestamp string) *source_metadatapb.MetaData {
 			return &source_metadatapb.MetaData{
 				Data: &source_metadatapb.MetaData_Gitlab{
 					Gitlab: &source_metadatapb.Gitlab{
-						Commit:     sanitizer.UTF8(commit),
-						File:       sanitizer.UTF8(file),
-						Email:      sanitizer.UTF8(email),
-						Repository: sanitizer.UTF8(repository),
-						Link:       git.GenerateLink(repository, commit, file),
-						Timestamp:  sanitizer.UTF8(timestamp),
-						Line:       line,
+						Commit:          sanitizer.UTF8(commit),
+						File:            sanitizer.UTF8(file),
+						Email:           sanitizer.UTF8(email),
+						Repository:      sanitizer.UTF8(repository),
+						Link:            git.GenerateLink(repository, commit, file),
+						Timestamp:       sanitizer.UTF8(timestamp),
+						Line:            line,
+						AuthorName:      sanitizer.UTF8(authorName),
+						CommitterName:   sanitizer.UTF8(committerName),
+						CommitterEmail:  sanitizer.UTF8(committerEmail),
+						CommitTimestamp: sanitizer.UTF8(commitTimestamp),
 					},
 				},
 			}
@@ -127,14 +140,14 @@ func (s *Source) newClient() (*gitlab.Client, error) {
 	// Initialize a new api instance.
 	switch s.authMethod {
 	case "OAUTH":
-		apiClient, err := gitlab.NewOAuthClient(s.token, gitlab.WithBaseURL(s.url))
+		apiClient, err := gitlab.NewOAuthClient(s.token, gitlab.WithBaseURL(s.url), gitlab.WithHTTPClient(common.APIHttpClient()))
 		if err != nil {
 			return nil, fmt.Errorf("could not create Gitlab OAUTH client for %s. Error: %v", s.url, err)
 		}
 		return apiClient, nil
 
 	case "BASIC_AUTH":
-		apiClient, err := gitlab.NewBasicAuthClient(s.user, s.password, gitlab.WithBaseURL(s.url))
+		apiClient, err := gitlab.NewBasicAuthClient(s.user, s.password, gitlab.WithBaseURL(s.url), gitlab.WithHTTPClient(common.APIHttpClient()))
 		if err != nil {
 			return nil, fmt.Errorf("could not create Gitlab BASICAUTH client for %s. Error: %v", s.url, err)
 		}
@@ -147,7 +160,7 @@ func (s *Source) newClient() (*gitlab.Client, error) {
 		}
 		fallthrough
 	case "TOKEN":
-		apiClient, err := gitlab.NewOAuthClient(s.token, gitlab.WithBaseURL(s.url))
+		apiClient, err := gitlab.NewOAuthClient(s.token, gitlab.WithBaseURL(s.url), gitlab.WithHTTPClient(common.APIHttpClient()))
 		if err != nil {
 			return nil, fmt.Errorf("could not create Gitlab TOKEN client for %s. Error: %v", s.url, err)
 		}
@@ -283,13 +296,17 @@ func (s *Source) scanRepos(ctx context.Context, chunksChan chan *sources.Chunk,
 			if len(repoURL.String()) == 0 {
 				return
 			}
+			ctx = logging.WithUnit(ctx, "repo", repoURL.String())
 			s.SetProgressComplete(i, len(repos), fmt.Sprintf("Repo: %s", repoURL), "")
+			parentCtx := ctx
+			ctx, cancel := s.limits.WithTimeout(ctx)
+			defer cancel()
 
 			var path string
 			var repo *gogit.Repository
 			var err error
 			if s.authMethod == "UNAUTHENTICATED" {
-				path, repo, err = git.CloneRepoUsingUnauthenticated(repoURL.String())
+				path, repo, err = git.CloneRepoUsingUnauthenticated(ctx, repoURL.String())
 			} else {
 				// If a username is not provided we need to use a default one in order to clone a private repo.
 				// Not setting "placeholder" as s.user on purpose in case any downstream services rely on a "" value for s.user.
@@ -297,24 +314,36 @@ func (s *Source) scanRepos(ctx context.Context, chunksChan chan *sources.Chunk,
 				if user == "" {
 					user = "placeholder"
 				}
-				path, repo, err = git.CloneRepoUsingToken(s.token, repoURL.String(), user)
+				path, repo, err = git.CloneRepoUsingToken(ctx, s.token, repoURL.String(), user)
 			}
 			defer os.RemoveAll(path)
+			if err := s.limits.CheckTimeout(parentCtx, ctx); err != nil {
+				git.RecordSkipped(ctx, &s.Progress, repoURL.String(), err)
+				return
+			}
 			if err != nil {
 				errsMut.Lock()
 				errs = append(errs, err)
 				errsMut.Unlock()
 				return
 			}
-			log.Debugf("Starting to scan repo %d/%d: %s", i+1, len(repos), repoURL.String())
+			if err := s.limits.CheckObjects(ctx, path); err != nil {
+				git.RecordSkipped(ctx, &s.Progress, repoURL.String(), err)
+				return
+			}
+			logging.FromContext(ctx).Debugf("Starting to scan repo %d/%d", i+1, len(repos))
 			err = s.git.ScanRepo(ctx, repo, path, git.NewScanOptions(), chunksChan)
+			if limitErr := s.limits.CheckTimeout(parentCtx, ctx); limitErr != nil {
+				git.RecordSkipped(ctx, &s.Progress, repoURL.String(), limitErr)
+				return
+			}
 			if err != nil {
 				errsMut.Lock()
 				errs = append(errs, err)
 				errsMut.Unlock()
 				return
 			}
-			log.Debugf("Completed scanning repo %d/%d: %s", i+1, len(repos), repoURL.String())
+			logging.FromContext(ctx).Debugf("Completed scanning repo %d/%d", i+1, len(repos))
 		}(ctx, u, i)
 	}
 	wg.Wait()
@@ -322,6 +351,11 @@ func (s *Source) scanRepos(ctx context.Context, chunksChan chan *sources.Chunk,
 	return errs
 }
 
+// SetRepoLimits skips the repositories that are over the limits, recording them in the source's progress.
+func (s *Source) SetRepoLimits(limits git.RepoLimits) {
+	s.limits = limits
+}
+
 // Chunks emits chunks of bytes over a channel.
 func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk) error {
 	// Start client.
@@ -348,9 +382,14 @@ func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk) err
 		}
 		// Turn projects into URLs for Git cloner.
 		for _, prj := range projects {
+			if !s.repoFilter.Pass(prj.PathWithNamespace) {
+				log.WithField("project", prj.PathWithNamespace).Debug("skipping filtered project")
+				continue
+			}
 			u, err := url.Parse(prj.HTTPURLToRepo)
 			if err != nil {
-				fmt.Printf("could not parse url given by project: %s", prj.HTTPURLToRepo)
+				log.WithError(err).Warnf("could not parse url given by project: %s", prj.HTTPURLToRepo)
+				continue
 			}
 			repos = append(repos, u)
 		}
@@ -1,6 +1,7 @@
 package s3
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io/ioutil"
@@ -16,6 +17,8 @@ import (
 	"github.com/go-errors/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/handlers"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/logging"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sanitizer"
@@ -36,6 +39,7 @@ type Source struct {
 	sources.Progress
 	errorCount *sync.Map
 	conn       *sourcespb.S3
+	archive    *handlers.Archive
 }
 
 // Ensure the Source satisfies the interface at compile time
@@ -65,6 +69,7 @@ func (s *Source) Init(aCtx context.Context, name string, jobId, sourceId int64,
 	s.verify = verify
 	s.concurrency = concurrency
 	s.errorCount = &sync.Map{}
+	s.archive = handlers.NewArchive()
 
 	var conn sourcespb.S3
 	err := anypb.UnmarshalTo(connection, &conn, proto.UnmarshalOptions{})
@@ -199,6 +204,8 @@ func (s *Source) pageChunker(ctx context.Context, client *s3.S3, chunksChan chan
 			if (*obj.Key)[len(*obj.Key)-1:] == "/" {
 				return
 			}
+			ctx = logging.WithUnit(ctx, "object", "s3://"+bucket+"/"+*obj.Key)
+			objLog := s.log.WithFields(logging.FromContext(ctx).Data)
 			//log.Debugf("Object: %s", *obj.Key)
 
 			path := strings.Split(*obj.Key, "/")
@@ -210,30 +217,33 @@ func (s *Source) pageChunker(ctx context.Context, client *s3.S3, chunksChan chan
 			}
 			if nErr.(int) > 3 {
 				log.Debugf("Skipped: %s", *obj.Key)
+				s.RecordSkippedFile(*obj.Key, sources.SkipReasonErrors)
 				return
 			}
 
 			// ignore large files
 			if *obj.Size > int64(10*common.MB) {
+				s.RecordSkippedFile(*obj.Key, sources.SkipReasonTooLarge)
 				return
 			}
 
 			//file is 0 bytes - likely no permissions - skipping
 			if *obj.Size == 0 {
+				s.RecordSkippedFile(*obj.Key, sources.SkipReasonEmpty)
 				return
 			}
 
 			//files break with spaces, must replace with +
 			//objKey := strings.ReplaceAll(*obj.Key, " ", "+")
-			ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+			getCtx, cancel := context.WithTimeout(ctx, time.Second*5)
 			defer cancel()
-			res, err := client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+			res, err := client.GetObjectWithContext(getCtx, &s3.GetObjectInput{
 				Bucket: &bucket,
 				Key:    obj.Key,
 			})
 			if err != nil {
 				if !strings.Contains(err.Error(), "AccessDenied") {
-					s.log.WithError(err).Errorf("could not get S3 object: %s", *obj.Key)
+					objLog.WithError(err).Errorf("could not get S3 object: %s", *obj.Key)
 				}
 
 				nErr, ok := errorCount.Load(prefix)
@@ -248,14 +258,14 @@ func (s *Source) pageChunker(ctx context.Context, client *s3.S3, chunksChan chan
 				errorCount.Store(prefix, nErr)
 				//too many consective errors on this page
 				if nErr.(int) > 3 {
-					s.log.Warnf("Too many consecutive errors. Blacklisting %s", prefix)
+					objLog.Warnf("Too many consecutive errors. Blacklisting %s", prefix)
 				}
 				log.Debugf("Error Counts: %s:%s", prefix, nErr)
 				return
 			}
 			body, err := ioutil.ReadAll(res.Body)
 			if err != nil {
-				s.log.WithError(err).Error("could not read S3 object body")
+				objLog.WithError(err).Error("could not read S3 object body")
 				nErr, ok := errorCount.Load(prefix)
 				if !ok {
 					nErr = 0
@@ -269,39 +279,58 @@ func (s *Source) pageChunker(ctx context.Context, client *s3.S3, chunksChan chan
 				errorCount.Store(prefix, nErr)
 
 				if nErr.(int) > 3 {
-					s.log.Warnf("Too many consecutive errors. Blacklisting %s", prefix)
+					objLog.Warnf("Too many consecutive errors. Blacklisting %s", prefix)
 				}
 				return
 			}
 
-			// ignore files that don't have secrets
-			if common.SkipFile(*obj.Key, body) {
-				return
-			}
-
 			email := "Unknown"
 			if obj.Owner != nil {
 				email = *obj.Owner.DisplayName
 			}
 			modified := obj.LastModified.String()
-			chunk := sources.Chunk{
-				SourceType: s.Type(),
-				SourceName: s.name,
-				SourceID:   s.SourceID(),
-				Data:       body,
-				SourceMetadata: &source_metadatapb.MetaData{
-					Data: &source_metadatapb.MetaData_S3{
-						S3: &source_metadatapb.S3{
-							Bucket:    bucket,
-							File:      sanitizer.UTF8(*obj.Key),
-							Link:      sanitizer.UTF8(makeS3Link(bucket, *client.Config.Region, *obj.Key)),
-							Email:     sanitizer.UTF8(email),
-							Timestamp: sanitizer.UTF8(modified),
+			chunk := func(file string, data []byte) *sources.Chunk {
+				return &sources.Chunk{
+					SourceType: s.Type(),
+					SourceName: s.name,
+					SourceID:   s.SourceID(),
+					UnitID:     logging.UnitID(ctx),
+					Data:       data,
+					SourceMetadata: &source_metadatapb.MetaData{
+						Data: &source_metadatapb.MetaData_S3{
+							S3: &source_metadatapb.S3{
+								Bucket:    bucket,
+								File:      sanitizer.UTF8(file),
+								Link:      sanitizer.UTF8(makeS3Link(bucket, *client.Config.Region, *obj.Key)),
+								Email:     sanitizer.UTF8(email),
+								Timestamp: sanitizer.UTF8(modified),
+							},
 						},
 					},
-				},
-				Verify: s.verify,
+					Verify: s.verify,
+				}
 			}
+
+			// Archives are streamed through the chunker an entry at a time, so only the compressed object is held in
+			// memory.
+			isArchive, err := s.archive.Chunks(ctx, bytes.NewReader(body), func(provenance []string, data []byte) {
+				c := chunk(*obj.Key+"/"+strings.Join(provenance, "/"), data)
+				c.Provenance = append([]string{"s3://" + bucket + "/" + *obj.Key}, provenance...)
+				chunksChan <- c
+			})
+			if isArchive {
+				if err != nil {
+					objLog.WithError(err).Warnf("could not read archive: %s", *obj.Key)
+				}
+				return
+			}
+
+			// ignore files that don't have secrets
+			if common.SkipFile(*obj.Key, body) {
+				s.RecordSkippedFile(*obj.Key, sources.SkipReasonIgnoredType)
+				return
+			}
+
 			nErr, ok = errorCount.Load(prefix)
 			if !ok {
 				nErr = 0
@@ -309,7 +338,7 @@ func (s *Source) pageChunker(ctx context.Context, client *s3.S3, chunksChan chan
 			if nErr.(int) > 0 {
 				errorCount.Store(prefix, 0)
 			}
-			chunksChan <- &chunk
+			chunksChan <- chunk(*obj.Key, body)
 		}(ctx, &wg, sem, obj)
 	}
 	wg.Wait()
@@ -19,6 +19,19 @@ type Chunk struct {
 	SourceType sourcespb.SourceType
 	// SourceMetadata holds the context of where the Chunk was found.
 	SourceMetadata *source_metadatapb.MetaData
+	// Provenance is set when the Chunk was extracted from nested containers, such as a file in a tarball in an S3
+	// object. It names each container, outermost first, and then the file.
+	Provenance []string
+	// ExtraData is added to the extra data of results found in the Chunk, without replacing what detectors set. It
+	// holds what the source knows about the file, such as the hosts an SSH key is configured for.
+	ExtraData map[string]string
+	// Original maps positions in Data to the original source it was generated from, if it was, such as minified
+	// JavaScript with a source map. The file and line of the original source are set as "original_file" and
+	// "original_line" in the extra data of results found in the Chunk.
+	Original PositionMapper
+	// UnitID is the correlation ID of the unit the Chunk was read from, such as a repository or an object, if the
+	// source scanned it in a context from logging.WithUnit. It's copied to results, and matches the unit's logs.
+	UnitID string
 
 	// Data is the data to decode and scan.
 	Data []byte
@@ -26,6 +39,13 @@ type Chunk struct {
 	Verify bool
 }
 
+// PositionMapper maps a position in generated data to the original source it was generated from.
+type PositionMapper interface {
+	// OriginalPosition returns the file and one-based line of the original source a zero-based line and byte column
+	// of the data was generated from.
+	OriginalPosition(line, column int) (file string, originalLine int, ok bool)
+}
+
 // Source defines the interface required to implement a source chunker.
 type Source interface {
 	// Type returns the source type, used for matching against configuration and jobs.
@@ -50,8 +70,37 @@ type Progress struct {
 	EncodedResumeInfo string
 	SectionsCompleted int32
 	SectionsRemaining int32
+	Skipped           []SkippedFile
+	SkippedRepoList   []SkippedRepo
+}
+
+// SkippedFile is a file a source chose not to scan.
+type SkippedFile struct {
+	Name   string
+	Reason string
 }
 
+// Reasons a file may be skipped.
+const (
+	SkipReasonIgnoredType = "ignored file type"
+	SkipReasonTooLarge    = "too large"
+	SkipReasonEmpty       = "empty"
+	SkipReasonErrors      = "too many errors"
+)
+
+// SkippedRepo is a repository a source didn't scan, or stopped scanning, because it was over a limit.
+type SkippedRepo struct {
+	Name   string
+	Reason string
+}
+
+// Reasons a repository may be skipped.
+const (
+	SkipReasonRepoSize    = "over max repo size"
+	SkipReasonRepoTimeout = "over repo timeout"
+	SkipReasonRepoObjects = "over max repo objects"
+)
+
 // SetProgressComplete sets job progress information for a running job based on the highest level objects in the source.
 // i is the current iteration in the loop of target scope
 // scope should be the len(scopedItems)
@@ -74,3 +123,32 @@ func (p *Progress) GetProgress() *Progress {
 	defer p.mut.Unlock()
 	return p
 }
+
+// RecordSkippedFile records that a file was not scanned and why, for reporting in the scan summary.
+func (p *Progress) RecordSkippedFile(name, reason string) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	p.Skipped = append(p.Skipped, SkippedFile{Name: name, Reason: reason})
+}
+
+// SkippedFiles returns the files recorded by RecordSkippedFile.
+func (p *Progress) SkippedFiles() []SkippedFile {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	return append([]SkippedFile(nil), p.Skipped...)
+}
+
+// RecordSkippedRepo records that a repository was not scanned, or not completely, and why, for reporting in the scan
+// summary.
+func (p *Progress) RecordSkippedRepo(name, reason string) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	p.SkippedRepoList = append(p.SkippedRepoList, SkippedRepo{Name: name, Reason: reason})
+}
+
+// SkippedRepos returns the repositories recorded by RecordSkippedRepo.
+func (p *Progress) SkippedRepos() []SkippedRepo {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	return append([]SkippedRepo(nil), p.SkippedRepoList...)
+}
@@ -0,0 +1,411 @@
+// Package sshremote scans files on remote hosts over SSH, for auditing VMs and jump hosts for credentials left on disk.
+// Nothing is installed on the hosts: each path is read by running tar over the connection and streaming its archive
+// through the chunker, so only a POSIX shell and tar are needed, which every Unix-like host has.
+package sshremote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/handlers"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sanitizer"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+const (
+	// dialTimeout limits connecting to a host and the SSH handshake, but not the scan.
+	dialTimeout = 30 * time.Second
+	// maxStderr is how much of tar's error output is kept for each path, for the warning when it fails.
+	maxStderr = 64 * 1024
+)
+
+// defaultIdentities are the keys in ~/.ssh that are tried when no IdentityFiles are given, like ssh does.
+var defaultIdentities = []string{"id_ed25519", "id_ecdsa", "id_rsa"}
+
+// Source scans paths on hosts over SSH.
+type Source struct {
+	// Hosts are the hosts to scan, as host, user@host, or user@host:port.
+	Hosts []string
+	// Paths are the files and directories to scan on each host. Relative paths are relative to the user's home
+	// directory, and a leading ~/ is expanded by the host's shell.
+	Paths []string
+	// User logs in to hosts that don't name one. Defaults to the local user.
+	User string
+	// IdentityFiles are unencrypted private keys to authenticate with, in addition to the keys of a running ssh-agent.
+	// Defaults to the keys in ~/.ssh that ssh tries. Encrypted keys need to be added to ssh-agent.
+	IdentityFiles []string
+	// KnownHostsFiles are checked for the keys of the hosts. Defaults to ~/.ssh/known_hosts.
+	KnownHostsFiles []string
+	// InsecureIgnoreHostKey connects to hosts without checking their keys, which lets anyone who can intercept the
+	// connection read the scanned files.
+	InsecureIgnoreHostKey bool
+	// Jump is a host, as host, user@host, or user@host:port, that connections to the hosts are made through, like
+	// ssh -J.
+	Jump string
+	// Sudo reads the paths as root with sudo -n, which needs passwordless sudo on the hosts.
+	Sudo bool
+	// Concurrency is the number of hosts scanned at once. Defaults to 1.
+	Concurrency int
+
+	SourceName string
+	Verify     bool
+
+	archive *handlers.Archive
+}
+
+// Chunks scans the paths on each host, sending a chunk for each part of every file. A host that can't be connected to,
+// or a path that can't be read, is logged and skipped so the rest are still scanned.
+func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk) error {
+	if len(s.Hosts) == 0 || len(s.Paths) == 0 {
+		return errors.New("at least one host and path are required")
+	}
+	config, closeAgent, err := s.clientConfig()
+	if err != nil {
+		return err
+	}
+	defer closeAgent()
+	s.archive = handlers.NewArchive()
+
+	var jump *ssh.Client
+	if s.Jump != "" {
+		user, _, addr := s.parseHost(s.Jump)
+		if jump, err = dial(ctx, nil, addr, withUser(config, user)); err != nil {
+			return fmt.Errorf("could not connect to jump host %s: %w", s.Jump, err)
+		}
+		defer jump.Close()
+	}
+
+	concurrency := s.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, target := range s.Hosts {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if common.IsDone(ctx) {
+			break
+		}
+		wg.Add(1)
+		go func(target string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := s.scanHost(ctx, jump, target, config, chunksChan); err != nil && !common.IsDone(ctx) {
+				log.WithError(err).WithField("host", target).Warn("could not scan host")
+			}
+		}(target)
+	}
+	wg.Wait()
+	return nil
+}
+
+// clientConfig returns the configuration shared by every connection, without a user, and a function that closes the
+// connection to ssh-agent, if one was made.
+func (s *Source) clientConfig() (*ssh.ClientConfig, func(), error) {
+	hostKeyCallback := ssh.InsecureIgnoreHostKey()
+	if !s.InsecureIgnoreHostKey {
+		files := s.KnownHostsFiles
+		if len(files) == 0 {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, nil, fmt.Errorf("could not find known hosts: %w", err)
+			}
+			files = []string{filepath.Join(home, ".ssh", "known_hosts")}
+		}
+		callback, err := knownhosts.New(files...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not read known hosts: %w", err)
+		}
+		hostKeyCallback = callback
+	}
+
+	var signers []ssh.Signer
+	identities, explicit := s.IdentityFiles, true
+	if len(identities) == 0 {
+		explicit = false
+		if home, err := os.UserHomeDir(); err == nil {
+			for _, name := range defaultIdentities {
+				identities = append(identities, filepath.Join(home, ".ssh", name))
+			}
+		}
+	}
+	for _, file := range identities {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			if !explicit && os.IsNotExist(err) {
+				continue
+			}
+			return nil, nil, fmt.Errorf("could not read identity file: %w", err)
+		}
+		signer, err := ssh.ParsePrivateKey(data)
+		if err != nil {
+			var missing *ssh.PassphraseMissingError
+			switch {
+			case !explicit:
+				log.WithError(err).Debugf("skipping identity file %s", file)
+				continue
+			case errors.As(err, &missing):
+				return nil, nil, fmt.Errorf("identity file %s is encrypted, add it to ssh-agent instead", file)
+			}
+			return nil, nil, fmt.Errorf("could not parse identity file %s: %w", file, err)
+		}
+		signers = append(signers, signer)
+	}
+
+	var auth []ssh.AuthMethod
+	if len(signers) > 0 {
+		auth = append(auth, ssh.PublicKeys(signers...))
+	}
+	closeAgent := func() {}
+	if socket := os.Getenv("SSH_AUTH_SOCK"); socket != "" {
+		conn, err := net.Dial("unix", socket)
+		if err != nil {
+			log.WithError(err).Debug("could not connect to ssh-agent")
+		} else {
+			closeAgent = func() { conn.Close() }
+			auth = append(auth, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+		}
+	}
+	if len(auth) == 0 {
+		return nil, nil, errors.New("no SSH keys found, give an identity file or run ssh-agent")
+	}
+
+	return &ssh.ClientConfig{
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         dialTimeout,
+	}, closeAgent, nil
+}
+
+// withUser returns a copy of the config that logs in as user.
+func withUser(config *ssh.ClientConfig, user string) *ssh.ClientConfig {
+	c := *config
+	c.User = user
+	return &c
+}
+
+// parseHost splits a host given as host, user@host, or user@host:port into the user to log in as, the host as it's
+// reported in results, without the user, and the address to connect to.
+func (s *Source) parseHost(target string) (user, host, addr string) {
+	user, host = s.User, target
+	if i := strings.LastIndex(target, "@"); i >= 0 {
+		user, host = target[:i], target[i+1:]
+	}
+	if user == "" {
+		user = os.Getenv("USER")
+	}
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return user, host, host
+	}
+	return user, host, net.JoinHostPort(strings.Trim(host, "[]"), "22")
+}
+
+// dial connects to addr, through the jump host if there is one.
+func dial(ctx context.Context, jump *ssh.Client, addr string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	var conn net.Conn
+	var err error
+	if jump != nil {
+		conn, err = jump.Dial("tcp", addr)
+	} else {
+		d := net.Dialer{Timeout: dialTimeout}
+		conn, err = d.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Connections through a jump host don't support deadlines, so their handshake is only bounded by the jump host's.
+	_ = conn.SetDeadline(time.Now().Add(dialTimeout))
+	c, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return ssh.NewClient(c, chans, reqs), nil
+}
+
+// scanHost connects to a host and scans each of the paths on it.
+func (s *Source) scanHost(ctx context.Context, jump *ssh.Client, target string, config *ssh.ClientConfig, chunksChan chan *sources.Chunk) error {
+	user, host, addr := s.parseHost(target)
+	client, err := dial(ctx, jump, addr, withUser(config, user))
+	if err != nil {
+		return fmt.Errorf("could not connect: %w", err)
+	}
+	defer client.Close()
+
+	// Closing the connection unblocks a scan waiting on the host when the context is done.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			client.Close()
+		case <-stop:
+		}
+	}()
+
+	log.WithField("host", host).Info("scanning host")
+	for _, p := range s.Paths {
+		if common.IsDone(ctx) {
+			return nil
+		}
+		if err := s.scanPath(ctx, client, user, host, p, chunksChan); err != nil && !common.IsDone(ctx) {
+			log.WithError(err).WithField("host", host).Warnf("could not scan %s", p)
+		}
+	}
+	return nil
+}
+
+// scanPath streams a path from the host as a tar archive, sending the chunks of each file in it. Files tar can't read,
+// such as those the user has no permission for, are skipped by tar, and reported in the returned error once the rest
+// of the path has been scanned.
+func (s *Source) scanPath(ctx context.Context, client *ssh.Client, user, host, remotePath string, chunksChan chan *sources.Chunk) error {
+	session, err := client.NewSession()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr := &limitedBuffer{max: maxStderr}
+	session.Stderr = stderr
+	if err := session.Start(tarCommand(remotePath, s.Sudo)); err != nil {
+		return err
+	}
+
+	_, err = s.archive.Chunks(ctx, stdout, func(provenance []string, data []byte) {
+		file := remoteFile(remotePath, provenance[0])
+		chunk := s.chunk(user, host, path.Join(append([]string{file}, provenance[1:]...)...), data)
+		if len(provenance) > 1 {
+			chunk.Provenance = append([]string{file}, provenance[1:]...)
+		}
+		select {
+		case chunksChan <- chunk:
+		case <-ctx.Done():
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if common.IsDone(ctx) {
+		return nil
+	}
+	// tar exits once the rest of its output, such as the archive's trailing blocks, has been read.
+	if _, err := io.Copy(io.Discard, stdout); err != nil {
+		return err
+	}
+	if err := session.Wait(); err != nil {
+		var lines []string
+		for _, line := range strings.Split(stderr.String(), "\n") {
+			// GNU tar notes that it strips the leading / of absolute paths, which isn't an error.
+			if line = strings.TrimSpace(line); line == "" || strings.Contains(line, "Removing leading") {
+				continue
+			}
+			log.WithField("host", host).Debug(line)
+			lines = append(lines, line)
+		}
+		switch len(lines) {
+		case 0:
+			return err
+		case 1:
+			return fmt.Errorf("%s: %w", lines[0], err)
+		}
+		return fmt.Errorf("%s, and %d more lines of errors: %w", lines[0], len(lines)-1, err)
+	}
+	return nil
+}
+
+// tarCommand returns the shell command that writes a tar archive of the remote path to stdout.
+func tarCommand(remotePath string, sudo bool) string {
+	command := "tar -cf - -- " + quotePath(remotePath)
+	if sudo {
+		command = "sudo -n " + command
+	}
+	return command
+}
+
+// quotePath quotes a path for the remote shell, leaving a leading ~ unquoted so that the shell expands it.
+func quotePath(p string) string {
+	switch {
+	case p == "~":
+		return p
+	case strings.HasPrefix(p, "~/"):
+		return "~/" + shellQuote(p[2:])
+	}
+	return shellQuote(p)
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// remoteFile returns the path on the host of a tar entry read from remotePath. tar strips the leading / of absolute
+// paths, including those the shell expanded from ~, so it's restored for them.
+func remoteFile(remotePath, name string) string {
+	name = path.Clean(name)
+	if path.IsAbs(remotePath) || remotePath == "~" || strings.HasPrefix(remotePath, "~/") {
+		return "/" + strings.TrimPrefix(name, "/")
+	}
+	return name
+}
+
+func (s *Source) chunk(user, host, file string, data []byte) *sources.Chunk {
+	return &sources.Chunk{
+		SourceName: s.SourceName,
+		SourceType: sourcespb.SourceType_SOURCE_TYPE_SSH,
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_Ssh{
+				Ssh: &source_metadatapb.SSH{
+					Host: host,
+					User: user,
+					File: sanitizer.UTF8(file),
+				},
+			},
+		},
+		Data:   data,
+		Verify: s.Verify,
+	}
+}
+
+// limitedBuffer keeps the first max bytes written to it, and discards the rest.
+type limitedBuffer struct {
+	buf []byte
+	max int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - len(b.buf); room > 0 {
+		if len(p) > room {
+			b.buf = append(b.buf, p[:room]...)
+		} else {
+			b.buf = append(b.buf, p...)
+		}
+	}
+	return len(p), nil
+}
+
+func (b *limitedBuffer) String() string {
+	return string(b.buf)
+}
@@ -0,0 +1,385 @@
+package sshremote
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// tarball returns a tar archive of the files, by name.
+func tarball(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// testServer is an SSH server that answers the commands the source runs with canned output, and forwards connections
+// for jump hosts.
+type testServer struct {
+	addr     string
+	hostKey  ssh.Signer
+	outputs  map[string][]byte
+	failures map[string]string
+
+	mu       sync.Mutex
+	commands []string
+	forwards []string
+}
+
+func newTestServer(t *testing.T, clientKey ssh.PublicKey) *testServer {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostKey, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if conn.User() != "deploy" || !bytes.Equal(key.Marshal(), clientKey.Marshal()) {
+				return nil, fmt.Errorf("unknown key for %s", conn.User())
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	s := &testServer{addr: listener.Addr().String(), hostKey: hostKey, outputs: map[string][]byte{}, failures: map[string]string{}}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn, config)
+		}
+	}()
+	return s
+}
+
+func (s *testServer) serve(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		switch newChannel.ChannelType() {
+		case "session":
+			channel, requests, err := newChannel.Accept()
+			if err != nil {
+				continue
+			}
+			go s.session(channel, requests)
+		case "direct-tcpip":
+			var target struct {
+				Host       string
+				Port       uint32
+				OriginHost string
+				OriginPort uint32
+			}
+			if err := ssh.Unmarshal(newChannel.ExtraData(), &target); err != nil {
+				_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+				continue
+			}
+			addr := net.JoinHostPort(target.Host, fmt.Sprint(target.Port))
+			upstream, err := net.Dial("tcp", addr)
+			if err != nil {
+				_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+				continue
+			}
+			channel, requests, err := newChannel.Accept()
+			if err != nil {
+				upstream.Close()
+				continue
+			}
+			s.mu.Lock()
+			s.forwards = append(s.forwards, addr)
+			s.mu.Unlock()
+			go ssh.DiscardRequests(requests)
+			go func() {
+				_, _ = io.Copy(channel, upstream)
+				channel.Close()
+			}()
+			go func() {
+				_, _ = io.Copy(upstream, channel)
+				upstream.Close()
+			}()
+		default:
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported")
+		}
+	}
+}
+
+func (s *testServer) session(channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+	for req := range requests {
+		if req.Type != "exec" {
+			_ = req.Reply(false, nil)
+			continue
+		}
+		var exec struct{ Command string }
+		if err := ssh.Unmarshal(req.Payload, &exec); err != nil {
+			_ = req.Reply(false, nil)
+			continue
+		}
+		_ = req.Reply(true, nil)
+		s.mu.Lock()
+		s.commands = append(s.commands, exec.Command)
+		s.mu.Unlock()
+
+		status := uint32(0)
+		if output, ok := s.outputs[exec.Command]; ok {
+			_, _ = channel.Write(output)
+		}
+		if msg, ok := s.failures[exec.Command]; ok {
+			_, _ = channel.Stderr().Write([]byte(msg))
+			status = 2
+		}
+		_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{status}))
+		return
+	}
+}
+
+// writeKeys writes the client's private key and a known_hosts file with the servers' host keys, returning their paths.
+func writeKeys(t *testing.T, clientKey ed25519.PrivateKey, servers ...*testServer) (identity, knownHosts string) {
+	t.Helper()
+	dir := t.TempDir()
+	der, err := x509.MarshalPKCS8PrivateKey(clientKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity = filepath.Join(dir, "id_ed25519")
+	if err := os.WriteFile(identity, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for _, s := range servers {
+		lines = append(lines, knownhosts.Line([]string{knownhosts.Normalize(s.addr)}, s.hostKey.PublicKey()))
+	}
+	knownHosts = filepath.Join(dir, "known_hosts")
+	if err := os.WriteFile(knownHosts, []byte(strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return identity, knownHosts
+}
+
+func TestSource_Chunks(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+	_, clientKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientPub, err := ssh.NewPublicKey(clientKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := newTestServer(t, clientPub)
+	server.outputs["sudo -n tar -cf - -- '/etc/app'"] = tarball(t, map[string]string{
+		"etc/app/config.yml": "aws_secret_access_key: config-secret\n",
+		"etc/app/backup.tar": string(tarball(t, map[string]string{"db.env": "DB_PASSWORD=nested-secret\n"})),
+	})
+	server.outputs["sudo -n tar -cf - -- ~/'.aws'"] = tarball(t, map[string]string{
+		"home/deploy/.aws/credentials": "aws_secret_access_key = home-secret\n",
+	})
+	server.outputs["sudo -n tar -cf - -- 'it'\\''s'"] = tarball(t, map[string]string{
+		"it's/notes.txt": "token=relative-secret\n",
+	})
+	server.failures["sudo -n tar -cf - -- '/missing'"] = "tar: Removing leading `/' from member names\ntar: /missing: Cannot stat: No such file or directory\ntar: Exiting with failure status due to previous errors\n"
+	identity, knownHosts := writeKeys(t, clientKey, server)
+
+	s := &Source{
+		Hosts:           []string{"deploy@" + server.addr, "deploy@127.0.0.1:1"},
+		Paths:           []string{"/etc/app", "/missing", "~/.aws", "it's"},
+		IdentityFiles:   []string{identity},
+		KnownHostsFiles: []string{knownHosts},
+		Sudo:            true,
+		Concurrency:     2,
+		SourceName:      "trufflehog - ssh",
+	}
+	chunksChan := make(chan *sources.Chunk, 10)
+	if err := s.Chunks(context.Background(), chunksChan); err != nil {
+		t.Fatal(err)
+	}
+	close(chunksChan)
+
+	want := map[string]string{
+		"/etc/app/config.yml":           "config-secret",
+		"/etc/app/backup.tar/db.env":    "nested-secret",
+		"/home/deploy/.aws/credentials": "home-secret",
+		"it's/notes.txt":                "relative-secret",
+	}
+	got := 0
+	for chunk := range chunksChan {
+		got++
+		metadata := chunk.SourceMetadata.GetSsh()
+		secret, ok := want[metadata.GetFile()]
+		if !ok {
+			t.Errorf("unexpected chunk for %s", metadata.GetFile())
+			continue
+		}
+		if metadata.GetHost() != server.addr || metadata.GetUser() != "deploy" || chunk.SourceName != "trufflehog - ssh" {
+			t.Errorf("%s: unexpected metadata: %v", metadata.GetFile(), metadata)
+		}
+		if !strings.Contains(string(chunk.Data), secret) {
+			t.Errorf("%s: expected %q in chunk, got: %q", metadata.GetFile(), secret, chunk.Data)
+		}
+		if metadata.GetFile() == "/etc/app/backup.tar/db.env" {
+			if want := []string{"/etc/app/backup.tar", "db.env"}; strings.Join(chunk.Provenance, "|") != strings.Join(want, "|") {
+				t.Errorf("expected provenance %v, got %v", want, chunk.Provenance)
+			}
+		}
+	}
+	if got != len(want) {
+		t.Errorf("unexpected number of chunks. Got: %d, Expected: %d", got, len(want))
+	}
+	if len(server.commands) != len(s.Paths) {
+		t.Errorf("expected a command for each path, got %q", server.commands)
+	}
+}
+
+func TestSource_Chunks_Jump(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+	_, clientKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientPub, err := ssh.NewPublicKey(clientKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	jump, target := newTestServer(t, clientPub), newTestServer(t, clientPub)
+	target.outputs["tar -cf - -- '/srv/.env'"] = tarball(t, map[string]string{"srv/.env": "API_KEY=jump-secret\n"})
+	identity, knownHosts := writeKeys(t, clientKey, jump, target)
+
+	s := &Source{
+		Hosts:           []string{target.addr},
+		Paths:           []string{"/srv/.env"},
+		User:            "deploy",
+		Jump:            "deploy@" + jump.addr,
+		IdentityFiles:   []string{identity},
+		KnownHostsFiles: []string{knownHosts},
+	}
+	chunksChan := make(chan *sources.Chunk, 10)
+	if err := s.Chunks(context.Background(), chunksChan); err != nil {
+		t.Fatal(err)
+	}
+	close(chunksChan)
+
+	chunk := <-chunksChan
+	if chunk == nil || chunk.SourceMetadata.GetSsh().GetFile() != "/srv/.env" || !strings.Contains(string(chunk.Data), "jump-secret") {
+		t.Fatalf("unexpected chunk %v", chunk)
+	}
+	if len(jump.forwards) != 1 || jump.forwards[0] != target.addr || len(jump.commands) != 0 {
+		t.Errorf("expected the connection to be forwarded by the jump host, got forwards %v and commands %v", jump.forwards, jump.commands)
+	}
+}
+
+func TestSource_Chunks_UnknownHostKey(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+	_, clientKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientPub, err := ssh.NewPublicKey(clientKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	known, unknown := newTestServer(t, clientPub), newTestServer(t, clientPub)
+	identity, knownHosts := writeKeys(t, clientKey, known)
+
+	s := &Source{
+		Hosts:           []string{"deploy@" + unknown.addr},
+		Paths:           []string{"/etc"},
+		IdentityFiles:   []string{identity},
+		KnownHostsFiles: []string{knownHosts},
+	}
+	chunksChan := make(chan *sources.Chunk, 1)
+	if err := s.Chunks(context.Background(), chunksChan); err != nil {
+		t.Fatal(err)
+	}
+	if len(unknown.commands) != 0 {
+		t.Errorf("expected no commands on a host with an unknown key, got %q", unknown.commands)
+	}
+
+	s.InsecureIgnoreHostKey = true
+	if err := s.Chunks(context.Background(), chunksChan); err != nil {
+		t.Fatal(err)
+	}
+	if len(unknown.commands) != 1 {
+		t.Errorf("expected the host to be scanned when host keys are ignored, got %q", unknown.commands)
+	}
+}
+
+func TestParseHost(t *testing.T) {
+	s := &Source{User: "audit"}
+	tests := []struct {
+		target, user, host, addr string
+	}{
+		{"web1", "audit", "web1", "web1:22"},
+		{"root@web1", "root", "web1", "web1:22"},
+		{"root@web1:2222", "root", "web1:2222", "web1:2222"},
+		{"root@[::1]:2222", "root", "[::1]:2222", "[::1]:2222"},
+		{"::1", "audit", "::1", "[::1]:22"},
+	}
+	for _, tt := range tests {
+		user, host, addr := s.parseHost(tt.target)
+		if user != tt.user || host != tt.host || addr != tt.addr {
+			t.Errorf("parseHost(%q) = %q, %q, %q, expected %q, %q, %q", tt.target, user, host, addr, tt.user, tt.host, tt.addr)
+		}
+	}
+}
+
+func TestRemoteFile(t *testing.T) {
+	tests := []struct {
+		remotePath, name, want string
+	}{
+		{"/etc", "etc/passwd", "/etc/passwd"},
+		{"~/.aws", "home/deploy/.aws/config", "/home/deploy/.aws/config"},
+		{"~", "home/deploy/.netrc", "/home/deploy/.netrc"},
+		{"app", "app/.env", "app/.env"},
+		{".", "./.env", ".env"},
+	}
+	for _, tt := range tests {
+		if got := remoteFile(tt.remotePath, tt.name); got != tt.want {
+			t.Errorf("remoteFile(%q, %q) = %q, expected %q", tt.remotePath, tt.name, got, tt.want)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"net"
 	"runtime"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/bill-rich/go-syslog/pkg/syslogparser/rfc3164"
@@ -244,6 +245,15 @@ func (s *Source) monitorConnection(ctx context.Context, conn net.Conn, chunksCha
 }
 
 func (s *Source) acceptTCPConnections(ctx context.Context, netListener net.Listener, chunksChan chan *sources.Chunk) error {
+	// Accept blocks, so the listener is closed to unblock it when the context is cancelled.
+	go func() {
+		<-ctx.Done()
+		netListener.Close()
+	}()
+
+	// Wait for open connections to finish sending chunks before returning, since the caller closes the channel.
+	var connWg sync.WaitGroup
+	defer connWg.Wait()
 	for {
 		if common.IsDone(ctx) {
 			return nil
@@ -253,7 +263,12 @@ func (s *Source) acceptTCPConnections(ctx context.Context, netListener net.Liste
 			logrus.WithError(err).Debug("failed to accept TCP connection")
 			continue
 		}
-		go s.monitorConnection(ctx, conn, chunksChan)
+		connWg.Add(1)
+		go func() {
+			defer connWg.Done()
+			defer conn.Close()
+			s.monitorConnection(ctx, conn, chunksChan)
+		}()
 	}
 }
 
@@ -262,6 +277,11 @@ func (s *Source) acceptUDPConnections(ctx context.Context, netListener net.Packe
 		if common.IsDone(ctx) {
 			return nil
 		}
+		// Reads time out regularly so that a cancelled context is noticed promptly.
+		err := netListener.SetReadDeadline(time.Now().Add(time.Second))
+		if err != nil {
+			return errors.WrapPrefix(err, "could not set UDP deadline", 0)
+		}
 		input := make([]byte, 65535)
 		_, remote, err := netListener.ReadFrom(input)
 		if err != nil {
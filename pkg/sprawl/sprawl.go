@@ -0,0 +1,256 @@
+// Package sprawl reports how much of the secret material found by a scan lives outside managed storage, by comparing
+// each secret with the contents of the configured secret managers. Secrets that are copies of a managed secret, or that
+// are assigned to a variable named after one, are accounted separately from secrets no secret manager knows about.
+package sprawl
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/secretstore"
+)
+
+// Status is how a secret relates to managed storage.
+type Status string
+
+const (
+	// Managed secrets have their value held in a secret manager. Finding one elsewhere is a copy of a managed secret.
+	Managed Status = "managed"
+	// Named secrets are assigned to a variable named after a managed or CI secret, but their value isn't managed.
+	// They're likely the value of that secret from before it was rotated, or from another environment.
+	Named Status = "named"
+	// Unmanaged secrets aren't known to any secret manager.
+	Unmanaged Status = "unmanaged"
+)
+
+// Report quantifies the secrets of a scan by their Status. Each secret is counted once, with the status of its
+// closest occurrence to managed storage.
+type Report struct {
+	Findings int `json:"findings"`
+	Counts
+	Detectors []Group `json:"detectors"`
+	Sources   []Group `json:"sources"`
+	Entries   []Entry `json:"entries"`
+}
+
+// Counts are the number of unique secrets with each Status.
+type Counts struct {
+	Secrets   int `json:"secrets"`
+	Managed   int `json:"managed"`
+	Named     int `json:"named"`
+	Unmanaged int `json:"unmanaged"`
+}
+
+// Group counts the secrets of a detector or source.
+type Group struct {
+	Name string `json:"name"`
+	Counts
+}
+
+// Entry is a unique secret in a Report.
+type Entry struct {
+	Fingerprint  string `json:"fingerprint"`
+	DetectorType string `json:"detector_type"`
+	Verified     bool   `json:"verified"`
+	Redacted     string `json:"redacted,omitempty"`
+	SourceName   string `json:"source_name"`
+	Location     string `json:"location"`
+	Occurrences  int    `json:"occurrences"`
+	Status       Status `json:"status"`
+	// Store is the secret manager holding a managed secret.
+	Store string `json:"store,omitempty"`
+	// SecretName and SecretNameScope are the managed or CI secret a named secret is assigned to.
+	SecretName      string `json:"secret_name,omitempty"`
+	SecretNameScope string `json:"secret_name_scope,omitempty"`
+}
+
+// Build reports the findings of a scan. Findings are classified by the tags a scan run with --secret-store adds, and
+// are also looked up in inv, which may be nil, so that results of scans run without it can be reported.
+func Build(results []findings.Finding, inv *secretstore.Inventory) *Report {
+	report := &Report{Findings: len(results), Detectors: []Group{}, Sources: []Group{}, Entries: []Entry{}}
+	entries := map[string]*Entry{}
+	var order []string
+	for i := range results {
+		finding := &results[i]
+		entry := classify(finding, inv)
+		existing, ok := entries[entry.Fingerprint]
+		if !ok {
+			entries[entry.Fingerprint] = &entry
+			order = append(order, entry.Fingerprint)
+			continue
+		}
+		existing.Occurrences++
+		existing.Verified = existing.Verified || finding.Verified
+		if rank(entry.Status) < rank(existing.Status) {
+			entry.Occurrences, entry.Verified = existing.Occurrences, existing.Verified
+			*existing = entry
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := entries[order[i]], entries[order[j]]
+		if a.Status != b.Status {
+			return rank(a.Status) > rank(b.Status)
+		}
+		if a.DetectorType != b.DetectorType {
+			return a.DetectorType < b.DetectorType
+		}
+		return a.Location < b.Location
+	})
+
+	detectors := map[string]*Counts{}
+	sources := map[string]*Counts{}
+	for _, fingerprint := range order {
+		entry := entries[fingerprint]
+		report.Entries = append(report.Entries, *entry)
+		report.Counts.add(entry.Status)
+		countGroup(detectors, entry.DetectorType, entry.Status)
+		countGroup(sources, entry.SourceName, entry.Status)
+	}
+	report.Detectors = groups(detectors)
+	report.Sources = groups(sources)
+	return report
+}
+
+// classify returns the entry of a single finding.
+func classify(finding *findings.Finding, inv *secretstore.Inventory) Entry {
+	entry := Entry{
+		Fingerprint:  finding.Fingerprint(),
+		DetectorType: finding.DetectorType,
+		Verified:     finding.Verified,
+		Redacted:     finding.Redacted,
+		SourceName:   finding.SourceName,
+		Location:     finding.Location(),
+		Occurrences:  1,
+		Status:       Unmanaged,
+	}
+	if finding.ExtraData["managed_secret"] == "true" {
+		entry.Status, entry.Store = Managed, finding.ExtraData["secret_store"]
+	} else if store, ok := inv.Lookup([]byte(finding.Raw)); ok {
+		entry.Status, entry.Store = Managed, store
+	} else if name := finding.ExtraData["secret_name"]; name != "" {
+		entry.Status, entry.SecretName, entry.SecretNameScope = Named, name, finding.ExtraData["secret_name_scope"]
+	}
+	return entry
+}
+
+// rank orders statuses by how close they are to managed storage.
+func rank(status Status) int {
+	switch status {
+	case Managed:
+		return 0
+	case Named:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func (c *Counts) add(status Status) {
+	c.Secrets++
+	switch status {
+	case Managed:
+		c.Managed++
+	case Named:
+		c.Named++
+	default:
+		c.Unmanaged++
+	}
+}
+
+func countGroup(counts map[string]*Counts, name string, status Status) {
+	if counts[name] == nil {
+		counts[name] = &Counts{}
+	}
+	counts[name].add(status)
+}
+
+// groups returns the groups sorted by the most unmanaged secrets, then by name.
+func groups(counts map[string]*Counts) []Group {
+	groups := make([]Group, 0, len(counts))
+	for name, c := range counts {
+		groups = append(groups, Group{Name: name, Counts: *c})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Unmanaged != groups[j].Unmanaged {
+			return groups[i].Unmanaged > groups[j].Unmanaged
+		}
+		return groups[i].Name < groups[j].Name
+	})
+	return groups
+}
+
+// WriteText writes a human readable report.
+func (r *Report) WriteText(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "Secrets: %d, in %d findings\n", r.Secrets, r.Findings); err != nil {
+		return err
+	}
+	rows := []struct {
+		label string
+		count int
+		help  string
+	}{
+		{"Unmanaged", r.Unmanaged, "not in any secret manager"},
+		{"Named", r.Named, "assigned to a variable named after a managed or CI secret"},
+		{"Managed", r.Managed, "copies of a secret held in a secret manager"},
+	}
+	for _, row := range rows {
+		if _, err := fmt.Fprintf(w, "  %-10s %5d %s  %s\n", row.label+":", row.count, percent(row.count, r.Secrets), row.help); err != nil {
+			return err
+		}
+	}
+
+	for _, section := range []struct {
+		title  string
+		groups []Group
+	}{
+		{"By detector", r.Detectors},
+		{"By source", r.Sources},
+	} {
+		if _, err := fmt.Fprintf(w, "\n%s:\n", section.title); err != nil {
+			return err
+		}
+		for _, g := range section.groups {
+			if _, err := fmt.Fprintf(w, "  %s: %d unmanaged, %d named, %d managed\n", g.Name, g.Unmanaged, g.Named, g.Managed); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := fmt.Fprintln(w, "\nSecrets:"); err != nil {
+		return err
+	}
+	for _, entry := range r.Entries {
+		verified := "unverified"
+		if entry.Verified {
+			verified = "verified"
+		}
+		detail := ""
+		switch entry.Status {
+		case Managed:
+			detail = " in " + entry.Store
+		case Named:
+			detail = fmt.Sprintf(" as %s of %s", entry.SecretName, entry.SecretNameScope)
+		}
+		if _, err := fmt.Fprintf(w, "  [%s%s] %s (%s) %s %s", entry.Status, detail, entry.DetectorType, verified, entry.SourceName, entry.Location); err != nil {
+			return err
+		}
+		if entry.Occurrences > 1 {
+			if _, err := fmt.Fprintf(w, " and %d more", entry.Occurrences-1); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func percent(n, total int) string {
+	if total == 0 {
+		return "(  0%)"
+	}
+	return fmt.Sprintf("(%3d%%)", n*100/total)
+}
@@ -0,0 +1,610 @@
+// Package store persists scan runs, their findings, and the findings' triage states in a SQLite or Postgres
+// database, so that scans can be compared with earlier ones instead of each being treated in isolation. It also
+// persists the sources and scans of the server's tenants, each only reachable with its tenant's name.
+//
+// Findings are keyed by their fingerprint, so a secret found again in a later scan, or in another place, updates the
+// same finding and adds an occurrence to it.
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// Register the database drivers.
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+)
+
+// State is the triage state of a finding.
+type State string
+
+const (
+	// StateOpen findings need attention. New findings are open.
+	StateOpen State = "open"
+	// StateFalsePositive findings aren't secrets, or are accepted.
+	StateFalsePositive State = "false-positive"
+	// StateResolved findings have been rotated or removed.
+	StateResolved State = "resolved"
+)
+
+// ParseState parses a triage state name such as "resolved".
+func ParseState(name string) (State, error) {
+	switch state := State(strings.ToLower(name)); state {
+	case StateOpen, StateFalsePositive, StateResolved:
+		return state, nil
+	}
+	return "", fmt.Errorf("unknown state %q, expected open, false-positive, or resolved", name)
+}
+
+// ErrNotFound is returned when a scan or finding isn't in the store.
+var ErrNotFound = errors.New("not found")
+
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS scans (
+		id TEXT PRIMARY KEY,
+		command TEXT NOT NULL,
+		version TEXT NOT NULL,
+		started_at TEXT NOT NULL,
+		ended_at TEXT NOT NULL DEFAULT '',
+		results INTEGER NOT NULL DEFAULT 0
+	)`,
+	`CREATE TABLE IF NOT EXISTS findings (
+		fingerprint TEXT PRIMARY KEY,
+		detector_type TEXT NOT NULL,
+		redacted TEXT NOT NULL,
+		severity TEXT NOT NULL,
+		verified INTEGER NOT NULL,
+		state TEXT NOT NULL DEFAULT 'open',
+		state_reason TEXT NOT NULL DEFAULT '',
+		state_updated_at TEXT NOT NULL DEFAULT '',
+		first_seen TEXT NOT NULL,
+		last_seen TEXT NOT NULL,
+		first_scan TEXT NOT NULL,
+		last_scan TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS occurrences (
+		fingerprint TEXT NOT NULL REFERENCES findings (fingerprint),
+		scan_id TEXT NOT NULL REFERENCES scans (id),
+		source_name TEXT NOT NULL,
+		location TEXT NOT NULL,
+		link TEXT NOT NULL,
+		repository TEXT NOT NULL DEFAULT '',
+		file TEXT NOT NULL DEFAULT '',
+		owners TEXT NOT NULL DEFAULT '',
+		verified INTEGER NOT NULL DEFAULT 0,
+		PRIMARY KEY (fingerprint, scan_id, location)
+	)`,
+	`CREATE INDEX IF NOT EXISTS occurrences_scan ON occurrences (scan_id)`,
+	`CREATE TABLE IF NOT EXISTS tenant_sources (
+		tenant TEXT NOT NULL,
+		name TEXT NOT NULL,
+		source TEXT NOT NULL,
+		PRIMARY KEY (tenant, name)
+	)`,
+	`CREATE TABLE IF NOT EXISTS tenant_scans (
+		tenant TEXT NOT NULL,
+		id TEXT NOT NULL,
+		source TEXT NOT NULL,
+		status TEXT NOT NULL,
+		started_at TEXT NOT NULL,
+		ended_at TEXT NOT NULL DEFAULT '',
+		results INTEGER NOT NULL DEFAULT 0,
+		verified INTEGER NOT NULL DEFAULT 0,
+		error TEXT NOT NULL DEFAULT '',
+		PRIMARY KEY (tenant, id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS tenant_results (
+		tenant TEXT NOT NULL,
+		scan_id TEXT NOT NULL,
+		seq INTEGER NOT NULL,
+		result TEXT NOT NULL,
+		PRIMARY KEY (tenant, scan_id, seq),
+		FOREIGN KEY (tenant, scan_id) REFERENCES tenant_scans (tenant, id)
+	)`,
+}
+
+// migrations add the columns of occurrences added after its table was first created. Each runs once, if its column
+// doesn't exist yet.
+var migrations = []struct {
+	column string
+	stmts  []string
+}{
+	{"repository", []string{`ALTER TABLE occurrences ADD COLUMN repository TEXT NOT NULL DEFAULT ''`}},
+	{"file", []string{`ALTER TABLE occurrences ADD COLUMN file TEXT NOT NULL DEFAULT ''`}},
+	{"owners", []string{`ALTER TABLE occurrences ADD COLUMN owners TEXT NOT NULL DEFAULT ''`}},
+	{"verified", []string{
+		`ALTER TABLE occurrences ADD COLUMN verified INTEGER NOT NULL DEFAULT 0`,
+		// Earlier occurrences take the latest verification of their finding, which is all that was recorded.
+		`UPDATE occurrences SET verified = (SELECT f.verified FROM findings f WHERE f.fingerprint = occurrences.fingerprint)`,
+	}},
+}
+
+// Store is a results database.
+type Store struct {
+	db       *sql.DB
+	postgres bool
+}
+
+// Open opens the database at dsn, creating its tables if they don't exist. A dsn starting with postgres:// or
+// postgresql:// is a Postgres connection URL, and anything else is the path of a SQLite database file.
+func Open(ctx context.Context, dsn string) (*Store, error) {
+	s := &Store{}
+	var err error
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		s.postgres = true
+		s.db, err = sql.Open("postgres", dsn)
+	} else {
+		s.db, err = sql.Open("sqlite", strings.TrimPrefix(dsn, "sqlite://"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.postgres {
+		// SQLite allows one writer at a time, so sharing one connection avoids "database is locked" errors.
+		s.db.SetMaxOpenConns(1)
+		if _, err := s.db.ExecContext(ctx, "PRAGMA foreign_keys = ON"); err != nil {
+			s.db.Close()
+			return nil, fmt.Errorf("could not open results database: %w", err)
+		}
+	}
+	for _, stmt := range schema {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			s.db.Close()
+			return nil, fmt.Errorf("could not create results database tables: %w", err)
+		}
+	}
+	if err := s.migrate(ctx); err != nil {
+		s.db.Close()
+		return nil, fmt.Errorf("could not update results database tables: %w", err)
+	}
+	return s, nil
+}
+
+// migrate adds the columns that are missing from tables created by earlier versions.
+func (s *Store) migrate(ctx context.Context) error {
+	for _, m := range migrations {
+		// Selecting the column fails if it doesn't exist, which works the same in both databases.
+		rows, err := s.db.QueryContext(ctx, `SELECT `+m.column+` FROM occurrences LIMIT 0`)
+		if err == nil {
+			rows.Close()
+			continue
+		}
+		for _, stmt := range m.stmts {
+			if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Scan is a scan run.
+type Scan struct {
+	ID      string
+	Command string
+	Version string
+	Start   time.Time
+	// End is zero while the scan is running, or if it didn't finish.
+	End     time.Time
+	Results int
+}
+
+// StartScan records the start of a scan.
+func (s *Store) StartScan(ctx context.Context, scan *Scan) error {
+	_, err := s.exec(ctx, `INSERT INTO scans (id, command, version, started_at) VALUES (?, ?, ?, ?)`,
+		scan.ID, scan.Command, scan.Version, formatTime(scan.Start))
+	return err
+}
+
+// EndScan records the end of a scan and the number of results it output.
+func (s *Store) EndScan(ctx context.Context, id string, end time.Time, results int) error {
+	_, err := s.exec(ctx, `UPDATE scans SET ended_at = ?, results = ? WHERE id = ?`, formatTime(end), results, id)
+	return err
+}
+
+// Scans returns the most recent scans, newest first.
+func (s *Store) Scans(ctx context.Context, limit int) ([]Scan, error) {
+	rows, err := s.query(ctx, `SELECT id, command, version, started_at, ended_at, results FROM scans
+		ORDER BY started_at DESC LIMIT ?`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scans []Scan
+	for rows.Next() {
+		scan, err := scanScan(rows)
+		if err != nil {
+			return nil, err
+		}
+		scans = append(scans, *scan)
+	}
+	return scans, rows.Err()
+}
+
+// Scan returns a scan by ID.
+func (s *Store) Scan(ctx context.Context, id string) (*Scan, error) {
+	rows, err := s.query(ctx, `SELECT id, command, version, started_at, ended_at, results FROM scans WHERE id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("scan %s: %w", id, ErrNotFound)
+	}
+	return scanScan(rows)
+}
+
+func scanScan(rows *sql.Rows) (*Scan, error) {
+	var scan Scan
+	var start, end string
+	if err := rows.Scan(&scan.ID, &scan.Command, &scan.Version, &start, &end, &scan.Results); err != nil {
+		return nil, err
+	}
+	scan.Start, scan.End = parseTime(start), parseTime(end)
+	return &scan, nil
+}
+
+// Finding is a secret in the store, with its triage state.
+type Finding struct {
+	Fingerprint  string
+	DetectorType string
+	Redacted     string
+	Severity     detectors.Severity
+	Verified     bool
+	State        State
+	// StateReason is the note given when the state was last changed.
+	StateReason    string
+	StateUpdatedAt time.Time
+	FirstSeen      time.Time
+	LastSeen       time.Time
+	FirstScan      string
+	LastScan       string
+}
+
+// AddFinding records that the scan found the finding. The raw secret isn't stored, only its fingerprint.
+func (s *Store) AddFinding(ctx context.Context, scanID string, f *findings.Finding, now time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	fingerprint := f.Fingerprint()
+	seen := formatTime(now)
+	verified := 0
+	if f.Verified {
+		verified = 1
+	}
+	if _, err := tx.ExecContext(ctx, s.rebind(`INSERT INTO findings
+		(fingerprint, detector_type, redacted, severity, verified, first_seen, last_seen, first_scan, last_scan)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (fingerprint) DO UPDATE SET
+			redacted = excluded.redacted,
+			severity = excluded.severity,
+			verified = excluded.verified,
+			last_seen = excluded.last_seen,
+			last_scan = excluded.last_scan`),
+		fingerprint, f.DetectorType, f.Redacted, f.Severity.String(), verified, seen, seen, scanID, scanID); err != nil {
+		return err
+	}
+
+	link, _ := f.Metadata["link"].(string)
+	repository, _ := f.Metadata["repository"].(string)
+	file, _ := f.Metadata["file"].(string)
+	if _, err := tx.ExecContext(ctx, s.rebind(`INSERT INTO occurrences
+		(fingerprint, scan_id, source_name, location, link, repository, file, owners, verified)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT DO NOTHING`),
+		fingerprint, scanID, f.SourceName, f.Location(), link, repository, file, f.ExtraData["codeowners"], verified); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+const findingColumns = `f.fingerprint, f.detector_type, f.redacted, f.severity, f.verified, f.state, f.state_reason,
+	f.state_updated_at, f.first_seen, f.last_seen, f.first_scan, f.last_scan`
+
+// ScanFindings returns the findings found by a scan.
+func (s *Store) ScanFindings(ctx context.Context, scanID string) ([]Finding, error) {
+	return s.findings(ctx, `SELECT `+findingColumns+` FROM findings f WHERE f.fingerprint IN
+		(SELECT fingerprint FROM occurrences WHERE scan_id = ?) ORDER BY f.first_seen, f.fingerprint`, scanID)
+}
+
+// SetState changes the triage state of a finding, noting the reason.
+func (s *Store) SetState(ctx context.Context, fingerprint string, state State, reason string, now time.Time) error {
+	res, err := s.exec(ctx, `UPDATE findings SET state = ?, state_reason = ?, state_updated_at = ? WHERE fingerprint = ?`,
+		string(state), reason, formatTime(now), fingerprint)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("finding %s: %w", fingerprint, ErrNotFound)
+	}
+	return nil
+}
+
+// FindingFilter selects findings. Zero fields match everything.
+type FindingFilter struct {
+	State State
+	// DetectorTypes are detector type names, matched case insensitively.
+	DetectorTypes []string
+	Limit         int
+}
+
+// Findings returns the findings matching the filter, most recently seen first.
+func (s *Store) Findings(ctx context.Context, filter FindingFilter) ([]Finding, error) {
+	query := `SELECT ` + findingColumns + ` FROM findings f WHERE 1 = 1`
+	var args []interface{}
+	if filter.State != "" {
+		query += ` AND f.state = ?`
+		args = append(args, string(filter.State))
+	}
+	if len(filter.DetectorTypes) > 0 {
+		query += ` AND LOWER(f.detector_type) IN (?` + strings.Repeat(", ?", len(filter.DetectorTypes)-1) + `)`
+		for _, detectorType := range filter.DetectorTypes {
+			args = append(args, strings.ToLower(detectorType))
+		}
+	}
+	query += ` ORDER BY f.last_seen DESC, f.fingerprint`
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+	return s.findings(ctx, query, args...)
+}
+
+// Finding returns the finding with a fingerprint, which may be abbreviated to a unique prefix.
+func (s *Store) Finding(ctx context.Context, fingerprint string) (*Finding, error) {
+	prefix := strings.ToLower(fingerprint)
+	// Fingerprints are hex, which also keeps LIKE wildcards out of the prefix.
+	if prefix == "" || strings.Trim(prefix, "0123456789abcdef") != "" {
+		return nil, fmt.Errorf("finding %s: %w", fingerprint, ErrNotFound)
+	}
+	findings, err := s.findings(ctx, `SELECT `+findingColumns+` FROM findings f WHERE f.fingerprint LIKE ? LIMIT 2`,
+		prefix+"%")
+	if err != nil {
+		return nil, err
+	}
+	switch len(findings) {
+	case 0:
+		return nil, fmt.Errorf("finding %s: %w", fingerprint, ErrNotFound)
+	case 1:
+		return &findings[0], nil
+	default:
+		return nil, fmt.Errorf("fingerprint %s is ambiguous, give more of it", fingerprint)
+	}
+}
+
+// Occurrence is a place a finding was found in a scan.
+type Occurrence struct {
+	ScanID     string
+	SourceName string
+	Location   string
+	Link       string
+	// Repository and File are empty for sources without them.
+	Repository string `json:",omitempty"`
+	File       string `json:",omitempty"`
+	// Owners are the code owners of the file, from the repository's CODEOWNERS file.
+	Owners []string `json:",omitempty"`
+	// Verified is whether the secret was verified by the scan.
+	Verified bool
+}
+
+const occurrenceColumns = `o.scan_id, o.source_name, o.location, o.link, o.repository, o.file, o.owners, o.verified`
+
+func scanOccurrence(rows *sql.Rows, o *Occurrence, dest ...interface{}) error {
+	var owners string
+	var verified int
+	dest = append(dest, &o.ScanID, &o.SourceName, &o.Location, &o.Link, &o.Repository, &o.File, &owners, &verified)
+	if err := rows.Scan(dest...); err != nil {
+		return err
+	}
+	o.Owners = strings.Fields(owners)
+	o.Verified = verified != 0
+	return nil
+}
+
+// Occurrences returns the places a finding was found, in the order of the scans that found them.
+func (s *Store) Occurrences(ctx context.Context, fingerprint string) ([]Occurrence, error) {
+	rows, err := s.query(ctx, `SELECT `+occurrenceColumns+` FROM occurrences o
+		JOIN scans s ON s.id = o.scan_id WHERE o.fingerprint = ? ORDER BY s.started_at, o.location`, fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var occurrences []Occurrence
+	for rows.Next() {
+		var o Occurrence
+		if err := scanOccurrence(rows, &o); err != nil {
+			return nil, err
+		}
+		occurrences = append(occurrences, o)
+	}
+	return occurrences, rows.Err()
+}
+
+// Sighting is an occurrence of a finding, with the finding and when its scan started, for reporting on findings over
+// time.
+type Sighting struct {
+	Fingerprint  string
+	DetectorType string
+	State        State
+	Seen         time.Time
+	Occurrence
+}
+
+// Sightings returns the occurrences of findings in the scans started at or after since, in the order of the scans.
+func (s *Store) Sightings(ctx context.Context, since time.Time) ([]Sighting, error) {
+	rows, err := s.query(ctx, `SELECT f.fingerprint, f.detector_type, f.state, s.started_at, `+occurrenceColumns+`
+		FROM occurrences o JOIN findings f ON f.fingerprint = o.fingerprint JOIN scans s ON s.id = o.scan_id
+		WHERE s.started_at >= ? ORDER BY s.started_at, f.fingerprint, o.location`, formatTime(since))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sightings []Sighting
+	for rows.Next() {
+		var sighting Sighting
+		var state, seen string
+		if err := scanOccurrence(rows, &sighting.Occurrence, &sighting.Fingerprint, &sighting.DetectorType, &state, &seen); err != nil {
+			return nil, err
+		}
+		sighting.State, sighting.Seen = State(state), parseTime(seen)
+		sightings = append(sightings, sighting)
+	}
+	return sightings, rows.Err()
+}
+
+// Suppressed returns the fingerprints of findings that were triaged as false positives or resolved, with their
+// states. Scans don't report them again.
+func (s *Store) Suppressed(ctx context.Context) (map[string]State, error) {
+	rows, err := s.query(ctx, `SELECT fingerprint, state FROM findings WHERE state IN (?, ?)`,
+		string(StateFalsePositive), string(StateResolved))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suppressed := map[string]State{}
+	for rows.Next() {
+		var fingerprint, state string
+		if err := rows.Scan(&fingerprint, &state); err != nil {
+			return nil, err
+		}
+		suppressed[fingerprint] = State(state)
+	}
+	return suppressed, rows.Err()
+}
+
+// PurgeResolved deletes the findings that were resolved before the time, with their occurrences, and returns how many
+// were deleted. If export is set, it's called with each finding and its occurrences before it's deleted. An error
+// from export stops the purge, so a finding is never deleted without having been exported. A purged finding that's
+// found again is recorded as a new, open finding.
+func (s *Store) PurgeResolved(ctx context.Context, before time.Time, export func(*Finding, []Occurrence) error) (int, error) {
+	findings, err := s.findings(ctx, `SELECT `+findingColumns+` FROM findings f
+		WHERE f.state = ? AND f.state_updated_at != '' AND f.state_updated_at < ? ORDER BY f.state_updated_at`,
+		string(StateResolved), formatTime(before))
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for i := range findings {
+		f := &findings[i]
+		if export != nil {
+			occurrences, err := s.Occurrences(ctx, f.Fingerprint)
+			if err != nil {
+				return purged, err
+			}
+			if err := export(f, occurrences); err != nil {
+				return purged, fmt.Errorf("could not export finding %s: %w", f.Fingerprint, err)
+			}
+		}
+		if err := s.deleteFinding(ctx, f.Fingerprint); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+func (s *Store) deleteFinding(ctx context.Context, fingerprint string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, s.rebind(`DELETE FROM occurrences WHERE fingerprint = ?`), fingerprint); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, s.rebind(`DELETE FROM findings WHERE fingerprint = ?`), fingerprint); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *Store) findings(ctx context.Context, query string, args ...interface{}) ([]Finding, error) {
+	rows, err := s.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var findings []Finding
+	for rows.Next() {
+		var f Finding
+		var severity, state, stateUpdated, firstSeen, lastSeen string
+		var verified int
+		if err := rows.Scan(&f.Fingerprint, &f.DetectorType, &f.Redacted, &severity, &verified, &state, &f.StateReason,
+			&stateUpdated, &firstSeen, &lastSeen, &f.FirstScan, &f.LastScan); err != nil {
+			return nil, err
+		}
+		f.Severity, _ = detectors.ParseSeverity(severity)
+		f.Verified = verified != 0
+		f.State = State(state)
+		f.StateUpdatedAt, f.FirstSeen, f.LastSeen = parseTime(stateUpdated), parseTime(firstSeen), parseTime(lastSeen)
+		findings = append(findings, f)
+	}
+	return findings, rows.Err()
+}
+
+func (s *Store) exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return s.db.ExecContext(ctx, s.rebind(query), args...)
+}
+
+func (s *Store) query(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return s.db.QueryContext(ctx, s.rebind(query), args...)
+}
+
+// rebind replaces the ? placeholders in a query with Postgres' $1, $2, and so on.
+func (s *Store) rebind(query string) string {
+	if !s.postgres {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// timeLayout is RFC 3339 with fixed width fractional seconds. Times are stored as text in UTC in this layout, which
+// sorts chronologically in both databases.
+const timeLayout = "2006-01-02T15:04:05.000000Z07:00"
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(timeLayout)
+}
+
+func parseTime(s string) time.Time {
+	t, _ := time.Parse(timeLayout, s)
+	return t
+}
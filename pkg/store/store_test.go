@@ -0,0 +1,407 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(context.Background(), filepath.Join(t.TempDir(), "results.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func testFinding(raw, file string) *findings.Finding {
+	return &findings.Finding{
+		DetectorType: "AWS",
+		Raw:          raw,
+		Redacted:     "AKIA...",
+		Severity:     detectors.SeverityHigh,
+		SourceName:   "trufflehog - git",
+		Metadata:     map[string]interface{}{"file": file, "link": "https://github.com/org/repo/blob/abc/" + file},
+	}
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if err := s.StartScan(ctx, &Scan{ID: "first", Command: "git", Version: "dev", Start: start}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddFinding(ctx, "first", testFinding("AKIAONE", "a.sh"), start); err != nil {
+		t.Fatal(err)
+	}
+	// The same secret in another file is the same finding.
+	if err := s.AddFinding(ctx, "first", testFinding("AKIAONE", "b.sh"), start); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.EndScan(ctx, "first", start.Add(time.Minute), 2); err != nil {
+		t.Fatal(err)
+	}
+
+	later := start.Add(time.Hour)
+	if err := s.StartScan(ctx, &Scan{ID: "second", Command: "git", Version: "dev", Start: later}); err != nil {
+		t.Fatal(err)
+	}
+	verified := testFinding("AKIAONE", "a.sh")
+	verified.Verified = true
+	for _, f := range []*findings.Finding{verified, testFinding("AKIATWO", "c.sh")} {
+		if err := s.AddFinding(ctx, "second", f, later); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scans, err := s.Scans(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scans) != 2 || scans[0].ID != "second" || !scans[0].End.IsZero() {
+		t.Fatalf("unexpected scans: %+v", scans)
+	}
+	if scans[1].Results != 2 || !scans[1].End.Equal(start.Add(time.Minute)) {
+		t.Errorf("the end of the first scan wasn't recorded: %+v", scans[1])
+	}
+
+	first, err := s.ScanFindings(ctx, "first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != 1 {
+		t.Fatalf("expected one finding in the first scan, got %d", len(first))
+	}
+	second, err := s.ScanFindings(ctx, "second")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(second) != 2 {
+		t.Fatalf("expected two findings in the second scan, got %d", len(second))
+	}
+	f := second[0]
+	if !f.Verified || f.State != StateOpen || f.FirstScan != "first" || f.LastScan != "second" || !f.LastSeen.Equal(later) {
+		t.Errorf("finding wasn't updated by the second scan: %+v", f)
+	}
+
+	if err := s.SetState(ctx, f.Fingerprint, StateFalsePositive, "test key", later); err != nil {
+		t.Fatal(err)
+	}
+	second, _ = s.ScanFindings(ctx, "second")
+	if second[0].State != StateFalsePositive || second[0].StateReason != "test key" {
+		t.Errorf("state wasn't changed: %+v", second[0])
+	}
+	if err := s.SetState(ctx, "missing", StateResolved, "", later); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := s.Scan(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestTriage(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if err := s.StartScan(ctx, &Scan{ID: "scan", Command: "git", Version: "dev", Start: now}); err != nil {
+		t.Fatal(err)
+	}
+	one, two := testFinding("AKIAONE", "a.sh"), testFinding("AKIATWO", "b.sh")
+	for _, f := range []*findings.Finding{one, testFinding("AKIAONE", "c.sh"), two} {
+		if err := s.AddFinding(ctx, "scan", f, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	found, err := s.Finding(ctx, one.Fingerprint()[:10])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Fingerprint != one.Fingerprint() {
+		t.Errorf("found the wrong finding: %+v", found)
+	}
+	for _, invalid := range []string{"", "%", "zz"} {
+		if _, err := s.Finding(ctx, invalid); !errors.Is(err, ErrNotFound) {
+			t.Errorf("%q: expected ErrNotFound, got %v", invalid, err)
+		}
+	}
+	occurrences, err := s.Occurrences(ctx, one.Fingerprint())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(occurrences) != 2 || occurrences[0].Location != "a.sh" || occurrences[1].Link == "" {
+		t.Errorf("unexpected occurrences: %+v", occurrences)
+	}
+
+	if err := s.SetState(ctx, one.Fingerprint(), StateResolved, "rotated", now); err != nil {
+		t.Fatal(err)
+	}
+	suppressed, err := s.Suppressed(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(suppressed) != 1 || suppressed[one.Fingerprint()] != StateResolved {
+		t.Errorf("unexpected suppressed findings: %v", suppressed)
+	}
+
+	open, err := s.Findings(ctx, FindingFilter{State: StateOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(open) != 1 || open[0].Fingerprint != two.Fingerprint() {
+		t.Errorf("unexpected open findings: %+v", open)
+	}
+	all, err := s.Findings(ctx, FindingFilter{DetectorTypes: []string{"aws", "github"}, Limit: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 {
+		t.Errorf("expected two AWS findings, got %d", len(all))
+	}
+}
+
+func TestPurgeResolved(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if err := s.StartScan(ctx, &Scan{ID: "scan", Command: "git", Version: "dev", Start: now}); err != nil {
+		t.Fatal(err)
+	}
+	old, recent, open := testFinding("AKIAOLD", "a.sh"), testFinding("AKIARECENT", "b.sh"), testFinding("AKIAOPEN", "c.sh")
+	for _, f := range []*findings.Finding{old, recent, open} {
+		if err := s.AddFinding(ctx, "scan", f, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.SetState(ctx, old.Fingerprint(), StateResolved, "rotated", now); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetState(ctx, recent.Fingerprint(), StateResolved, "rotated", now.Add(48*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	cutoff := now.Add(24 * time.Hour)
+	if _, err := s.PurgeResolved(ctx, cutoff, func(*Finding, []Occurrence) error { return errors.New("full") }); err == nil {
+		t.Error("expected the export error to stop the purge")
+	}
+	if _, err := s.Finding(ctx, old.Fingerprint()); err != nil {
+		t.Errorf("a finding that failed to export was purged: %v", err)
+	}
+
+	var exported []string
+	purged, err := s.PurgeResolved(ctx, cutoff, func(f *Finding, occurrences []Occurrence) error {
+		if len(occurrences) != 1 || occurrences[0].Location != "a.sh" {
+			t.Errorf("unexpected occurrences: %+v", occurrences)
+		}
+		exported = append(exported, f.Fingerprint)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if purged != 1 || len(exported) != 1 || exported[0] != old.Fingerprint() {
+		t.Errorf("expected only the finding resolved before the cutoff to be purged, got %d and %v", purged, exported)
+	}
+	if _, err := s.Finding(ctx, old.Fingerprint()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected the purged finding to be gone, got %v", err)
+	}
+	results, err := s.ScanFindings(ctx, "scan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Errorf("expected the other findings to be kept, got %+v", results)
+	}
+}
+
+func TestSightings(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for i, id := range []string{"first", "second"} {
+		if err := s.StartScan(ctx, &Scan{ID: id, Command: "git", Version: "dev", Start: start.AddDate(0, 0, 7*i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	owned := testFinding("AKIAONE", "deploy/prod.env")
+	owned.Metadata["repository"] = "https://github.com/org/repo.git"
+	owned.ExtraData = map[string]string{"codeowners": "@org/platform @alice"}
+	if err := s.AddFinding(ctx, "first", owned, start); err != nil {
+		t.Fatal(err)
+	}
+	verified := testFinding("AKIAONE", "deploy/prod.env")
+	verified.Verified = true
+	for _, f := range []*findings.Finding{verified, testFinding("AKIATWO", "b.sh")} {
+		if err := s.AddFinding(ctx, "second", f, start.AddDate(0, 0, 7)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sightings, err := s.Sightings(ctx, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sightings) != 3 {
+		t.Fatalf("expected 3 sightings, got %+v", sightings)
+	}
+	first := sightings[0]
+	if first.ScanID != "first" || !first.Seen.Equal(start) || first.DetectorType != "AWS" || first.State != StateOpen ||
+		first.Repository != "https://github.com/org/repo.git" || first.File != "deploy/prod.env" ||
+		len(first.Owners) != 2 || first.Owners[0] != "@org/platform" || first.Verified {
+		t.Errorf("unexpected sighting: %+v", first)
+	}
+	// Each occurrence keeps its own verification, so the finding was only verified by the second scan.
+	for _, sighting := range sightings[1:] {
+		if sighting.ScanID != "second" || sighting.Verified != (sighting.Fingerprint == owned.Fingerprint()) {
+			t.Errorf("unexpected sighting: %+v", sighting)
+		}
+	}
+
+	recent, err := s.Sightings(ctx, start.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recent) != 2 || recent[0].ScanID != "second" {
+		t.Errorf("expected the sightings of the second scan, got %+v", recent)
+	}
+}
+
+func TestTenants(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if err := s.PutTenantSource(ctx, "a", "repo", json.RawMessage(`{"type":"git"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PutTenantSource(ctx, "a", "repo", json.RawMessage(`{"type":"github"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if source, err := s.TenantSource(ctx, "a", "repo"); err != nil || string(source) != `{"type":"github"}` {
+		t.Errorf("expected the source to be replaced, got %s and %v", source, err)
+	}
+	if _, err := s.TenantSource(ctx, "b", "repo"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected another tenant's source to be missing, got %v", err)
+	}
+	if err := s.DeleteTenantSource(ctx, "b", "repo"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected another tenant's source not to be deleted, got %v", err)
+	}
+
+	for i, tenant := range []string{"a", "a", "b"} {
+		scan := &TenantScan{Tenant: tenant, ID: fmt.Sprintf("scan-%d", i), Source: "repo", Status: "running", Start: start.Add(time.Duration(i) * time.Hour)}
+		if err := s.StartTenantScan(ctx, scan); err != nil {
+			t.Fatal(err)
+		}
+		if i == 2 {
+			continue
+		}
+		scan.Status, scan.End, scan.Results = "succeeded", scan.Start.Add(time.Minute), 1
+		if err := s.EndTenantScan(ctx, scan, []json.RawMessage{json.RawMessage(`{"n":1}`)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scans, err := s.TenantScans(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scans) != 2 || scans[0].ID != "scan-1" || scans[0].Results != 1 || !scans[0].End.Equal(start.Add(time.Hour+time.Minute)) {
+		t.Errorf("expected tenant a's scans, newest first, got %+v", scans)
+	}
+	if _, err := s.TenantScan(ctx, "b", "scan-0"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected another tenant's scan to be missing, got %v", err)
+	}
+	if results, err := s.TenantScanResults(ctx, "b", "scan-0"); err != nil || len(results) != 0 {
+		t.Errorf("expected no results for another tenant's scan, got %s and %v", results, err)
+	}
+
+	// Only finished scans expire, by age or beyond the newest of their tenant.
+	expired, err := s.ExpiredTenantScans(ctx, start.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expired) != 1 || expired[0].ID != "scan-0" {
+		t.Errorf("expected the oldest scan to expire, got %+v", expired)
+	}
+	if expired, err = s.ExpiredTenantScans(ctx, time.Time{}, 0); err != nil || len(expired) != 2 {
+		t.Errorf("expected the finished scans beyond the newest 0 to expire, got %+v and %v", expired, err)
+	}
+
+	if n, err := s.EndRunningTenantScans(ctx, "failed", "interrupted", start.Add(3*time.Hour)); err != nil || n != 1 {
+		t.Errorf("expected one running scan to be ended, got %d and %v", n, err)
+	}
+	if err := s.DeleteTenantScan(ctx, "a", "scan-0"); err != nil {
+		t.Fatal(err)
+	}
+	if results, err := s.TenantScanResults(ctx, "a", "scan-0"); err != nil || len(results) != 0 {
+		t.Errorf("expected the deleted scan's results to be deleted, got %s and %v", results, err)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "results.db")
+	s, err := Open(ctx, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Recreate the occurrences table as it was before it had the columns of migrations.
+	for _, stmt := range []string{
+		`DROP TABLE occurrences`,
+		`CREATE TABLE occurrences (
+			fingerprint TEXT NOT NULL REFERENCES findings (fingerprint),
+			scan_id TEXT NOT NULL REFERENCES scans (id),
+			source_name TEXT NOT NULL,
+			location TEXT NOT NULL,
+			link TEXT NOT NULL,
+			PRIMARY KEY (fingerprint, scan_id, location)
+		)`,
+		`INSERT INTO scans (id, command, version, started_at) VALUES ('old', 'git', 'dev', '2024-01-02T03:04:05.000000Z')`,
+		`INSERT INTO findings (fingerprint, detector_type, redacted, severity, verified, first_seen, last_seen, first_scan, last_scan)
+			VALUES ('abc', 'AWS', 'AKIA...', 'high', 1, '', '', 'old', 'old')`,
+		`INSERT INTO occurrences VALUES ('abc', 'old', 'trufflehog - git', 'a.sh', '')`,
+	} {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.Close()
+
+	s, err = Open(ctx, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	occurrences, err := s.Occurrences(ctx, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(occurrences) != 1 || !occurrences[0].Verified || occurrences[0].Repository != "" {
+		t.Errorf("expected the occurrence to take its finding's verification, got %+v", occurrences)
+	}
+	// Opening a migrated database doesn't change it again.
+	if err := s.migrate(ctx); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRebind(t *testing.T) {
+	s := &Store{postgres: true}
+	if got := s.rebind("SELECT a FROM b WHERE c = ? AND d = ?"); got != "SELECT a FROM b WHERE c = $1 AND d = $2" {
+		t.Errorf("unexpected query: %s", got)
+	}
+}
@@ -0,0 +1,194 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Every query of the tenant tables is scoped to a tenant, so nothing one tenant saved is reachable with another's
+// name. Sources and results are stored as the JSON the server reads and serves. Results are only stored once their raw
+// secrets have been replaced with their fingerprints, but sources hold the credentials they're scanned with.
+
+// TenantScan is a scan started by a tenant through the server's API.
+type TenantScan struct {
+	Tenant string
+	ID     string
+	Source string
+	Status string
+	Start  time.Time
+	// End is zero while the scan is running.
+	End      time.Time
+	Results  int
+	Verified int
+	Error    string
+}
+
+// PutTenantSource creates or replaces a tenant's source.
+func (s *Store) PutTenantSource(ctx context.Context, tenant, name string, source json.RawMessage) error {
+	_, err := s.exec(ctx, `INSERT INTO tenant_sources (tenant, name, source) VALUES (?, ?, ?)
+		ON CONFLICT (tenant, name) DO UPDATE SET source = excluded.source`, tenant, name, string(source))
+	return err
+}
+
+// TenantSource returns a tenant's source by name.
+func (s *Store) TenantSource(ctx context.Context, tenant, name string) (json.RawMessage, error) {
+	var source string
+	err := s.db.QueryRowContext(ctx, s.rebind(`SELECT source FROM tenant_sources WHERE tenant = ? AND name = ?`),
+		tenant, name).Scan(&source)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("source %s: %w", name, ErrNotFound)
+	}
+	return json.RawMessage(source), err
+}
+
+// TenantSources returns a tenant's sources, ordered by name.
+func (s *Store) TenantSources(ctx context.Context, tenant string) ([]json.RawMessage, error) {
+	return s.rawMessages(ctx, `SELECT source FROM tenant_sources WHERE tenant = ? ORDER BY name`, tenant)
+}
+
+// DeleteTenantSource deletes a tenant's source. Its scans are kept.
+func (s *Store) DeleteTenantSource(ctx context.Context, tenant, name string) error {
+	res, err := s.exec(ctx, `DELETE FROM tenant_sources WHERE tenant = ? AND name = ?`, tenant, name)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("source %s: %w", name, ErrNotFound)
+	}
+	return nil
+}
+
+// StartTenantScan records the start of a tenant's scan.
+func (s *Store) StartTenantScan(ctx context.Context, scan *TenantScan) error {
+	_, err := s.exec(ctx, `INSERT INTO tenant_scans (tenant, id, source, status, started_at) VALUES (?, ?, ?, ?, ?)`,
+		scan.Tenant, scan.ID, scan.Source, scan.Status, formatTime(scan.Start))
+	return err
+}
+
+// EndTenantScan records the end of a tenant's scan, with its status and results.
+func (s *Store) EndTenantScan(ctx context.Context, scan *TenantScan, results []json.RawMessage) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, s.rebind(`UPDATE tenant_scans SET status = ?, ended_at = ?, results = ?,
+		verified = ?, error = ? WHERE tenant = ? AND id = ?`),
+		scan.Status, formatTime(scan.End), scan.Results, scan.Verified, scan.Error, scan.Tenant, scan.ID); err != nil {
+		return err
+	}
+	for i, result := range results {
+		if _, err := tx.ExecContext(ctx, s.rebind(`INSERT INTO tenant_results (tenant, scan_id, seq, result)
+			VALUES (?, ?, ?, ?)`), scan.Tenant, scan.ID, i, string(result)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// EndRunningTenantScans ends the scans of every tenant that are still running with the status and error, and returns
+// how many there were. It's used on startup, for the scans that were running when the server stopped.
+func (s *Store) EndRunningTenantScans(ctx context.Context, status, msg string, end time.Time) (int, error) {
+	res, err := s.exec(ctx, `UPDATE tenant_scans SET status = ?, error = ?, ended_at = ? WHERE ended_at = ''`,
+		status, msg, formatTime(end))
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+const tenantScanColumns = `tenant, id, source, status, started_at, ended_at, results, verified, error`
+
+// TenantScan returns a tenant's scan by ID.
+func (s *Store) TenantScan(ctx context.Context, tenant, id string) (*TenantScan, error) {
+	scans, err := s.tenantScans(ctx, `SELECT `+tenantScanColumns+` FROM tenant_scans WHERE tenant = ? AND id = ?`,
+		tenant, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(scans) == 0 {
+		return nil, fmt.Errorf("scan %s: %w", id, ErrNotFound)
+	}
+	return &scans[0], nil
+}
+
+// TenantScans returns a tenant's scans, newest first.
+func (s *Store) TenantScans(ctx context.Context, tenant string) ([]TenantScan, error) {
+	return s.tenantScans(ctx, `SELECT `+tenantScanColumns+` FROM tenant_scans WHERE tenant = ?
+		ORDER BY started_at DESC, id`, tenant)
+}
+
+// ExpiredTenantScans returns the finished scans of every tenant that ended before the time, if it's not zero, or
+// that are beyond the newest keep scans of their tenant, oldest first.
+func (s *Store) ExpiredTenantScans(ctx context.Context, before time.Time, keep int) ([]TenantScan, error) {
+	return s.tenantScans(ctx, `SELECT `+tenantScanColumns+` FROM (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY tenant ORDER BY started_at DESC, id) AS n FROM tenant_scans
+		) ranked WHERE ended_at != '' AND (n > ? OR (? != '' AND ended_at < ?)) ORDER BY started_at, id`,
+		keep, formatTime(before), formatTime(before))
+}
+
+// TenantScanResults returns the results of a tenant's scan, in the order they were found.
+func (s *Store) TenantScanResults(ctx context.Context, tenant, id string) ([]json.RawMessage, error) {
+	return s.rawMessages(ctx, `SELECT result FROM tenant_results WHERE tenant = ? AND scan_id = ? ORDER BY seq`,
+		tenant, id)
+}
+
+// DeleteTenantScan deletes a tenant's scan and its results.
+func (s *Store) DeleteTenantScan(ctx context.Context, tenant, id string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, s.rebind(`DELETE FROM tenant_results WHERE tenant = ? AND scan_id = ?`), tenant, id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, s.rebind(`DELETE FROM tenant_scans WHERE tenant = ? AND id = ?`), tenant, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *Store) tenantScans(ctx context.Context, query string, args ...interface{}) ([]TenantScan, error) {
+	rows, err := s.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scans []TenantScan
+	for rows.Next() {
+		var scan TenantScan
+		var start, end string
+		if err := rows.Scan(&scan.Tenant, &scan.ID, &scan.Source, &scan.Status, &start, &end, &scan.Results,
+			&scan.Verified, &scan.Error); err != nil {
+			return nil, err
+		}
+		scan.Start, scan.End = parseTime(start), parseTime(end)
+		scans = append(scans, scan)
+	}
+	return scans, rows.Err()
+}
+
+func (s *Store) rawMessages(ctx context.Context, query string, args ...interface{}) ([]json.RawMessage, error) {
+	rows, err := s.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []json.RawMessage
+	for rows.Next() {
+		var message string
+		if err := rows.Scan(&message); err != nil {
+			return nil, err
+		}
+		messages = append(messages, json.RawMessage(message))
+	}
+	return messages, rows.Err()
+}
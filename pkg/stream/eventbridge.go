@@ -0,0 +1,134 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/output"
+)
+
+const (
+	// maxEventBridgeEntries is the most events PutEvents accepts at once.
+	maxEventBridgeEntries = 10
+	// maxEventBridgeSize is the most bytes of events PutEvents accepts at once.
+	maxEventBridgeSize = 256 * 1024
+	// eventSource and eventDetailType identify events from trufflehog in EventBridge rules.
+	eventSource     = "trufflehog"
+	eventDetailType = "TruffleHog Finding"
+)
+
+// EventBridgeConfig configures an EventBridgeSink.
+type EventBridgeConfig struct {
+	// Bus is the name or ARN of the event bus. Empty uses the account's default bus.
+	Bus string
+	// Region is the region of the bus. Empty uses the SDK's default, such as $AWS_REGION.
+	Region string
+	// Source is the source of the events, which defaults to "trufflehog".
+	Source string
+}
+
+// EventBridgeSink sends each result as an event to an Amazon EventBridge bus, so that rules can route findings to
+// automation such as Lambda functions that rotate keys. The event's detail is the result as in --json output, and its
+// detail type is "TruffleHog Finding". Events are sent in batches of 10, and Flush returns once every event has been
+// accepted.
+type EventBridgeSink struct {
+	config  EventBridgeConfig
+	client  eventbridgeiface.EventBridgeAPI
+	entries []*eventbridge.PutEventsRequestEntry
+	size    int
+}
+
+func newEventBridgeSink(options map[string]string) (output.Sink, error) {
+	if err := output.CheckSinkOptions(options, "bus", "region", "source"); err != nil {
+		return nil, err
+	}
+	return NewEventBridgeSink(EventBridgeConfig{Bus: options["bus"], Region: options["region"], Source: options["source"]}, nil)
+}
+
+// NewEventBridgeSink returns an EventBridgeSink that sends events with client, or with a client that authenticates
+// with the SDK's default credential chain if it's nil.
+func NewEventBridgeSink(config EventBridgeConfig, client eventbridgeiface.EventBridgeAPI) (*EventBridgeSink, error) {
+	if config.Source == "" {
+		config.Source = eventSource
+	}
+	if client == nil {
+		cfg := aws.NewConfig()
+		if config.Region != "" {
+			cfg.Region = aws.String(config.Region)
+		}
+		sess, err := session.NewSessionWithOptions(session.Options{
+			SharedConfigState: session.SharedConfigEnable,
+			Config:            *cfg,
+		})
+		if err != nil {
+			return nil, err
+		}
+		client = eventbridge.New(sess)
+	}
+	return &EventBridgeSink{config: config, client: client}, nil
+}
+
+func (s *EventBridgeSink) Start(context.Context) error { return nil }
+
+// Write adds an event for the result to the batch, and sends the batch once it's full.
+func (s *EventBridgeSink) Write(ctx context.Context, r *detectors.ResultWithMetadata) error {
+	detail, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	entry := &eventbridge.PutEventsRequestEntry{
+		Source:     aws.String(s.config.Source),
+		DetailType: aws.String(eventDetailType),
+		Detail:     aws.String(string(detail)),
+	}
+	if s.config.Bus != "" {
+		entry.EventBusName = aws.String(s.config.Bus)
+	}
+	// EventBridge counts an entry's size as the sum of its fields.
+	size := len(s.config.Source) + len(eventDetailType) + len(detail)
+	if size > maxEventBridgeSize {
+		return fmt.Errorf("result is %d bytes, more than EventBridge's limit of %d", size, maxEventBridgeSize)
+	}
+	if s.size+size > maxEventBridgeSize {
+		if err := s.Flush(ctx); err != nil {
+			return err
+		}
+	}
+	s.entries = append(s.entries, entry)
+	s.size += size
+	if len(s.entries) == maxEventBridgeEntries {
+		return s.Flush(ctx)
+	}
+	return nil
+}
+
+// Flush sends the batch.
+func (s *EventBridgeSink) Flush(ctx context.Context) error {
+	if len(s.entries) == 0 {
+		return nil
+	}
+	out, err := s.client.PutEventsWithContext(ctx, &eventbridge.PutEventsInput{Entries: s.entries})
+	if err != nil {
+		return fmt.Errorf("could not send events: %w", err)
+	}
+	if failed := aws.Int64Value(out.FailedEntryCount); failed > 0 {
+		for _, entry := range out.Entries {
+			if entry != nil && entry.ErrorCode != nil {
+				return fmt.Errorf("EventBridge rejected %d of %d events, the first with %s: %s", failed, len(s.entries),
+					aws.StringValue(entry.ErrorCode), aws.StringValue(entry.ErrorMessage))
+			}
+		}
+		return fmt.Errorf("EventBridge rejected %d of %d events", failed, len(s.entries))
+	}
+	s.entries, s.size = nil, 0
+	return nil
+}
+
+func (s *EventBridgeSink) Close() error { return nil }
@@ -0,0 +1,73 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+type fakeEventBridge struct {
+	eventbridgeiface.EventBridgeAPI
+	batches [][]*eventbridge.PutEventsRequestEntry
+	// reject fails the events of results from this source.
+	reject string
+}
+
+func (f *fakeEventBridge) PutEventsWithContext(_ aws.Context, in *eventbridge.PutEventsInput, _ ...request.Option) (*eventbridge.PutEventsOutput, error) {
+	f.batches = append(f.batches, in.Entries)
+	out := &eventbridge.PutEventsOutput{FailedEntryCount: aws.Int64(0)}
+	for _, entry := range in.Entries {
+		var r detectors.ResultWithMetadata
+		if err := json.Unmarshal([]byte(aws.StringValue(entry.Detail)), &r); err != nil {
+			return nil, err
+		}
+		if r.SourceName == f.reject {
+			*out.FailedEntryCount++
+			out.Entries = append(out.Entries, &eventbridge.PutEventsResultEntry{
+				ErrorCode: aws.String("InternalFailure"), ErrorMessage: aws.String("rejected"),
+			})
+			continue
+		}
+		out.Entries = append(out.Entries, &eventbridge.PutEventsResultEntry{EventId: aws.String("id")})
+	}
+	return out, nil
+}
+
+func TestEventBridgeSink(t *testing.T) {
+	client := &fakeEventBridge{reject: "rejected"}
+	sink, err := NewEventBridgeSink(EventBridgeConfig{Bus: "security"}, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	for i := 0; i < 12; i++ {
+		if err := sink.Write(ctx, &detectors.ResultWithMetadata{SourceName: "repo"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sink.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.batches) != 2 || len(client.batches[0]) != 10 || len(client.batches[1]) != 2 {
+		t.Fatalf("expected batches of 10 and 2 events, got %d batches", len(client.batches))
+	}
+	entry := client.batches[0][0]
+	if aws.StringValue(entry.EventBusName) != "security" || aws.StringValue(entry.Source) != "trufflehog" || aws.StringValue(entry.DetailType) != eventDetailType {
+		t.Errorf("unexpected event: %+v", entry)
+	}
+
+	if err := sink.Write(ctx, &detectors.ResultWithMetadata{SourceName: "rejected"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Flush(ctx); err == nil || !strings.Contains(err.Error(), "InternalFailure") {
+		t.Errorf("expected a rejected event to fail Flush, got %v", err)
+	}
+}
@@ -0,0 +1,211 @@
+package stream
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/allowlist"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/output"
+)
+
+const (
+	// maxBatchRecords and maxBatchBytes limit the records sent to a partition per Produce request.
+	maxBatchRecords = 500
+	maxBatchBytes   = 1 << 20
+	// maxAttempts is the number of times a batch is produced before giving up, refreshing the partitions' leaders
+	// between attempts.
+	maxAttempts = 5
+)
+
+// KafkaConfig configures a KafkaSink.
+type KafkaConfig struct {
+	// Brokers are the host:port addresses used to discover the cluster.
+	Brokers []string
+	Topic   string
+	Format  Format
+	// TLS connects to brokers with TLS, verified against the system roots.
+	TLS bool
+	// Username and Password authenticate with SASL, which should only be used with TLS.
+	Username string
+	Password string
+	// SASLMechanism is PLAIN, SCRAM-SHA-256, or SCRAM-SHA-512, defaulting to PLAIN.
+	SASLMechanism string
+	// Compression is none, gzip, snappy, lz4, or zstd, defaulting to none.
+	Compression string
+}
+
+// KafkaSink produces each result as a record to a Kafka topic. Records are keyed by the SHA-256 hash of the secret
+// and partitioned with murmur2, like the Java client, so every occurrence of a secret goes to the same partition, in
+// order. Records are buffered, and Flush returns once every record is acknowledged by all in-sync replicas.
+type KafkaSink struct {
+	config    KafkaConfig
+	transport *kafka.Transport
+	writer    *kafka.Writer
+	pending   []kafka.Message
+}
+
+func newKafkaSink(options map[string]string) (output.Sink, error) {
+	if err := output.CheckSinkOptions(options, "brokers", "topic", "format", "tls", "username", "password", "sasl-mechanism", "compression"); err != nil {
+		return nil, err
+	}
+	format, err := ParseFormat(options["format"])
+	if err != nil {
+		return nil, err
+	}
+	useTLS, err := output.BoolSinkOption(options, "tls")
+	if err != nil {
+		return nil, err
+	}
+	var brokers []string
+	for _, broker := range strings.Split(options["brokers"], ",") {
+		if broker = strings.TrimSpace(broker); broker != "" {
+			brokers = append(brokers, broker)
+		}
+	}
+	return NewKafkaSink(KafkaConfig{
+		Brokers:       brokers,
+		Topic:         options["topic"],
+		Format:        format,
+		TLS:           useTLS,
+		Username:      options["username"],
+		Password:      options["password"],
+		SASLMechanism: options["sasl-mechanism"],
+		Compression:   options["compression"],
+	})
+}
+
+// NewKafkaSink returns a KafkaSink. It connects to the cluster on Start.
+func NewKafkaSink(config KafkaConfig) (*KafkaSink, error) {
+	if len(config.Brokers) == 0 {
+		return nil, errors.New("the brokers option is required")
+	}
+	if config.Topic == "" {
+		return nil, errors.New("the topic option is required")
+	}
+	if (config.Username == "") != (config.Password == "") {
+		return nil, errors.New("username and password must be given together")
+	}
+	if config.Format == "" {
+		config.Format = FormatJSON
+	}
+
+	transport := &kafka.Transport{DialTimeout: dialTimeout, ClientID: clientName}
+	if config.TLS {
+		transport.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if config.Username != "" {
+		mechanism, err := saslMechanism(config.SASLMechanism, config.Username, config.Password)
+		if err != nil {
+			return nil, err
+		}
+		transport.SASL = mechanism
+	} else if config.SASLMechanism != "" {
+		return nil, errors.New("sasl-mechanism needs a username and password")
+	}
+	var compression kafka.Compression
+	if config.Compression != "" {
+		if err := compression.UnmarshalText([]byte(strings.ToLower(config.Compression))); err != nil {
+			return nil, fmt.Errorf("unknown compression %q, expected none, gzip, snappy, lz4, or zstd", config.Compression)
+		}
+	}
+
+	return &KafkaSink{
+		config:    config,
+		transport: transport,
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(config.Brokers...),
+			Topic:        config.Topic,
+			Balancer:     kafka.Murmur2Balancer{},
+			MaxAttempts:  maxAttempts,
+			BatchSize:    maxBatchRecords,
+			BatchBytes:   maxBatchBytes,
+			ReadTimeout:  ioTimeout,
+			WriteTimeout: ioTimeout,
+			RequiredAcks: kafka.RequireAll,
+			Compression:  compression,
+			Transport:    transport,
+		},
+	}, nil
+}
+
+// saslMechanism returns the SASL mechanism named name.
+func saslMechanism(name, username, password string) (sasl.Mechanism, error) {
+	switch strings.ToUpper(name) {
+	case "", "PLAIN":
+		return plain.Mechanism{Username: username, Password: password}, nil
+	case "SCRAM-SHA-256":
+		return scram.Mechanism(scram.SHA256, username, password)
+	case "SCRAM-SHA-512":
+		return scram.Mechanism(scram.SHA512, username, password)
+	default:
+		return nil, fmt.Errorf("unknown sasl-mechanism %q, expected PLAIN, SCRAM-SHA-256, or SCRAM-SHA-512", name)
+	}
+}
+
+// Start checks that the brokers can be reached and the topic exists, so a misconfigured sink fails before scanning.
+// The partitions' leaders are looked up again as they change while producing.
+func (s *KafkaSink) Start(ctx context.Context) error {
+	client := &kafka.Client{Addr: s.writer.Addr, Timeout: ioTimeout, Transport: s.transport}
+	metadata, err := client.Metadata(ctx, &kafka.MetadataRequest{Topics: []string{s.config.Topic}})
+	if err != nil {
+		return fmt.Errorf("could not load the metadata of topic %s: %w", s.config.Topic, err)
+	}
+	for _, topic := range metadata.Topics {
+		if topic.Name != s.config.Topic {
+			continue
+		}
+		if topic.Error != nil {
+			return fmt.Errorf("could not load the metadata of topic %s: %w", s.config.Topic, topic.Error)
+		}
+		if len(topic.Partitions) == 0 {
+			return fmt.Errorf("topic %s has no partitions", s.config.Topic)
+		}
+		return nil
+	}
+	return fmt.Errorf("topic %s doesn't exist", s.config.Topic)
+}
+
+// Write buffers a record with the result, and produces the buffered records once there's a batch of them.
+func (s *KafkaSink) Write(ctx context.Context, r *detectors.ResultWithMetadata) error {
+	value, err := s.config.Format.Marshal(r)
+	if err != nil {
+		return err
+	}
+	s.pending = append(s.pending, kafka.Message{
+		Key:     []byte(allowlist.Hash(r.Raw)),
+		Value:   value,
+		Headers: []kafka.Header{{Key: "content-type", Value: []byte(s.config.Format.ContentType())}},
+	})
+	if len(s.pending) >= maxBatchRecords {
+		return s.Flush(ctx)
+	}
+	return nil
+}
+
+// Flush produces the buffered records, retrying batches that fail with retriable errors such as a leader election.
+func (s *KafkaSink) Flush(ctx context.Context) error {
+	if len(s.pending) == 0 {
+		return nil
+	}
+	if err := s.writer.WriteMessages(ctx, s.pending...); err != nil {
+		return fmt.Errorf("could not produce to topic %s: %w", s.config.Topic, err)
+	}
+	s.pending = s.pending[:0]
+	return nil
+}
+
+// Close closes the connections to the brokers.
+func (s *KafkaSink) Close() error {
+	err := s.writer.Close()
+	s.transport.CloseIdleConnections()
+	return err
+}
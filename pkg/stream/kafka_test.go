@@ -0,0 +1,75 @@
+package stream
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func TestNewKafkaSink(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]string
+		wantErr bool
+	}{
+		{name: "defaults", options: map[string]string{"brokers": "kafka-1:9092, kafka-2:9092", "topic": "secrets"}},
+		{name: "scram", options: map[string]string{"brokers": "kafka:9093", "topic": "secrets", "tls": "true", "username": "trufflehog", "password": "hunter2", "sasl-mechanism": "SCRAM-SHA-512", "compression": "zstd"}},
+		{name: "no topic", options: map[string]string{"brokers": "kafka:9092"}, wantErr: true},
+		{name: "no brokers", options: map[string]string{"topic": "secrets"}, wantErr: true},
+		{name: "username without password", options: map[string]string{"brokers": "kafka:9092", "topic": "secrets", "username": "trufflehog"}, wantErr: true},
+		{name: "mechanism without credentials", options: map[string]string{"brokers": "kafka:9092", "topic": "secrets", "sasl-mechanism": "PLAIN"}, wantErr: true},
+		{name: "unknown mechanism", options: map[string]string{"brokers": "kafka:9092", "topic": "secrets", "username": "u", "password": "p", "sasl-mechanism": "GSSAPI"}, wantErr: true},
+		{name: "unknown compression", options: map[string]string{"brokers": "kafka:9092", "topic": "secrets", "compression": "brotli"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newKafkaSink(tt.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newKafkaSink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	sink, err := newKafkaSink(map[string]string{"brokers": "kafka:9092", "topic": "secrets", "compression": "zstd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer := sink.(*KafkaSink).writer
+	if _, ok := writer.Balancer.(kafka.Murmur2Balancer); !ok {
+		t.Errorf("expected records to be partitioned with murmur2, got %T", writer.Balancer)
+	}
+	if writer.RequiredAcks != kafka.RequireAll {
+		t.Errorf("expected records to be acknowledged by all in-sync replicas, got %v", writer.RequiredAcks)
+	}
+	if writer.Compression != kafka.Zstd {
+		t.Errorf("expected zstd compression, got %v", writer.Compression)
+	}
+}
+
+func TestKafkaSinkStartUnreachable(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			c.Close()
+		}
+	}()
+	defer l.Close()
+
+	sink, err := NewKafkaSink(KafkaConfig{Brokers: []string{l.Addr().String()}, Topic: "secrets"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+	if err := sink.Start(context.Background()); err == nil {
+		t.Error("expected a broker that closes connections to fail Start")
+	}
+}
@@ -0,0 +1,134 @@
+package stream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+// fakeNATS accepts connections with the token, pings the client before the first message, and sends the payloads
+// published to the subject on payloads.
+func fakeNATS(t *testing.T, c net.Conn, token, subject string, payloads chan<- string) {
+	defer c.Close()
+	r := bufio.NewReader(c)
+	fmt.Fprint(c, "INFO {\"server_id\":\"test\",\"max_payload\":1048576,\"auth_required\":true}\r\n")
+	pinged := false
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case strings.HasPrefix(line, "CONNECT "):
+			var options struct {
+				AuthToken string `json:"auth_token"`
+			}
+			if err := json.Unmarshal([]byte(line[len("CONNECT "):]), &options); err != nil || options.AuthToken != token {
+				fmt.Fprint(c, "-ERR 'Authorization Violation'\r\n")
+				return
+			}
+		case line == "PING":
+			fmt.Fprint(c, "PONG\r\n")
+		case line == "PONG":
+		case strings.HasPrefix(line, "PUB "):
+			fields := strings.Fields(line)
+			n, _ := strconv.Atoi(fields[len(fields)-1])
+			payload := make([]byte, n+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+			if !pinged {
+				fmt.Fprint(c, "PING\r\n")
+				pinged = true
+			}
+			if fields[1] != subject {
+				fmt.Fprintf(c, "-ERR 'Permissions Violation for Publish to %s'\r\n", fields[1])
+				continue
+			}
+			payloads <- string(payload[:n])
+		default:
+			t.Errorf("unexpected message %q", line)
+			return
+		}
+	}
+}
+
+func TestNATSSink(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	payloads := make(chan string, 10)
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go fakeNATS(t, c, "s3cr3t", "secrets.found", payloads)
+		}
+	}()
+	ctx := context.Background()
+
+	if _, err := NewNATSSink(NATSConfig{URL: "nats://" + l.Addr().String(), Subject: "secrets.>"}); err == nil {
+		t.Error("expected a wildcard subject to be rejected")
+	}
+	bad, err := NewNATSSink(NATSConfig{URL: "nats://wrong@" + l.Addr().String(), Subject: "secrets.found"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bad.Start(ctx); err == nil || !strings.Contains(strings.ToLower(err.Error()), "authorization violation") {
+		t.Errorf("expected the wrong token to be rejected, got %v", err)
+	}
+
+	sink, err := NewNATSSink(NATSConfig{URL: "nats://s3cr3t@" + l.Addr().String(), Subject: "secrets.found"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+	for _, name := range []string{"a", "b"} {
+		if err := sink.Write(ctx, &detectors.ResultWithMetadata{SourceName: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sink.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a", "b"} {
+		var r detectors.ResultWithMetadata
+		if err := json.Unmarshal([]byte(<-payloads), &r); err != nil {
+			t.Fatal(err)
+		}
+		if r.SourceName != name {
+			t.Errorf("expected results in order, got %s for %s", r.SourceName, name)
+		}
+	}
+
+	denied, err := NewNATSSink(NATSConfig{URL: "nats://s3cr3t@" + l.Addr().String(), Subject: "secrets.other"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := denied.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer denied.Close()
+	if err := denied.Write(ctx, &detectors.ResultWithMetadata{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := denied.Flush(ctx); err == nil || !strings.Contains(strings.ToLower(err.Error()), "permissions violation") {
+		t.Errorf("expected the server's error to be returned by Flush, got %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package stream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/output"
+)
+
+const (
+	// pubsubEndpoint is the Pub/Sub API.
+	pubsubEndpoint = "https://pubsub.googleapis.com"
+	// pubsubScope is the OAuth scope of the Pub/Sub API.
+	pubsubScope = "https://www.googleapis.com/auth/pubsub"
+	// maxPubSubMessages is the number of messages sent per publish request. The API accepts up to 1000, or 10MB.
+	maxPubSubMessages = 100
+)
+
+// pubsubTopic matches the full name of a Pub/Sub topic.
+var pubsubTopic = regexp.MustCompile(`^projects/[^/]+/topics/[^/]+$`)
+
+// PubSubConfig configures a PubSubSink.
+type PubSubConfig struct {
+	// Topic is the full name of the topic, projects/<project>/topics/<topic>.
+	Topic  string
+	Format Format
+	// CredentialsFile is a service account key. Empty uses the application default credentials.
+	CredentialsFile string
+	// Endpoint is the Pub/Sub API. Empty uses the public API, and it can be set to an emulator.
+	Endpoint string
+}
+
+// PubSubSink publishes each result as a message to a Google Cloud Pub/Sub topic, so that Cloud Functions and other
+// subscribers can act on findings. Messages carry the detector type, whether the secret was verified, and the
+// content type as attributes, so subscriptions can filter on them. Messages are published in batches, and Flush
+// returns once every message has been accepted.
+type PubSubSink struct {
+	config   PubSubConfig
+	client   *http.Client
+	messages []pubsubMessage
+}
+
+// pubsubMessage is a message in a publish request.
+type pubsubMessage struct {
+	// Data is base64 encoded by encoding/json, as the API expects.
+	Data       []byte            `json:"data"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+func newPubSubSink(options map[string]string) (output.Sink, error) {
+	if err := output.CheckSinkOptions(options, "topic", "format", "credentials", "endpoint"); err != nil {
+		return nil, err
+	}
+	format, err := ParseFormat(options["format"])
+	if err != nil {
+		return nil, err
+	}
+	return NewPubSubSink(PubSubConfig{
+		Topic:           options["topic"],
+		Format:          format,
+		CredentialsFile: options["credentials"],
+		Endpoint:        options["endpoint"],
+	}, nil)
+}
+
+// NewPubSubSink returns a PubSubSink that sends requests with client, which must authenticate them. If client is
+// nil, Start creates one from the configured credentials.
+func NewPubSubSink(config PubSubConfig, client *http.Client) (*PubSubSink, error) {
+	if config.Topic == "" {
+		return nil, errors.New("the topic option is required")
+	}
+	if !pubsubTopic.MatchString(config.Topic) {
+		return nil, fmt.Errorf("invalid topic %q, expected projects/<project>/topics/<topic>", config.Topic)
+	}
+	if config.Format == "" {
+		config.Format = FormatJSON
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = pubsubEndpoint
+	}
+	return &PubSubSink{config: config, client: client}, nil
+}
+
+// Start loads the credentials, unless the sink was given a client.
+func (s *PubSubSink) Start(ctx context.Context) error {
+	if s.client != nil {
+		return nil
+	}
+	var creds *google.Credentials
+	var err error
+	if s.config.CredentialsFile != "" {
+		var data []byte
+		data, err = os.ReadFile(s.config.CredentialsFile)
+		if err != nil {
+			return err
+		}
+		creds, err = google.CredentialsFromJSON(ctx, data, pubsubScope)
+	} else {
+		creds, err = google.FindDefaultCredentials(ctx, pubsubScope)
+	}
+	if err != nil {
+		return fmt.Errorf("could not load Google credentials: %w", err)
+	}
+	// The token source outlives Start, so it mustn't use its context.
+	s.client = oauth2.NewClient(context.Background(), creds.TokenSource)
+	return nil
+}
+
+// Write adds a message with the result to the batch, and publishes the batch once it's full.
+func (s *PubSubSink) Write(ctx context.Context, r *detectors.ResultWithMetadata) error {
+	data, err := s.config.Format.Marshal(r)
+	if err != nil {
+		return err
+	}
+	s.messages = append(s.messages, pubsubMessage{
+		Data: data,
+		Attributes: map[string]string{
+			"detector_type": r.DetectorType.String(),
+			"verified":      strconv.FormatBool(r.Verified),
+			"content_type":  s.config.Format.ContentType(),
+		},
+	})
+	if len(s.messages) == maxPubSubMessages {
+		return s.Flush(ctx)
+	}
+	return nil
+}
+
+// Flush publishes the batch.
+func (s *PubSubSink) Flush(ctx context.Context) error {
+	if len(s.messages) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(map[string]interface{}{"messages": s.messages})
+	if err != nil {
+		return err
+	}
+	url := strings.TrimSuffix(s.config.Endpoint, "/") + "/v1/" + s.config.Topic + ":publish"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not publish to %s: %w", s.config.Topic, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("could not publish to %s: unexpected status %d: %s", s.config.Topic, res.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	var published struct {
+		MessageIDs []string `json:"messageIds"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&published); err != nil {
+		return fmt.Errorf("could not decode publish response: %w", err)
+	}
+	if len(published.MessageIDs) != len(s.messages) {
+		return fmt.Errorf("published %d of %d messages to %s", len(published.MessageIDs), len(s.messages), s.config.Topic)
+	}
+	s.messages = nil
+	return nil
+}
+
+func (s *PubSubSink) Close() error { return nil }
@@ -0,0 +1,81 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+func TestPubSubSink(t *testing.T) {
+	var published []pubsubMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/security/topics/secrets:publish" {
+			http.NotFound(w, r)
+			return
+		}
+		var req struct {
+			Messages []pubsubMessage `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		published = append(published, req.Messages...)
+		ids := make([]string, len(req.Messages))
+		for i := range ids {
+			ids[i] = fmt.Sprint(len(published) - len(req.Messages) + i)
+		}
+		json.NewEncoder(w).Encode(map[string][]string{"messageIds": ids})
+	}))
+	defer srv.Close()
+
+	if _, err := NewPubSubSink(PubSubConfig{Topic: "secrets"}, srv.Client()); err == nil {
+		t.Error("expected a topic without a project to be rejected")
+	}
+	sink, err := NewPubSubSink(PubSubConfig{Topic: "projects/security/topics/secrets", Endpoint: srv.URL}, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := sink.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < maxPubSubMessages+1; i++ {
+		r := &detectors.ResultWithMetadata{SourceName: fmt.Sprint(i), Result: detectors.Result{Verified: i == 0}}
+		if err := sink.Write(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(published) != maxPubSubMessages {
+		t.Errorf("expected a full batch to be published, got %d messages", len(published))
+	}
+	if err := sink.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(published) != maxPubSubMessages+1 {
+		t.Fatalf("expected every message to be published, got %d", len(published))
+	}
+	var r detectors.ResultWithMetadata
+	if err := json.Unmarshal(published[0].Data, &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.SourceName != "0" || published[0].Attributes["verified"] != "true" || published[0].Attributes["content_type"] != "application/json" {
+		t.Errorf("unexpected message: %s %v", published[0].Data, published[0].Attributes)
+	}
+
+	missing, err := NewPubSubSink(PubSubConfig{Topic: "projects/security/topics/missing", Endpoint: srv.URL}, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := missing.Write(ctx, &detectors.ResultWithMetadata{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := missing.Flush(ctx); err == nil {
+		t.Error("expected publishing to a missing topic to fail")
+	}
+}
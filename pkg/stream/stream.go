@@ -0,0 +1,71 @@
+// Package stream publishes results to Kafka topics, NATS subjects, Amazon EventBridge buses, and Google Cloud Pub/Sub
+// topics, for security data pipelines and automation that consume streams instead of files. Importing it registers
+// the "kafka", "nats", "eventbridge", and "pubsub" output sinks.
+//
+// Each result is one message, serialized as the JSON of --json output or as a protobuf Finding message, defined in
+// proto/results.proto. Messages hold the secret, like --json output, so topics and subjects should be access
+// controlled accordingly.
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/output"
+)
+
+const (
+	// dialTimeout limits connecting to a broker or server.
+	dialTimeout = 10 * time.Second
+	// ioTimeout limits each request and the wait for its response.
+	ioTimeout = 30 * time.Second
+	// clientName identifies trufflehog to brokers and servers.
+	clientName = "trufflehog"
+)
+
+func init() {
+	output.RegisterSink("kafka", newKafkaSink)
+	output.RegisterSink("nats", newNATSSink)
+	output.RegisterSink("eventbridge", newEventBridgeSink)
+	output.RegisterSink("pubsub", newPubSubSink)
+}
+
+// Format is how results are serialized into messages.
+type Format string
+
+const (
+	// FormatJSON serializes results like --json output.
+	FormatJSON Format = "json"
+	// FormatProto serializes results as Finding messages, without a length prefix.
+	FormatProto Format = "proto"
+)
+
+// ParseFormat returns the format named s, defaulting to JSON.
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case "", FormatJSON:
+		return FormatJSON, nil
+	case FormatProto:
+		return FormatProto, nil
+	default:
+		return "", fmt.Errorf("unknown format %q, expected json or proto", s)
+	}
+}
+
+// Marshal serializes a result.
+func (f Format) Marshal(r *detectors.ResultWithMetadata) ([]byte, error) {
+	if f == FormatProto {
+		return output.MarshalFinding(r)
+	}
+	return json.Marshal(r)
+}
+
+// ContentType returns the MIME type of messages in the format.
+func (f Format) ContentType() string {
+	if f == FormatProto {
+		return "application/x-protobuf"
+	}
+	return "application/json"
+}
@@ -0,0 +1,146 @@
+// Package telemetry sends anonymized statistics of a scan to an endpoint the user configures, so platform teams can
+// measure detector effectiveness across the CI pipelines they operate. Nothing is sent unless an endpoint is given.
+//
+// A Report only holds counts and durations. It never includes secrets, results, file paths, repository or host names,
+// or the scan ID.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine"
+)
+
+// Timeout bounds sending a report, so an unreachable endpoint doesn't hold up the end of a pipeline.
+const Timeout = 10 * time.Second
+
+// Report is the anonymized statistics of one scan.
+type Report struct {
+	Version string `json:"version"`
+	OS      string `json:"os"`
+	Arch    string `json:"arch"`
+	// Command is the scan command, such as "git" or "filesystem".
+	Command string `json:"command"`
+	// CI is the CI system the scan ran in, such as "github-actions", if it's known.
+	CI             string `json:"ci,omitempty"`
+	Cancelled      bool   `json:"cancelled,omitempty"`
+	DurationMillis int64  `json:"duration_ms"`
+	ChunksScanned  uint64 `json:"chunks_scanned"`
+	BytesScanned   uint64 `json:"bytes_scanned"`
+	SkippedFiles   int    `json:"skipped_files"`
+	// Results is the number of results output.
+	Results uint64 `json:"results"`
+	// Detectors is keyed by detector name and only includes detectors that ran.
+	Detectors  map[string]DetectorStats `json:"detectors"`
+	Suppressed map[string]uint64        `json:"suppressed,omitempty"`
+}
+
+// DetectorStats counts the runs and results of one detector.
+type DetectorStats struct {
+	Calls          uint64 `json:"calls"`
+	Results        uint64 `json:"results"`
+	Verified       uint64 `json:"verified"`
+	DurationMillis int64  `json:"duration_ms"`
+}
+
+// NewReport returns the report of a scan from its summary.
+func NewReport(summary *engine.Summary, version, command string, results uint64) *Report {
+	report := &Report{
+		Version:        version,
+		OS:             runtime.GOOS,
+		Arch:           runtime.GOARCH,
+		Command:        command,
+		Cancelled:      summary.Cancelled,
+		DurationMillis: summary.Duration.Milliseconds(),
+		ChunksScanned:  summary.ChunksScanned,
+		BytesScanned:   summary.BytesScanned,
+		SkippedFiles:   len(summary.SkippedFiles),
+		Results:        results,
+		Detectors:      make(map[string]DetectorStats, len(summary.Detectors)),
+		Suppressed:     summary.Suppressed,
+	}
+	for name, stats := range summary.Detectors {
+		report.Detectors[name] = DetectorStats{
+			Calls:          stats.Calls,
+			Results:        stats.Results,
+			Verified:       stats.Verified,
+			DurationMillis: stats.Duration.Milliseconds(),
+		}
+	}
+	return report
+}
+
+// ciSystems are the environment variables that CI systems set, and the name each is reported as.
+var ciSystems = []struct{ env, name string }{
+	{"GITHUB_ACTIONS", "github-actions"},
+	{"GITLAB_CI", "gitlab-ci"},
+	{"CIRCLECI", "circleci"},
+	{"JENKINS_URL", "jenkins"},
+	{"BUILDKITE", "buildkite"},
+	{"TF_BUILD", "azure-pipelines"},
+	{"BITBUCKET_BUILD_NUMBER", "bitbucket-pipelines"},
+	{"TRAVIS", "travis"},
+	{"CI", "other"},
+}
+
+// CISystem returns the name of the CI system getenv's environment is in, or an empty string.
+func CISystem(getenv func(string) string) string {
+	for _, ci := range ciSystems {
+		if getenv(ci.env) != "" {
+			return ci.name
+		}
+	}
+	return ""
+}
+
+// Client sends reports to an endpoint.
+type Client struct {
+	// Endpoint is the URL reports are POSTed to as JSON.
+	Endpoint string
+	// Token, if set, is sent as a bearer token.
+	Token string
+
+	client *http.Client
+}
+
+// Send posts the report.
+func (c *Client) Send(ctx context.Context, report *Report) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	client := c.client
+	if client == nil {
+		client = common.SaneHttpClient()
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("telemetry endpoint returned status %d: %s", res.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+func TestSend(t *testing.T) {
+	summary := &engine.Summary{
+		ScanID:        "scan-1234",
+		Duration:      1500 * time.Millisecond,
+		ChunksScanned: 10,
+		BytesScanned:  1024,
+		Detectors: map[string]engine.DetectorStats{
+			"aws": {Calls: 4, Duration: 20 * time.Millisecond, Results: 2, Verified: 1},
+		},
+		SkippedFiles: []sources.SkippedFile{{Name: "repo/secret-dump.bin", Reason: sources.SkipReasonIgnoredType}},
+		Suppressed:   map[string]uint64{engine.SuppressedAllowlist: 3},
+	}
+	report := NewReport(summary, "3.0.0", "git", 2)
+
+	var body []byte
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		auth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	c := &Client{Endpoint: server.URL, Token: "telemetry-token", client: server.Client()}
+	if err := c.Send(context.Background(), report); err != nil {
+		t.Fatal(err)
+	}
+	if auth != "Bearer telemetry-token" {
+		t.Errorf("unexpected authorization %q", auth)
+	}
+	if strings.Contains(string(body), "scan-1234") || strings.Contains(string(body), "secret-dump") {
+		t.Errorf("expected the report not to include identifiers, got %s", body)
+	}
+	var got Report
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Command != "git" || got.DurationMillis != 1500 || got.SkippedFiles != 1 || got.Results != 2 ||
+		got.Detectors["aws"] != (DetectorStats{Calls: 4, Results: 2, Verified: 1, DurationMillis: 20}) ||
+		got.Suppressed[engine.SuppressedAllowlist] != 3 {
+		t.Errorf("unexpected report: %s", body)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusForbidden)
+	}))
+	defer failing.Close()
+	c = &Client{Endpoint: failing.URL, client: failing.Client()}
+	if err := c.Send(context.Background(), report); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("expected the status to be reported, got %v", err)
+	}
+}
+
+func TestCISystem(t *testing.T) {
+	env := map[string]string{"CI": "true", "GITLAB_CI": "true"}
+	if ci := CISystem(func(k string) string { return env[k] }); ci != "gitlab-ci" {
+		t.Errorf("expected gitlab-ci, got %q", ci)
+	}
+	if ci := CISystem(func(string) string { return "" }); ci != "" {
+		t.Errorf("expected no CI system, got %q", ci)
+	}
+}
@@ -0,0 +1,489 @@
+// Package tui implements an interactive terminal browser for triaging findings from a previous scan.
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/go-errors/errors"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/allowlist"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/findings"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/output"
+)
+
+const help = `Keys:
+  up, k / down, j     move between findings, or scroll the diff preview
+  pgup / pgdown       move a page
+  home, g / end, G    go to the first or last finding
+  enter               show the finding with a diff preview, enter or esc goes back
+  n / p               next or previous finding in the preview
+  /                   only show findings from a detector, an empty detector clears the filter
+  v                   toggle only showing verified findings
+  f                   mark the finding as a false positive in the baseline file
+  ?                   show this help
+  q, ctrl+c           quit
+
+Press any key to go back.`
+
+// mode is what the browser is showing, which decides how keys are handled.
+type mode int
+
+const (
+	modeList mode = iota
+	modeDetail
+	modeFilter
+	modeHelp
+)
+
+// Browser lets a user page through findings, filter them, and mark false positives. It's a bubbletea model, so keys
+// are read one at a time with the terminal in raw mode.
+type Browser struct {
+	findings []findings.Finding
+	visible  []int
+	cursor   int
+	// offset is the index in visible of the first row of the list, which scrolls to keep the cursor on screen.
+	offset int
+
+	detectorFilter string
+	verifiedOnly   bool
+	ignored        *allowlist.Allowlist
+
+	mode   mode
+	input  []rune
+	detail []string
+	// detailOffset is the first line of the detail shown, which scrolls through long diffs.
+	detailOffset int
+	status       string
+	width        int
+	height       int
+	err          error
+
+	in           io.Reader
+	out          io.Writer
+	baselinePath string
+	repo         *gogit.Repository
+}
+
+// Option configures a Browser.
+type Option func(*Browser) error
+
+// WithBaseline sets the file false positives are appended to. Findings already in the file are hidden.
+func WithBaseline(path string) Option {
+	return func(b *Browser) error {
+		b.baselinePath = path
+		ignored, err := allowlist.Load(path)
+		if err != nil {
+			return err
+		}
+		b.ignored = ignored
+		return nil
+	}
+}
+
+// WithRepo sets the local clone used to show diffs of findings from git based sources.
+func WithRepo(path string) Option {
+	return func(b *Browser) error {
+		repo, err := gogit.PlainOpenWithOptions(path, &gogit.PlainOpenOptions{DetectDotGit: true})
+		if err != nil {
+			return errors.WrapPrefix(err, fmt.Sprintf("could not open repo %s", path), 0)
+		}
+		b.repo = repo
+		return nil
+	}
+}
+
+// NewBrowser returns a Browser reading keys from in and drawing to out. When in is a terminal, it's put in raw mode
+// while the browser runs.
+func NewBrowser(results []findings.Finding, in io.Reader, out io.Writer, opts ...Option) (*Browser, error) {
+	b := &Browser{
+		findings: results,
+		ignored:  allowlist.New(),
+		in:       in,
+		out:      out,
+		width:    80,
+		height:   24,
+	}
+	for _, opt := range opts {
+		if err := opt(b); err != nil {
+			return nil, err
+		}
+	}
+	b.applyFilters()
+	b.status = fmt.Sprintf("%d findings, %d shown. Press ? for help.", len(b.findings), len(b.visible))
+	return b, nil
+}
+
+// Run shows the browser on the alternate screen until the user quits.
+func (b *Browser) Run() error {
+	program := tea.NewProgram(b, tea.WithInput(b.in), tea.WithOutput(b.out), tea.WithAltScreen())
+	if err := program.Start(); err != nil {
+		return err
+	}
+	return b.err
+}
+
+func (b *Browser) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles a key press or a resize of the terminal.
+func (b *Browser) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		b.width, b.height = msg.Width, msg.Height
+		b.scrollList()
+		return b, nil
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return b, tea.Quit
+		}
+		switch b.mode {
+		case modeHelp:
+			b.mode = modeList
+			return b, nil
+		case modeFilter:
+			return b, b.updateFilter(msg)
+		case modeDetail:
+			return b, b.updateDetail(msg)
+		default:
+			return b, b.updateList(msg)
+		}
+	}
+	return b, nil
+}
+
+func (b *Browser) updateList(msg tea.KeyMsg) tea.Cmd {
+	b.status = ""
+	switch msg.String() {
+	case "q":
+		return tea.Quit
+	case "?":
+		b.mode = modeHelp
+	case "up", "k":
+		b.move(-1)
+	case "down", "j":
+		b.move(1)
+	case "pgup":
+		b.move(-b.listHeight())
+	case "pgdown":
+		b.move(b.listHeight())
+	case "home", "g":
+		b.move(-len(b.visible))
+	case "end", "G":
+		b.move(len(b.visible))
+	case "enter":
+		if b.current() == nil {
+			b.status = "no findings match the current filters"
+			return nil
+		}
+		b.mode = modeDetail
+		b.showDetail()
+	case "/":
+		b.mode = modeFilter
+		b.input = []rune(b.detectorFilter)
+	case "v":
+		b.verifiedOnly = !b.verifiedOnly
+		b.applyFilters()
+	case "f":
+		return b.markFalsePositive()
+	default:
+		b.status = fmt.Sprintf("unknown key %q, press ? for help", msg.String())
+	}
+	return nil
+}
+
+func (b *Browser) updateDetail(msg tea.KeyMsg) tea.Cmd {
+	b.status = ""
+	switch msg.String() {
+	case "q":
+		return tea.Quit
+	case "enter", "esc", "backspace":
+		b.mode = modeList
+	case "up", "k":
+		b.scrollDetail(-1)
+	case "down", "j":
+		b.scrollDetail(1)
+	case "pgup":
+		b.scrollDetail(-b.detailHeight())
+	case "pgdown":
+		b.scrollDetail(b.detailHeight())
+	case "n":
+		if b.move(1) {
+			b.showDetail()
+		}
+	case "p":
+		if b.move(-1) {
+			b.showDetail()
+		}
+	case "f":
+		cmd := b.markFalsePositive()
+		if b.current() == nil {
+			b.mode = modeList
+		} else {
+			b.showDetail()
+		}
+		return cmd
+	}
+	return nil
+}
+
+func (b *Browser) updateFilter(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEnter:
+		b.detectorFilter = strings.TrimSpace(string(b.input))
+		b.mode = modeList
+		b.applyFilters()
+		if len(b.visible) == 0 {
+			b.status = "no findings match the current filters"
+		}
+	case tea.KeyEsc:
+		b.mode = modeList
+	case tea.KeyBackspace:
+		if len(b.input) > 0 {
+			b.input = b.input[:len(b.input)-1]
+		}
+	case tea.KeyRunes:
+		b.input = append(b.input, msg.Runes...)
+	}
+	return nil
+}
+
+func (b *Browser) applyFilters() {
+	b.visible = b.visible[:0]
+	for i, f := range b.findings {
+		if b.verifiedOnly && !f.Verified {
+			continue
+		}
+		if b.detectorFilter != "" && !strings.EqualFold(f.DetectorType, b.detectorFilter) {
+			continue
+		}
+		if b.ignored.ContainsHash(f.Hash()) {
+			continue
+		}
+		b.visible = append(b.visible, i)
+	}
+	if b.cursor >= len(b.visible) {
+		b.cursor = len(b.visible) - 1
+	}
+	if b.cursor < 0 {
+		b.cursor = 0
+	}
+	b.scrollList()
+}
+
+func (b *Browser) current() *findings.Finding {
+	if len(b.visible) == 0 {
+		return nil
+	}
+	return &b.findings[b.visible[b.cursor]]
+}
+
+// move moves the cursor by delta, stopping at the first and last findings, and returns false if it didn't move.
+func (b *Browser) move(delta int) bool {
+	next := b.cursor + delta
+	if next < 0 {
+		next = 0
+	}
+	if next > len(b.visible)-1 {
+		next = len(b.visible) - 1
+	}
+	if next < 0 || next == b.cursor {
+		b.status = "no more findings"
+		return false
+	}
+	b.cursor = next
+	b.scrollList()
+	return true
+}
+
+// listHeight is the number of findings shown at once, below the header and above the status and key lines.
+func (b *Browser) listHeight() int {
+	if b.height <= 4 {
+		return 1
+	}
+	return b.height - 3
+}
+
+// detailHeight is the number of lines of the detail shown at once, above the status and key lines.
+func (b *Browser) detailHeight() int {
+	if b.height <= 3 {
+		return 1
+	}
+	return b.height - 2
+}
+
+func (b *Browser) scrollList() {
+	height := b.listHeight()
+	if b.cursor < b.offset {
+		b.offset = b.cursor
+	}
+	if b.cursor >= b.offset+height {
+		b.offset = b.cursor - height + 1
+	}
+}
+
+func (b *Browser) scrollDetail(delta int) {
+	b.detailOffset += delta
+	if last := len(b.detail) - b.detailHeight(); b.detailOffset > last {
+		b.detailOffset = last
+	}
+	if b.detailOffset < 0 {
+		b.detailOffset = 0
+	}
+}
+
+// showDetail renders the current finding with its diff preview.
+func (b *Browser) showDetail() {
+	f := b.current()
+	var lines []string
+	lines = append(lines, fmt.Sprintf("[%d/%d] %s (%s)", b.cursor+1, len(b.visible), f.DetectorType, verifiedLabel(f.Verified)))
+	lines = append(lines, "Raw result: "+f.Raw)
+	if f.SourceKind != "" {
+		lines = append(lines, "Source: "+f.SourceKind)
+	}
+	for _, k := range f.MetadataKeys() {
+		lines = append(lines, fmt.Sprintf("%s: %v", strings.Title(k), f.Metadata[k]))
+	}
+	lines = append(lines, "")
+	lines = append(lines, strings.Split(strings.TrimRight(b.diff(f), "\n"), "\n")...)
+	b.detail = lines
+	b.detailOffset = 0
+}
+
+// View draws the screen for the current mode.
+func (b *Browser) View() string {
+	var lines []string
+	keys := "enter preview  / detector  v verified  f false positive  ? help  q quit"
+	switch b.mode {
+	case modeHelp:
+		return help
+	case modeDetail:
+		end := b.detailOffset + b.detailHeight()
+		if end > len(b.detail) {
+			end = len(b.detail)
+		}
+		lines = append(lines, b.detail[b.detailOffset:end]...)
+		for len(lines) < b.detailHeight() {
+			lines = append(lines, "")
+		}
+		keys = "up/down scroll  n/p next/previous  f false positive  esc back  q quit"
+	default:
+		lines = append(lines, b.header())
+		for i := b.offset; i < len(b.visible) && i < b.offset+b.listHeight(); i++ {
+			lines = append(lines, b.row(i))
+		}
+		for len(lines) < b.listHeight()+1 {
+			lines = append(lines, "")
+		}
+		if b.mode == modeFilter {
+			keys = "Detector: " + string(b.input) + "█"
+		}
+	}
+	lines = append(lines, b.status, keys)
+	for i, line := range lines {
+		lines[i] = truncate(line, b.width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (b *Browser) header() string {
+	header := fmt.Sprintf("%d findings, %d shown", len(b.findings), len(b.visible))
+	if b.detectorFilter != "" {
+		header += ", detector " + b.detectorFilter
+	}
+	if b.verifiedOnly {
+		header += ", verified only"
+	}
+	return header
+}
+
+// row formats the finding at index i of visible, highlighting it if it's under the cursor.
+func (b *Browser) row(i int) string {
+	f := b.findings[b.visible[i]]
+	row := fmt.Sprintf("%4d  %-24s %-10s %s", i+1, f.DetectorType, verifiedLabel(f.Verified), f.Location())
+	if i == b.cursor {
+		return "\u001b[7m" + truncate(row, b.width) + "\u001b[0m"
+	}
+	return row
+}
+
+// truncate cuts lines to the width of the terminal, so they don't wrap and scroll the screen.
+func truncate(line string, width int) string {
+	if width <= 0 || strings.Contains(line, "\u001b") {
+		return line
+	}
+	runes := []rune(line)
+	if len(runes) <= width {
+		return line
+	}
+	return string(runes[:width])
+}
+
+// diff returns the diff of the commit the finding was introduced in, if it came from a git based source.
+func (b *Browser) diff(f *findings.Finding) string {
+	commitHash, _ := f.Metadata["commit"].(string)
+	file, _ := f.Metadata["file"].(string)
+	if commitHash == "" || file == "" {
+		return "no diff available for this finding"
+	}
+	if b.repo == nil {
+		return "no diff available, run with --repo pointing at a local clone to see diffs"
+	}
+	commit, err := b.repo.CommitObject(plumbing.NewHash(commitHash))
+	if err != nil {
+		return fmt.Sprintf("could not find commit %s: %s", commitHash, err)
+	}
+	diff := output.GenerateDiff(commit, file)
+	return strings.ReplaceAll(diff, f.Raw, fmt.Sprintf("\u001b[93m%s\u001b[0m", f.Raw))
+}
+
+// markFalsePositive appends the current finding to the baseline file and hides it. Failing to write the baseline
+// quits the browser with the error.
+func (b *Browser) markFalsePositive() tea.Cmd {
+	f := b.current()
+	if f == nil {
+		b.status = "no finding selected"
+		return nil
+	}
+	if b.baselinePath == "" {
+		b.status = "no baseline file configured"
+		return nil
+	}
+	if err := appendBaseline(b.baselinePath, f); err != nil {
+		b.err = err
+		return tea.Quit
+	}
+	b.ignored.Add(f.Hash())
+	b.applyFilters()
+	b.status = fmt.Sprintf("marked as a false positive in %s", b.baselinePath)
+	return nil
+}
+
+func verifiedLabel(verified bool) string {
+	if verified {
+		return "verified"
+	}
+	return "unverified"
+}
+
+// appendBaseline adds the finding's hash to the baseline file, with a comment describing it that doesn't include
+// the secret.
+func appendBaseline(path string, f *findings.Finding) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.WrapPrefix(err, "could not open baseline file", 0)
+	}
+	defer file.Close()
+	comment := []string{f.DetectorType}
+	if location := f.Location(); location != "" {
+		comment = append(comment, location)
+	}
+	_, err = fmt.Fprintf(file, "%s # %s\n", f.Hash(), strings.Join(comment, " "))
+	return err
+}